@@ -3,22 +3,84 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/database"
 	"github.com/yourusername/football-prediction/internal/handlers"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/jobs"
+	"github.com/yourusername/football-prediction/internal/lifecycle"
+	"github.com/yourusername/football-prediction/internal/news"
+	"github.com/yourusername/football-prediction/internal/repository"
 	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/internal/tracing"
+	"github.com/yourusername/football-prediction/migrations"
+	"github.com/yourusername/football-prediction/pkg/blob"
+	"github.com/yourusername/football-prediction/pkg/breaker"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+	"github.com/yourusername/football-prediction/pkg/events"
+	"github.com/yourusername/football-prediction/pkg/mail"
+	"github.com/yourusername/football-prediction/pkg/mlclient"
+	"github.com/yourusername/football-prediction/pkg/quota"
+	"github.com/yourusername/football-prediction/pkg/tts"
 )
 
+// services holds the long-lived services shared by the router and the
+// background scheduler.
+type services struct {
+	football      *service.FootballService
+	predictions   *service.PredictionService
+	recaps        *service.RecapService
+	ask           *service.AskService
+	events        *service.EventBus
+	webhooks      *service.WebhookService
+	users         *service.UserService
+	feeds         *service.FeedService
+	fantasy       *service.FantasyService
+	leagues       *service.LeagueService
+	exports       *service.ExportService
+	failures      *service.IngestFailureService
+	dataQuality   *service.DataQualityService
+	teams         *service.TeamService
+	competitions  *service.CompetitionService
+	search        *service.SearchService
+	mappings      *service.EntityMappingService
+	crests        *service.CrestService
+	standings     *service.StandingsService
+	projections   *service.ProjectionService
+	anomalies     *service.AnomalyService
+	streaks       *service.StreakService
+	homeAdvantage *service.HomeAdvantageService
+	travel        *service.TravelService
+	previews      *service.PreviewService
+	audio         *service.InsightAudioService
+	draws         *service.DrawService
+	ties          *service.TieService
+	news          *service.NewsService
+	similarity    *service.SimilarityService
+	usage         *service.UsageService
+	retention     *service.RetentionService
+	llmUsage      *service.LLMUsageService
+	reviews       *service.InsightReviewService
+	blobs         blob.Store
+	digests       *service.DigestService
+	audit         *service.AuditService
+	quotas        *quota.Manager
+	breakers      *breaker.Set
+}
+
 func main() {
 	// Load environment variables from project root
 	if err := godotenv.Load("../.env"); err != nil {
@@ -28,6 +90,12 @@ func main() {
 	// Setup logger
 	setupLogger()
 
+	// Export traces when an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Setup(context.Background(), "football-prediction-api")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+
 	// Connect to database
 	db, err := connectDB()
 	if err != nil {
@@ -35,17 +103,463 @@ func main() {
 	}
 	defer db.Close()
 
+	// Apply pending migrations when opted in
+	if os.Getenv("AUTO_MIGRATE") == "true" {
+		version, err := migrations.Up(os.Getenv("DATABASE_URL"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to run migrations")
+		}
+		log.Info().Uint("version", version).Msg("Database migrations applied")
+	}
+
+	// Select the provider data comes from
+	source, err := datasource.Open(os.Getenv("PROVIDER"), os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid PROVIDER")
+	}
+	if source.Mock() {
+		log.Info().Str("fixtures", source.FixturesDir()).Msg("Serving provider data from recorded fixtures")
+	}
+
 	// Get Football API key
 	apiKey := os.Getenv("FOOTBALL_API_KEY")
-	if apiKey == "" {
+	if apiKey == "" && !source.Mock() {
 		log.Warn().Msg("FOOTBALL_API_KEY not set - API calls will fail")
 	}
 
+	// Everything below runs until SIGINT or SIGTERM
+	lc := lifecycle.New(context.Background())
+
+	// Connect to the event broker
+	broker, err := events.Open(os.Getenv("EVENT_BROKER_URL"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to event broker")
+	}
+	// Handle the events already received before flushing what they queue
+	lc.OnShutdown("event broker", func(context.Context) error {
+		return broker.Close()
+	})
+
+	// Initialize services
+	svc := newServices(db, source, apiKey, broker)
+	if err := handlers.RegisterValidations(svc.competitions); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register parameter validations")
+	}
+
+	// Start background jobs
+	scheduler := startScheduler(lc, db, svc)
+
+	// Keep the usage recorded since the last flush
+	lc.OnShutdown("usage flush", func(context.Context) error {
+		_, err := svc.usage.Flush()
+		return err
+	})
+
+	// Export the spans of everything above last
+	lc.OnShutdown("tracing", shutdownTracing)
+
 	// Setup Gin router
-	router := setupRouter(db, apiKey)
+	router := setupRouter(lc, db, svc, scheduler)
 
 	// Start server
-	startServer(router)
+	startServer(lc, router)
+
+	<-lc.Done()
+	log.Info().Msg("Shutting down: finishing requests and running jobs...")
+
+	if err := lc.Shutdown(durationFromEnv("SHUTDOWN_TIMEOUT", 30*time.Second)); err != nil {
+		log.Error().Err(err).Msg("Shutdown did not complete cleanly")
+		return
+	}
+	log.Info().Msg("Shut down cleanly")
+}
+
+func newServices(db *sql.DB, source *datasource.Source, apiKey string, broker events.Broker) *services {
+	// One breaker per upstream, so a failing one fails fast instead of
+	// tying up requests until they time out
+	breakers := breaker.NewSet(breaker.Config{
+		Failures: intFromEnv("BREAKER_FAILURES", breaker.DefaultFailures),
+		Cooldown: durationFromEnv("BREAKER_COOLDOWN", breaker.DefaultCooldown),
+	})
+
+	llmClient, err := service.NewLLMClient(db, service.LLMSettings{
+		OpenAIURL:    os.Getenv("LLM_BASE_URL"),
+		OpenAIKey:    os.Getenv("LLM_API_KEY"),
+		OpenAIModel:  os.Getenv("LLM_MODEL"),
+		AnthropicURL: os.Getenv("ANTHROPIC_BASE_URL"),
+		AnthropicKey: os.Getenv("ANTHROPIC_API_KEY"),
+		OllamaURL:    os.Getenv("OLLAMA_URL"),
+		Routes:       os.Getenv("LLM_ROUTES"),
+		Prices:       os.Getenv("LLM_PRICES"),
+		Breakers:     breakers,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid LLM routes or prices")
+	}
+	if !llmClient.Enabled() {
+		log.Warn().Msg("No LLM provider configured - generated content will use templates")
+	}
+
+	// Budgets shared with the ingest commands using the same keys
+	quotaLimits, err := service.ParseProviderQuotas(os.Getenv("PROVIDER_QUOTAS"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid provider quotas")
+	}
+	quotas := service.NewProviderQuotas(db, quotaLimits)
+
+	cacheTTLs, err := service.ParseCacheTTLs(os.Getenv("CACHE_TTLS"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid cache TTLs")
+	}
+	footballService := service.NewFootballService(source, apiKey, db, quotas, breakers, cacheTTLs)
+
+	var bannedTerms []string
+	if v := os.Getenv("MODERATION_BANNED_TERMS"); v != "" {
+		bannedTerms = strings.Split(v, ",")
+	}
+	reviews := service.NewInsightReviewService(db, bannedTerms)
+
+	speech, err := tts.New(os.Getenv("TTS_PROVIDER"), os.Getenv("TTS_BASE_URL"), os.Getenv("TTS_API_KEY"),
+		os.Getenv("TTS_MODEL"), os.Getenv("TTS_VOICE"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid TTS provider - audio previews are disabled")
+	}
+
+	var shadows []string
+	if v := os.Getenv("MODEL_SHADOW_BACKENDS"); v != "" {
+		shadows = strings.Split(v, ",")
+	}
+	mlClient := mlclient.New(mlclient.Config{
+		URL:        os.Getenv("ML_SERVICE_URL"),
+		Workers:    intFromEnv("ML_WORKERS", mlclient.DefaultWorkers),
+		QueueDepth: intFromEnv("ML_QUEUE_DEPTH", mlclient.DefaultQueueDepth),
+		Timeout:    durationFromEnv("ML_TIMEOUT", mlclient.DefaultTimeout),
+		Transport:  tracing.Transport(breaker.Transport(breakers.Get("ml"), nil)),
+	})
+	predictionService := service.NewPredictionService(db, os.Getenv("MODEL_BACKEND"), shadows,
+		mlClient, durationFromEnv("MODEL_REFIT_INTERVAL", 6*time.Hour),
+		durationFromEnv("PREDICTION_CACHE_TTL", 6*time.Hour))
+	log.Info().Str("backend", predictionService.Backend()).Strs("shadows", predictionService.Shadows()).
+		Msg("Prediction model backend selected")
+
+	eventBus := service.NewEventBus(broker)
+	webhookService := service.NewWebhookService(db)
+	retentionPolicies, err := service.ParseRetentionPolicies(os.Getenv("RETENTION_POLICIES"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid retention policies")
+	}
+
+	homeAdvantageWindows, err := service.ParseHomeAdvantageWindows(os.Getenv("HOME_ADVANTAGE_WINDOWS"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid home advantage windows")
+	}
+
+	newsFeeds, err := news.ParseFeeds(os.Getenv("NEWS_FEEDS"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid news feeds")
+	}
+	if url := os.Getenv("NEWS_API_URL"); url != "" {
+		newsFeeds = append(newsFeeds, news.Feed{Name: "news-api", URL: url, Kind: news.KindAPI, APIKey: os.Getenv("NEWS_API_KEY")})
+	}
+
+	anomalyService := service.NewAnomalyService(db, eventBus,
+		floatFromEnv("UPSET_PROBABILITY_THRESHOLD", 0.2), floatFromEnv("SCORELINE_ERROR_THRESHOLD", 4))
+
+	// Links in emails and signed downloads point here
+	publicURL := strings.TrimSuffix(os.Getenv("API_PUBLIC_URL"), "/")
+
+	blobStore, err := blob.New(blob.Config{
+		Backend:     os.Getenv("BLOB_BACKEND"),
+		Dir:         blobDir(),
+		PublicURL:   publicURL + "/api/v1/blobs",
+		SigningKey:  os.Getenv("BLOB_SIGNING_KEY"),
+		S3Endpoint:  os.Getenv("S3_ENDPOINT"),
+		S3Region:    os.Getenv("S3_REGION"),
+		S3Bucket:    os.Getenv("S3_BUCKET"),
+		S3AccessKey: os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		S3PathStyle: os.Getenv("S3_PATH_STYLE") != "false",
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid blob storage settings - storing files locally")
+		blobStore = blob.NewLocal(blobDir(), "", "")
+	}
+
+	mailer, err := mail.New(mail.Config{
+		Provider:     os.Getenv("MAIL_PROVIDER"),
+		From:         os.Getenv("MAIL_FROM"),
+		SMTPAddr:     os.Getenv("SMTP_ADDR"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		SendGridURL:  os.Getenv("SENDGRID_BASE_URL"),
+		SendGridKey:  os.Getenv("SENDGRID_API_KEY"),
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid mail settings - email digests are disabled")
+	}
+	if mailer != nil && publicURL == "" {
+		log.Warn().Msg("API_PUBLIC_URL is not set - unsubscribe links in email digests will not work")
+	}
+	digestDay := time.Monday
+	if v := os.Getenv("DIGEST_DAY"); v != "" {
+		if digestDay, err = service.ParseWeekday(v); err != nil {
+			log.Warn().Err(err).Msg("Ignoring invalid digest day")
+			digestDay = time.Monday
+		}
+	}
+
+	previewService := service.NewPreviewService(db, predictionService, llmClient, reviews,
+		durationFromEnv("PREVIEW_CACHE_TTL", 15*time.Minute))
+
+	return &services{
+		football:      footballService,
+		predictions:   predictionService,
+		recaps:        service.NewRecapService(footballService, llmClient, reviews, db),
+		ask:           service.NewAskService(llmClient, reviews, db),
+		events:        eventBus,
+		webhooks:      webhookService,
+		users:         service.NewUserService(db),
+		feeds:         service.NewFeedService(db),
+		fantasy:       service.NewFantasyService(db),
+		leagues:       service.NewLeagueService(db),
+		exports:       service.NewExportService(db, blobStore, durationFromEnv("EXPORT_LINK_TTL", 24*time.Hour)),
+		failures:      service.NewIngestFailureService(db),
+		dataQuality:   service.NewDataQualityService(db),
+		teams:         service.NewTeamService(db),
+		competitions:  service.NewCompetitionService(db),
+		search:        service.NewSearchService(db),
+		mappings:      service.NewEntityMappingService(db),
+		crests:        service.NewCrestService(db, blobStore, durationFromEnv("CREST_MAX_AGE", 7*24*time.Hour)),
+		standings:     service.NewStandingsService(db),
+		projections:   service.NewProjectionService(db, predictionService),
+		anomalies:     anomalyService,
+		streaks:       service.NewStreakService(db),
+		previews:      previewService,
+		audio:         service.NewInsightAudioService(previewService, speech, blobStore),
+		homeAdvantage: service.NewHomeAdvantageService(db, homeAdvantageWindows),
+		travel:        service.NewTravelService(db),
+		draws:         service.NewDrawService(db, predictionService),
+		ties:          service.NewTieService(db, predictionService),
+		news:          service.NewNewsService(db, llmClient, newsFeeds),
+		similarity:    service.NewSimilarityService(db),
+		usage:         service.NewUsageService(db, intFromEnv("API_MONTHLY_QUOTA", 0)),
+		retention:     service.NewRetentionService(db, retentionPolicies),
+		llmUsage:      service.NewLLMUsageService(db),
+		reviews:       reviews,
+		blobs:         blobStore,
+		digests:       service.NewDigestService(db, mailer, digestDay, publicURL+"/api/v1/digest/unsubscribe"),
+		audit:         service.NewAuditService(db),
+		quotas:        quotas,
+		breakers:      breakers,
+	}
+}
+
+// startScheduler runs the background jobs until shutdown, which waits for
+// the runs in progress and then delivers the webhooks still queued.
+func startScheduler(lc *lifecycle.Manager, db *sql.DB, svc *services) *jobs.Scheduler {
+	scheduler := jobs.NewScheduler()
+	scheduler.UseLocker(repository.NewJobLockRepository(db), jobs.InstanceID(), durationFromEnv("JOB_LOCK_TTL", time.Minute))
+
+	settlement := jobs.NewSettlementJob(db, svc.recaps, svc.events, svc.leagues)
+	scheduler.Register("settlement", durationFromEnv("SETTLEMENT_INTERVAL", 15*time.Minute), settlement.Run)
+
+	scheduleChanges := jobs.NewScheduleChangeJob(db, svc.events)
+	scheduler.Register("schedule-changes", durationFromEnv("SCHEDULE_CHANGES_INTERVAL", 5*time.Minute), scheduleChanges.Run)
+
+	webhookDelivery := jobs.NewWebhookDeliveryJob(svc.webhooks)
+	scheduler.Register("webhook-delivery", durationFromEnv("WEBHOOK_DELIVERY_INTERVAL", 30*time.Second), webhookDelivery.Run)
+	lc.OnShutdown("webhook delivery", webhookDelivery.Drain)
+
+	fantasy := jobs.NewFantasyJob(svc.fantasy)
+	scheduler.Register("fantasy", durationFromEnv("FANTASY_INTERVAL", 30*time.Minute), fantasy.Run)
+
+	// A local blob store is per instance, so jobs tending it run on every one
+	registerBlobJob := scheduler.Register
+	if _, ok := svc.blobs.(*blob.Local); ok {
+		registerBlobJob = scheduler.RegisterLocal
+	}
+
+	crestRefresh := jobs.NewCrestRefreshJob(svc.crests)
+	registerBlobJob("crest-refresh", durationFromEnv("CREST_REFRESH_INTERVAL", 6*time.Hour), crestRefresh.Run)
+
+	blobLifecycle, err := blob.ParseLifecycle(os.Getenv("BLOB_LIFECYCLE"), blob.DefaultLifecycle)
+	if err != nil {
+		log.Warn().Err(err).Msg("Ignoring invalid blob lifecycle rules")
+	}
+	blobCleanup := jobs.NewBlobCleanupJob(svc.blobs, blobLifecycle)
+	registerBlobJob("blob-cleanup", durationFromEnv("BLOB_CLEANUP_INTERVAL", 6*time.Hour), blobCleanup.Run)
+
+	digests := jobs.NewDigestJob(svc.digests)
+	scheduler.Register("email-digest", durationFromEnv("DIGEST_INTERVAL", time.Hour), digests.Run)
+
+	anomalies := jobs.NewAnomalyJob(svc.anomalies)
+	scheduler.Register("anomalies", durationFromEnv("ANOMALY_INTERVAL", 30*time.Minute), anomalies.Run)
+
+	predictionInputs := jobs.NewPredictionInputsJob(svc.predictions)
+	scheduler.Register("prediction-inputs", durationFromEnv("PREDICTION_INPUTS_INTERVAL", 5*time.Minute), predictionInputs.Run)
+
+	lineupPredictions := jobs.NewLineupPredictionJob(svc.predictions, svc.events)
+	scheduler.Register("lineup-predictions", durationFromEnv("LINEUP_PREDICTIONS_INTERVAL", 5*time.Minute), lineupPredictions.Run)
+
+	setPieces := jobs.NewSetPieceJob(svc.teams)
+	scheduler.Register("set-pieces", durationFromEnv("SET_PIECES_INTERVAL", time.Hour), setPieces.Run)
+
+	streaks := jobs.NewStreakJob(svc.streaks)
+	scheduler.Register("streaks", durationFromEnv("STREAKS_INTERVAL", time.Hour), streaks.Run)
+
+	homeAdvantage := jobs.NewHomeAdvantageJob(svc.homeAdvantage)
+	scheduler.Register("home-advantage", durationFromEnv("HOME_ADVANTAGE_INTERVAL", 6*time.Hour), homeAdvantage.Run)
+
+	travel := jobs.NewTravelJob(svc.travel)
+	scheduler.Register("travel-distance", durationFromEnv("TRAVEL_DISTANCE_INTERVAL", time.Hour), travel.Run)
+
+	usageFlush := jobs.NewUsageFlushJob(svc.usage)
+	// Each instance flushes the usage it counted
+	scheduler.RegisterLocal("usage-flush", durationFromEnv("USAGE_FLUSH_INTERVAL", time.Minute), usageFlush.Run)
+
+	newsIngest := jobs.NewNewsJob(svc.news)
+	scheduler.Register("news", durationFromEnv("NEWS_INTERVAL", 30*time.Minute), newsIngest.Run)
+
+	embeddings := jobs.NewEmbeddingJob(svc.similarity)
+	scheduler.Register("match-embeddings", durationFromEnv("MATCH_EMBEDDINGS_INTERVAL", time.Hour), embeddings.Run)
+
+	retention := jobs.NewRetentionJob(svc.retention)
+	scheduler.Register("retention", durationFromEnv("RETENTION_INTERVAL", 24*time.Hour), retention.Run)
+
+	// Subscribe before the first runs so their events are not missed
+	subscribeEvents(svc, scheduler)
+
+	lc.Go("scheduler", func(ctx context.Context) {
+		scheduler.Start(ctx)
+		scheduler.Wait()
+	})
+	return scheduler
+}
+
+// subscribeEvents connects the event consumers: webhook subscribers are
+// notified of the events they asked for, and settlement runs as soon as a
+// finished match is ingested rather than on its next tick. With a shared
+// broker the API instances form one consumer group per consumer, so each
+// event is handled once, except for cache invalidation, which every
+// instance does for its own cache.
+func subscribeEvents(svc *services, scheduler *jobs.Scheduler) {
+	subscribe := func(eventType, group string, handler events.Handler) {
+		err := svc.events.Subscribe(eventType, group, func(e events.Event) error {
+			if err := handler(e); err != nil {
+				log.Error().Err(err).Str("event", e.Type).Str("key", e.Key).Str("consumer", group).
+					Msg("Failed to handle event")
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			log.Fatal().Err(err).Str("event", eventType).Msg("Failed to subscribe to events")
+		}
+	}
+
+	for _, eventType := range service.WebhookEvents {
+		subscribe(eventType, "webhooks", svc.webhooks.Record)
+	}
+
+	// Each instance invalidates its own cache, so it is a group of its own
+	cacheGroup := "cache:" + jobs.InstanceID()
+
+	subscribe(events.MatchIngested, "settlement", func(e events.Event) error {
+		var match events.IngestedMatch
+		if err := json.Unmarshal(e.Data, &match); err != nil {
+			return err
+		}
+		switch match.Status {
+		case "FINISHED":
+			scheduler.Trigger("settlement")
+		case "POSTPONED", "CANCELLED":
+			scheduler.Trigger("schedule-changes")
+		}
+		return nil
+	})
+
+	subscribe(events.MatchIngested, cacheGroup, func(e events.Event) error {
+		var match events.IngestedMatch
+		if err := json.Unmarshal(e.Data, &match); err != nil {
+			return err
+		}
+		if n := svc.football.InvalidateMatch(match.ExternalID, match.CompetitionCode, match.Status); n > 0 {
+			log.Debug().Int("match", match.ExternalID).Int("keys", n).Msg("Invalidated cached match data")
+		}
+		return nil
+	})
+
+	// A moved fixture's prediction was made for the old kickoff: rest days
+	// and fixture congestion no longer hold
+	for _, eventType := range []string{events.MatchPostponed, events.MatchRescheduled} {
+		subscribe(eventType, cacheGroup, func(e events.Event) error {
+			var change repository.ScheduleChange
+			if err := json.Unmarshal(e.Data, &change); err != nil {
+				return err
+			}
+			svc.predictions.InvalidateMatch(change.MatchID)
+			svc.football.InvalidateMatch(change.ExternalID, change.CompetitionCode, change.ToStatus)
+			return nil
+		})
+	}
+}
+
+// durationFromEnv parses a Go duration (e.g. "15m") from the environment,
+// falling back to def when unset or invalid.
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Warn().Str("key", key).Str("value", value).Msg("Invalid duration, using default")
+		return def
+	}
+
+	return d
+}
+
+// floatFromEnv parses a number from the environment, falling back to def
+// when unset or invalid.
+func floatFromEnv(key string, def float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f <= 0 {
+		log.Warn().Str("key", key).Str("value", value).Msg("Invalid number, using default")
+		return def
+	}
+	return f
+}
+
+// intFromEnv parses a non-negative integer from the environment, falling back
+// to def when unset or invalid.
+func intFromEnv(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Warn().Str("key", key).Str("value", value).Msg("Invalid number, using default")
+		return def
+	}
+	return n
+}
+
+// blobDir is where the local blob store keeps files, BLOB_DIR or data/blobs
+// under the working directory.
+func blobDir() string {
+	if dir := os.Getenv("BLOB_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join("data", "blobs")
 }
 
 func setupLogger() {
@@ -64,34 +578,19 @@ func setupLogger() {
 }
 
 func connectDB() (*sql.DB, error) {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		return nil, fmt.Errorf("DATABASE_URL environment variable not set")
-	}
-
-	db, err := sql.Open("postgres", dbURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-
-	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	db, err := database.OpenFromEnv(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Set connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
-
 	log.Info().Msg("Successfully connected to database")
 	return db, nil
 }
 
-func setupRouter(db *sql.DB, apiKey string) *gin.Engine {
+func setupRouter(lc *lifecycle.Manager, db *sql.DB, svc *services, scheduler *jobs.Scheduler) *gin.Engine {
 	// Set Gin mode
 	if os.Getenv("API_ENV") == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -100,6 +599,9 @@ func setupRouter(db *sql.DB, apiKey string) *gin.Engine {
 	router := gin.Default()
 
 	// Middleware
+	router.Use(httpx.RequestID())
+	router.Use(tracing.Middleware())
+	router.Use(httpx.Errors())
 	router.Use(corsMiddleware())
 	router.Use(rateLimitMiddleware())
 
@@ -110,19 +612,103 @@ func setupRouter(db *sql.DB, apiKey string) *gin.Engine {
 			"timestamp": time.Now().Unix(),
 		})
 	})
-
-	// Initialize services and handlers
-	footballService := service.NewFootballService(apiKey, db)
-	footballHandler := handlers.NewFootballHandler(footballService)
+	healthHandler := handlers.NewHealthHandler(db, svc.breakers)
+	router.GET("/health/ready", healthHandler.Ready)
+
+	router.NoRoute(httpx.NoRoute)
+
+	// Initialize handlers
+	footballHandler := handlers.NewFootballHandler(svc.football, svc.predictions, svc.events, lc)
+	recapHandler := handlers.NewRecapHandler(svc.recaps)
+	askHandler := handlers.NewAskHandler(svc.ask)
+	webhookHandler := handlers.NewWebhookHandler(svc.webhooks)
+	meHandler := handlers.NewMeHandler(svc.feeds, svc.usage)
+	adminUserHandler := handlers.NewAdminUserHandler(svc.users)
+	fantasyHandler := handlers.NewFantasyHandler(svc.fantasy)
+	leagueHandler := handlers.NewLeagueHandler(svc.leagues)
+	exportHandler := handlers.NewExportHandler(svc.exports)
+	ingestFailureHandler := handlers.NewIngestFailureHandler(svc.failures)
+	dataQualityHandler := handlers.NewDataQualityHandler(svc.dataQuality)
+	teamHandler := handlers.NewTeamHandler(svc.teams, svc.streaks)
+	competitionHandler := handlers.NewCompetitionHandler(svc.competitions, svc.previews)
+	searchHandler := handlers.NewSearchHandler(svc.search)
+	mappingHandler := handlers.NewEntityMappingHandler(svc.mappings)
+	assetHandler := handlers.NewAssetHandler(svc.crests)
+	blobHandler := handlers.NewBlobHandler(svc.blobs)
+	digestHandler := handlers.NewDigestHandler(svc.digests)
+	standingsHandler := handlers.NewStandingsHandler(svc.standings)
+	projectionHandler := handlers.NewProjectionHandler(svc.projections)
+	insightsHandler := handlers.NewInsightsHandler(svc.anomalies)
+	analyticsHandler := handlers.NewAnalyticsHandler(svc.homeAdvantage)
+	retentionHandler := handlers.NewRetentionHandler(svc.retention)
+	drawHandler := handlers.NewDrawHandler(svc.draws)
+	tieHandler := handlers.NewTieHandler(svc.ties)
+	newsHandler := handlers.NewNewsHandler(svc.news)
+	similarityHandler := handlers.NewSimilarityHandler(svc.similarity)
+	audioHandler := handlers.NewInsightAudioHandler(svc.audio)
+	jobsHandler := handlers.NewJobsHandler(scheduler)
+	quotaHandler := handlers.NewQuotaHandler(svc.quotas, svc.football)
+	llmUsageHandler := handlers.NewLLMUsageHandler(svc.llmUsage)
+	mlHandler := handlers.NewMLHandler(svc.predictions)
+	cacheHandler := handlers.NewCacheHandler(svc.football)
+	reviewHandler := handlers.NewInsightReviewHandler(svc.reviews)
+	auditHandler := handlers.NewAuditHandler(svc.audit)
+
+	requireAdmin := auth.RequireAdminToken(os.Getenv("ADMIN_API_TOKEN"))
+	requireUser := auth.RequireUser(svc.users.Repository(), svc.usage)
+	audit := auditHandler.Audit
 
 	// API v1 routes
-	v1 := router.Group("/api/v1")
+	v1 := router.Group("/api/v1", httpx.Timezone())
 	{
 		v1.GET("/competitions", footballHandler.GetCompetitions)
+		v1.GET("/competitions/:code", competitionHandler.GetCompetition)
+		v1.GET("/competitions/:code/matchdays/:n", competitionHandler.GetMatchday)
+		v1.GET("/competitions/:code/bracket", competitionHandler.GetBracket)
+		v1.GET("/ties/:id", tieHandler.GetTie)
+		v1.GET("/competitions/:code/preview", competitionHandler.GetPreview)
+		v1.GET("/competitions/:code/fixtures.ics", competitionHandler.GetFixturesCalendar)
 		v1.GET("/matches", footballHandler.GetMatches)
 		v1.GET("/matches/:id", footballHandler.GetMatch)
+		v1.GET("/matches/:id/recap", recapHandler.GetRecap)
+		v1.GET("/matches/:id/team-stats", footballHandler.GetMatchTeamStats)
+		v1.GET("/matches/:id/timeline", footballHandler.GetMatchTimeline)
+		v1.GET("/matches/:id/status-history", footballHandler.GetMatchStatusHistory)
+		v1.GET("/matches/:id/predictions", footballHandler.GetPredictionVersions)
+		v1.GET("/matches/:id/similar", similarityHandler.GetSimilarMatches)
+		v1.GET("/matches/:id/insights/audio", requireUser, audioHandler.GetPreviewAudio)
 		v1.GET("/standings/:competition", footballHandler.GetStandings)
+		v1.GET("/standings/:competition/computed", standingsHandler.GetComputed)
+		v1.GET("/standings/:competition/projected", requireUser, projectionHandler.GetProjected)
+		v1.GET("/qualifiers/:confederation", standingsHandler.GetQualifiers)
+		v1.GET("/head-to-head", footballHandler.GetHeadToHead)
+		v1.GET("/teams/:id/season-stats", teamHandler.GetSeasonStats)
+		v1.GET("/teams/:id/matches", teamHandler.GetMatches)
+		v1.GET("/teams/:id/streaks", teamHandler.GetStreaks)
+		v1.GET("/teams/:id/coaches", teamHandler.GetCoaches)
+		v1.GET("/teams/:id/form", teamHandler.GetForm)
+		v1.GET("/teams/:id/news", newsHandler.GetTeamNews)
+		v1.GET("/teams/:id/signals", newsHandler.GetTeamSignals)
+		v1.GET("/teams/:id/suspension-risk", teamHandler.GetSuspensionRisk)
+		v1.GET("/teams/:id/set-piece-profile", teamHandler.GetSetPieceProfile)
+		v1.GET("/teams/:id/goal-timings", teamHandler.GetGoalTimings)
+		v1.GET("/teams/:id/fixtures.ics", teamHandler.GetFixturesCalendar)
+		v1.GET("/compare", teamHandler.Compare)
+		v1.GET("/search", searchHandler.Search)
+		v1.GET("/insights/upsets", insightsHandler.ListUpsets)
+		v1.GET("/analytics/home-advantage", analyticsHandler.GetHomeAdvantage)
+		v1.GET("/assets/crest/:teamId", assetHandler.GetCrest)
+		// Signed links to stored files, such as export downloads
+		v1.GET("/blobs/*key", blobHandler.GetBlob)
+		// Unsubscribe links in email digests
+		v1.GET("/digest/unsubscribe", digestHandler.ConfirmUnsubscribe)
+		v1.POST("/digest/unsubscribe", digestHandler.Unsubscribe)
 		v1.GET("/predictions/:matchId", footballHandler.GetPrediction)
+		v1.POST("/predictions/batch", requireUser, footballHandler.BatchPredictions)
+		v1.POST("/predictions/simulate", requireUser, footballHandler.SimulatePrediction)
+		v1.GET("/predictions/compare", footballHandler.ComparePredictions)
+		v1.GET("/predictions/head-to-head", footballHandler.GetModelHeadToHead)
+		v1.POST("/draws/simulate", requireUser, drawHandler.SimulateDraw)
 
 		// Prediction history routes
 		v1.GET("/predictions/history", func(c *gin.Context) {
@@ -131,12 +717,87 @@ func setupRouter(db *sql.DB, apiKey string) *gin.Engine {
 		v1.GET("/predictions/accuracy", func(c *gin.Context) {
 			handlers.GetPredictionAccuracy(c, db)
 		})
+
+		// Each question calls an LLM provider (requires an API key)
+		v1.POST("/ask", requireUser, askHandler.Ask)
+
+		// Fantasy points
+		v1.GET("/fantasy/gameweek/:n/top", fantasyHandler.GetGameweekTop)
+		v1.GET("/fantasy/players/:id/history", fantasyHandler.GetPlayerHistory)
+		v1.GET("/fantasy/rules", fantasyHandler.GetRules)
+
+		// Training data export (requires an API key)
+		v1.GET("/export/matches", requireUser, exportHandler.ExportMatches)
+
+		// Webhook registration (requires admin token)
+		v1.POST("/webhooks", requireAdmin, audit("webhook.register"), webhookHandler.Register)
+		v1.DELETE("/webhooks/:id", requireAdmin, audit("webhook.delete"), webhookHandler.Delete)
+	}
+
+	// Admin routes
+	admin := v1.Group("/admin", requireAdmin)
+	{
+		admin.GET("/webhooks", webhookHandler.List)
+		admin.PUT("/fantasy/rules", audit("fantasy.rules.update"), fantasyHandler.UpdateRules)
+		admin.GET("/standings/:competition/rules", standingsHandler.GetRules)
+		admin.PUT("/standings/:competition/rules", audit("standings.rules.update"), standingsHandler.UpdateRules)
+		admin.GET("/ingest/failures", ingestFailureHandler.List)
+		admin.GET("/data-quality", dataQualityHandler.Get)
+		admin.GET("/mappings", mappingHandler.List)
+		admin.PUT("/mappings/:type/:provider/:providerId", audit("mapping.set"), mappingHandler.Set)
+		admin.DELETE("/mappings/:type/:provider/:providerId", audit("mapping.delete"), mappingHandler.Delete)
+		admin.POST("/aliases", audit("alias.add"), mappingHandler.AddAlias)
+		admin.DELETE("/aliases/:id", audit("alias.delete"), mappingHandler.DeleteAlias)
+		admin.POST("/users", audit("user.create"), adminUserHandler.CreateUser)
+		admin.GET("/users/:id/keys", adminUserHandler.ListKeys)
+		admin.POST("/users/:id/keys", audit("key.create"), adminUserHandler.CreateKey)
+		admin.DELETE("/users/:id/keys/:keyId", audit("key.revoke"), adminUserHandler.RevokeKey)
+		admin.PUT("/users/:id/quota", audit("user.quota.update"), adminUserHandler.SetQuota)
+		admin.GET("/retention", retentionHandler.Get)
+		admin.POST("/retention/run", audit("retention.run"), retentionHandler.Run)
+		admin.GET("/audit", auditHandler.List)
+		admin.GET("/jobs", jobsHandler.List)
+		admin.GET("/quota", quotaHandler.Get)
+		admin.GET("/llm-usage", llmUsageHandler.Get)
+		admin.GET("/ml", mlHandler.GetStats)
+		admin.GET("/breakers", healthHandler.Breakers)
+		admin.GET("/cache", cacheHandler.Get)
+		admin.DELETE("/cache", audit("cache.invalidate"), cacheHandler.Invalidate)
+		admin.GET("/insights/review", reviewHandler.List)
+		admin.PUT("/insights/review/:id", audit("insight.review"), reviewHandler.Resolve)
+	}
+
+	// Personalized routes (require an API key)
+	me := v1.Group("/me", requireUser)
+	{
+		me.GET("", meHandler.GetMe)
+		me.GET("/follows", meHandler.ListFollows)
+		me.POST("/follows", meHandler.Follow)
+		me.DELETE("/follows/:type/:id", meHandler.Unfollow)
+		me.GET("/feed", meHandler.GetFeed)
+		me.GET("/usage", meHandler.GetUsage)
+		me.GET("/digest", digestHandler.GetSubscription)
+		me.PUT("/digest", digestHandler.UpdateSubscription)
+		me.GET("/digest/preview", digestHandler.PreviewDigest)
+		me.GET("/predictions", leagueHandler.ListPredictions)
+		me.PUT("/predictions/:matchId", leagueHandler.Predict)
+	}
+
+	// Private prediction leagues (require an API key)
+	leagues := v1.Group("/leagues", requireUser)
+	{
+		leagues.GET("", leagueHandler.List)
+		leagues.POST("", leagueHandler.Create)
+		leagues.POST("/join", leagueHandler.Join)
+		leagues.GET("/:id/table", leagueHandler.GetTable)
 	}
 
 	return router
 }
 
-func startServer(router *gin.Engine) {
+// startServer serves the API until shutdown, which stops accepting
+// connections and waits for the requests in flight.
+func startServer(lc *lifecycle.Manager, router *gin.Engine) {
 	port := os.Getenv("API_PORT")
 	if port == "" {
 		port = "8080"
@@ -165,22 +826,19 @@ func startServer(router *gin.Engine) {
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	log.Info().Msg("Shutting down server...")
-
-	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	lc.Go("http server", func(ctx context.Context) {
+		<-ctx.Done()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal().Err(err).Msg("Server forced to shutdown")
-	}
+		// Graceful shutdown
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
 
-	log.Info().Msg("Server exited")
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("Server forced to shutdown")
+			return
+		}
+		log.Info().Msg("Server exited")
+	})
 }
 
 // Middleware
@@ -188,7 +846,7 @@ func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Admin-Token, X-API-Key, X-Request-ID, X-Timezone, traceparent, tracestate, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
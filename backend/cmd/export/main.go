@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/yourusername/football-prediction/internal/database"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// This command exports matches, stats, features and settled predictions for
+// model training, e.g.:
+//
+//	go run ./cmd/export -format parquet -competition PL -season 2024 -out pl_2024.parquet
+
+func main() {
+	format := flag.String("format", "csv", "output format: csv, parquet, json or ndjson")
+	competition := flag.String("competition", "", "competition code, e.g. PL (default all)")
+	season := flag.String("season", "", "season start year, e.g. 2024 (default all)")
+	status := flag.String("status", "", "match status, e.g. FINISHED (default all)")
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	_ = godotenv.Load("../.env")
+	_ = godotenv.Load("../../.env")
+
+	db, err := database.OpenFromEnv(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	dest := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("failed to create %s: %v", *out, err)
+		}
+		defer f.Close()
+		dest = f
+	}
+
+	w := bufio.NewWriterSize(dest, 1<<16)
+
+	filter := repository.ExportFilter{
+		CompetitionCode: *competition,
+		Season:          *season,
+		Status:          *status,
+	}
+
+	rows, err := service.NewExportService(db, nil, 0).ExportMatches(filter, *format, w)
+	if err != nil {
+		log.Fatalf("export failed after %d rows: %v", rows, err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ Exported %d matches\n", rows)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// recordAudit writes a command run to the audit log as "cli.<name>", with
+// the OS user who ran it and whether it succeeded. A failure to record is
+// only reported.
+func recordAudit(name string, args []string, runErr error) {
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		log.Printf("⚠️  Audit log not written: %v", err)
+		return
+	}
+	defer db.Close()
+
+	actor := "unknown"
+	if u, err := user.Current(); err == nil {
+		actor = u.Username
+	}
+
+	details := map[string]string{}
+	if host, err := os.Hostname(); err == nil {
+		details["host"] = host
+	}
+	status := 0
+	if runErr != nil {
+		status = 1
+		details["error"] = runErr.Error()
+	}
+	detailsJSON, _ := json.Marshal(details)
+
+	err = service.NewAuditService(db).Record(repository.AuditEntry{
+		Action:    "cli." + name,
+		ActorType: service.AuditActorCLI,
+		Actor:     actor,
+		Target:    strings.Join(args, " "),
+		Status:    &status,
+		Details:   detailsJSON,
+	})
+	if err != nil {
+		log.Printf("⚠️  Audit log not written: %v", err)
+	}
+}
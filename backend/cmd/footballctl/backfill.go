@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/ingest"
+	schedule "github.com/yourusername/football-prediction/internal/jobs"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// runBackfill walks every configured competition for each season in
+// [from, to]. Each competition season is checkpointed in backfill_jobs, so an
+// interrupted run resumes where it stopped and completed seasons are skipped.
+func runBackfill(args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	from := fs.Int("from", 0, "first season start year (required)")
+	to := fs.Int("to", 0, "last season start year (default: -from)")
+	comps := fs.String("competitions", "PL,PD,BL1,SA,FL1,CL", "comma-separated competition codes")
+	delay := fs.Duration("delay", 7*time.Second, "minimum pause between API requests (free tier allows 10/min)")
+	workers := fs.Int("workers", 4, "concurrent database writers")
+	maxWaits := fs.Int("max-rate-limit-waits", 20, "give up after this many rate-limit pauses")
+	statusOnly := fs.Bool("status", false, "print checkpoint status without fetching")
+	fs.Parse(args)
+
+	if *from == 0 {
+		return fmt.Errorf("-from is required")
+	}
+	if *to == 0 {
+		*to = *from
+	}
+	if *to < *from {
+		return fmt.Errorf("-to must not be before -from")
+	}
+
+	var codes []string
+	for _, code := range strings.Split(*comps, ",") {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return fmt.Errorf("no competitions given")
+	}
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := repository.NewBackfillRepository(db)
+	fromSeason, toSeason := strconv.Itoa(*from), strconv.Itoa(*to)
+
+	for season := *from; season <= *to; season++ {
+		for _, code := range codes {
+			if err := repo.Ensure(code, strconv.Itoa(season)); err != nil {
+				return err
+			}
+		}
+	}
+
+	jobs, err := repo.List(codes, fromSeason, toSeason)
+	if err != nil {
+		return err
+	}
+
+	if *statusOnly {
+		printBackfillStatus(jobs)
+		return nil
+	}
+
+	source, err := datasource.Open(os.Getenv("PROVIDER"), os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		return err
+	}
+	apiKey := os.Getenv("FOOTBALL_API_KEY")
+	if apiKey == "" && !source.Mock() {
+		return fmt.Errorf("FOOTBALL_API_KEY not set")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// The key's request budget is shared with the API and ingest runs
+	quotaLimits, err := service.ParseProviderQuotas(os.Getenv("PROVIDER_QUOTAS"))
+	if err != nil {
+		return err
+	}
+	client := football.NewClient(source.Keys(apiKey)...)
+	client.SetTransport(source.Transport())
+	client.SetMinInterval(source.MinInterval(*delay))
+	client.SetQuota(source.Quota(service.NewProviderQuotas(db, quotaLimits)))
+
+	// Responses are kept for 'footballctl replay' when RAW_ARCHIVE=true
+	var archive *datasource.Archive
+	if os.Getenv("RAW_ARCHIVE") == "true" && !source.Mock() {
+		archive = ingest.ArchiveTransport(db, football.Provider, source.Transport())
+		client.SetTransport(archive)
+	}
+
+	// Backfills share the API rate limit with ingest runs, so they take turns
+	err = schedule.RunLocked(ctx, repository.NewJobLockRepository(db), "ingest", schedule.InstanceID(), time.Minute,
+		func(ctx context.Context) error {
+			return runBackfillJobs(ctx, db, repo, client, *workers, *maxWaits, jobs)
+		})
+	if archive != nil {
+		s := archive.Stats()
+		log.Printf("🗄️  Archived %d responses for replay", s.Archived)
+		if s.Failed > 0 {
+			log.Printf("⚠️  Failed to archive %d responses: %s", s.Failed, s.LastError)
+		}
+	}
+	if errors.Is(err, schedule.ErrLockHeld) {
+		return fmt.Errorf("an ingest or backfill is already running")
+	}
+	return err
+}
+
+// runBackfillJobs ingests the jobs not yet done through the ingest pipeline,
+// checkpointing each season as its result comes in. Interrupted seasons are
+// left running and picked up again on the next run.
+func runBackfillJobs(ctx context.Context, db *sql.DB, repo *repository.BackfillRepository,
+	client *football.Client, workers, maxWaits int, jobs []repository.BackfillJob) error {
+	var pending []ingest.SeasonJob
+	for _, j := range jobs {
+		if j.Status != repository.BackfillDone {
+			pending = append(pending, ingest.SeasonJob{ID: j.ID, CompetitionCode: j.CompetitionCode, Season: j.Season})
+		}
+	}
+
+	log.Printf("🚀 Backfill: %d competition seasons, %d already done, %d to process",
+		len(jobs), len(jobs)-len(pending), len(pending))
+
+	start := time.Now()
+	reported, done, failed := 0, 0, 0
+	var checkpointErr error
+
+	pipeline := &ingest.Pipeline{
+		DB:                db,
+		Client:            client,
+		Workers:           workers,
+		MaxRateLimitWaits: maxWaits,
+		OnFetch: func(job ingest.SeasonJob) error {
+			return repo.MarkRunning(job.ID)
+		},
+		OnResult: func(r ingest.SeasonResult) {
+			reported++
+			job := r.Job
+			if r.Err != nil {
+				failed++
+				log.Printf("❌ [%d/%d] %s %s: %v", reported, len(pending), job.CompetitionCode, job.Season, r.Err)
+				if err := repo.MarkFailed(job.ID, r.Err.Error()); err != nil && checkpointErr == nil {
+					checkpointErr = err
+				}
+				return
+			}
+
+			done++
+			if err := repo.MarkDone(job.ID, r.Saved); err != nil && checkpointErr == nil {
+				checkpointErr = err
+			}
+
+			elapsed := time.Since(start)
+			eta := elapsed / time.Duration(reported) * time.Duration(len(pending)-reported)
+			log.Printf("✅ [%d/%d] %s %s: %d matches (elapsed %s, ETA %s)",
+				reported, len(pending), job.CompetitionCode, job.Season, r.Saved,
+				elapsed.Round(time.Second), eta.Round(time.Second))
+		},
+	}
+
+	metrics, err := pipeline.Run(ctx, pending)
+	log.Printf("📊 %s", metrics)
+	if ctx.Err() != nil {
+		log.Printf("⏸️  Interrupted, progress saved")
+	} else if err != nil {
+		log.Printf("❌ Backfill stopped: %v", err)
+	}
+
+	log.Printf("🎉 Backfill finished: %d done, %d failed, %d remaining", done, failed, len(pending)-done-failed)
+	if failed > 0 {
+		log.Printf("   Failed seasons are retried on the next run; see 'footballctl backfill -status'")
+	}
+
+	return checkpointErr
+}
+
+func printBackfillStatus(jobs []repository.BackfillJob) {
+	counts := make(map[string]int)
+	fmt.Printf("%-6s %-6s %-8s %8s %8s  %s\n", "COMP", "SEASON", "STATUS", "ATTEMPTS", "MATCHES", "LAST ERROR")
+	for _, j := range jobs {
+		counts[j.Status]++
+
+		lastErr := j.LastError
+		if len(lastErr) > 60 {
+			lastErr = lastErr[:60] + "…"
+		}
+		fmt.Printf("%-6s %-6s %-8s %8d %8d  %s\n", j.CompetitionCode, j.Season, j.Status, j.Attempts, j.MatchesSaved, lastErr)
+	}
+
+	fmt.Printf("\n%d done, %d pending, %d running, %d failed\n",
+		counts[repository.BackfillDone], counts[repository.BackfillPending],
+		counts[repository.BackfillRunning], counts[repository.BackfillFailed])
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/pkg/mlclient"
+)
+
+// mlBackendAlias names the ML backend after the ML service's predictor.
+const mlBackendAlias = "v2"
+
+// runBacktest replays a finished season through a prediction backend using
+// only data stored before each kickoff, stores the run and reports its
+// scores.
+func runBacktest(args []string) error {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	backend := fs.String("model", service.BackendGo, "prediction backend to replay (go, ml or v2, ensemble, baseline)")
+	season := fs.String("season", "", "season start year to replay, e.g. 2023 (required)")
+	competition := fs.String("competition", "", "only replay this competition code, e.g. PL")
+	fs.Parse(args)
+
+	if *season == "" {
+		return fmt.Errorf("--season is required")
+	}
+	if *backend == mlBackendAlias {
+		*backend = service.BackendML
+	}
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	log.Printf("⏪ Backtesting %s on season %s", *backend, *season)
+
+	ml := mlclient.New(mlclient.Config{URL: os.Getenv("ML_SERVICE_URL")})
+	predictions := service.NewPredictionService(db, service.BackendGo, nil, ml, 0, 0)
+	report, err := service.NewBacktestService(db, predictions).Run(service.BacktestOptions{
+		Backend:         *backend,
+		CompetitionCode: *competition,
+		Season:          *season,
+	})
+	if err != nil {
+		return err
+	}
+
+	scope := report.CompetitionCode
+	if scope == "" {
+		scope = "all competitions"
+	}
+	fmt.Printf("Backtest #%d: %s (%s), %s season %s\n", report.ID, report.Backend, report.ModelVersion, scope, report.Season)
+	fmt.Printf("  %-10s %d (%d skipped without earlier results)\n", "matches", report.Matches, report.Skipped)
+	fmt.Printf("  %-10s %.1f%% (%d correct)\n", "accuracy", report.Accuracy*100, report.Correct)
+	fmt.Printf("  %-10s %.4f\n", "log-loss", report.LogLoss)
+	fmt.Printf("  %-10s %.4f\n", "brier", report.Brier)
+	return nil
+}
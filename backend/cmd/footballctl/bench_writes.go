@@ -0,0 +1,169 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/ingest"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// benchExternalID offsets the external IDs of generated rows so they cannot
+// collide with real football-data.org IDs.
+const benchExternalID = 900_000_000
+
+// runBenchWrites times the ways the ingest pipeline can write a backfill:
+// one upsert per row with the SQL re-parsed each time, one upsert per row
+// through prepared statements, and batched upserts. Each strategy writes the
+// same generated matches in its own transaction, which is rolled back.
+func runBenchWrites(args []string) error {
+	fs := flag.NewFlagSet("bench-writes", flag.ExitOnError)
+	matches := fs.Int("matches", 10000, "number of generated matches to write")
+	batchSize := fs.Int("batch", 200, "matches per statement in the batched strategy")
+	fs.Parse(args)
+
+	if *matches < 1 || *batchSize < 1 {
+		return fmt.Errorf("--matches and --batch must be positive")
+	}
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	comp, rows := benchMatches(*matches)
+
+	strategies := []struct {
+		name string
+		save func(tx *sql.Tx) error
+	}{
+		{"row by row", func(tx *sql.Tx) error {
+			for _, m := range rows {
+				if err := ingest.SaveMatch(tx, m); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"row by row, prepared", func(tx *sql.Tx) error {
+			stmts := ingest.NewStmtCache(tx)
+			defer stmts.Close()
+			for _, m := range rows {
+				if err := ingest.SaveMatch(stmts, m); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{fmt.Sprintf("batches of %d", *batchSize), func(tx *sql.Tx) error {
+			stmts := ingest.NewStmtCache(tx)
+			defer stmts.Close()
+			for from := 0; from < len(rows); from += *batchSize {
+				missing, err := ingest.SaveMatches(stmts, rows[from:min(from+*batchSize, len(rows))])
+				if err != nil {
+					return err
+				}
+				if len(missing) > 0 {
+					return fmt.Errorf("%d matches were not saved", len(missing))
+				}
+			}
+			return nil
+		}},
+	}
+
+	fmt.Printf("Writing %d generated matches per strategy (rolled back)\n\n", len(rows))
+	fmt.Printf("%-24s %12s %14s %8s\n", "STRATEGY", "TIME", "MATCHES/S", "SPEEDUP")
+
+	var baseline time.Duration
+	for _, s := range strategies {
+		elapsed, err := benchTx(db, comp, s.save)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+		if baseline == 0 {
+			baseline = elapsed
+		}
+		fmt.Printf("%-24s %12s %14.0f %7.1fx\n", s.name, elapsed.Round(time.Millisecond),
+			float64(len(rows))/elapsed.Seconds(), baseline.Seconds()/elapsed.Seconds())
+	}
+	return nil
+}
+
+// benchTx saves the competition and runs save in a transaction that is
+// always rolled back, returning the time save took.
+func benchTx(db *sql.DB, comp *football.Competition, save func(tx *sql.Tx) error) (time.Duration, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := ingest.SaveCompetition(tx, comp); err != nil {
+		return 0, fmt.Errorf("failed to save competition: %w", err)
+	}
+
+	began := time.Now()
+	if err := save(tx); err != nil {
+		return 0, err
+	}
+	return time.Since(began), nil
+}
+
+// benchMatches generates n finished matches of a 20-team league, as a
+// backfill would fetch them, plus their competition.
+func benchMatches(n int) (*football.Competition, []*football.Match) {
+	comp := football.Competition{ID: benchExternalID, Name: "Write Benchmark League", Code: "WBL"}
+
+	const teamCount = 20
+	teams := make([]football.Team, teamCount)
+	for i := range teams {
+		id := benchExternalID + i + 1
+		teams[i] = football.Team{
+			ID:        id,
+			Name:      fmt.Sprintf("Benchmark Team %d", i+1),
+			ShortName: fmt.Sprintf("Bench %d", i+1),
+			TLA:       fmt.Sprintf("B%02d", i+1),
+		}
+	}
+
+	kickoff := time.Date(2000, 8, 1, 15, 0, 0, 0, time.UTC)
+	matches := make([]*football.Match, n)
+	for i := range matches {
+		home, away := i%teamCount, (i/teamCount+i+1)%teamCount
+		if away == home {
+			away = (away + 1) % teamCount
+		}
+		homeGoals, awayGoals := i%4, (i/3)%3
+		winner := "DRAW"
+		switch {
+		case homeGoals > awayGoals:
+			winner = "HOME_TEAM"
+		case awayGoals > homeGoals:
+			winner = "AWAY_TEAM"
+		}
+
+		season := i / 380
+		matches[i] = &football.Match{
+			ID:          benchExternalID + i + 1,
+			Competition: comp,
+			Season:      football.Season{ID: 2000 + season},
+			UtcDate:     kickoff.AddDate(season, 0, (i%380)/10*7),
+			Status:      "FINISHED",
+			Matchday:    (i%380)/10 + 1,
+			Venue:       fmt.Sprintf("Benchmark Stadium %d", home+1),
+			HomeTeam:    teams[home],
+			AwayTeam:    teams[away],
+			Score: football.Score{
+				Winner:   winner,
+				Duration: "REGULAR",
+				FullTime: football.ScoreTime{Home: &homeGoals, Away: &awayGoals},
+			},
+		}
+	}
+	return &comp, matches
+}
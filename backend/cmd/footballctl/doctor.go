@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/yourusername/football-prediction/internal/doctor"
+)
+
+// runDoctor looks for duplicate players, orphaned player stats and matches
+// whose teams are missing, and with --fix merges and deletes them in one
+// transaction.
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "merge duplicate players and delete orphaned rows")
+	fs.Parse(args)
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var report *doctor.Report
+	if *fix {
+		report, err = doctor.Fix(db)
+	} else {
+		report, err = doctor.Check(db)
+	}
+	if err != nil {
+		return err
+	}
+
+	printDoctorReport(report)
+	switch {
+	case report.Clean():
+		log.Printf("✅ No problems found")
+	case *fix:
+		log.Printf("🩹 Merged %d duplicate players, deleted %d orphaned player stats and %d matches without teams",
+			len(report.DuplicatePlayers), report.OrphanedPlayerStats, len(report.MatchesWithoutTeams))
+	default:
+		log.Printf("⚠️  Found %d duplicate players, %d orphaned player stats and %d matches without teams; run with -fix to repair",
+			len(report.DuplicatePlayers), report.OrphanedPlayerStats, len(report.MatchesWithoutTeams))
+	}
+	return nil
+}
+
+func printDoctorReport(report *doctor.Report) {
+	if len(report.DuplicatePlayers) > 0 {
+		fmt.Println("Duplicate players (kept ← duplicate, by internal and external ID):")
+		for _, d := range report.DuplicatePlayers {
+			fmt.Printf("  %-28s %-24s %d/%d ← %d/%d\n",
+				d.Name, d.Team, d.KeepID, d.KeepExternalID, d.DuplicateID, d.DuplicateExternalID)
+		}
+	}
+	if len(report.MatchesWithoutTeams) > 0 {
+		fmt.Println("Matches without teams:")
+		for _, m := range report.MatchesWithoutTeams {
+			fmt.Printf("  %d (external %d) %s %-9s home %s away %s\n",
+				m.ID, m.ExternalID, m.Date.Format("2006-01-02"), m.Status, teamRef(m.HomeTeamID), teamRef(m.AwayTeamID))
+		}
+	}
+}
+
+// teamRef prints a match's team ID, which may be missing.
+func teamRef(id *int) string {
+	if id == nil {
+		return "none"
+	}
+	return fmt.Sprint(*id)
+}
@@ -0,0 +1,129 @@
+// Command footballctl runs operational tasks against the football database,
+// such as historical backfills.
+//
+// Usage:
+//
+//	footballctl <command> [flags]
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/joho/godotenv"
+	"github.com/yourusername/football-prediction/internal/database"
+)
+
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"backfill": {
+		summary: "ingest historical seasons with resumable checkpoints",
+		run:     runBackfill,
+	},
+	"bench-writes": {
+		summary: "time row-by-row against batched match writes on generated data",
+		run:     runBenchWrites,
+	},
+	"backtest": {
+		summary: "replay a past season through a prediction model and score it",
+		run:     runBacktest,
+	},
+	"doctor": {
+		summary: "find duplicate players and orphaned rows, and repair them with --fix",
+		run:     runDoctor,
+	},
+	"minutes": {
+		summary: "aggregate minutes played from stored lineups into player stats",
+		run:     runMinutes,
+	},
+	"record": {
+		summary: "capture live provider responses as fixtures for PROVIDER=mock",
+		run:     runRecord,
+	},
+	"replay": {
+		summary: "re-process archived provider responses through the current parsers",
+		run:     runReplay,
+	},
+	"retry-failures": {
+		summary: "re-process rows recorded in the ingest dead-letter table",
+		run:     runRetryFailures,
+	},
+	"seed": {
+		summary: "load a synthetic demo dataset, or remove it with --clean",
+		run:     runSeed,
+	},
+	"venues": {
+		summary: "load stadium coordinates from CSV and compute away travel distances",
+		run:     runVenues,
+	},
+	"verify-provider": {
+		summary: "check provider responses, live or recorded, against our models",
+		run:     runVerifyProvider,
+	},
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	err := cmd.run(os.Args[2:])
+	recordAudit(name, os.Args[2:], err)
+	if err != nil {
+		log.Fatalf("❌ %s: %v", name, err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: footballctl <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'footballctl <command> -h' for command flags.")
+}
+
+// loadEnv loads .env from the current directory or the project root.
+func loadEnv() {
+	_ = godotenv.Load()
+	_ = godotenv.Load("../.env")
+	_ = godotenv.Load("../../.env")
+}
+
+// openDB connects to DATABASE_URL.
+func openDB() (*sql.DB, error) {
+	return database.OpenFromEnv(context.Background())
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/yourusername/football-prediction/internal/ingest"
+)
+
+// runMinutes copies minutes played from stored lineups into
+// player_match_stats. Matches whose minutes change lose their fantasy points
+// so the next scoring run recomputes them with the new minutes.
+func runMinutes(args []string) error {
+	fs := flag.NewFlagSet("minutes", flag.ExitOnError)
+	fs.Parse(args)
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	updated, err := ingest.AggregateMinutes(db)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Updated minutes played for %d matches", updated)
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+	"github.com/yourusername/football-prediction/pkg/football"
+	"github.com/yourusername/football-prediction/pkg/keypool"
+)
+
+// runRecord captures live provider responses as fixtures the mock provider
+// (PROVIDER=mock) serves. Recording into the default directory refreshes the
+// fixtures embedded in the binaries.
+func runRecord(args []string) error {
+	fs := flag.NewFlagSet("record", flag.ExitOnError)
+	dir := fs.String("dir", "pkg/datasource/fixtures", "directory to write fixtures to")
+	codes := fs.String("competitions", "PL", "comma-separated competition codes")
+	season := fs.String("season", "2024", "season start year")
+	matches := fs.Int("matches", 5, "finished matches per competition to record in detail")
+	squads := fs.Bool("squads", false, "also record the squads of the teams in those matches")
+	fixtures := fs.String("fixtures", "", "comma-separated API-Football fixture IDs to record lineups, events, statistics and players of")
+	delay := fs.Duration("delay", 7*time.Second, "minimum pause between football-data.org requests")
+	fs.Parse(args)
+
+	loadEnv()
+
+	apiKey := os.Getenv("FOOTBALL_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("FOOTBALL_API_KEY not set")
+	}
+
+	recorder := datasource.NewRecorder(*dir, nil)
+	client := football.NewClient(keypool.Split(apiKey)...)
+	client.SetTransport(recorder)
+	client.SetMinInterval(*delay)
+
+	log.Printf("🎙️  Recording fixtures into %s", *dir)
+
+	if _, err := client.GetCompetitions(); err != nil {
+		return fmt.Errorf("competitions: %w", err)
+	}
+
+	for _, code := range strings.Split(*codes, ",") {
+		code = strings.TrimSpace(code)
+		resp, err := client.GetMatches(code, *season)
+		if err != nil {
+			return fmt.Errorf("%s %s matches: %w", code, *season, err)
+		}
+		if _, err := client.GetStandings(code, *season); err != nil {
+			return fmt.Errorf("%s %s standings: %w", code, *season, err)
+		}
+
+		recorded := 0
+		teams := map[int]bool{}
+		for _, m := range resp.Matches {
+			if recorded >= *matches {
+				break
+			}
+			if m.Status != "FINISHED" {
+				continue
+			}
+			if _, err := client.GetMatch(m.ID); err != nil {
+				return fmt.Errorf("match %d: %w", m.ID, err)
+			}
+			teams[m.HomeTeam.ID], teams[m.AwayTeam.ID] = true, true
+			recorded++
+		}
+
+		if *squads {
+			for id := range teams {
+				if _, err := client.GetTeamSquad(id); err != nil {
+					return fmt.Errorf("team %d squad: %w", id, err)
+				}
+			}
+		}
+		log.Printf("✅ %s %s: %d matches, %d recorded in detail", code, *season, len(resp.Matches), recorded)
+	}
+
+	if *fixtures != "" {
+		key := os.Getenv("API_FOOTBALL_KEY")
+		if key == "" {
+			return fmt.Errorf("API_FOOTBALL_KEY not set")
+		}
+		stats := apifootball.NewClient(keypool.Split(key)...)
+		stats.SetTransport(recorder)
+
+		for _, value := range strings.Split(*fixtures, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid fixture ID %q", value)
+			}
+			if _, err := stats.GetFixtureLineups(id); err != nil {
+				return fmt.Errorf("fixture %d lineups: %w", id, err)
+			}
+			if _, err := stats.GetFixtureEvents(id); err != nil {
+				return fmt.Errorf("fixture %d events: %w", id, err)
+			}
+			if _, err := stats.GetFixtureStatistics(id); err != nil {
+				return fmt.Errorf("fixture %d statistics: %w", id, err)
+			}
+			if _, err := stats.GetFixturePlayers(id); err != nil {
+				return fmt.Errorf("fixture %d players: %w", id, err)
+			}
+		}
+	}
+
+	log.Printf("✅ Recorded %d fixtures", len(recorder.Saved()))
+	return nil
+}
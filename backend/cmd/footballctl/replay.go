@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/ingest"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// runReplay re-processes provider responses archived by ingest runs with
+// RAW_ARCHIVE=true through the current parsers, to recover from a schema or
+// parser change, or to rebuild the data as it was known at a point in time,
+// without spending API quota. The latest response archived for each
+// endpoint is replayed, in the order they were fetched.
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	source := fs.String("source", "", "only replay responses from this data source, e.g. football-data")
+	endpoint := fs.String("endpoint", "", "only replay endpoints starting with this, e.g. /v4/competitions/PL/")
+	asOf := fs.String("as-of", "", "replay the responses archived up to this time, RFC 3339 or YYYY-MM-DD (default now)")
+	dryRun := fs.Bool("dry-run", false, "list the responses that would be replayed without saving anything")
+	fs.Parse(args)
+
+	until := time.Now().UTC()
+	if *asOf != "" {
+		t, err := time.Parse(time.RFC3339, *asOf)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, *asOf); err != nil {
+				return fmt.Errorf("invalid -as-of %q", *asOf)
+			}
+			// A date includes the whole day
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		until = t.UTC()
+	}
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	responses, err := repository.NewRawResponseRepository(db).ListLatest(*source, *endpoint, until)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🔁 Replaying %d archived responses up to %s", len(responses), until.Format(time.RFC3339))
+
+	saved, replayed, skipped, failed := 0, 0, 0, 0
+	for _, r := range responses {
+		if *dryRun {
+			fmt.Printf("%-14s %-60s %s %8d bytes\n", r.Source, r.Endpoint, r.FetchedAt.Format(time.RFC3339), len(r.Body))
+			continue
+		}
+
+		n, err := ingest.ReplayArchived(db, r)
+		saved += n
+		switch {
+		case errors.Is(err, ingest.ErrNotReplayable):
+			skipped++
+		case err != nil:
+			failed++
+			log.Printf("❌ %s %s: %v", r.Source, r.Endpoint, err)
+		default:
+			replayed++
+		}
+	}
+	if *dryRun {
+		return nil
+	}
+
+	log.Printf("✅ Replay complete: %d responses replayed saving %d rows, %d without a parser, %d failed", replayed, saved, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d responses failed to replay", failed)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/yourusername/football-prediction/internal/ingest"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// runRetryFailures re-processes rows from the ingest_failures dead-letter
+// table, resolving those that now save successfully.
+func runRetryFailures(args []string) error {
+	fs := flag.NewFlagSet("retry-failures", flag.ExitOnError)
+	entityType := fs.String("type", "", "only retry this entity type (competition, team, match, player_stats, match_event)")
+	limit := fs.Int("limit", 500, "maximum failures to retry")
+	maxAttempts := fs.Int("max-attempts", 10, "skip failures that already failed this many times")
+	statsOnly := fs.Bool("stats", false, "print failure counts without retrying")
+	fs.Parse(args)
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := repository.NewIngestFailureRepository(db)
+
+	if *statsOnly {
+		counts, err := repo.Counts()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-14s %8s %8s %8s\n", "TYPE", "OPEN", "RESOLVED", "ATTEMPTS")
+		for _, c := range counts {
+			fmt.Printf("%-14s %8d %8d %8d\n", c.EntityType, c.Open, c.Resolved, c.Attempts)
+		}
+		return nil
+	}
+
+	failures, err := repo.ListOpen(*entityType, *maxAttempts, *limit)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🔁 Retrying %d failed ingest rows", len(failures))
+
+	// Competitions and teams first so matches referencing them can succeed.
+	order := []string{ingest.EntityCompetition, ingest.EntityTeam, ingest.EntityMatch, ingest.EntityPlayerStats, ingest.EntityMatchEvent, ingest.EntityTeamStats, ingest.EntityLineup, ingest.EntityPlayerRatings}
+
+	resolved, stillFailing := 0, 0
+	for _, t := range order {
+		for _, f := range failures {
+			if f.EntityType != t {
+				continue
+			}
+
+			if err := ingest.Replay(db, f.EntityType, f.Payload); err != nil {
+				stillFailing++
+				log.Printf("❌ %s %s: %v", f.EntityType, f.ExternalRef, err)
+				if err := repo.MarkRetryFailed(f.ID, err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			resolved++
+			if err := repo.MarkResolved(f.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("✅ Retry complete: %d resolved, %d still failing", resolved, stillFailing)
+	return nil
+}
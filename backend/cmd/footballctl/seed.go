@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/yourusername/football-prediction/internal/seed"
+)
+
+// runSeed loads a curated demo dataset, or purges all seeded data with
+// --clean. Seeded rows are flagged is_synthetic.
+func runSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	dataset := fs.String("dataset", "demo-league", "dataset to load")
+	seedValue := fs.Int64("seed", 1, "random seed for generated results; the same seed reproduces the same data")
+	clean := fs.Bool("clean", false, "delete all seeded data instead of loading")
+	list := fs.Bool("list", false, "list available datasets and stored synthetic rows")
+	fs.Parse(args)
+
+	if *list {
+		names, err := seed.List()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			ds, err := seed.Load(name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%-16s %s\n", name, ds.Description)
+		}
+	}
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if *list {
+		count, err := seed.Count(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nStored synthetic rows: %d competitions, %d teams, %d players, %d matches\n",
+			count.Competitions, count.Teams, count.Players, count.Matches)
+		return nil
+	}
+
+	if *clean {
+		removed, err := seed.Purge(db)
+		if err != nil {
+			return err
+		}
+		log.Printf("🧹 Removed %d competitions, %d teams, %d players, %d matches of synthetic data",
+			removed.Competitions, removed.Teams, removed.Players, removed.Matches)
+		return nil
+	}
+
+	ds, err := seed.Load(*dataset)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🌱 Seeding %s (seed %d)", ds.Name, *seedValue)
+	summary, err := seed.Apply(db, ds, *seedValue)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Seeded %d teams, %d players, %d matches, %d goals into %s (%s)",
+		summary.Teams, summary.Players, summary.Matches, summary.Goals, ds.Competition.Name, ds.Competition.Code)
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// venueColumns is the header expected of a venues CSV file.
+var venueColumns = []string{"name", "city", "country", "latitude", "longitude"}
+
+// runVenues loads stadium coordinates from a CSV file with the columns
+// name,city,country,latitude,longitude, names spelt as the provider gives
+// match and team venues, then computes the travel distance of every match
+// the new coordinates cover.
+func runVenues(args []string) error {
+	fs := flag.NewFlagSet("venues", flag.ExitOnError)
+	file := fs.String("file", "", "CSV file of venue coordinates (required)")
+	recompute := fs.Bool("recompute", false, "recompute the travel distance of every match, not just those without one")
+	fs.Parse(args)
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	venues, err := readVenues(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+
+	loadEnv()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	travel := service.NewTravelService(db)
+	if err := travel.ImportVenues(venues); err != nil {
+		return err
+	}
+	log.Printf("📍 Stored %d venues", len(venues))
+
+	updated, err := travel.Refresh(*recompute)
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Computed travel distance for %d matches", updated)
+	return nil
+}
+
+func readVenues(r io.Reader) ([]repository.Venue, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(venueColumns)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range venueColumns {
+		if strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return nil, fmt.Errorf("expected header %s", strings.Join(venueColumns, ","))
+		}
+	}
+
+	var venues []repository.Venue
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return venues, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		lat, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("venue %q: bad latitude %q", record[0], record[3])
+		}
+		lon, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("venue %q: bad longitude %q", record[0], record[4])
+		}
+		venues = append(venues, repository.Venue{
+			Name:      strings.TrimSpace(record[0]),
+			City:      strings.TrimSpace(record[1]),
+			Country:   strings.TrimSpace(record[2]),
+			Latitude:  lat,
+			Longitude: lon,
+		})
+	}
+}
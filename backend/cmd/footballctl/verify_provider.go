@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/contract"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// runVerifyProvider calls the provider endpoints the stack depends on and
+// checks their responses against our models, or with --recorded checks the
+// fixtures the mock provider serves. It fails when any response has drifted
+// from the model it is decoded into.
+func runVerifyProvider(args []string) error {
+	fs := flag.NewFlagSet("verify-provider", flag.ExitOnError)
+	code := fs.String("competition", "PL", "competition whose endpoints to call")
+	season := fs.String("season", "2024", "season start year")
+	fixture := fs.Int("fixture", 0, "API-Football fixture ID to check the fixture endpoints with (needs API_FOOTBALL_KEY)")
+	recorded := fs.Bool("recorded", false, "check the recorded fixtures (MOCK_FIXTURES_DIR, or the built-in ones) instead of calling the providers")
+	fs.Parse(args)
+
+	loadEnv()
+
+	var results []contract.Result
+	var err error
+	if *recorded {
+		results, err = verifyRecorded()
+	} else {
+		results, err = verifyLive(*code, *season, *fixture)
+	}
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.OK() {
+			log.Printf("✅ %s %s: %s", r.Provider, r.Schema, r.Path)
+			continue
+		}
+		failed++
+		log.Printf("❌ %s %s: %s", r.Provider, r.Schema, r.Path)
+		if r.Err != nil {
+			log.Printf("      %v", r.Err)
+		}
+		for _, m := range r.Mismatches {
+			log.Printf("      %s", m)
+		}
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("no responses checked")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d responses do not match our models", failed, len(results))
+	}
+	log.Printf("✅ All %d responses match our models", len(results))
+	return nil
+}
+
+// verifyLive exercises the endpoints through the configured provider,
+// PROVIDER=mock included, checking what comes back.
+func verifyLive(code, season string, fixture int) ([]contract.Result, error) {
+	source, err := datasource.Open(os.Getenv("PROVIDER"), os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		return nil, err
+	}
+	apiKey := os.Getenv("FOOTBALL_API_KEY")
+	if apiKey == "" && !source.Mock() {
+		return nil, fmt.Errorf("FOOTBALL_API_KEY not set")
+	}
+
+	verifier := contract.NewVerifier(source.Transport())
+	client := football.NewClient(source.Keys(apiKey)...)
+	client.SetTransport(verifier)
+
+	// Decoding errors are reported by the checks, so calls carry on past them
+	report := func(what string, err error) {
+		if err != nil {
+			log.Printf("⚠️  %s: %v", what, err)
+		}
+	}
+
+	_, err = client.GetCompetitions()
+	report("competitions", err)
+	matches, err := client.GetMatches(code, season)
+	report("matches", err)
+	_, err = client.GetStandings(code, season)
+	report("standings", err)
+	if matches != nil {
+		for _, m := range matches.Matches {
+			if m.Status != "FINISHED" {
+				continue
+			}
+			_, err = client.GetMatch(m.ID)
+			report("match", err)
+			_, err = client.GetTeamSquad(m.HomeTeam.ID)
+			report("team", err)
+			break
+		}
+	}
+
+	if fixture > 0 {
+		key := os.Getenv("API_FOOTBALL_KEY")
+		if key == "" && !source.Mock() {
+			return nil, fmt.Errorf("API_FOOTBALL_KEY not set")
+		}
+		stats := apifootball.NewClient(source.Keys(key)...)
+		stats.SetTransport(verifier)
+		_, err = stats.GetFixtureLineups(fixture)
+		report("fixture lineups", err)
+		_, err = stats.GetFixtureEvents(fixture)
+		report("fixture events", err)
+		_, err = stats.GetFixtureStatistics(fixture)
+		report("fixture statistics", err)
+		_, err = stats.GetFixturePlayers(fixture)
+		report("fixture players", err)
+	}
+
+	return verifier.Results(), nil
+}
+
+// verifyRecorded checks every fixture of a known endpoint.
+func verifyRecorded() ([]contract.Result, error) {
+	source, err := datasource.Open(datasource.Mock, os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []contract.Result
+	err = fs.WalkDir(source.Fixtures(), ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		body, err := fs.ReadFile(source.Fixtures(), path)
+		if err != nil {
+			return err
+		}
+		if r, ok := contract.CheckFixture(path, body); ok {
+			results = append(results, r)
+		} else {
+			log.Printf("⚠️  %s: no schema for this endpoint", path)
+		}
+		return nil
+	})
+	return results, err
+}
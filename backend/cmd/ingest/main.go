@@ -1,18 +1,30 @@
 package main
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
+	"errors"
+	"flag"
 	"log"
 	"os"
 	"time"
 
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/yourusername/football-prediction/internal/database"
+	"github.com/yourusername/football-prediction/internal/ingest"
+	"github.com/yourusername/football-prediction/internal/jobs"
+	"github.com/yourusername/football-prediction/internal/lifecycle"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+	"github.com/yourusername/football-prediction/pkg/events"
 	"github.com/yourusername/football-prediction/pkg/football"
 )
 
 func main() {
+	workers := flag.Int("workers", 4, "concurrent database writers")
+	delay := flag.Duration("delay", 7*time.Second, "minimum pause between API requests (free tier allows 10/min)")
+	flag.Parse()
+
 	// Load environment variables from project root
 	if err := godotenv.Load("../.env"); err != nil {
 		// Try two levels up
@@ -21,32 +33,44 @@ func main() {
 		}
 	}
 
+	// PROVIDER=mock ingests recorded fixtures instead of calling the API
+	source, err := datasource.Open(os.Getenv("PROVIDER"), os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Get API key
 	apiKey := os.Getenv("FOOTBALL_API_KEY")
-	if apiKey == "" {
+	if apiKey == "" && !source.Mock() {
 		log.Fatal("FOOTBALL_API_KEY not set")
 	}
 
 	// Connect to database
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL not set")
-	}
-
-	db, err := sql.Open("postgres", dbURL)
+	db, err := database.OpenFromEnv(context.Background())
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 	defer db.Close()
 
-	if err := db.Ping(); err != nil {
-		log.Fatal("Failed to ping database:", err)
-	}
-
 	log.Println("✅ Connected to database")
 
-	// Create API client
-	client := football.NewClient(apiKey)
+	// Create API client, sharing the key's request budget with the API
+	quotaLimits, err := service.ParseProviderQuotas(os.Getenv("PROVIDER_QUOTAS"))
+	if err != nil {
+		log.Printf("⚠️  Ignoring invalid provider quotas: %v", err)
+	}
+	quotas := service.NewProviderQuotas(db, quotaLimits)
+	client := football.NewClient(source.Keys(apiKey)...)
+	client.SetTransport(source.Transport())
+	client.SetMinInterval(source.MinInterval(*delay))
+	client.SetQuota(source.Quota(quotas))
+
+	// Responses are kept for 'footballctl replay' when RAW_ARCHIVE=true
+	var archive *datasource.Archive
+	if os.Getenv("RAW_ARCHIVE") == "true" && !source.Mock() {
+		archive = ingest.ArchiveTransport(db, football.Provider, source.Transport())
+		client.SetTransport(archive)
+	}
 
 	// Competitions to ingest with their respective seasons
 	// Club competitions: PL (Premier League), PD (La Liga), BL1 (Bundesliga), SA (Serie A), FL1 (Ligue 1), CL (Champions League)
@@ -67,169 +91,87 @@ func main() {
 		{Code: "EC", Seasons: []string{"2024"}},
 	}
 
-	log.Println("🚀 Starting data ingestion...")
-
+	var seasons []ingest.SeasonJob
 	for _, comp := range competitions {
 		for _, season := range comp.Seasons {
-			log.Printf("📥 Fetching %s season %s...", comp.Code, season)
-
-			// Fetch matches with retry on rate limit
-			var matches *football.MatchesResponse
-			var err error
-
-			for retries := 0; retries < 3; retries++ {
-				matches, err = client.GetMatches(comp.Code, season)
-				if err == nil {
-					break
-				}
-
-				// Check if it's a rate limit error
-				if err != nil && (err.Error() == "API error (status 429)" ||
-					err.Error() == "failed to parse response: json: cannot unmarshal number into Go struct field .filters.season of type string") {
-					log.Printf("⏳ Rate limit hit, waiting 60 seconds...")
-					time.Sleep(60 * time.Second)
-					continue
-				}
-
-				log.Printf("❌ Error fetching %s %s: %v", comp.Code, season, err)
-				break
-			}
-
-			if err != nil {
-				continue
-			}
-
-			if matches == nil || len(matches.Matches) == 0 {
-				log.Printf("⚠️  No matches found for %s %s", comp.Code, season)
-				time.Sleep(7 * time.Second)
-				continue
-			}
-
-			// Save competition
-			if err := saveCompetition(db, &matches.Competition); err != nil {
-				log.Printf("❌ Error saving competition: %v", err)
-				continue
-			}
-
-			// Save matches
-			saved := 0
-			for _, match := range matches.Matches {
-				if err := saveMatch(db, &match); err != nil {
-					log.Printf("❌ Error saving match %d: %v", match.ID, err)
-					continue
-				}
-				saved++
-			}
-
-			log.Printf("✅ Saved %d/%d matches for %s %s", saved, len(matches.Matches), comp.Code, season)
-
-			// Rate limiting - API allows 10 req/min
-			time.Sleep(7 * time.Second)
+			seasons = append(seasons, ingest.SeasonJob{CompetitionCode: comp.Code, Season: season})
 		}
 	}
 
-	log.Println("🎉 Data ingestion complete!")
-}
-
-func saveCompetition(db *sql.DB, comp *football.Competition) error {
-	query := `
-		INSERT INTO competitions (external_id, name, code, area_name, current_season_start_date, current_season_end_date)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (external_id) DO UPDATE
-		SET name = EXCLUDED.name,
-		    code = EXCLUDED.code,
-		    area_name = EXCLUDED.area_name,
-		    updated_at = CURRENT_TIMESTAMP
-	`
-
-	var startDate, endDate *string
-	if comp.CurrentSeason != nil {
-		startDate = &comp.CurrentSeason.StartDate
-		endDate = &comp.CurrentSeason.EndDate
+	// The current seasons of competitions with followed fixtures in the
+	// coming week are fetched first, at high priority
+	interest, err := repository.NewFollowRepository(db).UpcomingInterest(7 * 24 * time.Hour)
+	if err != nil {
+		log.Printf("⚠️  Fetching seasons in the configured order: %v", err)
 	}
-
-	_, err := db.Exec(query, comp.ID, comp.Name, comp.Code, comp.Area.Name, startDate, endDate)
-	return err
-}
-
-func saveMatch(db *sql.DB, match *football.Match) error {
-	// Save home team
-	if err := saveTeam(db, &match.HomeTeam); err != nil {
-		return fmt.Errorf("failed to save home team: %w", err)
+	seasons = ingest.Prioritize(seasons, interest)
+
+	// SIGINT or SIGTERM stops fetching; fetched matches are still saved
+	lc := lifecycle.New(context.Background())
+
+	// Announce ingested matches to the API's consumers. Without a shared
+	// broker there is no one in this process to hear them.
+	var publisher events.Publisher
+	if url := os.Getenv("EVENT_BROKER_URL"); url != "" {
+		broker, err := events.Open(url)
+		if err != nil {
+			log.Fatal("Failed to connect to event broker:", err)
+		}
+		lc.OnShutdown("event broker", func(context.Context) error {
+			return broker.Close()
+		})
+		publisher = broker
 	}
 
-	// Save away team
-	if err := saveTeam(db, &match.AwayTeam); err != nil {
-		return fmt.Errorf("failed to save away team: %w", err)
+	log.Println("🚀 Starting data ingestion...")
+
+	pipeline := &ingest.Pipeline{
+		DB:                db,
+		Client:            client,
+		Workers:           *workers,
+		MaxRateLimitWaits: 20,
+		Events:            publisher,
+		OnResult: func(r ingest.SeasonResult) {
+			switch {
+			case r.Err != nil:
+				log.Printf("❌ Error ingesting %s %s: %v", r.Job.CompetitionCode, r.Job.Season, r.Err)
+			case r.Matches == 0:
+				log.Printf("⚠️  No matches found for %s %s", r.Job.CompetitionCode, r.Job.Season)
+			default:
+				log.Printf("✅ Saved %d/%d matches for %s %s", r.Saved, r.Matches, r.Job.CompetitionCode, r.Job.Season)
+			}
+		},
 	}
 
-	// Save match
-	query := `
-		INSERT INTO matches (
-			external_id, competition_id, season, home_team_id, away_team_id,
-			utc_date, status, matchday, home_score, away_score, winner
-		)
-		SELECT $1, c.id, $2, ht.id, at.id, $3, $4, $5, $6, $7, $8
-		FROM competitions c
-		CROSS JOIN teams ht
-		CROSS JOIN teams at
-		WHERE c.external_id = $9
-		  AND ht.external_id = $10
-		  AND at.external_id = $11
-		ON CONFLICT (external_id) DO UPDATE
-		SET status = EXCLUDED.status,
-		    home_score = EXCLUDED.home_score,
-		    away_score = EXCLUDED.away_score,
-		    winner = EXCLUDED.winner,
-		    updated_at = CURRENT_TIMESTAMP
-	`
-
-	var homeScore, awayScore *int
-	if match.Score.FullTime.Home != nil {
-		homeScore = match.Score.FullTime.Home
+	// Ingest runs on one host at a time, as they share the API rate limit
+	err = jobs.RunLocked(lc.Context(), repository.NewJobLockRepository(db), "ingest", jobs.InstanceID(), time.Minute,
+		func(ctx context.Context) error {
+			metrics, err := pipeline.Run(ctx, seasons)
+			log.Printf("📊 %s", metrics)
+			if archive != nil {
+				s := archive.Stats()
+				log.Printf("🗄️  Archived %d responses for replay", s.Archived)
+				if s.Failed > 0 {
+					log.Printf("⚠️  Failed to archive %d responses: %s", s.Failed, s.LastError)
+				}
+			}
+			if keys := client.KeyStats(); len(keys) > 1 {
+				for _, k := range keys {
+					log.Printf("🔑 Key %s: %d requests, %d rate limited, %d forbidden, %d cooldowns",
+						k.Key, k.Requests, k.RateLimited, k.Forbidden, k.Cooldowns)
+				}
+			}
+			return err
+		})
+	if shutdownErr := lc.Shutdown(10 * time.Second); shutdownErr != nil {
+		log.Printf("⚠️  Shutdown incomplete: %v", shutdownErr)
 	}
-	if match.Score.FullTime.Away != nil {
-		awayScore = match.Score.FullTime.Away
+	if errors.Is(err, jobs.ErrLockHeld) {
+		log.Fatal("❌ Another ingest is already running")
 	}
-
-	var winner *string
-	if match.Score.Winner != "" {
-		winner = &match.Score.Winner
+	if err != nil {
+		log.Fatalf("❌ Ingestion stopped: %v", err)
 	}
 
-	// Get season from match
-	season := fmt.Sprintf("%d", match.Season.ID)
-
-	_, err := db.Exec(
-		query,
-		match.ID,             // $1 external_id
-		season,               // $2 season
-		match.UtcDate,        // $3 utc_date
-		match.Status,         // $4 status
-		match.Matchday,       // $5 matchday
-		homeScore,            // $6 home_score
-		awayScore,            // $7 away_score
-		winner,               // $8 winner
-		match.Competition.ID, // $9 competition external_id
-		match.HomeTeam.ID,    // $10 home_team external_id
-		match.AwayTeam.ID,    // $11 away_team external_id
-	)
-
-	return err
-}
-
-func saveTeam(db *sql.DB, team *football.Team) error {
-	query := `
-		INSERT INTO teams (external_id, name, short_name, tla, crest_url)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (external_id) DO UPDATE
-		SET name = EXCLUDED.name,
-		    short_name = EXCLUDED.short_name,
-		    tla = EXCLUDED.tla,
-		    crest_url = EXCLUDED.crest_url,
-		    updated_at = CURRENT_TIMESTAMP
-	`
-
-	_, err := db.Exec(query, team.ID, team.Name, team.ShortName, team.TLA, team.Crest)
-	return err
+	log.Println("🎉 Data ingestion complete!")
 }
@@ -1,19 +1,34 @@
 package main
 
 import (
-	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
 	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/yourusername/football-prediction/migrations"
 )
 
+const usage = `Usage: migrate [--steps N | --to VERSION] [up|down|version]
+
+Migrations are embedded in the binary, so it can be run from any directory.
+
+  up        apply all pending migrations, or N steps / up to VERSION
+  down      roll back all migrations, or N steps / down to VERSION
+  version   print the current schema version`
+
 func main() {
+	steps := flag.Int("steps", 0, "number of migrations to apply (up) or roll back (down)")
+	to := flag.Int("to", -1, "migrate up or down to this exact version")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
 	// Load .env file from project root
 	if err := godotenv.Load(); err != nil {
 		// Try parent directory
@@ -31,50 +46,52 @@ func main() {
 		log.Fatal("DATABASE_URL environment variable not set")
 	}
 
-	// Connect to database
-	db, err := sql.Open("postgres", dbURL)
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
 	}
-	defer db.Close()
-
-	// Test connection
-	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	if *steps < 0 {
+		log.Fatal("--steps must be positive; use the down command to roll back")
 	}
-
-	// Create postgres driver instance
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		log.Fatalf("Failed to create driver: %v", err)
+	if *steps > 0 && *to >= 0 {
+		log.Fatal("--steps and --to cannot be combined")
 	}
 
-	// Create migrate instance
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"postgres",
-		driver,
-	)
+	m, err := migrations.New(dbURL)
 	if err != nil {
-		log.Fatalf("Failed to create migrate instance: %v", err)
-	}
-
-	// Get command from args
-	if len(os.Args) < 2 {
-		log.Fatal("Usage: go run main.go [up|down|version]")
+		log.Fatalf("%v", err)
 	}
+	defer m.Close()
 
-	command := os.Args[1]
+	command := flag.Arg(0)
 
 	switch command {
 	case "up":
-		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		var err error
+		switch {
+		case *to >= 0:
+			err = m.Migrate(uint(*to))
+		case *steps > 0:
+			err = m.Steps(*steps)
+		default:
+			err = m.Up()
+		}
+		if err != nil && !errors.Is(err, migrate.ErrNoChange) {
 			log.Fatalf("Migration up failed: %v", err)
 		}
 		fmt.Println("✅ Migrations applied successfully")
 
 	case "down":
-		if err := m.Down(); err != nil && err != migrate.ErrNoChange {
+		var err error
+		switch {
+		case *to >= 0:
+			err = m.Migrate(uint(*to))
+		case *steps > 0:
+			err = m.Steps(-*steps)
+		default:
+			err = m.Down()
+		}
+		if err != nil && !errors.Is(err, migrate.ErrNoChange) {
 			log.Fatalf("Migration down failed: %v", err)
 		}
 		fmt.Println("✅ Migrations rolled back successfully")
@@ -1,216 +1,688 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/yourusername/football-prediction/internal/database"
+	"github.com/yourusername/football-prediction/internal/ingest"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/breaker"
+	"github.com/yourusername/football-prediction/pkg/datasource"
 	"github.com/yourusername/football-prediction/pkg/football"
 )
 
-// This command ingests player goal/assist data from football-data.org
-// for recent finished matches into the local Postgres database.
-// Uses the FREE tier goals endpoint which includes scorer and assist information.
+// This command ingests player goal/assist data from football-data.org for
+// finished matches into the local Postgres database. It uses the FREE tier
+// match endpoint, which includes scorer and assist information.
+//
+// Every finished match is checkpointed in player_ingest_state, so each run
+// works through whatever backlog is left, in batches, and an interrupted run
+// resumes where it stopped. Failed matches are retried by later runs up to
+// -max-attempts times.
+
+// defaultRateLimitWait is used when a 429 response carries no reset header.
+const defaultRateLimitWait = 60 * time.Second
 
 func main() {
+	competition := flag.String("competition", "", "only ingest matches of this competition code (default: all)")
+	since := flag.String("since", "", "only ingest matches played on or after this date, YYYY-MM-DD (default: all)")
+	batchSize := flag.Int("batch-size", 25, "matches loaded from the backlog at a time")
+	limit := flag.Int("limit", 0, "stop after this many matches (default: whole backlog)")
+	delay := flag.Duration("delay", 7*time.Second, "pause between matches (free tier allows 10 requests/min)")
+	maxAttempts := flag.Int("max-attempts", 3, "stop retrying a match after this many failed attempts")
+	maxWaits := flag.Int("max-rate-limit-waits", 20, "give up after this many rate-limit pauses")
+	statusOnly := flag.Bool("status", false, "print backlog status without fetching")
+	flag.Parse()
+
+	if *batchSize < 1 {
+		log.Fatal("-batch-size must be at least 1")
+	}
+
+	filter := repository.PlayerIngestFilter{CompetitionCode: strings.ToUpper(strings.TrimSpace(*competition))}
+	if *since != "" {
+		t, err := time.Parse("2006-01-02", *since)
+		if err != nil {
+			log.Fatalf("invalid -since %q: want YYYY-MM-DD", *since)
+		}
+		filter.Since = t
+	}
+
 	// Load .env
 	_ = godotenv.Load()
 	_ = godotenv.Load("../.env")
 	_ = godotenv.Load("../../.env")
 
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL not set")
+	db, err := database.OpenFromEnv(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPlayerIngestRepository(db)
+
+	if *statusOnly {
+		if err := printStatus(repo, filter); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// PROVIDER=mock ingests recorded fixtures, with no rate limit to keep to
+	source, err := datasource.Open(os.Getenv("PROVIDER"), os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		log.Fatal(err)
 	}
+	*delay = source.MinInterval(*delay)
 
 	apiKey := os.Getenv("FOOTBALL_DATA_API_KEY")
-	if apiKey == "" {
+	if apiKey == "" && !source.Mock() {
 		log.Fatal("FOOTBALL_DATA_API_KEY not set")
 	}
 
-	db, err := sql.Open("postgres", dbURL)
+	// Team statistics (possession, shots, cards) come from API-Football and
+	// are only ingested when its key is configured.
+	// Both keys' request budgets are shared with the API and other ingests
+	quotaLimits, err := service.ParseProviderQuotas(os.Getenv("PROVIDER_QUOTAS"))
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		log.Printf("⚠️  Ignoring invalid provider quotas: %v", err)
 	}
-	defer db.Close()
-
-	if err := db.Ping(); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	quotas := service.NewProviderQuotas(db, quotaLimits)
+	// An upstream that keeps failing is given a rest instead of every match
+	// waiting out its timeouts
+	breakers := breaker.NewSet(breaker.Config{})
+	// Responses are kept for 'footballctl replay' when RAW_ARCHIVE=true
+	archive := os.Getenv("RAW_ARCHIVE") == "true" && !source.Mock()
+	transport := func(provider string) http.RoundTripper {
+		rt := source.Transport()
+		if archive {
+			rt = ingest.ArchiveTransport(db, provider, rt)
+		}
+		return breaker.Transport(breakers.Get(provider), rt)
+	}
+	client := football.NewClient(source.Keys(apiKey)...)
+	client.SetTransport(transport(football.Provider))
+	client.SetQuota(source.Quota(quotas))
+	var statsClient *apifootball.Client
+	if key := os.Getenv("API_FOOTBALL_KEY"); key != "" {
+		statsClient = apifootball.NewClient(source.Keys(key)...)
+		statsClient.SetTransport(transport(apifootball.Provider))
+		statsClient.SetQuota(source.Quota(quotas))
+		if source.Mock() {
+			statsClient.SetLimits(apifootball.Limits{})
+		}
 	}
 
-	client := football.NewClient(apiKey)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Per-match writes reuse statements prepared once for the whole run
+	stmts := ingest.NewStmtCache(db)
+	defer stmts.Close()
+
+	in := &ingester{
+		db:          db,
+		stmts:       stmts,
+		repo:        repo,
+		client:      client,
+		statsClient: statsClient,
+		mappings:    service.NewEntityMappingService(db),
+		maxWaits:    *maxWaits,
+	}
 
 	fmt.Println("🔄 Starting player data ingestion...")
 	fmt.Println("   📊 Using football-data.org goals data (FREE tier)")
-	fmt.Println("   ⚠️  Rate limit: 10 requests/minute")
-	fmt.Println("   Fetching recent finished matches from database...")
-
-	// Get recent finished matches from last 30 days
-	// Limit to 10 matches to respect rate limits
-	cutoffDate := time.Now().AddDate(0, 0, -30)
-	rows, err := db.Query(`
-        SELECT m.id, m.external_id, m.home_team_id, m.away_team_id
-        FROM matches m
-        WHERE m.status = 'FINISHED' 
-          AND m.utc_date >= $1
-          AND m.utc_date < NOW()
-        ORDER BY m.utc_date DESC
-        LIMIT 10
-    `, cutoffDate)
+	fmt.Printf("   ⚠️  Rate limit: 10 requests/minute, pausing %s between matches\n", *delay)
+
+	runStarted := time.Now()
+	processed, done, failed := 0, 0, 0
+
+run:
+	for {
+		size := *batchSize
+		if *limit > 0 {
+			if processed >= *limit {
+				break
+			}
+			size = min(size, *limit-processed)
+		}
+
+		batch, err := repo.Backlog(filter, *maxAttempts, runStarted, size)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, match := range batch {
+			processed++
+			fmt.Printf("   [%d] Processing %s match %d (%s)...\n",
+				processed, match.CompetitionCode, match.ExternalID, match.UtcDate.Format("2006-01-02"))
+
+			goals, err := in.ingestMatch(ctx, match)
+			switch {
+			case errors.Is(err, context.Canceled):
+				// Nothing recorded; the match stays in the backlog.
+				processed--
+				break run
+			case err != nil:
+				failed++
+				log.Printf("❌ Match %d: %v", match.ExternalID, err)
+				if err := repo.MarkFailed(match.ID, err.Error()); err != nil {
+					log.Fatal(err)
+				}
+			default:
+				done++
+				if err := repo.MarkDone(match.ID, goals); err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			if err := sleepCtx(ctx, *delay); err != nil {
+				break run
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		fmt.Println("\n⏸️  Interrupted, progress saved; run again to resume")
+	}
+	if updated, err := ingest.AggregateMinutes(db); err != nil {
+		log.Printf("⚠️  %v", err)
+	} else if updated > 0 {
+		fmt.Printf("   ⏱️  Updated minutes played for %d matches\n", updated)
+	}
+	fmt.Printf("\n✅ Player ingestion finished!\n")
+	fmt.Printf("   Processed: %d matches (%d done, %d failed)\n", processed, done, failed)
+	if failed > 0 {
+		fmt.Printf("   Failed matches are retried on the next run (up to %d attempts)\n", *maxAttempts)
+	}
+	if err := printStatus(repo, filter); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+}
+
+type ingester struct {
+	db          *sql.DB
+	stmts       *ingest.StmtCache
+	repo        *repository.PlayerIngestRepository
+	client      *football.Client
+	statsClient *apifootball.Client
+	mappings    *service.EntityMappingService
+	maxWaits    int
+	waits       int
+}
+
+// ingestMatch stores the goals, assists, events and lineups of one match,
+// and its team statistics and player ratings when API-Football is
+// configured. It returns the
+// number of goals the provider reported.
+func (in *ingester) ingestMatch(ctx context.Context, match repository.PlayerIngestMatch) (int, error) {
+	if in.statsClient != nil {
+		if err := ingestTeamStats(in.db, in.stmts, in.statsClient, in.mappings, match); err != nil {
+			log.Printf("⚠️  Failed to ingest team statistics: %v", err)
+		}
+		if err := ingestPlayerRatings(in.db, in.stmts, in.statsClient, in.mappings, match); err != nil {
+			log.Printf("⚠️  Failed to ingest player ratings: %v", err)
+		}
+	}
+
+	details, err := in.fetchMatch(ctx, match.ExternalID)
 	if err != nil {
-		log.Fatalf("failed to query matches: %v", err)
+		return 0, err
+	}
+
+	// Record goal and card events used by fantasy scoring
+	if err := processMatchEvents(in.db, in.stmts, match.ID, match.HomeTeamID, match.AwayTeamID, details); err != nil {
+		log.Printf("⚠️  Failed to process events: %v", err)
 	}
-	defer rows.Close()
 
-	type matchRecord struct {
-		id         int
-		externalID int
-		homeTeamID int
-		awayTeamID int
+	// Lineups and substitutions, for minutes played
+	if err := in.ingestLineups(match, details); err != nil {
+		log.Printf("⚠️  Failed to ingest lineups: %v", err)
+	}
+
+	if len(details.Goals) == 0 {
+		fmt.Printf("      ⏭️  No goals in match\n")
+		return 0, nil
+	}
+
+	// Process goals and assists
+	if err := processMatchGoals(in.db, in.stmts, match.ID, match.HomeTeamID, match.AwayTeamID, details.Goals); err != nil {
+		return 0, fmt.Errorf("failed to process goals: %w", err)
+	}
+
+	return len(details.Goals), nil
+}
+
+// ingestLineups stores both teams' lineups with minutes played. They come
+// from football-data.org when the plan includes them, otherwise from
+// API-Football when its key is configured.
+func (in *ingester) ingestLineups(match repository.PlayerIngestMatch, details *football.Match) error {
+	home := ingest.TeamSheetFromMatch(match.ID, match.HomeTeamID, true, details)
+	away := ingest.TeamSheetFromMatch(match.ID, match.AwayTeamID, false, details)
+
+	var sheets []*ingest.TeamSheet
+	switch {
+	case home != nil && away != nil:
+		sheets = []*ingest.TeamSheet{home, away}
+	case in.statsClient != nil:
+		var err error
+		sheets, err = apiTeamSheets(in.statsClient, in.mappings, match, ingest.MatchLength(details.Score.Duration))
+		if err != nil {
+			return err
+		}
+	}
+	if len(sheets) == 0 {
+		return nil
 	}
 
-	var matches []matchRecord
-	for rows.Next() {
-		var m matchRecord
-		if err := rows.Scan(&m.id, &m.externalID, &m.homeTeamID, &m.awayTeamID); err != nil {
-			log.Printf("⚠️  Failed to scan match: %v", err)
+	saved := 0
+	for _, sheet := range sheets {
+		if err := ingest.SaveTeamSheet(in.db, sheet); err != nil {
+			ingest.RecordFailure(in.db, ingest.EntityLineup, fmt.Sprintf("%d:%d", match.ID, sheet.TeamID), sheet, err)
 			continue
 		}
-		matches = append(matches, m)
+		saved++
 	}
 
-	fmt.Printf("   Found %d finished matches to process\n", len(matches))
+	fmt.Printf("      ✅ Stored lineups for %d teams\n", saved)
+	return nil
+}
 
-	successCount := 0
-	skipCount := 0
+// apiTeamSheets builds team sheets from API-Football lineups and events.
+// Players are resolved to local players through entity mappings; those that
+// cannot be resolved are left out of the stored lineup.
+func apiTeamSheets(client *apifootball.Client, mappings *service.EntityMappingService, match repository.PlayerIngestMatch, matchLength int) ([]*ingest.TeamSheet, error) {
+	fixtureID, err := mappings.ResolveFixture(client, service.FixtureLookup{
+		MatchID:    match.ID,
+		HomeTeamID: match.HomeTeamID,
+		AwayTeamID: match.AwayTeamID,
+		Date:       match.UtcDate,
+	})
+	if err != nil || fixtureID == 0 {
+		return nil, err
+	}
 
-	for i, match := range matches {
-		fmt.Printf("   [%d/%d] Processing match %d...\n", i+1, len(matches), match.externalID)
+	lineups, err := client.GetFixtureLineups(fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lineups) == 0 {
+		return nil, nil
+	}
+	events, err := client.GetFixtureEvents(fixtureID)
+	if err != nil {
+		return nil, err
+	}
 
-		// Check if we already have player stats for this match
-		var existingCount int
-		err := db.QueryRow(`
-            SELECT COUNT(*) FROM player_match_stats WHERE match_id = $1
-        `, match.id).Scan(&existingCount)
+	var sheets []*ingest.TeamSheet
+	for i := range lineups {
+		team, err := mappings.ResolveTeam(service.ProviderAPIFootball, lineups[i].Team.ID, lineups[i].Team.Name)
 		if err != nil {
-			log.Printf("⚠️  Failed to check existing stats: %v", err)
+			return nil, err
+		}
+		if team == nil || (team.LocalID != match.HomeTeamID && team.LocalID != match.AwayTeamID) {
 			continue
 		}
 
-		if existingCount > 0 {
-			fmt.Printf("      ⏭️  Skipping (already have player stats)\n")
-			skipCount++
-			continue
+		sheet := ingest.TeamSheetFromAPI(match.ID, team.LocalID, team.LocalID == match.HomeTeamID, matchLength, &lineups[i], events)
+		resolve := func(players []ingest.SheetPlayer) ([]ingest.SheetPlayer, error) {
+			var resolved []ingest.SheetPlayer
+			for _, p := range players {
+				m, err := mappings.ResolvePlayer(service.ProviderAPIFootball, p.ExternalID, p.Name, team.LocalID)
+				if err != nil {
+					return nil, err
+				}
+				if m == nil {
+					continue
+				}
+				p.PlayerID = m.LocalID
+				resolved = append(resolved, p)
+			}
+			return resolved, nil
+		}
+		// Minutes are keyed by API-Football ID, so unresolved players
+		// drop out without affecting anyone else's.
+		if sheet.Starters, err = resolve(sheet.Starters); err != nil {
+			return nil, err
+		}
+		if sheet.Bench, err = resolve(sheet.Bench); err != nil {
+			return nil, err
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
+
+// fetchMatch fetches match details, pausing and retrying on rate limits.
+func (in *ingester) fetchMatch(ctx context.Context, externalID int) (*football.Match, error) {
+	for {
+		details, err := in.client.GetMatch(externalID)
+		if !football.IsRateLimited(err) {
+			return details, err
+		}
+
+		in.waits++
+		if in.waits > in.maxWaits {
+			return nil, fmt.Errorf("rate limited too many times: %w", err)
+		}
+
+		wait := defaultRateLimitWait
+		var apiErr *football.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter + time.Second
+		}
+
+		fmt.Printf("      ⏳ Rate limited, pausing %s...\n", wait)
+		if err := sleepCtx(ctx, wait); err != nil {
+			return nil, err
 		}
+	}
+}
+
+func printStatus(repo *repository.PlayerIngestRepository, filter repository.PlayerIngestFilter) error {
+	counts, err := repo.Counts(filter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("   Backlog: %d done, %d pending, %d failed\n",
+		counts[repository.PlayerIngestDone], counts[repository.PlayerIngestPending],
+		counts[repository.PlayerIngestFailed])
+	return nil
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// ingestTeamStats stores both teams' API-Football statistics for a match,
+// unless they are already stored.
+func ingestTeamStats(db *sql.DB, stmts sqlcdb.DBTX, client *apifootball.Client, mappings *service.EntityMappingService, match repository.PlayerIngestMatch) error {
+	q := sqlcdb.New(stmts)
+	existing, err := q.CountTeamMatchStatistics(context.Background(), match.ID)
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	fixtureID, err := mappings.ResolveFixture(client, service.FixtureLookup{
+		MatchID:    match.ID,
+		HomeTeamID: match.HomeTeamID,
+		AwayTeamID: match.AwayTeamID,
+		Date:       match.UtcDate,
+	})
+	if err != nil {
+		return err
+	}
+	if fixtureID == 0 {
+		fmt.Printf("      ⏭️  No API-Football fixture found for team statistics\n")
+		return nil
+	}
+
+	stats, err := client.GetFixtureStatistics(fixtureID)
+	if err != nil {
+		return err
+	}
 
-		// Fetch match details with goals from football-data.org
-		matchDetails, err := client.GetMatch(match.externalID)
+	saved := 0
+	for i := range stats {
+		team, err := mappings.ResolveTeam(service.ProviderAPIFootball, stats[i].Team.ID, stats[i].Team.Name)
 		if err != nil {
-			log.Printf("⚠️  Failed to fetch match %d: %v", match.externalID, err)
+			return err
+		}
+		if team == nil || (team.LocalID != match.HomeTeamID && team.LocalID != match.AwayTeamID) {
 			continue
 		}
 
-		if len(matchDetails.Goals) == 0 {
-			fmt.Printf("      ⏭️  No goals in match\n")
-			skipCount++
+		row := ingest.TeamMatchStatsFromAPI(match.ID, team.LocalID, &stats[i])
+		if err := ingest.SaveTeamMatchStats(stmts, row); err != nil {
+			ingest.RecordFailure(db, ingest.EntityTeamStats, fmt.Sprintf("%d:%d", match.ID, team.LocalID), row, err)
 			continue
 		}
+		saved++
+	}
+
+	fmt.Printf("      ✅ Stored team statistics for %d teams\n", saved)
+	return nil
+}
+
+// processMatchGoals tallies goals, penalties, own goals and assists per
+// player. Penalties count as goals; own goals are kept out of the scorer's
+// goals and only counted as own goals.
+// ingestPlayerRatings stores API-Football ratings, shots, passes, duels,
+// saves, tackles and interceptions for every player of the match that maps
+// to a local player, unless ratings are already stored. Clean sheets are
+// worked out from the goals each team's goalkeepers conceded.
+func ingestPlayerRatings(db *sql.DB, stmts sqlcdb.DBTX, client *apifootball.Client, mappings *service.EntityMappingService, match repository.PlayerIngestMatch) error {
+	existing, err := sqlcdb.New(stmts).CountRatedPlayerMatchStats(context.Background(), match.ID)
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	fixtureID, err := mappings.ResolveFixture(client, service.FixtureLookup{
+		MatchID:    match.ID,
+		HomeTeamID: match.HomeTeamID,
+		AwayTeamID: match.AwayTeamID,
+		Date:       match.UtcDate,
+	})
+	if err != nil || fixtureID == 0 {
+		return err
+	}
 
-		// Process goals and assists
-		if err := processMatchGoals(db, match.id, match.homeTeamID, match.awayTeamID, matchDetails.Goals); err != nil {
-			log.Printf("⚠️  Failed to process goals: %v", err)
+	teams, err := client.GetFixturePlayers(fixtureID)
+	if err != nil {
+		return err
+	}
+
+	saved, unmapped := 0, 0
+	for i := range teams {
+		team, err := mappings.ResolveTeam(service.ProviderAPIFootball, teams[i].Team.ID, teams[i].Team.Name)
+		if err != nil {
+			return err
+		}
+		if team == nil || (team.LocalID != match.HomeTeamID && team.LocalID != match.AwayTeamID) {
 			continue
 		}
 
-		successCount++
-		fmt.Printf("      ✅ Processed lineups\n")
+		conceded := ingest.TeamGoalsConceded(&teams[i])
+		for j := range teams[i].Players {
+			p := &teams[i].Players[j]
+			stats := p.Stats()
+			if stats == nil || stats.Games.Minutes == nil {
+				continue // did not play
+			}
+
+			player, err := mappings.ResolvePlayer(service.ProviderAPIFootball, p.Player.ID, p.Player.Name, team.LocalID)
+			if err != nil {
+				return err
+			}
+			if player == nil {
+				unmapped++
+				continue
+			}
 
-		// Rate limiting: 10 requests per minute
-		if i < len(matches)-1 {
-			fmt.Printf("      ⏳ Waiting 6 seconds (rate limit)...\n")
-			time.Sleep(6 * time.Second)
+			row := ingest.PlayerMatchRatingsFromAPI(match.ID, player.LocalID, stats, conceded)
+			if err := ingest.SavePlayerMatchRatings(stmts, row); err != nil {
+				ingest.RecordFailure(db, ingest.EntityPlayerRatings, fmt.Sprintf("%d:%d", match.ID, player.LocalID), row, err)
+				continue
+			}
+			saved++
 		}
 	}
 
-	fmt.Printf("\n✅ Player ingestion complete!\n")
-	fmt.Printf("   Processed: %d matches\n", successCount)
-	fmt.Printf("   Skipped: %d matches (already had data)\n", skipCount)
+	fmt.Printf("      ✅ Stored ratings for %d players (%d unmapped)\n", saved, unmapped)
+	return nil
 }
 
-func processMatchGoals(db *sql.DB, matchID, homeTeamID, awayTeamID int, goals []football.Goal) error {
-	// Build player stats map from goals
-	playerStats := make(map[int]struct {
-		goals   int
-		assists int
-		teamID  int
-		name    string
-	})
+func processMatchGoals(db *sql.DB, stmts sqlcdb.DBTX, matchID, homeTeamID, awayTeamID int, goals []football.Goal) error {
+	awayExtID, err := sqlcdb.New(stmts).GetTeamExternalIDByID(context.Background(), awayTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to look up away team: %w", err)
+	}
+
+	playerStats := make(map[int]*ingest.PlayerMatchStats)
+	line := func(player football.PlayerRef, teamID int) *ingest.PlayerMatchStats {
+		stats, ok := playerStats[player.ID]
+		if !ok {
+			stats = &ingest.PlayerMatchStats{
+				MatchID:          matchID,
+				TeamID:           teamID,
+				PlayerExternalID: player.ID,
+				PlayerName:       player.Name,
+			}
+			playerStats[player.ID] = stats
+		}
+		return stats
+	}
 
 	for _, goal := range goals {
-		// Determine team ID
+		// The team the scorer plays for, which for an own goal is the
+		// conceding team
 		teamID := homeTeamID
-		if goal.Team.ID != 0 {
-			// Check if it's away team
-			var awayExtID int
-			db.QueryRow(`SELECT external_id FROM teams WHERE id = $1`, awayTeamID).Scan(&awayExtID)
-			if goal.Team.ID == awayExtID {
-				teamID = awayTeamID
-			}
+		if goal.Team.ID != 0 && goal.Team.ID == awayExtID {
+			teamID = awayTeamID
 		}
 
-		// Count goal for scorer
 		if goal.Scorer.ID > 0 {
-			stats := playerStats[goal.Scorer.ID]
-			stats.goals++
-			stats.teamID = teamID
-			stats.name = goal.Scorer.Name
-			playerStats[goal.Scorer.ID] = stats
-		}
-
-		// Count assist
-		if goal.Assist != nil && goal.Assist.ID > 0 {
-			stats := playerStats[goal.Assist.ID]
-			stats.assists++
-			stats.teamID = teamID
-			stats.name = goal.Assist.Name
-			playerStats[goal.Assist.ID] = stats
-		}
-	}
-
-	// Insert players and stats
-	for extID, stats := range playerStats {
-		// Upsert player
-		var playerID int
-		err := db.QueryRow(`
-            INSERT INTO players (external_id, name, team_id)
-            VALUES ($1, $2, $3)
-            ON CONFLICT (external_id) DO UPDATE SET
-                name = EXCLUDED.name,
-                updated_at = NOW()
-            RETURNING id
-        `, extID, stats.name, stats.teamID).Scan(&playerID)
-		if err != nil {
-			log.Printf("⚠️  Failed to upsert player %s: %v", stats.name, err)
-			continue
+			stats := line(goal.Scorer, teamID)
+			switch goal.Type {
+			case football.GoalOwn:
+				stats.OwnGoals++
+			case football.GoalPenalty:
+				stats.Goals++
+				stats.Penalties++
+			default:
+				stats.Goals++
+			}
 		}
 
-		// Insert player match stats
-		_, err = db.Exec(`
-            INSERT INTO player_match_stats (match_id, player_id, goals, assists)
-            VALUES ($1, $2, $3, $4)
-            ON CONFLICT (match_id, player_id) DO UPDATE SET
-                goals = EXCLUDED.goals,
-                assists = EXCLUDED.assists
-        `, matchID, playerID, stats.goals, stats.assists)
-		if err != nil {
-			log.Printf("⚠️  Failed to insert player stats: %v", err)
+		if goal.Assist != nil && goal.Assist.ID > 0 && goal.Type != football.GoalOwn {
+			line(*goal.Assist, teamID).Assists++
+		}
+	}
+
+	for extID, row := range playerStats {
+		if err := ingest.SavePlayerMatchStats(stmts, row); err != nil {
+			ingest.RecordFailure(db, ingest.EntityPlayerStats, fmt.Sprintf("%d:%d", matchID, extID), row, err)
+			log.Printf("⚠️  Failed to save player stats for %s: %v", row.PlayerName, err)
 		}
 	}
 
 	fmt.Printf("      ✅ Processed %d players (%d goals)\n", len(playerStats), len(goals))
 	return nil
 }
+
+func processMatchEvents(db *sql.DB, stmts sqlcdb.DBTX, matchID, homeTeamID, awayTeamID int, match *football.Match) error {
+	teamIDs := map[int]int{
+		match.HomeTeam.ID: homeTeamID,
+		match.AwayTeam.ID: awayTeamID,
+	}
+
+	var events []ingest.MatchEvent
+	for _, goal := range match.Goals {
+		e := ingest.MatchEvent{
+			MatchID:          matchID,
+			TeamID:           teamIDs[goal.Team.ID],
+			PlayerExternalID: goal.Scorer.ID,
+			PlayerName:       goal.Scorer.Name,
+			EventType:        "GOAL",
+			Minute:           goal.Minute,
+			InjuryTime:       goal.InjuryTime,
+		}
+		switch goal.Type {
+		case football.GoalOwn:
+			// The goal is credited to the opponent of the scorer's team
+			e.EventType = "OWN_GOAL"
+			if e.TeamID == homeTeamID {
+				e.TeamID = awayTeamID
+			} else {
+				e.TeamID = homeTeamID
+			}
+		case football.GoalPenalty:
+			e.EventType = "PENALTY"
+		}
+		events = append(events, e)
+	}
+
+	for _, booking := range match.Bookings {
+		eventType := "YELLOW_CARD"
+		switch booking.Card {
+		case "RED":
+			eventType = "RED_CARD"
+		case "YELLOW_RED":
+			eventType = "YELLOW_RED_CARD"
+		}
+		events = append(events, ingest.MatchEvent{
+			MatchID:          matchID,
+			TeamID:           teamIDs[booking.Team.ID],
+			PlayerExternalID: booking.Player.ID,
+			PlayerName:       booking.Player.Name,
+			EventType:        eventType,
+			Minute:           booking.Minute,
+		})
+	}
+
+	for _, sub := range match.Substitutions {
+		events = append(events, ingest.MatchEvent{
+			MatchID:                 matchID,
+			TeamID:                  teamIDs[sub.Team.ID],
+			PlayerExternalID:        sub.PlayerIn.ID,
+			PlayerName:              sub.PlayerIn.Name,
+			EventType:               "SUBSTITUTION",
+			Minute:                  sub.Minute,
+			RelatedPlayerExternalID: sub.PlayerOut.ID,
+			RelatedPlayerName:       sub.PlayerOut.Name,
+		})
+	}
+
+	failed := 0
+	for i := range events {
+		e := &events[i]
+		if e.PlayerExternalID <= 0 || e.TeamID == 0 {
+			continue
+		}
+
+		if err := ingest.SaveMatchEvent(stmts, e); err != nil {
+			ref := fmt.Sprintf("%d:%d:%s:%d", matchID, e.PlayerExternalID, e.EventType, e.Minute)
+			ingest.RecordFailure(db, ingest.EntityMatchEvent, ref, e, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d match events failed and were recorded for retry", failed, len(events))
+	}
+
+	fmt.Printf("      ✅ Recorded %d match events\n", len(events))
+	return nil
+}
@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
 
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
 	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/keypool"
 )
 
 // Quick test to verify API-Football integration works
@@ -25,16 +30,26 @@ func main() {
 		log.Fatal("DATABASE_URL or API_FOOTBALL_KEY not set")
 	}
 
-	db, err := sql.Open("postgres", dbURL)
+	db, err := sql.Open("pgx", dbURL)
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	client := apifootball.NewClient(apiKey)
+	client := apifootball.NewClient(keypool.Split(apiKey)...)
 
-	// Test with known fixture ID
+	// Test with known fixture ID, or resolve one from a stored match given as
+	// the first argument (internal or football-data.org match ID).
 	fixtureID := 1035098
+	if len(os.Args) > 1 {
+		matchID, err := strconv.Atoi(os.Args[1])
+		if err != nil {
+			log.Fatalf("invalid match ID %q", os.Args[1])
+		}
+		if fixtureID, err = resolveFixture(db, client, matchID); err != nil {
+			log.Fatalf("Failed to resolve fixture: %v", err)
+		}
+	}
 	fmt.Printf("Testing with fixture ID: %d\n\n", fixtureID)
 
 	// Fetch lineups
@@ -94,4 +109,41 @@ func main() {
 
 	fmt.Printf("\n✅ Test successful! API-Football integration is working.\n")
 	fmt.Printf("   Ready to run full player ingestion.\n")
+
+	quota := client.Quota()
+	fmt.Printf("   API-Football quota: %d used, %d remaining (resets %s)\n",
+		quota.Used, quota.Remaining, quota.ResetsAt.Format(time.RFC3339))
+}
+
+// resolveFixture finds the API-Football fixture for a stored match through
+// the entity mappings, matching teams by name on the match date.
+func resolveFixture(db *sql.DB, client *apifootball.Client, matchID int) (int, error) {
+	matches := repository.NewMatchRepository(db)
+	match, err := matches.GetDetailByExternalID(matchID)
+	if err != nil {
+		return 0, err
+	}
+	if match == nil {
+		if match, err = matches.GetDetailByID(matchID); err != nil {
+			return 0, err
+		}
+	}
+	if match == nil {
+		return 0, fmt.Errorf("match %d not found", matchID)
+	}
+
+	fixtureID, err := service.NewEntityMappingService(db).ResolveFixture(client, service.FixtureLookup{
+		MatchID:    match.ID,
+		HomeTeamID: match.HomeTeam.ID,
+		AwayTeamID: match.AwayTeam.ID,
+		Date:       match.UtcDate,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if fixtureID == 0 {
+		return 0, fmt.Errorf("no API-Football fixture matches %s vs %s on %s",
+			match.HomeTeam.Name, match.AwayTeam.Name, match.UtcDate.Format("2006-01-02"))
+	}
+	return fixtureID, nil
 }
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// keyPrefix marks keys issued by this API so they are easy to recognise.
+const keyPrefix = "fp_"
+
+const (
+	userContextKey   = "auth.user"
+	apiKeyContextKey = "auth.apiKey"
+	adminContextKey  = "auth.admin"
+)
+
+// GenerateKey returns a new random API key, its display prefix and the hash
+// to persist. The plain key is never stored.
+func GenerateKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	key = keyPrefix + hex.EncodeToString(b)
+	return key, key[:len(keyPrefix)+8], HashKey(key), nil
+}
+
+// HashKey returns the sha256 hex digest used to look up a key.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Usage describes one authenticated request. Endpoint is the method and
+// route pattern, e.g. "GET /api/v1/me/feed".
+type Usage struct {
+	UserID   int
+	APIKeyID int
+	Endpoint string
+	Status   int
+	Bytes    int
+	Latency  time.Duration
+	At       time.Time
+}
+
+// Meter enforces monthly request quotas and records the usage of
+// authenticated requests.
+type Meter interface {
+	// Quota returns the user's monthly request limit, 0 when unlimited, and
+	// the requests made so far this month.
+	Quota(user *repository.User) (limit, used int, err error)
+	Record(u Usage)
+}
+
+// RequireUser authenticates requests with "Authorization: Bearer <key>" or
+// "X-API-Key: <key>" and stores the user in the request context. Requests
+// over the user's monthly quota are rejected; the others are recorded with
+// meter once handled.
+func RequireUser(users *repository.UserRepository, meter Meter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-API-Key")
+		if key == "" {
+			key = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		}
+
+		if !strings.HasPrefix(key, keyPrefix) {
+			httpx.Abort(c, httpx.Unauthorized("API key required"))
+			return
+		}
+
+		user, apiKey, err := users.Authenticate(HashKey(key))
+		if err != nil {
+			httpx.Abort(c, httpx.Internal("failed to authenticate", err))
+			return
+		}
+		if user == nil {
+			httpx.Abort(c, httpx.Unauthorized("invalid API key"))
+			return
+		}
+
+		limit, used, err := meter.Quota(user)
+		if err != nil {
+			httpx.Abort(c, httpx.Internal("failed to check quota", err))
+			return
+		}
+		if limit > 0 {
+			c.Header("X-Quota-Limit", strconv.Itoa(limit))
+			c.Header("X-Quota-Remaining", strconv.Itoa(max(limit-used-1, 0)))
+			if used >= limit {
+				httpx.Abort(c, httpx.QuotaExceeded("monthly quota exceeded"))
+				return
+			}
+		}
+
+		c.Set(userContextKey, user)
+		c.Set(apiKeyContextKey, apiKey)
+
+		started := time.Now()
+		c.Next()
+
+		meter.Record(Usage{
+			UserID:   user.ID,
+			APIKeyID: apiKey.ID,
+			Endpoint: c.Request.Method + " " + c.FullPath(),
+			Status:   c.Writer.Status(),
+			Bytes:    max(c.Writer.Size(), 0),
+			Latency:  time.Since(started),
+			At:       started,
+		})
+	}
+}
+
+// RequireAdminToken guards operational endpoints with a shared admin token,
+// accepted as "Authorization: Bearer <token>" or "X-Admin-Token". An empty
+// token disables the endpoints entirely.
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			httpx.Abort(c, httpx.Unavailable("admin endpoints are disabled"))
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Token")
+		if provided == "" {
+			provided = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			httpx.Abort(c, httpx.Unauthorized("invalid admin token"))
+			return
+		}
+
+		c.Set(adminContextKey, true)
+		c.Next()
+	}
+}
+
+// CurrentUser returns the authenticated user, or nil outside RequireUser.
+func CurrentUser(c *gin.Context) *repository.User {
+	if v, ok := c.Get(userContextKey); ok {
+		return v.(*repository.User)
+	}
+	return nil
+}
+
+// CurrentAPIKey returns the key used for the request, or nil outside
+// RequireUser.
+func CurrentAPIKey(c *gin.Context) *repository.APIKey {
+	if v, ok := c.Get(apiKeyContextKey); ok {
+		return v.(*repository.APIKey)
+	}
+	return nil
+}
+
+// IsAdmin reports whether the request presented the admin token.
+func IsAdmin(c *gin.Context) bool {
+	return c.GetBool(adminContextKey)
+}
@@ -0,0 +1,148 @@
+// Package database opens the PostgreSQL connection pool shared by the API
+// and the commands. Connections come from a pgx pool, exposed as a *sql.DB
+// so the repositories and generated queries keep using database/sql.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/yourusername/football-prediction/internal/tracing"
+)
+
+// PoolConfig sizes the connection pool. Zero durations disable the limit.
+type PoolConfig struct {
+	MaxConns        int
+	MinConns        int
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+	// StatementTimeout aborts any statement running longer, server side.
+	StatementTimeout time.Duration
+}
+
+// DefaultPoolConfig returns the limits used when none are configured.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxConns:        25,
+		MinConns:        0,
+		MaxConnLifetime: 5 * time.Minute,
+		MaxConnIdleTime: 5 * time.Minute,
+	}
+}
+
+// PoolConfigFromEnv returns DefaultPoolConfig overridden by DB_MAX_CONNS,
+// DB_MIN_CONNS, DB_MAX_CONN_LIFETIME, DB_MAX_CONN_IDLE_TIME and
+// DB_STATEMENT_TIMEOUT. Durations use Go syntax, e.g. 30s.
+func PoolConfigFromEnv() (PoolConfig, error) {
+	cfg := DefaultPoolConfig()
+
+	for key, dst := range map[string]*int{
+		"DB_MAX_CONNS": &cfg.MaxConns,
+		"DB_MIN_CONNS": &cfg.MinConns,
+	} {
+		if v := os.Getenv(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return cfg, fmt.Errorf("invalid %s %q: must be a non-negative number", key, v)
+			}
+			*dst = n
+		}
+	}
+
+	for key, dst := range map[string]*time.Duration{
+		"DB_MAX_CONN_LIFETIME":  &cfg.MaxConnLifetime,
+		"DB_MAX_CONN_IDLE_TIME": &cfg.MaxConnIdleTime,
+		"DB_STATEMENT_TIMEOUT":  &cfg.StatementTimeout,
+	} {
+		if v := os.Getenv(key); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d < 0 {
+				return cfg, fmt.Errorf("invalid %s %q: must be a duration such as 30s", key, v)
+			}
+			*dst = d
+		}
+	}
+
+	if cfg.MaxConns < 1 {
+		return cfg, fmt.Errorf("DB_MAX_CONNS must be at least 1")
+	}
+	if cfg.MinConns > cfg.MaxConns {
+		return cfg, fmt.Errorf("DB_MIN_CONNS (%d) exceeds DB_MAX_CONNS (%d)", cfg.MinConns, cfg.MaxConns)
+	}
+	return cfg, nil
+}
+
+// Open creates a pgx pool for databaseURL, checks that the database answers,
+// and returns the pool as a *sql.DB. Closing the *sql.DB closes the pool.
+func Open(ctx context.Context, databaseURL string, cfg PoolConfig) (*sql.DB, error) {
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database URL: %w", err)
+	}
+
+	poolConfig.MaxConns = int32(cfg.MaxConns)
+	poolConfig.MinConns = int32(cfg.MinConns)
+	// pgxpool treats a zero lifetime or idle time as already exceeded
+	poolConfig.MaxConnLifetime = noLimitIfZero(cfg.MaxConnLifetime)
+	poolConfig.MaxConnIdleTime = noLimitIfZero(cfg.MaxConnIdleTime)
+	if cfg.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	// Queries run within a traced request become its child spans
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// database/sql keeps no idle connections of its own; the pool does
+	db := sql.OpenDB(poolConnector{Connector: stdlib.GetPoolConnector(pool), pool: pool})
+	db.SetMaxIdleConns(0)
+	return db, nil
+}
+
+func noLimitIfZero(d time.Duration) time.Duration {
+	if d == 0 {
+		return math.MaxInt64
+	}
+	return d
+}
+
+// poolConnector closes the pool when the *sql.DB over it is closed.
+type poolConnector struct {
+	driver.Connector
+	pool *pgxpool.Pool
+}
+
+func (c poolConnector) Close() error {
+	c.pool.Close()
+	return nil
+}
+
+// OpenFromEnv opens DATABASE_URL with the pool configured by
+// PoolConfigFromEnv.
+func OpenFromEnv(ctx context.Context) (*sql.DB, error) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return nil, fmt.Errorf("DATABASE_URL not set")
+	}
+
+	cfg, err := PoolConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return Open(ctx, databaseURL, cfg)
+}
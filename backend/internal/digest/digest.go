@@ -0,0 +1,105 @@
+// Package digest renders the weekly email digest from templates.
+package digest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var funcs = map[string]interface{}{
+	"kickoff": func(t time.Time) string { return t.UTC().Format("Mon 2 Jan 15:04 MST") },
+	"day":     func(t time.Time) string { return t.UTC().Format("2 January") },
+}
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/digest.txt.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/digest.html.tmpl"))
+)
+
+// Fixture is an upcoming match of a followed team with the model's call.
+type Fixture struct {
+	Kickoff     time.Time
+	Competition string
+	Home        string
+	Away        string
+	// Prediction reads e.g. "Arsenal 2-1 (64% confidence)"; empty when the
+	// match has not been predicted yet.
+	Prediction string
+}
+
+// Record is how many settled predictions were correct.
+type Record struct {
+	Correct int
+	Total   int
+}
+
+// Percent is the share of correct predictions, rounded.
+func (r Record) Percent() int {
+	if r.Total == 0 {
+		return 0
+	}
+	return (r.Correct*100 + r.Total/2) / r.Total
+}
+
+// Digest is one user's weekly email.
+type Digest struct {
+	Name string
+	// WeekOf is the first day of the week the fixtures cover.
+	WeekOf   time.Time
+	Fixtures []Fixture
+	// TeamRecord covers last week's matches of the followed teams,
+	// ModelRecord every match.
+	TeamRecord     Record
+	ModelRecord    Record
+	Streaks        []string
+	UnsubscribeURL string
+}
+
+// Empty reports whether the digest has nothing to tell.
+func (d Digest) Empty() bool {
+	return len(d.Fixtures) == 0 && d.TeamRecord.Total == 0 && len(d.Streaks) == 0
+}
+
+// Subject is the email subject line.
+func (d Digest) Subject() string {
+	switch len(d.Fixtures) {
+	case 0:
+		return "Your football week in review"
+	case 1:
+		return "Your week ahead: 1 fixture"
+	default:
+		return fmt.Sprintf("Your week ahead: %d fixtures", len(d.Fixtures))
+	}
+}
+
+// Render returns the plain text and HTML bodies of the digest.
+func Render(d Digest) (text, html string, err error) {
+	var t, h bytes.Buffer
+	if err := textTemplate.Execute(&t, d); err != nil {
+		return "", "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	if err := htmlTemplate.Execute(&h, d); err != nil {
+		return "", "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return t.String(), h.String(), nil
+}
+
+var pageTemplate = htmltemplate.Must(htmltemplate.New("unsubscribe.html.tmpl").ParseFS(templateFS, "templates/unsubscribe.html.tmpl"))
+
+// UnsubscribePage renders the page an unsubscribe link opens. It asks for
+// confirmation, so link scanners fetching it do not unsubscribe anyone;
+// without a token it confirms the unsubscription instead.
+func UnsubscribePage(token string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := pageTemplate.Execute(&b, struct{ Token string }{token}); err != nil {
+		return nil, fmt.Errorf("failed to render page: %w", err)
+	}
+	return b.Bytes(), nil
+}
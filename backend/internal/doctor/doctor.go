@@ -0,0 +1,135 @@
+// Package doctor finds rows that break the data model's assumptions without
+// breaking its constraints: players stored twice for a team, as the
+// synthetic data generator and cross-provider inserts can leave behind,
+// player stats without their player or match, and matches without their
+// teams. It can merge and delete them.
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// DuplicatePlayer is a player stored again for the same team and name under
+// another external ID. Fixing merges it into the player it duplicates.
+type DuplicatePlayer struct {
+	KeepID              int
+	KeepExternalID      int
+	DuplicateID         int
+	DuplicateExternalID int
+	Name                string
+	Team                string
+}
+
+// OrphanMatch is a match whose home or away team is missing.
+type OrphanMatch struct {
+	ID         int
+	ExternalID int
+	Date       time.Time
+	Status     string
+	HomeTeamID *int
+	AwayTeamID *int
+}
+
+// Report is what a check found, or what a fix repaired.
+type Report struct {
+	DuplicatePlayers    []DuplicatePlayer
+	OrphanedPlayerStats int
+	MatchesWithoutTeams []OrphanMatch
+}
+
+// Clean reports whether nothing was found.
+func (r *Report) Clean() bool {
+	return len(r.DuplicatePlayers) == 0 && r.OrphanedPlayerStats == 0 && len(r.MatchesWithoutTeams) == 0
+}
+
+// Check looks for problems without changing anything.
+func Check(db *sql.DB) (*Report, error) {
+	return check(context.Background(), sqlcdb.New(db))
+}
+
+// Fix repairs every problem Check finds in one transaction and returns
+// them: duplicate players are merged into the players they duplicate, and
+// orphaned player stats and matches without teams are deleted, the
+// matches' predictions, stats and events with them.
+func Fix(db *sql.DB) (*Report, error) {
+	ctx := context.Background()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := sqlcdb.New(db).WithTx(tx)
+
+	report, err := check(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range report.DuplicatePlayers {
+		err := q.MergePlayer(ctx, sqlcdb.MergePlayerParams{KeepID: d.KeepID, DuplicateID: d.DuplicateID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge player %d into %d: %w", d.DuplicateID, d.KeepID, err)
+		}
+		if err := q.DeleteMergedPlayer(ctx, d.DuplicateID); err != nil {
+			return nil, fmt.Errorf("failed to delete merged player %d: %w", d.DuplicateID, err)
+		}
+	}
+	// Matches first: their stats cascade from them rather than being
+	// orphaned.
+	if _, err := q.DeleteMatchesWithoutTeams(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete matches without teams: %w", err)
+	}
+	if _, err := q.DeleteOrphanedPlayerStats(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete orphaned player stats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit fixes: %w", err)
+	}
+	return report, nil
+}
+
+func check(ctx context.Context, q *sqlcdb.Queries) (*Report, error) {
+	duplicates, err := q.ListDuplicatePlayers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate players: %w", err)
+	}
+	orphans, err := q.CountOrphanedPlayerStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orphaned player stats: %w", err)
+	}
+	matches, err := q.ListMatchesWithoutTeams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matches without teams: %w", err)
+	}
+
+	report := &Report{OrphanedPlayerStats: orphans}
+	for _, d := range duplicates {
+		report.DuplicatePlayers = append(report.DuplicatePlayers, DuplicatePlayer{
+			KeepID:              d.KeepID,
+			KeepExternalID:      d.KeepExternalID,
+			DuplicateID:         d.DuplicateID,
+			DuplicateExternalID: d.DuplicateExternalID,
+			Name:                d.Name,
+			Team:                d.TeamName,
+		})
+	}
+	for _, m := range matches {
+		report.MatchesWithoutTeams = append(report.MatchesWithoutTeams, OrphanMatch{
+			ID:         m.ID,
+			ExternalID: m.ExternalID,
+			Date:       m.UtcDate,
+			Status:     m.Status,
+			HomeTeamID: m.HomeTeamID,
+			AwayTeamID: m.AwayTeamID,
+		})
+	}
+	return report, nil
+}
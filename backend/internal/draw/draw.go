@@ -0,0 +1,294 @@
+// Package draw simulates constrained cup draws: knockout draws pairing the
+// teams of two pots, as in the Champions League round of 16, and group
+// draws taking one team from each pot per group, as in the World Cup.
+// Every simulated draw keeps to the constraints, so the pairing counts
+// reflect what the real draw can produce.
+package draw
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Format is the shape of a draw.
+type Format string
+
+const (
+	// Knockout pairs every team of pot 2 with one of pot 1.
+	Knockout Format = "knockout"
+	// Groups puts one team of each pot in every group. There are as many
+	// groups as teams in pot 1; later pots may be short.
+	Groups Format = "groups"
+)
+
+// maxRestarts is how many times a group draw that ran into a dead end is
+// started over before the constraints are deemed unsatisfiable.
+const maxRestarts = 1000
+
+// ErrUnsatisfiable is returned when no draw can meet the constraints.
+var ErrUnsatisfiable = errors.New("no draw satisfies the constraints")
+
+// Team is a team in the draw. Country is its association, or confederation
+// in a World Cup draw; teams with none are never kept apart. Group is the
+// group it played in before a knockout draw.
+type Team struct {
+	ID      int
+	Pot     int
+	Country string
+	Group   string
+}
+
+// Rules are a draw's constraints. With CountryProtection, teams of one
+// country meet no earlier than the rules allow: never in a knockout draw,
+// and in a group draw no more of them share a group than their
+// CountryLimits entry, 1 when there is none. With GroupProtection, teams
+// from the same group do not meet again in a knockout draw.
+type Rules struct {
+	Format            Format
+	CountryProtection bool
+	CountryLimits     map[string]int
+	GroupProtection   bool
+}
+
+// Pair is two teams, the lower ID first.
+type Pair struct {
+	A, B int
+}
+
+func newPair(a, b int) Pair {
+	if a > b {
+		a, b = b, a
+	}
+	return Pair{A: a, B: b}
+}
+
+// Result counts how often each pair of teams was drawn together, paired in
+// a knockout draw or in the same group.
+type Result struct {
+	Simulations int
+	Counts      map[Pair]int
+}
+
+// Probability returns the share of draws that put a and b together.
+func (r *Result) Probability(a, b int) float64 {
+	if r.Simulations == 0 {
+		return 0
+	}
+	return float64(r.Counts[newPair(a, b)]) / float64(r.Simulations)
+}
+
+// Simulate runs n draws of teams under rules, with randomness from rng.
+func Simulate(teams []Team, rules Rules, n int, rng *rand.Rand) (*Result, error) {
+	pots, err := splitPots(teams)
+	if err != nil {
+		return nil, err
+	}
+
+	var run func() ([][]Team, error)
+	switch rules.Format {
+	case Knockout:
+		if len(pots) != 2 || len(pots[0]) != len(pots[1]) {
+			return nil, fmt.Errorf("a knockout draw needs two pots of equal size")
+		}
+		run = func() ([][]Team, error) { return knockout(pots[0], pots[1], rules, rng) }
+	case Groups:
+		for i, pot := range pots[1:] {
+			if len(pot) > len(pots[0]) {
+				return nil, fmt.Errorf("pot %d has more teams than there are groups", i+2)
+			}
+		}
+		run = func() ([][]Team, error) { return groups(pots, rules, rng) }
+	default:
+		return nil, fmt.Errorf("unknown draw format %q", rules.Format)
+	}
+
+	result := &Result{Simulations: n, Counts: make(map[Pair]int)}
+	for i := 0; i < n; i++ {
+		drawn, err := run()
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range drawn {
+			for x := 0; x < len(group); x++ {
+				for y := x + 1; y < len(group); y++ {
+					result.Counts[newPair(group[x].ID, group[y].ID)]++
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// splitPots groups teams by pot, in pot order, rejecting repeated teams
+// and gaps in the pot numbers.
+func splitPots(teams []Team) ([][]Team, error) {
+	byPot := make(map[int][]Team)
+	seen := make(map[int]bool)
+	for _, t := range teams {
+		if seen[t.ID] {
+			return nil, fmt.Errorf("team %d is in the draw twice", t.ID)
+		}
+		seen[t.ID] = true
+		byPot[t.Pot] = append(byPot[t.Pot], t)
+	}
+	numbers := make([]int, 0, len(byPot))
+	for pot := range byPot {
+		numbers = append(numbers, pot)
+	}
+	sort.Ints(numbers)
+
+	pots := make([][]Team, len(numbers))
+	for i, pot := range numbers {
+		if pot != i+1 {
+			return nil, fmt.Errorf("pots must be numbered from 1 without gaps")
+		}
+		pots[i] = byPot[pot]
+	}
+	if len(pots) == 0 {
+		return nil, fmt.Errorf("no teams to draw")
+	}
+	return pots, nil
+}
+
+// knockout draws the teams of pot 2 in random order, each against a random
+// pot 1 team among those that leave the rest of the draw possible, the way
+// UEFA's draw software does.
+func knockout(seeded, unseeded []Team, rules Rules, rng *rand.Rand) ([][]Team, error) {
+	allowed := func(a, b Team) bool {
+		if rules.CountryProtection && a.Country != "" && a.Country == b.Country {
+			return false
+		}
+		if rules.GroupProtection && a.Group != "" && a.Group == b.Group {
+			return false
+		}
+		return true
+	}
+
+	left := append([]Team(nil), seeded...)
+	order := append([]Team(nil), unseeded...)
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+	ties := make([][]Team, 0, len(order))
+	for i, team := range order {
+		var candidates []int
+		for j, opponent := range left {
+			if !allowed(team, opponent) {
+				continue
+			}
+			rest := append(append([]Team(nil), left[:j]...), left[j+1:]...)
+			if matchable(order[i+1:], rest, allowed) {
+				candidates = append(candidates, j)
+			}
+		}
+		if len(candidates) == 0 {
+			return nil, ErrUnsatisfiable
+		}
+		j := candidates[rng.Intn(len(candidates))]
+		ties = append(ties, []Team{left[j], team})
+		left = append(left[:j], left[j+1:]...)
+	}
+	return ties, nil
+}
+
+// matchable reports whether every team of a can be paired with its own
+// team of b, by augmenting paths.
+func matchable(a, b []Team, allowed func(a, b Team) bool) bool {
+	owner := make([]int, len(b)) // index in a, or -1
+	for i := range owner {
+		owner[i] = -1
+	}
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j := range b {
+			if visited[j] || !allowed(a[i], b[j]) {
+				continue
+			}
+			visited[j] = true
+			if owner[j] < 0 || augment(owner[j], visited) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range a {
+		if !augment(i, make([]bool, len(b))) {
+			return false
+		}
+	}
+	return true
+}
+
+// groups draws pot by pot, each team into a random group that leaves a
+// place for the rest of its pot. Later pots can still run into a dead end,
+// in which case the draw starts over.
+func groups(pots [][]Team, rules Rules, rng *rand.Rand) ([][]Team, error) {
+	limit := func(country string) int {
+		if n, ok := rules.CountryLimits[country]; ok {
+			return n
+		}
+		return 1
+	}
+
+	for attempt := 0; attempt < maxRestarts; attempt++ {
+		drawn := make([][]Team, len(pots[0]))
+		countries := make([]map[string]int, len(drawn))
+		for g := range countries {
+			countries[g] = make(map[string]int)
+		}
+		fits := func(t Team, g int) bool {
+			// Pots are drawn in order, so a team of this pot would be last
+			if n := len(drawn[g]); n > 0 && drawn[g][n-1].Pot == t.Pot {
+				return false
+			}
+			return !rules.CountryProtection || t.Country == "" || countries[g][t.Country] < limit(t.Country)
+		}
+
+		ok := true
+		for _, pot := range pots {
+			order := append([]Team(nil), pot...)
+			rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+			for i, team := range order {
+				var candidates []int
+				for g := range drawn {
+					if !fits(team, g) {
+						continue
+					}
+					drawn[g] = append(drawn[g], team)
+					countries[g][team.Country]++
+					if placeable(order[i+1:], len(drawn), fits) {
+						candidates = append(candidates, g)
+					}
+					drawn[g] = drawn[g][:len(drawn[g])-1]
+					countries[g][team.Country]--
+				}
+				if len(candidates) == 0 {
+					ok = false
+					break
+				}
+				g := candidates[rng.Intn(len(candidates))]
+				drawn[g] = append(drawn[g], team)
+				countries[g][team.Country]++
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok {
+			return drawn, nil
+		}
+	}
+	return nil, ErrUnsatisfiable
+}
+
+// placeable reports whether the teams left in a pot can each go to a
+// different group they fit.
+func placeable(teams []Team, groups int, fits func(t Team, g int) bool) bool {
+	slots := make([]Team, groups)
+	for g := range slots {
+		slots[g] = Team{ID: g}
+	}
+	return matchable(teams, slots, func(t, slot Team) bool { return fits(t, slot.ID) })
+}
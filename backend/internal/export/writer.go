@@ -0,0 +1,236 @@
+// Package export encodes training-data rows as CSV, Parquet, a JSON array or
+// NDJSON while they are streamed from the database.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Supported formats.
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+	FormatJSON    = "json"
+	FormatNDJSON  = "ndjson"
+)
+
+// parquetRowGroupSize bounds how many rows are buffered before a row group is
+// flushed to the output.
+const parquetRowGroupSize = 5000
+
+// Writer encodes export rows. Close must be called to flush buffered data.
+type Writer interface {
+	Write(row *repository.ExportRow) error
+	Close() error
+}
+
+// NewWriter returns a writer for the given format.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatParquet:
+		return newParquetWriter(w), nil
+	case FormatJSON, FormatNDJSON:
+		return newJSONWriter(w, format == FormatNDJSON), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q (use csv, parquet, json or ndjson)", format)
+	}
+}
+
+// ContentType returns the HTTP content type for a format.
+func ContentType(format string) string {
+	switch format {
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	case FormatJSON:
+		return "application/json"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "text/csv"
+	}
+}
+
+var csvHeader = []string{
+	"match_id", "competition_code", "season", "matchday", "utc_date", "status",
+	"home_team_id", "home_team", "away_team_id", "away_team",
+	"home_score", "away_score", "winner",
+	"home_xg", "away_xg", "home_possession", "away_possession",
+	"home_shots", "away_shots", "home_shots_on_target", "away_shots_on_target",
+	"home_corners", "away_corners",
+	"predicted_home_goals", "predicted_away_goals", "predicted_winner",
+	"confidence", "prediction_correct", "model_version", "features",
+}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+	record      []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), record: make([]string, 0, len(csvHeader))}
+}
+
+func (c *csvWriter) Write(r *repository.ExportRow) error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+
+	c.record = append(c.record[:0],
+		strconv.Itoa(r.MatchID), r.CompetitionCode, r.Season, strconv.Itoa(r.Matchday),
+		r.UtcDate.UTC().Format(time.RFC3339), r.Status,
+		strconv.Itoa(r.HomeTeamID), r.HomeTeam, strconv.Itoa(r.AwayTeamID), r.AwayTeam,
+		intCell(r.HomeScore), intCell(r.AwayScore), r.Winner,
+		floatCell(r.HomeXG), floatCell(r.AwayXG), floatCell(r.HomePossession), floatCell(r.AwayPossession),
+		intCell(r.HomeShots), intCell(r.AwayShots), intCell(r.HomeShotsOnTarget), intCell(r.AwayShotsOnTarget),
+		intCell(r.HomeCorners), intCell(r.AwayCorners),
+		floatCell(r.PredictedHomeGoals), floatCell(r.PredictedAwayGoals), stringCell(r.PredictedWinner),
+		floatCell(r.Confidence), boolCell(r.PredictionCorrect), stringCell(r.ModelVersion), stringCell(r.Features),
+	)
+
+	if err := c.w.Write(c.record); err != nil {
+		return err
+	}
+
+	// Flush per row so the HTTP response streams instead of buffering.
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type parquetWriter struct {
+	w   *parquet.GenericWriter[repository.ExportRow]
+	buf []repository.ExportRow
+}
+
+func newParquetWriter(w io.Writer) *parquetWriter {
+	return &parquetWriter{
+		w:   parquet.NewGenericWriter[repository.ExportRow](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize)),
+		buf: make([]repository.ExportRow, 0, 256),
+	}
+}
+
+func (p *parquetWriter) Write(r *repository.ExportRow) error {
+	p.buf = append(p.buf, *r)
+	if len(p.buf) < cap(p.buf) {
+		return nil
+	}
+	return p.flush()
+}
+
+func (p *parquetWriter) flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	if _, err := p.w.Write(p.buf); err != nil {
+		return fmt.Errorf("failed to write parquet rows: %w", err)
+	}
+	p.buf = p.buf[:0]
+	return nil
+}
+
+func (p *parquetWriter) Close() error {
+	if err := p.flush(); err != nil {
+		return err
+	}
+	return p.w.Close()
+}
+
+// jsonWriter encodes rows as one JSON array, or as NDJSON with a row per
+// line. Features is embedded as an object rather than a string.
+type jsonWriter struct {
+	w       io.Writer
+	enc     *json.Encoder
+	ndjson  bool
+	started bool
+}
+
+type jsonRow struct {
+	*repository.ExportRow
+	Features json.RawMessage `json:"features"`
+}
+
+func newJSONWriter(w io.Writer, ndjson bool) *jsonWriter {
+	return &jsonWriter{w: w, enc: json.NewEncoder(w), ndjson: ndjson}
+}
+
+func (j *jsonWriter) Write(r *repository.ExportRow) error {
+	if !j.ndjson {
+		sep := ","
+		if !j.started {
+			sep = "["
+		}
+		if _, err := io.WriteString(j.w, sep); err != nil {
+			return err
+		}
+	}
+	j.started = true
+
+	row := jsonRow{ExportRow: r, Features: json.RawMessage("null")}
+	if r.Features != nil {
+		row.Features = json.RawMessage(*r.Features)
+	}
+	return j.enc.Encode(row)
+}
+
+func (j *jsonWriter) Close() error {
+	if j.ndjson {
+		return nil
+	}
+	end := "]\n"
+	if !j.started {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+func intCell(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func floatCell(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func stringCell(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func boolCell(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
@@ -0,0 +1,194 @@
+// Package grounding assembles verified facts into LLM prompts and checks the
+// generated text against them, so narratives only state numbers the stored
+// data supports.
+package grounding
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Instructions tell the model how to use the facts. They are appended to
+// the system prompt of every grounded narrative.
+const Instructions = `Facts are listed one per line, each after an ID in square brackets such as [F1].
+Cite the IDs of the facts each sentence relies on, in square brackets at the end of the sentence, e.g. "They won 3-1 [F2]."
+Every number you write, in digits or words, must appear in a fact you cite. Do not add up, average or otherwise derive new numbers.`
+
+// Fact is one verified statement given to the model.
+type Fact struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// Facts is the set of verified statements a narrative may use, with the
+// numbers each states.
+type Facts struct {
+	facts   []Fact
+	numbers map[string][]float64
+}
+
+// Add appends a fact formatted as with fmt.Sprintf and returns its ID. The
+// numbers in the text are what the fact lets the model state.
+func (f *Facts) Add(format string, args ...interface{}) string {
+	if f.numbers == nil {
+		f.numbers = make(map[string][]float64)
+	}
+	id := "F" + strconv.Itoa(len(f.facts)+1)
+	text := fmt.Sprintf(format, args...)
+	f.facts = append(f.facts, Fact{ID: id, Text: text})
+	values := []float64{}
+	for _, n := range numbers(text) {
+		values = append(values, n.value)
+	}
+	f.numbers[id] = values
+	return id
+}
+
+// Prompt renders the facts one per line for the user prompt.
+func (f *Facts) Prompt() string {
+	var b strings.Builder
+	for _, fact := range f.facts {
+		fmt.Fprintf(&b, "[%s] %s\n", fact.ID, fact.Text)
+	}
+	return b.String()
+}
+
+// Check validates generated text against the facts: every citation must name
+// a fact, and every number must appear in a cited fact, at the precision the
+// text gives it. It returns the IDs cited, sorted.
+func (f *Facts) Check(text string) ([]string, error) {
+	cited := make(map[string]bool)
+	var unknown []string
+	for _, m := range citations.FindAllStringSubmatch(text, -1) {
+		for _, id := range strings.Split(m[1], ",") {
+			id = strings.TrimSpace(id)
+			if _, ok := f.numbers[id]; !ok {
+				unknown = append(unknown, id)
+				continue
+			}
+			cited[id] = true
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("cites unknown facts %s", strings.Join(unknown, ", "))
+	}
+
+	var unsupported []string
+	for _, n := range numbers(citations.ReplaceAllString(text, " ")) {
+		if !f.supports(cited, n) {
+			unsupported = append(unsupported, n.text)
+		}
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("states numbers no cited fact supports: %s", strings.Join(unsupported, ", "))
+	}
+
+	ids := make([]string, 0, len(cited))
+	for id := range cited {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Unsupported returns the numbers and scorelines in text, cited or not,
+// that no fact states. A scoreline such as 2-1 must appear as such in a fact,
+// either way round, rather than as two separate numbers.
+func (f *Facts) Unsupported(text string) []string {
+	text = citations.ReplaceAllString(text, " ")
+	all := make(map[string]bool, len(f.numbers))
+	for id := range f.numbers {
+		all[id] = true
+	}
+
+	var out []string
+	for _, n := range numbers(scorelines.ReplaceAllString(text, " ")) {
+		if !f.supports(all, n) {
+			out = append(out, n.text)
+		}
+	}
+
+	stated := make(map[[2]string]bool)
+	for _, fact := range f.facts {
+		for _, m := range scorelines.FindAllStringSubmatch(fact.Text, -1) {
+			stated[[2]string{m[1], m[2]}] = true
+			stated[[2]string{m[2], m[1]}] = true
+		}
+	}
+	for _, m := range scorelines.FindAllStringSubmatch(text, -1) {
+		if !stated[[2]string{m[1], m[2]}] {
+			out = append(out, m[0])
+		}
+	}
+	return out
+}
+
+// Strip removes the citations from checked text before it is published.
+func Strip(text string) string {
+	text = citations.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+	return spaceBeforePunctuation.ReplaceAllString(text, "$1")
+}
+
+// supports reports whether a cited fact states n, rounded to the number of
+// decimals n is given with.
+func (f *Facts) supports(cited map[string]bool, n number) bool {
+	scale := math.Pow(10, float64(n.decimals))
+	for id := range cited {
+		for _, v := range f.numbers[id] {
+			if math.Round(v*scale) == math.Round(n.value*scale) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	citations              = regexp.MustCompile(`\[\s*(F\d+(?:\s*,\s*F\d+)*)\s*\]`)
+	digits                 = regexp.MustCompile(`\d+(?:\.\d+)?`)
+	scorelines             = regexp.MustCompile(`\b(\d{1,2})\s*[-–]\s*(\d{1,2})\b`)
+	words                  = regexp.MustCompile(`[A-Za-z]+`)
+	spaceBeforePunctuation = regexp.MustCompile(`\s+([.,;:!?])`)
+)
+
+// numberWords are the spelled-out numbers checked like digits. "One" is left
+// out, as it is as often a pronoun as a count.
+var numberWords = map[string]float64{
+	"zero": 0, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// number is a number found in text.
+type number struct {
+	text     string
+	value    float64
+	decimals int
+}
+
+// numbers returns the numbers in text, in digits or words. A scoreline such
+// as 3-1 or a time such as 15:00 is two numbers.
+func numbers(text string) []number {
+	var out []number
+	for _, s := range digits.FindAllString(text, -1) {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			continue
+		}
+		decimals := 0
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			decimals = len(s) - i - 1
+		}
+		out = append(out, number{text: s, value: v, decimals: decimals})
+	}
+	for _, w := range words.FindAllString(text, -1) {
+		if v, ok := numberWords[strings.ToLower(w)]; ok {
+			out = append(out, number{text: w, value: v})
+		}
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type AdminUserHandler struct {
+	users *service.UserService
+}
+
+func NewAdminUserHandler(users *service.UserService) *AdminUserHandler {
+	return &AdminUserHandler{users: users}
+}
+
+type createUserRequest struct {
+	Email   string `json:"email" binding:"required"`
+	Name    string `json:"name"`
+	IsAdmin bool   `json:"isAdmin"`
+}
+
+type createKeyRequest struct {
+	Name string `json:"name"`
+}
+
+type setQuotaRequest struct {
+	MonthlyQuota *int `json:"monthlyQuota" binding:"omitempty,min=0"`
+}
+
+// CreateUser creates a user and returns its first API key.
+func (h *AdminUserHandler) CreateUser(c *gin.Context) {
+	var req createUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("email is required"))
+		return
+	}
+
+	user, key, plain, err := h.users.CreateUser(req.Email, req.Name, req.IsAdmin)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to create user"))
+		return
+	}
+	setAuditTarget(c, fmt.Sprintf("id=%d keyId=%d", user.ID, key.ID))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"user":    user,
+		"apiKey":  key,
+		"key":     plain,
+		"warning": "store this key now; it cannot be retrieved again",
+	})
+}
+
+// CreateKey issues an additional API key for a user.
+func (h *AdminUserHandler) CreateKey(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid user ID"))
+		return
+	}
+
+	var req createKeyRequest
+	_ = c.ShouldBindJSON(&req)
+
+	key, plain, err := h.users.CreateKey(userID, req.Name)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to create key", err))
+		return
+	}
+	if key == nil {
+		httpx.Abort(c, httpx.NotFound("user not found"))
+		return
+	}
+	setAuditTarget(c, fmt.Sprintf("id=%d keyId=%d", userID, key.ID))
+
+	c.JSON(http.StatusCreated, gin.H{"apiKey": key, "key": plain})
+}
+
+// ListKeys lists a user's keys without their secrets.
+func (h *AdminUserHandler) ListKeys(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid user ID"))
+		return
+	}
+
+	keys, err := h.users.ListKeys(userID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list keys", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": len(keys), "keys": keys})
+}
+
+// RevokeKey revokes one of a user's keys.
+func (h *AdminUserHandler) RevokeKey(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid user ID"))
+		return
+	}
+	keyID, err := strconv.Atoi(c.Param("keyId"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid key ID"))
+		return
+	}
+
+	revoked, err := h.users.RevokeKey(userID, keyID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to revoke key", err))
+		return
+	}
+	if !revoked {
+		httpx.Abort(c, httpx.NotFound("active key not found"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetQuota overrides a user's monthly request quota. A null monthlyQuota
+// restores the default and 0 makes it unlimited.
+func (h *AdminUserHandler) SetQuota(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid user ID"))
+		return
+	}
+
+	var req setQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("monthlyQuota must be null or a non-negative number"))
+		return
+	}
+
+	found, err := h.users.SetMonthlyQuota(userID, req.MonthlyQuota)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to set quota", err))
+		return
+	}
+	if !found {
+		httpx.Abort(c, httpx.NotFound("user not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"userId": userID, "monthlyQuota": req.MonthlyQuota})
+}
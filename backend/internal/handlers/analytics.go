@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type AnalyticsHandler struct {
+	homeAdvantage *service.HomeAdvantageService
+}
+
+func NewAnalyticsHandler(homeAdvantage *service.HomeAdvantageService) *AnalyticsHandler {
+	return &AnalyticsHandler{homeAdvantage: homeAdvantage}
+}
+
+// GetHomeAdvantage returns home/away points-per-game and goal differentials
+// per competition and per team, with their trend since the previous window.
+// Query: competition (code), team (ID), window (matches per venue, one of
+// the computed windows, default the shortest).
+func (h *AnalyticsHandler) GetHomeAdvantage(c *gin.Context) {
+	var query struct {
+		Competition string `form:"competition" binding:"omitempty,competition"`
+		Team        int    `form:"team" binding:"omitempty,min=1"`
+		Window      int    `form:"window" binding:"omitempty,min=1"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	if query.Window != 0 && !containsInt(h.homeAdvantage.Windows(), query.Window) {
+		var windows []string
+		for _, w := range h.homeAdvantage.Windows() {
+			windows = append(windows, strconv.Itoa(w))
+		}
+		httpx.Abort(c, httpx.InvalidFields(map[string]string{"window": "must be one of " + strings.Join(windows, ", ")}))
+		return
+	}
+
+	report, err := h.homeAdvantage.Report(query.Competition, query.Team, query.Window)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get home advantage", err))
+		return
+	}
+
+	if report == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
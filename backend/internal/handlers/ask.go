@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type AskHandler struct {
+	service *service.AskService
+}
+
+func NewAskHandler(service *service.AskService) *AskHandler {
+	return &AskHandler{service: service}
+}
+
+type askRequest struct {
+	Question string `json:"question" binding:"required"`
+}
+
+// Ask answers a natural-language question about stored football data.
+func (h *AskHandler) Ask(c *gin.Context) {
+	var req askRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("question is required"))
+		return
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" || len(question) > 500 {
+		httpx.Abort(c, httpx.BadRequest("question must be between 1 and 500 characters"))
+		return
+	}
+
+	if !h.service.Enabled() {
+		httpx.Abort(c, httpx.Unavailable("natural-language queries are not configured"))
+		return
+	}
+
+	result, err := h.service.Ask(question)
+	if err != nil {
+		httpx.Abort(c, httpx.Upstream("failed to answer question", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
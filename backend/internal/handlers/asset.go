@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// crestCacheControl lets browsers and CDNs keep crests for a week and serve
+// a stale copy for a day while they revalidate.
+const crestCacheControl = "public, max-age=604800, stale-while-revalidate=86400"
+
+type AssetHandler struct {
+	crests *service.CrestService
+}
+
+func NewAssetHandler(crests *service.CrestService) *AssetHandler {
+	return &AssetHandler{crests: crests}
+}
+
+// GetCrest serves a team's crest from the cache, fetching it from the
+// provider on first use, so the frontend never hotlinks provider CDNs. The
+// team is identified by its football-data.org or internal ID.
+func (h *AssetHandler) GetCrest(c *gin.Context) {
+	var path struct {
+		TeamID int `uri:"teamId" binding:"min=1"`
+	}
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	crest, err := h.crests.Get(path.TeamID)
+	if err != nil {
+		httpx.Abort(c, httpx.Upstream("failed to fetch crest", err))
+		return
+	}
+	if crest == nil {
+		httpx.Abort(c, httpx.NotFound("crest not found"))
+		return
+	}
+
+	data, err := crest.Read()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to read crest", err))
+		return
+	}
+
+	c.Header("Content-Type", crest.ContentType)
+	c.Header("Cache-Control", crestCacheControl)
+	c.Header("ETag", crest.ETag)
+	// Crests may be SVG; keep any embedded script from running on our origin.
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", crest.FetchedAt, bytes.NewReader(data))
+}
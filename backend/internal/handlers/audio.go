@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type InsightAudioHandler struct {
+	audio *service.InsightAudioService
+}
+
+func NewInsightAudioHandler(audio *service.InsightAudioService) *InsightAudioHandler {
+	return &InsightAudioHandler{audio: audio}
+}
+
+// GetPreviewAudio serves the spoken preview of a match identified by its
+// football-data.org or internal ID, synthesized on first request and again
+// whenever the written preview changes. Clients are redirected to a signed
+// link to the stored audio when the store can sign one.
+func (h *InsightAudioHandler) GetPreviewAudio(c *gin.Context) {
+	if !h.audio.Enabled() {
+		httpx.Abort(c, httpx.Unavailable("audio previews are not configured"))
+		return
+	}
+
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	audio, err := h.audio.Preview(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Upstream("failed to generate preview audio", err))
+		return
+	}
+	if audio == nil {
+		httpx.Abort(c, httpx.NotFound("preview not found"))
+		return
+	}
+
+	if audio.URL != "" {
+		// Shorter than the link's lifetime so a cached redirect still works
+		c.Header("Cache-Control", "private, max-age=300")
+		c.Redirect(http.StatusFound, audio.URL)
+		return
+	}
+
+	r, err := audio.Open()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to read preview audio", err))
+		return
+	}
+	defer r.Close()
+
+	c.Header("Content-Type", audio.ContentType)
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Header("ETag", audio.ETag)
+	serveBlob(c, r, audio.GeneratedAt)
+}
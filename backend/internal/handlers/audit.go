@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// auditTargetKey holds a target set by a handler, e.g. the ID of a created
+// resource, which the route parameters cannot name.
+const auditTargetKey = "audit.target"
+
+type AuditHandler struct {
+	audit *service.AuditService
+}
+
+func NewAuditHandler(audit *service.AuditService) *AuditHandler {
+	return &AuditHandler{audit: audit}
+}
+
+// Audit returns middleware that records action once the request has been
+// handled, with who made it and the response status. Use it after the auth
+// middleware so the actor is known.
+func (h *AuditHandler) Audit(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		status := c.Writer.Status()
+		entry := repository.AuditEntry{
+			Action: action,
+			Target: auditTarget(c),
+			Method: c.Request.Method,
+			Path:   c.Request.URL.Path,
+			Status: &status,
+			IP:     c.ClientIP(),
+		}
+		switch user := auth.CurrentUser(c); {
+		case user != nil:
+			entry.ActorType, entry.Actor, entry.UserID = service.AuditActorUser, user.Email, &user.ID
+		case auth.IsAdmin(c):
+			entry.ActorType, entry.Actor = service.AuditActorAdmin, "admin token"
+		}
+		if query := c.Request.URL.RawQuery; query != "" {
+			entry.Details, _ = json.Marshal(map[string]string{"query": query})
+		}
+
+		if err := h.audit.Record(entry); err != nil {
+			log.Error().Err(err).Str("action", action).Msg("Failed to record audit entry")
+		}
+	}
+}
+
+// setAuditTarget names what an audited request acted on.
+func setAuditTarget(c *gin.Context, target string) {
+	c.Set(auditTargetKey, target)
+}
+
+// auditTarget returns the target set by the handler, or the route
+// parameters, e.g. "id=3 keyId=7".
+func auditTarget(c *gin.Context) string {
+	if target := c.GetString(auditTargetKey); target != "" {
+		return target
+	}
+
+	params := make([]string, 0, len(c.Params))
+	for _, p := range c.Params {
+		params = append(params, p.Key+"="+p.Value)
+	}
+	return strings.Join(params, " ")
+}
+
+// List returns audit entries, newest first. Query: action (prefix, e.g.
+// "key."), actorType, actor, since and until (RFC 3339, or YYYY-MM-DD in the
+// request's time zone), limit.
+func (h *AuditHandler) List(c *gin.Context) {
+	var query struct {
+		Action    string `form:"action" binding:"max=100"`
+		ActorType string `form:"actorType" binding:"max=50"`
+		Actor     string `form:"actor" binding:"max=200"`
+		Since     string `form:"since"`
+		Until     string `form:"until"`
+		Limit     int    `form:"limit,default=100" binding:"min=1,max=1000"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	invalid := map[string]string{}
+	since, err := parseAuditTime(query.Since, httpx.Location(c))
+	if err != nil {
+		invalid["since"] = "must be an RFC 3339 time or a date (YYYY-MM-DD)"
+	}
+	until, err := parseAuditTime(query.Until, httpx.Location(c))
+	if err != nil {
+		invalid["until"] = "must be an RFC 3339 time or a date (YYYY-MM-DD)"
+	}
+	if len(invalid) > 0 {
+		httpx.Abort(c, httpx.InvalidFields(invalid))
+		return
+	}
+
+	filter := repository.AuditFilter{
+		Action:    query.Action,
+		ActorType: query.ActorType,
+		Actor:     query.Actor,
+		Since:     since,
+		Until:     until,
+		Limit:     query.Limit,
+	}
+
+	entries, err := h.audit.List(filter)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list audit entries", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": len(entries), "entries": entries})
+}
+
+// parseAuditTime parses an RFC 3339 time or a date starting in loc, returning
+// nil for "".
+func parseAuditTime(v string, loc *time.Location) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = time.ParseInLocation(httpx.DateLayout, v, loc); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
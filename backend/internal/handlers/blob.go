@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/pkg/blob"
+)
+
+type BlobHandler struct {
+	store blob.Store
+}
+
+func NewBlobHandler(store blob.Store) *BlobHandler {
+	return &BlobHandler{store: store}
+}
+
+// GetBlob serves an object of the local blob store to holders of a signed
+// link. Other backends sign links to the bucket itself, so the route only
+// answers for the local one.
+func (h *BlobHandler) GetBlob(c *gin.Context) {
+	local, ok := h.store.(*blob.Local)
+	if !ok {
+		httpx.Abort(c, httpx.NotFound("blob not found"))
+		return
+	}
+
+	var query struct {
+		Expires   string `form:"expires" binding:"required"`
+		Signature string `form:"signature" binding:"required"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if err := local.Verify(key, query.Expires, query.Signature); err != nil {
+		httpx.Abort(c, httpx.NotFound("blob not found"))
+		return
+	}
+
+	r, obj, err := h.store.Get(key)
+	if errors.Is(err, blob.ErrNotFound) {
+		httpx.Abort(c, httpx.NotFound("blob not found"))
+		return
+	}
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to read blob", err))
+		return
+	}
+	defer r.Close()
+
+	if obj.ContentType != "" {
+		c.Header("Content-Type", obj.ContentType)
+	}
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Content-Type-Options", "nosniff")
+	serveBlob(c, r, obj.ModTime)
+}
+
+// serveBlob writes a stored object, with range and conditional request
+// support when the backend's reader can seek, as local files can.
+func serveBlob(c *gin.Context, r io.Reader, modTime time.Time) {
+	if rs, ok := r.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, "", modTime, rs)
+		return
+	}
+
+	if etag := c.Writer.Header().Get("ETag"); etag != "" && c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, r)
+}
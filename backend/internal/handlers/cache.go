@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type CacheHandler struct {
+	football *service.FootballService
+}
+
+func NewCacheHandler(football *service.FootballService) *CacheHandler {
+	return &CacheHandler{football: football}
+}
+
+// Get returns how long each entity is cached and the keys this instance
+// holds, with their ages.
+func (h *CacheHandler) Get(c *gin.Context) {
+	ttls := make(map[string]string)
+	for name, ttl := range h.football.CacheTTLs() {
+		ttls[name] = ttl.String()
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"ttls":    ttls,
+		"entries": h.football.CacheEntries(),
+	})
+}
+
+// Invalidate removes this instance's cached keys starting with the prefix
+// query parameter, e.g. "standings:PL:", or every key without one.
+func (h *CacheHandler) Invalidate(c *gin.Context) {
+	removed := h.football.InvalidateCache(c.Query("prefix"))
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/pkg/ical"
+)
+
+// calendarCacheControl lets calendar clients and proxies reuse a feed for
+// an hour; the feed itself asks subscribers to refresh every few hours.
+const calendarCacheControl = "public, max-age=3600"
+
+// writeCalendar renders cal as an iCalendar file named name.ics.
+func writeCalendar(c *gin.Context, cal *ical.Calendar, name string) {
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf); err != nil {
+		httpx.Abort(c, httpx.Internal("failed to encode calendar", err))
+		return
+	}
+
+	c.Header("Cache-Control", calendarCacheControl)
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, name))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// previewCacheControl lets browsers and CDNs share a matchday preview for
+// five minutes.
+const previewCacheControl = "public, max-age=300"
+
+type CompetitionHandler struct {
+	service  *service.CompetitionService
+	previews *service.PreviewService
+}
+
+func NewCompetitionHandler(service *service.CompetitionService, previews *service.PreviewService) *CompetitionHandler {
+	return &CompetitionHandler{service: service, previews: previews}
+}
+
+// GetCompetition returns a stored competition with its seasons, match
+// counts per season, current matchday and which detail data is available.
+func (h *CompetitionHandler) GetCompetition(c *gin.Context) {
+	var path struct {
+		Code string `uri:"code" binding:"required,competition"`
+	}
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	meta, err := h.service.GetMetadata(path.Code)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get competition", err))
+		return
+	}
+
+	if meta == nil {
+		httpx.Abort(c, httpx.NotFound("competition not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, meta)
+}
+
+// GetMatchday returns one round of a competition with its fixtures, results
+// and table movement. The round is a number or "current".
+// Query: season (start year, default latest).
+func (h *CompetitionHandler) GetMatchday(c *gin.Context) {
+	var path struct {
+		Code string `uri:"code" binding:"required,competition"`
+		N    string `uri:"n" binding:"required,matchday"`
+	}
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	round, err := h.service.GetMatchday(path.Code, query.Season, matchdayNumber(path.N))
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get matchday", err))
+		return
+	}
+
+	if round == nil {
+		httpx.Abort(c, httpx.NotFound("matchday not found"))
+		return
+	}
+	localizeMatches(round.Matches, httpx.Location(c))
+
+	c.JSON(http.StatusOK, round)
+}
+
+// GetPreview returns a preview of every fixture in a round: prediction,
+// head-to-head record, key and unavailable players, streaks and a narrative.
+// Query: matchday (number, default current), season (start year, default
+// latest).
+func (h *CompetitionHandler) GetPreview(c *gin.Context) {
+	var path struct {
+		Code string `uri:"code" binding:"required,competition"`
+	}
+	var query struct {
+		Matchday string `form:"matchday" binding:"omitempty,matchday"`
+		Season   string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	preview, err := h.previews.GetPreview(path.Code, query.Season, matchdayNumber(query.Matchday), httpx.Location(c))
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to build preview", err))
+		return
+	}
+
+	if preview == nil {
+		httpx.Abort(c, httpx.NotFound("matchday not found"))
+		return
+	}
+
+	c.Header("Cache-Control", previewCacheControl)
+	c.Header("ETag", preview.ETag)
+	if c.GetHeader("If-None-Match") == preview.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// GetFixturesCalendar returns a competition's fixtures and recent results as
+// an iCalendar feed to subscribe to. Each match keeps its UID across
+// refreshes and bumps its SEQUENCE when rescheduled.
+func (h *CompetitionHandler) GetFixturesCalendar(c *gin.Context) {
+	var path struct {
+		Code string `uri:"code" binding:"required,competition"`
+	}
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	cal, err := h.service.FixturesCalendar(path.Code)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to build calendar", err))
+		return
+	}
+
+	if cal == nil {
+		httpx.Abort(c, httpx.NotFound("competition not found"))
+		return
+	}
+
+	writeCalendar(c, cal, strings.ToLower(path.Code)+"_fixtures")
+}
+
+// GetBracket returns the knockout tree of a competition season assembled
+// from stored matches: rounds, ties with their legs and aggregate score, and
+// the tie each winner advances to.
+// Query: season (start year, default latest).
+func (h *CompetitionHandler) GetBracket(c *gin.Context) {
+	var path struct {
+		Code string `uri:"code" binding:"required,competition"`
+	}
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	bracket, err := h.service.GetBracket(path.Code, query.Season)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to build bracket", err))
+		return
+	}
+
+	if bracket == nil {
+		httpx.Abort(c, httpx.NotFound("season not found"))
+		return
+	}
+	loc := httpx.Location(c)
+	for _, round := range bracket.Rounds {
+		for _, tie := range round.Ties {
+			for i := range tie.Legs {
+				tie.Legs[i].Localize(loc)
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, bracket)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type DataQualityHandler struct {
+	service *service.DataQualityService
+}
+
+func NewDataQualityHandler(service *service.DataQualityService) *DataQualityHandler {
+	return &DataQualityHandler{service: service}
+}
+
+// Get returns per-competition counts of missing scores, player stats,
+// crests and predictions, and of predictions never settled.
+func (h *DataQualityHandler) Get(c *gin.Context) {
+	report, err := h.service.Report()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to check data quality", err))
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/digest"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type DigestHandler struct {
+	digests *service.DigestService
+}
+
+func NewDigestHandler(digests *service.DigestService) *DigestHandler {
+	return &DigestHandler{digests: digests}
+}
+
+// GetSubscription returns the user's weekly digest subscription.
+func (h *DigestHandler) GetSubscription(c *gin.Context) {
+	sub, err := h.digests.Subscription(auth.CurrentUser(c).ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get digest subscription", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+// UpdateSubscription subscribes the user to the weekly digest or
+// unsubscribes them. Body: {"subscribed": bool}.
+func (h *DigestHandler) UpdateSubscription(c *gin.Context) {
+	var req struct {
+		Subscribed *bool `json:"subscribed" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("subscribed is required"))
+		return
+	}
+
+	sub, err := h.digests.SetSubscribed(auth.CurrentUser(c).ID, *req.Subscribed)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to update digest subscription", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+// PreviewDigest renders the digest the user would get this week, as HTML or,
+// with ?format=text, plain text. Nothing is sent.
+func (h *DigestHandler) PreviewDigest(c *gin.Context) {
+	var query struct {
+		Format string `form:"format,default=html" binding:"oneof=html text"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	user := auth.CurrentUser(c)
+	d, err := h.digests.Build(user.ID, user.Name, time.Now().UTC())
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to build digest", err))
+		return
+	}
+	text, html, err := digest.Render(*d)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to render digest", err))
+		return
+	}
+
+	c.Header("X-Digest-Subject", d.Subject())
+	if query.Format == "text" {
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(text))
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// ConfirmUnsubscribe serves the page the digest's unsubscribe link opens,
+// which asks before unsubscribing.
+func (h *DigestHandler) ConfirmUnsubscribe(c *gin.Context) {
+	var query struct {
+		Token string `form:"token" binding:"required"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	page, err := digest.UnsubscribePage(query.Token)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to render page", err))
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// Unsubscribe unsubscribes the holder of an unsubscribe token, given in the
+// query as by one-click unsubscribe from mail clients (RFC 8058), or in the
+// confirmation form.
+func (h *DigestHandler) Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		token = c.PostForm("token")
+	}
+
+	found, err := h.digests.Unsubscribe(token)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to unsubscribe", err))
+		return
+	}
+	if !found {
+		httpx.Abort(c, httpx.NotFound("subscription not found"))
+		return
+	}
+
+	page, err := digest.UnsubscribePage("")
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to render page", err))
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type DrawHandler struct {
+	service *service.DrawService
+}
+
+func NewDrawHandler(service *service.DrawService) *DrawHandler {
+	return &DrawHandler{service: service}
+}
+
+// SimulateDraw simulates a Champions League knockout or World Cup group
+// draw of the qualified teams many times, returning how likely each pairing
+// is, the model's neutral-venue odds for it, and how hard a draw each team
+// can expect.
+func (h *DrawHandler) SimulateDraw(c *gin.Context) {
+	var req service.DrawRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid draw"))
+		return
+	}
+
+	simulation, err := h.service.Simulate(c.Request.Context(), req)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to simulate draw"))
+		return
+	}
+	c.JSON(http.StatusOK, simulation)
+}
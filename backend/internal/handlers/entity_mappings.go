@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type EntityMappingHandler struct {
+	service *service.EntityMappingService
+}
+
+func NewEntityMappingHandler(service *service.EntityMappingService) *EntityMappingHandler {
+	return &EntityMappingHandler{service: service}
+}
+
+type setMappingRequest struct {
+	LocalID      int    `json:"localId" binding:"required"`
+	ProviderName string `json:"providerName"`
+}
+
+type addAliasRequest struct {
+	EntityType string `json:"entityType" binding:"required"`
+	LocalID    int    `json:"localId" binding:"required"`
+	Alias      string `json:"alias" binding:"required"`
+}
+
+// List returns stored cross-provider mappings, least confident first, so
+// doubtful fuzzy matches can be reviewed. Query: type, provider,
+// maxConfidence (default 1), limit.
+func (h *EntityMappingHandler) List(c *gin.Context) {
+	var query struct {
+		Type          string  `form:"type"`
+		Provider      string  `form:"provider" binding:"max=50"`
+		MaxConfidence float64 `form:"maxConfidence,default=1" binding:"min=0,max=1"`
+		Limit         int     `form:"limit,default=100" binding:"min=1,max=500"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	entityType := strings.ToLower(query.Type)
+	if entityType != "" && !validMappingType(entityType) {
+		httpx.Abort(c, httpx.InvalidFields(map[string]string{"type": "must be one of " + strings.Join(service.MappingEntityTypes, ", ")}))
+		return
+	}
+
+	mappings, err := h.service.ListMappings(entityType, query.Provider, query.MaxConfidence, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list mappings", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"mappings": mappings})
+}
+
+// Set pins a provider ID to a local team, player or match, overriding any
+// automatic mapping.
+func (h *EntityMappingHandler) Set(c *gin.Context) {
+	entityType, providerID, ok := mappingKey(c)
+	if !ok {
+		return
+	}
+
+	var req setMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("localId is required"))
+		return
+	}
+
+	mapping, err := h.service.SetMapping(entityType, c.Param("provider"), providerID, req.LocalID, req.ProviderName)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to save mapping", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, mapping)
+}
+
+// Delete removes a mapping so it is resolved again on next use.
+func (h *EntityMappingHandler) Delete(c *gin.Context) {
+	entityType, providerID, ok := mappingKey(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.service.DeleteMapping(entityType, c.Param("provider"), providerID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to delete mapping", err))
+		return
+	}
+	if !deleted {
+		httpx.Abort(c, httpx.NotFound("mapping not found"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddAlias records another name for a team or player.
+func (h *EntityMappingHandler) AddAlias(c *gin.Context) {
+	var req addAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("entityType, localId and alias are required"))
+		return
+	}
+
+	entityType := strings.ToLower(req.EntityType)
+	if entityType != "team" && entityType != "player" {
+		httpx.Abort(c, httpx.BadRequest("aliases can only be added to teams and players"))
+		return
+	}
+
+	alias, err := h.service.AddAlias(entityType, req.LocalID, req.Alias)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to add alias"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, alias)
+}
+
+// DeleteAlias removes an alias by ID.
+func (h *EntityMappingHandler) DeleteAlias(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid alias ID"))
+		return
+	}
+
+	deleted, err := h.service.DeleteAlias(id)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to delete alias", err))
+		return
+	}
+	if !deleted {
+		httpx.Abort(c, httpx.NotFound("alias not found"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// mappingKey parses the :type and :providerId path parameters, writing a
+// 400 response and returning false if either is invalid.
+func mappingKey(c *gin.Context) (string, int, bool) {
+	entityType := strings.ToLower(c.Param("type"))
+	if !validMappingType(entityType) {
+		httpx.Abort(c, httpx.BadRequest("invalid type (valid: "+strings.Join(service.MappingEntityTypes, ", ")+")"))
+		return "", 0, false
+	}
+
+	providerID, err := strconv.Atoi(c.Param("providerId"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid provider ID"))
+		return "", 0, false
+	}
+
+	return entityType, providerID, true
+}
+
+func validMappingType(entityType string) bool {
+	for _, t := range service.MappingEntityTypes {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// serviceError passes service validation errors to the client as bad
+// requests and hides anything else behind message.
+func serviceError(err error, message string) error {
+	var invalid *service.ValidationError
+	if errors.As(err, &invalid) {
+		return httpx.BadRequest(invalid.Error())
+	}
+	return httpx.Internal(message, err)
+}
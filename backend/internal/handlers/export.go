@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/export"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type ExportHandler struct {
+	service *service.ExportService
+}
+
+func NewExportHandler(service *service.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// ExportMatches streams matches with stats, features and settled predictions.
+// Query: format (csv|parquet|json|ndjson), competition, season (start year), status,
+// delivery (stream|link). With delivery=link the export is stored and a signed
+// download link returned instead.
+func (h *ExportHandler) ExportMatches(c *gin.Context) {
+	var query struct {
+		Format      string `form:"format,default=csv" binding:"oneof=csv parquet json ndjson"`
+		Competition string `form:"competition" binding:"omitempty,competition"`
+		Season      string `form:"season" binding:"omitempty,season"`
+		Status      string `form:"status" binding:"omitempty,oneof=SCHEDULED TIMED IN_PLAY PAUSED FINISHED POSTPONED SUSPENDED CANCELLED AWARDED"`
+		Delivery    string `form:"delivery,default=stream" binding:"oneof=stream link"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+	format := query.Format
+
+	filter := repository.ExportFilter{
+		CompetitionCode: query.Competition,
+		Season:          query.Season,
+		Status:          query.Status,
+	}
+
+	if query.Delivery == "link" && !h.service.LinksEnabled() {
+		httpx.Abort(c, httpx.Unavailable("export links are not configured"))
+		return
+	}
+
+	// Large exports outlive the server's default write timeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	name := "matches"
+	if filter.CompetitionCode != "" {
+		name += "_" + strings.ToLower(filter.CompetitionCode)
+	}
+	if filter.Season != "" {
+		name += "_" + filter.Season
+	}
+
+	if query.Delivery == "link" {
+		link, err := h.service.StoreMatches(filter, format, name)
+		if err != nil {
+			httpx.Abort(c, httpx.Internal("failed to store export", err))
+			return
+		}
+		c.JSON(http.StatusCreated, link)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	c.Status(http.StatusOK)
+
+	rows, err := h.service.ExportMatches(filter, format, c.Writer)
+	if err != nil {
+		// Headers are already sent; the truncated body signals the failure.
+		log.Error().Err(err).Int("rows", rows).Msg("Export failed")
+		c.Abort()
+	}
+}
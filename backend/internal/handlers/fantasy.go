@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type FantasyHandler struct {
+	service *service.FantasyService
+}
+
+func NewFantasyHandler(service *service.FantasyService) *FantasyHandler {
+	return &FantasyHandler{service: service}
+}
+
+// GetGameweekTop returns the top scoring players of a matchday.
+// Query: competition (required), season (start year, default latest), limit.
+func (h *FantasyHandler) GetGameweekTop(c *gin.Context) {
+	var path struct {
+		N int `uri:"n" binding:"min=1"`
+	}
+	var query struct {
+		Competition string `form:"competition" binding:"required,competition"`
+		Season      string `form:"season" binding:"omitempty,season"`
+		Limit       int    `form:"limit,default=20" binding:"min=1,max=100"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	players, err := h.service.TopForGameweek(query.Competition, query.Season, path.N, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get fantasy points", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"competition": query.Competition,
+		"gameweek":    path.N,
+		"count":       len(players),
+		"players":     players,
+	})
+}
+
+// GetPlayerHistory returns a player's fantasy points per match.
+func (h *FantasyHandler) GetPlayerHistory(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Limit int `form:"limit,default=38" binding:"min=1,max=100"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	history, err := h.service.PlayerHistory(path.ID, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get player history", err))
+		return
+	}
+	if history == nil {
+		httpx.Abort(c, httpx.NotFound("player not found"))
+		return
+	}
+
+	total := 0
+	for _, row := range history {
+		total += row.Points
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"playerId":    path.ID,
+		"totalPoints": total,
+		"matches":     history,
+	})
+}
+
+// GetRules returns the scoring rules.
+func (h *FantasyHandler) GetRules(c *gin.Context) {
+	rules, err := h.service.Rules()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get scoring rules", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// UpdateRules replaces the scoring rules (admin only).
+func (h *FantasyHandler) UpdateRules(c *gin.Context) {
+	var req struct {
+		Rules []repository.ScoringRule `json:"rules" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("rules are required"))
+		return
+	}
+
+	if err := h.service.UpdateRules(req.Rules); err != nil {
+		httpx.Abort(c, serviceError(err, "failed to update rules"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": req.Rules})
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/handlers"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/internal/testutil"
+)
+
+func TestGetPlayerHistory(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFactory(t, db)
+
+	pl := f.Competition("PL")
+	arsenal := f.Team("Arsenal")
+	chelsea := f.Team("Chelsea")
+	match := f.Result(pl, arsenal, chelsea, 1, 0, time.Date(2024, 8, 17, 14, 0, 0, 0, time.UTC))
+	saka := f.Player(arsenal, "Bukayo Saka", "Offence")
+	f.PlayerStats(match, saka, testutil.PlayerStats{Goals: 1, Shots: 3})
+
+	fantasy := service.NewFantasyService(db)
+	if _, err := fantasy.ScorePending(10); err != nil {
+		t.Fatalf("ScorePending: %v", err)
+	}
+
+	var externalID int
+	if err := db.QueryRow(`SELECT external_id FROM players WHERE id = $1`, saka).Scan(&externalID); err != nil {
+		t.Fatalf("failed to read player: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/fantasy/players/:id/history", handlers.NewFantasyHandler(fantasy).GetPlayerHistory)
+
+	t.Run("scored match", func(t *testing.T) {
+		w := testutil.PerformRequest(t, router, http.MethodGet, "/api/v1/fantasy/players/"+strconv.Itoa(externalID)+"/history", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+		}
+
+		var got struct {
+			TotalPoints int `json:"totalPoints"`
+			Matches     []struct {
+				Opponent string `json:"opponent"`
+				Points   int    `json:"points"`
+			} `json:"matches"`
+		}
+		testutil.DecodeJSON(t, w, &got)
+		// A forward's 90 minutes and goal: 1 + 1 for the appearance, 4 for
+		// the goal under the seeded rules
+		if got.TotalPoints != 6 || len(got.Matches) != 1 {
+			t.Fatalf("got %d points over %d matches, want 6 over 1", got.TotalPoints, len(got.Matches))
+		}
+		if got.Matches[0].Opponent != "Chelsea" {
+			t.Errorf("opponent = %q, want Chelsea", got.Matches[0].Opponent)
+		}
+	})
+
+	t.Run("unknown player", func(t *testing.T) {
+		w := testutil.PerformRequest(t, router, http.MethodGet, "/api/v1/fantasy/players/999999/history", nil)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", w.Code, w.Body)
+		}
+	})
+}
@@ -1,29 +1,37 @@
 package handlers
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"fmt"
 	"net/http"
-	"os"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/lifecycle"
+	"github.com/yourusername/football-prediction/internal/repository"
 	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/internal/tracing"
+	"github.com/yourusername/football-prediction/pkg/football"
 )
 
 type FootballHandler struct {
-	service *service.FootballService
+	service     *service.FootballService
+	predictions *service.PredictionService
+	events      *service.EventBus
+	background  *lifecycle.Manager
 }
 
-func NewFootballHandler(service *service.FootballService) *FootballHandler {
-	return &FootballHandler{service: service}
+func NewFootballHandler(service *service.FootballService, predictions *service.PredictionService, bus *service.EventBus,
+	background *lifecycle.Manager) *FootballHandler {
+	return &FootballHandler{service: service, predictions: predictions, events: bus, background: background}
 }
 
 func (h *FootballHandler) GetCompetitions(c *gin.Context) {
 	competitions, err := h.service.GetCompetitions()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httpx.Abort(c, httpx.Upstream("failed to fetch competitions", err))
 		return
 	}
 
@@ -33,48 +41,179 @@ func (h *FootballHandler) GetCompetitions(c *gin.Context) {
 	})
 }
 
+// GetMatches returns a competition's matches from football-data.org,
+// streamed item by item. Query: competition, season (start year), format
+// (json|ndjson; NDJSON is one match per line without the envelope).
 func (h *FootballHandler) GetMatches(c *gin.Context) {
-	competition := c.Query("competition")
-	season := c.Query("season")
-
-	if competition == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "competition parameter is required"})
+	var query struct {
+		Competition string `form:"competition" binding:"required,competition"`
+		Season      string `form:"season" binding:"omitempty,season"`
+		formatParam
+	}
+	if !httpx.BindQuery(c, &query) {
 		return
 	}
 
-	matches, err := h.service.GetMatches(competition, season)
+	matches, err := h.service.GetMatches(query.Competition, query.Season)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httpx.Abort(c, httpx.Upstream("failed to fetch matches", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, matches)
+	// The response is cached and shared between requests, so localize copies
+	loc := httpx.Location(c)
+	local := make([]football.Match, len(matches.Matches))
+	for i, m := range matches.Matches {
+		m.Localize(loc)
+		local[i] = m
+	}
+
+	streamList(c, query.Format, gin.H{
+		"filters":     matches.Filters,
+		"resultSet":   matches.ResultSet,
+		"competition": matches.Competition,
+	}, "matches", local)
 }
 
 func (h *FootballHandler) GetMatch(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	fields, err := service.ParseMatchFields(c.Query("fields"))
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "invalid fields"))
+		return
+	}
+
+	detail, err := h.service.GetMatchDetail(path.ID, fields)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to load match", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, matchDetailBody(detail, fields, httpx.Location(c)))
+}
+
+// GetMatchTeamStats returns both teams' statistics for a match. Teams is
+// empty when statistics have not been ingested yet.
+func (h *FootballHandler) GetMatchTeamStats(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	stats, err := h.service.GetMatchTeamStats(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to load team statistics", err))
+		return
+	}
+	if stats == nil {
+		httpx.Abort(c, httpx.NotFound("match not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetMatchTimeline returns chart data for a stored match: the cumulative
+// score minute by minute and markers for goals, cards and substitutions.
+func (h *FootballHandler) GetMatchTimeline(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	timeline, err := h.service.GetMatchTimeline(path.ID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match ID"})
+		httpx.Abort(c, httpx.Internal("failed to build match timeline", err))
+		return
+	}
+	if timeline == nil {
+		httpx.Abort(c, httpx.NotFound("match not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
+// GetMatchStatusHistory returns every change of a match's status and
+// kickoff, such as a postponement and the new date it was moved to.
+func (h *FootballHandler) GetMatchStatusHistory(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
 		return
 	}
 
-	match, err := h.service.GetMatch(id)
+	match, changes, err := h.service.GetMatchStatusHistory(path.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httpx.Abort(c, httpx.Internal("failed to get match status history", err))
+		return
+	}
+	if match == nil {
+		httpx.Abort(c, httpx.NotFound("match not found"))
 		return
 	}
 
-	c.JSON(http.StatusOK, match)
+	c.JSON(http.StatusOK, gin.H{
+		"match":   match,
+		"changes": changes,
+	})
+}
+
+// matchDetailBody returns the core match fields, with the kickoff in loc,
+// plus the requested sections only, so fields= also trims the payload.
+func matchDetailBody(d *repository.MatchDetail, fields []string, loc *time.Location) gin.H {
+	localDate, localTime := football.LocalKickoff(d.UtcDate, loc)
+	body := gin.H{
+		"id":              d.ID,
+		"externalId":      d.ExternalID,
+		"competitionCode": d.CompetitionCode,
+		"season":          d.Season,
+		"matchday":        d.Matchday,
+		"utcDate":         d.UtcDate,
+		"localDate":       localDate,
+		"localTime":       localTime,
+		"status":          d.Status,
+		"homeTeam":        d.HomeTeam,
+		"awayTeam":        d.AwayTeam,
+	}
+
+	for _, f := range fields {
+		switch f {
+		case service.MatchFieldScore:
+			body["score"] = d.Score
+		case service.MatchFieldGoals:
+			body["goals"] = d.Goals
+		case service.MatchFieldLineups:
+			body["lineups"] = d.Lineups
+		case service.MatchFieldPlayers:
+			body["playerStats"] = d.PlayerStats
+		case service.MatchFieldReferee:
+			body["referee"] = d.Referee
+		case service.MatchFieldVenue:
+			body["venue"] = d.Venue
+		case service.MatchFieldPrediction:
+			body["prediction"] = d.Prediction
+		}
+	}
+
+	return body
 }
 
 func (h *FootballHandler) GetStandings(c *gin.Context) {
-	competition := c.Param("competition")
-	season := c.Query("season")
+	var path competitionParam
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
 
-	standings, err := h.service.GetStandings(competition, season)
+	standings, err := h.service.GetStandings(path.Competition, query.Season)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httpx.Abort(c, httpx.Upstream("failed to fetch standings", err))
 		return
 	}
 
@@ -82,53 +221,42 @@ func (h *FootballHandler) GetStandings(c *gin.Context) {
 }
 
 func (h *FootballHandler) GetPrediction(c *gin.Context) {
-	matchIDStr := c.Param("matchId")
-	matchID, err := strconv.Atoi(matchIDStr)
+	var path struct {
+		MatchID int `uri:"matchId" binding:"min=1"`
+	}
+	var query struct {
+		Refresh bool `form:"refresh"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	prediction, err := h.predictMatch(c.Request.Context(), path.MatchID, query.Refresh)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match ID"})
+		httpx.Abort(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, prediction)
+}
 
-	// Get match details from database - try external ID first (from API), then internal ID
-	matchData, err := h.service.GetMatchByExternalID(matchID)
+// predictMatch predicts a match given by its football-data.org or internal
+// ID, records the prediction of a stored upcoming match and announces it.
+func (h *FootballHandler) predictMatch(ctx context.Context, matchID int, refresh bool) (gin.H, *httpx.Error) {
+	// Upcoming matches are read from the match_prediction_inputs read model
+	// in one query; anything else is assembled from the base tables
+	inputs, err := h.predictions.MatchInputs(ctx, matchID)
 	if err != nil {
-		// If not found by external ID, try internal ID
-		matchData, err = h.service.GetMatchFromDB(matchID)
+		log.Warn().Err(err).Int("match_id", matchID).Msg("Failed to read prediction inputs")
+	}
+	if inputs == nil {
+		inputs, err = h.assemblePredictionInputs(ctx, matchID)
 		if err != nil {
-			// If still not found, fetch from API as fallback
-			match, apiErr := h.service.GetMatch(matchID)
-			if apiErr != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get match details"})
-				return
-			}
-			// Convert Match struct to map for processing
-			matchData = map[string]interface{}{
-				"id":       match.ID,
-				"matchday": match.Matchday,
-				"homeTeam": map[string]interface{}{
-					"id":         match.HomeTeam.ID,
-					"externalId": match.HomeTeam.ID,
-					"name":       match.HomeTeam.Name,
-				},
-				"awayTeam": map[string]interface{}{
-					"id":         match.AwayTeam.ID,
-					"externalId": match.AwayTeam.ID,
-					"name":       match.AwayTeam.Name,
-				},
-			}
+			return nil, httpx.Upstream("failed to get match details", err)
 		}
 	}
 
-	homeTeam := matchData["homeTeam"].(map[string]interface{})
-	awayTeam := matchData["awayTeam"].(map[string]interface{})
-	homeTeamID := homeTeam["id"].(int)
-	awayTeamID := awayTeam["id"].(int)
-	homeTeamExtID := homeTeam["externalId"].(int)
-	awayTeamExtID := awayTeam["externalId"].(int)
-
-	// Best-effort head-to-head statistics (do not fail on error)
 	var headToHead gin.H
-	if h2h, err := h.service.GetHeadToHead(homeTeamID, awayTeamID, 10); err == nil && h2h != nil {
+	if h2h := inputs.HeadToHead; h2h != nil {
 		headToHead = gin.H{
 			"homeWins": h2h.HomeWins,
 			"awayWins": h2h.AwayWins,
@@ -136,93 +264,95 @@ func (h *FootballHandler) GetPrediction(c *gin.Context) {
 		}
 	}
 
-	// Best-effort key players based on stored player_match_stats (do not fail on error)
+	// Only include key players if we have at least one on either side
 	var keyPlayers gin.H
-	if homeKP, awayKP, err := h.service.GetKeyPlayers(matchID, homeTeamID, awayTeamID, 6); err == nil {
-		// Only include if we have at least one player on either side
-		if len(homeKP) > 0 || len(awayKP) > 0 {
-			keyPlayers = gin.H{
-				"home": homeKP,
-				"away": awayKP,
-			}
+	if len(inputs.HomeKeyPlayers) > 0 || len(inputs.AwayKeyPlayers) > 0 {
+		keyPlayers = gin.H{
+			"home": inputs.HomeKeyPlayers,
+			"away": inputs.AwayKeyPlayers,
 		}
 	}
 
-	// Call ML service for prediction
-	mlServiceURL := os.Getenv("ML_SERVICE_URL")
-	if mlServiceURL == "" {
-		mlServiceURL = "http://localhost:8000"
+	internalID := inputs.MatchID
+	input := service.PredictionInput{
+		MatchID:            internalID,
+		HomeTeamID:         inputs.HomeTeamID,
+		AwayTeamID:         inputs.AwayTeamID,
+		HomeTeamExternalID: inputs.HomeTeamExternalID,
+		AwayTeamExternalID: inputs.AwayTeamExternalID,
+		HomeTeamName:       inputs.HomeTeamName,
+		AwayTeamName:       inputs.AwayTeamName,
+		Matchday:           inputs.Matchday,
 	}
+	result := h.predictions.PredictFrom(ctx, input, inputs.State, refresh)
 
-	// Prepare request payload using external IDs for ML service
-	matchday := 1 // default
-	if md, ok := matchData["matchday"].(int); ok {
-		matchday = md
-	}
+	prediction := predictionResponse(input, result)
+	prediction["matchId"] = matchID
 
-	homeTeamName := homeTeam["name"].(string)
-	awayTeamName := awayTeam["name"].(string)
-
-	payload := map[string]interface{}{
-		"home_team_id":   homeTeamExtID,
-		"away_team_id":   awayTeamExtID,
-		"matchday":       matchday,
-		"home_team_name": homeTeamName,
-		"away_team_name": awayTeamName,
+	// Attach head-to-head summary if available
+	if headToHead != nil {
+		prediction["headToHead"] = headToHead
 	}
 
-	jsonData, _ := json.Marshal(payload)
-	resp, err := http.Post(mlServiceURL+"/predict", "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		// Fallback to mock if ML service unavailable
-		c.JSON(http.StatusOK, gin.H{
-			"matchId":            matchID,
-			"homeWinProbability": 0.45,
-			"drawProbability":    0.30,
-			"awayWinProbability": 0.25,
-			"predictedOutcome":   "HOME_WIN",
-			"confidenceScore":    0.65,
-			"modelVersion":       "fallback",
-		})
-		return
+	// Attach keyPlayers if available
+	if keyPlayers != nil {
+		prediction["keyPlayers"] = keyPlayers
 	}
-	defer resp.Body.Close()
 
-	var mlResponse map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&mlResponse); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse prediction"})
-		return
+	// Record fresh pre-match predictions of stored matches so they are
+	// settled and compared per model version once the result is in
+	if internalID != 0 && !result.Cached && isUpcoming(inputs.Status) {
+		if err := h.predictions.Record(internalID, input, result); err != nil {
+			log.Warn().Err(err).Int("match_id", internalID).Msg("Failed to record prediction")
+		}
+		if len(h.predictions.Shadows()) > 0 {
+			// Finished before exit; skipped once shutdown has begun. Traced
+			// under the request, which may have ended by then
+			shadowCtx := context.WithoutCancel(ctx)
+			h.background.Go("shadow predictions", func(context.Context) {
+				if err := h.predictions.RecordShadows(shadowCtx, internalID, input, result.ModelVersion); err != nil {
+					log.Warn().Err(err).Int("match_id", internalID).Msg("Failed to record shadow predictions")
+				}
+			})
+		}
 	}
 
-	// Convert snake_case to camelCase for frontend
-	predictedOutcome := mlResponse["predicted_outcome"].(string)
-
-	// Extract winner from predicted_outcome string
-	var predictedWinner string
-	if predictedOutcome == "Draw" {
-		predictedWinner = "Draw"
-	} else if strings.Contains(predictedOutcome, homeTeamName) {
-		predictedWinner = homeTeamName
-	} else if strings.Contains(predictedOutcome, awayTeamName) {
-		predictedWinner = awayTeamName
-	} else {
-		// Fallback: parse "Team Name Win" format
-		predictedWinner = strings.TrimSuffix(predictedOutcome, " Win")
+	// Notify subscribers once per match and model version
+	if !result.Cached {
+		eventKey := fmt.Sprintf("%s:%d:%v", service.EventPredictionCreated, matchID, result.ModelVersion)
+		if err := h.events.Publish(service.EventPredictionCreated, eventKey, prediction); err != nil {
+			log.Warn().Err(err).Int("match_id", matchID).Msg("Failed to publish prediction event")
+		}
 	}
 
+	return prediction, nil
+}
+
+// predictionResponse is the body of a served prediction, without the parts
+// only a stored match has.
+func predictionResponse(input service.PredictionInput, result *service.MatchPrediction) gin.H {
 	prediction := gin.H{
-		"matchId":            matchID,
-		"homeWinProbability": mlResponse["home_win_probability"],
-		"drawProbability":    mlResponse["draw_probability"],
-		"awayWinProbability": mlResponse["away_win_probability"],
-		"predictedOutcome":   predictedOutcome,
-		"predictedWinner":    predictedWinner,
-		"confidenceScore":    mlResponse["confidence_score"],
-		"modelVersion":       mlResponse["model_version"],
+		"homeWinProbability": result.HomeWinProbability,
+		"drawProbability":    result.DrawProbability,
+		"awayWinProbability": result.AwayWinProbability,
+		"predictedOutcome":   result.PredictedOutcome,
+		"confidenceScore":    result.ConfidenceScore,
+		"modelVersion":       result.ModelVersion,
+		"predictedAt":        result.PredictedAt,
+		"cached":             result.Cached,
+	}
+	if result.PredictedWinner != "" {
+		prediction["predictedWinner"] = result.PredictedWinner
+	}
+	if result.ExpectedHomeGoals != nil && result.ExpectedAwayGoals != nil {
+		prediction["expectedGoals"] = gin.H{
+			"home": *result.ExpectedHomeGoals,
+			"away": *result.ExpectedAwayGoals,
+		}
 	}
 
 	// Map team_stats (if present) to camelCase teamStats
-	if tsRaw, ok := mlResponse["team_stats"].(map[string]interface{}); ok {
+	if tsRaw := result.TeamStats; tsRaw != nil {
 		prediction["teamStats"] = gin.H{
 			"homeForm":     tsRaw["home_form"],
 			"awayForm":     tsRaw["away_form"],
@@ -233,30 +363,213 @@ func (h *FootballHandler) GetPrediction(c *gin.Context) {
 		}
 	}
 
-	// Attach head-to-head summary if available
-	if headToHead != nil {
-		prediction["headToHead"] = headToHead
+	// Add team names for reference
+	prediction["homeTeam"] = input.HomeTeamName
+	prediction["awayTeam"] = input.AwayTeamName
+
+	if result.Insights != nil {
+		prediction["insights"] = result.Insights
 	}
 
-	// Attach keyPlayers if available
-	if keyPlayers != nil {
-		prediction["keyPlayers"] = keyPlayers
+	if result.ModelAccuracy != nil {
+		prediction["modelAccuracy"] = *result.ModelAccuracy
 	}
 
-	// ML service already provides team-specific predicted_winner
-	// Just add team names for reference
-	prediction["homeTeam"] = homeTeamName
-	prediction["awayTeam"] = awayTeamName
+	return prediction
+}
 
-	// Add insights from ML response if available
-	if insights, ok := mlResponse["insights"].([]interface{}); ok {
-		prediction["insights"] = insights
+// assemblePredictionInputs reads what a prediction needs from the stored
+// match, or from football-data.org when the match is not stored, in which
+// case MatchID is 0. Head-to-head and key players are best-effort.
+func (h *FootballHandler) assemblePredictionInputs(ctx context.Context, matchID int) (_ *repository.MatchPredictionInputs, err error) {
+	ctx, span := tracing.Start(ctx, "prediction.inputs.assemble")
+	defer func() { tracing.End(span, err) }()
+
+	var inputs *repository.MatchPredictionInputs
+
+	// Try external ID first (from API), then internal ID
+	matchData, err := h.service.GetMatchByExternalID(matchID)
+	if err != nil {
+		matchData, err = h.service.GetMatchFromDB(matchID)
+	}
+	if err == nil {
+		homeTeam := matchData["homeTeam"].(map[string]interface{})
+		awayTeam := matchData["awayTeam"].(map[string]interface{})
+		inputs = &repository.MatchPredictionInputs{
+			MatchID:            matchData["id"].(int),
+			ExternalID:         matchData["externalId"].(int),
+			Status:             matchData["status"].(string),
+			Matchday:           matchData["matchday"].(int),
+			HomeTeamID:         homeTeam["id"].(int),
+			HomeTeamExternalID: homeTeam["externalId"].(int),
+			HomeTeamName:       homeTeam["name"].(string),
+			AwayTeamID:         awayTeam["id"].(int),
+			AwayTeamExternalID: awayTeam["externalId"].(int),
+			AwayTeamName:       awayTeam["name"].(string),
+		}
+	} else {
+		// If still not found, fetch from API as fallback
+		match, err := h.service.GetMatchContext(ctx, matchID)
+		if err != nil {
+			return nil, err
+		}
+		inputs = &repository.MatchPredictionInputs{
+			ExternalID:         match.ID,
+			Matchday:           match.Matchday,
+			HomeTeamID:         match.HomeTeam.ID,
+			HomeTeamExternalID: match.HomeTeam.ID,
+			HomeTeamName:       match.HomeTeam.Name,
+			AwayTeamID:         match.AwayTeam.ID,
+			AwayTeamExternalID: match.AwayTeam.ID,
+			AwayTeamName:       match.AwayTeam.Name,
+		}
 	}
 
-	// Add model accuracy if available
-	if accuracy, ok := mlResponse["model_accuracy"].(float64); ok {
-		prediction["modelAccuracy"] = accuracy
+	// Best-effort head-to-head statistics (do not fail on error)
+	if h2h, err := h.service.GetHeadToHead(inputs.HomeTeamExternalID, inputs.AwayTeamExternalID, repository.HeadToHeadFilter{Limit: 10}); err == nil {
+		inputs.HeadToHead = h2h
 	}
 
-	c.JSON(http.StatusOK, prediction)
+	// Best-effort key players based on stored player_match_stats (do not fail on error)
+	if home, away, err := h.service.GetKeyPlayers(inputs.ExternalID, inputs.HomeTeamExternalID, inputs.AwayTeamExternalID, 6); err == nil {
+		inputs.HomeKeyPlayers, inputs.AwayKeyPlayers = home, away
+	}
+
+	return inputs, nil
+}
+
+// ComparePredictions returns every model's stored prediction for the match
+// given by the matchId query parameter, a football-data.org or internal ID,
+// with the head-to-head record of those models on settled matches.
+func (h *FootballHandler) ComparePredictions(c *gin.Context) {
+	var query struct {
+		MatchID int `form:"matchId" binding:"required,min=1"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+	matchID := query.MatchID
+
+	matchData, err := h.service.GetMatchByExternalID(matchID)
+	if err != nil {
+		if matchData, err = h.service.GetMatchFromDB(matchID); err != nil {
+			httpx.Abort(c, httpx.NotFound("match not found"))
+			return
+		}
+	}
+	internalID, ok := matchData["id"].(int)
+	if !ok {
+		httpx.Abort(c, httpx.NotFound("match not found"))
+		return
+	}
+
+	comparison, err := h.predictions.Compare(internalID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to compare predictions", err))
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
+}
+
+// GetPredictionVersions returns the version history of a stored match's
+// predictions, given by football-data.org or internal ID: every prediction
+// each model made, oldest first, with the final pre-kickoff one of each
+// model marked.
+func (h *FootballHandler) GetPredictionVersions(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	matchData, err := h.service.GetMatchByExternalID(path.ID)
+	if err != nil {
+		if matchData, err = h.service.GetMatchFromDB(path.ID); err != nil {
+			httpx.Abort(c, httpx.NotFound("match not found"))
+			return
+		}
+	}
+	internalID, ok := matchData["id"].(int)
+	if !ok {
+		httpx.Abort(c, httpx.NotFound("match not found"))
+		return
+	}
+
+	versions, err := h.predictions.Versions(internalID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list prediction versions", err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"matchId":  internalID,
+		"count":    len(versions),
+		"versions": versions,
+	})
+}
+
+// GetHeadToHead returns the record between the teams given by the home and
+// away query parameters, football-data.org IDs, over their last meetings at
+// either venue, counted as is and weighted by age and venue from home's
+// point of view, overall and broken down by competition. ?competition=
+// keeps only the meetings in one competition.
+func (h *FootballHandler) GetHeadToHead(c *gin.Context) {
+	var query struct {
+		Home            int    `form:"home" binding:"required,min=1"`
+		Away            int    `form:"away" binding:"required,min=1"`
+		CompetitionType string `form:"competitionType" binding:"omitempty,oneof=LEAGUE CUP"`
+		TeamType        string `form:"teamType" binding:"omitempty,oneof=CLUB NATIONAL"`
+		Competition     string `form:"competition" binding:"omitempty,competition"`
+		Limit           int    `form:"limit,default=10" binding:"min=1,max=50"`
+		weightingQuery
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	weighting := query.weighting()
+	record, err := h.service.GetHeadToHead(query.Home, query.Away, repository.HeadToHeadFilter{
+		CompetitionType: query.CompetitionType,
+		TeamType:        query.TeamType,
+		CompetitionCode: query.Competition,
+		Limit:           query.Limit,
+		Weighting:       &weighting,
+	})
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get head-to-head", err))
+		return
+	}
+	if record == nil {
+		record = &repository.HeadToHeadRecord{
+			Weighted:     &repository.WeightedHeadToHead{},
+			Competitions: []repository.CompetitionHeadToHead{},
+			Matches:      []repository.HeadToHeadMatch{},
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"homeTeamExternalId": query.Home,
+		"awayTeamExternalId": query.Away,
+		"weighting":          weighting,
+		"record":             record,
+	})
+}
+
+// GetModelHeadToHead returns pairwise accuracy between model versions on the
+// settled matches both predicted, optionally only for pairs with ?model=.
+func (h *FootballHandler) GetModelHeadToHead(c *gin.Context) {
+	pairs, err := h.predictions.HeadToHead(c.Query("model"))
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to compare models", err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"pairs": pairs})
+}
+
+// isUpcoming reports whether a stored match status is before kickoff.
+func isUpcoming(status interface{}) bool {
+	switch status {
+	case "SCHEDULED", "TIMED":
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/pkg/breaker"
+)
+
+// readyTimeout bounds the database ping of a readiness check.
+const readyTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	db       *sql.DB
+	breakers *breaker.Set
+}
+
+func NewHealthHandler(db *sql.DB, breakers *breaker.Set) *HealthHandler {
+	return &HealthHandler{db: db, breakers: breakers}
+}
+
+// Ready reports whether the server can take traffic. It answers 503 only
+// when the database is unreachable; an upstream whose breaker is open makes
+// the server degraded, as requests then fall back to cached or template
+// content, but still ready. The route is public, so it gives each breaker's
+// state only; the counters and failures are on /admin/breakers.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+	defer cancel()
+
+	states := make(map[string]string)
+	for _, s := range h.breakers.Statuses() {
+		states[s.Name] = s.State
+	}
+	if err := h.db.PingContext(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":   "unavailable",
+			"database": "unreachable",
+			"breakers": states,
+		})
+		return
+	}
+
+	status := "ready"
+	for _, state := range states {
+		if state != breaker.Closed {
+			status = "degraded"
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":   status,
+		"database": "ok",
+		"breakers": states,
+	})
+}
+
+// Breakers returns each upstream's breaker state with its request, failure
+// and rejection counts.
+func (h *HealthHandler) Breakers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"breakers": h.breakers.Statuses()})
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type IngestFailureHandler struct {
+	service *service.IngestFailureService
+}
+
+func NewIngestFailureHandler(service *service.IngestFailureService) *IngestFailureHandler {
+	return &IngestFailureHandler{service: service}
+}
+
+// List returns failure counts per entity type and the most recent failures.
+// Query: type, status (open|all), limit.
+func (h *IngestFailureHandler) List(c *gin.Context) {
+	var query struct {
+		Type   string `form:"type" binding:"max=50"`
+		Status string `form:"status,default=open" binding:"oneof=open all"`
+		Limit  int    `form:"limit,default=50" binding:"min=1,max=500"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	counts, err := h.service.Counts()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to count ingest failures", err))
+		return
+	}
+
+	failures, err := h.service.Recent(query.Type, query.Status == "open", query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list ingest failures", err))
+		return
+	}
+
+	open := 0
+	for _, count := range counts {
+		open += count.Open
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"open":     open,
+		"counts":   counts,
+		"failures": failures,
+	})
+}
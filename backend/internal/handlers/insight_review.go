@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type InsightReviewHandler struct {
+	reviews *service.InsightReviewService
+}
+
+func NewInsightReviewHandler(reviews *service.InsightReviewService) *InsightReviewHandler {
+	return &InsightReviewHandler{reviews: reviews}
+}
+
+// List returns generated insights that failed a quality check, newest
+// first. Query: status (pending, approved or rejected, default pending),
+// kind (recap, preview or answer), limit (default 50, max 200).
+func (h *InsightReviewHandler) List(c *gin.Context) {
+	var query struct {
+		Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+		Kind   string `form:"kind" binding:"omitempty,oneof=recap preview answer"`
+		Limit  int    `form:"limit,default=50" binding:"min=1,max=200"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	reviews, err := h.reviews.List(query.Status, query.Kind, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list insight reviews", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"count":   len(reviews),
+	})
+}
+
+type resolveReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+}
+
+// Resolve records the verdict on a queued insight: approved or rejected.
+func (h *InsightReviewHandler) Resolve(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	var req resolveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("status must be approved or rejected"))
+		return
+	}
+
+	found, err := h.reviews.Resolve(path.ID, req.Status)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to resolve insight review"))
+		return
+	}
+	if !found {
+		httpx.Abort(c, httpx.NotFound("insight review not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": path.ID, "status": req.Status})
+}
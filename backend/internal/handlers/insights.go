@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type InsightsHandler struct {
+	anomalies *service.AnomalyService
+}
+
+func NewInsightsHandler(anomalies *service.AnomalyService) *InsightsHandler {
+	return &InsightsHandler{anomalies: anomalies}
+}
+
+// ListUpsets returns flagged upsets and extreme scorelines, most recent
+// first. Query: since (date in the request's time zone or RFC 3339 time of
+// kickoff, default 7 days ago), kind (upset or scoreline, default both),
+// limit (default 50, max 200).
+func (h *InsightsHandler) ListUpsets(c *gin.Context) {
+	var query struct {
+		Since string `form:"since"`
+		Kind  string `form:"kind" binding:"omitempty,oneof=upset scoreline"`
+		Limit int    `form:"limit,default=50" binding:"min=1,max=200"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -7)
+	if query.Since != "" {
+		parsed, err := parseSince(query.Since, httpx.Location(c))
+		if err != nil {
+			httpx.Abort(c, httpx.InvalidFields(map[string]string{"since": "must be a date (YYYY-MM-DD) or RFC 3339 time"}))
+			return
+		}
+		since = parsed
+	}
+
+	anomalies, err := h.anomalies.List(since, query.Kind, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list upsets", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"since":     since,
+		"anomalies": anomalies,
+		"count":     len(anomalies),
+	})
+}
+
+func parseSince(v string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(httpx.DateLayout, v, loc); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
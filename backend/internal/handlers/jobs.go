@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/jobs"
+)
+
+type JobsHandler struct {
+	scheduler *jobs.Scheduler
+}
+
+func NewJobsHandler(scheduler *jobs.Scheduler) *JobsHandler {
+	return &JobsHandler{scheduler: scheduler}
+}
+
+// List returns the scheduled jobs of the instance serving the request, with
+// their run counters and how often they found another instance holding
+// their lock.
+func (h *JobsHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"instance": h.scheduler.Owner(),
+		"jobs":     h.scheduler.Stats(),
+	})
+}
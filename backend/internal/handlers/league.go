@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type LeagueHandler struct {
+	service *service.LeagueService
+}
+
+func NewLeagueHandler(service *service.LeagueService) *LeagueHandler {
+	return &LeagueHandler{service: service}
+}
+
+type userPredictionRequest struct {
+	HomeGoals *int `json:"homeGoals" binding:"required"`
+	AwayGoals *int `json:"awayGoals" binding:"required"`
+}
+
+type createLeagueRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Competition string `json:"competition"`
+}
+
+type joinLeagueRequest struct {
+	InviteCode string `json:"inviteCode" binding:"required"`
+}
+
+// Predict stores the user's score prediction for a fixture.
+func (h *LeagueHandler) Predict(c *gin.Context) {
+	var path struct {
+		MatchID int `uri:"matchId" binding:"min=1"`
+	}
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+	matchID := path.MatchID
+
+	var req userPredictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("homeGoals and awayGoals are required"))
+		return
+	}
+
+	found, err := h.service.Predict(auth.CurrentUser(c).ID, matchID, *req.HomeGoals, *req.AwayGoals)
+	if !found && err == nil {
+		httpx.Abort(c, httpx.NotFound("match not found"))
+		return
+	}
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to save prediction"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"matchId":   matchID,
+		"homeGoals": *req.HomeGoals,
+		"awayGoals": *req.AwayGoals,
+	})
+}
+
+// ListPredictions returns the user's predictions and their settled points.
+func (h *LeagueHandler) ListPredictions(c *gin.Context) {
+	var query struct {
+		Limit int `form:"limit,default=50" binding:"min=1,max=200"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	predictions, err := h.service.ListPredictions(auth.CurrentUser(c).ID, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list predictions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": len(predictions), "predictions": predictions})
+}
+
+// Create starts a league owned by the user.
+func (h *LeagueHandler) Create(c *gin.Context) {
+	var req createLeagueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("name is required"))
+		return
+	}
+
+	league, err := h.service.Create(auth.CurrentUser(c).ID, req.Name, req.Competition)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to create league"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, league)
+}
+
+// Join adds the user to a league by invite code.
+func (h *LeagueHandler) Join(c *gin.Context) {
+	var req joinLeagueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("inviteCode is required"))
+		return
+	}
+
+	league, err := h.service.Join(auth.CurrentUser(c).ID, req.InviteCode)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to join league", err))
+		return
+	}
+	if league == nil {
+		httpx.Abort(c, httpx.NotFound("invalid invite code"))
+		return
+	}
+
+	c.JSON(http.StatusOK, league)
+}
+
+// List returns the user's leagues.
+func (h *LeagueHandler) List(c *gin.Context) {
+	leagues, err := h.service.ListForUser(auth.CurrentUser(c).ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list leagues", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": len(leagues), "leagues": leagues})
+}
+
+// GetTable returns a league's table, ranking members against the model.
+func (h *LeagueHandler) GetTable(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	league, table, err := h.service.Table(path.ID, auth.CurrentUser(c).ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get league table", err))
+		return
+	}
+	if league == nil {
+		httpx.Abort(c, httpx.NotFound("league not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"league": league,
+		"table":  table,
+		"scoring": gin.H{
+			"exactScore":     repository.ExactScorePoints,
+			"correctOutcome": repository.CorrectOutcomePoints,
+		},
+	})
+}
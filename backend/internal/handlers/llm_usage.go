@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type LLMUsageHandler struct {
+	usage *service.LLMUsageService
+}
+
+func NewLLMUsageHandler(usage *service.LLMUsageService) *LLMUsageHandler {
+	return &LLMUsageHandler{usage: usage}
+}
+
+// Get returns the tokens and cost of LLM requests per task and model.
+// Query: days.
+func (h *LLMUsageHandler) Get(c *gin.Context) {
+	var query struct {
+		Days int `form:"days,default=30" binding:"min=1,max=365"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	summary, err := h.usage.Summary(query.Days)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to read llm usage", err))
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// localizeMatches sets the local kickoff of each match in loc.
+func localizeMatches(matches []repository.MatchSummary, loc *time.Location) {
+	for i := range matches {
+		matches[i].Localize(loc)
+	}
+}
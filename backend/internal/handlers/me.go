@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type MeHandler struct {
+	feeds *service.FeedService
+	usage *service.UsageService
+}
+
+func NewMeHandler(feeds *service.FeedService, usage *service.UsageService) *MeHandler {
+	return &MeHandler{feeds: feeds, usage: usage}
+}
+
+type followRequest struct {
+	Type string `json:"type" binding:"required"`
+	ID   string `json:"id" binding:"required"`
+}
+
+// GetMe returns the authenticated user.
+func (h *MeHandler) GetMe(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"user":   auth.CurrentUser(c),
+		"apiKey": auth.CurrentAPIKey(c),
+	})
+}
+
+// Follow follows a team (by external ID) or competition (by code).
+func (h *MeHandler) Follow(c *gin.Context) {
+	var req followRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("type and id are required"))
+		return
+	}
+
+	user := auth.CurrentUser(c)
+	found, err := h.feeds.Follow(user.ID, req.Type, req.ID)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to follow"))
+		return
+	}
+	if !found {
+		httpx.Abort(c, httpx.NotFound(req.Type+" not found"))
+		return
+	}
+
+	follows, err := h.feeds.ListFollows(user.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list follows", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"follows": follows})
+}
+
+// Unfollow removes a follow.
+func (h *MeHandler) Unfollow(c *gin.Context) {
+	user := auth.CurrentUser(c)
+	removed, err := h.feeds.Unfollow(user.ID, c.Param("type"), c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to unfollow"))
+		return
+	}
+	if !removed {
+		httpx.Abort(c, httpx.NotFound("follow not found"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFollows returns the user's followed teams and competitions.
+func (h *MeHandler) ListFollows(c *gin.Context) {
+	follows, err := h.feeds.ListFollows(auth.CurrentUser(c).ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list follows", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"follows": follows})
+}
+
+// GetFeed returns upcoming fixtures, predictions and recent results for the
+// user's followed teams and competitions.
+func (h *MeHandler) GetFeed(c *gin.Context) {
+	var query struct {
+		Days int `form:"days,default=7" binding:"min=1,max=60"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	feed, err := h.feeds.Feed(auth.CurrentUser(c).ID, query.Days)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to build feed", err))
+		return
+	}
+	loc := httpx.Location(c)
+	for _, matches := range [][]service.FeedMatch{feed.Upcoming, feed.Results} {
+		for i := range matches {
+			matches[i].Localize(loc)
+		}
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// GetUsage returns the user's monthly quota and their API usage per endpoint
+// and per day since ?since (YYYY-MM-DD in the request's time zone, default
+// the start of the month), optionally for one key with ?key=<id>.
+func (h *MeHandler) GetUsage(c *gin.Context) {
+	var query struct {
+		Since string `form:"since" binding:"omitempty,datetime=2006-01-02"`
+		Key   *int   `form:"key" binding:"omitempty,min=1"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	var since time.Time
+	if query.Since != "" {
+		since, _ = time.ParseInLocation(httpx.DateLayout, query.Since, httpx.Location(c))
+	}
+	keyID := query.Key
+
+	report, err := h.usage.Report(auth.CurrentUser(c), since, keyID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to load usage", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
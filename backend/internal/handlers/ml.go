@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type MLHandler struct {
+	predictions *service.PredictionService
+}
+
+func NewMLHandler(predictions *service.PredictionService) *MLHandler {
+	return &MLHandler{predictions: predictions}
+}
+
+// GetStats returns the ML client's workers, queue and request counters,
+// including requests shed while the queue was full and how long requests
+// waited for a worker.
+func (h *MLHandler) GetStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.predictions.MLStats())
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type NewsHandler struct {
+	service *service.NewsService
+}
+
+func NewNewsHandler(service *service.NewsService) *NewsHandler {
+	return &NewsHandler{service: service}
+}
+
+// GetTeamNews returns the news about a team from the last ?days=, newest
+// first, with the players each article mentions. ?minRelevance= drops
+// articles that only mention the team in passing: 1 keeps those naming it
+// in the headline.
+func (h *NewsHandler) GetTeamNews(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Days         int     `form:"days,default=7" binding:"min=1,max=90"`
+		MinRelevance float64 `form:"minRelevance,default=0" binding:"min=0,max=1"`
+		Limit        int     `form:"limit,default=20" binding:"min=1,max=100"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	teamNews, err := h.service.TeamNews(path.ID, query.Days, query.MinRelevance, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get team news", err))
+		return
+	}
+	if teamNews == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, teamNews)
+}
+
+// GetTeamSignals returns the injury doubts, suspensions and manager pressure
+// extracted from the news about a team over the last ?days=, newest first.
+// ?minConfidence= drops the signals the extractor was less sure of.
+func (h *NewsHandler) GetTeamSignals(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Days          int     `form:"days,default=7" binding:"min=1,max=90"`
+		MinConfidence float64 `form:"minConfidence,default=0" binding:"min=0,max=1"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	signals, err := h.service.TeamSignals(path.ID, query.Days, query.MinConfidence)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get team signals", err))
+		return
+	}
+	if signals == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, signals)
+}
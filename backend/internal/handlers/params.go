@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+var seasonYearPattern = regexp.MustCompile(`^\d{4}$`)
+
+// RegisterValidations adds the binding tags of handler parameters:
+// competition (a known competition code), season (a start year),
+// confederation (one with World Cup qualifiers) and matchday (a round number
+// or "current"). It must run before the router serves requests.
+func RegisterValidations(competitions *service.CompetitionService) error {
+	if err := httpx.RegisterValidation("competition", "must be a known competition code, e.g. PL",
+		competitions.IsKnownCode); err != nil {
+		return err
+	}
+	if err := httpx.RegisterValidation("season", "must be a start year, e.g. 2024",
+		seasonYearPattern.MatchString); err != nil {
+		return err
+	}
+	if err := httpx.RegisterValidation("confederation", "must be a confederation, e.g. UEFA",
+		func(v string) bool {
+			_, ok := football.QualifierCodes[strings.ToUpper(v)]
+			return ok
+		}); err != nil {
+		return err
+	}
+	return httpx.RegisterValidation("matchday", `must be a positive number or "current"`,
+		func(v string) bool { return v == "current" || matchdayNumber(v) > 0 })
+}
+
+// matchdayNumber returns a round number, or 0 for "current" and anything
+// else that is not a positive number.
+func matchdayNumber(v string) int {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0
+	}
+	return n
+}
+
+// idParam is a positive :id path parameter.
+type idParam struct {
+	ID int `uri:"id" binding:"min=1"`
+}
+
+// competitionParam is a :competition path parameter.
+type competitionParam struct {
+	Competition string `uri:"competition" binding:"required,competition"`
+}
+
+// weightingQuery is the optional halfLifeDays and venueWeight query
+// parameters of an endpoint returning weighted results. Either one left out
+// takes its value from service.DefaultWeighting.
+type weightingQuery struct {
+	HalfLifeDays *float64 `form:"halfLifeDays" binding:"omitempty,min=0,max=3650"`
+	VenueWeight  *float64 `form:"venueWeight" binding:"omitempty,min=0,max=1"`
+}
+
+// weighting is the weighting asked for, completed from the defaults.
+func (q weightingQuery) weighting() repository.Weighting {
+	w := service.DefaultWeighting
+	if q.HalfLifeDays != nil {
+		w.HalfLifeDays = *q.HalfLifeDays
+	}
+	if q.VenueWeight != nil {
+		w.VenueWeight = *q.VenueWeight
+	}
+	return w
+}
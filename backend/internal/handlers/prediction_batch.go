@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/httpx"
+)
+
+const (
+	// maxBatchPredictions is the most items a batch prediction request may
+	// carry.
+	maxBatchPredictions = 50
+	// batchPredictionWorkers bounds how many items of a batch are predicted
+	// at once, so one batch cannot take over the model services.
+	batchPredictionWorkers = 4
+)
+
+// batchPredictionItem is a stored match, by football-data.org or internal
+// ID, or a hypothetical fixture between two stored teams on a date,
+// optionally at a neutral venue.
+type batchPredictionItem struct {
+	MatchID    int    `json:"matchId"`
+	HomeTeamID int    `json:"homeTeamId"`
+	AwayTeamID int    `json:"awayTeamId"`
+	Date       string `json:"date"` // YYYY-MM-DD
+	Neutral    bool   `json:"neutral"`
+}
+
+type batchPredictionRequest struct {
+	Items   []batchPredictionItem `json:"items"`
+	Refresh bool                  `json:"refresh"`
+}
+
+// batchPredictionResult is the outcome of one item: its HTTP-style status
+// and either the prediction, as served by GetPrediction, or the error.
+type batchPredictionResult struct {
+	Index      int         `json:"index"`
+	Status     int         `json:"status"`
+	Prediction gin.H       `json:"prediction,omitempty"`
+	Error      *batchError `json:"error,omitempty"`
+}
+
+type batchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BatchPredictions predicts up to maxBatchPredictions matches or
+// hypothetical fixtures at once, concurrently. Results come back in request
+// order with a status each, so one failed item does not fail the batch.
+// Stored upcoming matches are recorded as by GetPrediction; hypothetical
+// fixtures are predicted from the teams' current form and not recorded.
+func (h *FootballHandler) BatchPredictions(c *gin.Context) {
+	var req batchPredictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("body must be a JSON object with an items array"))
+		return
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxBatchPredictions {
+		httpx.Abort(c, httpx.InvalidFields(map[string]string{
+			"items": fmt.Sprintf("must hold between 1 and %d items", maxBatchPredictions),
+		}))
+		return
+	}
+
+	ctx := c.Request.Context()
+	results := make([]batchPredictionResult, len(req.Items))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchPredictionWorkers)
+	for i, item := range req.Items {
+		wg.Add(1)
+		go func(i int, item batchPredictionItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = h.predictBatchItem(ctx, i, item, req.Refresh)
+		}(i, item)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, r := range results {
+		if r.Status == http.StatusOK {
+			succeeded++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":     len(results),
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
+
+func (h *FootballHandler) predictBatchItem(ctx context.Context, index int, item batchPredictionItem, refresh bool) batchPredictionResult {
+	result := batchPredictionResult{Index: index, Status: http.StatusOK}
+
+	var prediction gin.H
+	var err *httpx.Error
+	switch {
+	case item.MatchID > 0:
+		prediction, err = h.predictMatch(ctx, item.MatchID, refresh)
+	case item.HomeTeamID > 0 && item.AwayTeamID > 0 && item.Date != "":
+		prediction, err = h.predictBatchFixture(ctx, item, refresh)
+	default:
+		err = httpx.BadRequest("either matchId, or homeTeamId, awayTeamId and date are required")
+	}
+
+	if err != nil {
+		if err.Err != nil {
+			log.Warn().Err(err.Err).Int("index", index).Msg("Batch prediction item failed")
+		}
+		result.Status = err.Status
+		result.Error = &batchError{Code: err.Code, Message: err.Message}
+		return result
+	}
+	result.Prediction = prediction
+	return result
+}
+
+// predictBatchFixture predicts a hypothetical fixture of a batch, echoing
+// its date.
+func (h *FootballHandler) predictBatchFixture(ctx context.Context, item batchPredictionItem, refresh bool) (gin.H, *httpx.Error) {
+	date, err := time.Parse("2006-01-02", item.Date)
+	if err != nil {
+		return nil, httpx.BadRequest("date must be YYYY-MM-DD")
+	}
+	prediction, apiErr := h.predictFixture(ctx, item.HomeTeamID, item.AwayTeamID, item.Neutral, refresh)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	prediction["date"] = date.Format("2006-01-02")
+	return prediction, nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type simulateRequest struct {
+	HomeTeamID int  `json:"homeTeamId" binding:"required,min=1"`
+	AwayTeamID int  `json:"awayTeamId" binding:"required,min=1"`
+	Neutral    bool `json:"neutral"`
+	Refresh    bool `json:"refresh"`
+}
+
+// SimulatePrediction predicts a fixture that need not be scheduled between
+// two stored teams, given by football-data.org or internal ID, from their
+// stored results and form. With neutral set neither side gets home
+// advantage, as in a cup final. Simulations are not recorded.
+func (h *FootballHandler) SimulatePrediction(c *gin.Context) {
+	var req simulateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("homeTeamId and awayTeamId are required"))
+		return
+	}
+
+	prediction, err := h.predictFixture(c.Request.Context(), req.HomeTeamID, req.AwayTeamID, req.Neutral, req.Refresh)
+	if err != nil {
+		httpx.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, prediction)
+}
+
+// predictFixture predicts a hypothetical fixture between two stored teams,
+// each given by its football-data.org or internal ID.
+func (h *FootballHandler) predictFixture(ctx context.Context, homeTeamID, awayTeamID int, neutral, refresh bool) (gin.H, *httpx.Error) {
+	if homeTeamID == awayTeamID {
+		return nil, httpx.BadRequest("homeTeamId and awayTeamId must differ")
+	}
+
+	home, err := h.service.GetTeam(homeTeamID)
+	if err != nil {
+		return nil, httpx.Internal("failed to get team", err)
+	}
+	away, err := h.service.GetTeam(awayTeamID)
+	if err != nil {
+		return nil, httpx.Internal("failed to get team", err)
+	}
+	if home == nil || away == nil {
+		return nil, httpx.NotFound("team not found")
+	}
+
+	input := service.PredictionInput{
+		HomeTeamID:         home.ID,
+		AwayTeamID:         away.ID,
+		HomeTeamExternalID: home.ExternalID,
+		AwayTeamExternalID: away.ExternalID,
+		HomeTeamName:       home.Name,
+		AwayTeamName:       away.Name,
+		Neutral:            neutral,
+	}
+	result := h.predictions.PredictFrom(ctx, input, nil, refresh)
+
+	prediction := predictionResponse(input, result)
+	prediction["homeTeamId"] = home.ExternalID
+	prediction["awayTeamId"] = away.ExternalID
+	prediction["neutral"] = neutral
+	return prediction, nil
+}
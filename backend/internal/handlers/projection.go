@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type ProjectionHandler struct {
+	service *service.ProjectionService
+}
+
+func NewProjectionHandler(service *service.ProjectionService) *ProjectionHandler {
+	return &ProjectionHandler{service: service}
+}
+
+// GetProjected returns a competition's table projected to the end of the
+// season from stored results and simulated remaining fixtures, ranked by
+// the same tie-break rules as the computed table. Query: season (start
+// year, default latest).
+func (h *ProjectionHandler) GetProjected(c *gin.Context) {
+	var path competitionParam
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	projection, err := h.service.Project(c.Request.Context(), path.Competition, query.Season)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to project standings", err))
+		return
+	}
+
+	if projection == nil {
+		httpx.Abort(c, httpx.NotFound("season not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, projection)
+}
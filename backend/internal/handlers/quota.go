@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/pkg/football"
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+type QuotaHandler struct {
+	quotas   *quota.Manager
+	football *service.FootballService
+}
+
+func NewQuotaHandler(quotas *quota.Manager, football *service.FootballService) *QuotaHandler {
+	return &QuotaHandler{quotas: quotas, football: football}
+}
+
+// Get returns each provider key's requests this minute and this UTC day
+// against its budget, counted across every process sharing the key, and
+// how the keys this instance rotates over have fared.
+func (h *QuotaHandler) Get(c *gin.Context) {
+	providers, err := h.quotas.Status()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to read provider quotas", err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"providers": providers,
+		"keys": gin.H{
+			football.Provider: h.football.KeyStats(),
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type RecapHandler struct {
+	service *service.RecapService
+}
+
+func NewRecapHandler(service *service.RecapService) *RecapHandler {
+	return &RecapHandler{service: service}
+}
+
+// GetRecap returns the post-match recap for a match external ID.
+func (h *RecapHandler) GetRecap(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	recap, err := h.service.GetRecap(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get recap", err))
+		return
+	}
+
+	if recap == nil {
+		httpx.Abort(c, httpx.NotFound("recap not available yet"))
+		return
+	}
+
+	c.JSON(http.StatusOK, recap)
+}
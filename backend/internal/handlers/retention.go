@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type RetentionHandler struct {
+	retention *service.RetentionService
+}
+
+func NewRetentionHandler(retention *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{retention: retention}
+}
+
+// Get returns the retention policies and the most recent runs. Query: limit.
+func (h *RetentionHandler) Get(c *gin.Context) {
+	var query struct {
+		Limit int `form:"limit,default=50" binding:"min=1,max=500"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	runs, err := h.retention.Runs(query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list retention runs", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policies": h.retention.Policies(),
+		"runs":     runs,
+	})
+}
+
+// Run applies the enabled retention policies now, or only ?policy=<name>.
+func (h *RetentionHandler) Run(c *gin.Context) {
+	runs, err := h.retention.Run(c.Query("policy"), service.RetentionTriggerAdmin)
+	if errors.Is(err, service.ErrRetentionRunning) {
+		httpx.Abort(c, httpx.Conflict(err.Error()))
+		return
+	}
+	if err != nil && len(runs) > 0 {
+		httpx.Abort(c, httpx.Internal("retention failed", err).WithDetails(map[string]any{"runs": runs}))
+		return
+	}
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "retention failed"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type SearchHandler struct {
+	service *service.SearchService
+}
+
+func NewSearchHandler(service *service.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search returns teams, players and competitions matching q, best match
+// first. Query: q (at least 2 characters), type (comma-separated
+// team|player|competition), limit.
+func (h *SearchHandler) Search(c *gin.Context) {
+	var params struct {
+		Q     string `form:"q" binding:"required,max=100"`
+		Type  string `form:"type"`
+		Limit int    `form:"limit,default=20" binding:"min=1,max=100"`
+	}
+	if !httpx.BindQuery(c, &params) {
+		return
+	}
+
+	query := service.NormalizeSearchQuery(params.Q)
+	if len([]rune(query)) < 2 {
+		httpx.Abort(c, httpx.InvalidFields(map[string]string{"q": "must be at least 2 characters"}))
+		return
+	}
+
+	kinds, err := service.ParseSearchTypes(params.Type)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "invalid type"))
+		return
+	}
+
+	results, err := h.service.Search(query, kinds, params.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("search failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":   query,
+		"count":   len(results),
+		"results": results,
+	})
+}
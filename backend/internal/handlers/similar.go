@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type SimilarityHandler struct {
+	service *service.SimilarityService
+}
+
+func NewSimilarityHandler(service *service.SimilarityService) *SimilarityHandler {
+	return &SimilarityHandler{service: service}
+}
+
+// GetSimilarMatches returns the ?limit= past matches most like a match by
+// form, head-to-head, strength gap and stakes at kickoff, nearest first,
+// with how they ended.
+func (h *SimilarityHandler) GetSimilarMatches(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Limit int `form:"limit,default=10" binding:"min=1,max=50"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	similar, err := h.service.Similar(path.ID, query.Limit)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to find similar matches", err))
+		return
+	}
+	if similar == nil {
+		httpx.Abort(c, httpx.NotFound("match not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, similar)
+}
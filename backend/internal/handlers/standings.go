@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type StandingsHandler struct {
+	service *service.StandingsService
+}
+
+func NewStandingsHandler(service *service.StandingsService) *StandingsHandler {
+	return &StandingsHandler{service: service}
+}
+
+// GetComputed returns a competition's table derived from stored results
+// rather than the provider's standings, which also covers historical
+// seasons. Query: season (start year, default latest).
+func (h *StandingsHandler) GetComputed(c *gin.Context) {
+	var path competitionParam
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	standings, err := h.service.Compute(path.Competition, query.Season)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to compute standings", err))
+		return
+	}
+
+	if standings == nil {
+		httpx.Abort(c, httpx.NotFound("season not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
+
+// GetRules returns the points and tie-break order used for a competition
+// (admin only).
+func (h *StandingsHandler) GetRules(c *gin.Context) {
+	var path competitionParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	rules, err := h.service.Rules(path.Competition)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get standings rules", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules, "tieBreakers": service.TieBreakers})
+}
+
+// UpdateRules replaces the points and tie-break order of a competition
+// (admin only).
+func (h *StandingsHandler) UpdateRules(c *gin.Context) {
+	var path competitionParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	var req struct {
+		PointsWin   int      `json:"pointsWin" binding:"required"`
+		PointsDraw  int      `json:"pointsDraw"`
+		PointsLoss  int      `json:"pointsLoss"`
+		TieBreakers []string `json:"tieBreakers"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("pointsWin is required"))
+		return
+	}
+
+	rules := repository.StandingsRules{
+		CompetitionCode: path.Competition,
+		PointsWin:       req.PointsWin,
+		PointsDraw:      req.PointsDraw,
+		PointsLoss:      req.PointsLoss,
+		TieBreakers:     req.TieBreakers,
+	}
+	if err := h.service.UpdateRules(rules); err != nil {
+		httpx.Abort(c, serviceError(err, "failed to update rules"))
+		return
+	}
+
+	saved, err := h.service.Rules(rules.CompetitionCode)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get standings rules", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": saved})
+}
+
+// GetQualifiers returns the World Cup qualifying groups of a confederation
+// computed from stored matches, with each team's qualification scenario.
+// Query: season (start year, default latest).
+func (h *StandingsHandler) GetQualifiers(c *gin.Context) {
+	var path struct {
+		Confederation string `uri:"confederation" binding:"required,confederation"`
+	}
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	qualifiers, err := h.service.Qualifiers(path.Confederation, query.Season)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to compute qualifiers"))
+		return
+	}
+
+	if qualifiers == nil {
+		httpx.Abort(c, httpx.NotFound("no qualifiers stored for confederation"))
+		return
+	}
+
+	c.JSON(http.StatusOK, qualifiers)
+}
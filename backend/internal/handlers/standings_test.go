@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/handlers"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+	"github.com/yourusername/football-prediction/internal/testutil"
+)
+
+func TestGetComputedStandings(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFactory(t, db)
+
+	pl := f.Competition("PL")
+	arsenal := f.Team("Arsenal")
+	chelsea := f.Team("Chelsea")
+	everton := f.Team("Everton")
+
+	kickoff := time.Date(2024, 8, 17, 14, 0, 0, 0, time.UTC)
+	f.Result(pl, arsenal, chelsea, 2, 0, kickoff)
+	f.Result(pl, chelsea, everton, 2, 2, kickoff.AddDate(0, 0, 7))
+	f.Result(pl, everton, arsenal, 1, 3, kickoff.AddDate(0, 0, 14))
+	// A cancelled match is neither counted nor left to play
+	f.Match(testutil.Match{CompetitionID: pl, HomeTeamID: chelsea, AwayTeamID: arsenal, UTCDate: kickoff.AddDate(0, 0, 21), Status: "CANCELLED"})
+
+	if err := handlers.RegisterValidations(service.NewCompetitionService(db)); err != nil {
+		t.Fatalf("RegisterValidations: %v", err)
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(httpx.Errors())
+	router.GET("/api/v1/standings/:competition/computed", handlers.NewStandingsHandler(service.NewStandingsService(db)).GetComputed)
+	// A finished season needs no predictions
+	router.GET("/api/v1/standings/:competition/projected", handlers.NewProjectionHandler(service.NewProjectionService(db, nil)).GetProjected)
+
+	t.Run("computed", func(t *testing.T) {
+		w := testutil.PerformRequest(t, router, http.MethodGet, "/api/v1/standings/PL/computed?season=2024", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+		}
+
+		var got struct {
+			MatchesCounted int `json:"matchesCounted"`
+			Table          []struct {
+				TeamName string `json:"teamName"`
+			} `json:"table"`
+		}
+		testutil.DecodeJSON(t, w, &got)
+		var order []string
+		for _, row := range got.Table {
+			order = append(order, row.TeamName)
+		}
+		if want := []string{"Arsenal", "Everton", "Chelsea"}; !reflect.DeepEqual(order, want) || got.MatchesCounted != 3 {
+			t.Errorf("order = %v over %d matches, want %v over 3", order, got.MatchesCounted, want)
+		}
+	})
+
+	t.Run("projected finished season", func(t *testing.T) {
+		w := testutil.PerformRequest(t, router, http.MethodGet, "/api/v1/standings/PL/projected", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+		}
+
+		var got struct {
+			MatchesRemaining int `json:"matchesRemaining"`
+			Simulations      int `json:"simulations"`
+			Table            []struct {
+				TeamName  string    `json:"teamName"`
+				Positions []float64 `json:"positions"`
+			} `json:"table"`
+		}
+		testutil.DecodeJSON(t, w, &got)
+		if got.MatchesRemaining != 0 || got.Simulations != 0 || len(got.Table) != 3 {
+			t.Fatalf("got %+v, want the 3 teams' final table without simulations", got)
+		}
+		for i, row := range got.Table {
+			if row.Positions[i] != 1 {
+				t.Errorf("%s finishes %d with probability %v, want 1", row.TeamName, i+1, row.Positions[i])
+			}
+		}
+	})
+
+	t.Run("season not stored", func(t *testing.T) {
+		w := testutil.PerformRequest(t, router, http.MethodGet, "/api/v1/standings/PL/computed?season=2019", nil)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want 404: %s", w.Code, w.Body)
+		}
+	})
+
+	t.Run("invalid season", func(t *testing.T) {
+		w := testutil.PerformRequest(t, router, http.MethodGet, "/api/v1/standings/PL/projected?season=latest", nil)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400: %s", w.Code, w.Body)
+		}
+	})
+}
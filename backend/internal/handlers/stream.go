@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/httpx"
+)
+
+// formatParam is the format query parameter of streamed list endpoints.
+type formatParam struct {
+	Format string `form:"format,default=json" binding:"oneof=json ndjson"`
+}
+
+// streamList writes items under key of envelope, or as NDJSON, encoding one
+// item at a time. Once the stream has started a failure can only truncate
+// the body, so it is logged.
+func streamList[T any](c *gin.Context, format string, envelope any, key string, items []T) {
+	stream, err := httpx.StreamList(c, format, envelope, key)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to encode response", err))
+		return
+	}
+
+	for i := range items {
+		if err = stream.Write(&items[i]); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = stream.Close()
+	}
+	if err != nil {
+		log.Error().Err(err).Str("path", c.FullPath()).Int("written", stream.Count()).Msg("Failed to stream list")
+		c.Abort()
+	}
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type TeamHandler struct {
+	service *service.TeamService
+	streaks *service.StreakService
+}
+
+func NewTeamHandler(service *service.TeamService, streaks *service.StreakService) *TeamHandler {
+	return &TeamHandler{service: service, streaks: streaks}
+}
+
+// GetSeasonStats returns a team's season statistics computed from stored
+// matches. Query: season (start year, required), competition.
+func (h *TeamHandler) GetSeasonStats(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Season      string `form:"season" binding:"required,season"`
+		Competition string `form:"competition" binding:"omitempty,competition"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	stats, err := h.service.GetSeasonStats(path.ID, query.Season, query.Competition)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to compute season stats", err))
+		return
+	}
+
+	if stats == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetMatches returns a team's fixtures and results across all competitions.
+// Query: status (e.g. SCHEDULED), venue (home|away), competition, season
+// (start year), competitionType (LEAGUE|CUP), teamType (CLUB|NATIONAL),
+// limit (default 20, max 100), format (json|ndjson).
+func (h *TeamHandler) GetMatches(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Status          string `form:"status" binding:"omitempty,oneof=SCHEDULED TIMED IN_PLAY PAUSED FINISHED POSTPONED SUSPENDED CANCELLED AWARDED"`
+		Venue           string `form:"venue" binding:"omitempty,oneof=home away"`
+		Competition     string `form:"competition" binding:"omitempty,competition"`
+		Season          string `form:"season" binding:"omitempty,season"`
+		CompetitionType string `form:"competitionType" binding:"omitempty,oneof=LEAGUE CUP"`
+		TeamType        string `form:"teamType" binding:"omitempty,oneof=CLUB NATIONAL"`
+		Limit           int    `form:"limit,default=20" binding:"min=1,max=100"`
+		formatParam
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	fixtures, err := h.service.ListMatches(path.ID, repository.TeamMatchFilter{
+		Venue:           query.Venue,
+		Season:          query.Season,
+		CompetitionCode: query.Competition,
+		Status:          query.Status,
+		CompetitionType: query.CompetitionType,
+		TeamType:        query.TeamType,
+		Limit:           query.Limit,
+	})
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list team matches", err))
+		return
+	}
+
+	if fixtures == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+	localizeMatches(fixtures.Matches, httpx.Location(c))
+
+	streamList(c, query.Format, gin.H{
+		"team":  fixtures.Team,
+		"count": len(fixtures.Matches),
+	}, "matches", fixtures.Matches)
+}
+
+// GetFixturesCalendar returns a team's fixtures and recent results as an
+// iCalendar feed to subscribe to. Each match keeps its UID across refreshes
+// and bumps its SEQUENCE when rescheduled.
+func (h *TeamHandler) GetFixturesCalendar(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	cal, err := h.service.FixturesCalendar(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to build calendar", err))
+		return
+	}
+
+	if cal == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	writeCalendar(c, cal, fmt.Sprintf("team_%d_fixtures", path.ID))
+}
+
+// GetStreaks returns a team's current streaks and upcoming milestones, its
+// players' included, as last computed by the streaks job.
+func (h *TeamHandler) GetStreaks(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	streaks, err := h.streaks.GetTeamStreaks(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get streaks", err))
+		return
+	}
+
+	if streaks == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, streaks)
+}
+
+// GetCoaches returns a team's coaching spells, latest first, with its record
+// in each, as recorded from stored lineups.
+func (h *TeamHandler) GetCoaches(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	coaches, err := h.service.Coaches(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get coaches", err))
+		return
+	}
+
+	if coaches == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, coaches)
+}
+
+// GetForm returns a team's last five results and its upcoming fixtures with
+// the days of rest and matches in the previous 14 days going into each. The
+// weighted points per game take the halfLifeDays and venueWeight query
+// parameters.
+func (h *TeamHandler) GetForm(c *gin.Context) {
+	var path idParam
+	var query weightingQuery
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	form, err := h.service.Form(path.ID, query.weighting())
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get form", err))
+		return
+	}
+
+	if form == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, form)
+}
+
+// GetSuspensionRisk lists a team's players one booking from a ban, in the
+// competitions whose accumulation rules are known.
+func (h *TeamHandler) GetSuspensionRisk(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	risk, err := h.service.SuspensionRisk(path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get suspension risk", err))
+		return
+	}
+
+	if risk == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, risk)
+}
+
+// GetSetPieceProfile returns a team's penalty scoring and takers in a
+// season. Query: season (start year, default latest).
+func (h *TeamHandler) GetSetPieceProfile(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Season string `form:"season" binding:"omitempty,season"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	profile, err := h.service.SetPieceProfile(path.ID, query.Season)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get set-piece profile", err))
+		return
+	}
+
+	if profile == nil {
+		httpx.Abort(c, httpx.NotFound("set-piece profile not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Compare returns two teams side by side over a season: form, league attack
+// and defense ranks, expected goals, Elo, key players, discipline and their
+// head-to-head record. Query: teamA, teamB (football-data.org or internal
+// IDs, required), season (start year, required).
+func (h *TeamHandler) Compare(c *gin.Context) {
+	var query struct {
+		TeamA  int    `form:"teamA" binding:"required,min=1"`
+		TeamB  int    `form:"teamB" binding:"required,min=1,nefield=TeamA"`
+		Season string `form:"season" binding:"required,season"`
+	}
+	if !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	comparison, err := h.service.Compare(query.TeamA, query.TeamB, query.Season)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to compare teams", err))
+		return
+	}
+
+	if comparison == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+// GetGoalTimings returns when a team scores and concedes, in 15-minute
+// intervals, and the shares of its goals after minute 75. Query: season
+// (start year), competition; both default to every stored match.
+func (h *TeamHandler) GetGoalTimings(c *gin.Context) {
+	var path idParam
+	var query struct {
+		Season      string `form:"season" binding:"omitempty,season"`
+		Competition string `form:"competition" binding:"omitempty,competition"`
+	}
+	if !httpx.BindURI(c, &path) || !httpx.BindQuery(c, &query) {
+		return
+	}
+
+	timings, err := h.service.GoalTimings(path.ID, query.Season, query.Competition)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to compute goal timings", err))
+		return
+	}
+
+	if timings == nil {
+		httpx.Abort(c, httpx.NotFound("team not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, timings)
+}
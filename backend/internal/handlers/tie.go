@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type TieHandler struct {
+	service *service.TieService
+}
+
+func NewTieHandler(service *service.TieService) *TieHandler {
+	return &TieHandler{service: service}
+}
+
+// GetTie returns a two-legged knockout tie, as linked in the bracket, with
+// its aggregate score and away goals after the finished legs and how likely
+// each side is to go through.
+func (h *TieHandler) GetTie(c *gin.Context) {
+	var path idParam
+	if !httpx.BindURI(c, &path) {
+		return
+	}
+
+	tie, err := h.service.GetTie(c.Request.Context(), path.ID)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to get tie", err))
+		return
+	}
+	if tie == nil {
+		httpx.Abort(c, httpx.NotFound("tie not found"))
+		return
+	}
+
+	loc := httpx.Location(c)
+	for i := range tie.Legs {
+		tie.Legs[i].Localize(loc)
+	}
+	c.JSON(http.StatusOK, tie)
+}
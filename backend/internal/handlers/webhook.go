@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/football-prediction/internal/httpx"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(service *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+type registerWebhookRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	Events      []string `json:"events" binding:"required"`
+	Description string   `json:"description"`
+}
+
+// Register creates a webhook. The signing secret is only returned here.
+func (h *WebhookHandler) Register(c *gin.Context) {
+	var req registerWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpx.Abort(c, httpx.BadRequest("url and events are required"))
+		return
+	}
+
+	webhook, secret, err := h.service.Register(req.URL, req.Events, req.Description)
+	if err != nil {
+		httpx.Abort(c, serviceError(err, "failed to register webhook"))
+		return
+	}
+	setAuditTarget(c, fmt.Sprintf("id=%d", webhook.ID))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": webhook,
+		"secret":  secret,
+	})
+}
+
+// Delete removes a webhook by ID.
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		httpx.Abort(c, httpx.BadRequest("invalid webhook ID"))
+		return
+	}
+
+	deleted, err := h.service.Delete(id)
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to delete webhook", err))
+		return
+	}
+	if !deleted {
+		httpx.Abort(c, httpx.NotFound("webhook not found"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List returns all webhooks with delivery statistics (admin only).
+func (h *WebhookHandler) List(c *gin.Context) {
+	webhooks, err := h.service.List()
+	if err != nil {
+		httpx.Abort(c, httpx.Internal("failed to list webhooks", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":    len(webhooks),
+		"webhooks": webhooks,
+		"events":   service.WebhookEvents,
+	})
+}
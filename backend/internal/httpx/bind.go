@@ -0,0 +1,180 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// DateLayout is the format of date parameters, for the datetime tag.
+const DateLayout = "2006-01-02"
+
+// messages holds the messages of validation tags added with
+// RegisterValidation.
+var messages = map[string]string{}
+
+func init() {
+	// Report fields under the parameter names clients send
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(paramName)
+	}
+}
+
+// RegisterValidation adds a binding tag for string fields, reported with
+// message when valid returns false. Tags are registered at startup, before
+// requests are served.
+func RegisterValidation(tag, message string, valid func(string) bool) error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("unexpected validator engine %T", binding.Validator.Engine())
+	}
+	err := v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+		return valid(fl.Field().String())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register %s validation: %w", tag, err)
+	}
+	messages[tag] = message
+	return nil
+}
+
+// BindQuery binds query parameters into obj by form tags and validates them
+// by binding tags. On failure it aborts with a 400 naming every invalid
+// parameter and returns false.
+func BindQuery(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		Abort(c, invalidParams(err, obj, c.GetQuery))
+		return false
+	}
+	return true
+}
+
+// BindURI binds path parameters into obj by uri tags and validates them like
+// BindQuery.
+func BindURI(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindUri(obj); err != nil {
+		Abort(c, invalidParams(err, obj, c.Params.Get))
+		return false
+	}
+	return true
+}
+
+// InvalidFields reports invalid parameters, keyed by name, with what is
+// wrong with each.
+func InvalidFields(fields map[string]string) *Error {
+	return BadRequest("invalid parameters").WithDetails(map[string]any{"fields": fields})
+}
+
+// invalidParams turns a binding error into field errors. Values that do not
+// parse as their field's type are reported by gin without the field, so they
+// are found again from the raw values.
+func invalidParams(err error, obj any, value func(string) (string, bool)) *Error {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make(map[string]string, len(invalid))
+		for _, fe := range invalid {
+			fields[fe.Field()] = fieldMessage(fe)
+		}
+		return InvalidFields(fields)
+	}
+
+	if fields := typeErrors(obj, value); len(fields) > 0 {
+		return InvalidFields(fields)
+	}
+	return BadRequest("invalid parameters")
+}
+
+// typeErrors returns the raw values that cannot be converted to the numeric
+// or boolean fields of obj.
+func typeErrors(obj any, value func(string) (string, bool)) map[string]string {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := paramName(f)
+		raw, ok := value(name)
+		if !ok || raw == "" {
+			continue
+		}
+
+		kind := f.Type.Kind()
+		if kind == reflect.Pointer {
+			kind = f.Type.Elem().Kind()
+		}
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+				fields[name] = "must be an integer"
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if _, err := strconv.ParseUint(raw, 10, 64); err != nil {
+				fields[name] = "must be a non-negative integer"
+			}
+		case reflect.Float32, reflect.Float64:
+			if _, err := strconv.ParseFloat(raw, 64); err != nil {
+				fields[name] = "must be a number"
+			}
+		case reflect.Bool:
+			if _, err := strconv.ParseBool(raw); err != nil {
+				fields[name] = "must be true or false"
+			}
+		}
+	}
+	return fields
+}
+
+// fieldMessage describes a failed validation tag.
+func fieldMessage(fe validator.FieldError) string {
+	if msg, ok := messages[fe.Tag()]; ok {
+		return msg
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max", "lte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "datetime":
+		if fe.Param() == DateLayout {
+			return "must be a date (YYYY-MM-DD)"
+		}
+		return "must match the format " + fe.Param()
+	case "alphanum":
+		return "must contain only letters and digits"
+	default:
+		return "is invalid"
+	}
+}
+
+// paramName returns the name a field is bound from.
+func paramName(f reflect.StructField) string {
+	for _, tag := range []string{"uri", "form", "json"} {
+		if name, _, _ := strings.Cut(f.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
@@ -0,0 +1,103 @@
+// Package httpx renders API errors in one envelope:
+//
+//	{"error": {"code": "not_found", "message": "match not found", "requestId": "…"}}
+//
+// Handlers report errors with Abort. Internal causes are logged with the
+// request ID and never returned to clients.
+package httpx
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error codes. Clients should branch on the code, not the message.
+const (
+	CodeInvalidRequest = "invalid_request"
+	CodeUnauthorized   = "unauthorized"
+	CodeNotFound       = "not_found"
+	CodeConflict       = "conflict"
+	CodeQuotaExceeded  = "quota_exceeded"
+	CodeUnavailable    = "unavailable"
+	CodeUpstream       = "upstream_error"
+	CodeInternal       = "internal"
+)
+
+// Error is an API error. Status is the HTTP status and Err the internal
+// cause, which is logged but not rendered.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Details map[string]any
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithDetails returns a copy of the error with extra fields rendered under
+// "details".
+func (e *Error) WithDetails(details map[string]any) *Error {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+// BadRequest reports an invalid parameter or body.
+func BadRequest(message string) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: CodeInvalidRequest, Message: message}
+}
+
+// Unauthorized reports missing or invalid credentials.
+func Unauthorized(message string) *Error {
+	return &Error{Status: http.StatusUnauthorized, Code: CodeUnauthorized, Message: message}
+}
+
+// NotFound reports a missing resource.
+func NotFound(message string) *Error {
+	return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: message}
+}
+
+// Conflict reports a request that clashes with the current state.
+func Conflict(message string) *Error {
+	return &Error{Status: http.StatusConflict, Code: CodeConflict, Message: message}
+}
+
+// QuotaExceeded reports a client over its request quota.
+func QuotaExceeded(message string) *Error {
+	return &Error{Status: http.StatusTooManyRequests, Code: CodeQuotaExceeded, Message: message}
+}
+
+// Unavailable reports a feature that is disabled or not ready.
+func Unavailable(message string) *Error {
+	return &Error{Status: http.StatusServiceUnavailable, Code: CodeUnavailable, Message: message}
+}
+
+// Upstream reports a failure of an external service the request needed.
+func Upstream(message string, err error) *Error {
+	return &Error{Status: http.StatusBadGateway, Code: CodeUpstream, Message: message, Err: err}
+}
+
+// Internal reports an unexpected failure. Only message reaches the client.
+func Internal(message string, err error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Message: message, Err: err}
+}
+
+// asError returns err as an *Error, treating anything else as an internal
+// error with a generic message.
+func asError(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return Internal("internal server error", err)
+}
@@ -0,0 +1,91 @@
+package httpx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// RequestIDHeader carries the request ID in requests and responses.
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDContextKey = "httpx.requestId"
+
+// validRequestID limits the incoming IDs that are reused, so logs cannot be
+// polluted through the header.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID assigns every request an ID, reusing a well-formed incoming
+// X-Request-ID, and echoes it in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			b := make([]byte, 8)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+
+		c.Set(requestIDContextKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// RequestIDFrom returns the request's ID, or "" outside RequestID.
+func RequestIDFrom(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
+
+// Abort renders err in the error envelope and stops the handler chain.
+// Errors other than *Error are rendered as internal errors. The error is
+// also attached to the context for Errors to log.
+func Abort(c *gin.Context, err error) {
+	apiErr := asError(err)
+	_ = c.Error(err)
+
+	body := gin.H{"code": apiErr.Code, "message": apiErr.Message}
+	if len(apiErr.Details) > 0 {
+		body["details"] = apiErr.Details
+	}
+	if id := RequestIDFrom(c); id != "" {
+		body["requestId"] = id
+	}
+	c.AbortWithStatusJSON(apiErr.Status, gin.H{"error": body})
+}
+
+// Errors logs the errors attached to a request once it is handled and
+// renders the last one if nothing was written yet, so handlers may also
+// report errors with c.Error.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		last := c.Errors.Last().Err
+		for _, ginErr := range c.Errors {
+			apiErr := asError(ginErr.Err)
+			if apiErr.Status < 500 {
+				continue
+			}
+			log.Error().Err(ginErr.Err).Str("requestId", RequestIDFrom(c)).
+				Str("method", c.Request.Method).Str("path", c.Request.URL.Path).
+				Int("status", apiErr.Status).Msg(apiErr.Message)
+		}
+
+		if !c.Writer.Written() {
+			Abort(c, last)
+		}
+	}
+}
+
+// NoRoute renders unknown routes in the error envelope.
+func NoRoute(c *gin.Context) {
+	Abort(c, NotFound("route not found"))
+}
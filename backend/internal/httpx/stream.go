@@ -0,0 +1,108 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Formats of streamed list responses, chosen with the format query
+// parameter.
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// streamFlushEvery is the number of items written between flushes, so
+// clients receive a long list in chunks as it is encoded.
+const streamFlushEvery = 100
+
+// ListStream writes a list response one item at a time, so a large list is
+// never marshalled into a single buffer. As JSON the items form an array
+// inside an envelope object; as NDJSON each item is one line and the
+// envelope is left out.
+//
+// The status and headers are sent when the stream starts, so an error while
+// writing items can only truncate the body: handlers log it and abort.
+type ListStream struct {
+	c      *gin.Context
+	ndjson bool
+	enc    *json.Encoder
+	count  int
+}
+
+// StreamList starts a 200 response in format (FormatJSON or FormatNDJSON).
+// For JSON, envelope, if not nil, must marshal to an object; its fields are
+// written first and the items follow under key.
+func StreamList(c *gin.Context, format string, envelope any, key string) (*ListStream, error) {
+	s := &ListStream{c: c, ndjson: format == FormatNDJSON, enc: json.NewEncoder(c.Writer)}
+
+	if s.ndjson {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		return s, nil
+	}
+
+	head := []byte("{")
+	if envelope != nil {
+		b, err := json.Marshal(envelope)
+		if err != nil {
+			return nil, err
+		}
+		b = bytes.TrimSpace(b)
+		if len(b) < 2 || b[0] != '{' || b[len(b)-1] != '}' {
+			return nil, fmt.Errorf("stream envelope %T is not a JSON object", envelope)
+		}
+		head = b[:len(b)-1]
+		if len(head) > 1 {
+			head = append(head, ',')
+		}
+	}
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	head = append(append(head, keyJSON...), ":["...)
+
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+	_, err = c.Writer.Write(head)
+	return s, err
+}
+
+// Write encodes one item.
+func (s *ListStream) Write(item any) error {
+	if !s.ndjson && s.count > 0 {
+		if _, err := s.c.Writer.Write([]byte{','}); err != nil {
+			return err
+		}
+	}
+	if err := s.enc.Encode(item); err != nil {
+		return err
+	}
+
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		s.c.Writer.Flush()
+	}
+	return nil
+}
+
+// Close ends the list and flushes the response.
+func (s *ListStream) Close() error {
+	if !s.ndjson {
+		if _, err := s.c.Writer.Write([]byte("]}")); err != nil {
+			return err
+		}
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+// Count returns the number of items written.
+func (s *ListStream) Count() int {
+	return s.count
+}
@@ -0,0 +1,49 @@
+package httpx
+
+import (
+	"time"
+	// Zones resolve on hosts without a zoneinfo database too
+	_ "time/tzdata"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimezoneHeader names the client's time zone when there is no tz query
+// parameter.
+const TimezoneHeader = "X-Timezone"
+
+const locationContextKey = "httpx.location"
+
+// Timezone resolves the time zone a request's kickoff times and dates are
+// in, from the tz query parameter or else the X-Timezone header, as an IANA
+// name such as Europe/London. Requests without one are served in UTC; an
+// unknown zone aborts with a 400.
+func Timezone() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("tz")
+		if name == "" {
+			name = c.GetHeader(TimezoneHeader)
+		}
+
+		loc := time.UTC
+		if name != "" {
+			var err error
+			// "Local" is the server's zone, which clients cannot know
+			if loc, err = time.LoadLocation(name); err != nil || name == "Local" {
+				Abort(c, InvalidFields(map[string]string{"tz": "must be an IANA time zone, e.g. Europe/London"}))
+				return
+			}
+		}
+
+		c.Set(locationContextKey, loc)
+		c.Next()
+	}
+}
+
+// Location returns the request's time zone, or UTC outside Timezone.
+func Location(c *gin.Context) *time.Location {
+	if loc, ok := c.Get(locationContextKey); ok {
+		return loc.(*time.Location)
+	}
+	return time.UTC
+}
@@ -0,0 +1,87 @@
+package ingest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// ErrNotReplayable is returned for archived responses of an endpoint no
+// parser handles yet. They stay archived for when one does.
+var ErrNotReplayable = errors.New("no parser for this endpoint")
+
+// football-data.org endpoints that can be replayed.
+var (
+	competitionsEndpoint = regexp.MustCompile(`^/v4/competitions$`)
+	matchesEndpoint      = regexp.MustCompile(`^/v4/competitions/[^/]+/matches(\?.*)?$`)
+	matchEndpoint        = regexp.MustCompile(`^/v4/matches/\d+$`)
+)
+
+// ArchiveTransport returns a transport archiving into raw_responses, under
+// source, the responses base returns, for 'footballctl replay'.
+func ArchiveTransport(db *sql.DB, source string, base http.RoundTripper) *datasource.Archive {
+	return datasource.NewArchive(repository.NewRawResponseRepository(db), source, base)
+}
+
+// ReplayArchived parses an archived response with the current models and
+// saves what it holds, as the ingest that fetched it would have. It returns
+// how many competitions and matches were saved. Matches whose competition
+// or teams are missing are skipped, like in a bulk load.
+func ReplayArchived(db *sql.DB, r datasource.ArchivedResponse) (int, error) {
+	if r.Source != football.Provider {
+		return 0, ErrNotReplayable
+	}
+
+	switch {
+	case competitionsEndpoint.MatchString(r.Endpoint):
+		var resp football.CompetitionsResponse
+		if err := json.Unmarshal(r.Body, &resp); err != nil {
+			return 0, fmt.Errorf("failed to decode competitions: %w", err)
+		}
+		for i := range resp.Competitions {
+			if err := SaveCompetition(db, &resp.Competitions[i]); err != nil {
+				return i, fmt.Errorf("failed to save competition %d: %w", resp.Competitions[i].ID, err)
+			}
+		}
+		return len(resp.Competitions), nil
+
+	case matchesEndpoint.MatchString(r.Endpoint):
+		var resp football.MatchesResponse
+		if err := json.Unmarshal(r.Body, &resp); err != nil {
+			return 0, fmt.Errorf("failed to decode matches: %w", err)
+		}
+		if len(resp.Matches) == 0 {
+			return 0, nil
+		}
+		if err := SaveCompetition(db, &resp.Competition); err != nil {
+			return 0, fmt.Errorf("failed to save competition: %w", err)
+		}
+		matches := make([]*football.Match, len(resp.Matches))
+		for i := range resp.Matches {
+			matches[i] = &resp.Matches[i]
+		}
+		missing, err := SaveMatches(db, matches)
+		if err != nil {
+			return 1, err
+		}
+		return 1 + len(matches) - len(missing), nil
+
+	case matchEndpoint.MatchString(r.Endpoint):
+		var match football.Match
+		if err := json.Unmarshal(r.Body, &match); err != nil {
+			return 0, fmt.Errorf("failed to decode match: %w", err)
+		}
+		if err := SaveMatch(db, &match); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	return 0, ErrNotReplayable
+}
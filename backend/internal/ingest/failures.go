@@ -0,0 +1,95 @@
+package ingest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// Entity types recorded in ingest_failures.
+const (
+	EntityCompetition   = "competition"
+	EntityTeam          = "team"
+	EntityMatch         = "match"
+	EntityPlayerStats   = "player_stats"
+	EntityMatchEvent    = "match_event"
+	EntityTeamStats     = "team_stats"
+	EntityLineup        = "lineup"
+	EntityPlayerRatings = "player_ratings"
+)
+
+// RecordFailure stores a row that could not be saved in the dead-letter
+// table so it can be retried with 'footballctl retry-failures'. Failing to
+// record is logged rather than returned, since the caller is already
+// handling an error.
+func RecordFailure(db *sql.DB, entityType, externalRef string, payload interface{}, saveErr error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("⚠️  Failed to encode %s %s for dead-letter: %v", entityType, externalRef, err)
+		return
+	}
+
+	if err := repository.NewIngestFailureRepository(db).Record(entityType, externalRef, data, saveErr.Error()); err != nil {
+		log.Printf("⚠️  Failed to record %s %s in dead-letter: %v", entityType, externalRef, err)
+	}
+}
+
+// Replay decodes a dead-letter payload and saves it again.
+func Replay(db *sql.DB, entityType string, payload []byte) error {
+	switch entityType {
+	case EntityCompetition:
+		var comp football.Competition
+		if err := json.Unmarshal(payload, &comp); err != nil {
+			return fmt.Errorf("failed to decode competition: %w", err)
+		}
+		return SaveCompetition(db, &comp)
+	case EntityTeam:
+		var team football.Team
+		if err := json.Unmarshal(payload, &team); err != nil {
+			return fmt.Errorf("failed to decode team: %w", err)
+		}
+		return SaveTeam(db, &team)
+	case EntityMatch:
+		var match football.Match
+		if err := json.Unmarshal(payload, &match); err != nil {
+			return fmt.Errorf("failed to decode match: %w", err)
+		}
+		return SaveMatch(db, &match)
+	case EntityPlayerStats:
+		var stats PlayerMatchStats
+		if err := json.Unmarshal(payload, &stats); err != nil {
+			return fmt.Errorf("failed to decode player stats: %w", err)
+		}
+		return SavePlayerMatchStats(db, &stats)
+	case EntityMatchEvent:
+		var event MatchEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("failed to decode match event: %w", err)
+		}
+		return SaveMatchEvent(db, &event)
+	case EntityTeamStats:
+		var stats TeamMatchStats
+		if err := json.Unmarshal(payload, &stats); err != nil {
+			return fmt.Errorf("failed to decode team stats: %w", err)
+		}
+		return SaveTeamMatchStats(db, &stats)
+	case EntityLineup:
+		var sheet TeamSheet
+		if err := json.Unmarshal(payload, &sheet); err != nil {
+			return fmt.Errorf("failed to decode lineup: %w", err)
+		}
+		return SaveTeamSheet(db, &sheet)
+	case EntityPlayerRatings:
+		var ratings PlayerMatchRatings
+		if err := json.Unmarshal(payload, &ratings); err != nil {
+			return fmt.Errorf("failed to decode player ratings: %w", err)
+		}
+		return SavePlayerMatchRatings(db, &ratings)
+	default:
+		return fmt.Errorf("unknown entity type %q", entityType)
+	}
+}
@@ -0,0 +1,372 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// Lineup roles stored in match_lineup_players.
+const (
+	RoleStarter    = "starter"
+	RoleSubstitute = "substitute"
+)
+
+// SheetPlayer is a player named in a team sheet. PlayerID is the internal
+// player ID when the caller has already resolved it; otherwise the player is
+// upserted by ExternalID, an ID issued by the sheet's source.
+type SheetPlayer struct {
+	PlayerID    int    `json:"playerId,omitempty"`
+	ExternalID  int    `json:"externalId"`
+	Name        string `json:"name"`
+	Position    string `json:"position"`
+	ShirtNumber int    `json:"shirtNumber"`
+}
+
+// Substitution is a player change, by the provider player IDs used in the
+// sheet.
+type Substitution struct {
+	Minute    int `json:"minute"`
+	PlayerOut int `json:"playerOut"`
+	PlayerIn  int `json:"playerIn"`
+}
+
+// SheetCoach is the coach named in a team sheet, by the ID of the provider
+// the sheet came from.
+type SheetCoach struct {
+	Source      string `json:"source"`
+	ExternalID  int    `json:"externalId"`
+	Name        string `json:"name"`
+	Nationality string `json:"nationality,omitempty"`
+}
+
+// TeamSheet is one team's lineup, bench, changes and sendings-off in a
+// match, enough to work out how long each player was on the pitch.
+type TeamSheet struct {
+	Source        string         `json:"source"`  // provider of the player IDs
+	MatchID       int            `json:"matchId"` // internal match ID
+	TeamID        int            `json:"teamId"`  // internal team ID
+	IsHome        bool           `json:"isHome"`
+	Formation     string         `json:"formation"`
+	MatchLength   int            `json:"matchLength"` // 90, or 120 after extra time
+	Starters      []SheetPlayer  `json:"starters"`
+	Bench         []SheetPlayer  `json:"bench"`
+	Substitutions []Substitution `json:"substitutions"`
+	SentOff       map[int]int    `json:"sentOff"` // provider player ID -> minute
+	Coach         *SheetCoach    `json:"coach,omitempty"`
+}
+
+// MatchLength returns the playing time of a match from football-data.org's
+// score duration: 120 minutes after extra time, otherwise 90.
+func MatchLength(duration string) int {
+	switch duration {
+	case "EXTRA_TIME", "PENALTY_SHOOTOUT":
+		return 120
+	default:
+		return 90
+	}
+}
+
+// Minutes returns how long each player in the sheet was on the pitch, keyed
+// by the provider player ID. Starters play from kick-off and substitutes
+// from the minute they come on, until they are taken off, sent off or the
+// match ends. Unused substitutes get zero; anyone who came on gets at least
+// one minute. Stoppage-time minutes count as the last minute of the match.
+func (t *TeamSheet) Minutes() map[int]int {
+	length := t.MatchLength
+	if length <= 0 {
+		length = 90
+	}
+	clamp := func(minute int) int {
+		return min(max(minute, 0), length)
+	}
+
+	on := make(map[int]int)
+	off := make(map[int]int)
+	for _, p := range t.Starters {
+		on[p.ExternalID] = 0
+	}
+	for _, s := range t.Substitutions {
+		minute := clamp(s.Minute)
+		if _, ok := on[s.PlayerIn]; !ok {
+			on[s.PlayerIn] = minute
+		}
+		if _, ok := off[s.PlayerOut]; !ok {
+			off[s.PlayerOut] = minute
+		}
+	}
+	for id, minute := range t.SentOff {
+		if prev, ok := off[id]; !ok || minute < prev {
+			off[id] = clamp(minute)
+		}
+	}
+
+	minutes := make(map[int]int)
+	for _, p := range t.Bench {
+		minutes[p.ExternalID] = 0
+	}
+	for id, start := range on {
+		end, ok := off[id]
+		if !ok {
+			end = length
+		}
+		minutes[id] = max(end-start, 1)
+	}
+	return minutes
+}
+
+// TeamSheetFromMatch builds the home or away team sheet from a
+// football-data.org match. It returns nil when the provider sent no lineup,
+// which is the case on the free plan.
+func TeamSheetFromMatch(matchID, teamID int, isHome bool, m *football.Match) *TeamSheet {
+	team := m.AwayTeam
+	if isHome {
+		team = m.HomeTeam
+	}
+	if len(team.Lineup) == 0 {
+		return nil
+	}
+
+	sheet := &TeamSheet{
+		Source:      football.Provider,
+		MatchID:     matchID,
+		TeamID:      teamID,
+		IsHome:      isHome,
+		Formation:   team.Formation,
+		MatchLength: MatchLength(m.Score.Duration),
+		SentOff:     make(map[int]int),
+	}
+	if c := team.Coach; c != nil && c.ID != 0 {
+		sheet.Coach = &SheetCoach{Source: football.Provider, ExternalID: c.ID, Name: c.Name, Nationality: c.Nationality}
+	}
+	for _, p := range team.Lineup {
+		sheet.Starters = append(sheet.Starters, sheetPlayerFromFD(p))
+	}
+	for _, p := range team.Bench {
+		sheet.Bench = append(sheet.Bench, sheetPlayerFromFD(p))
+	}
+	for _, s := range m.Substitutions {
+		if s.Team.ID != team.ID {
+			continue
+		}
+		sheet.Substitutions = append(sheet.Substitutions, Substitution{
+			Minute:    s.Minute,
+			PlayerOut: s.PlayerOut.ID,
+			PlayerIn:  s.PlayerIn.ID,
+		})
+	}
+	for _, b := range m.Bookings {
+		if b.Team.ID == team.ID && (b.Card == "RED" || b.Card == "YELLOW_RED") {
+			sheet.SentOff[b.Player.ID] = b.Minute
+		}
+	}
+	return sheet
+}
+
+func sheetPlayerFromFD(p football.LineupPlayer) SheetPlayer {
+	return SheetPlayer{ExternalID: p.ID, Name: p.Name, Position: p.Position, ShirtNumber: p.ShirtNumber}
+}
+
+// TeamSheetFromAPI builds a team sheet from an API-Football lineup and the
+// fixture's events. Players carry API-Football IDs in ExternalID; the caller
+// must resolve them to local players and set PlayerID before saving.
+func TeamSheetFromAPI(matchID, teamID int, isHome bool, matchLength int, lineup *apifootball.FixtureLineupsResponse, events []apifootball.FixtureEvent) *TeamSheet {
+	sheet := &TeamSheet{
+		Source:      apifootball.Provider,
+		MatchID:     matchID,
+		TeamID:      teamID,
+		IsHome:      isHome,
+		Formation:   lineup.Formation,
+		MatchLength: matchLength,
+		SentOff:     make(map[int]int),
+	}
+	if c := lineup.Coach; c.ID != 0 {
+		sheet.Coach = &SheetCoach{Source: apifootball.Provider, ExternalID: c.ID, Name: c.Name}
+	}
+	for _, p := range lineup.StartXI {
+		sheet.Starters = append(sheet.Starters, sheetPlayerFromAPI(p.Player))
+	}
+	for _, p := range lineup.Substitutes {
+		sheet.Bench = append(sheet.Bench, sheetPlayerFromAPI(p.Player))
+	}
+	for i := range events {
+		e := &events[i]
+		if e.Team.ID != lineup.Team.ID {
+			continue
+		}
+		switch {
+		case e.Type == apifootball.EventSubst:
+			sheet.Substitutions = append(sheet.Substitutions, Substitution{
+				Minute:    e.Time.Elapsed,
+				PlayerOut: e.Player.ID,
+				PlayerIn:  e.Assist.ID,
+			})
+		case e.IsSendingOff():
+			sheet.SentOff[e.Player.ID] = e.Time.Elapsed
+		}
+	}
+	return sheet
+}
+
+// API-Football lineup positions are single letters.
+var apiPositions = map[string]string{
+	"G": "Goalkeeper",
+	"D": "Defender",
+	"M": "Midfielder",
+	"F": "Forward",
+}
+
+func sheetPlayerFromAPI(p apifootball.PlayerInfo) SheetPlayer {
+	position := apiPositions[strings.ToUpper(p.Pos)]
+	if position == "" {
+		position = p.Pos
+	}
+	return SheetPlayer{ExternalID: p.ID, Name: p.Name, Position: position, ShirtNumber: p.Number}
+}
+
+// SaveTeamSheet stores the lineup with each player's minutes, replacing any
+// lineup already stored for the team in that match. Players without a
+// PlayerID are upserted by the sheet's source and ExternalID. When the sheet names a coach, it is
+// stored as the lineup's coach and the team's coaching spells are rebuilt.
+func SaveTeamSheet(db *sql.DB, t *TeamSheet) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := sqlcdb.New(db).WithTx(tx)
+
+	var formation *string
+	if t.Formation != "" {
+		formation = &t.Formation
+	}
+	var coachID *int
+	if c := t.Coach; c != nil {
+		var nationality *string
+		if c.Nationality != "" {
+			nationality = &c.Nationality
+		}
+		id, err := q.UpsertCoach(ctx, sqlcdb.UpsertCoachParams{
+			Source:      &c.Source,
+			ExternalID:  c.ExternalID,
+			Name:        c.Name,
+			Nationality: nationality,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save coach %s: %w", c.Name, err)
+		}
+		coachID = &id
+	}
+	lineupID, err := q.UpsertMatchLineup(ctx, sqlcdb.UpsertMatchLineupParams{
+		MatchID:   t.MatchID,
+		TeamID:    t.TeamID,
+		IsHome:    t.IsHome,
+		Formation: formation,
+		CoachID:   coachID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save lineup: %w", err)
+	}
+	if err := q.DeleteMatchLineupPlayers(ctx, lineupID); err != nil {
+		return fmt.Errorf("failed to clear lineup players: %w", err)
+	}
+
+	// Sheets recorded as failures before sources were tracked are all
+	// football-data.org ones.
+	source := t.Source
+	if source == "" {
+		source = football.Provider
+	}
+	minutes := t.Minutes()
+	save := func(p SheetPlayer, role string) error {
+		playerID := p.PlayerID
+		if playerID == 0 {
+			if playerID, err = upsertPlayer(q, source, p.ExternalID, p.Name, t.TeamID); err != nil {
+				return err
+			}
+		}
+
+		var position *string
+		if p.Position != "" {
+			position = &p.Position
+		}
+		err := q.InsertMatchLineupPlayer(ctx, sqlcdb.InsertMatchLineupPlayerParams{
+			MatchLineupID: lineupID,
+			PlayerID:      playerID,
+			Role:          role,
+			Position:      position,
+			MinutesPlayed: minutes[p.ExternalID],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save lineup player %s: %w", p.Name, err)
+		}
+		return nil
+	}
+
+	for _, p := range t.Starters {
+		if err := save(p, RoleStarter); err != nil {
+			return err
+		}
+	}
+	for _, p := range t.Bench {
+		if err := save(p, RoleSubstitute); err != nil {
+			return err
+		}
+	}
+
+	if coachID != nil {
+		if err := q.DeleteTeamCoachSpells(ctx, t.TeamID); err != nil {
+			return fmt.Errorf("failed to clear coaching spells: %w", err)
+		}
+		if err := q.InsertTeamCoachSpells(ctx, t.TeamID); err != nil {
+			return fmt.Errorf("failed to rebuild coaching spells: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit lineup: %w", err)
+	}
+	return nil
+}
+
+// AggregateMinutes copies lineup minutes into player_match_stats and clears
+// the fantasy points of every match whose minutes changed, so the next
+// scoring run recomputes them. It returns the number of matches updated.
+func AggregateMinutes(db *sql.DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := sqlcdb.New(db).WithTx(tx)
+
+	changed, err := q.AggregateLineupMinutes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to aggregate lineup minutes: %w", err)
+	}
+
+	matches := make(map[int]bool)
+	for _, matchID := range changed {
+		if matches[matchID] {
+			continue
+		}
+		matches[matchID] = true
+		if err := q.DeleteFantasyPointsForMatch(ctx, matchID); err != nil {
+			return 0, fmt.Errorf("failed to clear fantasy points: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit minutes: %w", err)
+	}
+	return len(matches), nil
+}
@@ -0,0 +1,330 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/football-prediction/pkg/events"
+	"github.com/yourusername/football-prediction/pkg/football"
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+// defaultRateLimitWait is used when a 429 response carries no reset header.
+const defaultRateLimitWait = 60 * time.Second
+
+// defaultBatchSize is the number of matches a worker upserts per statement.
+const defaultBatchSize = 200
+
+// SeasonJob is one competition season to ingest. ID is the caller's
+// reference, e.g. a backfill job ID, and is passed back in its result.
+// Priority is the quota priority the season is fetched at.
+type SeasonJob struct {
+	ID              int
+	CompetitionCode string
+	Season          string
+	Priority        quota.Priority
+}
+
+// SeasonResult is the outcome of a season once all its matches are saved.
+// Err is set when the season could not be fetched, or when matches were
+// fetched but none could be saved.
+type SeasonResult struct {
+	Job     SeasonJob
+	Matches int
+	Saved   int
+	Err     error
+}
+
+// PipelineMetrics are per-stage counters of a pipeline run. FetchTime
+// includes rate-limit pauses; SaveTime is summed over workers, so it can
+// exceed Elapsed.
+type PipelineMetrics struct {
+	Requests       int
+	RateLimitWaits int
+	FetchTime      time.Duration
+	MatchesSaved   int
+	MatchesFailed  int
+	SaveTime       time.Duration
+	Elapsed        time.Duration
+}
+
+func (m PipelineMetrics) String() string {
+	return fmt.Sprintf("fetch: %d requests, %d rate-limit waits, %s; save: %d saved, %d failed, %s worker time; total %s",
+		m.Requests, m.RateLimitWaits, m.FetchTime.Round(time.Millisecond),
+		m.MatchesSaved, m.MatchesFailed, m.SaveTime.Round(time.Millisecond),
+		m.Elapsed.Round(time.Millisecond))
+}
+
+// Pipeline ingests competition seasons in two stages. A single fetcher calls
+// football-data.org, paced by the client's own rate limit, and a bounded
+// pool of workers saves the fetched matches while the next season is being
+// fetched. Workers write matches in batches through statements prepared
+// once per run; a batch that fails is retried match by match so only the
+// bad rows reach the dead-letter table.
+//
+// Cancelling the context stops fetching; matches already fetched are still
+// saved before Run returns, so no season is left half-written without a
+// result.
+type Pipeline struct {
+	DB     *sql.DB
+	Client *football.Client
+	// Workers is the number of concurrent match writers (default 4).
+	Workers int
+	// BatchSize is the number of matches written per statement
+	// (default 200).
+	BatchSize int
+	// MaxRateLimitWaits gives up on the run after this many rate-limit
+	// pauses. Zero means no limit.
+	MaxRateLimitWaits int
+	// OnFetch, if set, is called before a season is fetched. An error skips
+	// the season with that error as its result.
+	OnFetch func(SeasonJob) error
+	// OnResult, if set, is called with each season's result, from the
+	// goroutine that called Run.
+	OnResult func(SeasonResult)
+	// Events, if set, receives a match.ingested event for every match
+	// saved. Failing to publish does not fail the save.
+	Events events.Publisher
+}
+
+// seasonBatch tracks the saves of one fetched season.
+type seasonBatch struct {
+	job     SeasonJob
+	matches int
+	saved   atomic.Int64
+	pending sync.WaitGroup
+}
+
+type saveTask struct {
+	batch   *seasonBatch
+	matches []*football.Match
+}
+
+// Run ingests jobs in order and returns the run's metrics. It returns the
+// context's error if it was cancelled, or an error if the rate limit was hit
+// more than MaxRateLimitWaits times; seasons not reached have no result.
+func (p *Pipeline) Run(ctx context.Context, jobs []SeasonJob) (PipelineMetrics, error) {
+	workers := p.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	stmts := NewStmtCache(p.DB)
+	defer stmts.Close()
+
+	var (
+		metrics   PipelineMetrics
+		saveNanos atomic.Int64
+		saved     atomic.Int64
+		failed    atomic.Int64
+	)
+	start := time.Now()
+
+	tasks := make(chan saveTask, workers*2)
+	results := make(chan SeasonResult)
+
+	var writers sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for task := range tasks {
+				began := time.Now()
+				retry, err := SaveMatches(stmts, task.matches)
+				if err != nil {
+					log.Printf("⚠️  Failed to save %d matches in one batch, saving one by one: %v", len(task.matches), err)
+					retry = task.matches
+				}
+				ok := len(task.matches) - len(retry)
+				failedIDs := make(map[int]bool)
+				for _, match := range retry {
+					if err := SaveMatch(stmts, match); err != nil {
+						RecordFailure(p.DB, EntityMatch, strconv.Itoa(match.ID), match, err)
+						log.Printf("⚠️  Failed to save match %d: %v", match.ID, err)
+						failedIDs[match.ID] = true
+						failed.Add(1)
+					} else {
+						ok++
+					}
+				}
+				for _, match := range task.matches {
+					if !failedIDs[match.ID] {
+						p.publishIngested(ctx, match)
+					}
+				}
+				task.batch.saved.Add(int64(ok))
+				saved.Add(int64(ok))
+				saveNanos.Add(int64(time.Since(began)))
+				task.batch.pending.Done()
+			}
+		}()
+	}
+
+	// Each fetched season reports once all its saves are done
+	var batches sync.WaitGroup
+	report := func(b *seasonBatch) {
+		defer batches.Done()
+		b.pending.Wait()
+		result := SeasonResult{Job: b.job, Matches: b.matches, Saved: int(b.saved.Load())}
+		if result.Saved == 0 && result.Matches > 0 {
+			result.Err = fmt.Errorf("none of %d matches could be saved", result.Matches)
+		}
+		results <- result
+	}
+
+	// Seasons that fail before any save report right away
+	fail := func(job SeasonJob, err error) {
+		batches.Add(1)
+		go func() {
+			defer batches.Done()
+			results <- SeasonResult{Job: job, Err: err}
+		}()
+	}
+
+	var runErr error
+	go func() {
+		defer func() {
+			close(tasks)
+			writers.Wait()
+			batches.Wait()
+			close(results)
+		}()
+
+		for _, job := range jobs {
+			if ctx.Err() != nil {
+				runErr = ctx.Err()
+				return
+			}
+
+			if p.OnFetch != nil {
+				if err := p.OnFetch(job); err != nil {
+					fail(job, err)
+					continue
+				}
+			}
+
+			began := time.Now()
+			resp, err := p.fetch(ctx, job, &metrics)
+			metrics.FetchTime += time.Since(began)
+			if err != nil && (ctx.Err() != nil || errors.Is(err, errTooManyWaits)) {
+				runErr = err
+				return
+			}
+
+			if err == nil && len(resp.Matches) > 0 {
+				if err = SaveCompetition(stmts, &resp.Competition); err != nil {
+					RecordFailure(p.DB, EntityCompetition, strconv.Itoa(resp.Competition.ID), &resp.Competition, err)
+					err = fmt.Errorf("failed to save competition: %w", err)
+				}
+			}
+			if err != nil {
+				fail(job, err)
+				continue
+			}
+
+			batch := &seasonBatch{job: job, matches: len(resp.Matches)}
+			batch.pending.Add((batch.matches + batchSize - 1) / batchSize)
+			batches.Add(1)
+			go report(batch)
+
+			for from := 0; from < len(resp.Matches); from += batchSize {
+				end := min(from+batchSize, len(resp.Matches))
+				chunk := make([]*football.Match, 0, end-from)
+				for i := from; i < end; i++ {
+					chunk = append(chunk, &resp.Matches[i])
+				}
+				tasks <- saveTask{batch: batch, matches: chunk}
+			}
+		}
+	}()
+
+	for result := range results {
+		if p.OnResult != nil {
+			p.OnResult(result)
+		}
+	}
+
+	metrics.MatchesSaved = int(saved.Load())
+	metrics.MatchesFailed = int(failed.Load())
+	metrics.SaveTime = time.Duration(saveNanos.Load())
+	metrics.Elapsed = time.Since(start)
+	return metrics, runErr
+}
+
+var errTooManyWaits = errors.New("rate limited too many times")
+
+// fetch gets a season's matches, pausing and retrying on rate limits.
+func (p *Pipeline) fetch(ctx context.Context, job SeasonJob, metrics *PipelineMetrics) (*football.MatchesResponse, error) {
+	for {
+		metrics.Requests++
+		resp, err := p.Client.GetMatchesContext(quota.WithPriority(ctx, job.Priority), job.CompetitionCode, job.Season)
+		if !football.IsRateLimited(err) {
+			return resp, err
+		}
+
+		metrics.RateLimitWaits++
+		if p.MaxRateLimitWaits > 0 && metrics.RateLimitWaits > p.MaxRateLimitWaits {
+			return nil, fmt.Errorf("%w: %v", errTooManyWaits, err)
+		}
+
+		wait := defaultRateLimitWait
+		var apiErr *football.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter + time.Second
+		}
+
+		log.Printf("⏳ Rate limited, pausing %s before retrying %s %s", wait, job.CompetitionCode, job.Season)
+		if err := sleepCtx(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// publishIngested announces a saved match. The key includes the status, so
+// consumers see each state of a match once however often it is ingested.
+func (p *Pipeline) publishIngested(ctx context.Context, match *football.Match) {
+	if p.Events == nil {
+		return
+	}
+
+	goals := match.Score.Goals()
+	e, err := events.New(events.MatchIngested, fmt.Sprintf("%s:%d:%s", events.MatchIngested, match.ID, match.Status),
+		events.IngestedMatch{
+			ExternalID:      match.ID,
+			CompetitionCode: match.Competition.Code,
+			Status:          match.Status,
+			UtcDate:         match.UtcDate,
+			HomeScore:       goals.Home,
+			AwayScore:       goals.Away,
+		})
+	if err == nil {
+		// Saves finish after cancellation, so their events are still sent
+		err = p.Events.Publish(context.WithoutCancel(ctx), e)
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to publish ingest of match %d: %v", match.ID, err)
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
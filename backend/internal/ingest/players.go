@@ -0,0 +1,111 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// PlayerMatchStats is a player's goal and assist tally in one match, with
+// football-data.org player IDs. Goals
+// counts every goal scored for the player's team, penalties included; own
+// goals are only counted in OwnGoals.
+type PlayerMatchStats struct {
+	MatchID          int    `json:"matchId"` // internal match ID
+	TeamID           int    `json:"teamId"`  // internal team ID
+	PlayerExternalID int    `json:"playerExternalId"`
+	PlayerName       string `json:"playerName"`
+	Goals            int    `json:"goals"`
+	Assists          int    `json:"assists"`
+	Penalties        int    `json:"penalties"`
+	OwnGoals         int    `json:"ownGoals"`
+}
+
+// MatchEvent is a goal, card or substitution to be stored in match_events,
+// with football-data.org player IDs. For a substitution the player is the one coming on and the related player
+// the one going off.
+type MatchEvent struct {
+	MatchID                 int    `json:"matchId"` // internal match ID
+	TeamID                  int    `json:"teamId"`  // internal team ID
+	PlayerExternalID        int    `json:"playerExternalId"`
+	PlayerName              string `json:"playerName"`
+	EventType               string `json:"eventType"`
+	Minute                  int    `json:"minute"`
+	InjuryTime              *int   `json:"injuryTime,omitempty"`
+	RelatedPlayerExternalID int    `json:"relatedPlayerExternalId,omitempty"`
+	RelatedPlayerName       string `json:"relatedPlayerName,omitempty"`
+}
+
+// SavePlayerMatchStats upserts the player and their stats for the match.
+func SavePlayerMatchStats(db sqlcdb.DBTX, s *PlayerMatchStats) error {
+	q := sqlcdb.New(db)
+
+	playerID, err := upsertPlayer(q, football.Provider, s.PlayerExternalID, s.PlayerName, s.TeamID)
+	if err != nil {
+		return err
+	}
+
+	err = q.UpsertPlayerMatchStats(context.Background(), sqlcdb.UpsertPlayerMatchStatsParams{
+		MatchID:   s.MatchID,
+		PlayerID:  playerID,
+		Goals:     s.Goals,
+		Assists:   s.Assists,
+		Penalties: s.Penalties,
+		OwnGoals:  s.OwnGoals,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert player stats: %w", err)
+	}
+
+	return nil
+}
+
+// SaveMatchEvent upserts the players and records the event once.
+func SaveMatchEvent(db sqlcdb.DBTX, e *MatchEvent) error {
+	q := sqlcdb.New(db)
+
+	playerID, err := upsertPlayer(q, football.Provider, e.PlayerExternalID, e.PlayerName, e.TeamID)
+	if err != nil {
+		return err
+	}
+
+	var relatedID *int
+	if e.RelatedPlayerExternalID > 0 {
+		id, err := upsertPlayer(q, football.Provider, e.RelatedPlayerExternalID, e.RelatedPlayerName, e.TeamID)
+		if err != nil {
+			return err
+		}
+		relatedID = &id
+	}
+
+	err = q.InsertMatchEvent(context.Background(), sqlcdb.InsertMatchEventParams{
+		MatchID:         e.MatchID,
+		TeamID:          e.TeamID,
+		PlayerID:        playerID,
+		EventType:       e.EventType,
+		Minute:          e.Minute,
+		InjuryTime:      e.InjuryTime,
+		RelatedPlayerID: relatedID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert match event: %w", err)
+	}
+
+	return nil
+}
+
+// upsertPlayer saves a player by the ID a source issued for them.
+func upsertPlayer(q *sqlcdb.Queries, source string, externalID int, name string, teamID int) (int, error) {
+	playerID, err := q.UpsertPlayer(context.Background(), sqlcdb.UpsertPlayerParams{
+		Source:     source,
+		ExternalID: externalID,
+		Name:       name,
+		TeamID:     &teamID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert player %s: %w", name, err)
+	}
+	return playerID, nil
+}
@@ -0,0 +1,96 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// StmtCache is a sqlcdb.DBTX that prepares each query the first time it is
+// run and reuses the statement afterwards, so the upserts of a batch are
+// parsed and planned once rather than once per row. It is safe for
+// concurrent use. Close it when the batch is done; statements prepared on a
+// transaction are also released when it ends.
+type StmtCache struct {
+	db    sqlcdb.DBTX
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// NewStmtCache returns an empty statement cache over db, a *sql.DB or a
+// *sql.Tx.
+func NewStmtCache(db sqlcdb.DBTX) *StmtCache {
+	return &StmtCache{db: db, stmts: make(map[string]*sql.Stmt)}
+}
+
+func (c *StmtCache) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	s, ok := c.stmts[query]
+	c.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	// Prepared outside the lock so writers of other queries are not held
+	// up; if two goroutines race, the loser's statement is closed.
+	s, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.stmts[query]; ok {
+		s.Close()
+		return existing, nil
+	}
+	c.stmts[query] = s
+	return s, nil
+}
+
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	s, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.ExecContext(ctx, args...)
+}
+
+func (c *StmtCache) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(ctx, query)
+}
+
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	s, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.QueryContext(ctx, args...)
+}
+
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	s, err := c.stmt(ctx, query)
+	if err != nil {
+		// *sql.Row cannot carry an error of its own; running the query
+		// unprepared reports the same failure from Scan.
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+	return s.QueryRowContext(ctx, args...)
+}
+
+// Close closes every cached statement.
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var first error
+	for query, s := range c.stmts {
+		if err := s.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(c.stmts, query)
+	}
+	return first
+}
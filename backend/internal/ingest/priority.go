@@ -0,0 +1,42 @@
+package ingest
+
+import (
+	"sort"
+
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+// Prioritize orders jobs for a run on a limited request budget. interest
+// counts the follows of each competition's upcoming fixtures, as returned
+// by FollowRepository.UpcomingInterest. The current season of every
+// competition with any interest goes first, most followed first, at
+// quota.High priority so it may use the budget held back for such fetches;
+// the other jobs keep their order after them. A competition's current
+// season is its latest, or the one left empty.
+func Prioritize(jobs []SeasonJob, interest map[string]int) []SeasonJob {
+	current := make(map[string]string)
+	for _, job := range jobs {
+		season, seen := current[job.CompetitionCode]
+		if !seen || (season != "" && (job.Season == "" || job.Season > season)) {
+			current[job.CompetitionCode] = job.Season
+		}
+	}
+
+	prioritized := make([]SeasonJob, len(jobs))
+	copy(prioritized, jobs)
+	for i := range prioritized {
+		job := &prioritized[i]
+		if interest[job.CompetitionCode] > 0 && job.Season == current[job.CompetitionCode] {
+			job.Priority = quota.High
+		}
+	}
+
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		a, b := prioritized[i], prioritized[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.Priority == quota.High && interest[a.CompetitionCode] > interest[b.CompetitionCode]
+	})
+	return prioritized
+}
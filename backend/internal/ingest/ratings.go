@@ -0,0 +1,125 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+)
+
+// cleanSheetMinutes is how long a player must have been on the pitch in a
+// match their team conceded none in to be credited with a clean sheet.
+const cleanSheetMinutes = 60
+
+// PlayerMatchRatings is a player's rating and detailed statistics for a
+// match, to be stored in player_match_stats. Nil fields were not reported by
+// the provider. Position is the line played, G, D, M or F, and CleanSheet
+// is nil when the team's goals conceded are unknown.
+type PlayerMatchRatings struct {
+	MatchID         int      `json:"matchId"`  // internal match ID
+	PlayerID        int      `json:"playerId"` // internal player ID
+	Rating          *float64 `json:"rating"`
+	MinutesPlayed   *int     `json:"minutesPlayed"`
+	Shots           *int     `json:"shots"`
+	ShotsOnTarget   *int     `json:"shotsOnTarget"`
+	KeyPasses       *int     `json:"keyPasses"`
+	Passes          *int     `json:"passes"`
+	PassesAccurate  *int     `json:"passesAccurate"`
+	Tackles         *int     `json:"tackles"`
+	Interceptions   *int     `json:"interceptions"`
+	Duels           *int     `json:"duels"`
+	DuelsWon        *int     `json:"duelsWon"`
+	PenaltiesMissed *int     `json:"penaltiesMissed"`
+	Position        *string  `json:"position"`
+	Saves           *int     `json:"saves"`
+	GoalsConceded   *int     `json:"goalsConceded"`
+	PenaltiesSaved  *int     `json:"penaltiesSaved"`
+	Blocks          *int     `json:"blocks"`
+	CleanSheet      *bool    `json:"cleanSheet"`
+}
+
+// PlayerMatchRatingsFromAPI converts API-Football fixture player statistics
+// for the given internal match and player. teamConceded is the goals the
+// player's team conceded, nil when unknown, from which the clean sheet is
+// worked out.
+func PlayerMatchRatingsFromAPI(matchID, playerID int, s *apifootball.FixturePlayerStats, teamConceded *int) *PlayerMatchRatings {
+	r := &PlayerMatchRatings{
+		MatchID:         matchID,
+		PlayerID:        playerID,
+		Rating:          s.Rating(),
+		MinutesPlayed:   s.Games.Minutes,
+		Shots:           s.Shots.Total,
+		ShotsOnTarget:   s.Shots.On,
+		KeyPasses:       s.Passes.Key,
+		Passes:          s.Passes.Total,
+		PassesAccurate:  s.PassesAccurate(),
+		Tackles:         s.Tackles.Total,
+		Interceptions:   s.Tackles.Interceptions,
+		Duels:           s.Duels.Total,
+		DuelsWon:        s.Duels.Won,
+		PenaltiesMissed: s.Penalty.Missed,
+		Saves:           s.Goals.Saves,
+		GoalsConceded:   s.Goals.Conceded,
+		PenaltiesSaved:  s.Penalty.Saved,
+		Blocks:          s.Tackles.Blocks,
+	}
+	if s.Games.Position != "" {
+		position := s.Games.Position
+		r.Position = &position
+	}
+	if teamConceded != nil {
+		cleanSheet := *teamConceded == 0 && s.Games.Minutes != nil && *s.Games.Minutes >= cleanSheetMinutes
+		r.CleanSheet = &cleanSheet
+	}
+	return r
+}
+
+// TeamGoalsConceded returns the goals a team conceded in a fixture, the sum
+// of those its goalkeepers conceded, or nil if no goalkeeper's were
+// reported.
+func TeamGoalsConceded(team *apifootball.FixtureTeamPlayers) *int {
+	var conceded *int
+	for i := range team.Players {
+		s := team.Players[i].Stats()
+		if s == nil || s.Games.Position != apifootball.PositionGoalkeeper || s.Goals.Conceded == nil {
+			continue
+		}
+		if conceded == nil {
+			conceded = new(int)
+		}
+		*conceded += *s.Goals.Conceded
+	}
+	return conceded
+}
+
+// SavePlayerMatchRatings upserts a player's rating and detailed statistics,
+// leaving goals and assists as they are.
+func SavePlayerMatchRatings(db sqlcdb.DBTX, r *PlayerMatchRatings) error {
+	err := sqlcdb.New(db).UpsertPlayerMatchRatings(context.Background(), sqlcdb.UpsertPlayerMatchRatingsParams{
+		MatchID:         r.MatchID,
+		PlayerID:        r.PlayerID,
+		Rating:          r.Rating,
+		MinutesPlayed:   r.MinutesPlayed,
+		Shots:           r.Shots,
+		ShotsOnTarget:   r.ShotsOnTarget,
+		KeyPasses:       r.KeyPasses,
+		Passes:          r.Passes,
+		PassesAccurate:  r.PassesAccurate,
+		Tackles:         r.Tackles,
+		Interceptions:   r.Interceptions,
+		Duels:           r.Duels,
+		DuelsWon:        r.DuelsWon,
+		PenaltiesMissed: r.PenaltiesMissed,
+		Position:        r.Position,
+		Saves:           r.Saves,
+		GoalsConceded:   r.GoalsConceded,
+		PenaltiesSaved:  r.PenaltiesSaved,
+		Blocks:          r.Blocks,
+		CleanSheet:      r.CleanSheet,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save player ratings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Package ingest persists football-data.org and API-Football payloads into the
+// database. It is shared by the ingest commands and footballctl.
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// SaveCompetition upserts a competition by external ID.
+func SaveCompetition(db sqlcdb.DBTX, comp *football.Competition) error {
+	return sqlcdb.New(db).UpsertCompetition(context.Background(), repository.CompetitionParams(comp))
+}
+
+// SaveMatch upserts a match and both of its teams, which are national teams
+// if the competition is played by them. The competition must already be
+// saved.
+func SaveMatch(db sqlcdb.DBTX, match *football.Match) error {
+	// Save home team
+	if err := saveTeam(db, &match.HomeTeam, match.Competition.TeamType()); err != nil {
+		return fmt.Errorf("failed to save home team: %w", err)
+	}
+
+	// Save away team
+	if err := saveTeam(db, &match.AwayTeam, match.Competition.TeamType()); err != nil {
+		return fmt.Errorf("failed to save away team: %w", err)
+	}
+
+	var winner, duration, stage, group, referee, venue *string
+	if match.Score.Winner != "" {
+		winner = &match.Score.Winner
+	}
+	if match.Score.Duration != "" {
+		duration = &match.Score.Duration
+	}
+	if match.Stage != "" {
+		stage = &match.Stage
+	}
+	if match.Group != "" {
+		group = &match.Group
+	}
+	if name := match.MainReferee(); name != "" {
+		referee = &name
+	}
+	if match.Venue != "" {
+		venue = &match.Venue
+	}
+
+	// Shootout penalties are stored apart from the goals
+	goals := match.Score.Goals()
+	n, err := sqlcdb.New(db).UpsertMatch(context.Background(), sqlcdb.UpsertMatchParams{
+		Source:                football.Provider,
+		ExternalID:            match.ID,
+		Season:                fmt.Sprintf("%d", match.Season.ID),
+		UtcDate:               match.UtcDate,
+		Status:                match.Status,
+		Matchday:              match.Matchday,
+		HomeScore:             goals.Home,
+		AwayScore:             goals.Away,
+		HalfTimeHomeScore:     match.Score.HalfTime.Home,
+		HalfTimeAwayScore:     match.Score.HalfTime.Away,
+		ExtraTimeHomeScore:    match.Score.ExtraTime.Home,
+		ExtraTimeAwayScore:    match.Score.ExtraTime.Away,
+		PenaltiesHomeScore:    match.Score.Penalties.Home,
+		PenaltiesAwayScore:    match.Score.Penalties.Away,
+		Winner:                winner,
+		Duration:              duration,
+		Stage:                 stage,
+		GroupName:             group,
+		Referee:               referee,
+		Venue:                 venue,
+		CompetitionExternalID: match.Competition.ID,
+		HomeTeamExternalID:    match.HomeTeam.ID,
+		AwayTeamExternalID:    match.AwayTeam.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The INSERT ... SELECT silently inserts nothing if a referenced row is
+	// missing, so surface that as an error instead of losing the match.
+	if n == 0 {
+		return fmt.Errorf("competition %d or teams not found for match %d", match.Competition.ID, match.ID)
+	}
+
+	return nil
+}
+
+// SaveMatches upserts matches and their teams with one statement for the
+// teams and one for the matches, for bulk loads. The competitions must
+// already be saved. It returns the matches that were skipped because their
+// competition or teams could not be found.
+func SaveMatches(db sqlcdb.DBTX, matches []*football.Match) ([]*football.Match, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	// A statement cannot upsert the same row twice, so repeated teams and
+	// matches are collapsed, keeping the last copy.
+	teams := make(map[int]*football.Team)
+	teamTypes := make(map[int]string)
+	unique := make(map[int]*football.Match, len(matches))
+	for _, m := range matches {
+		teams[m.HomeTeam.ID] = &m.HomeTeam
+		teams[m.AwayTeam.ID] = &m.AwayTeam
+		for _, id := range []int{m.HomeTeam.ID, m.AwayTeam.ID} {
+			if teamTypes[id] != football.TeamTypeNational {
+				teamTypes[id] = m.Competition.TeamType()
+			}
+		}
+		unique[m.ID] = m
+	}
+
+	tp := sqlcdb.UpsertTeamsParams{Source: football.Provider}
+	for _, t := range teams {
+		tp.ExternalIds = append(tp.ExternalIds, t.ID)
+		tp.Names = append(tp.Names, t.Name)
+		tp.ShortNames = append(tp.ShortNames, t.ShortName)
+		tp.Tlas = append(tp.Tlas, t.TLA)
+		tp.CrestUrls = append(tp.CrestUrls, t.Crest)
+		tp.TeamTypes = append(tp.TeamTypes, teamTypes[t.ID])
+	}
+	if err := sqlcdb.New(db).UpsertTeams(context.Background(), tp); err != nil {
+		return nil, fmt.Errorf("failed to save teams: %w", err)
+	}
+
+	mp := sqlcdb.UpsertMatchesParams{Source: football.Provider}
+	for _, m := range unique {
+		mp.ExternalIds = append(mp.ExternalIds, m.ID)
+		mp.Seasons = append(mp.Seasons, fmt.Sprintf("%d", m.Season.ID))
+		mp.UtcDates = append(mp.UtcDates, m.UtcDate)
+		mp.Statuses = append(mp.Statuses, m.Status)
+		mp.Matchdays = append(mp.Matchdays, m.Matchday)
+		goals := m.Score.Goals()
+		mp.HomeScores = append(mp.HomeScores, goals.Home)
+		mp.AwayScores = append(mp.AwayScores, goals.Away)
+		mp.HalfTimeHomeScores = append(mp.HalfTimeHomeScores, m.Score.HalfTime.Home)
+		mp.HalfTimeAwayScores = append(mp.HalfTimeAwayScores, m.Score.HalfTime.Away)
+		mp.ExtraTimeHomeScores = append(mp.ExtraTimeHomeScores, m.Score.ExtraTime.Home)
+		mp.ExtraTimeAwayScores = append(mp.ExtraTimeAwayScores, m.Score.ExtraTime.Away)
+		mp.PenaltiesHomeScores = append(mp.PenaltiesHomeScores, m.Score.Penalties.Home)
+		mp.PenaltiesAwayScores = append(mp.PenaltiesAwayScores, m.Score.Penalties.Away)
+		mp.Winners = append(mp.Winners, m.Score.Winner)
+		mp.Durations = append(mp.Durations, m.Score.Duration)
+		mp.Stages = append(mp.Stages, m.Stage)
+		mp.GroupNames = append(mp.GroupNames, m.Group)
+		mp.Referees = append(mp.Referees, m.MainReferee())
+		mp.Venues = append(mp.Venues, m.Venue)
+		mp.CompetitionExternalIds = append(mp.CompetitionExternalIds, m.Competition.ID)
+		mp.HomeTeamExternalIds = append(mp.HomeTeamExternalIds, m.HomeTeam.ID)
+		mp.AwayTeamExternalIds = append(mp.AwayTeamExternalIds, m.AwayTeam.ID)
+	}
+	saved, err := sqlcdb.New(db).UpsertMatches(context.Background(), mp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range saved {
+		delete(unique, id)
+	}
+	var missing []*football.Match
+	for _, m := range matches {
+		if unique[m.ID] == m {
+			missing = append(missing, m)
+		}
+	}
+	return missing, nil
+}
+
+// SaveTeam upserts a team by external ID. A new team is saved as a club; one
+// already known as a national team stays one.
+func SaveTeam(db sqlcdb.DBTX, team *football.Team) error {
+	return saveTeam(db, team, football.TeamTypeClub)
+}
+
+func saveTeam(db sqlcdb.DBTX, team *football.Team, teamType string) error {
+	return sqlcdb.New(db).UpsertTeam(context.Background(), sqlcdb.UpsertTeamParams{
+		Source:     football.Provider,
+		ExternalID: team.ID,
+		Name:       team.Name,
+		ShortName:  &team.ShortName,
+		Tla:        &team.TLA,
+		CrestUrl:   &team.Crest,
+		TeamType:   teamType,
+	})
+}
@@ -0,0 +1,85 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+)
+
+// TeamMatchStats is one team's statistics for a match, to be stored in
+// team_match_statistics. Nil fields were not reported by the provider.
+type TeamMatchStats struct {
+	MatchID         int      `json:"matchId"` // internal match ID
+	TeamID          int      `json:"teamId"`  // internal team ID
+	Possession      *int     `json:"possession"`
+	ShotsTotal      *int     `json:"shotsTotal"`
+	ShotsOnTarget   *int     `json:"shotsOnTarget"`
+	ShotsOffTarget  *int     `json:"shotsOffTarget"`
+	ShotsBlocked    *int     `json:"shotsBlocked"`
+	ShotsInsideBox  *int     `json:"shotsInsideBox"`
+	ShotsOutsideBox *int     `json:"shotsOutsideBox"`
+	Corners         *int     `json:"corners"`
+	Fouls           *int     `json:"fouls"`
+	Offsides        *int     `json:"offsides"`
+	YellowCards     *int     `json:"yellowCards"`
+	RedCards        *int     `json:"redCards"`
+	Saves           *int     `json:"saves"`
+	PassesTotal     *int     `json:"passesTotal"`
+	PassesAccurate  *int     `json:"passesAccurate"`
+	ExpectedGoals   *float64 `json:"expectedGoals"`
+}
+
+// TeamMatchStatsFromAPI converts API-Football fixture statistics for the
+// given internal match and team.
+func TeamMatchStatsFromAPI(matchID, teamID int, s *apifootball.FixtureTeamStatistics) *TeamMatchStats {
+	return &TeamMatchStats{
+		MatchID:         matchID,
+		TeamID:          teamID,
+		Possession:      s.Int(apifootball.StatBallPossession),
+		ShotsTotal:      s.Int(apifootball.StatTotalShots),
+		ShotsOnTarget:   s.Int(apifootball.StatShotsOnGoal),
+		ShotsOffTarget:  s.Int(apifootball.StatShotsOffGoal),
+		ShotsBlocked:    s.Int(apifootball.StatBlockedShots),
+		ShotsInsideBox:  s.Int(apifootball.StatShotsInsideBox),
+		ShotsOutsideBox: s.Int(apifootball.StatShotsOutsideBox),
+		Corners:         s.Int(apifootball.StatCornerKicks),
+		Fouls:           s.Int(apifootball.StatFouls),
+		Offsides:        s.Int(apifootball.StatOffsides),
+		YellowCards:     s.Int(apifootball.StatYellowCards),
+		RedCards:        s.Int(apifootball.StatRedCards),
+		Saves:           s.Int(apifootball.StatGoalkeeperSaves),
+		PassesTotal:     s.Int(apifootball.StatTotalPasses),
+		PassesAccurate:  s.Int(apifootball.StatPassesAccurate),
+		ExpectedGoals:   s.Float(apifootball.StatExpectedGoals),
+	}
+}
+
+// SaveTeamMatchStats upserts a team's statistics for a match.
+func SaveTeamMatchStats(db sqlcdb.DBTX, s *TeamMatchStats) error {
+	err := sqlcdb.New(db).UpsertTeamMatchStatistics(context.Background(), sqlcdb.UpsertTeamMatchStatisticsParams{
+		MatchID:         s.MatchID,
+		TeamID:          s.TeamID,
+		Possession:      s.Possession,
+		ShotsTotal:      s.ShotsTotal,
+		ShotsOnTarget:   s.ShotsOnTarget,
+		ShotsOffTarget:  s.ShotsOffTarget,
+		ShotsBlocked:    s.ShotsBlocked,
+		ShotsInsideBox:  s.ShotsInsideBox,
+		ShotsOutsideBox: s.ShotsOutsideBox,
+		Corners:         s.Corners,
+		Fouls:           s.Fouls,
+		Offsides:        s.Offsides,
+		YellowCards:     s.YellowCards,
+		RedCards:        s.RedCards,
+		Saves:           s.Saves,
+		PassesTotal:     s.PassesTotal,
+		PassesAccurate:  s.PassesAccurate,
+		ExpectedGoals:   s.ExpectedGoals,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save team match statistics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// AnomalyJob flags upsets and extreme scorelines among settled predictions.
+type AnomalyJob struct {
+	anomalies *service.AnomalyService
+}
+
+func NewAnomalyJob(anomalies *service.AnomalyService) *AnomalyJob {
+	return &AnomalyJob{anomalies: anomalies}
+}
+
+// Run checks up to 200 newly settled predictions.
+func (j *AnomalyJob) Run() error {
+	found, err := j.anomalies.Detect(200)
+	if found > 0 {
+		log.Info().Int("anomalies", found).Msg("Prediction anomalies detected")
+	}
+	return err
+}
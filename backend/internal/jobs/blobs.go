@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/pkg/blob"
+)
+
+// BlobCleanupJob deletes stored files past the age their lifecycle rule
+// allows, such as expired export downloads.
+type BlobCleanupJob struct {
+	store blob.Store
+	rules map[string]time.Duration
+}
+
+func NewBlobCleanupJob(store blob.Store, rules map[string]time.Duration) *BlobCleanupJob {
+	return &BlobCleanupJob{store: store, rules: rules}
+}
+
+func (j *BlobCleanupJob) Run() error {
+	deleted, err := blob.Cleanup(j.store, j.rules, time.Now())
+	if deleted > 0 {
+		log.Info().Int("blobs", deleted).Msg("Expired blobs deleted")
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// CrestRefreshJob keeps the crest cache in step with the providers.
+type CrestRefreshJob struct {
+	crests *service.CrestService
+}
+
+func NewCrestRefreshJob(crests *service.CrestService) *CrestRefreshJob {
+	return &CrestRefreshJob{crests: crests}
+}
+
+// Run fetches up to 100 missing, stale or moved crests.
+func (j *CrestRefreshJob) Run() error {
+	refreshed, err := j.crests.Refresh(100)
+	if refreshed > 0 {
+		log.Info().Int("crests", refreshed).Msg("Team crests refreshed")
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// DigestJob emails the weekly digests on the digest day.
+type DigestJob struct {
+	digests *service.DigestService
+}
+
+func NewDigestJob(digests *service.DigestService) *DigestJob {
+	return &DigestJob{digests: digests}
+}
+
+// Run sends up to 200 due digests; the rest go out on the next runs.
+func (j *DigestJob) Run() error {
+	sent, err := j.digests.SendDue(200)
+	if sent > 0 {
+		log.Info().Int("digests", sent).Msg("Weekly digests sent")
+	}
+	return err
+}
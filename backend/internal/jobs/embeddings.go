@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// embeddingBatch caps the matches encoded per run, so a backfill after the
+// encoder changes is spread over several runs.
+const embeddingBatch = 500
+
+// EmbeddingJob encodes finished matches for similar-match retrieval.
+type EmbeddingJob struct {
+	similarity *service.SimilarityService
+}
+
+func NewEmbeddingJob(similarity *service.SimilarityService) *EmbeddingJob {
+	return &EmbeddingJob{similarity: similarity}
+}
+
+// Run encodes the matches finished since the last run, or the next batch of
+// a backfill.
+func (j *EmbeddingJob) Run() error {
+	embedded, err := j.similarity.EmbedMissing(embeddingBatch)
+	if embedded > 0 {
+		log.Info().Int("matches", embedded).Msg("Match embeddings stored")
+	}
+	return err
+}
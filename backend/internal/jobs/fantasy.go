@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// FantasyJob scores finished matches for the fantasy leaderboards.
+type FantasyJob struct {
+	fantasy *service.FantasyService
+}
+
+func NewFantasyJob(fantasy *service.FantasyService) *FantasyJob {
+	return &FantasyJob{fantasy: fantasy}
+}
+
+// Run scores up to 50 unscored finished matches.
+func (j *FantasyJob) Run() error {
+	scored, err := j.fantasy.ScorePending(50)
+	if scored > 0 {
+		log.Info().Int("matches", scored).Msg("Fantasy points computed")
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// HomeAdvantageJob recomputes the home advantage of every competition and
+// team from stored results.
+type HomeAdvantageJob struct {
+	homeAdvantage *service.HomeAdvantageService
+}
+
+func NewHomeAdvantageJob(homeAdvantage *service.HomeAdvantageService) *HomeAdvantageJob {
+	return &HomeAdvantageJob{homeAdvantage: homeAdvantage}
+}
+
+// Run replaces the stored aggregates of every window.
+func (j *HomeAdvantageJob) Run() error {
+	return j.homeAdvantage.Refresh()
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// lineupPredictionWindow is how far ahead matches are watched for lineups,
+// which are announced about an hour before kickoff.
+const lineupPredictionWindow = 2 * time.Hour
+
+// LineupPredictionJob predicts upcoming matches again once their lineups
+// are stored, by whichever ingest stored them, so regulars left out are
+// reflected in the served prediction, and announces each new prediction.
+type LineupPredictionJob struct {
+	predictions *service.PredictionService
+	events      *service.EventBus
+}
+
+func NewLineupPredictionJob(predictions *service.PredictionService, bus *service.EventBus) *LineupPredictionJob {
+	return &LineupPredictionJob{predictions: predictions, events: bus}
+}
+
+// Run predicts up to 50 matches kicking off within the next two hours whose
+// lineups were stored since they were last predicted, publishes
+// prediction.updated for each and records the prediction as the match's
+// next version once it is published. A match that cannot be predicted or
+// announced now is left for the next run.
+func (j *LineupPredictionJob) Run() error {
+	matches, err := j.predictions.NewLineupMatches(lineupPredictionWindow, 50)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// Requests read the lineup state from the read model, which must match
+	// the one the new predictions were made from to be served from cache
+	if err := j.predictions.RefreshMatchInputs(); err != nil {
+		log.Warn().Err(err).Msg("Failed to refresh prediction inputs")
+	}
+
+	ctx := context.Background()
+	updated := 0
+	for _, m := range matches {
+		update, err := j.predictions.RepredictWithLineups(ctx, m, func(update *service.PredictionUpdate) error {
+			key := fmt.Sprintf("%s:%d:%d", service.EventPredictionUpdated, m.ExternalID, m.LineupsUpdatedAt.Unix())
+			return j.events.Publish(service.EventPredictionUpdated, key, update)
+		})
+		if err != nil {
+			log.Warn().Err(err).Int("match_id", m.MatchID).Msg("Failed to predict or announce match with lineups")
+			continue
+		}
+		if update == nil {
+			continue
+		}
+		if len(j.predictions.Shadows()) > 0 {
+			if err := j.predictions.RecordShadows(ctx, m.MatchID, service.PredictionInput{
+				MatchID:            m.MatchID,
+				HomeTeamID:         m.HomeTeamID,
+				AwayTeamID:         m.AwayTeamID,
+				HomeTeamExternalID: m.HomeTeamExternalID,
+				AwayTeamExternalID: m.AwayTeamExternalID,
+				HomeTeamName:       m.HomeTeamName,
+				AwayTeamName:       m.AwayTeamName,
+				Matchday:           m.Matchday,
+			}, update.ModelVersion); err != nil {
+				log.Warn().Err(err).Int("match_id", m.MatchID).Msg("Failed to record shadow predictions")
+			}
+		}
+		updated++
+	}
+
+	if updated > 0 {
+		log.Info().Int("matches", updated).Msg("Predictions updated for announced lineups")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Locker grants expiring leases on named locks shared by every instance.
+// repository.JobLockRepository implements it on Postgres; another store,
+// e.g. Redis, only needs the same three operations.
+type Locker interface {
+	// Acquire takes the lock for ttl, reporting false if another owner
+	// holds it.
+	Acquire(name, owner string, ttl time.Duration) (bool, error)
+	// Renew extends owner's lease by ttl, reporting false if it was lost.
+	Renew(name, owner string, ttl time.Duration) (bool, error)
+	Release(name, owner string) error
+}
+
+var (
+	// ErrLockHeld means another instance holds the lock.
+	ErrLockHeld = errors.New("lock held by another instance")
+	// ErrLockUnavailable means the lock store could not be reached, so
+	// nothing ran.
+	ErrLockUnavailable = errors.New("lock unavailable")
+	// ErrLeaseLost means the lease expired or was taken over while the work
+	// was running.
+	ErrLeaseLost = errors.New("lock lease lost")
+)
+
+// InstanceID names this process as a lock owner: its host, process ID and a
+// random suffix, so a restarted process does not inherit its old leases.
+func InstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// RunLocked runs fn while holding the named lock, renewing the lease every
+// third of ttl so work may take longer than ttl. It returns ErrLockHeld
+// without running fn if another owner holds the lock. If the lease is lost,
+// or cannot be renewed before it expires, fn's context is cancelled and the
+// returned error includes ErrLeaseLost.
+func RunLocked(ctx context.Context, locker Locker, name, owner string, ttl time.Duration, fn func(context.Context) error) error {
+	ok, err := locker.Acquire(name, owner, ttl)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrLockUnavailable, err)
+	}
+	if !ok {
+		return ErrLockHeld
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+
+		renewed := time.Now()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			ok, err := locker.Renew(name, owner, ttl)
+			switch {
+			case ok:
+				renewed = time.Now()
+			case err == nil || time.Since(renewed) >= ttl:
+				// Taken over, or expired while the store was unreachable
+				cancel(ErrLeaseLost)
+				return
+			}
+		}
+	}()
+
+	err = fn(runCtx)
+	close(stop)
+	<-stopped
+
+	if errors.Is(context.Cause(runCtx), ErrLeaseLost) {
+		// The lock is no longer ours to release
+		return errors.Join(err, ErrLeaseLost)
+	}
+	if releaseErr := locker.Release(name, owner); releaseErr != nil {
+		// The lease still expires on its own
+		return errors.Join(err, releaseErr)
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// NewsJob pulls team news from the configured feeds and extracts team
+// signals from it.
+type NewsJob struct {
+	news *service.NewsService
+}
+
+func NewNewsJob(news *service.NewsService) *NewsJob {
+	return &NewsJob{news: news}
+}
+
+// Run stores the articles published since the last run, then extracts
+// signals from those not yet classified.
+func (j *NewsJob) Run() error {
+	stored, ingestErr := j.news.Ingest(context.Background())
+	if stored > 0 {
+		log.Info().Int("articles", stored).Msg("Team news ingested")
+	}
+
+	signals, extractErr := j.news.ExtractSignals()
+	if signals > 0 {
+		log.Info().Int("signals", signals).Msg("Team signals extracted")
+	}
+	return errors.Join(ingestErr, extractErr)
+}
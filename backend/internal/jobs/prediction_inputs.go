@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// PredictionInputsJob refreshes the match_prediction_inputs read model, so
+// predictions of upcoming matches pick up new results, lineups and key
+// players within one interval.
+type PredictionInputsJob struct {
+	predictions *service.PredictionService
+}
+
+func NewPredictionInputsJob(predictions *service.PredictionService) *PredictionInputsJob {
+	return &PredictionInputsJob{predictions: predictions}
+}
+
+// Run recomputes the read model.
+func (j *PredictionInputsJob) Run() error {
+	return j.predictions.RefreshMatchInputs()
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// RetentionJob applies every enabled retention policy.
+type RetentionJob struct {
+	retention *service.RetentionService
+}
+
+func NewRetentionJob(retention *service.RetentionService) *RetentionJob {
+	return &RetentionJob{retention: retention}
+}
+
+// Run purges data past its retention period.
+func (j *RetentionJob) Run() error {
+	runs, err := j.retention.Run("", service.RetentionTriggerSchedule)
+	for _, run := range runs {
+		if run.Error == nil {
+			log.Info().Str("policy", run.Policy).Int("rows", run.RowsAffected).Time("cutoff", run.Cutoff).
+				Msg("Retention policy applied")
+		}
+	}
+	return err
+}
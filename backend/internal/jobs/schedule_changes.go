@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// ScheduleChangeJob announces matches that were postponed, cancelled,
+// reinstated or moved to a new kickoff since its last run, as recorded in
+// match_status_history by whichever ingest stored them.
+type ScheduleChangeJob struct {
+	repo   *repository.MatchStatusRepository
+	events *service.EventBus
+}
+
+func NewScheduleChangeJob(db *sql.DB, bus *service.EventBus) *ScheduleChangeJob {
+	return &ScheduleChangeJob{repo: repository.NewMatchStatusRepository(db), events: bus}
+}
+
+// Run publishes match.postponed for postponements and cancellations and
+// match.rescheduled for new kickoffs and reinstatements, up to 100 per
+// tick. A change is marked announced once published, so a failure leaves
+// it and the changes after it for the next run.
+func (j *ScheduleChangeJob) Run() error {
+	changes, err := j.repo.PendingScheduleChanges(100)
+	if err != nil {
+		return err
+	}
+
+	var published []int64
+	defer func() {
+		if len(published) == 0 {
+			return
+		}
+		if err := j.repo.MarkNotified(published); err != nil {
+			log.Error().Err(err).Msg("Failed to mark schedule changes announced")
+		}
+	}()
+
+	for _, c := range changes {
+		eventType := service.EventMatchRescheduled
+		if c.Kind == repository.StatusChangePostponed || c.Kind == repository.StatusChangeCancelled {
+			eventType = service.EventMatchPostponed
+		}
+		if err := j.events.Publish(eventType, fmt.Sprintf("%s:%d:%d", eventType, c.ExternalID, c.ID), c); err != nil {
+			return err
+		}
+		published = append(published, c.ID)
+	}
+
+	if len(published) > 0 {
+		log.Info().Int("changes", len(published)).Msg("Schedule changes announced")
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Job is a unit of background work run on a fixed interval. A Local job
+// works on state of its own process, so it runs on every instance rather
+// than under the job's lock.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+	Local    bool
+
+	trigger chan struct{}
+}
+
+// JobStats counts a job's runs on this instance since it started. Contended
+// counts the runs skipped because another instance held the job's lock, and
+// LockErrors those skipped because the lock could not be taken at all.
+type JobStats struct {
+	Name            string     `json:"name"`
+	IntervalSeconds int        `json:"intervalSeconds"`
+	Local           bool       `json:"local"`
+	Running         bool       `json:"running"`
+	Runs            int        `json:"runs"`
+	Failures        int        `json:"failures"`
+	Contended       int        `json:"contended"`
+	LockErrors      int        `json:"lockErrors"`
+	LeasesLost      int        `json:"leasesLost"`
+	LastRunAt       *time.Time `json:"lastRunAt,omitempty"`
+	LastContendedAt *time.Time `json:"lastContendedAt,omitempty"`
+}
+
+// Scheduler runs registered jobs periodically until its context is cancelled.
+// With a locker, each job that is not local runs on one instance at a time:
+// an instance whose turn comes while another holds the lock skips the run.
+type Scheduler struct {
+	jobs []Job
+	wg   sync.WaitGroup
+
+	locker  Locker
+	owner   string
+	lockTTL time.Duration
+
+	mu    sync.Mutex
+	stats map[string]*JobStats
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{stats: map[string]*JobStats{}}
+}
+
+// UseLocker runs jobs under locks taken from locker as owner, renewing their
+// leases every third of ttl. It must be called before Start.
+func (s *Scheduler) UseLocker(locker Locker, owner string, ttl time.Duration) {
+	s.locker, s.owner, s.lockTTL = locker, owner, ttl
+}
+
+// Owner returns the name this instance takes locks under, empty without a
+// locker.
+func (s *Scheduler) Owner() string {
+	return s.owner
+}
+
+// Register adds a job. Jobs must be registered before Start is called.
+func (s *Scheduler) Register(name string, interval time.Duration, run func() error) {
+	s.register(Job{Name: name, Interval: interval, Run: run})
+}
+
+// RegisterLocal adds a job that runs on every instance, without a lock.
+func (s *Scheduler) RegisterLocal(name string, interval time.Duration, run func() error) {
+	s.register(Job{Name: name, Interval: interval, Run: run, Local: true})
+}
+
+func (s *Scheduler) register(job Job) {
+	job.trigger = make(chan struct{}, 1)
+	s.jobs = append(s.jobs, job)
+	s.stats[job.Name] = &JobStats{Name: job.Name, IntervalSeconds: int(job.Interval.Seconds()), Local: job.Local}
+}
+
+// Stats returns the run and lock counters of every job, in registration
+// order.
+func (s *Scheduler) Stats() []JobStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make([]JobStats, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		stats = append(stats, *s.stats[job.Name])
+	}
+	return stats
+}
+
+// Trigger runs the named job as soon as it is idle, without waiting for its
+// next tick. Triggers while a run is already pending are merged into it. It
+// reports whether the job exists.
+func (s *Scheduler) Trigger(name string) bool {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			select {
+			case job.trigger <- struct{}{}:
+			default:
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Start launches every registered job in its own goroutine. Each job runs once
+// immediately and then on every tick of its interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Wait blocks until all job loops have exited.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	log.Info().Str("job", job.Name).Dur("interval", job.Interval).Msg("Scheduled job started")
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			log.Info().Str("job", job.Name).Msg("Scheduled job stopped")
+			return
+		case <-ticker.C:
+		case <-job.trigger:
+			log.Debug().Str("job", job.Name).Msg("Scheduled job triggered")
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	start := time.Now()
+	s.setRunning(job.Name, true)
+
+	var err error
+	if s.locker == nil || job.Local {
+		err = job.Run()
+	} else {
+		err = RunLocked(ctx, s.locker, job.Name, s.owner, s.lockTTL, func(context.Context) error {
+			return job.Run()
+		})
+	}
+	s.record(job.Name, start, err)
+
+	switch {
+	case errors.Is(err, ErrLockHeld):
+		log.Debug().Str("job", job.Name).Msg("Scheduled job skipped, running on another instance")
+	case errors.Is(err, ErrLeaseLost):
+		log.Warn().Err(err).Str("job", job.Name).Dur("took", time.Since(start)).
+			Msg("Scheduled job lost its lock while running")
+	case err != nil:
+		log.Error().Err(err).Str("job", job.Name).Msg("Scheduled job failed")
+	default:
+		log.Debug().Str("job", job.Name).Dur("took", time.Since(start)).Msg("Scheduled job finished")
+	}
+}
+
+func (s *Scheduler) setRunning(name string, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats[name].Running = running
+}
+
+// record counts a finished or skipped run.
+func (s *Scheduler) record(name string, start time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats[name]
+	stats.Running = false
+	switch {
+	case errors.Is(err, ErrLockHeld):
+		stats.Contended++
+		stats.LastContendedAt = &start
+		return
+	case errors.Is(err, ErrLockUnavailable):
+		stats.LockErrors++
+		return
+	case errors.Is(err, ErrLeaseLost):
+		stats.LeasesLost++
+	}
+
+	stats.Runs++
+	stats.LastRunAt = &start
+	if err != nil {
+		stats.Failures++
+	}
+}
@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// SetPieceJob refreshes the team and player set-piece read models behind
+// the set-piece profiles and match previews.
+type SetPieceJob struct {
+	teams *service.TeamService
+}
+
+func NewSetPieceJob(teams *service.TeamService) *SetPieceJob {
+	return &SetPieceJob{teams: teams}
+}
+
+// Run recomputes the read models.
+func (j *SetPieceJob) Run() error {
+	return j.teams.RefreshSetPieces()
+}
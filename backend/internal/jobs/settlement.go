@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// SettlementJob compares stored model and user predictions with final
+// results, publishes result events and then generates recaps for newly
+// finished matches.
+type SettlementJob struct {
+	matchRepo *repository.MatchRepository
+	predRepo  *repository.PredictionRepository
+	recaps    *service.RecapService
+	events    *service.EventBus
+	leagues   *service.LeagueService
+}
+
+func NewSettlementJob(db *sql.DB, recaps *service.RecapService, bus *service.EventBus, leagues *service.LeagueService) *SettlementJob {
+	return &SettlementJob{
+		matchRepo: repository.NewMatchRepository(db),
+		predRepo:  repository.NewPredictionRepository(db),
+		recaps:    recaps,
+		events:    bus,
+		leagues:   leagues,
+	}
+}
+
+// Run settles up to 100 predictions and generates up to 5 recaps per tick.
+// The recap limit keeps football-data.org usage under the free tier budget.
+func (j *SettlementJob) Run() error {
+	if err := j.publishFinished(); err != nil {
+		log.Error().Err(err).Msg("Failed to publish finished matches")
+	}
+
+	ids, err := j.predRepo.ListUnsettledFinished(100)
+	if err != nil {
+		return err
+	}
+
+	settled := 0
+	for _, id := range ids {
+		if err := j.predRepo.Settle(id); err != nil {
+			log.Error().Err(err).Int("match_id", id).Msg("Failed to settle prediction")
+			continue
+		}
+		settled++
+
+		if err := j.publishSettled(id); err != nil {
+			log.Error().Err(err).Int("match_id", id).Msg("Failed to publish settlement")
+		}
+	}
+
+	userSettled, err := j.leagues.SettlePredictions()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to settle user predictions")
+	}
+
+	recaps, err := j.recaps.GenerateMissing(5)
+	if err != nil {
+		return err
+	}
+
+	if settled > 0 || userSettled > 0 || recaps > 0 {
+		log.Info().Int("settled", settled).Int("user_settled", userSettled).Int("recaps", recaps).Msg("Settlement run complete")
+	}
+
+	return nil
+}
+
+// publishFinished emits match.finished for matches from the last two days.
+// Event keys make repeated runs idempotent.
+func (j *SettlementJob) publishFinished() error {
+	matches, err := j.matchRepo.ListFinishedSince(time.Now().Add(-48*time.Hour), 200)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		if err := j.events.Publish(service.EventMatchFinished, fmt.Sprintf("%s:%d", service.EventMatchFinished, m.ExternalID), m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *SettlementJob) publishSettled(matchID int) error {
+	prediction, err := j.predRepo.GetByMatchID(matchID)
+	if err != nil || prediction == nil {
+		return err
+	}
+	match, err := j.matchRepo.GetSummaryByID(matchID)
+	if err != nil || match == nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"match":      match,
+		"prediction": prediction,
+	}
+
+	// Upsets are published by the anomaly job
+	return j.events.Publish(service.EventPredictionSettled, fmt.Sprintf("%s:%d", service.EventPredictionSettled, match.ExternalID), data)
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// StreakJob recomputes team and player streaks and milestones for teams
+// that have played since their facts were last computed.
+type StreakJob struct {
+	streaks *service.StreakService
+}
+
+func NewStreakJob(streaks *service.StreakService) *StreakJob {
+	return &StreakJob{streaks: streaks}
+}
+
+// Run refreshes the facts of up to 50 teams.
+func (j *StreakJob) Run() error {
+	refreshed, err := j.streaks.Refresh(50)
+	if refreshed > 0 {
+		log.Info().Int("teams", refreshed).Msg("Streaks refreshed")
+	}
+	return err
+}
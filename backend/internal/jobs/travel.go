@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// TravelJob fills in the travel distance of new fixtures whose venues have
+// coordinates.
+type TravelJob struct {
+	travel *service.TravelService
+}
+
+func NewTravelJob(travel *service.TravelService) *TravelJob {
+	return &TravelJob{travel: travel}
+}
+
+// Run computes the distances still missing.
+func (j *TravelJob) Run() error {
+	_, err := j.travel.Refresh(false)
+	return err
+}
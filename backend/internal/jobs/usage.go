@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// UsageFlushJob writes the API usage aggregated in memory to the database.
+type UsageFlushJob struct {
+	usage *service.UsageService
+}
+
+func NewUsageFlushJob(usage *service.UsageService) *UsageFlushJob {
+	return &UsageFlushJob{usage: usage}
+}
+
+// Run flushes the pending usage aggregates.
+func (j *UsageFlushJob) Run() error {
+	flushed, err := j.usage.Flush()
+	if flushed > 0 {
+		log.Debug().Int("aggregates", flushed).Msg("API usage flushed")
+	}
+	return err
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/football-prediction/internal/service"
+)
+
+// WebhookDeliveryJob drains the webhook delivery queue.
+type WebhookDeliveryJob struct {
+	webhooks *service.WebhookService
+}
+
+func NewWebhookDeliveryJob(webhooks *service.WebhookService) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{webhooks: webhooks}
+}
+
+// Run delivers up to 50 due webhook deliveries.
+func (j *WebhookDeliveryJob) Run() error {
+	delivered, err := j.webhooks.DeliverPending(50)
+	if delivered > 0 {
+		log.Info().Int("delivered", delivered).Msg("Webhook deliveries sent")
+	}
+	return err
+}
+
+// Drain delivers what is due in batches until a batch delivers nothing or
+// ctx expires, so events from the last moments before shutdown are not left
+// waiting for the next start. Failed deliveries keep their retry schedule.
+func (j *WebhookDeliveryJob) Drain(ctx context.Context) error {
+	total := 0
+	for ctx.Err() == nil {
+		delivered, err := j.webhooks.DeliverPending(50)
+		total += delivered
+		if err != nil {
+			return err
+		}
+		if delivered == 0 {
+			break
+		}
+	}
+	if total > 0 {
+		log.Info().Int("delivered", total).Msg("Webhook deliveries sent before shutdown")
+	}
+	return ctx.Err()
+}
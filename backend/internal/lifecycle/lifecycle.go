@@ -0,0 +1,132 @@
+// Package lifecycle runs a process's background work under one context and
+// shuts it down in order: on SIGINT or SIGTERM the context is cancelled so
+// no new work starts, the work already running is waited for, and then the
+// shutdown hooks flush what is left, e.g. queued webhook deliveries.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Manager tracks a process's goroutines and shutdown hooks.
+type Manager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	stopping bool
+	running  map[string]int
+	hooks    []hook
+	wg       sync.WaitGroup
+}
+
+type hook struct {
+	name string
+	fn   func(context.Context) error
+}
+
+// New returns a manager whose context is cancelled by SIGINT, SIGTERM or
+// Shutdown, whichever comes first.
+func New(parent context.Context) *Manager {
+	ctx, cancel := signal.NotifyContext(parent, syscall.SIGINT, syscall.SIGTERM)
+	return &Manager{ctx: ctx, cancel: cancel, running: map[string]int{}}
+}
+
+// Context is cancelled when shutdown begins. Work started under it should
+// finish what it has begun and then return.
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// Done is closed when shutdown begins.
+func (m *Manager) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+// Go runs fn in a goroutine Shutdown waits for, passing it the manager's
+// context. Once shutdown has begun it reports false and does not run fn.
+func (m *Manager) Go(name string, fn func(ctx context.Context)) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopping {
+		return false
+	}
+
+	m.running[name]++
+	m.wg.Add(1)
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			if m.running[name]--; m.running[name] == 0 {
+				delete(m.running, name)
+			}
+			m.mu.Unlock()
+			m.wg.Done()
+		}()
+		fn(m.ctx)
+	}()
+	return true
+}
+
+// OnShutdown adds a hook run once every goroutine started with Go has
+// returned. Hooks run in the order added, with a context that expires at the
+// shutdown deadline.
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Shutdown cancels the context, waits up to timeout for running work and
+// then runs the hooks within what is left of it. If work is still running
+// at the deadline the hooks are skipped, since they may race with it, and
+// the error names the work.
+func (m *Manager) Shutdown(timeout time.Duration) error {
+	m.mu.Lock()
+	m.stopping = true
+	hooks := m.hooks
+	m.mu.Unlock()
+	m.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	finished := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		return fmt.Errorf("still running after %s: %s", timeout, strings.Join(m.runningNames(), ", "))
+	}
+
+	var errs []error
+	for _, h := range hooks {
+		if err := h.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) runningNames() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.running))
+	for name := range m.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
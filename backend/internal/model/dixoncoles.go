@@ -0,0 +1,344 @@
+// Package model is a native Go prediction engine: a Poisson goals model with
+// per-team attack and defence strengths, the Dixon-Coles low-score
+// correction and exponential time decay, fitted from stored results.
+package model
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Version identifies predictions made by this model in prediction_history.
+const Version = "dixon-coles-v1"
+
+// maxGoals bounds the score grid used for outcome probabilities.
+const maxGoals = 10
+
+// ErrNoResults is returned when there is nothing to fit.
+var ErrNoResults = errors.New("no results to fit")
+
+// Result is a finished match used for fitting. Team and competition IDs
+// are internal IDs; CompetitionID is 0 when unknown.
+type Result struct {
+	HomeTeamID    int
+	AwayTeamID    int
+	HomeGoals     int
+	AwayGoals     int
+	Date          time.Time
+	CompetitionID int
+}
+
+// Options tune the fit. Zero values take the defaults.
+type Options struct {
+	// HalfLife is the age at which a result counts half as much as one
+	// played today. Default 180 days.
+	HalfLife time.Duration
+	// PriorGoals pulls teams with little data towards the league average by
+	// adding this many average goals to each team's totals. Default 2.
+	PriorGoals float64
+	// Iterations caps the fitting passes. Default 100.
+	Iterations int
+	// Now is the reference time for decay. Default time.Now().
+	Now time.Time
+}
+
+func (o Options) withDefaults() Options {
+	if o.HalfLife <= 0 {
+		o.HalfLife = 180 * 24 * time.Hour
+	}
+	if o.PriorGoals <= 0 {
+		o.PriorGoals = 2
+	}
+	if o.Iterations <= 0 {
+		o.Iterations = 100
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
+
+// Model holds fitted strengths. Attack and Defence average 1 across teams;
+// a Defence above 1 concedes more than average. Expected home goals are
+// Base * HomeAdvantage * Attack[home] * Defence[away].
+type Model struct {
+	Attack        map[int]float64
+	Defence       map[int]float64
+	Base          float64
+	HomeAdvantage float64
+	Rho           float64
+	Matches       int
+	FittedAt      time.Time
+}
+
+// Fit estimates team strengths by weighted maximum likelihood. Attack,
+// defence, home advantage and the base rate are found by alternating
+// closed-form updates of the Poisson likelihood; rho, the low-score
+// correction, is then chosen by a grid search.
+func Fit(results []Result, opts Options) (*Model, error) {
+	if len(results) == 0 {
+		return nil, ErrNoResults
+	}
+	opts = opts.withDefaults()
+
+	decay := math.Ln2 / opts.HalfLife.Hours()
+	weights := make([]float64, len(results))
+	var homeGoals, awayGoals, total float64
+	for i, r := range results {
+		age := math.Max(opts.Now.Sub(r.Date).Hours(), 0)
+		weights[i] = math.Exp(-decay * age)
+		homeGoals += weights[i] * float64(r.HomeGoals)
+		awayGoals += weights[i] * float64(r.AwayGoals)
+		total += weights[i]
+	}
+	if total == 0 {
+		return nil, ErrNoResults
+	}
+
+	m := &Model{
+		Attack:        make(map[int]float64),
+		Defence:       make(map[int]float64),
+		Base:          math.Max(awayGoals/total, 0.1),
+		HomeAdvantage: 1,
+		Matches:       len(results),
+		FittedAt:      opts.Now,
+	}
+	if awayGoals > 0 {
+		m.HomeAdvantage = homeGoals / awayGoals
+	}
+	for _, r := range results {
+		m.Attack[r.HomeTeamID], m.Defence[r.HomeTeamID] = 1, 1
+		m.Attack[r.AwayTeamID], m.Defence[r.AwayTeamID] = 1, 1
+	}
+
+	prior := opts.PriorGoals
+	for iter := 0; iter < opts.Iterations; iter++ {
+		scored := make(map[int]float64)
+		scoredExp := make(map[int]float64)
+		for i, r := range results {
+			w := weights[i]
+			scored[r.HomeTeamID] += w * float64(r.HomeGoals)
+			scored[r.AwayTeamID] += w * float64(r.AwayGoals)
+			scoredExp[r.HomeTeamID] += w * m.Base * m.HomeAdvantage * m.Defence[r.AwayTeamID]
+			scoredExp[r.AwayTeamID] += w * m.Base * m.Defence[r.HomeTeamID]
+		}
+		for id := range m.Attack {
+			m.Attack[id] = (prior + scored[id]) / (prior + scoredExp[id])
+		}
+
+		conceded := make(map[int]float64)
+		concededExp := make(map[int]float64)
+		for i, r := range results {
+			w := weights[i]
+			conceded[r.HomeTeamID] += w * float64(r.AwayGoals)
+			conceded[r.AwayTeamID] += w * float64(r.HomeGoals)
+			concededExp[r.HomeTeamID] += w * m.Base * m.Attack[r.AwayTeamID]
+			concededExp[r.AwayTeamID] += w * m.Base * m.HomeAdvantage * m.Attack[r.HomeTeamID]
+		}
+		for id := range m.Defence {
+			m.Defence[id] = (prior + conceded[id]) / (prior + concededExp[id])
+		}
+
+		var homeExp, allExp float64
+		for i, r := range results {
+			w := weights[i]
+			h := w * m.Base * m.Attack[r.HomeTeamID] * m.Defence[r.AwayTeamID]
+			homeExp += h
+			allExp += h*m.HomeAdvantage + w*m.Base*m.Attack[r.AwayTeamID]*m.Defence[r.HomeTeamID]
+		}
+		prevHome := m.HomeAdvantage
+		if homeExp > 0 {
+			m.HomeAdvantage = homeGoals / homeExp
+		}
+		if allExp > 0 {
+			m.Base *= (homeGoals + awayGoals) / allExp
+		}
+		m.normalise()
+
+		if math.Abs(m.HomeAdvantage-prevHome) < 1e-7 {
+			break
+		}
+	}
+
+	m.Rho = fitRho(m, results, weights)
+	return m, nil
+}
+
+// normalise rescales attack and defence to average 1, folding the scale
+// into the base rate so expected goals are unchanged.
+func (m *Model) normalise() {
+	var attack, defence float64
+	for id := range m.Attack {
+		attack += m.Attack[id]
+		defence += m.Defence[id]
+	}
+	n := float64(len(m.Attack))
+	attack /= n
+	defence /= n
+	for id := range m.Attack {
+		m.Attack[id] /= attack
+		m.Defence[id] /= defence
+	}
+	m.Base *= attack * defence
+}
+
+// fitRho picks the low-score correction that maximises the weighted
+// likelihood of the observed scores given the fitted goal rates.
+func fitRho(m *Model, results []Result, weights []float64) float64 {
+	best, bestLL := 0.0, math.Inf(-1)
+	for rho := -0.25; rho <= 0.25+1e-9; rho += 0.005 {
+		ll, valid := 0.0, true
+		for i, r := range results {
+			home, away := m.rates(r.HomeTeamID, r.AwayTeamID, Adjustment{}, Adjustment{})
+			t := tau(r.HomeGoals, r.AwayGoals, home, away, rho)
+			if t <= 0 {
+				valid = false
+				break
+			}
+			ll += weights[i] * math.Log(t)
+		}
+		if valid && ll > bestLL {
+			best, bestLL = rho, ll
+		}
+	}
+	return best
+}
+
+// tau is the Dixon-Coles adjustment to the independent Poisson probability
+// of the four lowest scorelines.
+func tau(x, y int, lambda, mu, rho float64) float64 {
+	switch {
+	case x == 0 && y == 0:
+		return 1 - lambda*mu*rho
+	case x == 0 && y == 1:
+		return 1 + lambda*rho
+	case x == 1 && y == 0:
+		return 1 + mu*rho
+	case x == 1 && y == 1:
+		return 1 - rho
+	default:
+		return 1
+	}
+}
+
+// Strength returns a team's attack and defence. Teams without results get
+// average strength.
+func (m *Model) Strength(teamID int) (attack, defence float64) {
+	if !m.Knows(teamID) {
+		return 1, 1
+	}
+	return m.Attack[teamID], m.Defence[teamID]
+}
+
+// Adjustment scales a team's fitted strengths for one match, for what its
+// past results cannot show, such as starters missing from the lineup. A
+// zero factor leaves that strength as fitted.
+type Adjustment struct {
+	Attack  float64
+	Defence float64
+}
+
+func (a Adjustment) apply(attack, defence float64) (float64, float64) {
+	if a.Attack > 0 {
+		attack *= a.Attack
+	}
+	if a.Defence > 0 {
+		defence *= a.Defence
+	}
+	return attack, defence
+}
+
+// rates returns the expected goals of each side.
+func (m *Model) rates(homeTeamID, awayTeamID int, home, away Adjustment) (float64, float64) {
+	homeAttack, homeDefence := home.apply(m.Strength(homeTeamID))
+	awayAttack, awayDefence := away.apply(m.Strength(awayTeamID))
+	return m.Base * m.HomeAdvantage * homeAttack * awayDefence, m.Base * awayAttack * homeDefence
+}
+
+// Knows reports whether the team appeared in the fitted results.
+func (m *Model) Knows(teamID int) bool {
+	_, ok := m.Attack[teamID]
+	return ok
+}
+
+// Prediction is the model's view of one match.
+type Prediction struct {
+	HomeWin           float64
+	Draw              float64
+	AwayWin           float64
+	ExpectedHomeGoals float64
+	ExpectedAwayGoals float64
+	// LikelyHomeGoals and LikelyAwayGoals are the most probable scoreline.
+	LikelyHomeGoals int
+	LikelyAwayGoals int
+	LikelyScoreProb float64
+}
+
+// Predict returns outcome probabilities for a match between two teams,
+// identified by internal ID.
+func (m *Model) Predict(homeTeamID, awayTeamID int) Prediction {
+	return m.PredictAdjusted(homeTeamID, awayTeamID, Adjustment{}, Adjustment{})
+}
+
+// PredictAdjusted is Predict with each side's strengths adjusted first.
+func (m *Model) PredictAdjusted(homeTeamID, awayTeamID int, home, away Adjustment) Prediction {
+	lambda, mu := m.rates(homeTeamID, awayTeamID, home, away)
+	return m.predict(lambda, mu)
+}
+
+// PredictNeutral is Predict at a neutral venue. Neither side gets the home
+// advantage; both get its square root instead, so an average pairing
+// expects as many goals as it would at either team's ground.
+func (m *Model) PredictNeutral(teamA, teamB int) Prediction {
+	return m.PredictNeutralAdjusted(teamA, teamB, Adjustment{}, Adjustment{})
+}
+
+// PredictNeutralAdjusted is PredictNeutral with each side's strengths
+// adjusted first.
+func (m *Model) PredictNeutralAdjusted(teamA, teamB int, a, b Adjustment) Prediction {
+	lambda, mu := m.rates(teamA, teamB, a, b)
+	split := math.Sqrt(m.HomeAdvantage)
+	return m.predict(lambda/split, mu*split)
+}
+
+func (m *Model) predict(lambda, mu float64) Prediction {
+	p := Prediction{ExpectedHomeGoals: lambda, ExpectedAwayGoals: mu}
+
+	var total float64
+	for x := 0; x <= maxGoals; x++ {
+		for y := 0; y <= maxGoals; y++ {
+			prob := tau(x, y, lambda, mu, m.Rho) * poisson(x, lambda) * poisson(y, mu)
+			total += prob
+			switch {
+			case x > y:
+				p.HomeWin += prob
+			case x == y:
+				p.Draw += prob
+			default:
+				p.AwayWin += prob
+			}
+			if prob > p.LikelyScoreProb {
+				p.LikelyHomeGoals, p.LikelyAwayGoals, p.LikelyScoreProb = x, y, prob
+			}
+		}
+	}
+	if total > 0 {
+		p.HomeWin /= total
+		p.Draw /= total
+		p.AwayWin /= total
+		p.LikelyScoreProb /= total
+	}
+	return p
+}
+
+func poisson(k int, lambda float64) float64 {
+	if lambda <= 0 {
+		if k == 0 {
+			return 1
+		}
+		return 0
+	}
+	lg, _ := math.Lgamma(float64(k + 1))
+	return math.Exp(float64(k)*math.Log(lambda) - lambda - lg)
+}
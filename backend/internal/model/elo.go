@@ -0,0 +1,59 @@
+package model
+
+import "math"
+
+// Elo rating defaults. Teams start at eloInitial; a home side is treated as
+// eloHomeAdvantage points stronger when computing expected results.
+const (
+	eloInitial       = 1500.0
+	eloK             = 20.0
+	eloHomeAdvantage = 65.0
+)
+
+// Elo replays results, oldest first, and returns every team's rating
+// afterwards. Wins by a wider margin move ratings further, as in the World
+// Football Elo ratings.
+func Elo(results []Result) map[int]float64 {
+	ratings := make(map[int]float64)
+	rating := func(teamID int) float64 {
+		if r, ok := ratings[teamID]; ok {
+			return r
+		}
+		return eloInitial
+	}
+
+	for _, r := range results {
+		home, away := rating(r.HomeTeamID), rating(r.AwayTeamID)
+		expected := 1 / (1 + math.Pow(10, (away-home-eloHomeAdvantage)/400))
+
+		actual := 0.5
+		switch {
+		case r.HomeGoals > r.AwayGoals:
+			actual = 1
+		case r.HomeGoals < r.AwayGoals:
+			actual = 0
+		}
+
+		margin := 1.0
+		switch diff := math.Abs(float64(r.HomeGoals - r.AwayGoals)); {
+		case diff == 2:
+			margin = 1.5
+		case diff >= 3:
+			margin = (11 + diff) / 8
+		}
+
+		delta := eloK * margin * (actual - expected)
+		ratings[r.HomeTeamID] = home + delta
+		ratings[r.AwayTeamID] = away - delta
+	}
+	return ratings
+}
+
+// EloRating returns a team's rating, or the initial rating for teams not in
+// ratings.
+func EloRating(ratings map[int]float64, teamID int) float64 {
+	if r, ok := ratings[teamID]; ok {
+		return r
+	}
+	return eloInitial
+}
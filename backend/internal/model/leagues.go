@@ -0,0 +1,121 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// LeagueStrength is how strong the teams at home in a competition are
+// against those of other competitions.
+type LeagueStrength struct {
+	CompetitionID int
+	Teams         int
+	MeanElo       float64
+	// Coefficient is twice the expected score of the league's average team
+	// against the average team of every league at a neutral venue: 1 is
+	// average and above 1 stronger.
+	Coefficient float64
+	// InterLeagueMatches counts the league's results against teams from
+	// other leagues, which are all that set its teams apart from others.
+	InterLeagueMatches int
+}
+
+// Leagues are the home competitions of teams and their strengths.
+type Leagues struct {
+	Home      map[int]int            // competition ID by team ID
+	Strengths map[int]LeagueStrength // by competition ID
+}
+
+// FitLeagues estimates league strengths from results across competitions,
+// oldest first. A team's home competition is the one it played most of its
+// results in, so clubs belong to their domestic league rather than to
+// European cups and national teams to their qualifying competition. Elo
+// only moves points between the two sides of a result, so a league's mean
+// rating drifts from the start only through inter-league results.
+func FitLeagues(results []Result) *Leagues {
+	type played struct {
+		matches int
+		last    time.Time
+	}
+	counts := make(map[int]map[int]*played)
+	for _, r := range results {
+		if r.CompetitionID == 0 {
+			continue
+		}
+		for _, teamID := range []int{r.HomeTeamID, r.AwayTeamID} {
+			if counts[teamID] == nil {
+				counts[teamID] = make(map[int]*played)
+			}
+			p := counts[teamID][r.CompetitionID]
+			if p == nil {
+				p = &played{}
+				counts[teamID][r.CompetitionID] = p
+			}
+			p.matches++
+			p.last = r.Date
+		}
+	}
+
+	l := &Leagues{Home: make(map[int]int), Strengths: make(map[int]LeagueStrength)}
+	for teamID, byCompetition := range counts {
+		var best *played
+		for competitionID, p := range byCompetition {
+			if best == nil || p.matches > best.matches || (p.matches == best.matches && p.last.After(best.last)) {
+				best = p
+				l.Home[teamID] = competitionID
+			}
+		}
+	}
+
+	ratings := Elo(results)
+	var total float64
+	for teamID, competitionID := range l.Home {
+		rating := EloRating(ratings, teamID)
+		s := l.Strengths[competitionID]
+		s.CompetitionID = competitionID
+		s.Teams++
+		s.MeanElo += rating
+		l.Strengths[competitionID] = s
+		total += rating
+	}
+	if len(l.Home) == 0 {
+		return l
+	}
+	reference := total / float64(len(l.Home))
+
+	for _, r := range results {
+		home, homeOK := l.Home[r.HomeTeamID]
+		away, awayOK := l.Home[r.AwayTeamID]
+		if !homeOK || !awayOK || home == away {
+			continue
+		}
+		for _, competitionID := range []int{home, away} {
+			s := l.Strengths[competitionID]
+			s.InterLeagueMatches++
+			l.Strengths[competitionID] = s
+		}
+	}
+	for competitionID, s := range l.Strengths {
+		s.MeanElo /= float64(s.Teams)
+		s.Coefficient = 2 / (1 + math.Pow(10, (reference-s.MeanElo)/400))
+		s.MeanElo = math.Round(s.MeanElo*10) / 10
+		s.Coefficient = math.Round(s.Coefficient*1000) / 1000
+		l.Strengths[competitionID] = s
+	}
+	return l
+}
+
+// Between returns the strength coefficients of two teams' home leagues. ok
+// is false when they share a league or either league is unknown, in which
+// case their stats need no normalising.
+func (l *Leagues) Between(teamA, teamB int) (a, b float64, ok bool) {
+	if l == nil {
+		return 1, 1, false
+	}
+	leagueA, okA := l.Home[teamA]
+	leagueB, okB := l.Home[teamB]
+	if !okA || !okB || leagueA == leagueB {
+		return 1, 1, false
+	}
+	return l.Strengths[leagueA].Coefficient, l.Strengths[leagueB].Coefficient, true
+}
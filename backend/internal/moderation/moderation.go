@@ -0,0 +1,234 @@
+// Package moderation checks generated text before it is published: length
+// bounds, banned content and signs of low quality. Issues either block the
+// text or only flag it for review.
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Severities of an issue.
+const (
+	// SeverityReject keeps the text from being published.
+	SeverityReject = "reject"
+	// SeverityFlag publishes the text but queues it for review.
+	SeverityFlag = "flag"
+)
+
+// Issue is one problem found in a text. Check names the rule that found it.
+type Issue struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Detail   string `json:"detail"`
+}
+
+// Limits bound a text's length in characters; 0 leaves a side unbounded.
+type Limits struct {
+	Min int
+	Max int
+}
+
+// Rules are what a text is checked against. A body shorter than ShortBody
+// is flagged as thin; Banned terms are matched as whole words, ignoring case.
+type Rules struct {
+	Headline  Limits
+	Body      Limits
+	ShortBody int
+	Banned    []string
+}
+
+// DefaultBanned are terms generated text must never contain: profanity,
+// gambling promotion and model self-references.
+var DefaultBanned = []string{
+	"fuck", "fucking", "shit", "cunt", "bitch", "bastard", "wanker",
+	"sure bet", "bet now", "betting tip", "guaranteed win", "place your bets",
+	"as an ai", "language model",
+}
+
+// Text is a generated headline, empty for answers, and body. Names are the
+// subjects it should be about, such as the match's teams; a text naming none
+// of them is flagged.
+type Text struct {
+	Headline string
+	Body     string
+	Names    []string
+}
+
+// Check returns the issues found in t, rejections first.
+func (r Rules) Check(t Text) []Issue {
+	var rejects, flags []Issue
+	reject := func(check, detail string) {
+		rejects = append(rejects, Issue{Check: check, Severity: SeverityReject, Detail: detail})
+	}
+	flag := func(check, detail string) {
+		flags = append(flags, Issue{Check: check, Severity: SeverityFlag, Detail: detail})
+	}
+
+	if t.Headline != "" || r.Headline.Min > 0 {
+		if detail := r.Headline.check("headline", t.Headline); detail != "" {
+			reject("length", detail)
+		}
+	}
+	if detail := r.Body.check("body", t.Body); detail != "" {
+		reject("length", detail)
+	}
+
+	text := t.Headline + "\n" + t.Body
+	if terms := banned(r.Banned, text); len(terms) > 0 {
+		reject("banned", "contains "+strings.Join(terms, ", "))
+	}
+	if links.MatchString(text) {
+		reject("link", "contains a link")
+	}
+	if placeholders.MatchString(text) {
+		reject("placeholder", "contains template or citation leftovers")
+	}
+
+	if r.ShortBody > 0 && len([]rune(t.Body)) < r.ShortBody {
+		flag("thin", "body is under the usual length")
+	}
+	if s := repeatedSentence(t.Body); s != "" {
+		flag("repetition", "repeats \""+s+"\"")
+	}
+	if shouting(t.Headline) {
+		flag("shouting", "headline is mostly capitals")
+	}
+	if len(t.Names) > 0 && !mentionsAny(text, t.Names) {
+		flag("off_topic", "names none of "+strings.Join(t.Names, ", "))
+	}
+
+	return append(rejects, flags...)
+}
+
+// Rejected reports whether any issue keeps the text from being published.
+func Rejected(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityReject {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns why text breaks the limits, or "" if it does not.
+func (l Limits) check(field, text string) string {
+	n := len([]rune(strings.TrimSpace(text)))
+	switch {
+	case n < l.Min:
+		return field + " is too short"
+	case l.Max > 0 && n > l.Max:
+		return field + " is too long"
+	}
+	return ""
+}
+
+var (
+	links        = regexp.MustCompile(`(?i)https?://|www\.`)
+	placeholders = regexp.MustCompile(`(?i)\{\{|\}\}|\[F\d+|\bTODO\b|\blorem ipsum\b|<[a-z/][^>]*>`)
+	sentences    = regexp.MustCompile(`[^.!?]+[.!?]*`)
+)
+
+// banned returns the terms found in text as whole words.
+func banned(terms []string, text string) []string {
+	lower := strings.ToLower(text)
+	var found []string
+	for _, term := range terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term != "" && containsWord(lower, term) {
+			found = append(found, term)
+		}
+	}
+	return found
+}
+
+// containsWord reports whether word occurs in text not as part of a longer
+// word.
+func containsWord(text, word string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if !wordRuneBefore(text, start) && !wordRuneAt(text, end) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func wordRuneBefore(text string, i int) bool {
+	if i == 0 {
+		return false
+	}
+	r := []rune(text[:i])
+	return isWordRune(r[len(r)-1])
+}
+
+func wordRuneAt(text string, i int) bool {
+	if i >= len(text) {
+		return false
+	}
+	return isWordRune([]rune(text[i:])[0])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// repeatedSentence returns a sentence text states twice, or "".
+func repeatedSentence(text string) string {
+	seen := make(map[string]bool)
+	for _, s := range sentences.FindAllString(text, -1) {
+		key := strings.ToLower(strings.Join(strings.Fields(strings.Trim(s, ".!? \n")), " "))
+		if len(strings.Fields(key)) < 4 {
+			continue
+		}
+		if seen[key] {
+			return strings.TrimSpace(s)
+		}
+		seen[key] = true
+	}
+	return ""
+}
+
+// shouting reports whether most of a headline's letters, of at least ten,
+// are capitals.
+func shouting(headline string) bool {
+	letters, upper := 0, 0
+	for _, r := range headline {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 10 && upper*10 > letters*6
+}
+
+// mentionsAny reports whether text names any of names, in full or by a
+// distinctive word such as "Arsenal" for "Arsenal FC".
+func mentionsAny(text string, names []string) bool {
+	lower := strings.ToLower(text)
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n != "" && strings.Contains(lower, n) {
+			return true
+		}
+		for _, w := range strings.Fields(n) {
+			if len(w) >= 4 && !genericNameWords[w] && containsWord(lower, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// genericNameWords are words of club names too common to identify one.
+var genericNameWords = map[string]bool{
+	"club": true, "football": true, "united": true, "city": true, "town": true,
+	"athletic": true, "sporting": true, "real": true, "county": true, "rovers": true,
+}
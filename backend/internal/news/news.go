@@ -0,0 +1,271 @@
+// Package news fetches team news from RSS and Atom feeds and from a news
+// API, and finds the teams and players each article mentions.
+package news
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Feed kinds.
+const (
+	// KindRSS is an RSS 2.0 or Atom feed.
+	KindRSS = "rss"
+	// KindAPI is a news API endpoint answering in the NewsAPI format, with
+	// the key sent in the X-Api-Key header.
+	KindAPI = "api"
+)
+
+const (
+	// maxFeedBytes caps the size of a fetched feed.
+	maxFeedBytes = 5 << 20
+	// maxSummaryLength caps a stored summary, in runes.
+	maxSummaryLength = 500
+)
+
+// Feed is a source of articles.
+type Feed struct {
+	Name   string
+	URL    string
+	Kind   string
+	APIKey string
+}
+
+// Article is a news item as fetched. Summary is plain text.
+type Article struct {
+	Source      string
+	URL         string
+	Title       string
+	Summary     string
+	PublishedAt time.Time
+}
+
+// ParseFeeds parses "name=url" RSS feeds separated by commas, e.g.
+// "bbc=https://feeds.bbci.co.uk/sport/football/rss.xml". Invalid pairs are
+// reported in the error and left out of the result.
+func ParseFeeds(spec string) ([]Feed, error) {
+	var feeds []Feed
+	var invalid []string
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, url, _ := strings.Cut(pair, "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if name == "" || !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			invalid = append(invalid, pair)
+			continue
+		}
+		feeds = append(feeds, Feed{Name: name, URL: url, Kind: KindRSS})
+	}
+
+	if len(invalid) > 0 {
+		return feeds, fmt.Errorf("invalid news feeds %s", strings.Join(invalid, ", "))
+	}
+	return feeds, nil
+}
+
+// Client fetches feeds over HTTP.
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// Fetch returns the articles of a feed, leaving out those without a title
+// or link. Articles without a date are dated now.
+func (c *Client) Fetch(ctx context.Context, f Feed) ([]Article, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if f.APIKey != "" {
+		req.Header.Set("X-Api-Key", f.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", f.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", f.Name, resp.StatusCode)
+	}
+	body := io.LimitReader(resp.Body, maxFeedBytes)
+
+	var articles []Article
+	switch f.Kind {
+	case KindAPI:
+		articles, err = parseAPI(body, f.Name)
+	default:
+		articles, err = parseRSS(body, f.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+	}
+	return articles, nil
+}
+
+// feedDocument decodes both RSS (channel items) and Atom (entries).
+type feedDocument struct {
+	Items []struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		PubDate     string `xml:"pubDate"`
+	} `xml:"channel>item"`
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+func parseRSS(r io.Reader, source string) ([]Article, error) {
+	dec := xml.NewDecoder(r)
+	// Feeds are read as UTF-8 whatever they declare; the few in other
+	// encodings only lose their accented letters
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	var doc feedDocument
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var articles []Article
+	for _, item := range doc.Items {
+		articles = appendArticle(articles, Article{
+			Source:      source,
+			URL:         strings.TrimSpace(item.Link),
+			Title:       plainText(item.Title),
+			Summary:     plainText(item.Description),
+			PublishedAt: parseDate(item.PubDate, now),
+		})
+	}
+	for _, entry := range doc.Entries {
+		var link string
+		for _, l := range entry.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		summary := entry.Summary
+		if summary == "" {
+			summary = entry.Content
+		}
+		published := entry.Published
+		if published == "" {
+			published = entry.Updated
+		}
+		articles = appendArticle(articles, Article{
+			Source:      source,
+			URL:         strings.TrimSpace(link),
+			Title:       plainText(entry.Title),
+			Summary:     plainText(summary),
+			PublishedAt: parseDate(published, now),
+		})
+	}
+	return articles, nil
+}
+
+func parseAPI(r io.Reader, source string) ([]Article, error) {
+	var reply struct {
+		Status   string `json:"status"`
+		Message  string `json:"message"`
+		Articles []struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+			URL         string `json:"url"`
+			PublishedAt string `json:"publishedAt"`
+		} `json:"articles"`
+	}
+	if err := json.NewDecoder(r).Decode(&reply); err != nil {
+		return nil, err
+	}
+	if reply.Status == "error" {
+		return nil, fmt.Errorf("news API error: %s", reply.Message)
+	}
+
+	now := time.Now().UTC()
+	var articles []Article
+	for _, a := range reply.Articles {
+		articles = appendArticle(articles, Article{
+			Source:      source,
+			URL:         strings.TrimSpace(a.URL),
+			Title:       plainText(a.Title),
+			Summary:     plainText(a.Description),
+			PublishedAt: parseDate(a.PublishedAt, now),
+		})
+	}
+	return articles, nil
+}
+
+// appendArticle appends a unless it lacks a title or an absolute link.
+func appendArticle(articles []Article, a Article) []Article {
+	if a.Title == "" || !strings.HasPrefix(a.URL, "http") {
+		return articles
+	}
+	return append(articles, a)
+}
+
+// dateLayouts are the date formats seen in feeds: RFC 822 variants in RSS
+// and RFC 3339 in Atom and news APIs.
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+}
+
+// parseDate parses a feed date to UTC, or returns fallback if it is in no
+// known format.
+func parseDate(s string, fallback time.Time) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return fallback
+}
+
+var (
+	htmlTags   = regexp.MustCompile(`<[^>]*>`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// plainText strips markup from feed text, unescapes entities, collapses
+// whitespace and caps it at maxSummaryLength runes.
+func plainText(s string) string {
+	s = html.UnescapeString(htmlTags.ReplaceAllString(s, " "))
+	s = strings.TrimSpace(whitespace.ReplaceAllString(s, " "))
+	if runes := []rune(s); len(runes) > maxSummaryLength {
+		s = strings.TrimSpace(string(runes[:maxSummaryLength])) + "…"
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+package news
+
+import "strings"
+
+// Relevance of an article to a team or player it mentions, by where the
+// name appears.
+const (
+	TitleRelevance   = 1.0
+	SummaryRelevance = 0.6
+)
+
+// minNameLength is the shortest normalized name looked for, so three-letter
+// codes and initials do not match ordinary words.
+const minNameLength = 4
+
+// Entity is a team or player with the normalized names it goes by.
+type Entity struct {
+	ID    int
+	Names []string
+}
+
+// Tag is an entity an article mentions and how relevant the article is to
+// it.
+type Tag struct {
+	ID        int
+	Relevance float64
+}
+
+// Text is an article's title and summary normalized for finding names.
+type Text struct {
+	title, summary string
+}
+
+// NewText normalizes a's title and summary with normalize, which must be
+// the function the entity names were normalized with.
+func NewText(a Article, normalize func(string) string) Text {
+	return Text{
+		title:   " " + normalize(a.Title) + " ",
+		summary: " " + normalize(a.Summary) + " ",
+	}
+}
+
+// Mentions returns the entities whose names appear as whole words in the
+// text, each at the relevance of its most prominent name.
+func (t Text) Mentions(entities []Entity) []Tag {
+	var tags []Tag
+	for _, e := range entities {
+		best := 0.0
+		for _, name := range e.Names {
+			if len(name) < minNameLength {
+				continue
+			}
+			needle := " " + name + " "
+			switch {
+			case strings.Contains(t.title, needle):
+				best = TitleRelevance
+			case strings.Contains(t.summary, needle) && best < SummaryRelevance:
+				best = SummaryRelevance
+			}
+			if best == TitleRelevance {
+				break
+			}
+		}
+		if best > 0 {
+			tags = append(tags, Tag{ID: e.ID, Relevance: best})
+		}
+	}
+	return tags
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// AnomalyCandidate is a settled served prediction waiting to be checked for
+// anomalies. Probabilities are nil for predictions stored without them.
+type AnomalyCandidate struct {
+	ID                  int
+	MatchID             int
+	ExternalID          int
+	UtcDate             time.Time
+	CompetitionCode     string
+	HomeTeamName        string
+	AwayTeamName        string
+	ModelVersion        string
+	PredictedTeamAGoals *float64
+	PredictedTeamBGoals *float64
+	PredictedOutcome    string
+	ConfidenceScore     float64
+	HomeWinProbability  *float64
+	DrawProbability     *float64
+	AwayWinProbability  *float64
+	ActualTeamAGoals    int
+	ActualTeamBGoals    int
+	ActualOutcome       string
+	PredictionCorrect   *bool
+}
+
+// NewAnomaly is a match to flag.
+type NewAnomaly struct {
+	MatchID            int
+	Kind               string
+	ModelVersion       string
+	OutcomeProbability *float64
+	GoalsError         *float64
+	Context            json.RawMessage
+}
+
+// Anomaly is a flagged match with the details needed to display it.
+type Anomaly struct {
+	ID                 int             `json:"id"`
+	Kind               string          `json:"kind"`
+	ModelVersion       string          `json:"modelVersion"`
+	OutcomeProbability *float64        `json:"outcomeProbability"`
+	GoalsError         *float64        `json:"goalsError"`
+	Context            json.RawMessage `json:"context"`
+	DetectedAt         *time.Time      `json:"detectedAt"`
+	MatchID            int             `json:"matchId"`
+	ExternalID         int             `json:"externalId"`
+	UtcDate            time.Time       `json:"utcDate"`
+	CompetitionCode    string          `json:"competitionCode"`
+	HomeTeamName       string          `json:"homeTeamName"`
+	AwayTeamName       string          `json:"awayTeamName"`
+	HomeScore          *int            `json:"homeScore"`
+	AwayScore          *int            `json:"awayScore"`
+}
+
+// AnomalyRepository provides DB access for the anomalies table.
+type AnomalyRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewAnomalyRepository(db *sql.DB) *AnomalyRepository {
+	return &AnomalyRepository{q: sqlcdb.New(db)}
+}
+
+// ListCandidates returns up to limit settled predictions not yet checked,
+// oldest kickoff first.
+func (r *AnomalyRepository) ListCandidates(limit int) ([]AnomalyCandidate, error) {
+	rows, err := r.q.ListAnomalyCandidates(context.Background(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query anomaly candidates: %w", err)
+	}
+
+	candidates := make([]AnomalyCandidate, 0, len(rows))
+	for _, row := range rows {
+		candidates = append(candidates, AnomalyCandidate(row))
+	}
+	return candidates, nil
+}
+
+// Insert flags a match. It returns false if the match was already flagged
+// for the same kind.
+func (r *AnomalyRepository) Insert(a NewAnomaly) (bool, error) {
+	rows, err := r.q.InsertAnomaly(context.Background(), sqlcdb.InsertAnomalyParams(a))
+	if err != nil {
+		return false, fmt.Errorf("failed to insert anomaly: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// MarkChecked records that a prediction has been checked.
+func (r *AnomalyRepository) MarkChecked(predictionID int) error {
+	if err := r.q.MarkAnomalyChecked(context.Background(), predictionID); err != nil {
+		return fmt.Errorf("failed to mark prediction checked: %w", err)
+	}
+	return nil
+}
+
+// List returns anomalies of matches kicked off on or after since, most
+// recent first. An empty kind matches every kind.
+func (r *AnomalyRepository) List(since time.Time, kind string, limit int) ([]Anomaly, error) {
+	rows, err := r.q.ListAnomalies(context.Background(), sqlcdb.ListAnomaliesParams{
+		Since:    since,
+		Kind:     kind,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query anomalies: %w", err)
+	}
+
+	anomalies := make([]Anomaly, 0, len(rows))
+	for _, row := range rows {
+		anomalies = append(anomalies, Anomaly(row))
+	}
+	return anomalies, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// AuditEntry records an admin, key management or operational action.
+// UserID is set when a user's API key was used and Status holds the HTTP
+// status, or the exit code of a CLI command.
+type AuditEntry struct {
+	ID        int             `json:"id"`
+	Action    string          `json:"action"`
+	ActorType string          `json:"actorType"`
+	Actor     string          `json:"actor"`
+	UserID    *int            `json:"userId,omitempty"`
+	Target    string          `json:"target,omitempty"`
+	Method    string          `json:"method,omitempty"`
+	Path      string          `json:"path,omitempty"`
+	Status    *int            `json:"status,omitempty"`
+	IP        string          `json:"ip,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// AuditFilter narrows the audit log. Empty fields match everything; Action
+// matches a prefix.
+type AuditFilter struct {
+	Action    string
+	ActorType string
+	Actor     string
+	Since     *time.Time
+	Until     *time.Time
+	Limit     int
+}
+
+// AuditRepository provides DB access for the audit log.
+type AuditRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{q: sqlcdb.New(db)}
+}
+
+// Record stores an entry.
+func (r *AuditRepository) Record(e AuditEntry) error {
+	err := r.q.InsertAuditEntry(context.Background(), sqlcdb.InsertAuditEntryParams{
+		Action:    e.Action,
+		ActorType: e.ActorType,
+		Actor:     e.Actor,
+		UserID:    e.UserID,
+		Target:    e.Target,
+		Method:    e.Method,
+		Path:      e.Path,
+		Status:    e.Status,
+		Ip:        e.IP,
+		Details:   e.Details,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns matching entries, newest first.
+func (r *AuditRepository) List(f AuditFilter) ([]AuditEntry, error) {
+	rows, err := r.q.ListAuditEntries(context.Background(), sqlcdb.ListAuditEntriesParams{
+		Action:    f.Action,
+		ActorType: f.ActorType,
+		Actor:     f.Actor,
+		Since:     f.Since,
+		Until:     f.Until,
+		RowLimit:  f.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries := make([]AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, AuditEntry{
+			ID:        row.ID,
+			Action:    row.Action,
+			ActorType: row.ActorType,
+			Actor:     row.Actor,
+			UserID:    row.UserID,
+			Target:    row.Target,
+			Method:    row.Method,
+			Path:      row.Path,
+			Status:    row.Status,
+			IP:        row.Ip,
+			Details:   row.Details,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return entries, nil
+}
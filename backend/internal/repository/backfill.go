@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Backfill job statuses.
+const (
+	BackfillPending = "pending"
+	BackfillRunning = "running"
+	BackfillDone    = "done"
+	BackfillFailed  = "failed"
+)
+
+// BackfillJob is the checkpoint for one competition season.
+type BackfillJob struct {
+	ID              int
+	CompetitionCode string
+	Season          string
+	Status          string
+	Attempts        int
+	MatchesSaved    int
+	LastError       string
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+}
+
+// BackfillRepository persists backfill checkpoints.
+type BackfillRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewBackfillRepository(db *sql.DB) *BackfillRepository {
+	return &BackfillRepository{q: sqlcdb.New(db)}
+}
+
+// Ensure creates pending jobs for the given competition seasons. Existing
+// jobs keep their progress.
+func (r *BackfillRepository) Ensure(competitionCode, season string) error {
+	err := r.q.EnsureBackfillJob(context.Background(), sqlcdb.EnsureBackfillJobParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create backfill job: %w", err)
+	}
+	return nil
+}
+
+// List returns jobs for the given competitions and season range ordered by
+// season then competition, the order they are processed in.
+func (r *BackfillRepository) List(competitionCodes []string, fromSeason, toSeason string) ([]BackfillJob, error) {
+	rows, err := r.q.ListBackfillJobs(context.Background(), sqlcdb.ListBackfillJobsParams{
+		CompetitionCodes: competitionCodes,
+		FromSeason:       fromSeason,
+		ToSeason:         toSeason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backfill jobs: %w", err)
+	}
+
+	var jobs []BackfillJob
+	for _, row := range rows {
+		jobs = append(jobs, BackfillJob(row))
+	}
+	return jobs, nil
+}
+
+// MarkRunning records the start of an attempt.
+func (r *BackfillRepository) MarkRunning(id int) error {
+	if err := r.q.MarkBackfillJobRunning(context.Background(), id); err != nil {
+		return fmt.Errorf("failed to update backfill job: %w", err)
+	}
+	return nil
+}
+
+// MarkDone checkpoints a completed competition season.
+func (r *BackfillRepository) MarkDone(id, matchesSaved int) error {
+	err := r.q.MarkBackfillJobDone(context.Background(), sqlcdb.MarkBackfillJobDoneParams{
+		MatchesSaved: matchesSaved,
+		ID:           id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update backfill job: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records an error. Failed jobs are retried on the next run.
+func (r *BackfillRepository) MarkFailed(id int, errMsg string) error {
+	err := r.q.MarkBackfillJobFailed(context.Background(), sqlcdb.MarkBackfillJobFailedParams{
+		LastError: errMsg,
+		ID:        id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update backfill job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// BacktestFixture is a finished match replayed by a backtest.
+type BacktestFixture struct {
+	ID                 int
+	UtcDate            time.Time
+	CompetitionID      *int
+	Matchday           int
+	HomeTeamID         int
+	AwayTeamID         int
+	HomeTeamExternalID int
+	AwayTeamExternalID int
+	HomeTeamName       string
+	AwayTeamName       string
+	HomeScore          int
+	AwayScore          int
+}
+
+// Backtest is the summary of one backtest run. CompetitionCode is empty
+// when every competition was replayed.
+type Backtest struct {
+	ID              int
+	Backend         string
+	ModelVersion    string
+	CompetitionCode string
+	Season          string
+	Matches         int
+	Correct         int
+	Accuracy        *float64
+	LogLoss         *float64
+	Brier           *float64
+	StartedAt       time.Time
+}
+
+// BacktestPrediction is a backtest's prediction for one fixture. Outcomes
+// are HOME, DRAW or AWAY.
+type BacktestPrediction struct {
+	MatchID            int
+	HomeWinProbability float64
+	DrawProbability    float64
+	AwayWinProbability float64
+	PredictedOutcome   string
+	ActualOutcome      string
+	ExpectedHomeGoals  *float64
+	ExpectedAwayGoals  *float64
+	TrainedOn          int
+}
+
+// BacktestRepository provides DB access for backtests.
+type BacktestRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewBacktestRepository(db *sql.DB) *BacktestRepository {
+	return &BacktestRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// ListFixtures returns the real finished matches of a season, by start year,
+// in kickoff order. An empty competitionCode matches every competition.
+func (r *BacktestRepository) ListFixtures(competitionCode, season string) ([]BacktestFixture, error) {
+	rows, err := r.q.ListBacktestFixtures(context.Background(), sqlcdb.ListBacktestFixturesParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backtest fixtures: %w", err)
+	}
+
+	fixtures := make([]BacktestFixture, 0, len(rows))
+	for _, row := range rows {
+		fixtures = append(fixtures, BacktestFixture(row))
+	}
+	return fixtures, nil
+}
+
+// Save stores a backtest with its predictions and returns its ID.
+func (r *BacktestRepository) Save(b Backtest, predictions []BacktestPrediction) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	var competitionCode *string
+	if b.CompetitionCode != "" {
+		competitionCode = &b.CompetitionCode
+	}
+
+	id, err := q.InsertBacktest(ctx, sqlcdb.InsertBacktestParams{
+		Backend:         b.Backend,
+		ModelVersion:    b.ModelVersion,
+		CompetitionCode: competitionCode,
+		Season:          b.Season,
+		Matches:         b.Matches,
+		Correct:         b.Correct,
+		Accuracy:        b.Accuracy,
+		LogLoss:         b.LogLoss,
+		Brier:           b.Brier,
+		StartedAt:       b.StartedAt,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert backtest: %w", err)
+	}
+
+	for _, p := range predictions {
+		err := q.InsertBacktestPrediction(ctx, sqlcdb.InsertBacktestPredictionParams{
+			BacktestID:         id,
+			MatchID:            p.MatchID,
+			HomeWinProbability: p.HomeWinProbability,
+			DrawProbability:    p.DrawProbability,
+			AwayWinProbability: p.AwayWinProbability,
+			PredictedOutcome:   p.PredictedOutcome,
+			ActualOutcome:      p.ActualOutcome,
+			ExpectedHomeGoals:  p.ExpectedHomeGoals,
+			ExpectedAwayGoals:  p.ExpectedAwayGoals,
+			TrainedOn:          p.TrainedOn,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert backtest prediction: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit backtest: %w", err)
+	}
+	return id, nil
+}
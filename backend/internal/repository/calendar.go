@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// CalendarMatch is a stored match as a calendar feed needs it. Sequence
+// counts the times it was rescheduled, postponed, cancelled or reinstated.
+type CalendarMatch struct {
+	ExternalID      int
+	CompetitionCode string
+	CompetitionName string
+	Matchday        int
+	Stage           string
+	UtcDate         time.Time
+	Status          string
+	HomeTeamName    string
+	AwayTeamName    string
+	HomeScore       *int
+	AwayScore       *int
+	Venue           string
+	Sequence        int
+	UpdatedAt       time.Time
+}
+
+// ListCalendarMatches returns the matches of a team, by internal ID, or of a
+// competition kicked off since since, in kickoff order, at most limit.
+// teamID 0 and an empty competitionCode are not filtered on.
+func (r *MatchRepository) ListCalendarMatches(teamID int, competitionCode string, since time.Time, limit int) ([]CalendarMatch, error) {
+	rows, err := r.q.ListCalendarMatches(context.Background(), sqlcdb.ListCalendarMatchesParams{
+		TeamID:          teamID,
+		CompetitionCode: competitionCode,
+		Since:           since,
+		RowLimit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query calendar matches: %w", err)
+	}
+
+	matches := make([]CalendarMatch, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, CalendarMatch{
+			ExternalID:      row.ExternalID,
+			CompetitionCode: row.CompetitionCode,
+			CompetitionName: row.CompetitionName,
+			Matchday:        row.Matchday,
+			Stage:           row.Stage,
+			UtcDate:         row.UtcDate,
+			Status:          row.Status,
+			HomeTeamName:    row.HomeTeamName,
+			AwayTeamName:    row.AwayTeamName,
+			HomeScore:       row.HomeScore,
+			AwayScore:       row.AwayScore,
+			Venue:           row.Venue,
+			Sequence:        row.ScheduleSequence,
+			UpdatedAt:       row.UpdatedAt,
+		})
+	}
+	return matches, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// newManagerMatches is how many matches a coach who replaced another counts
+// as new, the window of the "new manager bounce".
+const newManagerMatches = 5
+
+// CoachSpell is a run of consecutive matches of a team under one coach,
+// with the team's record in the finished ones.
+type CoachSpell struct {
+	CoachID     int       `json:"coachId"`
+	Name        string    `json:"name"`
+	Nationality *string   `json:"nationality"`
+	StartedAt   time.Time `json:"startedAt"`
+	LastMatchAt time.Time `json:"lastMatchAt"`
+	Matches     int       `json:"matches"`
+	Played      int       `json:"played"`
+	Won         int       `json:"won"`
+	Draw        int       `json:"draw"`
+	Lost        int       `json:"lost"`
+}
+
+// Tenure is how long a team's coach had been in charge at a point in time,
+// from the coaches of its stored lineups.
+type Tenure struct {
+	CoachID int
+	// Matches is how many of the team's latest lineups the coach led, up
+	// to newManagerMatches+1.
+	Matches int
+	// Replaced is whether a different coach led an earlier lineup.
+	Replaced bool
+}
+
+// NewManager reports whether the coach replaced another within the last
+// newManagerMatches matches.
+func (t *Tenure) NewManager() bool {
+	return t != nil && t.Replaced && t.Matches <= newManagerMatches
+}
+
+// CoachRepository provides DB access for coaches and their spells.
+type CoachRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewCoachRepository(db *sql.DB) *CoachRepository {
+	return &CoachRepository{q: sqlcdb.New(db)}
+}
+
+// ListSpells returns a team's coaching spells, latest first.
+func (r *CoachRepository) ListSpells(teamID int) ([]CoachSpell, error) {
+	rows, err := r.q.ListTeamCoachSpells(context.Background(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coaching spells: %w", err)
+	}
+
+	spells := make([]CoachSpell, 0, len(rows))
+	for _, row := range rows {
+		spells = append(spells, CoachSpell(row))
+	}
+	return spells, nil
+}
+
+// Tenure returns the tenure of the coach of a team's latest lineup kicking
+// off before a time, or nil when none of its lineups name a coach.
+func (r *CoachRepository) Tenure(teamID int, before time.Time) (*Tenure, error) {
+	coaches, err := r.q.ListTeamRecentCoaches(context.Background(), sqlcdb.ListTeamRecentCoachesParams{
+		TeamID:     teamID,
+		Before:     before,
+		MaxMatches: newManagerMatches + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent coaches: %w", err)
+	}
+	if len(coaches) == 0 {
+		return nil, nil
+	}
+
+	t := &Tenure{CoachID: coaches[0]}
+	for _, id := range coaches {
+		if id != t.CoachID {
+			t.Replaced = true
+			break
+		}
+		t.Matches++
+	}
+	return t, nil
+}
@@ -1,69 +1,53 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
 	"github.com/yourusername/football-prediction/pkg/football"
 )
 
 type CompetitionRepository struct {
-	db *sql.DB
+	q *sqlcdb.Queries
 }
 
 func NewCompetitionRepository(db *sql.DB) *CompetitionRepository {
-	return &CompetitionRepository{db: db}
+	return &CompetitionRepository{q: sqlcdb.New(db)}
 }
 
 func (r *CompetitionRepository) Create(comp *football.Competition) error {
-	query := `
-		INSERT INTO competitions (external_id, name, code, area_name, current_season_start_date, current_season_end_date)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (external_id) DO UPDATE
-		SET name = EXCLUDED.name,
-		    code = EXCLUDED.code,
-		    area_name = EXCLUDED.area_name,
-		    current_season_start_date = EXCLUDED.current_season_start_date,
-		    current_season_end_date = EXCLUDED.current_season_end_date,
-		    updated_at = CURRENT_TIMESTAMP
-		RETURNING id
-	`
-
-	var startDate, endDate *string
-	if comp.CurrentSeason != nil {
-		startDate = &comp.CurrentSeason.StartDate
-		endDate = &comp.CurrentSeason.EndDate
-	}
-
-	var id int
-	err := r.db.QueryRow(query, comp.ID, comp.Name, comp.Code, comp.Area.Name, startDate, endDate).Scan(&id)
-	if err != nil {
+	if err := r.q.UpsertCompetition(context.Background(), CompetitionParams(comp)); err != nil {
 		return fmt.Errorf("failed to create competition: %w", err)
 	}
-
 	return nil
 }
 
-func (r *CompetitionRepository) GetByCode(code string) (*football.Competition, error) {
-	query := `
-		SELECT id, external_id, name, code, area_name, current_season_start_date, current_season_end_date
-		FROM competitions
-		WHERE code = $1
-	`
-
-	var comp football.Competition
-	var startDate, endDate sql.NullString
-
-	err := r.db.QueryRow(query, code).Scan(
-		&comp.ID,
-		&comp.ID,
-		&comp.Name,
-		&comp.Code,
-		&comp.Area.Name,
-		&startDate,
-		&endDate,
-	)
+// CompetitionParams maps a football-data.org competition onto the upsert
+// parameters.
+func CompetitionParams(comp *football.Competition) sqlcdb.UpsertCompetitionParams {
+	p := sqlcdb.UpsertCompetitionParams{
+		Source:     football.Provider,
+		ExternalID: comp.ID,
+		Name:       comp.Name,
+		Code:       &comp.Code,
+		AreaName:   &comp.Area.Name,
+		TeamType:   comp.TeamType(),
+	}
+	if comp.Type != "" {
+		p.Type = &comp.Type
+	}
+	if comp.CurrentSeason != nil {
+		p.CurrentSeasonStartDate = parseDate(comp.CurrentSeason.StartDate)
+		p.CurrentSeasonEndDate = parseDate(comp.CurrentSeason.EndDate)
+	}
+	return p
+}
 
+func (r *CompetitionRepository) GetByCode(code string) (*football.Competition, error) {
+	row, err := r.q.GetCompetitionByCode(context.Background(), code)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -71,62 +55,74 @@ func (r *CompetitionRepository) GetByCode(code string) (*football.Competition, e
 		return nil, fmt.Errorf("failed to get competition: %w", err)
 	}
 
-	if comp.CurrentSeason == nil {
-		comp.CurrentSeason = &football.Season{}
-	}
-	if startDate.Valid {
-		comp.CurrentSeason.StartDate = startDate.String
-	}
-	if endDate.Valid {
-		comp.CurrentSeason.EndDate = endDate.String
-	}
-
-	return &comp, nil
+	return toCompetition(sqlcdb.ListCompetitionsRow(row)), nil
 }
 
 func (r *CompetitionRepository) List() ([]*football.Competition, error) {
-	query := `
-		SELECT id, external_id, name, code, area_name, current_season_start_date, current_season_end_date
-		FROM competitions
-		ORDER BY name
-	`
-
-	rows, err := r.db.Query(query)
+	rows, err := r.q.ListCompetitions(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list competitions: %w", err)
 	}
-	defer rows.Close()
 
-	var competitions []*football.Competition
-	for rows.Next() {
-		var comp football.Competition
-		var startDate, endDate sql.NullString
+	competitions := make([]*football.Competition, 0, len(rows))
+	for _, row := range rows {
+		competitions = append(competitions, toCompetition(row))
+	}
 
-		err := rows.Scan(
-			&comp.ID,
-			&comp.ID,
-			&comp.Name,
-			&comp.Code,
-			&comp.Area.Name,
-			&startDate,
-			&endDate,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan competition: %w", err)
-		}
+	return competitions, nil
+}
 
-		if comp.CurrentSeason == nil {
-			comp.CurrentSeason = &football.Season{}
-		}
-		if startDate.Valid {
-			comp.CurrentSeason.StartDate = startDate.String
-		}
-		if endDate.Valid {
-			comp.CurrentSeason.EndDate = endDate.String
-		}
+// toCompetition maps a stored competition onto the provider type. ID is the
+// football-data.org ID, which is what API clients see.
+func toCompetition(row sqlcdb.ListCompetitionsRow) *football.Competition {
+	return &football.Competition{
+		ID:   row.ExternalID,
+		Name: row.Name,
+		Code: derefString(row.Code),
+		Type: derefString(row.Type),
+		Area: football.Area{Name: derefString(row.AreaName)},
+		CurrentSeason: &football.Season{
+			StartDate: formatDate(row.CurrentSeasonStartDate),
+			EndDate:   formatDate(row.CurrentSeasonEndDate),
+		},
+	}
+}
+
+// SeasonCoverage is a stored season of a competition with how many of its
+// matches carry each kind of detail data.
+type SeasonCoverage struct {
+	Season                 string    `json:"season"`
+	Year                   int       `json:"year"`
+	FirstMatchDate         time.Time `json:"firstMatchDate"`
+	LastMatchDate          time.Time `json:"lastMatchDate"`
+	Matches                int       `json:"matches"`
+	FinishedMatches        int       `json:"finishedMatches"`
+	MatchesWithLineups     int       `json:"matchesWithLineups"`
+	MatchesWithPlayerStats int       `json:"matchesWithPlayerStats"`
+	MatchesWithTeamStats   int       `json:"matchesWithTeamStats"`
+}
 
-		competitions = append(competitions, &comp)
+// ListSeasonCoverage returns the stored seasons of a competition, most recent
+// first.
+func (r *CompetitionRepository) ListSeasonCoverage(code string) ([]SeasonCoverage, error) {
+	rows, err := r.q.ListCompetitionSeasonCoverage(context.Background(), code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list competition seasons: %w", err)
 	}
 
-	return competitions, nil
+	seasons := make([]SeasonCoverage, 0, len(rows))
+	for _, row := range rows {
+		seasons = append(seasons, SeasonCoverage{
+			Season:                 row.Season,
+			Year:                   row.SeasonYear,
+			FirstMatchDate:         row.FirstMatchDate,
+			LastMatchDate:          row.LastMatchDate,
+			Matches:                row.Matches,
+			FinishedMatches:        row.FinishedMatches,
+			MatchesWithLineups:     row.MatchesWithLineups,
+			MatchesWithPlayerStats: row.MatchesWithPlayerStats,
+			MatchesWithTeamStats:   row.MatchesWithTeamStats,
+		})
+	}
+	return seasons, nil
 }
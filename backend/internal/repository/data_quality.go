@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// CompetitionDataQuality counts the gaps in a competition's stored data.
+type CompetitionDataQuality struct {
+	CompetitionID              int    `json:"competitionId"`
+	CompetitionCode            string `json:"competitionCode"`
+	CompetitionName            string `json:"competitionName"`
+	Matches                    int    `json:"matches"`
+	MatchesMissingScores       int    `json:"matchesMissingScores"`
+	FinishedWithoutPlayerStats int    `json:"finishedWithoutPlayerStats"`
+	TeamsMissingCrests         int    `json:"teamsMissingCrests"`
+	UnsettledPredictions       int    `json:"unsettledPredictions"`
+	UpcomingWithoutPredictions int    `json:"upcomingWithoutPredictions"`
+}
+
+// DataQualityRepository runs the data-quality checks.
+type DataQualityRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewDataQualityRepository(db *sql.DB) *DataQualityRepository {
+	return &DataQualityRepository{q: sqlcdb.New(db)}
+}
+
+// ByCompetition returns the gaps of every competition with stored matches,
+// by name.
+func (r *DataQualityRepository) ByCompetition() ([]CompetitionDataQuality, error) {
+	rows, err := r.q.ListCompetitionDataQuality(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check data quality: %w", err)
+	}
+
+	report := make([]CompetitionDataQuality, 0, len(rows))
+	for _, row := range rows {
+		report = append(report, CompetitionDataQuality{
+			CompetitionID:              row.ID,
+			CompetitionCode:            derefString(row.Code),
+			CompetitionName:            row.Name,
+			Matches:                    row.Matches,
+			MatchesMissingScores:       row.MatchesMissingScores,
+			FinishedWithoutPlayerStats: row.FinishedWithoutPlayerStats,
+			TeamsMissingCrests:         row.TeamsMissingCrests,
+			UnsettledPredictions:       row.UnsettledPredictions,
+			UpcomingWithoutPredictions: row.UpcomingWithoutPredictions,
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// DigestRecipient is a user due a weekly digest.
+type DigestRecipient struct {
+	UserID int
+	Email  string
+	Name   string
+}
+
+// DigestSubscription is a user's weekly digest subscription.
+type DigestSubscription struct {
+	Subscribed       bool       `json:"subscribed"`
+	UnsubscribeToken string     `json:"-"`
+	LastSentAt       *time.Time `json:"lastSentAt,omitempty"`
+	UnsubscribedAt   *time.Time `json:"unsubscribedAt,omitempty"`
+}
+
+// DigestRepository provides DB access for email digest subscriptions.
+type DigestRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewDigestRepository(db *sql.DB) *DigestRepository {
+	return &DigestRepository{q: sqlcdb.New(db)}
+}
+
+// ListDue returns up to limit subscribed users following a team who have not
+// been sent a digest since sentBefore.
+func (r *DigestRepository) ListDue(sentBefore time.Time, limit int) ([]DigestRecipient, error) {
+	rows, err := r.q.ListDueDigestRecipients(context.Background(), sqlcdb.ListDueDigestRecipientsParams{
+		SentBefore: sentBefore,
+		RowLimit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest recipients: %w", err)
+	}
+
+	recipients := make([]DigestRecipient, 0, len(rows))
+	for _, row := range rows {
+		recipients = append(recipients, DigestRecipient{UserID: row.ID, Email: row.Email, Name: row.Name})
+	}
+	return recipients, nil
+}
+
+// Ensure returns a user's subscription, creating a subscribed one with token
+// as its unsubscribe token if the user has none.
+func (r *DigestRepository) Ensure(userID int, token string) (*DigestSubscription, error) {
+	row, err := r.q.EnsureDigestSubscription(context.Background(), sqlcdb.EnsureDigestSubscriptionParams{
+		UserID:           userID,
+		UnsubscribeToken: token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscription: %w", err)
+	}
+
+	return &DigestSubscription{
+		Subscribed:       row.Subscribed,
+		UnsubscribeToken: row.UnsubscribeToken,
+		LastSentAt:       row.LastSentAt,
+		UnsubscribedAt:   row.UnsubscribedAt,
+	}, nil
+}
+
+// SetSubscribed subscribes or unsubscribes a user with an existing
+// subscription.
+func (r *DigestRepository) SetSubscribed(userID int, subscribed bool) error {
+	var unsubscribedAt *time.Time
+	if !subscribed {
+		now := time.Now().UTC()
+		unsubscribedAt = &now
+	}
+
+	err := r.q.SetDigestSubscribed(context.Background(), sqlcdb.SetDigestSubscribedParams{
+		Subscribed:     subscribed,
+		UnsubscribedAt: unsubscribedAt,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update digest subscription: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes the user holding an unsubscribe token. Returns
+// false if the token is unknown.
+func (r *DigestRepository) Unsubscribe(token string) (bool, error) {
+	n, err := r.q.UnsubscribeDigestByToken(context.Background(), sqlcdb.UnsubscribeDigestByTokenParams{
+		UnsubscribedAt:   time.Now().UTC(),
+		UnsubscribeToken: token,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to unsubscribe from digest: %w", err)
+	}
+	return n > 0, nil
+}
+
+// MarkSent records when a user was last sent a digest.
+func (r *DigestRepository) MarkSent(userID int, at time.Time) error {
+	err := r.q.MarkDigestSent(context.Background(), sqlcdb.MarkDigestSentParams{
+		SentAt: at,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record digest delivery: %w", err)
+	}
+	return nil
+}
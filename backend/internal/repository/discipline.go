@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// PlayerBookings is a player's cards for a team in one competition season,
+// up to the last matchday the team had finished in it.
+type PlayerBookings struct {
+	CompetitionCode  string
+	Season           string
+	Matchday         int
+	PlayerID         int
+	PlayerExternalID int
+	PlayerName       string
+	YellowCards      int
+	SecondYellows    int
+	RedCards         int
+}
+
+// ListTeamBookings returns the cards of a team's players recorded before a
+// time, in the season of each competition the team last played in.
+func (r *PlayerRepository) ListTeamBookings(teamID int, before time.Time) ([]PlayerBookings, error) {
+	rows, err := r.q.ListTeamBookings(context.Background(), sqlcdb.ListTeamBookingsParams{
+		TeamID: teamID,
+		Before: before,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team bookings: %w", err)
+	}
+
+	bookings := make([]PlayerBookings, 0, len(rows))
+	for _, row := range rows {
+		bookings = append(bookings, PlayerBookings(row))
+	}
+	return bookings, nil
+}
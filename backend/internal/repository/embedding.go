@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// EmbeddingInput is what the match encoder needs about a match beyond the
+// results before it. SeasonMatchdays is the last matchday of its
+// competition's season, 0 when unknown.
+type EmbeddingInput struct {
+	ID              int
+	UtcDate         time.Time
+	HomeTeamID      int
+	AwayTeamID      int
+	Matchday        int
+	Stage           string
+	SeasonMatchdays int
+}
+
+// MatchEmbedding is a stored match vector with the named features it was
+// encoded from.
+type MatchEmbedding struct {
+	Vector   []float64
+	Features json.RawMessage
+}
+
+// SimilarMatch is a past match near another in feature space. Distance is
+// the Euclidean distance between their vectors.
+type SimilarMatch struct {
+	ID                 int             `json:"id"`
+	ExternalID         int             `json:"externalId"`
+	CompetitionCode    string          `json:"competitionCode"`
+	Season             string          `json:"season"`
+	UtcDate            time.Time       `json:"utcDate"`
+	HomeTeamExternalID int             `json:"homeTeamExternalId"`
+	HomeTeamName       string          `json:"homeTeamName"`
+	AwayTeamExternalID int             `json:"awayTeamExternalId"`
+	AwayTeamName       string          `json:"awayTeamName"`
+	HomeScore          int             `json:"homeScore"`
+	AwayScore          int             `json:"awayScore"`
+	Winner             string          `json:"winner"`
+	Distance           float64         `json:"distance"`
+	Features           json.RawMessage `json:"features"`
+}
+
+// EmbeddingRepository provides DB access for match embeddings, stored as
+// pgvector vectors.
+type EmbeddingRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewEmbeddingRepository(db *sql.DB) *EmbeddingRepository {
+	return &EmbeddingRepository{q: sqlcdb.New(db)}
+}
+
+// ListToEmbed returns up to limit finished matches without an embedding from
+// the encoder version, oldest first.
+func (r *EmbeddingRepository) ListToEmbed(encoderVersion string, limit int) ([]EmbeddingInput, error) {
+	rows, err := r.q.ListMatchesToEmbed(context.Background(), sqlcdb.ListMatchesToEmbedParams{
+		EncoderVersion: encoderVersion,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches to embed: %w", err)
+	}
+
+	inputs := make([]EmbeddingInput, 0, len(rows))
+	for _, row := range rows {
+		inputs = append(inputs, EmbeddingInput(row))
+	}
+	return inputs, nil
+}
+
+// GetInput returns the encoder input of a match by internal ID, or nil if it
+// does not exist or its teams are not known yet.
+func (r *EmbeddingRepository) GetInput(matchID int) (*EmbeddingInput, error) {
+	row, err := r.q.GetMatchToEmbed(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match to embed: %w", err)
+	}
+	input := EmbeddingInput(row)
+	return &input, nil
+}
+
+// Get returns a match's embedding from the encoder version, or nil if it has
+// none.
+func (r *EmbeddingRepository) Get(matchID int, encoderVersion string) (*MatchEmbedding, error) {
+	row, err := r.q.GetMatchEmbedding(context.Background(), sqlcdb.GetMatchEmbeddingParams{
+		MatchID:        matchID,
+		EncoderVersion: encoderVersion,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match embedding: %w", err)
+	}
+
+	vector, err := parseVector(row.Embedding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse match embedding: %w", err)
+	}
+	return &MatchEmbedding{Vector: vector, Features: row.Features}, nil
+}
+
+// Save stores a match's embedding, replacing any from an older encoder.
+func (r *EmbeddingRepository) Save(matchID int, encoderVersion string, e MatchEmbedding) error {
+	if err := r.q.UpsertMatchEmbedding(context.Background(), sqlcdb.UpsertMatchEmbeddingParams{
+		MatchID:        matchID,
+		EncoderVersion: encoderVersion,
+		Embedding:      formatVector(e.Vector),
+		Features:       e.Features,
+	}); err != nil {
+		return fmt.Errorf("failed to save match embedding: %w", err)
+	}
+	return nil
+}
+
+// ListSimilar returns up to limit matches kicked off before a time whose
+// embeddings from the encoder version are nearest vector, nearest first,
+// leaving out matchID.
+func (r *EmbeddingRepository) ListSimilar(vector []float64, encoderVersion string, matchID int, before time.Time, limit int) ([]SimilarMatch, error) {
+	rows, err := r.q.ListSimilarMatches(context.Background(), sqlcdb.ListSimilarMatchesParams{
+		Embedding:      formatVector(vector),
+		EncoderVersion: encoderVersion,
+		MatchID:        matchID,
+		Before:         before,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query similar matches: %w", err)
+	}
+
+	matches := make([]SimilarMatch, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, SimilarMatch{
+			ID:                 row.ID,
+			ExternalID:         row.ExternalID,
+			CompetitionCode:    row.CompetitionCode,
+			Season:             row.Season,
+			UtcDate:            row.UtcDate,
+			HomeTeamExternalID: row.HomeTeamExternalID,
+			HomeTeamName:       row.HomeTeamName,
+			AwayTeamExternalID: row.AwayTeamExternalID,
+			AwayTeamName:       row.AwayTeamName,
+			HomeScore:          row.HomeScore,
+			AwayScore:          row.AwayScore,
+			Winner:             row.Winner,
+			Distance:           row.Distance,
+			Features:           row.Features,
+		})
+	}
+	return matches, nil
+}
+
+// formatVector renders a vector in pgvector's text format, "[1,2,3]".
+func formatVector(v []float64) string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// parseVector parses a vector in pgvector's text format.
+func parseVector(s string) ([]float64, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	v := make([]float64, len(parts))
+	for i, p := range parts {
+		x, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = x
+	}
+	return v, nil
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Entity types that can be mapped across providers.
+const (
+	EntityTeam   = "team"
+	EntityPlayer = "player"
+	EntityMatch  = "match"
+)
+
+// Mapping methods, from most to least trustworthy.
+const (
+	MappingManual = "manual"
+	MappingExact  = "exact"
+	MappingAlias  = "alias"
+	MappingFuzzy  = "fuzzy"
+)
+
+// EntityMapping links a provider's ID for a team, player or match to the
+// local row. Confidence is 1 for exact and manual mappings.
+type EntityMapping struct {
+	ID           int       `json:"id"`
+	EntityType   string    `json:"entityType"`
+	Provider     string    `json:"provider"`
+	ProviderID   int       `json:"providerId"`
+	ProviderName string    `json:"providerName,omitempty"`
+	LocalID      int       `json:"localId"`
+	Confidence   float64   `json:"confidence"`
+	Method       string    `json:"method"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// EntityAlias is an alternative normalized name for a team or player.
+type EntityAlias struct {
+	ID         int    `json:"id"`
+	EntityType string `json:"entityType"`
+	LocalID    int    `json:"localId"`
+	Alias      string `json:"alias"`
+}
+
+// NameCandidate is a local team or player with every name it is known by.
+type NameCandidate struct {
+	ID      int
+	Names   []string
+	Aliases []string
+}
+
+// EntityMappingRepository provides DB access for entity_mappings and
+// entity_aliases.
+type EntityMappingRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewEntityMappingRepository(db *sql.DB) *EntityMappingRepository {
+	return &EntityMappingRepository{q: sqlcdb.New(db)}
+}
+
+func toEntityMapping(row sqlcdb.EntityMapping) *EntityMapping {
+	return &EntityMapping{
+		ID:           row.ID,
+		EntityType:   row.EntityType,
+		Provider:     row.Provider,
+		ProviderID:   row.ProviderID,
+		ProviderName: derefString(row.ProviderName),
+		LocalID:      row.LocalID,
+		Confidence:   row.Confidence,
+		Method:       row.Method,
+		UpdatedAt:    derefTime(row.UpdatedAt),
+	}
+}
+
+// Get returns the mapping for a provider ID, or nil if there is none.
+func (r *EntityMappingRepository) Get(entityType, provider string, providerID int) (*EntityMapping, error) {
+	row, err := r.q.GetEntityMapping(context.Background(), sqlcdb.GetEntityMappingParams{
+		EntityType: entityType,
+		Provider:   provider,
+		ProviderID: providerID,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entity mapping: %w", err)
+	}
+	return toEntityMapping(row), nil
+}
+
+// GetByLocalID returns the most confident mapping of a local row to the
+// provider, or nil if there is none.
+func (r *EntityMappingRepository) GetByLocalID(entityType, provider string, localID int) (*EntityMapping, error) {
+	row, err := r.q.GetEntityMappingByLocalID(context.Background(), sqlcdb.GetEntityMappingByLocalIDParams{
+		EntityType: entityType,
+		Provider:   provider,
+		LocalID:    localID,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entity mapping: %w", err)
+	}
+	return toEntityMapping(row), nil
+}
+
+// Save stores an automatically resolved mapping. Manual mappings for the
+// same provider ID are left untouched.
+func (r *EntityMappingRepository) Save(m *EntityMapping) error {
+	var name *string
+	if m.ProviderName != "" {
+		name = &m.ProviderName
+	}
+
+	err := r.q.UpsertEntityMapping(context.Background(), sqlcdb.UpsertEntityMappingParams{
+		EntityType:   m.EntityType,
+		Provider:     m.Provider,
+		ProviderID:   m.ProviderID,
+		ProviderName: name,
+		LocalID:      m.LocalID,
+		Confidence:   m.Confidence,
+		Method:       m.Method,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save entity mapping: %w", err)
+	}
+	return nil
+}
+
+// SetManual pins a provider ID to a local row, overriding any automatic
+// mapping.
+func (r *EntityMappingRepository) SetManual(entityType, provider string, providerID, localID int, providerName string) (*EntityMapping, error) {
+	var name *string
+	if providerName != "" {
+		name = &providerName
+	}
+
+	row, err := r.q.SetManualEntityMapping(context.Background(), sqlcdb.SetManualEntityMappingParams{
+		EntityType:   entityType,
+		Provider:     provider,
+		ProviderID:   providerID,
+		ProviderName: name,
+		LocalID:      localID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set entity mapping: %w", err)
+	}
+	return toEntityMapping(row), nil
+}
+
+// Delete removes a mapping so it is resolved again on next use. It reports
+// whether a mapping existed.
+func (r *EntityMappingRepository) Delete(entityType, provider string, providerID int) (bool, error) {
+	n, err := r.q.DeleteEntityMapping(context.Background(), sqlcdb.DeleteEntityMappingParams{
+		EntityType: entityType,
+		Provider:   provider,
+		ProviderID: providerID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete entity mapping: %w", err)
+	}
+	return n > 0, nil
+}
+
+// List returns mappings with confidence at or below maxConfidence, least
+// confident first. Empty entityType and provider match all.
+func (r *EntityMappingRepository) List(entityType, provider string, maxConfidence float64, limit int) ([]EntityMapping, error) {
+	rows, err := r.q.ListEntityMappings(context.Background(), sqlcdb.ListEntityMappingsParams{
+		EntityType:    entityType,
+		Provider:      provider,
+		MaxConfidence: maxConfidence,
+		RowLimit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entity mappings: %w", err)
+	}
+
+	mappings := []EntityMapping{}
+	for _, row := range rows {
+		mappings = append(mappings, *toEntityMapping(row))
+	}
+	return mappings, nil
+}
+
+// TeamCandidates returns every stored team with its names and aliases.
+func (r *EntityMappingRepository) TeamCandidates() ([]NameCandidate, error) {
+	rows, err := r.q.ListTeamMatchCandidates(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	candidates := make([]NameCandidate, 0, len(rows))
+	for _, row := range rows {
+		names := []string{row.Name}
+		if row.ShortName != "" {
+			names = append(names, row.ShortName)
+		}
+		if row.Tla != "" {
+			names = append(names, row.Tla)
+		}
+		candidates = append(candidates, NameCandidate{ID: row.ID, Names: names, Aliases: row.Aliases})
+	}
+	return candidates, nil
+}
+
+// PlayerCandidates returns a team's players with their aliases.
+func (r *EntityMappingRepository) PlayerCandidates(teamID int) ([]NameCandidate, error) {
+	rows, err := r.q.ListPlayerMatchCandidates(context.Background(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list players: %w", err)
+	}
+
+	candidates := make([]NameCandidate, 0, len(rows))
+	for _, row := range rows {
+		candidates = append(candidates, NameCandidate{ID: row.ID, Names: []string{row.Name}, Aliases: row.Aliases})
+	}
+	return candidates, nil
+}
+
+// AddAlias records an alternative normalized name for a team or player and
+// returns its ID.
+func (r *EntityMappingRepository) AddAlias(entityType string, localID int, alias string) (int, error) {
+	id, err := r.q.AddEntityAlias(context.Background(), sqlcdb.AddEntityAliasParams{
+		EntityType: entityType,
+		LocalID:    localID,
+		Alias:      alias,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to add alias: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteAlias removes an alias. It reports whether the alias existed.
+func (r *EntityMappingRepository) DeleteAlias(id int) (bool, error) {
+	n, err := r.q.DeleteEntityAlias(context.Background(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete alias: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListAliases returns the aliases of a team or player.
+func (r *EntityMappingRepository) ListAliases(entityType string, localID int) ([]EntityAlias, error) {
+	rows, err := r.q.ListEntityAliases(context.Background(), sqlcdb.ListEntityAliasesParams{
+		EntityType: entityType,
+		LocalID:    localID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+
+	aliases := []EntityAlias{}
+	for _, row := range rows {
+		aliases = append(aliases, EntityAlias{
+			ID:         row.ID,
+			EntityType: row.EntityType,
+			LocalID:    row.LocalID,
+			Alias:      row.Alias,
+		})
+	}
+	return aliases, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ExportRow is one match flattened with its stats, model features and
+// settled prediction for training-data exports. Pointer fields are nullable.
+type ExportRow struct {
+	MatchID         int       `json:"match_id" parquet:"match_id"`
+	CompetitionCode string    `json:"competition_code" parquet:"competition_code"`
+	Season          string    `json:"season" parquet:"season"`
+	Matchday        int       `json:"matchday" parquet:"matchday"`
+	UtcDate         time.Time `json:"utc_date" parquet:"utc_date"`
+	Status          string    `json:"status" parquet:"status"`
+	HomeTeamID      int       `json:"home_team_id" parquet:"home_team_id"`
+	HomeTeam        string    `json:"home_team" parquet:"home_team"`
+	AwayTeamID      int       `json:"away_team_id" parquet:"away_team_id"`
+	AwayTeam        string    `json:"away_team" parquet:"away_team"`
+	HomeScore       *int      `json:"home_score" parquet:"home_score,optional"`
+	AwayScore       *int      `json:"away_score" parquet:"away_score,optional"`
+	Winner          string    `json:"winner" parquet:"winner"`
+
+	HomeXG            *float64 `json:"home_xg" parquet:"home_xg,optional"`
+	AwayXG            *float64 `json:"away_xg" parquet:"away_xg,optional"`
+	HomePossession    *float64 `json:"home_possession" parquet:"home_possession,optional"`
+	AwayPossession    *float64 `json:"away_possession" parquet:"away_possession,optional"`
+	HomeShots         *int     `json:"home_shots" parquet:"home_shots,optional"`
+	AwayShots         *int     `json:"away_shots" parquet:"away_shots,optional"`
+	HomeShotsOnTarget *int     `json:"home_shots_on_target" parquet:"home_shots_on_target,optional"`
+	AwayShotsOnTarget *int     `json:"away_shots_on_target" parquet:"away_shots_on_target,optional"`
+	HomeCorners       *int     `json:"home_corners" parquet:"home_corners,optional"`
+	AwayCorners       *int     `json:"away_corners" parquet:"away_corners,optional"`
+
+	PredictedHomeGoals *float64 `json:"predicted_home_goals" parquet:"predicted_home_goals,optional"`
+	PredictedAwayGoals *float64 `json:"predicted_away_goals" parquet:"predicted_away_goals,optional"`
+	PredictedWinner    *string  `json:"predicted_winner" parquet:"predicted_winner,optional"`
+	Confidence         *float64 `json:"confidence" parquet:"confidence,optional"`
+	PredictionCorrect  *bool    `json:"prediction_correct" parquet:"prediction_correct,optional"`
+	ModelVersion       *string  `json:"model_version" parquet:"model_version,optional"`
+	Features           *string  `json:"features" parquet:"features,optional"` // JSON object
+}
+
+// ExportFilter narrows an export. Empty fields are not filtered on.
+type ExportFilter struct {
+	CompetitionCode string
+	Season          string // season start year, e.g. "2024"
+	Status          string
+}
+
+// ExportRepository streams training data out of the database.
+type ExportRepository struct {
+	db *sql.DB
+}
+
+func NewExportRepository(db *sql.DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+// StreamMatches calls fn for every matching row in kickoff order without
+// loading the result set into memory. The row passed to fn is reused.
+func (r *ExportRepository) StreamMatches(f ExportFilter, fn func(*ExportRow) error) error {
+	query := `
+        SELECT
+            m.external_id, COALESCE(c.code, ''), m.season, COALESCE(m.matchday, 0),
+            m.utc_date, m.status,
+            ht.external_id, ht.name, at.external_id, at.name,
+            m.home_score, m.away_score, COALESCE(m.winner, ''),
+            mc.team_a_xg, mc.team_b_xg, mc.team_a_possession, mc.team_b_possession,
+            mc.team_a_shots, mc.team_b_shots, mc.team_a_shots_on_target, mc.team_b_shots_on_target,
+            mc.team_a_corners, mc.team_b_corners,
+            ph.predicted_team_a_goals, ph.predicted_team_b_goals, ph.predicted_winner,
+            ph.confidence_score, ph.prediction_correct, ph.model_version, ph.features_used::text
+        FROM matches m
+        JOIN teams ht ON ht.id = m.home_team_id
+        JOIN teams at ON at.id = m.away_team_id
+        LEFT JOIN competitions c ON c.id = m.competition_id
+        LEFT JOIN match_context mc ON mc.match_id = m.id
+        LEFT JOIN LATERAL (
+            SELECT * FROM prediction_history
+            WHERE match_id = m.id AND is_primary
+            ORDER BY is_final DESC, predicted_at DESC
+            LIMIT 1
+        ) ph ON true
+        WHERE 1 = 1`
+
+	var args []interface{}
+	if f.CompetitionCode != "" {
+		args = append(args, f.CompetitionCode)
+		query += fmt.Sprintf(" AND c.code = $%d", len(args))
+	}
+	if f.Season != "" {
+		args = append(args, f.Season)
+		query += " AND " + seasonYearCondition(fmt.Sprintf("$%d", len(args)))
+	}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		query += fmt.Sprintf(" AND m.status = $%d", len(args))
+	}
+	query += " ORDER BY m.utc_date, m.id"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query export rows: %w", err)
+	}
+	defer rows.Close()
+
+	var row ExportRow
+	for rows.Next() {
+		row = ExportRow{}
+		if err := rows.Scan(
+			&row.MatchID, &row.CompetitionCode, &row.Season, &row.Matchday,
+			&row.UtcDate, &row.Status,
+			&row.HomeTeamID, &row.HomeTeam, &row.AwayTeamID, &row.AwayTeam,
+			&row.HomeScore, &row.AwayScore, &row.Winner,
+			&row.HomeXG, &row.AwayXG, &row.HomePossession, &row.AwayPossession,
+			&row.HomeShots, &row.AwayShots, &row.HomeShotsOnTarget, &row.AwayShotsOnTarget,
+			&row.HomeCorners, &row.AwayCorners,
+			&row.PredictedHomeGoals, &row.PredictedAwayGoals, &row.PredictedWinner,
+			&row.Confidence, &row.PredictionCorrect, &row.ModelVersion, &row.Features,
+		); err != nil {
+			return fmt.Errorf("failed to scan export row: %w", err)
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("export rows error: %w", err)
+	}
+
+	return nil
+}
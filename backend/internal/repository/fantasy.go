@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// ScoringRule awards points for an action, optionally for one position only.
+type ScoringRule struct {
+	Action   string `json:"action"`
+	Position string `json:"position"` // GK, DEF, MID, FWD or ANY
+	Points   int    `json:"points"`
+}
+
+// FantasyLine is one player's raw contribution to a match, used as input to
+// the points calculation.
+type FantasyLine struct {
+	PlayerID   int
+	TeamID     int
+	Position   string
+	Minutes    *int
+	Goals      int // includes penalties
+	Assists    int
+	Penalties  int
+	OwnGoals   int
+	YellowCard int
+	RedCard    int
+}
+
+// FantasyPoints is the computed score of one player in one match.
+type FantasyPoints struct {
+	PlayerID  int
+	Position  string
+	Points    int
+	Breakdown map[string]int
+}
+
+// FantasyScore is a scored player in a gameweek leaderboard.
+type FantasyScore struct {
+	PlayerExternalID int            `json:"playerId"`
+	Name             string         `json:"name"`
+	TeamName         string         `json:"teamName"`
+	Position         string         `json:"position"`
+	Points           int            `json:"points"`
+	Matches          int            `json:"matches"`
+	Breakdown        map[string]int `json:"breakdown"`
+}
+
+// FantasyHistoryRow is a player's score in one match.
+type FantasyHistoryRow struct {
+	MatchExternalID int            `json:"matchId"`
+	CompetitionCode string         `json:"competitionCode"`
+	Matchday        int            `json:"matchday"`
+	UtcDate         time.Time      `json:"utcDate"`
+	Opponent        string         `json:"opponent"`
+	Points          int            `json:"points"`
+	Breakdown       map[string]int `json:"breakdown"`
+}
+
+// FantasyRepository provides DB access for fantasy scoring.
+type FantasyRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewFantasyRepository(db *sql.DB) *FantasyRepository {
+	return &FantasyRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// ListRules returns the scoring rules.
+func (r *FantasyRepository) ListRules() ([]ScoringRule, error) {
+	rows, err := r.q.ListFantasyScoringRules(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scoring rules: %w", err)
+	}
+
+	rules := []ScoringRule{}
+	for _, row := range rows {
+		rules = append(rules, ScoringRule(row))
+	}
+	return rules, nil
+}
+
+// ReplaceRules swaps the full rule set and clears computed points so they
+// are recalculated with the new rules.
+func (r *FantasyRepository) ReplaceRules(rules []ScoringRule) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	if err := q.DeleteFantasyScoringRules(ctx); err != nil {
+		return fmt.Errorf("failed to clear scoring rules: %w", err)
+	}
+
+	for _, rule := range rules {
+		if err := q.InsertFantasyScoringRule(ctx, sqlcdb.InsertFantasyScoringRuleParams(rule)); err != nil {
+			return fmt.Errorf("failed to insert scoring rule: %w", err)
+		}
+	}
+
+	if err := q.DeleteAllFantasyPoints(ctx); err != nil {
+		return fmt.Errorf("failed to clear fantasy points: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit scoring rules: %w", err)
+	}
+
+	return nil
+}
+
+// ListMatchesToScore returns finished matches that have player stats or
+// events but no computed fantasy points yet.
+func (r *FantasyRepository) ListMatchesToScore(limit int) ([]MatchSummary, error) {
+	rows, err := r.q.ListMatchesToScore(context.Background(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches to score: %w", err)
+	}
+
+	var matches []MatchSummary
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+	return matches, nil
+}
+
+// GetLines returns every player with stats or events in a match. Minutes
+// fall back to the lineup when player_match_stats has none.
+func (r *FantasyRepository) GetLines(matchID int) ([]FantasyLine, error) {
+	rows, err := r.q.GetFantasyLines(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fantasy lines: %w", err)
+	}
+
+	var lines []FantasyLine
+	for _, row := range rows {
+		lines = append(lines, FantasyLine(row))
+	}
+	return lines, nil
+}
+
+// SavePoints replaces the computed points for a match.
+func (r *FantasyRepository) SavePoints(matchID int, points []FantasyPoints) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	if err := q.DeleteFantasyPointsForMatch(ctx, matchID); err != nil {
+		return fmt.Errorf("failed to clear fantasy points: %w", err)
+	}
+
+	for _, p := range points {
+		breakdown, err := json.Marshal(p.Breakdown)
+		if err != nil {
+			return fmt.Errorf("failed to encode breakdown: %w", err)
+		}
+
+		if err := q.InsertFantasyPoints(ctx, sqlcdb.InsertFantasyPointsParams{
+			MatchID:   matchID,
+			PlayerID:  p.PlayerID,
+			Position:  p.Position,
+			Points:    p.Points,
+			Breakdown: breakdown,
+		}); err != nil {
+			return fmt.Errorf("failed to insert fantasy points: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit fantasy points: %w", err)
+	}
+
+	return nil
+}
+
+// TopForGameweek ranks players by points in one matchday of a competition.
+// An empty season means the competition's most recent season.
+func (r *FantasyRepository) TopForGameweek(competitionCode, season string, matchday, limit int) ([]FantasyScore, error) {
+	rows, err := r.q.ListTopFantasyForGameweek(context.Background(), sqlcdb.ListTopFantasyForGameweekParams{
+		CompetitionCode: competitionCode,
+		Matchday:        matchday,
+		Season:          season,
+		RowLimit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gameweek points: %w", err)
+	}
+
+	scores := []FantasyScore{}
+	for _, row := range rows {
+		var parts []map[string]int
+		if err := json.Unmarshal(row.Breakdowns, &parts); err != nil {
+			return nil, fmt.Errorf("failed to decode breakdown: %w", err)
+		}
+
+		s := FantasyScore{
+			PlayerExternalID: row.PlayerExternalID,
+			Name:             row.Name,
+			TeamName:         row.TeamName,
+			Position:         row.Position,
+			Points:           row.Points,
+			Matches:          row.Matches,
+			Breakdown:        make(map[string]int),
+		}
+		for _, part := range parts {
+			for action, pts := range part {
+				s.Breakdown[action] += pts
+			}
+		}
+
+		scores = append(scores, s)
+	}
+
+	return scores, nil
+}
+
+// PlayerHistory returns a player's points per match, most recent first.
+// Returns nil if the player does not exist.
+func (r *FantasyRepository) PlayerHistory(playerExternalID, limit int) ([]FantasyHistoryRow, error) {
+	ctx := context.Background()
+
+	playerID, err := r.q.GetPlayerIDByExternalID(ctx, playerExternalID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	rows, err := r.q.ListPlayerFantasyHistory(ctx, sqlcdb.ListPlayerFantasyHistoryParams{
+		PlayerID: playerID,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player points: %w", err)
+	}
+
+	history := []FantasyHistoryRow{}
+	for _, row := range rows {
+		h := FantasyHistoryRow{
+			MatchExternalID: row.MatchExternalID,
+			CompetitionCode: row.CompetitionCode,
+			Matchday:        row.Matchday,
+			UtcDate:         row.UtcDate,
+			Opponent:        row.Opponent,
+			Points:          row.Points,
+		}
+		if err := json.Unmarshal(row.Breakdown, &h.Breakdown); err != nil {
+			return nil, fmt.Errorf("failed to decode breakdown: %w", err)
+		}
+		history = append(history, h)
+	}
+
+	return history, nil
+}
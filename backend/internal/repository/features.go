@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// TeamFeatures is a team's features for one match as they stood at AsOf.
+// Goal averages are nil when the team had no earlier results; the comeback
+// and lead-hold rates when it had not trailed, or led, at half time.
+// NewManager is set during a coach's first matches after replacing another,
+// and BookingRisk counts the players one booking from a ban in the match's
+// competition. HomeAdvantage is the team's home points per game less its
+// away points per game, nil until it has results at both venues.
+// LeagueStrength is the coefficient of the team's home league when the
+// opponent's is another, by which its goal averages were normalised.
+// NewsAbsences counts the players in Unavailable only because team news
+// reported them doubtful or suspended, and ManagerPressure is set when it
+// reported the manager under pressure. LineupAbsences counts the regular
+// starters left out of the team's announced lineup, 0 before it is known.
+// LateGoalShare is the share of the team's goals in its recent matches with
+// recorded events scored after minute 75, nil without enough goals.
+type TeamFeatures struct {
+	MatchID         int       `json:"matchId"`
+	TeamID          int       `json:"teamId"`
+	AsOf            time.Time `json:"asOf"`
+	Source          string    `json:"source"`
+	Elo             float64   `json:"elo"`
+	Form            string    `json:"form"`
+	FormPoints      int       `json:"formPoints"`
+	GoalsForAvg     *float64  `json:"goalsForAvg"`
+	GoalsAgainstAvg *float64  `json:"goalsAgainstAvg"`
+	Matches         int       `json:"matches"`
+	Unavailable     int       `json:"unavailable"`
+	ComebackRate    *float64  `json:"comebackRate"`
+	LeadHoldRate    *float64  `json:"leadHoldRate"`
+	NewManager      bool      `json:"newManager"`
+	BookingRisk     int       `json:"bookingRisk"`
+	HomeAdvantage   *float64  `json:"homeAdvantage"`
+	LeagueStrength  *float64  `json:"leagueStrength"`
+	NewsAbsences    int       `json:"newsAbsences"`
+	ManagerPressure bool      `json:"managerPressure"`
+	LineupAbsences  int       `json:"lineupAbsences"`
+	LateGoalShare   *float64  `json:"lateGoalShare"`
+}
+
+// FeatureRepository provides DB access for feature snapshots.
+type FeatureRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewFeatureRepository(db *sql.DB) *FeatureRepository {
+	return &FeatureRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// Save stores snapshots, replacing any taken for the same match, team and
+// time.
+func (r *FeatureRepository) Save(snapshots []TeamFeatures) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	for _, f := range snapshots {
+		if err := q.UpsertFeatureSnapshot(ctx, sqlcdb.UpsertFeatureSnapshotParams(f)); err != nil {
+			return fmt.Errorf("failed to save feature snapshot: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit feature snapshots: %w", err)
+	}
+	return nil
+}
+
+// ListForMatch returns a match's snapshots, latest first.
+func (r *FeatureRepository) ListForMatch(matchID int) ([]TeamFeatures, error) {
+	rows, err := r.q.ListFeatureSnapshots(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature snapshots: %w", err)
+	}
+
+	snapshots := make([]TeamFeatures, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, TeamFeatures(row))
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Followable entity types.
+const (
+	FollowTeam        = "team"
+	FollowCompetition = "competition"
+)
+
+// Follow is a team or competition followed by a user.
+type Follow struct {
+	EntityType string    `json:"type"`
+	EntityID   int       `json:"-"`
+	Ref        string    `json:"id"` // team external ID or competition code
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// FollowRepository provides DB access for user follows.
+type FollowRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewFollowRepository(db *sql.DB) *FollowRepository {
+	return &FollowRepository{q: sqlcdb.New(db)}
+}
+
+// Add follows an entity by its public reference. Returns false if the entity
+// does not exist. Following twice is a no-op.
+func (r *FollowRepository) Add(userID int, entityType, ref string) (bool, error) {
+	ctx := context.Background()
+
+	var (
+		entityID int
+		err      error
+	)
+	switch entityType {
+	case FollowTeam:
+		entityID, err = r.q.GetTeamIDByExternalRef(ctx, ref)
+	case FollowCompetition:
+		entityID, err = r.q.GetCompetitionIDByCode(ctx, ref)
+	default:
+		return false, fmt.Errorf("unknown follow type %q", entityType)
+	}
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve %s: %w", entityType, err)
+	}
+
+	err = r.q.AddFollow(ctx, sqlcdb.AddFollowParams{
+		UserID:     userID,
+		EntityType: entityType,
+		EntityID:   entityID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to follow %s: %w", entityType, err)
+	}
+
+	return true, nil
+}
+
+// Remove unfollows an entity. Returns false if it was not followed.
+func (r *FollowRepository) Remove(userID int, entityType, ref string) (bool, error) {
+	ctx := context.Background()
+
+	var (
+		n   int64
+		err error
+	)
+	switch entityType {
+	case FollowTeam:
+		n, err = r.q.RemoveTeamFollow(ctx, sqlcdb.RemoveTeamFollowParams{UserID: userID, Ref: ref})
+	case FollowCompetition:
+		n, err = r.q.RemoveCompetitionFollow(ctx, sqlcdb.RemoveCompetitionFollowParams{UserID: userID, Ref: ref})
+	default:
+		return false, fmt.Errorf("unknown follow type %q", entityType)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to unfollow %s: %w", entityType, err)
+	}
+	return n > 0, nil
+}
+
+// List returns everything a user follows with display names.
+func (r *FollowRepository) List(userID int) ([]Follow, error) {
+	rows, err := r.q.ListFollows(context.Background(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+
+	follows := []Follow{}
+	for _, row := range rows {
+		follows = append(follows, Follow{
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			Ref:        row.Ref,
+			Name:       row.Name,
+			CreatedAt:  derefTime(row.CreatedAt),
+		})
+	}
+	return follows, nil
+}
+
+// UpcomingInterest returns, by competition code, how many follows the
+// competitions with fixtures kicking off within the given window, and the
+// teams playing them, have. Competitions nobody follows are left out.
+func (r *FollowRepository) UpcomingInterest(within time.Duration) (map[string]int, error) {
+	now := time.Now().UTC()
+	rows, err := r.q.ListUpcomingFollowInterest(context.Background(), sqlcdb.ListUpcomingFollowInterestParams{
+		FromDate:  now,
+		UntilDate: now.Add(within),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count follows of upcoming fixtures: %w", err)
+	}
+
+	interest := make(map[string]int, len(rows))
+	for _, row := range rows {
+		interest[row.Code] = row.Follows
+	}
+	return interest, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// HomeAdvantage is how much better teams did at home than away over their
+// latest WindowMatches matches at each venue, for a competition as a whole
+// or one team in it. Averages are per match. PreviousPpgDifferential covers
+// the window before and is nil until it was played at both venues.
+type HomeAdvantage struct {
+	CompetitionCode         *string
+	CompetitionName         string
+	TeamExternalID          *int
+	TeamName                *string
+	WindowMatches           int
+	HomeMatches             int
+	AwayMatches             int
+	HomePpg                 float64
+	AwayPpg                 float64
+	PpgDifferential         float64
+	HomeGoalDifference      float64
+	AwayGoalDifference      float64
+	GoalDifferential        float64
+	PreviousPpgDifferential *float64
+	ComputedAt              time.Time
+}
+
+// HomeAdvantageFilter narrows a listing to a window and optionally to one
+// competition, by code, or one team, by internal ID.
+type HomeAdvantageFilter struct {
+	WindowMatches   int
+	CompetitionCode string
+	TeamID          int
+}
+
+// MatchHomeAdvantage is the points-per-game differential of a match's home
+// team and competition. Team is nil when the home team has not yet played
+// at both venues in the competition.
+type MatchHomeAdvantage struct {
+	WindowMatches int
+	Team          *float64
+	Competition   float64
+}
+
+// HomeAdvantageRepository provides DB access for home advantage aggregates.
+type HomeAdvantageRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewHomeAdvantageRepository(db *sql.DB) *HomeAdvantageRepository {
+	return &HomeAdvantageRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// Refresh replaces the stored aggregates with ones computed over each
+// window, in matches per venue.
+func (r *HomeAdvantageRepository) Refresh(windows []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	if err := q.DeleteHomeAdvantageStats(ctx); err != nil {
+		return fmt.Errorf("failed to clear home advantage: %w", err)
+	}
+	for _, window := range windows {
+		if err := q.InsertHomeAdvantageStats(ctx, window); err != nil {
+			return fmt.Errorf("failed to compute home advantage over %d matches: %w", window, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit home advantage: %w", err)
+	}
+	return nil
+}
+
+// List returns the stored aggregates matching the filter, each
+// competition's own first and then its teams by differential.
+func (r *HomeAdvantageRepository) List(filter HomeAdvantageFilter) ([]HomeAdvantage, error) {
+	rows, err := r.q.ListHomeAdvantageStats(context.Background(), sqlcdb.ListHomeAdvantageStatsParams{
+		WindowMatches:   filter.WindowMatches,
+		CompetitionCode: filter.CompetitionCode,
+		TeamID:          filter.TeamID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query home advantage: %w", err)
+	}
+
+	stats := make([]HomeAdvantage, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, HomeAdvantage(row))
+	}
+	return stats, nil
+}
+
+// ForMatch returns the home advantage of a match's home team and
+// competition over the shortest stored window, or nil if none is stored.
+func (r *HomeAdvantageRepository) ForMatch(matchID int) (*MatchHomeAdvantage, error) {
+	row, err := r.q.GetMatchHomeAdvantage(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match home advantage: %w", err)
+	}
+	return &MatchHomeAdvantage{
+		WindowMatches: row.WindowMatches,
+		Team:          row.TeamPpgDifferential,
+		Competition:   row.CompetitionPpgDifferential,
+	}, nil
+}
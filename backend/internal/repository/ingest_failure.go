@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// IngestFailure is a row that ingestion could not save, kept with its
+// payload so it can be re-processed.
+type IngestFailure struct {
+	ID            int             `json:"id"`
+	EntityType    string          `json:"entityType"`
+	ExternalRef   string          `json:"externalRef"`
+	Payload       json.RawMessage `json:"payload"`
+	Error         string          `json:"error"`
+	Attempts      int             `json:"attempts"`
+	FirstFailedAt time.Time       `json:"firstFailedAt"`
+	LastFailedAt  time.Time       `json:"lastFailedAt"`
+	ResolvedAt    *time.Time      `json:"resolvedAt,omitempty"`
+}
+
+// IngestFailureCount summarises failures for one entity type.
+type IngestFailureCount struct {
+	EntityType string `json:"entityType"`
+	Open       int    `json:"open"`
+	Resolved   int    `json:"resolved"`
+	Attempts   int    `json:"attempts"`
+}
+
+// IngestFailureRepository provides DB access for the ingest dead-letter table.
+type IngestFailureRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewIngestFailureRepository(db *sql.DB) *IngestFailureRepository {
+	return &IngestFailureRepository{q: sqlcdb.New(db)}
+}
+
+// Record stores a failed row. If the row already has an open failure its
+// payload and error are refreshed and the attempt count is increased.
+func (r *IngestFailureRepository) Record(entityType, externalRef string, payload []byte, errMsg string) error {
+	err := r.q.RecordIngestFailure(context.Background(), sqlcdb.RecordIngestFailureParams{
+		EntityType:  entityType,
+		ExternalRef: externalRef,
+		Payload:     payload,
+		Error:       errMsg,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record ingest failure: %w", err)
+	}
+	return nil
+}
+
+// ListOpen returns unresolved failures with fewer than maxAttempts attempts,
+// oldest first. An empty entityType matches all types.
+func (r *IngestFailureRepository) ListOpen(entityType string, maxAttempts, limit int) ([]IngestFailure, error) {
+	rows, err := r.q.ListOpenIngestFailures(context.Background(), sqlcdb.ListOpenIngestFailuresParams{
+		EntityType:  entityType,
+		MaxAttempts: maxAttempts,
+		RowLimit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingest failures: %w", err)
+	}
+	return toIngestFailures(rows), nil
+}
+
+// ListRecent returns the most recent failures, optionally only unresolved
+// ones and only of one type.
+func (r *IngestFailureRepository) ListRecent(entityType string, openOnly bool, limit int) ([]IngestFailure, error) {
+	rows, err := r.q.ListRecentIngestFailures(context.Background(), sqlcdb.ListRecentIngestFailuresParams{
+		EntityType: entityType,
+		OpenOnly:   openOnly,
+		RowLimit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingest failures: %w", err)
+	}
+	return toIngestFailures(rows), nil
+}
+
+func toIngestFailures(rows []sqlcdb.IngestFailure) []IngestFailure {
+	failures := make([]IngestFailure, 0, len(rows))
+	for _, row := range rows {
+		failures = append(failures, IngestFailure(row))
+	}
+	return failures
+}
+
+// MarkResolved closes a failure after a successful retry.
+func (r *IngestFailureRepository) MarkResolved(id int) error {
+	if err := r.q.ResolveIngestFailure(context.Background(), id); err != nil {
+		return fmt.Errorf("failed to resolve ingest failure: %w", err)
+	}
+	return nil
+}
+
+// MarkRetryFailed records another failed attempt.
+func (r *IngestFailureRepository) MarkRetryFailed(id int, errMsg string) error {
+	err := r.q.MarkIngestFailureRetryFailed(context.Background(), sqlcdb.MarkIngestFailureRetryFailedParams{
+		Error: errMsg,
+		ID:    id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update ingest failure: %w", err)
+	}
+	return nil
+}
+
+// Counts returns open and resolved failure counts per entity type.
+func (r *IngestFailureRepository) Counts() ([]IngestFailureCount, error) {
+	rows, err := r.q.CountIngestFailures(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count ingest failures: %w", err)
+	}
+
+	counts := []IngestFailureCount{}
+	for _, row := range rows {
+		counts = append(counts, IngestFailureCount(row))
+	}
+	return counts, nil
+}
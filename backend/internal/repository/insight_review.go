@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/moderation"
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// InsightReview is a generated text queued for review with the issues found
+// in it. Published is false for texts that were rejected and never served.
+type InsightReview struct {
+	ID              int                `json:"id"`
+	Kind            string             `json:"kind"`
+	MatchID         *int               `json:"matchId,omitempty"`
+	MatchExternalID *int               `json:"matchExternalId,omitempty"`
+	Subject         string             `json:"subject,omitempty"`
+	Headline        string             `json:"headline,omitempty"`
+	Body            string             `json:"body"`
+	GeneratedBy     string             `json:"generatedBy"`
+	Issues          []moderation.Issue `json:"issues"`
+	Published       bool               `json:"published"`
+	Status          string             `json:"status"`
+	ReviewedAt      *time.Time         `json:"reviewedAt,omitempty"`
+	CreatedAt       time.Time          `json:"createdAt"`
+}
+
+// InsightReviewRepository provides DB access for the review queue of
+// generated insights.
+type InsightReviewRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewInsightReviewRepository(db *sql.DB) *InsightReviewRepository {
+	return &InsightReviewRepository{q: sqlcdb.New(db)}
+}
+
+// Add queues a text for review unless the same text of its kind already is.
+func (r *InsightReviewRepository) Add(review InsightReview) error {
+	issues, err := json.Marshal(review.Issues)
+	if err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+	hash := sha256.Sum256([]byte(review.Headline + "\n" + review.Body))
+
+	if err := r.q.InsertInsightReview(context.Background(), sqlcdb.InsertInsightReviewParams{
+		Kind:        review.Kind,
+		MatchID:     review.MatchID,
+		Subject:     review.Subject,
+		Headline:    review.Headline,
+		Body:        review.Body,
+		GeneratedBy: review.GeneratedBy,
+		Issues:      issues,
+		Published:   review.Published,
+		ContentHash: hex.EncodeToString(hash[:]),
+	}); err != nil {
+		return fmt.Errorf("failed to queue insight review: %w", err)
+	}
+	return nil
+}
+
+// List returns up to limit queued texts with a status, newest first. An
+// empty kind lists every kind.
+func (r *InsightReviewRepository) List(status, kind string, limit int) ([]InsightReview, error) {
+	rows, err := r.q.ListInsightReviews(context.Background(), sqlcdb.ListInsightReviewsParams{
+		Status:   status,
+		Kind:     nullString(kind),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query insight reviews: %w", err)
+	}
+
+	reviews := make([]InsightReview, 0, len(rows))
+	for _, row := range rows {
+		review := InsightReview{
+			ID:              row.ID,
+			Kind:            row.Kind,
+			MatchID:         row.MatchID,
+			MatchExternalID: row.MatchExternalID,
+			Subject:         row.Subject,
+			Headline:        row.Headline,
+			Body:            row.Body,
+			GeneratedBy:     row.GeneratedBy,
+			Published:       row.Published,
+			Status:          row.Status,
+			ReviewedAt:      row.ReviewedAt,
+			CreatedAt:       row.CreatedAt,
+		}
+		if err := json.Unmarshal(row.Issues, &review.Issues); err != nil {
+			return nil, fmt.Errorf("failed to decode issues: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// Resolve sets a queued text's status. It returns false if the text does
+// not exist.
+func (r *InsightReviewRepository) Resolve(id int, status string) (bool, error) {
+	now := time.Now().UTC()
+	n, err := r.q.ResolveInsightReview(context.Background(), sqlcdb.ResolveInsightReviewParams{
+		Status:     status,
+		ReviewedAt: &now,
+		ID:         id,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve insight review: %w", err)
+	}
+	return n > 0, nil
+}
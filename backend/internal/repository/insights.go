@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// InsightsFact is a streak or upcoming milestone of a team or, when
+// PlayerID is set, of one of its players.
+type InsightsFact struct {
+	ID          int        `json:"id"`
+	TeamID      int        `json:"teamId"`
+	PlayerID    *int       `json:"playerId,omitempty"`
+	PlayerName  string     `json:"playerName,omitempty"`
+	Kind        string     `json:"kind"`
+	Value       int        `json:"value"`
+	Target      *int       `json:"target,omitempty"`
+	Description string     `json:"description"`
+	SinceDate   *time.Time `json:"sinceDate,omitempty"`
+	LastMatchID *int       `json:"lastMatchId,omitempty"`
+	ComputedAt  time.Time  `json:"computedAt"`
+}
+
+// TeamTotals counts a team's stored finished matches, wins and goals.
+type TeamTotals struct {
+	Matches int
+	Wins    int
+	Goals   int
+}
+
+// PlayerAppearance is a player's appearance in a finished match.
+type PlayerAppearance struct {
+	PlayerID   int
+	PlayerName string
+	MatchID    int
+	UtcDate    time.Time
+	Goals      int
+}
+
+// InsightsRepository provides DB access for computed insight facts.
+type InsightsRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewInsightsRepository(db *sql.DB) *InsightsRepository {
+	return &InsightsRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// ListTeamsDue returns internal IDs of up to limit teams whose facts are
+// missing, predate one of their results or were computed before
+// staleBefore.
+func (r *InsightsRepository) ListTeamsDue(staleBefore time.Time, limit int) ([]int, error) {
+	ids, err := r.q.ListTeamsDueForFacts(context.Background(), sqlcdb.ListTeamsDueForFactsParams{
+		StaleBefore: staleBefore,
+		RowLimit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams due for facts: %w", err)
+	}
+	return ids, nil
+}
+
+// TeamTotals returns a team's stored all-time totals.
+func (r *InsightsRepository) TeamTotals(teamID int) (*TeamTotals, error) {
+	row, err := r.q.GetTeamTotals(context.Background(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team totals: %w", err)
+	}
+	totals := TeamTotals(row)
+	return &totals, nil
+}
+
+// ListPlayerAppearances returns the finished-match appearances of a team's
+// current players, grouped by player and most recent first.
+func (r *InsightsRepository) ListPlayerAppearances(teamID int) ([]PlayerAppearance, error) {
+	rows, err := r.q.ListTeamPlayerAppearances(context.Background(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player appearances: %w", err)
+	}
+
+	appearances := make([]PlayerAppearance, 0, len(rows))
+	for _, row := range rows {
+		appearances = append(appearances, PlayerAppearance(row))
+	}
+	return appearances, nil
+}
+
+// ReplaceTeamFacts swaps a team's facts, its players' included, for a newly
+// computed set and records when they were computed.
+func (r *InsightsRepository) ReplaceTeamFacts(teamID int, facts []InsightsFact) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	if err := q.DeleteTeamFacts(ctx, teamID); err != nil {
+		return fmt.Errorf("failed to clear team facts: %w", err)
+	}
+
+	for _, f := range facts {
+		err := q.InsertInsightsFact(ctx, sqlcdb.InsertInsightsFactParams{
+			TeamID:      teamID,
+			PlayerID:    f.PlayerID,
+			Kind:        f.Kind,
+			Value:       f.Value,
+			Target:      f.Target,
+			Description: f.Description,
+			SinceDate:   f.SinceDate,
+			LastMatchID: f.LastMatchID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert team fact: %w", err)
+		}
+	}
+
+	if err := q.MarkTeamFactsComputed(ctx, teamID); err != nil {
+		return fmt.Errorf("failed to mark team facts computed: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit team facts: %w", err)
+	}
+	return nil
+}
+
+// ListTeamFacts returns a team's stored facts, team facts first.
+func (r *InsightsRepository) ListTeamFacts(teamID int) ([]InsightsFact, error) {
+	rows, err := r.q.ListTeamFacts(context.Background(), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team facts: %w", err)
+	}
+
+	facts := make([]InsightsFact, 0, len(rows))
+	for _, row := range rows {
+		facts = append(facts, InsightsFact(row))
+	}
+	return facts, nil
+}
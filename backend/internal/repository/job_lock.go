@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// JobLockRepository stores expiring leases on named locks in Postgres, so
+// processes sharing the database can take turns running a job.
+type JobLockRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewJobLockRepository(db *sql.DB) *JobLockRepository {
+	return &JobLockRepository{q: sqlcdb.New(db)}
+}
+
+// Acquire takes the lock for ttl and reports whether it got it, which it
+// does not while another owner holds an unexpired lease.
+func (r *JobLockRepository) Acquire(name, owner string, ttl time.Duration) (bool, error) {
+	_, err := r.q.AcquireJobLock(context.Background(), sqlcdb.AcquireJobLockParams{
+		Name:       name,
+		Owner:      owner,
+		TtlSeconds: ttl.Seconds(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Renew extends owner's lease by ttl from now. It reports false if the lease
+// has expired or passed to another owner.
+func (r *JobLockRepository) Renew(name, owner string, ttl time.Duration) (bool, error) {
+	n, err := r.q.RenewJobLock(context.Background(), sqlcdb.RenewJobLockParams{
+		TtlSeconds: ttl.Seconds(),
+		Name:       name,
+		Owner:      owner,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to renew lock %s: %w", name, err)
+	}
+	return n > 0, nil
+}
+
+// Release gives up owner's lease, if it still has it.
+func (r *JobLockRepository) Release(name, owner string) error {
+	err := r.q.ReleaseJobLock(context.Background(), sqlcdb.ReleaseJobLockParams{Name: name, Owner: owner})
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", name, err)
+	}
+	return nil
+}
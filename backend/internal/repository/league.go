@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Points awarded when a score prediction is settled. Users and the model are
+// scored with the same values so they can share a league table.
+const (
+	ExactScorePoints     = 3
+	CorrectOutcomePoints = 1
+)
+
+// UserPrediction is a user's score prediction for a fixture.
+type UserPrediction struct {
+	ID              int        `json:"id"`
+	MatchID         int        `json:"-"`
+	MatchExternalID int        `json:"matchId"`
+	HomeTeamName    string     `json:"homeTeamName"`
+	AwayTeamName    string     `json:"awayTeamName"`
+	UtcDate         time.Time  `json:"utcDate"`
+	HomeGoals       int        `json:"homeGoals"`
+	AwayGoals       int        `json:"awayGoals"`
+	Points          *int       `json:"points"`
+	OutcomeCorrect  *bool      `json:"outcomeCorrect"`
+	ExactScore      *bool      `json:"exactScore"`
+	SettledAt       *time.Time `json:"settledAt,omitempty"`
+}
+
+// League is a private group of users competing on predictions.
+type League struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	OwnerID         int       `json:"ownerId"`
+	CompetitionCode string    `json:"competitionCode,omitempty"`
+	InviteCode      string    `json:"inviteCode"`
+	Members         int       `json:"members"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// LeagueTableRow is one entrant in a league table. The model appears as an
+// entrant with IsModel set.
+type LeagueTableRow struct {
+	Position       int    `json:"position"`
+	UserID         *int   `json:"userId,omitempty"`
+	Name           string `json:"name"`
+	IsModel        bool   `json:"isModel"`
+	Predictions    int    `json:"predictions"`
+	Points         int    `json:"points"`
+	ExactScores    int    `json:"exactScores"`
+	CorrectResults int    `json:"correctResults"`
+}
+
+// LeagueRepository provides DB access for user predictions and leagues.
+type LeagueRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewLeagueRepository(db *sql.DB) *LeagueRepository {
+	return &LeagueRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// UpsertPrediction stores or replaces a user's prediction for a match.
+func (r *LeagueRepository) UpsertPrediction(userID, matchID, homeGoals, awayGoals int) error {
+	err := r.q.UpsertUserPrediction(context.Background(), sqlcdb.UpsertUserPredictionParams{
+		UserID:    userID,
+		MatchID:   matchID,
+		HomeGoals: homeGoals,
+		AwayGoals: awayGoals,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save user prediction: %w", err)
+	}
+	return nil
+}
+
+// ListPredictions returns a user's predictions, most recent fixture first.
+func (r *LeagueRepository) ListPredictions(userID, limit int) ([]UserPrediction, error) {
+	rows, err := r.q.ListUserPredictions(context.Background(), sqlcdb.ListUserPredictionsParams{
+		UserID:   userID,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user predictions: %w", err)
+	}
+
+	predictions := []UserPrediction{}
+	for _, row := range rows {
+		predictions = append(predictions, UserPrediction(row))
+	}
+	return predictions, nil
+}
+
+// SettlePredictions scores user predictions for finished matches. Returns the
+// number of predictions settled.
+func (r *LeagueRepository) SettlePredictions() (int, error) {
+	n, err := r.q.SettleUserPredictions(context.Background(), sqlcdb.SettleUserPredictionsParams{
+		ExactScorePoints: ExactScorePoints,
+		OutcomePoints:    CorrectOutcomePoints,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to settle user predictions: %w", err)
+	}
+	return int(n), nil
+}
+
+// Create inserts a league and adds its owner as the first member. A
+// non-empty CompetitionCode restricts the league to that competition; false
+// is returned if the code is unknown.
+func (r *LeagueRepository) Create(l *League) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	var competitionID *int
+	if l.CompetitionCode != "" {
+		id, err := q.GetCompetitionIDByCode(ctx, l.CompetitionCode)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve competition: %w", err)
+		}
+		competitionID = &id
+	}
+
+	row, err := q.CreateLeague(ctx, sqlcdb.CreateLeagueParams{
+		Name:          l.Name,
+		OwnerID:       l.OwnerID,
+		CompetitionID: competitionID,
+		InviteCode:    l.InviteCode,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to create league: %w", err)
+	}
+	l.ID = row.ID
+	l.CreatedAt = derefTime(row.CreatedAt)
+
+	if err := q.AddLeagueMember(ctx, sqlcdb.AddLeagueMemberParams{LeagueID: l.ID, UserID: l.OwnerID}); err != nil {
+		return false, fmt.Errorf("failed to add league owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit league: %w", err)
+	}
+
+	l.Members = 1
+	return true, nil
+}
+
+// Join adds a user to the league with the given invite code. Returns nil if
+// the code is unknown. Joining twice is a no-op.
+func (r *LeagueRepository) Join(userID int, inviteCode string) (*League, error) {
+	ctx := context.Background()
+
+	leagueID, err := r.q.GetLeagueIDByInviteCode(ctx, inviteCode)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find league: %w", err)
+	}
+
+	if err := r.q.AddLeagueMember(ctx, sqlcdb.AddLeagueMemberParams{LeagueID: leagueID, UserID: userID}); err != nil {
+		return nil, fmt.Errorf("failed to join league: %w", err)
+	}
+
+	return r.GetForMember(leagueID, userID)
+}
+
+func toLeague(row sqlcdb.ListLeaguesForUserRow) League {
+	return League{
+		ID:              row.ID,
+		Name:            row.Name,
+		OwnerID:         row.OwnerID,
+		CompetitionCode: row.CompetitionCode,
+		InviteCode:      row.InviteCode,
+		Members:         row.Members,
+		CreatedAt:       derefTime(row.CreatedAt),
+	}
+}
+
+// GetForMember returns a league if the user is a member, otherwise nil.
+func (r *LeagueRepository) GetForMember(leagueID, userID int) (*League, error) {
+	row, err := r.q.GetLeagueForMember(context.Background(), sqlcdb.GetLeagueForMemberParams{
+		ID:     leagueID,
+		UserID: userID,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+
+	l := toLeague(sqlcdb.ListLeaguesForUserRow(row))
+	return &l, nil
+}
+
+// ListForUser returns the leagues a user belongs to.
+func (r *LeagueRepository) ListForUser(userID int) ([]League, error) {
+	rows, err := r.q.ListLeaguesForUser(context.Background(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leagues: %w", err)
+	}
+
+	leagues := []League{}
+	for _, row := range rows {
+		leagues = append(leagues, toLeague(row))
+	}
+	return leagues, nil
+}
+
+// GetTable ranks league members by points on settled predictions for
+// fixtures after the league was created (and in its competition, if set).
+// The model is scored on the same fixtures any member predicted.
+func (r *LeagueRepository) GetTable(leagueID int) ([]LeagueTableRow, error) {
+	rows, err := r.q.GetLeagueTable(context.Background(), sqlcdb.GetLeagueTableParams{
+		LeagueID:         leagueID,
+		ExactScorePoints: ExactScorePoints,
+		OutcomePoints:    CorrectOutcomePoints,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query league table: %w", err)
+	}
+
+	table := []LeagueTableRow{}
+	for _, row := range rows {
+		table = append(table, LeagueTableRow{
+			UserID:         row.UserID,
+			Name:           row.Name,
+			IsModel:        row.IsModel,
+			Predictions:    row.Predictions,
+			Points:         row.Points,
+			ExactScores:    row.ExactScores,
+			CorrectResults: row.CorrectResults,
+		})
+	}
+	return table, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+// LLMModelUsage totals the requests of one task to one model.
+type LLMModelUsage struct {
+	Task         string  `json:"task"`
+	Provider     string  `json:"provider"`
+	Model        string  `json:"model"`
+	Requests     int     `json:"requests"`
+	Failures     int     `json:"failures"`
+	InputTokens  int     `json:"inputTokens"`
+	OutputTokens int     `json:"outputTokens"`
+	CostUSD      float64 `json:"costUsd"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+}
+
+// LLMUsageRepository stores every request made to an LLM provider. It
+// implements llm.UsageStore.
+type LLMUsageRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewLLMUsageRepository(db *sql.DB) *LLMUsageRepository {
+	return &LLMUsageRepository{q: sqlcdb.New(db)}
+}
+
+// Record stores one request.
+func (r *LLMUsageRepository) Record(u llm.Usage) error {
+	if err := r.q.InsertLLMUsage(context.Background(), sqlcdb.InsertLLMUsageParams{
+		Task:         u.Task,
+		Provider:     u.Provider,
+		Model:        u.Model,
+		InputTokens:  u.InputTokens,
+		OutputTokens: u.OutputTokens,
+		CostUsd:      u.CostUSD,
+		LatencyMs:    int(u.Latency.Milliseconds()),
+		Error:        nullString(u.Error),
+		CreatedAt:    u.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to save llm usage: %w", err)
+	}
+	return nil
+}
+
+// ListSummary totals the requests since a time per task and model,
+// costliest first.
+func (r *LLMUsageRepository) ListSummary(since time.Time) ([]LLMModelUsage, error) {
+	rows, err := r.q.ListLLMUsageSummary(context.Background(), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query llm usage: %w", err)
+	}
+
+	usage := make([]LLMModelUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, LLMModelUsage{
+			Task:         row.Task,
+			Provider:     row.Provider,
+			Model:        row.Model,
+			Requests:     row.Requests,
+			Failures:     row.Failures,
+			InputTokens:  row.InputTokens,
+			OutputTokens: row.OutputTokens,
+			CostUSD:      row.CostUsd,
+			AvgLatencyMs: row.AvgLatencyMs,
+		})
+	}
+	return usage, nil
+}
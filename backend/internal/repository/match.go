@@ -1,64 +1,157 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/football"
 )
 
 // HeadToHeadMatch represents a single historical meeting between two teams.
 type HeadToHeadMatch struct {
-	Season             string `json:"season"`
-	HomeTeamExternalID int    `json:"homeTeamExternalId"`
-	AwayTeamExternalID int    `json:"awayTeamExternalId"`
-	HomeScore          int    `json:"homeScore"`
-	AwayScore          int    `json:"awayScore"`
-	Winner             string `json:"winner"`
+	Season             string    `json:"season"`
+	UtcDate            time.Time `json:"utcDate"`
+	CompetitionCode    string    `json:"competitionCode"`
+	HomeTeamExternalID int       `json:"homeTeamExternalId"`
+	AwayTeamExternalID int       `json:"awayTeamExternalId"`
+	HomeScore          int       `json:"homeScore"`
+	AwayScore          int       `json:"awayScore"`
+	Winner             string    `json:"winner"`
 }
 
-// HeadToHeadRecord aggregates the record between two clubs.
+// HeadToHeadRecord aggregates the record between two clubs, overall and
+// by competition, most meetings first. Weighted is set when the filter
+// asked for a weighting.
 type HeadToHeadRecord struct {
-	HomeWins int               `json:"homeWins"`
-	AwayWins int               `json:"awayWins"`
-	Draws    int               `json:"draws"`
-	Matches  []HeadToHeadMatch `json:"lastMeetings"`
+	HomeWins     int                     `json:"homeWins"`
+	AwayWins     int                     `json:"awayWins"`
+	Draws        int                     `json:"draws"`
+	Weighted     *WeightedHeadToHead     `json:"weighted,omitempty"`
+	Competitions []CompetitionHeadToHead `json:"competitions"`
+	Matches      []HeadToHeadMatch       `json:"lastMeetings"`
+}
+
+// CompetitionHeadToHead is the part of a head-to-head record played in one
+// competition. Meetings outside a stored competition have an empty code.
+type CompetitionHeadToHead struct {
+	CompetitionCode string              `json:"competitionCode"`
+	CompetitionName string              `json:"competitionName"`
+	Matches         int                 `json:"matches"`
+	HomeWins        int                 `json:"homeWins"`
+	AwayWins        int                 `json:"awayWins"`
+	Draws           int                 `json:"draws"`
+	Weighted        *WeightedHeadToHead `json:"weighted,omitempty"`
+}
+
+// WeightedHeadToHead is a head-to-head record with each meeting counted at
+// its Weighting weight, to two decimals.
+type WeightedHeadToHead struct {
+	HomeWins float64 `json:"homeWins"`
+	AwayWins float64 `json:"awayWins"`
+	Draws    float64 `json:"draws"`
+}
+
+func (w *WeightedHeadToHead) round() {
+	w.HomeWins = math.Round(w.HomeWins*100) / 100
+	w.AwayWins = math.Round(w.AwayWins*100) / 100
+	w.Draws = math.Round(w.Draws*100) / 100
+}
+
+// Weighting discounts results by age and venue. A result counts half once
+// it is HalfLifeDays old, and halves again with every further half-life;
+// 0 disables the decay. A result at the other venue, with the team away
+// instead of at home or the reverse fixture of a meeting, also counts
+// VenueWeight, so 1 weighs both venues alike.
+type Weighting struct {
+	HalfLifeDays float64 `json:"halfLifeDays"`
+	VenueWeight  float64 `json:"venueWeight"`
+}
+
+// Weight is what a result played at playedAt counts for as of asOf.
+func (w Weighting) Weight(playedAt, asOf time.Time, sameVenue bool) float64 {
+	weight := 1.0
+	if age := asOf.Sub(playedAt).Hours() / 24; w.HalfLifeDays > 0 && age > 0 {
+		weight = math.Pow(0.5, age/w.HalfLifeDays)
+	}
+	if !sameVenue {
+		weight *= w.VenueWeight
+	}
+	return weight
+}
+
+// MatchSummary is a typed view of a stored match with both teams and the score.
+// The score counts goals in play, without shootout penalties. DecidedBy is
+// REGULAR, EXTRA_TIME or PENALTY_SHOOTOUT once the match has finished.
+type MatchSummary struct {
+	ID                 int       `json:"id"`
+	ExternalID         int       `json:"externalId"`
+	CompetitionCode    string    `json:"competitionCode"`
+	Season             string    `json:"season"`
+	Matchday           int       `json:"matchday"`
+	UtcDate            time.Time `json:"utcDate"`
+	Status             string    `json:"status"`
+	HomeTeamID         int       `json:"homeTeamId"`
+	HomeTeamExternalID int       `json:"homeTeamExternalId"`
+	HomeTeamName       string    `json:"homeTeamName"`
+	AwayTeamID         int       `json:"awayTeamId"`
+	AwayTeamExternalID int       `json:"awayTeamExternalId"`
+	AwayTeamName       string    `json:"awayTeamName"`
+	HomeScore          *int      `json:"homeScore"`
+	AwayScore          *int      `json:"awayScore"`
+	Winner             string    `json:"winner"`
+	DecidedBy          string    `json:"decidedBy"`
+	// LocalDate and LocalTime are the kickoff in the client's time zone, set
+	// by Localize when the match is served.
+	LocalDate string `json:"localDate,omitempty"`
+	LocalTime string `json:"localTime,omitempty"`
+}
+
+// Localize sets LocalDate and LocalTime to the kickoff in loc.
+func (m *MatchSummary) Localize(loc *time.Location) {
+	m.LocalDate, m.LocalTime = football.LocalKickoff(m.UtcDate, loc)
+}
+
+// toMatchSummary converts a summary row; the rows of the other summary
+// queries convert to this one.
+func toMatchSummary(row sqlcdb.GetMatchSummaryByIDRow) MatchSummary {
+	return MatchSummary{
+		ID:                 row.ID,
+		ExternalID:         row.ExternalID,
+		CompetitionCode:    row.CompetitionCode,
+		Season:             row.Season,
+		Matchday:           row.Matchday,
+		UtcDate:            row.UtcDate,
+		Status:             row.Status,
+		HomeTeamID:         row.HomeTeamID,
+		HomeTeamExternalID: row.HomeTeamExternalID,
+		HomeTeamName:       row.HomeTeamName,
+		AwayTeamID:         row.AwayTeamID,
+		AwayTeamExternalID: row.AwayTeamExternalID,
+		AwayTeamName:       row.AwayTeamName,
+		HomeScore:          row.HomeScore,
+		AwayScore:          row.AwayScore,
+		Winner:             row.Winner,
+		DecidedBy:          row.DecidedBy,
+	}
 }
 
 // MatchRepository provides DB access for matches and related stats.
 type MatchRepository struct {
-	db *sql.DB
+	q *sqlcdb.Queries
 }
 
 func NewMatchRepository(db *sql.DB) *MatchRepository {
-	return &MatchRepository{db: db}
+	return &MatchRepository{q: sqlcdb.New(db)}
 }
 
 // GetMatchByExternalID fetches a match from the database by its external API ID
 func (r *MatchRepository) GetMatchByExternalID(externalID int) (map[string]interface{}, error) {
-	query := `
-		SELECT 
-			m.id, m.external_id, m.status, m.utc_date, m.matchday,
-			m.home_team_id, m.away_team_id,
-			ht.name as home_team_name, ht.external_id as home_team_external_id,
-			at.name as away_team_name, at.external_id as away_team_external_id
-		FROM matches m
-		JOIN teams ht ON m.home_team_id = ht.id
-		JOIN teams at ON m.away_team_id = at.id
-		WHERE m.external_id = $1
-	`
-
-	var (
-		id, externalIDResult, homeTeamID, awayTeamID, homeExtID, awayExtID, matchday int
-		status, homeTeamName, awayTeamName                                           string
-		utcDate                                                                      sql.NullTime
-	)
-
-	err := r.db.QueryRow(query, externalID).Scan(
-		&id, &externalIDResult, &status, &utcDate, &matchday,
-		&homeTeamID, &awayTeamID,
-		&homeTeamName, &homeExtID,
-		&awayTeamName, &awayExtID,
-	)
-
+	row, err := r.q.GetMatchWithTeamsByExternalID(context.Background(), externalID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("match not found")
@@ -66,52 +159,12 @@ func (r *MatchRepository) GetMatchByExternalID(externalID int) (map[string]inter
 		return nil, fmt.Errorf("failed to fetch match: %w", err)
 	}
 
-	return map[string]interface{}{
-		"id":         id,
-		"externalId": externalIDResult,
-		"status":     status,
-		"utcDate":    utcDate.Time,
-		"matchday":   matchday,
-		"homeTeam": map[string]interface{}{
-			"id":         homeTeamID,
-			"externalId": homeExtID,
-			"name":       homeTeamName,
-		},
-		"awayTeam": map[string]interface{}{
-			"id":         awayTeamID,
-			"externalId": awayExtID,
-			"name":       awayTeamName,
-		},
-	}, nil
+	return matchWithTeamsMap(sqlcdb.GetMatchWithTeamsByIDRow(row)), nil
 }
 
 // GetMatchByID fetches a match from the database by its internal ID
 func (r *MatchRepository) GetMatchByID(matchID int) (map[string]interface{}, error) {
-	query := `
-		SELECT 
-			m.id, m.external_id, m.status, m.utc_date, m.matchday,
-			m.home_team_id, m.away_team_id,
-			ht.name as home_team_name, ht.external_id as home_team_external_id,
-			at.name as away_team_name, at.external_id as away_team_external_id
-		FROM matches m
-		JOIN teams ht ON m.home_team_id = ht.id
-		JOIN teams at ON m.away_team_id = at.id
-		WHERE m.id = $1
-	`
-
-	var (
-		id, externalID, homeTeamID, awayTeamID, homeExtID, awayExtID, matchday int
-		status, homeTeamName, awayTeamName                                     string
-		utcDate                                                                sql.NullTime
-	)
-
-	err := r.db.QueryRow(query, matchID).Scan(
-		&id, &externalID, &status, &utcDate, &matchday,
-		&homeTeamID, &awayTeamID,
-		&homeTeamName, &homeExtID,
-		&awayTeamName, &awayExtID,
-	)
-
+	row, err := r.q.GetMatchWithTeamsByID(context.Background(), matchID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("match not found")
@@ -119,104 +172,350 @@ func (r *MatchRepository) GetMatchByID(matchID int) (map[string]interface{}, err
 		return nil, fmt.Errorf("failed to fetch match: %w", err)
 	}
 
+	return matchWithTeamsMap(row), nil
+}
+
+func matchWithTeamsMap(row sqlcdb.GetMatchWithTeamsByIDRow) map[string]interface{} {
 	return map[string]interface{}{
-		"id":         id,
-		"externalId": externalID,
-		"status":     status,
-		"utcDate":    utcDate.Time,
-		"matchday":   matchday,
+		"id":         row.ID,
+		"externalId": row.ExternalID,
+		"status":     row.Status,
+		"utcDate":    row.UtcDate,
+		"matchday":   row.Matchday,
 		"homeTeam": map[string]interface{}{
-			"id":         homeTeamID,
-			"externalId": homeExtID,
-			"name":       homeTeamName,
+			"id":         row.HomeTeamID,
+			"externalId": row.HomeTeamExternalID,
+			"name":       row.HomeTeamName,
 		},
 		"awayTeam": map[string]interface{}{
-			"id":         awayTeamID,
-			"externalId": awayExtID,
-			"name":       awayTeamName,
+			"id":         row.AwayTeamID,
+			"externalId": row.AwayTeamExternalID,
+			"name":       row.AwayTeamName,
 		},
-	}, nil
+	}
+}
+
+// HeadToHeadFilter narrows the meetings counted by
+// GetHeadToHeadByExternalTeamIDs.
+type HeadToHeadFilter struct {
+	CompetitionType string // "LEAGUE", "CUP" or "" for both
+	TeamType        string // "CLUB", "NATIONAL" or "" for both
+	CompetitionCode string // e.g. "PL", or "" for every competition
+	Limit           int
+	Weighting       *Weighting // nil leaves the record unweighted
 }
 
 // GetHeadToHeadByExternalTeamIDs returns head-to-head record for two clubs
 // identified by their external IDs (from football-data.org).
-func (r *MatchRepository) GetHeadToHeadByExternalTeamIDs(homeExternalID, awayExternalID, limit int) (*HeadToHeadRecord, error) {
-	const query = `
-        SELECT
-            m.season,
-            m.home_score,
-            m.away_score,
-            m.winner,
-            th.external_id AS home_external_id,
-            ta.external_id AS away_external_id
-        FROM matches m
-        JOIN teams th ON m.home_team_id = th.id
-        JOIN teams ta ON m.away_team_id = ta.id
-        WHERE ((th.external_id = $1 AND ta.external_id = $2)
-            OR (th.external_id = $2 AND ta.external_id = $1))
-          AND m.home_score IS NOT NULL
-          AND m.away_score IS NOT NULL
-        ORDER BY m.utc_date DESC
-        LIMIT $3
-    `
-
-	rows, err := r.db.Query(query, homeExternalID, awayExternalID, limit)
+func (r *MatchRepository) GetHeadToHeadByExternalTeamIDs(homeExternalID, awayExternalID int, f HeadToHeadFilter) (*HeadToHeadRecord, error) {
+	rows, err := r.q.ListHeadToHead(context.Background(), sqlcdb.ListHeadToHeadParams{
+		TeamA:           homeExternalID,
+		TeamB:           awayExternalID,
+		CompetitionType: f.CompetitionType,
+		TeamType:        f.TeamType,
+		CompetitionCode: f.CompetitionCode,
+		RowLimit:        f.Limit,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query head-to-head: %w", err)
 	}
-	defer rows.Close()
+
+	// If no matches found, return nil so callers can decide what to do.
+	if len(rows) == 0 {
+		return nil, nil
+	}
 
 	record := &HeadToHeadRecord{}
+	if f.Weighting != nil {
+		record.Weighted = &WeightedHeadToHead{}
+	}
+	byCompetition := make(map[string]*CompetitionHeadToHead)
+	now := time.Now()
 
-	for rows.Next() {
-		var (
-			season                       string
-			homeScore, awayScore         int
-			winner                       string
-			rowHomeExternalID, rowAwayID int
-		)
+	for _, row := range rows {
+		competition := byCompetition[row.CompetitionCode]
+		if competition == nil {
+			competition = &CompetitionHeadToHead{
+				CompetitionCode: row.CompetitionCode,
+				CompetitionName: row.CompetitionName,
+			}
+			if f.Weighting != nil {
+				competition.Weighted = &WeightedHeadToHead{}
+			}
+			byCompetition[row.CompetitionCode] = competition
+		}
+		competition.Matches++
 
-		if err := rows.Scan(&season, &homeScore, &awayScore, &winner, &rowHomeExternalID, &rowAwayID); err != nil {
-			return nil, fmt.Errorf("failed to scan head-to-head row: %w", err)
+		var weight float64
+		if f.Weighting != nil {
+			weight = f.Weighting.Weight(row.UtcDate, now, row.HomeExternalID == homeExternalID)
 		}
 
 		// Count results from the perspective of the current home/away clubs,
 		// regardless of who was home in the historical fixture.
-		switch winner {
+		switch row.Winner {
 		case "DRAW":
 			record.Draws++
-		case "HOME_TEAM":
-			if rowHomeExternalID == homeExternalID {
-				record.HomeWins++
-			} else if rowHomeExternalID == awayExternalID {
-				record.AwayWins++
+			competition.Draws++
+			if f.Weighting != nil {
+				record.Weighted.Draws += weight
+				competition.Weighted.Draws += weight
+			}
+		case "HOME_TEAM", "AWAY_TEAM":
+			winner := row.HomeExternalID
+			if row.Winner == "AWAY_TEAM" {
+				winner = row.AwayExternalID
 			}
-		case "AWAY_TEAM":
-			if rowAwayID == homeExternalID {
+			switch winner {
+			case homeExternalID:
 				record.HomeWins++
-			} else if rowAwayID == awayExternalID {
+				competition.HomeWins++
+				if f.Weighting != nil {
+					record.Weighted.HomeWins += weight
+					competition.Weighted.HomeWins += weight
+				}
+			case awayExternalID:
 				record.AwayWins++
+				competition.AwayWins++
+				if f.Weighting != nil {
+					record.Weighted.AwayWins += weight
+					competition.Weighted.AwayWins += weight
+				}
 			}
 		}
 
 		record.Matches = append(record.Matches, HeadToHeadMatch{
-			Season:             season,
-			HomeTeamExternalID: rowHomeExternalID,
-			AwayTeamExternalID: rowAwayID,
-			HomeScore:          homeScore,
-			AwayScore:          awayScore,
-			Winner:             winner,
+			Season:             row.Season,
+			UtcDate:            row.UtcDate,
+			CompetitionCode:    row.CompetitionCode,
+			HomeTeamExternalID: row.HomeExternalID,
+			AwayTeamExternalID: row.AwayExternalID,
+			HomeScore:          derefInt(row.HomeScore),
+			AwayScore:          derefInt(row.AwayScore),
+			Winner:             row.Winner,
 		})
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("head-to-head rows error: %w", err)
+	if record.Weighted != nil {
+		record.Weighted.round()
 	}
+	record.Competitions = make([]CompetitionHeadToHead, 0, len(byCompetition))
+	for _, competition := range byCompetition {
+		if competition.Weighted != nil {
+			competition.Weighted.round()
+		}
+		record.Competitions = append(record.Competitions, *competition)
+	}
+	sort.Slice(record.Competitions, func(i, j int) bool {
+		a, b := record.Competitions[i], record.Competitions[j]
+		if a.Matches != b.Matches {
+			return a.Matches > b.Matches
+		}
+		return a.CompetitionCode < b.CompetitionCode
+	})
+	return record, nil
+}
 
-	// If no matches found, return nil so callers can decide what to do.
-	if len(record.Matches) == 0 {
+// GetSummaryByID returns a typed summary of a match by internal ID, or nil if
+// it does not exist.
+func (r *MatchRepository) GetSummaryByID(matchID int) (*MatchSummary, error) {
+	row, err := r.q.GetMatchSummaryByID(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch match summary: %w", err)
+	}
+	m := toMatchSummary(row)
+	return &m, nil
+}
+
+// GetSummaryByExternalID returns a typed summary of a match by its
+// football-data.org ID, or nil if it does not exist.
+func (r *MatchRepository) GetSummaryByExternalID(externalID int) (*MatchSummary, error) {
+	row, err := r.q.GetMatchSummaryByExternalID(context.Background(), externalID)
+	if err == sql.ErrNoRows {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch match summary: %w", err)
+	}
+	m := toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row))
+	return &m, nil
+}
 
-	return record, nil
+// seasonYearCondition matches rows whose season started in the given year.
+// The matches table stores the provider's season ID, so the year is derived
+// from the earliest kickoff recorded for that season. The sqlcdb queries
+// inline the same condition; this form is for hand-built queries.
+func seasonYearCondition(placeholder string) string {
+	return "m.season IN (SELECT season FROM matches GROUP BY season HAVING EXTRACT(YEAR FROM MIN(utc_date)) = " + placeholder + ")"
+}
+
+// TeamMatchFilter narrows the matches returned by ListTeamMatches.
+type TeamMatchFilter struct {
+	TeamID          int
+	Venue           string // "home", "away" or "" for both
+	Season          string // season start year, e.g. "2024"
+	CompetitionCode string
+	Status          string
+	CompetitionType string // "LEAGUE", "CUP" or "" for both
+	TeamType        string // "CLUB", "NATIONAL" or "" for both
+	OldestFirst     bool   // soonest first, for upcoming fixtures
+	Limit           int
+}
+
+// ListTeamMatches returns a team's stored matches, most recent first unless
+// OldestFirst is set.
+func (r *MatchRepository) ListTeamMatches(f TeamMatchFilter) ([]MatchSummary, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := r.q.ListTeamMatches(context.Background(), sqlcdb.ListTeamMatchesParams{
+		Venue:           f.Venue,
+		TeamID:          f.TeamID,
+		Season:          f.Season,
+		CompetitionCode: f.CompetitionCode,
+		Status:          f.Status,
+		CompetitionType: f.CompetitionType,
+		TeamType:        f.TeamType,
+		OldestFirst:     f.OldestFirst,
+		RowLimit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team matches: %w", err)
+	}
+
+	var matches []MatchSummary
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+
+	return matches, nil
+}
+
+// TableRow is a league table line derived from stored results.
+type TableRow struct {
+	Position       int    `json:"position"`
+	TeamID         int    `json:"teamId"`
+	TeamExternalID int    `json:"teamExternalId"`
+	TeamName       string `json:"teamName"`
+	Played         int    `json:"playedGames"`
+	Won            int    `json:"won"`
+	Draw           int    `json:"draw"`
+	Lost           int    `json:"lost"`
+	GoalsFor       int    `json:"goalsFor"`
+	GoalsAgainst   int    `json:"goalsAgainst"`
+	GoalDifference int    `json:"goalDifference"`
+	Points         int    `json:"points"`
+}
+
+// GetResultsTable aggregates finished matches of a competition season into a
+// table ordered by points, goal difference and goals scored.
+func (r *MatchRepository) GetResultsTable(competitionCode, season string) ([]TableRow, error) {
+	rows, err := r.q.GetResultsTable(context.Background(), sqlcdb.GetResultsTableParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results table: %w", err)
+	}
+
+	return rankTable(rows), nil
+}
+
+// rankTable converts aggregated results into table rows ordered by points,
+// goal difference and goals scored, and assigns positions.
+func rankTable(rows []sqlcdb.GetResultsTableRow) []TableRow {
+	var table []TableRow
+	for _, row := range rows {
+		table = append(table, TableRow{
+			TeamID:         row.TeamID,
+			TeamExternalID: row.TeamExternalID,
+			TeamName:       row.TeamName,
+			Played:         row.Played,
+			Won:            row.Won,
+			Draw:           row.Draw,
+			Lost:           row.Lost,
+			GoalsFor:       row.GoalsFor,
+			GoalsAgainst:   row.GoalsAgainst,
+			GoalDifference: row.GoalsFor - row.GoalsAgainst,
+			Points:         row.Won*3 + row.Draw,
+		})
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points > table[j].Points
+		}
+		if table[i].GoalDifference != table[j].GoalDifference {
+			return table[i].GoalDifference > table[j].GoalDifference
+		}
+		if table[i].GoalsFor != table[j].GoalsFor {
+			return table[i].GoalsFor > table[j].GoalsFor
+		}
+		return table[i].TeamName < table[j].TeamName
+	})
+
+	for i := range table {
+		table[i].Position = i + 1
+	}
+
+	return table
+}
+
+// ListFinishedSince returns matches that finished with a score and kicked off
+// on or after since, oldest first.
+func (r *MatchRepository) ListFinishedSince(since time.Time, limit int) ([]MatchSummary, error) {
+	rows, err := r.q.ListFinishedMatchesSince(context.Background(), sqlcdb.ListFinishedMatchesSinceParams{
+		Since:    since,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finished matches: %w", err)
+	}
+
+	var matches []MatchSummary
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+
+	return matches, nil
+}
+
+// FeedFilter selects matches involving followed teams or competitions.
+type FeedFilter struct {
+	TeamIDs        []int
+	CompetitionIDs []int
+	From           time.Time
+	To             time.Time
+	Statuses       []string
+	Descending     bool
+	Limit          int
+}
+
+// ListForFeed returns matches in [From, To) that involve any of the given
+// teams or belong to any of the given competitions.
+func (r *MatchRepository) ListForFeed(f FeedFilter) ([]MatchSummary, error) {
+	rows, err := r.q.ListFeedMatches(context.Background(), sqlcdb.ListFeedMatchesParams{
+		TeamIds:        f.TeamIDs,
+		CompetitionIds: f.CompetitionIDs,
+		FromDate:       f.From,
+		ToDate:         f.To,
+		Statuses:       f.Statuses,
+		Descending:     f.Descending,
+		RowLimit:       f.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feed matches: %w", err)
+	}
+
+	matches := []MatchSummary{}
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+
+	return matches, nil
 }
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// MatchDetail is the composite match document served by the match endpoint.
+// Goals, lineups, player stats and the prediction are loaded separately and
+// left empty unless requested.
+type MatchDetail struct {
+	ID              int               `json:"id"`
+	ExternalID      int               `json:"externalId"`
+	CompetitionCode string            `json:"competitionCode"`
+	Season          string            `json:"season"`
+	Matchday        int               `json:"matchday"`
+	Stage           string            `json:"stage"`
+	Group           string            `json:"group,omitempty"`
+	UtcDate         time.Time         `json:"utcDate"`
+	Status          string            `json:"status"`
+	HomeTeam        MatchTeam         `json:"homeTeam"`
+	AwayTeam        MatchTeam         `json:"awayTeam"`
+	Score           MatchScore        `json:"score"`
+	Referee         string            `json:"referee"`
+	Venue           string            `json:"venue"`
+	Goals           []MatchGoal       `json:"goals"`
+	Lineups         []TeamLineup      `json:"lineups"`
+	PlayerStats     []PlayerMatchLine `json:"playerStats"`
+	Prediction      *PredictionRecord `json:"prediction"`
+	// TravelDistanceKm is how far the away team travelled from its home
+	// venue, nil until both venues have coordinates.
+	TravelDistanceKm *float64 `json:"travelDistanceKm"`
+}
+
+// MatchTeam identifies one side of a match.
+type MatchTeam struct {
+	ID         int    `json:"id"`
+	ExternalID int    `json:"externalId"`
+	Name       string `json:"name"`
+	ShortName  string `json:"shortName"`
+	TLA        string `json:"tla"`
+	Crest      string `json:"crest"`
+}
+
+// MatchScore is the result. Home and Away count goals in play, extra time
+// included; ExtraTime is the goals of extra time alone and Penalties the
+// shootout. Winner is HOME_TEAM, AWAY_TEAM, DRAW or empty while the match
+// has not finished, and after a shootout is the side that won it. DecidedBy
+// is REGULAR, EXTRA_TIME or PENALTY_SHOOTOUT once the match has finished.
+type MatchScore struct {
+	Home      *int      `json:"home"`
+	Away      *int      `json:"away"`
+	HalfTime  ScorePair `json:"halfTime"`
+	ExtraTime ScorePair `json:"extraTime"`
+	Penalties ScorePair `json:"penalties"`
+	Winner    string    `json:"winner"`
+	Duration  string    `json:"duration"`
+	DecidedBy string    `json:"decidedBy"`
+}
+
+// ScorePair is one part of a score, nil when it is unknown or did not happen.
+type ScorePair struct {
+	Home *int `json:"home"`
+	Away *int `json:"away"`
+}
+
+// MatchGoal is one entry of the goals timeline. Type is GOAL, OWN_GOAL or
+// PENALTY; TeamExternalID is the team credited with the goal.
+type MatchGoal struct {
+	Minute         int    `json:"minute"`
+	InjuryTime     *int   `json:"injuryTime,omitempty"`
+	Type           string `json:"type"`
+	TeamExternalID int    `json:"teamExternalId"`
+	Scorer         string `json:"scorer"`
+	Assist         string `json:"assist,omitempty"`
+}
+
+// TeamLineup is a team's formation and the players it fielded.
+type TeamLineup struct {
+	TeamExternalID int           `json:"teamExternalId"`
+	IsHome         bool          `json:"isHome"`
+	Formation      string        `json:"formation"`
+	Players        []LineupEntry `json:"players"`
+}
+
+// LineupEntry is a player in a lineup. Role is starter or substitute.
+type LineupEntry struct {
+	Name          string `json:"name"`
+	Role          string `json:"role"`
+	Position      string `json:"position"`
+	ShirtNumber   *int   `json:"shirtNumber"`
+	MinutesPlayed *int   `json:"minutesPlayed"`
+}
+
+// PlayerMatchLine is a player's stored statistics for one match.
+type PlayerMatchLine struct {
+	Name           string   `json:"name"`
+	TeamExternalID int      `json:"teamExternalId"`
+	Goals          int      `json:"goals"` // includes penalties
+	Assists        int      `json:"assists"`
+	Penalties      int      `json:"penalties"`
+	OwnGoals       int      `json:"ownGoals"`
+	Shots          int      `json:"shots"`
+	ShotsOnTarget  *int     `json:"shotsOnTarget"`
+	KeyPasses      int      `json:"keyPasses"`
+	Passes         *int     `json:"passes"`
+	PassesAccurate *int     `json:"passesAccurate"`
+	Duels          *int     `json:"duels"`
+	DuelsWon       *int     `json:"duelsWon"`
+	Tackles        int      `json:"tackles"`
+	Interceptions  int      `json:"interceptions"`
+	Rating         *float64 `json:"rating"`
+	MinutesPlayed  *int     `json:"minutesPlayed"`
+}
+
+// GetDetailByID returns the stored core of a match (teams, score, referee and
+// venue) by internal ID, or nil if it does not exist.
+func (r *MatchRepository) GetDetailByID(matchID int) (*MatchDetail, error) {
+	row, err := r.q.GetMatchDetailByID(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch match detail: %w", err)
+	}
+	return toMatchDetail(row), nil
+}
+
+// GetDetailByExternalID is GetDetailByID keyed by the football-data.org ID.
+func (r *MatchRepository) GetDetailByExternalID(externalID int) (*MatchDetail, error) {
+	row, err := r.q.GetMatchDetailByExternalID(context.Background(), externalID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch match detail: %w", err)
+	}
+	return toMatchDetail(sqlcdb.GetMatchDetailByIDRow(row)), nil
+}
+
+func toMatchDetail(row sqlcdb.GetMatchDetailByIDRow) *MatchDetail {
+	return &MatchDetail{
+		ID:              row.ID,
+		ExternalID:      row.ExternalID,
+		CompetitionCode: row.CompetitionCode,
+		Season:          row.Season,
+		Matchday:        row.Matchday,
+		Stage:           row.Stage,
+		Group:           row.GroupName,
+		UtcDate:         row.UtcDate,
+		Status:          row.Status,
+		HomeTeam: MatchTeam{
+			ID:         row.HomeTeamID,
+			ExternalID: row.HomeTeamExternalID,
+			Name:       row.HomeTeamName,
+			ShortName:  row.HomeTeamShortName,
+			TLA:        row.HomeTeamTla,
+			Crest:      row.HomeTeamCrest,
+		},
+		AwayTeam: MatchTeam{
+			ID:         row.AwayTeamID,
+			ExternalID: row.AwayTeamExternalID,
+			Name:       row.AwayTeamName,
+			ShortName:  row.AwayTeamShortName,
+			TLA:        row.AwayTeamTla,
+			Crest:      row.AwayTeamCrest,
+		},
+		Score: MatchScore{
+			Home:      row.HomeScore,
+			Away:      row.AwayScore,
+			HalfTime:  ScorePair{Home: row.HalfTimeHomeScore, Away: row.HalfTimeAwayScore},
+			ExtraTime: ScorePair{Home: row.ExtraTimeHomeScore, Away: row.ExtraTimeAwayScore},
+			Penalties: ScorePair{Home: row.PenaltiesHomeScore, Away: row.PenaltiesAwayScore},
+			Winner:    row.Winner,
+			Duration:  row.Duration,
+			DecidedBy: row.DecidedBy,
+		},
+		Referee:          row.Referee,
+		Venue:            row.Venue,
+		TravelDistanceKm: row.TravelDistanceKm,
+	}
+}
+
+// ListGoals returns the stored goals timeline of a match.
+func (r *MatchRepository) ListGoals(matchID int) ([]MatchGoal, error) {
+	rows, err := r.q.ListMatchGoals(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match goals: %w", err)
+	}
+
+	goals := []MatchGoal{}
+	for _, row := range rows {
+		goals = append(goals, MatchGoal{
+			Minute:         row.Minute,
+			InjuryTime:     row.InjuryTime,
+			Type:           row.EventType,
+			TeamExternalID: row.TeamExternalID,
+			Scorer:         row.Scorer,
+		})
+	}
+	return goals, nil
+}
+
+// TimelineEvent is a stored goal, card or substitution. TeamExternalID is
+// the team it counts for: the credited team for a goal, the side making a
+// substitution. For a substitution Player came on and RelatedPlayer went off.
+type TimelineEvent struct {
+	Minute         int
+	InjuryTime     *int
+	Type           string
+	TeamExternalID int
+	IsHome         bool
+	Player         string
+	RelatedPlayer  string
+}
+
+// ListTimelineEvents returns every stored event of a match in match order.
+func (r *MatchRepository) ListTimelineEvents(matchID int) ([]TimelineEvent, error) {
+	rows, err := r.q.ListMatchTimelineEvents(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match events: %w", err)
+	}
+
+	events := make([]TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, TimelineEvent{
+			Minute:         row.Minute,
+			InjuryTime:     row.InjuryTime,
+			Type:           row.EventType,
+			TeamExternalID: row.TeamExternalID,
+			IsHome:         row.IsHome,
+			Player:         row.Player,
+			RelatedPlayer:  row.RelatedPlayer,
+		})
+	}
+	return events, nil
+}
+
+// ListLineups returns the stored lineups of a match, home side first.
+func (r *MatchRepository) ListLineups(matchID int) ([]TeamLineup, error) {
+	rows, err := r.q.ListMatchLineupPlayers(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match lineups: %w", err)
+	}
+
+	lineups := []TeamLineup{}
+	for _, row := range rows {
+		if len(lineups) == 0 || lineups[len(lineups)-1].TeamExternalID != row.TeamExternalID {
+			lineups = append(lineups, TeamLineup{
+				TeamExternalID: row.TeamExternalID,
+				IsHome:         row.IsHome,
+				Formation:      row.Formation,
+			})
+		}
+		l := &lineups[len(lineups)-1]
+		l.Players = append(l.Players, LineupEntry{
+			Name:          row.Name,
+			Role:          row.Role,
+			Position:      row.Position,
+			ShirtNumber:   row.ShirtNumber,
+			MinutesPlayed: row.MinutesPlayed,
+		})
+	}
+	return lineups, nil
+}
+
+// ListPlayerStats returns every stored player stat line for a match.
+func (r *MatchRepository) ListPlayerStats(matchID int) ([]PlayerMatchLine, error) {
+	rows, err := r.q.ListMatchPlayerStats(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match player stats: %w", err)
+	}
+
+	lines := []PlayerMatchLine{}
+	for _, row := range rows {
+		lines = append(lines, PlayerMatchLine(row))
+	}
+	return lines, nil
+}
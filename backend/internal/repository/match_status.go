@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Kinds of match status changes.
+const (
+	StatusChangeStatus      = "status"
+	StatusChangePostponed   = "postponed"
+	StatusChangeCancelled   = "cancelled"
+	StatusChangeReinstated  = "reinstated"
+	StatusChangeRescheduled = "rescheduled"
+)
+
+// StatusChange is a change of a match's status or kickoff, as recorded when
+// it was stored.
+type StatusChange struct {
+	ID          int64      `json:"id"`
+	Kind        string     `json:"kind"`
+	FromStatus  string     `json:"fromStatus"`
+	ToStatus    string     `json:"toStatus"`
+	FromUtcDate time.Time  `json:"fromUtcDate"`
+	ToUtcDate   time.Time  `json:"toUtcDate"`
+	ChangedAt   time.Time  `json:"changedAt"`
+	NotifiedAt  *time.Time `json:"notifiedAt,omitempty"`
+}
+
+// ScheduleChange is a postponement, cancellation, reinstatement or
+// reschedule of a match, with what an announcement of it needs.
+type ScheduleChange struct {
+	ID              int64     `json:"id"`
+	MatchID         int       `json:"matchId"`
+	ExternalID      int       `json:"externalId"`
+	CompetitionCode string    `json:"competitionCode"`
+	HomeTeamName    string    `json:"homeTeamName"`
+	AwayTeamName    string    `json:"awayTeamName"`
+	Kind            string    `json:"kind"`
+	FromStatus      string    `json:"fromStatus"`
+	ToStatus        string    `json:"toStatus"`
+	FromUtcDate     time.Time `json:"fromUtcDate"`
+	ToUtcDate       time.Time `json:"toUtcDate"`
+	ChangedAt       time.Time `json:"changedAt"`
+}
+
+// MatchStatusRepository reads the status and kickoff changes recorded in
+// match_status_history.
+type MatchStatusRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewMatchStatusRepository(db *sql.DB) *MatchStatusRepository {
+	return &MatchStatusRepository{q: sqlcdb.New(db)}
+}
+
+// History returns every change of an internal match ID, oldest first.
+func (r *MatchStatusRepository) History(matchID int) ([]StatusChange, error) {
+	rows, err := r.q.ListMatchStatusHistory(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list match status history: %w", err)
+	}
+
+	changes := make([]StatusChange, 0, len(rows))
+	for _, row := range rows {
+		changes = append(changes, StatusChange{
+			ID:          row.ID,
+			Kind:        row.Kind,
+			FromStatus:  row.FromStatus,
+			ToStatus:    row.ToStatus,
+			FromUtcDate: row.FromUtcDate,
+			ToUtcDate:   row.ToUtcDate,
+			ChangedAt:   row.ChangedAt,
+			NotifiedAt:  row.NotifiedAt,
+		})
+	}
+	return changes, nil
+}
+
+// PendingScheduleChanges returns up to limit schedule changes not yet
+// announced, oldest first.
+func (r *MatchStatusRepository) PendingScheduleChanges(limit int) ([]ScheduleChange, error) {
+	rows, err := r.q.ListPendingScheduleChanges(context.Background(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule changes: %w", err)
+	}
+
+	changes := make([]ScheduleChange, 0, len(rows))
+	for _, row := range rows {
+		changes = append(changes, ScheduleChange(row))
+	}
+	return changes, nil
+}
+
+// MarkNotified records that schedule changes were announced.
+func (r *MatchStatusRepository) MarkNotified(ids []int64) error {
+	if err := r.q.MarkScheduleChangesNotified(context.Background(), ids); err != nil {
+		return fmt.Errorf("failed to mark schedule changes notified: %w", err)
+	}
+	return nil
+}
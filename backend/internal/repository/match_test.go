@@ -0,0 +1,50 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/testutil"
+)
+
+func TestGetResultsTable(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFactory(t, db)
+
+	pl := f.Competition("PL")
+	cl := f.Competition("CL")
+	arsenal := f.Team("Arsenal")
+	chelsea := f.Team("Chelsea")
+	everton := f.Team("Everton")
+
+	kickoff := time.Date(2024, 8, 17, 14, 0, 0, 0, time.UTC)
+	f.Result(pl, arsenal, chelsea, 2, 0, kickoff)
+	f.Result(pl, chelsea, everton, 1, 1, kickoff.AddDate(0, 0, 7))
+	f.Result(pl, everton, arsenal, 0, 3, kickoff.AddDate(0, 0, 14))
+	// Neither another competition, a fixture still to play nor another
+	// season counts
+	f.Result(cl, chelsea, arsenal, 4, 0, kickoff.AddDate(0, 0, 21))
+	f.Match(testutil.Match{CompetitionID: pl, HomeTeamID: arsenal, AwayTeamID: everton, UTCDate: kickoff.AddDate(0, 0, 28)})
+	f.Result(pl, everton, chelsea, 5, 0, kickoff.AddDate(-1, 0, 0))
+
+	table, err := repository.NewMatchRepository(db).GetResultsTable("PL", "2024")
+	if err != nil {
+		t.Fatalf("GetResultsTable: %v", err)
+	}
+
+	want := []repository.TableRow{
+		{Position: 1, TeamID: arsenal, TeamName: "Arsenal", Played: 2, Won: 2, GoalsFor: 5, GoalDifference: 5, Points: 6},
+		{Position: 2, TeamID: chelsea, TeamName: "Chelsea", Played: 2, Draw: 1, Lost: 1, GoalsFor: 1, GoalsAgainst: 3, GoalDifference: -2, Points: 1},
+		{Position: 3, TeamID: everton, TeamName: "Everton", Played: 2, Draw: 1, Lost: 1, GoalsFor: 1, GoalsAgainst: 4, GoalDifference: -3, Points: 1},
+	}
+	if len(table) != len(want) {
+		t.Fatalf("table has %d rows, want %d: %+v", len(table), len(want), table)
+	}
+	for i, row := range table {
+		row.TeamExternalID = 0
+		if row != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, row, want[i])
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// CompetitionSeason is a stored season of a competition: the provider's
+// season ID and the year it started.
+type CompetitionSeason struct {
+	Season string
+	Year   int
+}
+
+// MatchdayRange is the span of matchdays stored for a competition season.
+type MatchdayRange struct {
+	First   int `json:"first"`
+	Last    int `json:"last"`
+	Current int `json:"current"`
+}
+
+// ResolveSeason maps a season start year to the stored season of a
+// competition, or nil if none is stored. An empty year means the most recent
+// season.
+func (r *MatchRepository) ResolveSeason(competitionCode, year string) (*CompetitionSeason, error) {
+	row, err := r.q.ResolveCompetitionSeason(context.Background(), sqlcdb.ResolveCompetitionSeasonParams{
+		CompetitionCode: competitionCode,
+		SeasonYear:      year,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve season: %w", err)
+	}
+	return &CompetitionSeason{Season: row.Season, Year: row.SeasonYear}, nil
+}
+
+// GetMatchdayRange returns the first, last and current matchday of a stored
+// competition season.
+func (r *MatchRepository) GetMatchdayRange(competitionCode, season string) (*MatchdayRange, error) {
+	row, err := r.q.GetMatchdayRange(context.Background(), sqlcdb.GetMatchdayRangeParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matchday range: %w", err)
+	}
+	return &MatchdayRange{First: row.FirstMatchday, Last: row.LastMatchday, Current: row.CurrentMatchday}, nil
+}
+
+// ListMatchday returns the fixtures and results of one round, in kickoff
+// order.
+func (r *MatchRepository) ListMatchday(competitionCode, season string, matchday int) ([]MatchSummary, error) {
+	rows, err := r.q.ListMatchdayMatches(context.Background(), sqlcdb.ListMatchdayMatchesParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+		Matchday:        matchday,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matchday: %w", err)
+	}
+
+	matches := []MatchSummary{}
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+	return matches, nil
+}
+
+// KnockoutMatch is a stored match of a knockout round with its shootout
+// score. TieID is the two-legged tie it is a leg of, if any.
+type KnockoutMatch struct {
+	MatchSummary
+	Stage     string    `json:"stage"`
+	Penalties ScorePair `json:"penalties"`
+	TieID     *int      `json:"tieId,omitempty"`
+}
+
+// ListKnockoutMatches returns the matches of a stored season played in the
+// given stages, in kickoff order.
+func (r *MatchRepository) ListKnockoutMatches(competitionCode, season string, stages []string) ([]KnockoutMatch, error) {
+	rows, err := r.q.ListKnockoutMatches(context.Background(), sqlcdb.ListKnockoutMatchesParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+		Stages:          stages,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query knockout matches: %w", err)
+	}
+
+	matches := make([]KnockoutMatch, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, knockoutMatch(row))
+	}
+	return matches, nil
+}
+
+// ListTieLegs returns the legs of a two-legged tie, first leg first, or none
+// if the tie does not exist.
+func (r *MatchRepository) ListTieLegs(tieID int) ([]KnockoutMatch, error) {
+	rows, err := r.q.ListTieLegs(context.Background(), tieID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tie legs: %w", err)
+	}
+
+	legs := make([]KnockoutMatch, 0, len(rows))
+	for _, row := range rows {
+		legs = append(legs, knockoutMatch(sqlcdb.ListKnockoutMatchesRow(row)))
+	}
+	return legs, nil
+}
+
+func knockoutMatch(row sqlcdb.ListKnockoutMatchesRow) KnockoutMatch {
+	return KnockoutMatch{
+		MatchSummary: MatchSummary{
+			ID:                 row.ID,
+			ExternalID:         row.ExternalID,
+			CompetitionCode:    row.CompetitionCode,
+			Season:             row.Season,
+			Matchday:           row.Matchday,
+			UtcDate:            row.UtcDate,
+			Status:             row.Status,
+			HomeTeamID:         row.HomeTeamID,
+			HomeTeamExternalID: row.HomeTeamExternalID,
+			HomeTeamName:       row.HomeTeamName,
+			AwayTeamID:         row.AwayTeamID,
+			AwayTeamExternalID: row.AwayTeamExternalID,
+			AwayTeamName:       row.AwayTeamName,
+			HomeScore:          row.HomeScore,
+			AwayScore:          row.AwayScore,
+			Winner:             row.Winner,
+			DecidedBy:          row.DecidedBy,
+		},
+		Stage:     row.Stage,
+		Penalties: ScorePair{Home: row.PenaltiesHomeScore, Away: row.PenaltiesAwayScore},
+		TieID:     row.TieID,
+	}
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// NewsArticle is a stored news article. Relevance is how relevant it is to
+// the team it was listed for, and Players are the players it mentions.
+// Teams, the teams it mentions, is only set for signal extraction.
+type NewsArticle struct {
+	ID          int          `json:"id"`
+	Source      string       `json:"source"`
+	URL         string       `json:"url"`
+	Title       string       `json:"title"`
+	Summary     string       `json:"summary,omitempty"`
+	PublishedAt time.Time    `json:"publishedAt"`
+	Relevance   float64      `json:"relevance"`
+	Teams       []NewsTeam   `json:"teams,omitempty"`
+	Players     []NewsPlayer `json:"players"`
+}
+
+// NewsTeam is a team an article mentions.
+type NewsTeam struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Relevance float64 `json:"relevance"`
+}
+
+// NewsPlayer is a player an article mentions. TeamID is 0 for a player
+// without a team.
+type NewsPlayer struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	TeamID    int     `json:"teamId"`
+	Relevance float64 `json:"relevance"`
+}
+
+// TeamSignal is a structured signal about a team, or one of its players,
+// extracted from a news article: an injury doubt, a suspension or pressure
+// on the manager, with the extractor's confidence in it.
+type TeamSignal struct {
+	ID           int       `json:"id"`
+	TeamID       int       `json:"teamId"`
+	PlayerID     *int      `json:"playerId,omitempty"`
+	PlayerName   string    `json:"playerName,omitempty"`
+	Signal       string    `json:"signal"`
+	Confidence   float64   `json:"confidence"`
+	Detail       string    `json:"detail,omitempty"`
+	ArticleID    int       `json:"articleId"`
+	ArticleTitle string    `json:"articleTitle"`
+	ArticleURL   string    `json:"articleUrl"`
+	PublishedAt  time.Time `json:"publishedAt"`
+}
+
+// NewsTag links an article to a team or player, by internal ID.
+type NewsTag struct {
+	ID        int
+	Relevance float64
+}
+
+// NewsRepository provides DB access for news articles and their team and
+// player tags.
+type NewsRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewNewsRepository(db *sql.DB) *NewsRepository {
+	return &NewsRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// Save stores an article with its tags in one transaction. It reports false,
+// storing nothing, if an article with the URL is already stored.
+func (r *NewsRepository) Save(a NewsArticle, teams, players []NewsTag) (bool, error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx)
+	id, err := q.InsertNewsArticle(ctx, sqlcdb.InsertNewsArticleParams{
+		Source:      a.Source,
+		Url:         a.URL,
+		Title:       a.Title,
+		Summary:     nullString(a.Summary),
+		PublishedAt: a.PublishedAt,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert news article: %w", err)
+	}
+
+	for _, t := range teams {
+		if err := q.TagNewsArticleTeam(ctx, sqlcdb.TagNewsArticleTeamParams{
+			ArticleID: id,
+			TeamID:    t.ID,
+			Relevance: t.Relevance,
+		}); err != nil {
+			return false, fmt.Errorf("failed to tag news article with team %d: %w", t.ID, err)
+		}
+	}
+	for _, p := range players {
+		if err := q.TagNewsArticlePlayer(ctx, sqlcdb.TagNewsArticlePlayerParams{
+			ArticleID: id,
+			PlayerID:  p.ID,
+			Relevance: p.Relevance,
+		}); err != nil {
+			return false, fmt.Errorf("failed to tag news article with player %d: %w", p.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit news article: %w", err)
+	}
+	return true, nil
+}
+
+// ListForTeam returns up to limit articles about a team published since a
+// time at minRelevance or above, newest first, with the players they
+// mention.
+func (r *NewsRepository) ListForTeam(teamID int, since time.Time, minRelevance float64, limit int) ([]NewsArticle, error) {
+	rows, err := r.q.ListTeamNews(context.Background(), sqlcdb.ListTeamNewsParams{
+		TeamID:       teamID,
+		MinRelevance: minRelevance,
+		Since:        since,
+		RowLimit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team news: %w", err)
+	}
+
+	articles := make([]NewsArticle, 0, len(rows))
+	ids := make([]int, 0, len(rows))
+	for _, row := range rows {
+		articles = append(articles, NewsArticle{
+			ID:          row.ID,
+			Source:      row.Source,
+			URL:         row.Url,
+			Title:       row.Title,
+			Summary:     row.Summary,
+			PublishedAt: row.PublishedAt,
+			Relevance:   row.Relevance,
+			Players:     []NewsPlayer{},
+		})
+		ids = append(ids, row.ID)
+	}
+	if len(ids) == 0 {
+		return articles, nil
+	}
+
+	players, err := r.q.ListNewsArticlePlayers(context.Background(), ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query news players: %w", err)
+	}
+	byArticle := make(map[int][]NewsPlayer)
+	for _, p := range players {
+		byArticle[p.ArticleID] = append(byArticle[p.ArticleID], NewsPlayer{ID: p.PlayerID, Name: p.Name, TeamID: p.TeamID, Relevance: p.Relevance})
+	}
+	for i := range articles {
+		if p, ok := byArticle[articles[i].ID]; ok {
+			articles[i].Players = p
+		}
+	}
+	return articles, nil
+}
+
+// ListWithoutSignals returns up to limit articles published since a time
+// whose signals have not been extracted, newest first, with the teams and
+// players they mention.
+func (r *NewsRepository) ListWithoutSignals(since time.Time, limit int) ([]NewsArticle, error) {
+	ctx := context.Background()
+	rows, err := r.q.ListArticlesWithoutSignals(ctx, sqlcdb.ListArticlesWithoutSignalsParams{
+		Since:    since,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query articles without signals: %w", err)
+	}
+
+	articles := make([]NewsArticle, 0, len(rows))
+	ids := make([]int, 0, len(rows))
+	for _, row := range rows {
+		articles = append(articles, NewsArticle{
+			ID:          row.ID,
+			Source:      row.Source,
+			URL:         row.Url,
+			Title:       row.Title,
+			Summary:     row.Summary,
+			PublishedAt: row.PublishedAt,
+			Teams:       []NewsTeam{},
+			Players:     []NewsPlayer{},
+		})
+		ids = append(ids, row.ID)
+	}
+	if len(ids) == 0 {
+		return articles, nil
+	}
+
+	teams, err := r.q.ListNewsArticleTeams(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query news teams: %w", err)
+	}
+	players, err := r.q.ListNewsArticlePlayers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query news players: %w", err)
+	}
+	index := make(map[int]int, len(articles))
+	for i, a := range articles {
+		index[a.ID] = i
+	}
+	for _, t := range teams {
+		a := &articles[index[t.ArticleID]]
+		a.Teams = append(a.Teams, NewsTeam{ID: t.TeamID, Name: t.Name, Relevance: t.Relevance})
+	}
+	for _, p := range players {
+		a := &articles[index[p.ArticleID]]
+		a.Players = append(a.Players, NewsPlayer{ID: p.PlayerID, Name: p.Name, TeamID: p.TeamID, Relevance: p.Relevance})
+	}
+	return articles, nil
+}
+
+// SaveSignals stores the signals extracted from an article and marks it
+// extracted in one transaction, so an article without signals is not sent
+// again.
+func (r *NewsRepository) SaveSignals(articleID int, signals []TeamSignal) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx)
+	for _, s := range signals {
+		if err := q.UpsertTeamSignal(ctx, sqlcdb.UpsertTeamSignalParams{
+			ArticleID:  articleID,
+			TeamID:     s.TeamID,
+			PlayerID:   s.PlayerID,
+			Signal:     s.Signal,
+			Confidence: s.Confidence,
+			Detail:     nullString(s.Detail),
+		}); err != nil {
+			return fmt.Errorf("failed to save %s signal for team %d: %w", s.Signal, s.TeamID, err)
+		}
+	}
+	if err := q.MarkSignalsExtracted(ctx, articleID); err != nil {
+		return fmt.Errorf("failed to mark signals extracted: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit team signals: %w", err)
+	}
+	return nil
+}
+
+// ListSignals returns the signals about the given teams from articles
+// published between since and knownAt and extracted by knownAt, at
+// minConfidence or above, newest first.
+func (r *NewsRepository) ListSignals(teamIDs []int, since, knownAt time.Time, minConfidence float64) ([]TeamSignal, error) {
+	rows, err := r.q.ListTeamSignals(context.Background(), sqlcdb.ListTeamSignalsParams{
+		TeamIds:       teamIDs,
+		Since:         since,
+		KnownAt:       knownAt,
+		MinConfidence: minConfidence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team signals: %w", err)
+	}
+
+	signals := make([]TeamSignal, 0, len(rows))
+	for _, row := range rows {
+		signals = append(signals, TeamSignal{
+			ID:           row.ID,
+			TeamID:       row.TeamID,
+			PlayerID:     row.PlayerID,
+			PlayerName:   row.PlayerName,
+			Signal:       row.Signal,
+			Confidence:   row.Confidence,
+			Detail:       row.Detail,
+			ArticleID:    row.ArticleID,
+			ArticleTitle: row.ArticleTitle,
+			ArticleURL:   row.ArticleUrl,
+			PublishedAt:  row.PublishedAt,
+		})
+	}
+	return signals, nil
+}
@@ -1,96 +1,202 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"math"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
 )
 
 // PlayerInsight represents a simple summary of a player's impact in a match.
+// Role is the line they played in, G, D, M or F, and Score their match
+// weighed by it. Goalkeeping and defensive stats are nil when not reported.
 type PlayerInsight struct {
 	Name           string   `json:"name"`
 	Position       string   `json:"position"`
+	Role           string   `json:"role"`
 	TeamExternalID int      `json:"teamExternalId"`
-	Goals          int      `json:"goals"`
+	Goals          int      `json:"goals"` // includes penalties
 	Assists        int      `json:"assists"`
+	Penalties      int      `json:"penalties"`
+	OwnGoals       int      `json:"ownGoals"`
+	MinutesPlayed  *int     `json:"minutesPlayed,omitempty"`
 	Rating         *float64 `json:"rating,omitempty"`
+	Saves          *int     `json:"saves,omitempty"`
+	CleanSheet     *bool    `json:"cleanSheet,omitempty"`
+	Tackles        *int     `json:"tackles,omitempty"`
+	Interceptions  *int     `json:"interceptions,omitempty"`
+	Score          float64  `json:"score"`
 }
 
 // PlayerRepository provides DB access for player-related data.
 type PlayerRepository struct {
-	db *sql.DB
+	q *sqlcdb.Queries
 }
 
 func NewPlayerRepository(db *sql.DB) *PlayerRepository {
-	return &PlayerRepository{db: db}
+	return &PlayerRepository{q: sqlcdb.New(db)}
 }
 
-// GetKeyPlayersForMatch returns top players for a given match external ID.
-// This uses the player_match_stats data if available. If there is no data,
-// it returns an empty slice and no error.
+// GetKeyPlayersForMatch returns top players for a given match external ID,
+// ranked by position-aware score so keepers and defenders count alongside
+// scorers. This uses the player_match_stats data if available. If there is
+// no data, it returns an empty slice and no error.
 func (r *PlayerRepository) GetKeyPlayersForMatch(matchExternalID int, limit int) ([]PlayerInsight, error) {
-	const query = `
-        SELECT
-            p.name,
-            COALESCE(p.position, ''),
-            t.external_id,
-            COALESCE(s.goals, 0) AS goals,
-            COALESCE(s.assists, 0) AS assists,
-            s.rating
-        FROM player_match_stats s
-        JOIN matches m ON m.id = s.match_id
-        JOIN players p ON p.id = s.player_id
-        JOIN teams t ON p.team_id = t.id
-        WHERE m.external_id = $1
-        ORDER BY goals DESC, assists DESC, COALESCE(rating, 0) DESC
-        LIMIT $2
-    `
-
-	rows, err := r.db.Query(query, matchExternalID, limit)
+	rows, err := r.q.ListKeyPlayersForMatch(context.Background(), sqlcdb.ListKeyPlayersForMatchParams{
+		MatchExternalID: matchExternalID,
+		RowLimit:        limit,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query key players: %w", err)
 	}
-	defer rows.Close()
-
-	var result []PlayerInsight
-
-	for rows.Next() {
-		var (
-			name     string
-			position string
-			teamExt  int
-			goals    int
-			assists  int
-			rating   sql.NullFloat64
-		)
-
-		if err := rows.Scan(&name, &position, &teamExt, &goals, &assists, &rating); err != nil {
-			return nil, fmt.Errorf("failed to scan key player: %w", err)
-		}
 
-		var ratingPtr *float64
-		if rating.Valid {
-			v := rating.Float64
-			ratingPtr = &v
-		}
+	// Note: we don't split into home/away here; service layer will do that once
+	// it knows the current match's home and away team external IDs.
+	result := make([]PlayerInsight, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, PlayerInsight(row))
+	}
+	return result, nil
+}
 
-		pi := PlayerInsight{
-			Name:           name,
-			Position:       position,
-			TeamExternalID: teamExt,
-			Goals:          goals,
-			Assists:        assists,
-			Rating:         ratingPtr,
-		}
+// UnavailablePlayer is a player ruled out by injury, suspension or another
+// reason. UnavailableUntil is nil when no return date is known.
+type UnavailablePlayer struct {
+	TeamID           int        `json:"teamId"`
+	PlayerID         *int       `json:"playerId,omitempty"`
+	PlayerName       string     `json:"playerName"`
+	Reason           string     `json:"reason"`
+	Severity         string     `json:"severity,omitempty"`
+	UnavailableFrom  time.Time  `json:"from"`
+	UnavailableUntil *time.Time `json:"until,omitempty"`
+}
+
+// LineupAbsence is a regular starter left out of a team's announced
+// lineup. Starts counts the lineups they started among the team's recent
+// ones.
+type LineupAbsence struct {
+	TeamID     int    `json:"teamId"`
+	PlayerID   int    `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Starts     int    `json:"starts"`
+}
+
+// ScorerFilter narrows the players returned by GetTopScorers.
+type ScorerFilter struct {
+	TeamID          int
+	CompetitionCode string
+	Season          string // season start year, e.g. "2024"
+	// Per90 ranks by goals per 90 minutes instead of total goals. Players
+	// below MinMinutes are left out either way.
+	Per90      bool
+	MinMinutes int
+	Limit      int
+}
 
-		// Caller will decide which team this belongs to based on TeamExternalID.
-		result = append(result, pi)
+// ScorerRow is a player's goal and assist totals across stored matches.
+// Per-90 rates are nil when no minutes are known.
+type ScorerRow struct {
+	PlayerID     int      `json:"playerId"`
+	Name         string   `json:"name"`
+	TeamName     string   `json:"teamName"`
+	Goals        int      `json:"goals"` // includes penalties
+	Penalties    int      `json:"penalties"`
+	Assists      int      `json:"assists"`
+	Matches      int      `json:"matches"`
+	Appearances  int      `json:"appearances"`
+	Minutes      int      `json:"minutes"`
+	GoalsPer90   *float64 `json:"goalsPer90"`
+	AssistsPer90 *float64 `json:"assistsPer90"`
+}
+
+// GetTopScorers returns players ranked by goals then assists.
+func (r *PlayerRepository) GetTopScorers(f ScorerFilter) ([]ScorerRow, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 10
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("key players rows error: %w", err)
+	rows, err := r.q.ListTopScorers(context.Background(), sqlcdb.ListTopScorersParams{
+		TeamID:          f.TeamID,
+		CompetitionCode: f.CompetitionCode,
+		Season:          f.Season,
+		MinMinutes:      f.MinMinutes,
+		Per90:           f.Per90,
+		RowLimit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top scorers: %w", err)
 	}
 
-	// Note: we don't split into home/away here; service layer will do that once
-	// it knows the current match's home and away team external IDs.
+	var result []ScorerRow
+	for _, row := range rows {
+		s := ScorerRow{
+			PlayerID:    row.PlayerID,
+			Name:        row.Name,
+			TeamName:    row.TeamName,
+			Goals:       row.Goals,
+			Penalties:   row.Penalties,
+			Assists:     row.Assists,
+			Matches:     row.Matches,
+			Appearances: row.Appearances,
+			Minutes:     row.Minutes,
+		}
+		if row.Minutes > 0 {
+			s.GoalsPer90 = per90(row.Goals, row.Minutes)
+			s.AssistsPer90 = per90(row.Assists, row.Minutes)
+		}
+		result = append(result, s)
+	}
 	return result, nil
 }
+
+// ListUnavailable returns the players of the given teams (internal IDs) who
+// are unavailable on the given date, counting only absences recorded by
+// knownAt.
+func (r *PlayerRepository) ListUnavailable(teamIDs []int, on, knownAt time.Time) ([]UnavailablePlayer, error) {
+	rows, err := r.q.ListUnavailablePlayers(context.Background(), sqlcdb.ListUnavailablePlayersParams{
+		TeamIds: teamIDs,
+		OnDate:  on,
+		KnownAt: knownAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unavailable players: %w", err)
+	}
+
+	players := make([]UnavailablePlayer, 0, len(rows))
+	for _, row := range rows {
+		players = append(players, UnavailablePlayer(row))
+	}
+	return players, nil
+}
+
+// ListLineupAbsences returns the regulars left out of the starting lineups
+// announced for a match (internal ID) by knownAt: the players who started
+// at least minStarts of their team's last recent lineups before it. Teams
+// without an announced lineup have none.
+func (r *PlayerRepository) ListLineupAbsences(matchID int, knownAt time.Time, recent, minStarts int) ([]LineupAbsence, error) {
+	rows, err := r.q.ListLineupAbsentRegulars(context.Background(), sqlcdb.ListLineupAbsentRegularsParams{
+		MatchID:   matchID,
+		KnownAt:   knownAt,
+		Recent:    recent,
+		MinStarts: minStarts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lineup absences: %w", err)
+	}
+
+	absences := make([]LineupAbsence, 0, len(rows))
+	for _, row := range rows {
+		absences = append(absences, LineupAbsence(row))
+	}
+	return absences, nil
+}
+
+// per90 returns count per 90 minutes rounded to two decimals.
+func per90(count, minutes int) *float64 {
+	v := math.Round(float64(count)*90/float64(minutes)*100) / 100
+	return &v
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Player ingest statuses. Matches without a state row are pending.
+const (
+	PlayerIngestPending = "pending"
+	PlayerIngestDone    = "done"
+	PlayerIngestFailed  = "failed"
+)
+
+// PlayerIngestFilter narrows the finished matches player ingestion works
+// through.
+type PlayerIngestFilter struct {
+	CompetitionCode string    // empty for all competitions
+	Since           time.Time // zero for all history
+}
+
+// PlayerIngestMatch is a finished match still waiting for player data.
+type PlayerIngestMatch struct {
+	ID              int
+	ExternalID      int
+	UtcDate         time.Time
+	HomeTeamID      int
+	AwayTeamID      int
+	CompetitionCode string
+	Attempts        int
+}
+
+// PlayerIngestRepository tracks which finished matches have had their goals,
+// assists and events ingested.
+type PlayerIngestRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewPlayerIngestRepository(db *sql.DB) *PlayerIngestRepository {
+	return &PlayerIngestRepository{q: sqlcdb.New(db)}
+}
+
+// Backlog returns up to limit matches that have never been ingested or that
+// failed fewer than maxAttempts times, newest first. Failures recorded at or
+// after attemptedBefore are left out so a run does not retry its own
+// failures.
+func (r *PlayerIngestRepository) Backlog(filter PlayerIngestFilter, maxAttempts int, attemptedBefore time.Time, limit int) ([]PlayerIngestMatch, error) {
+	rows, err := r.q.ListPlayerIngestBacklog(context.Background(), sqlcdb.ListPlayerIngestBacklogParams{
+		Since:           filter.Since,
+		CompetitionCode: filter.CompetitionCode,
+		MaxAttempts:     maxAttempts,
+		AttemptedBefore: attemptedBefore,
+		RowLimit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list player ingest backlog: %w", err)
+	}
+
+	var matches []PlayerIngestMatch
+	for _, row := range rows {
+		matches = append(matches, PlayerIngestMatch(row))
+	}
+	return matches, nil
+}
+
+// Counts returns the number of finished matches in each status.
+func (r *PlayerIngestRepository) Counts(filter PlayerIngestFilter) (map[string]int, error) {
+	rows, err := r.q.CountPlayerIngestStates(context.Background(), sqlcdb.CountPlayerIngestStatesParams{
+		Since:           filter.Since,
+		CompetitionCode: filter.CompetitionCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count player ingest states: %w", err)
+	}
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row.Status] = row.Matches
+	}
+	return counts, nil
+}
+
+// MarkDone checkpoints a match whose player data has been stored.
+func (r *PlayerIngestRepository) MarkDone(matchID, goals int) error {
+	err := r.q.MarkPlayerIngestDone(context.Background(), sqlcdb.MarkPlayerIngestDoneParams{
+		MatchID: matchID,
+		Goals:   goals,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update player ingest state: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records an error. The match is retried by later runs until it
+// reaches the attempt limit.
+func (r *PlayerIngestRepository) MarkFailed(matchID int, errMsg string) error {
+	err := r.q.MarkPlayerIngestFailed(context.Background(), sqlcdb.MarkPlayerIngestFailedParams{
+		MatchID:   matchID,
+		LastError: errMsg,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update player ingest state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,538 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// PredictionRecord is a stored prediction_history row.
+type PredictionRecord struct {
+	ID                  int       `json:"id"`
+	MatchID             int       `json:"matchId"`
+	PredictedAt         time.Time `json:"predictedAt"`
+	TeamAName           string    `json:"teamAName"`
+	TeamBName           string    `json:"teamBName"`
+	PredictedTeamAGoals *float64  `json:"predictedTeamAGoals"`
+	PredictedTeamBGoals *float64  `json:"predictedTeamBGoals"`
+	PredictedOutcome    string    `json:"predictedOutcome"`
+	PredictedWinner     string    `json:"predictedWinner"`
+	ConfidenceScore     float64   `json:"confidenceScore"`
+	ActualTeamAGoals    *int      `json:"actualTeamAGoals"`
+	ActualTeamBGoals    *int      `json:"actualTeamBGoals"`
+	ActualWinner        *string   `json:"actualWinner"`
+	PredictionCorrect   *bool     `json:"predictionCorrect"`
+	Insights            []string  `json:"insights"`
+	ModelVersion        string    `json:"modelVersion"`
+}
+
+// PredictionRepository provides DB access for prediction_history.
+type PredictionRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewPredictionRepository(db *sql.DB) *PredictionRepository {
+	return &PredictionRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// GetByMatchID returns the prediction served for an internal match ID, or
+// nil if the match was never predicted.
+func (r *PredictionRepository) GetByMatchID(matchID int) (*PredictionRecord, error) {
+	row, err := r.q.GetPredictionByMatchID(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prediction: %w", err)
+	}
+
+	return toPredictionRecord(sqlcdb.ListPredictionsByMatchIDsRow(row)), nil
+}
+
+func toPredictionRecord(row sqlcdb.ListPredictionsByMatchIDsRow) *PredictionRecord {
+	return &PredictionRecord{
+		ID:                  row.ID,
+		MatchID:             derefInt(row.MatchID),
+		PredictedAt:         derefTime(row.PredictedAt),
+		TeamAName:           row.TeamAName,
+		TeamBName:           row.TeamBName,
+		PredictedTeamAGoals: row.PredictedTeamAGoals,
+		PredictedTeamBGoals: row.PredictedTeamBGoals,
+		PredictedOutcome:    row.PredictedOutcome,
+		PredictedWinner:     row.PredictedWinner,
+		ConfidenceScore:     row.ConfidenceScore,
+		ActualTeamAGoals:    row.ActualTeamAGoals,
+		ActualTeamBGoals:    row.ActualTeamBGoals,
+		ActualWinner:        row.ActualWinner,
+		PredictionCorrect:   row.PredictionCorrect,
+		Insights:            row.InsightsGenerated,
+		ModelVersion:        row.ModelVersion,
+	}
+}
+
+// ListUnsettledFinished returns internal IDs of finished matches whose
+// prediction has not yet been compared with the actual result.
+func (r *PredictionRepository) ListUnsettledFinished(limit int) ([]int, error) {
+	ids, err := r.q.ListUnsettledFinishedPredictions(context.Background(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unsettled predictions: %w", err)
+	}
+	return ids, nil
+}
+
+// Settle fills in the actual result and error metrics for a finished match.
+func (r *PredictionRepository) Settle(matchID int) error {
+	if err := r.q.SettlePrediction(context.Background(), matchID); err != nil {
+		return fmt.Errorf("failed to settle prediction: %w", err)
+	}
+	return nil
+}
+
+// GetByMatchIDs returns served predictions keyed by internal match ID.
+func (r *PredictionRepository) GetByMatchIDs(matchIDs []int) (map[int]*PredictionRecord, error) {
+	result := make(map[int]*PredictionRecord)
+	if len(matchIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.q.ListPredictionsByMatchIDs(context.Background(), matchIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query predictions: %w", err)
+	}
+
+	for _, row := range rows {
+		p := toPredictionRecord(row)
+		result[p.MatchID] = p
+	}
+	return result, nil
+}
+
+// PredictionEntry is a prediction to store for a match. Goals are the
+// expected goals of each side, when the model provides them. Primary marks
+// the prediction served to users; other models' predictions of the same
+// match are shadow predictions kept for comparison.
+type PredictionEntry struct {
+	MatchID            int
+	HomeTeamName       string
+	AwayTeamName       string
+	HomeGoals          *float64
+	AwayGoals          *float64
+	PredictedOutcome   string
+	PredictedWinner    string
+	ConfidenceScore    float64
+	Insights           []string
+	ModelVersion       string
+	Features           json.RawMessage
+	Primary            bool
+	HomeWinProbability *float64
+	DrawProbability    *float64
+	AwayWinProbability *float64
+}
+
+// Save stores a model's prediction for a match as its next version, keeping
+// the earlier ones, and marks the model's latest made before kickoff as its
+// final prediction. Saving a primary prediction demotes the other models'.
+func (r *PredictionRepository) Save(e PredictionEntry) error {
+	features := e.Features
+	if features == nil {
+		features = json.RawMessage("null")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	err = q.InsertPredictionHistory(ctx, sqlcdb.InsertPredictionHistoryParams{
+		MatchID:             &e.MatchID,
+		TeamAName:           &e.HomeTeamName,
+		TeamBName:           &e.AwayTeamName,
+		PredictedTeamAGoals: e.HomeGoals,
+		PredictedTeamBGoals: e.AwayGoals,
+		PredictedOutcome:    &e.PredictedOutcome,
+		PredictedWinner:     &e.PredictedWinner,
+		ConfidenceScore:     &e.ConfidenceScore,
+		InsightsGenerated:   e.Insights,
+		ModelVersion:        e.ModelVersion,
+		FeaturesUsed:        features,
+		IsPrimary:           e.Primary,
+		HomeWinProbability:  e.HomeWinProbability,
+		DrawProbability:     e.DrawProbability,
+		AwayWinProbability:  e.AwayWinProbability,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save prediction: %w", err)
+	}
+
+	if err := q.MarkFinalPrediction(ctx, sqlcdb.MarkFinalPredictionParams{
+		MatchID:      e.MatchID,
+		ModelVersion: e.ModelVersion,
+	}); err != nil {
+		return fmt.Errorf("failed to mark final prediction: %w", err)
+	}
+
+	if e.Primary {
+		if err := q.SetPrimaryPredictionModel(ctx, sqlcdb.SetPrimaryPredictionModelParams{
+			ModelVersion: e.ModelVersion,
+			MatchID:      e.MatchID,
+		}); err != nil {
+			return fmt.Errorf("failed to demote predictions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit prediction: %w", err)
+	}
+	return nil
+}
+
+// ModelPrediction is one model's stored prediction for a match. Version
+// counts the model's predictions of the match from 1; Final marks the one
+// made last before kickoff, which the accuracy stats count.
+type ModelPrediction struct {
+	PredictionRecord
+	Primary            bool     `json:"primary"`
+	HomeWinProbability *float64 `json:"homeWinProbability"`
+	DrawProbability    *float64 `json:"drawProbability"`
+	AwayWinProbability *float64 `json:"awayWinProbability"`
+	Version            int      `json:"version"`
+	Final              bool     `json:"final"`
+}
+
+// ListForMatch returns every model's latest prediction for an internal
+// match ID, the served one first.
+func (r *PredictionRepository) ListForMatch(matchID int) ([]ModelPrediction, error) {
+	rows, err := r.q.ListPredictionsForMatch(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match predictions: %w", err)
+	}
+
+	predictions := []ModelPrediction{}
+	for _, row := range rows {
+		predictions = append(predictions, toModelPrediction(sqlcdb.ListPredictionVersionsRow(row)))
+	}
+	return predictions, nil
+}
+
+// ListVersions returns every version of every model's prediction for an
+// internal match ID, oldest first.
+func (r *PredictionRepository) ListVersions(matchID int) ([]ModelPrediction, error) {
+	rows, err := r.q.ListPredictionVersions(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prediction versions: %w", err)
+	}
+
+	versions := []ModelPrediction{}
+	for _, row := range rows {
+		versions = append(versions, toModelPrediction(row))
+	}
+	return versions, nil
+}
+
+func toModelPrediction(row sqlcdb.ListPredictionVersionsRow) ModelPrediction {
+	return ModelPrediction{
+		PredictionRecord: *toPredictionRecord(sqlcdb.ListPredictionsByMatchIDsRow{
+			ID:                  row.ID,
+			MatchID:             row.MatchID,
+			PredictedAt:         row.PredictedAt,
+			TeamAName:           row.TeamAName,
+			TeamBName:           row.TeamBName,
+			PredictedTeamAGoals: row.PredictedTeamAGoals,
+			PredictedTeamBGoals: row.PredictedTeamBGoals,
+			PredictedOutcome:    row.PredictedOutcome,
+			PredictedWinner:     row.PredictedWinner,
+			ConfidenceScore:     row.ConfidenceScore,
+			ActualTeamAGoals:    row.ActualTeamAGoals,
+			ActualTeamBGoals:    row.ActualTeamBGoals,
+			ActualWinner:        row.ActualWinner,
+			PredictionCorrect:   row.PredictionCorrect,
+			InsightsGenerated:   row.InsightsGenerated,
+			ModelVersion:        row.ModelVersion,
+		}),
+		Primary:            row.IsPrimary,
+		HomeWinProbability: row.HomeWinProbability,
+		DrawProbability:    row.DrawProbability,
+		AwayWinProbability: row.AwayWinProbability,
+		Version:            row.Version,
+		Final:              row.IsFinal,
+	}
+}
+
+// ModelAccuracy is the settled prediction record of one model version.
+type ModelAccuracy struct {
+	ModelVersion       string  `json:"modelVersion"`
+	TotalPredictions   int     `json:"totalPredictions"`
+	CorrectPredictions int     `json:"correctPredictions"`
+	AvgGoalsErrorA     float64 `json:"avgGoalsErrorA"`
+	AvgGoalsErrorB     float64 `json:"avgGoalsErrorB"`
+	AvgConfidence      float64 `json:"avgConfidence"`
+	AccuracyPercentage float64 `json:"accuracyPercentage"`
+}
+
+// AccuracyBetween returns how many served predictions of matches kicked off
+// from since up to but excluding before were settled, and how many were
+// correct.
+func (r *PredictionRepository) AccuracyBetween(since, before time.Time) (correct, total int, err error) {
+	row, err := r.q.GetPredictionAccuracyBetween(context.Background(), sqlcdb.GetPredictionAccuracyBetweenParams{
+		Since:  since,
+		Before: before,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query prediction accuracy: %w", err)
+	}
+	return row.CorrectPredictions, row.TotalPredictions, nil
+}
+
+// AccuracyByModel returns settled accuracy per model version, busiest first.
+func (r *PredictionRepository) AccuracyByModel() ([]ModelAccuracy, error) {
+	rows, err := r.q.ListPredictionAccuracyByModel(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accuracy by model: %w", err)
+	}
+
+	models := []ModelAccuracy{}
+	for _, row := range rows {
+		m := ModelAccuracy{
+			ModelVersion:       row.ModelVersion,
+			TotalPredictions:   row.TotalPredictions,
+			CorrectPredictions: row.CorrectPredictions,
+			AvgGoalsErrorA:     row.AvgGoalsErrorA,
+			AvgGoalsErrorB:     row.AvgGoalsErrorB,
+			AvgConfidence:      row.AvgConfidence,
+		}
+		if m.TotalPredictions > 0 {
+			m.AccuracyPercentage = float64(m.CorrectPredictions) / float64(m.TotalPredictions) * 100
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// TrainingResult is a finished match used to fit the Go prediction model.
+// The half-time score is nil when it was not stored.
+type TrainingResult struct {
+	HomeTeamID        int
+	AwayTeamID        int
+	HomeScore         int
+	AwayScore         int
+	HalfTimeHomeScore *int
+	HalfTimeAwayScore *int
+	UtcDate           time.Time
+	CompetitionID     *int
+}
+
+// ListTrainingResults returns real finished results since the given time,
+// oldest first.
+func (r *PredictionRepository) ListTrainingResults(since time.Time) ([]TrainingResult, error) {
+	rows, err := r.q.ListModelTrainingResults(context.Background(), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query training results: %w", err)
+	}
+
+	results := make([]TrainingResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, TrainingResult(row))
+	}
+	return results, nil
+}
+
+// ListTrainingResultsBetween returns real finished results kicked off from
+// since up to but excluding before, oldest first: what was known at a point
+// in time.
+func (r *PredictionRepository) ListTrainingResultsBetween(since, before time.Time) ([]TrainingResult, error) {
+	rows, err := r.q.ListTrainingResultsBetween(context.Background(), sqlcdb.ListTrainingResultsBetweenParams{
+		Since:  since,
+		Before: before,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query training results: %w", err)
+	}
+
+	results := make([]TrainingResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, TrainingResult(row))
+	}
+	return results, nil
+}
+
+// ModelHeadToHead compares two model versions on the settled matches both
+// predicted. Brier scores are nil when neither stored probabilities.
+type ModelHeadToHead struct {
+	ModelA       string   `json:"modelA"`
+	ModelB       string   `json:"modelB"`
+	Matches      int      `json:"matches"`
+	ACorrect     int      `json:"aCorrect"`
+	BCorrect     int      `json:"bCorrect"`
+	OnlyACorrect int      `json:"onlyACorrect"`
+	OnlyBCorrect int      `json:"onlyBCorrect"`
+	AGoalsError  *float64 `json:"aGoalsError"`
+	BGoalsError  *float64 `json:"bGoalsError"`
+	ABrier       *float64 `json:"aBrier"`
+	BBrier       *float64 `json:"bBrier"`
+}
+
+// HeadToHead returns every pair of model versions that predicted the same
+// settled matches, most shared matches first. A non-empty modelVersion keeps
+// only the pairs that include it.
+func (r *PredictionRepository) HeadToHead(modelVersion string) ([]ModelHeadToHead, error) {
+	rows, err := r.q.ListModelHeadToHead(context.Background(), modelVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query model head-to-head: %w", err)
+	}
+
+	pairs := []ModelHeadToHead{}
+	for _, row := range rows {
+		pairs = append(pairs, ModelHeadToHead(row))
+	}
+	return pairs, nil
+}
+
+// PredictionInputState summarises the stored data a match prediction
+// depends on. Times are nil when there is nothing stored yet.
+type PredictionInputState struct {
+	LineupPlayers    int
+	LineupsUpdatedAt *time.Time
+	TeamResults      int
+	ResultsUpdatedAt *time.Time
+}
+
+// InputState returns the lineup and results state behind a prediction of an
+// internal match ID between two internal team IDs.
+func (r *PredictionRepository) InputState(ctx context.Context, matchID, homeTeamID, awayTeamID int) (*PredictionInputState, error) {
+	row, err := r.q.GetPredictionInputState(ctx, sqlcdb.GetPredictionInputStateParams{
+		MatchID:    matchID,
+		HomeTeamID: homeTeamID,
+		AwayTeamID: awayTeamID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prediction input state: %w", err)
+	}
+	state := PredictionInputState(row)
+	return &state, nil
+}
+
+// MatchPredictionInputs is everything a prediction of a match reads, as
+// stored in the match_prediction_inputs read model. MatchID is 0 when the
+// match is not stored. HeadToHead is nil when the teams have not met and
+// Matches is left empty.
+type MatchPredictionInputs struct {
+	MatchID            int
+	ExternalID         int
+	Status             string
+	Matchday           int
+	UtcDate            time.Time
+	HomeTeamID         int
+	HomeTeamExternalID int
+	HomeTeamName       string
+	AwayTeamID         int
+	AwayTeamExternalID int
+	AwayTeamName       string
+	HeadToHead         *HeadToHeadRecord
+	HomeKeyPlayers     []PlayerInsight
+	AwayKeyPlayers     []PlayerInsight
+	// State is nil when it has to be read from the base tables.
+	State       *PredictionInputState
+	RefreshedAt time.Time
+}
+
+// MatchInputs returns the read-model row of a match by external ID, or by
+// internal ID when no match has that external ID. It returns nil if the
+// match is not in the read model.
+func (r *PredictionRepository) MatchInputs(ctx context.Context, matchID int) (*MatchPredictionInputs, error) {
+	row, err := r.q.GetMatchPredictionInputs(ctx, matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prediction inputs: %w", err)
+	}
+
+	in := &MatchPredictionInputs{
+		MatchID:            row.MatchID,
+		ExternalID:         row.ExternalID,
+		Status:             row.Status,
+		Matchday:           row.Matchday,
+		UtcDate:            row.UtcDate,
+		HomeTeamID:         row.HomeTeamID,
+		HomeTeamExternalID: row.HomeTeamExternalID,
+		HomeTeamName:       row.HomeTeamName,
+		AwayTeamID:         row.AwayTeamID,
+		AwayTeamExternalID: row.AwayTeamExternalID,
+		AwayTeamName:       row.AwayTeamName,
+		State: &PredictionInputState{
+			LineupPlayers:    row.LineupPlayers,
+			LineupsUpdatedAt: row.LineupsUpdatedAt,
+			TeamResults:      row.TeamResults,
+			ResultsUpdatedAt: row.ResultsUpdatedAt,
+		},
+		RefreshedAt: row.RefreshedAt,
+	}
+	if row.H2hMatches > 0 {
+		in.HeadToHead = &HeadToHeadRecord{
+			HomeWins: row.H2hHomeWins,
+			AwayWins: row.H2hAwayWins,
+			Draws:    row.H2hDraws,
+		}
+	}
+	if err := json.Unmarshal(row.HomeKeyPlayers, &in.HomeKeyPlayers); err != nil {
+		return nil, fmt.Errorf("failed to decode home key players: %w", err)
+	}
+	if err := json.Unmarshal(row.AwayKeyPlayers, &in.AwayKeyPlayers); err != nil {
+		return nil, fmt.Errorf("failed to decode away key players: %w", err)
+	}
+	return in, nil
+}
+
+// NewLineupMatch is an upcoming match whose starting lineups were stored
+// after its latest served prediction.
+type NewLineupMatch struct {
+	MatchID            int       `json:"matchId"`
+	ExternalID         int       `json:"externalId"`
+	Matchday           int       `json:"matchday"`
+	UtcDate            time.Time `json:"utcDate"`
+	HomeTeamID         int       `json:"homeTeamId"`
+	HomeTeamExternalID int       `json:"homeTeamExternalId"`
+	HomeTeamName       string    `json:"homeTeamName"`
+	AwayTeamID         int       `json:"awayTeamId"`
+	AwayTeamExternalID int       `json:"awayTeamExternalId"`
+	AwayTeamName       string    `json:"awayTeamName"`
+	LineupsUpdatedAt   time.Time `json:"lineupsUpdatedAt"`
+}
+
+// ListNewLineupMatches returns up to limit scheduled matches kicking off at
+// or after from and before until whose lineups were stored since they were
+// last predicted, soonest first.
+func (r *PredictionRepository) ListNewLineupMatches(from, until time.Time, limit int) ([]NewLineupMatch, error) {
+	rows, err := r.q.ListNewLineupMatches(context.Background(), sqlcdb.ListNewLineupMatchesParams{
+		FromDate:  from,
+		UntilDate: until,
+		RowLimit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matches with new lineups: %w", err)
+	}
+
+	matches := make([]NewLineupMatch, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, NewLineupMatch(row))
+	}
+	return matches, nil
+}
+
+// RefreshMatchInputs recomputes the match_prediction_inputs read model.
+// Readers keep seeing the previous rows until it completes.
+func (r *PredictionRepository) RefreshMatchInputs() error {
+	if err := r.q.RefreshMatchPredictionInputs(context.Background()); err != nil {
+		return fmt.Errorf("failed to refresh prediction inputs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+// ProviderQuotaRepository stores the request counts of provider API keys.
+// It implements quota.Store.
+type ProviderQuotaRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewProviderQuotaRepository(db *sql.DB) *ProviderQuotaRepository {
+	return &ProviderQuotaRepository{q: sqlcdb.New(db)}
+}
+
+// Add counts n requests in the window starting at start and returns its
+// total across every process.
+func (r *ProviderQuotaRepository) Add(provider, keyID string, period quota.Period, start time.Time, n int) (int, error) {
+	total, err := r.q.AddProviderQuotaUsage(context.Background(), sqlcdb.AddProviderQuotaUsageParams{
+		Provider:    provider,
+		KeyID:       keyID,
+		Period:      string(period),
+		WindowStart: start,
+		Requests:    n,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s requests: %w", provider, err)
+	}
+	return total, nil
+}
+
+// List returns the current window of every provider key and period.
+func (r *ProviderQuotaRepository) List() ([]quota.Count, error) {
+	rows, err := r.q.ListProviderQuotaUsage(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider quota usage: %w", err)
+	}
+
+	counts := make([]quota.Count, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, quota.Count{
+			Provider: row.Provider,
+			KeyID:    row.KeyID,
+			Period:   quota.Period(row.Period),
+			Start:    row.WindowStart,
+			Requests: row.Requests,
+		})
+	}
+	return counts, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+	"github.com/yourusername/football-prediction/pkg/datasource"
+)
+
+// RawResponseRepository stores provider responses gzip-compressed. It
+// implements datasource.ArchiveStore.
+type RawResponseRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewRawResponseRepository(db *sql.DB) *RawResponseRepository {
+	return &RawResponseRepository{q: sqlcdb.New(db)}
+}
+
+// Archive stores a response.
+func (r *RawResponseRepository) Archive(resp datasource.ArchivedResponse) error {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(resp.Body); err != nil {
+		return fmt.Errorf("failed to compress %s: %w", resp.Endpoint, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress %s: %w", resp.Endpoint, err)
+	}
+
+	err := r.q.InsertRawResponse(context.Background(), sqlcdb.InsertRawResponseParams{
+		Source:    resp.Source,
+		Endpoint:  resp.Endpoint,
+		Body:      compressed.Bytes(),
+		SizeBytes: len(resp.Body),
+		FetchedAt: resp.FetchedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", resp.Endpoint, err)
+	}
+	return nil
+}
+
+// ListLatest returns the latest response archived up to asOf for each
+// endpoint starting with prefix, oldest first. An empty source lists every
+// source.
+func (r *RawResponseRepository) ListLatest(source, prefix string, asOf time.Time) ([]datasource.ArchivedResponse, error) {
+	rows, err := r.q.ListLatestRawResponses(context.Background(), sqlcdb.ListLatestRawResponsesParams{
+		Source:   source,
+		Endpoint: prefix,
+		AsOf:     asOf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raw responses: %w", err)
+	}
+
+	responses := make([]datasource.ArchivedResponse, 0, len(rows))
+	for _, row := range rows {
+		body, err := decompress(row.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress raw response %d: %w", row.ID, err)
+		}
+		responses = append(responses, datasource.ArchivedResponse{
+			ID:        row.ID,
+			Source:    row.Source,
+			Endpoint:  row.Endpoint,
+			Body:      body,
+			FetchedAt: row.FetchedAt,
+		})
+	}
+	return responses, nil
+}
+
+func decompress(body []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// KeyMoment is a notable event in a match recap.
+type KeyMoment struct {
+	Minute     int    `json:"minute"`
+	InjuryTime *int   `json:"injuryTime,omitempty"`
+	Type       string `json:"type"`
+	Team       string `json:"team"`
+	Player     string `json:"player"`
+	Assist     string `json:"assist,omitempty"`
+	Score      string `json:"score,omitempty"`
+}
+
+// PredictionReview compares the stored prediction with the final result.
+type PredictionReview struct {
+	PredictedWinner string   `json:"predictedWinner"`
+	ActualWinner    string   `json:"actualWinner"`
+	Correct         bool     `json:"correct"`
+	PredictedScore  string   `json:"predictedScore,omitempty"`
+	ActualScore     string   `json:"actualScore"`
+	ConfidenceScore float64  `json:"confidenceScore"`
+	ModelVersion    string   `json:"modelVersion"`
+	GotRight        []string `json:"gotRight"`
+	GotWrong        []string `json:"gotWrong"`
+}
+
+// MatchRecap is the structured post-match summary stored in match_recaps.
+type MatchRecap struct {
+	MatchID          int               `json:"matchId"`
+	MatchExternalID  int               `json:"matchExternalId"`
+	Headline         string            `json:"headline"`
+	Summary          string            `json:"summary"`
+	KeyMoments       []KeyMoment       `json:"keyMoments"`
+	PlayerOfTheMatch *PlayerInsight    `json:"playerOfTheMatch,omitempty"`
+	PredictionReview *PredictionReview `json:"predictionReview,omitempty"`
+	GeneratedBy      string            `json:"generatedBy"`
+	GeneratedAt      time.Time         `json:"generatedAt"`
+}
+
+// RecapRepository provides DB access for match recaps.
+type RecapRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewRecapRepository(db *sql.DB) *RecapRepository {
+	return &RecapRepository{q: sqlcdb.New(db)}
+}
+
+// Upsert stores the recap for a match, replacing any previous version.
+func (r *RecapRepository) Upsert(recap *MatchRecap) error {
+	keyMoments, err := json.Marshal(recap.KeyMoments)
+	if err != nil {
+		return fmt.Errorf("failed to encode key moments: %w", err)
+	}
+
+	var potm, review []byte
+	if recap.PlayerOfTheMatch != nil {
+		if potm, err = json.Marshal(recap.PlayerOfTheMatch); err != nil {
+			return fmt.Errorf("failed to encode player of the match: %w", err)
+		}
+	}
+	if recap.PredictionReview != nil {
+		if review, err = json.Marshal(recap.PredictionReview); err != nil {
+			return fmt.Errorf("failed to encode prediction review: %w", err)
+		}
+	}
+
+	err = r.q.UpsertMatchRecap(context.Background(), sqlcdb.UpsertMatchRecapParams{
+		MatchID:          recap.MatchID,
+		Headline:         recap.Headline,
+		Summary:          recap.Summary,
+		KeyMoments:       keyMoments,
+		PlayerOfTheMatch: potm,
+		PredictionReview: review,
+		GeneratedBy:      recap.GeneratedBy,
+		GeneratedAt:      &recap.GeneratedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save recap: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMatchExternalID returns the recap for a match identified by its
+// football-data.org ID, or nil if none has been generated yet.
+func (r *RecapRepository) GetByMatchExternalID(externalID int) (*MatchRecap, error) {
+	row, err := r.q.GetMatchRecapByMatchExternalID(context.Background(), externalID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recap: %w", err)
+	}
+
+	recap := MatchRecap{
+		MatchID:         row.MatchID,
+		MatchExternalID: row.MatchExternalID,
+		Headline:        row.Headline,
+		Summary:         row.Summary,
+		GeneratedBy:     row.GeneratedBy,
+		GeneratedAt:     derefTime(row.GeneratedAt),
+	}
+
+	if err := json.Unmarshal(row.KeyMoments, &recap.KeyMoments); err != nil {
+		return nil, fmt.Errorf("failed to decode key moments: %w", err)
+	}
+	if len(row.PlayerOfTheMatch) > 0 {
+		recap.PlayerOfTheMatch = &PlayerInsight{}
+		if err := json.Unmarshal(row.PlayerOfTheMatch, recap.PlayerOfTheMatch); err != nil {
+			return nil, fmt.Errorf("failed to decode player of the match: %w", err)
+		}
+	}
+	if len(row.PredictionReview) > 0 {
+		recap.PredictionReview = &PredictionReview{}
+		if err := json.Unmarshal(row.PredictionReview, recap.PredictionReview); err != nil {
+			return nil, fmt.Errorf("failed to decode prediction review: %w", err)
+		}
+	}
+
+	return &recap, nil
+}
+
+// ListFinishedWithoutRecap returns internal IDs of recently finished matches
+// that do not have a recap yet, oldest first.
+func (r *RecapRepository) ListFinishedWithoutRecap(since time.Time, limit int) ([]int, error) {
+	ids, err := r.q.ListFinishedMatchesWithoutRecap(context.Background(), sqlcdb.ListFinishedMatchesWithoutRecapParams{
+		Since:    since,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches without recap: %w", err)
+	}
+	return ids, nil
+}
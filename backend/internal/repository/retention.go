@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// RetentionRun records one retention policy applied to data older than
+// Cutoff. Error is set when the purge failed.
+type RetentionRun struct {
+	ID           int       `json:"id"`
+	Policy       string    `json:"policy"`
+	Months       int       `json:"months"`
+	Cutoff       time.Time `json:"cutoff"`
+	RowsAffected int       `json:"rowsAffected"`
+	Error        *string   `json:"error,omitempty"`
+	TriggeredBy  string    `json:"triggeredBy"`
+	StartedAt    time.Time `json:"startedAt"`
+	FinishedAt   time.Time `json:"finishedAt"`
+}
+
+// RetentionRepository purges old data and records retention runs.
+type RetentionRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewRetentionRepository(db *sql.DB) *RetentionRepository {
+	return &RetentionRepository{q: sqlcdb.New(db)}
+}
+
+// PurgeIngestFailurePayloads clears the payloads of ingest failures resolved
+// before cutoff and returns how many were cleared.
+func (r *RetentionRepository) PurgeIngestFailurePayloads(cutoff time.Time) (int, error) {
+	n, err := r.q.PurgeIngestFailurePayloads(context.Background(), &cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge ingest failure payloads: %w", err)
+	}
+	return int(n), nil
+}
+
+// PurgePredictionFeatures clears the features stored with predictions made
+// before cutoff and returns how many were cleared.
+func (r *RetentionRepository) PurgePredictionFeatures(cutoff time.Time) (int, error) {
+	n, err := r.q.PurgePredictionFeatures(context.Background(), &cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge prediction features: %w", err)
+	}
+	return int(n), nil
+}
+
+// DeleteFeatureSnapshots deletes feature snapshots taken before cutoff.
+func (r *RetentionRepository) DeleteFeatureSnapshots(cutoff time.Time) (int, error) {
+	n, err := r.q.DeleteFeatureSnapshotsBefore(context.Background(), cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete feature snapshots: %w", err)
+	}
+	return int(n), nil
+}
+
+// DeleteAPIUsage deletes hourly API usage before cutoff.
+func (r *RetentionRepository) DeleteAPIUsage(cutoff time.Time) (int, error) {
+	n, err := r.q.DeleteAPIUsageBefore(context.Background(), cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete api usage: %w", err)
+	}
+	return int(n), nil
+}
+
+// DeleteLLMUsage deletes LLM requests made before cutoff.
+func (r *RetentionRepository) DeleteLLMUsage(cutoff time.Time) (int, error) {
+	n, err := r.q.DeleteLLMUsageBefore(context.Background(), cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete llm usage: %w", err)
+	}
+	return int(n), nil
+}
+
+// DeleteRawResponses deletes provider responses archived before cutoff.
+func (r *RetentionRepository) DeleteRawResponses(cutoff time.Time) (int, error) {
+	n, err := r.q.DeleteRawResponsesBefore(context.Background(), cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete raw responses: %w", err)
+	}
+	return int(n), nil
+}
+
+// RecordRun stores a run and fills in its ID.
+func (r *RetentionRepository) RecordRun(run *RetentionRun) error {
+	id, err := r.q.InsertRetentionRun(context.Background(), sqlcdb.InsertRetentionRunParams{
+		Policy:       run.Policy,
+		Months:       run.Months,
+		Cutoff:       run.Cutoff,
+		RowsAffected: run.RowsAffected,
+		Error:        run.Error,
+		TriggeredBy:  run.TriggeredBy,
+		StartedAt:    run.StartedAt,
+		FinishedAt:   run.FinishedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record retention run: %w", err)
+	}
+	run.ID = id
+	return nil
+}
+
+// ListRuns returns the most recent runs, latest first.
+func (r *RetentionRepository) ListRuns(limit int) ([]RetentionRun, error) {
+	rows, err := r.q.ListRetentionRuns(context.Background(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention runs: %w", err)
+	}
+
+	runs := make([]RetentionRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, RetentionRun(row))
+	}
+	return runs, nil
+}
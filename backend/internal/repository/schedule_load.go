@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// FixtureLoad is an upcoming fixture of a team with the team's schedule
+// leading up to it. PreviousMatchAt is nil before a team's first stored
+// match.
+type FixtureLoad struct {
+	ExternalID         int
+	UtcDate            time.Time
+	CompetitionCode    string
+	Home               bool
+	OpponentExternalID int
+	OpponentName       string
+	PreviousMatchAt    *time.Time
+	MatchesLast14Days  int
+}
+
+// MatchScheduleLoad is both teams' schedule leading up to a match.
+type MatchScheduleLoad struct {
+	HomePreviousMatchAt   *time.Time
+	HomeMatchesLast14Days int
+	AwayPreviousMatchAt   *time.Time
+	AwayMatchesLast14Days int
+	UtcDate               time.Time
+}
+
+// ListTeamScheduleLoad returns up to limit of a team's fixtures scheduled
+// from after, soonest first, with the matches the team plays before each.
+// Fixtures stored in between count towards the later ones, so a run of
+// midweek games shows before it is played.
+func (r *MatchRepository) ListTeamScheduleLoad(teamID int, after time.Time, limit int) ([]FixtureLoad, error) {
+	rows, err := r.q.ListTeamScheduleLoad(context.Background(), sqlcdb.ListTeamScheduleLoadParams{
+		TeamID:      teamID,
+		After:       after,
+		MaxFixtures: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team schedule load: %w", err)
+	}
+
+	fixtures := make([]FixtureLoad, 0, len(rows))
+	for _, row := range rows {
+		fixtures = append(fixtures, FixtureLoad(row))
+	}
+	return fixtures, nil
+}
+
+// GetScheduleLoad returns both teams' schedule leading up to a match, or
+// nil if the match does not exist.
+func (r *MatchRepository) GetScheduleLoad(matchID int) (*MatchScheduleLoad, error) {
+	row, err := r.q.GetMatchScheduleLoad(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match schedule load: %w", err)
+	}
+	load := MatchScheduleLoad(row)
+	return &load, nil
+}
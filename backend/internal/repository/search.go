@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// SearchResult is a team, player or competition matching a search query.
+// Detail is the team's TLA, the player's team or the competition code.
+type SearchResult struct {
+	Type       string  `json:"type"`
+	ID         int     `json:"id"`
+	ExternalID int     `json:"externalId"`
+	Name       string  `json:"name"`
+	Detail     string  `json:"detail"`
+	Score      float64 `json:"score"`
+}
+
+// SearchRepository provides fuzzy name search across entities.
+type SearchRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{q: sqlcdb.New(db)}
+}
+
+// Search returns the best matches for query, highest score first. types
+// limits the result kinds (team, player, competition); empty means all.
+func (r *SearchRepository) Search(query string, types []string, limit int) ([]SearchResult, error) {
+	if types == nil {
+		types = []string{}
+	}
+
+	rows, err := r.q.SearchEntities(context.Background(), sqlcdb.SearchEntitiesParams{
+		Query:    query,
+		Types:    types,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	results := []SearchResult{}
+	for _, row := range rows {
+		results = append(results, SearchResult{
+			Type:       row.Kind,
+			ID:         row.ID,
+			ExternalID: row.ExternalID,
+			Name:       row.Name,
+			Detail:     row.Detail,
+			Score:      row.Score,
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// TeamSetPieces is a team's scoring and penalty taking in a season, from
+// the team_set_piece_stats read model. Missed penalties are only known for
+// matches with API-Football player stats.
+type TeamSetPieces struct {
+	TeamID            int
+	SeasonYear        int
+	Matches           int
+	Goals             int
+	PenaltiesScored   int
+	PenaltiesMissed   int
+	MatchesWithMisses int
+	RefreshedAt       time.Time
+}
+
+// PlayerSetPieces is a player's penalty taking for a team in a season.
+type PlayerSetPieces struct {
+	PlayerID         int
+	PlayerExternalID int
+	PlayerName       string
+	Matches          int
+	Goals            int
+	PenaltiesScored  int
+	PenaltiesMissed  int
+}
+
+// RefreshSetPieces recomputes the team and player set-piece read models.
+func (r *TeamRepository) RefreshSetPieces() error {
+	ctx := context.Background()
+	if err := r.q.RefreshTeamSetPieceStats(ctx); err != nil {
+		return fmt.Errorf("failed to refresh team set-piece stats: %w", err)
+	}
+	if err := r.q.RefreshPlayerSetPieceStats(ctx); err != nil {
+		return fmt.Errorf("failed to refresh player set-piece stats: %w", err)
+	}
+	return nil
+}
+
+// GetSetPieces returns a team's set-piece stats in the season starting in
+// seasonYear, or its latest season when seasonYear is empty, or nil if
+// there are none.
+func (r *TeamRepository) GetSetPieces(teamID int, seasonYear string) (*TeamSetPieces, error) {
+	row, err := r.q.GetTeamSetPieceStats(context.Background(), sqlcdb.GetTeamSetPieceStatsParams{
+		TeamID:     teamID,
+		SeasonYear: seasonYear,
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team set-piece stats: %w", err)
+	}
+	stats := TeamSetPieces(row)
+	return &stats, nil
+}
+
+// ListPenaltyTakers returns the players who took penalties for a team in
+// the season starting in seasonYear, most penalties taken first.
+func (r *TeamRepository) ListPenaltyTakers(teamID, seasonYear int) ([]PlayerSetPieces, error) {
+	rows, err := r.q.ListPlayerSetPieceStats(context.Background(), sqlcdb.ListPlayerSetPieceStatsParams{
+		TeamID:     teamID,
+		SeasonYear: seasonYear,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query penalty takers: %w", err)
+	}
+
+	takers := make([]PlayerSetPieces, 0, len(rows))
+	for _, row := range rows {
+		takers = append(takers, PlayerSetPieces(row))
+	}
+	return takers, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// StandingsRules are the points awarded per result and the tie-break order
+// used when computing a competition's table from stored results.
+type StandingsRules struct {
+	CompetitionCode string   `json:"competitionCode"`
+	PointsWin       int      `json:"pointsWin"`
+	PointsDraw      int      `json:"pointsDraw"`
+	PointsLoss      int      `json:"pointsLoss"`
+	TieBreakers     []string `json:"tieBreakers"`
+}
+
+// StandingsRepository provides DB access for per-competition table rules.
+type StandingsRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewStandingsRepository(db *sql.DB) *StandingsRepository {
+	return &StandingsRepository{q: sqlcdb.New(db)}
+}
+
+// GetRules returns the rules configured for a competition, or nil if it has
+// none.
+func (r *StandingsRepository) GetRules(competitionCode string) (*StandingsRules, error) {
+	row, err := r.q.GetStandingsRules(context.Background(), competitionCode)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standings rules: %w", err)
+	}
+	rules := StandingsRules(row)
+	return &rules, nil
+}
+
+// SaveRules creates or replaces a competition's rules.
+func (r *StandingsRepository) SaveRules(rules StandingsRules) error {
+	if err := r.q.UpsertStandingsRules(context.Background(), sqlcdb.UpsertStandingsRulesParams(rules)); err != nil {
+		return fmt.Errorf("failed to save standings rules: %w", err)
+	}
+	return nil
+}
+
+// ListSeasonResults returns the finished matches of one stored provider
+// season, oldest first.
+func (r *MatchRepository) ListSeasonResults(competitionCode, season string) ([]MatchSummary, error) {
+	rows, err := r.q.ListSeasonResults(context.Background(), sqlcdb.ListSeasonResultsParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season results: %w", err)
+	}
+
+	var matches []MatchSummary
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+	return matches, nil
+}
+
+// ListSeasonFixtures returns the matches of one stored provider season
+// still to be played, cancelled ones aside, by kickoff.
+func (r *MatchRepository) ListSeasonFixtures(competitionCode, season string) ([]MatchSummary, error) {
+	rows, err := r.q.ListSeasonFixtures(context.Background(), sqlcdb.ListSeasonFixturesParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season fixtures: %w", err)
+	}
+
+	var matches []MatchSummary
+	for _, row := range rows {
+		matches = append(matches, toMatchSummary(sqlcdb.GetMatchSummaryByIDRow(row)))
+	}
+	return matches, nil
+}
+
+// GroupMatch is a stored match of a group stage.
+type GroupMatch struct {
+	MatchSummary
+	Group string `json:"group"`
+}
+
+// ListGroupMatches returns every group match of one stored provider season,
+// played or not, by group and kickoff.
+func (r *MatchRepository) ListGroupMatches(competitionCode, season string) ([]GroupMatch, error) {
+	rows, err := r.q.ListGroupMatches(context.Background(), sqlcdb.ListGroupMatchesParams{
+		CompetitionCode: competitionCode,
+		Season:          season,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group matches: %w", err)
+	}
+
+	matches := make([]GroupMatch, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, GroupMatch{
+			MatchSummary: MatchSummary{
+				ID:                 row.ID,
+				ExternalID:         row.ExternalID,
+				CompetitionCode:    row.CompetitionCode,
+				Season:             row.Season,
+				Matchday:           row.Matchday,
+				UtcDate:            row.UtcDate,
+				Status:             row.Status,
+				HomeTeamID:         row.HomeTeamID,
+				HomeTeamExternalID: row.HomeTeamExternalID,
+				HomeTeamName:       row.HomeTeamName,
+				AwayTeamID:         row.AwayTeamID,
+				AwayTeamExternalID: row.AwayTeamExternalID,
+				AwayTeamName:       row.AwayTeamName,
+				HomeScore:          row.HomeScore,
+				AwayScore:          row.AwayScore,
+				Winner:             row.Winner,
+				DecidedBy:          row.DecidedBy,
+			},
+			Group: row.GroupName,
+		})
+	}
+	return matches, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// TeamInfo is a stored team.
+type TeamInfo struct {
+	ID         int    `json:"id"`
+	ExternalID int    `json:"externalId"`
+	Name       string `json:"name"`
+	ShortName  string `json:"shortName"`
+	TLA        string `json:"tla"`
+	CrestURL   string `json:"crest"`
+	TeamType   string `json:"teamType"`
+}
+
+// TeamRepository provides DB access for teams.
+type TeamRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewTeamRepository(db *sql.DB) *TeamRepository {
+	return &TeamRepository{q: sqlcdb.New(db)}
+}
+
+func toTeamInfo(row sqlcdb.GetTeamByIDRow) *TeamInfo {
+	return &TeamInfo{
+		ID:         row.ID,
+		ExternalID: row.ExternalID,
+		Name:       row.Name,
+		ShortName:  row.ShortName,
+		TLA:        row.Tla,
+		CrestURL:   row.CrestUrl,
+		TeamType:   row.TeamType,
+	}
+}
+
+// GetByID returns a team by internal ID, or nil if it does not exist.
+func (r *TeamRepository) GetByID(id int) (*TeamInfo, error) {
+	row, err := r.q.GetTeamByID(context.Background(), id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return toTeamInfo(row), nil
+}
+
+// GetByExternalID returns a team by football-data.org ID, or nil if it does
+// not exist.
+func (r *TeamRepository) GetByExternalID(externalID int) (*TeamInfo, error) {
+	row, err := r.q.GetTeamByExternalID(context.Background(), externalID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return toTeamInfo(sqlcdb.GetTeamByIDRow(row)), nil
+}
+
+// FindByName resolves a free-text team name (full name, short name or TLA)
+// to the best matching stored team, or nil if nothing matches.
+func (r *TeamRepository) FindByName(name string) (*TeamInfo, error) {
+	row, err := r.q.FindTeamByName(context.Background(), name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find team: %w", err)
+	}
+	return toTeamInfo(sqlcdb.GetTeamByIDRow(row)), nil
+}
+
+// TeamCrest is a team's provider crest URL.
+type TeamCrest struct {
+	TeamID   int
+	CrestURL string
+}
+
+// ListCrests returns every team that has a crest URL.
+func (r *TeamRepository) ListCrests() ([]TeamCrest, error) {
+	rows, err := r.q.ListTeamCrests(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team crests: %w", err)
+	}
+
+	crests := make([]TeamCrest, 0, len(rows))
+	for _, row := range rows {
+		crests = append(crests, TeamCrest{TeamID: row.ID, CrestURL: row.CrestUrl})
+	}
+	return crests, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// TeamMatchStats is one team's statistics for a match. Nil values were not
+// reported by the provider.
+type TeamMatchStats struct {
+	TeamID          int      `json:"teamId"`
+	TeamExternalID  int      `json:"teamExternalId"`
+	TeamName        string   `json:"teamName"`
+	Possession      *int     `json:"possession"`
+	ShotsTotal      *int     `json:"shotsTotal"`
+	ShotsOnTarget   *int     `json:"shotsOnTarget"`
+	ShotsOffTarget  *int     `json:"shotsOffTarget"`
+	ShotsBlocked    *int     `json:"shotsBlocked"`
+	ShotsInsideBox  *int     `json:"shotsInsideBox"`
+	ShotsOutsideBox *int     `json:"shotsOutsideBox"`
+	Corners         *int     `json:"corners"`
+	Fouls           *int     `json:"fouls"`
+	Offsides        *int     `json:"offsides"`
+	YellowCards     *int     `json:"yellowCards"`
+	RedCards        *int     `json:"redCards"`
+	Saves           *int     `json:"saves"`
+	PassesTotal     *int     `json:"passesTotal"`
+	PassesAccurate  *int     `json:"passesAccurate"`
+	ExpectedGoals   *float64 `json:"expectedGoals"`
+}
+
+// ListTeamStats returns the stored team statistics of a match, home team
+// first. It is empty until statistics have been ingested.
+func (r *MatchRepository) ListTeamStats(matchID int) ([]TeamMatchStats, error) {
+	rows, err := r.q.ListTeamMatchStatistics(context.Background(), matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team match statistics: %w", err)
+	}
+
+	stats := []TeamMatchStats{}
+	for _, row := range rows {
+		stats = append(stats, TeamMatchStats(row))
+	}
+	return stats, nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// RecordSplit is a team's record over a set of matches.
+type RecordSplit struct {
+	Played         int `json:"played"`
+	Won            int `json:"won"`
+	Draw           int `json:"draw"`
+	Lost           int `json:"lost"`
+	GoalsFor       int `json:"goalsFor"`
+	GoalsAgainst   int `json:"goalsAgainst"`
+	GoalDifference int `json:"goalDifference"`
+	Points         int `json:"points"`
+	CleanSheets    int `json:"cleanSheets"`
+}
+
+func newRecordSplit(played, won, draw, lost, goalsFor, goalsAgainst, cleanSheets int) RecordSplit {
+	return RecordSplit{
+		Played:         played,
+		Won:            won,
+		Draw:           draw,
+		Lost:           lost,
+		GoalsFor:       goalsFor,
+		GoalsAgainst:   goalsAgainst,
+		GoalDifference: goalsFor - goalsAgainst,
+		Points:         won*3 + draw,
+		CleanSheets:    cleanSheets,
+	}
+}
+
+// Add returns the combined record of two splits.
+func (s RecordSplit) Add(o RecordSplit) RecordSplit {
+	return newRecordSplit(s.Played+o.Played, s.Won+o.Won, s.Draw+o.Draw, s.Lost+o.Lost,
+		s.GoalsFor+o.GoalsFor, s.GoalsAgainst+o.GoalsAgainst, s.CleanSheets+o.CleanSheets)
+}
+
+// TeamSeasonRecord is a team's home and away record for a season.
+type TeamSeasonRecord struct {
+	Home RecordSplit
+	Away RecordSplit
+}
+
+// GetTeamSeasonRecord aggregates a team's finished matches in a season.
+// season is the season start year; an empty competitionCode covers all
+// competitions.
+func (r *MatchRepository) GetTeamSeasonRecord(teamID int, season, competitionCode string) (*TeamSeasonRecord, error) {
+	row, err := r.q.GetTeamSeasonStats(context.Background(), sqlcdb.GetTeamSeasonStatsParams{
+		Season:          season,
+		CompetitionCode: competitionCode,
+		TeamID:          teamID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate team season: %w", err)
+	}
+
+	return &TeamSeasonRecord{
+		Home: newRecordSplit(row.HomePlayed, row.HomeWon, row.HomeDraw, row.HomeLost,
+			row.HomeGoalsFor, row.HomeGoalsAgainst, row.HomeCleanSheets),
+		Away: newRecordSplit(row.AwayPlayed, row.AwayWon, row.AwayDraw, row.AwayLost,
+			row.AwayGoalsFor, row.AwayGoalsAgainst, row.AwayCleanSheets),
+	}, nil
+}
+
+// ConcededByHalf totals goals conceded per half over the matches that have
+// recorded events.
+type ConcededByHalf struct {
+	Matches    int
+	FirstHalf  int
+	SecondHalf int
+}
+
+// GetTeamConcededByHalf returns goals conceded per half from match events for
+// a team's finished matches in a season.
+func (r *MatchRepository) GetTeamConcededByHalf(teamID int, season, competitionCode string) (*ConcededByHalf, error) {
+	row, err := r.q.GetTeamConcededByHalf(context.Background(), sqlcdb.GetTeamConcededByHalfParams{
+		Season:          season,
+		CompetitionCode: competitionCode,
+		TeamID:          teamID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate goals conceded by half: %w", err)
+	}
+	c := ConcededByHalf(row)
+	return &c, nil
+}
+
+// HalfTimeFullTimeCount is the number of matches a team had with one
+// half-time and full-time result pair, each W, D or L from its side.
+type HalfTimeFullTimeCount struct {
+	HalfTime string
+	FullTime string
+	Matches  int
+}
+
+// ListTeamHalfTimeFullTime counts a team's finished matches in a season by
+// result at half time and at full time. Matches without a stored half-time
+// score are left out.
+func (r *MatchRepository) ListTeamHalfTimeFullTime(teamID int, season, competitionCode string) ([]HalfTimeFullTimeCount, error) {
+	rows, err := r.q.GetTeamHalfTimeFullTime(context.Background(), sqlcdb.GetTeamHalfTimeFullTimeParams{
+		TeamID:          teamID,
+		Season:          season,
+		CompetitionCode: competitionCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate half-time/full-time results: %w", err)
+	}
+
+	counts := make([]HalfTimeFullTimeCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, HalfTimeFullTimeCount(row))
+	}
+	return counts, nil
+}
+
+// GoalTimingBucket is the goals a team scored and conceded in one
+// 15-minute interval, Bucket 0 being minutes 1-15 and 5 minutes 76-90.
+type GoalTimingBucket struct {
+	Bucket   int
+	Scored   int
+	Conceded int
+}
+
+// ListTeamGoalTimings returns a team's goals scored and conceded per
+// 15-minute interval over its finished matches with recorded events, every
+// interval included, and how many matches those are. Stoppage time counts
+// towards the interval it extends and extra time is ignored. An empty
+// season or competitionCode covers them all.
+func (r *MatchRepository) ListTeamGoalTimings(teamID int, season, competitionCode string) ([]GoalTimingBucket, int, error) {
+	rows, err := r.q.ListTeamGoalTimings(context.Background(), sqlcdb.ListTeamGoalTimingsParams{
+		Season:          season,
+		CompetitionCode: competitionCode,
+		TeamID:          teamID,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to aggregate goal timings: %w", err)
+	}
+
+	buckets := make([]GoalTimingBucket, 0, len(rows))
+	matches := 0
+	for _, row := range rows {
+		buckets = append(buckets, GoalTimingBucket{Bucket: row.Bucket, Scored: row.Scored, Conceded: row.Conceded})
+		matches = row.Matches
+	}
+	return buckets, matches, nil
+}
+
+// LateGoals counts the goals a team scored over its recent matches with
+// recorded events, and those after minute 75.
+type LateGoals struct {
+	Matches   int
+	Goals     int
+	LateGoals int
+}
+
+// GetTeamLateGoals counts a team's goals, and late goals, in its last
+// recent finished matches with recorded events kicked off before a time.
+func (r *MatchRepository) GetTeamLateGoals(teamID int, before time.Time, recent int) (*LateGoals, error) {
+	row, err := r.q.GetTeamLateGoals(context.Background(), sqlcdb.GetTeamLateGoalsParams{
+		TeamID: teamID,
+		Before: before,
+		Recent: recent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count late goals: %w", err)
+	}
+	l := LateGoals(row)
+	return &l, nil
+}
+
+// LeagueProfile is a team's season in its league: its goals and where they
+// rank among the league's Teams, attack by goals scored per match and
+// defense by goals conceded per match, 1 being best. Expected goals are
+// per-match averages over MatchesWithStatistics, nil without any, and cards
+// count over MatchesWithEvents.
+type LeagueProfile struct {
+	TeamID                int      `json:"-"`
+	CompetitionCode       string   `json:"competitionCode"`
+	CompetitionName       string   `json:"competitionName"`
+	Teams                 int      `json:"teams"`
+	Played                int      `json:"played"`
+	GoalsFor              int      `json:"goalsFor"`
+	GoalsAgainst          int      `json:"goalsAgainst"`
+	AttackRank            int      `json:"attackRank"`
+	DefenseRank           int      `json:"defenseRank"`
+	MatchesWithStatistics int      `json:"matchesWithStatistics"`
+	ExpectedGoalsFor      *float64 `json:"expectedGoalsFor"`
+	ExpectedGoalsAgainst  *float64 `json:"expectedGoalsAgainst"`
+	MatchesWithEvents     int      `json:"-"`
+	YellowCards           int      `json:"-"`
+	SendingsOff           int      `json:"-"`
+}
+
+// ListLeagueProfiles returns the league profiles of the given teams
+// (internal IDs) in a season, each in the league they played most matches
+// of. season is the season start year. Teams without a finished league
+// match in it are left out.
+func (r *MatchRepository) ListLeagueProfiles(teamIDs []int, season string) ([]LeagueProfile, error) {
+	rows, err := r.q.ListTeamLeagueProfiles(context.Background(), sqlcdb.ListTeamLeagueProfilesParams{
+		Season:  season,
+		TeamIds: teamIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank league profiles: %w", err)
+	}
+
+	profiles := make([]LeagueProfile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, LeagueProfile(row))
+	}
+	return profiles, nil
+}
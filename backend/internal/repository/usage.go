@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// APIUsage aggregates the requests one key made to one endpoint within an
+// hour. Errors counts responses with a status of 400 or more and Bytes the
+// response bodies.
+type APIUsage struct {
+	APIKeyID       int
+	Hour           time.Time
+	Endpoint       string
+	Requests       int
+	Errors         int
+	Bytes          int
+	TotalLatencyMs int
+	MaxLatencyMs   int
+}
+
+// EndpointUsage totals a user's usage of one endpoint.
+type EndpointUsage struct {
+	Endpoint     string  `json:"endpoint"`
+	Requests     int     `json:"requests"`
+	Errors       int     `json:"errors"`
+	Bytes        int     `json:"bytes"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+	MaxLatencyMs int     `json:"maxLatencyMs"`
+}
+
+// DailyUsage totals a user's usage on one UTC day.
+type DailyUsage struct {
+	Day      time.Time `json:"day"`
+	Requests int       `json:"requests"`
+	Errors   int       `json:"errors"`
+	Bytes    int       `json:"bytes"`
+}
+
+// UsageRepository provides DB access for API usage.
+type UsageRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewUsageRepository(db *sql.DB) *UsageRepository {
+	return &UsageRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// Add adds aggregates to the stored hourly usage.
+func (r *UsageRepository) Add(usage []APIUsage) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	for _, u := range usage {
+		err := q.UpsertAPIUsage(ctx, sqlcdb.UpsertAPIUsageParams{
+			ApiKeyID:       u.APIKeyID,
+			Hour:           u.Hour,
+			Endpoint:       u.Endpoint,
+			Requests:       u.Requests,
+			Errors:         u.Errors,
+			Bytes:          u.Bytes,
+			TotalLatencyMs: u.TotalLatencyMs,
+			MaxLatencyMs:   u.MaxLatencyMs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save api usage: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit api usage: %w", err)
+	}
+	return nil
+}
+
+// CountSince counts the requests made with any of a user's keys from the
+// hour containing since.
+func (r *UsageRepository) CountSince(userID int, since time.Time) (int, error) {
+	n, err := r.q.CountUserRequestsSince(context.Background(), sqlcdb.CountUserRequestsSinceParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count api usage: %w", err)
+	}
+	return n, nil
+}
+
+// ListByEndpoint totals a user's usage since a time per endpoint, busiest
+// first. A non-nil keyID limits it to one of the user's keys.
+func (r *UsageRepository) ListByEndpoint(userID int, since time.Time, keyID *int) ([]EndpointUsage, error) {
+	rows, err := r.q.ListUserUsageByEndpoint(context.Background(), sqlcdb.ListUserUsageByEndpointParams{
+		UserID:   userID,
+		Since:    since,
+		ApiKeyID: keyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api usage by endpoint: %w", err)
+	}
+
+	usage := make([]EndpointUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, EndpointUsage(row))
+	}
+	return usage, nil
+}
+
+// ListByDay totals a user's usage since a time per UTC day, oldest first. A
+// non-nil keyID limits it to one of the user's keys.
+func (r *UsageRepository) ListByDay(userID int, since time.Time, keyID *int) ([]DailyUsage, error) {
+	rows, err := r.q.ListUserUsageByDay(context.Background(), sqlcdb.ListUserUsageByDayParams{
+		UserID:   userID,
+		Since:    since,
+		ApiKeyID: keyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api usage by day: %w", err)
+	}
+
+	usage := make([]DailyUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, DailyUsage(row))
+	}
+	return usage, nil
+}
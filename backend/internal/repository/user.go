@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// User is an API consumer. MonthlyQuota overrides the default monthly
+// request quota; 0 means unlimited.
+type User struct {
+	ID           int       `json:"id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	IsAdmin      bool      `json:"isAdmin"`
+	MonthlyQuota *int      `json:"monthlyQuota,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// APIKey is a stored key. Only the hash of the key is persisted.
+type APIKey struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"userId"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// UserRepository provides DB access for users and API keys.
+type UserRepository struct {
+	q *sqlcdb.Queries
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{q: sqlcdb.New(db)}
+}
+
+// Create inserts a user and fills in its ID and creation time.
+func (r *UserRepository) Create(u *User) error {
+	row, err := r.q.CreateUser(context.Background(), sqlcdb.CreateUserParams{
+		Email:   u.Email,
+		Name:    &u.Name,
+		IsAdmin: u.IsAdmin,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	u.ID = row.ID
+	u.CreatedAt = derefTime(row.CreatedAt)
+	return nil
+}
+
+// GetByID returns a user, or nil if it does not exist.
+func (r *UserRepository) GetByID(id int) (*User, error) {
+	row, err := r.q.GetUserByID(context.Background(), id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &User{
+		ID:        row.ID,
+		Email:     row.Email,
+		Name:      row.Name,
+		IsAdmin:   row.IsAdmin,
+		CreatedAt: derefTime(row.CreatedAt),
+	}, nil
+}
+
+// CreateAPIKey stores the hash of a newly issued key.
+func (r *UserRepository) CreateAPIKey(key *APIKey, keyHash string) error {
+	row, err := r.q.CreateAPIKey(context.Background(), sqlcdb.CreateAPIKeyParams{
+		UserID:    key.UserID,
+		Name:      &key.Name,
+		KeyPrefix: key.Prefix,
+		KeyHash:   keyHash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	key.ID = row.ID
+	key.CreatedAt = derefTime(row.CreatedAt)
+	return nil
+}
+
+// ListAPIKeys returns all keys issued to a user.
+func (r *UserRepository) ListAPIKeys(userID int) ([]APIKey, error) {
+	rows, err := r.q.ListAPIKeys(context.Background(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	var keys []APIKey
+	for _, row := range rows {
+		keys = append(keys, APIKey{
+			ID:         row.ID,
+			UserID:     row.UserID,
+			Name:       row.Name,
+			Prefix:     row.KeyPrefix,
+			LastUsedAt: row.LastUsedAt,
+			RevokedAt:  row.RevokedAt,
+			CreatedAt:  derefTime(row.CreatedAt),
+		})
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey marks a key as revoked. Returns false if no active key matched.
+func (r *UserRepository) RevokeAPIKey(userID, keyID int) (bool, error) {
+	n, err := r.q.RevokeAPIKey(context.Background(), sqlcdb.RevokeAPIKeyParams{
+		ID:     keyID,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return n > 0, nil
+}
+
+// SetMonthlyQuota sets or, with nil, clears a user's quota override and
+// reports whether the user exists.
+func (r *UserRepository) SetMonthlyQuota(userID int, quota *int) (bool, error) {
+	n, err := r.q.SetUserMonthlyQuota(context.Background(), sqlcdb.SetUserMonthlyQuotaParams{
+		MonthlyQuota: quota,
+		ID:           userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set monthly quota: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Authenticate resolves an active key hash to its user and key, or nil if the
+// key is unknown or revoked. The key's last_used_at is refreshed.
+func (r *UserRepository) Authenticate(keyHash string) (*User, *APIKey, error) {
+	row, err := r.q.AuthenticateAPIKey(context.Background(), keyHash)
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	u := &User{
+		ID:           row.UserID,
+		Email:        row.Email,
+		Name:         row.UserName,
+		IsAdmin:      row.IsAdmin,
+		MonthlyQuota: row.MonthlyQuota,
+		CreatedAt:    derefTime(row.UserCreatedAt),
+	}
+	k := &APIKey{
+		ID:        row.KeyID,
+		UserID:    row.UserID,
+		Name:      row.KeyName,
+		Prefix:    row.KeyPrefix,
+		CreatedAt: derefTime(row.KeyCreatedAt),
+	}
+	return u, k, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Venue is a stadium's location, keyed by the name providers give it.
+type Venue struct {
+	Name      string
+	City      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+}
+
+// TravelCoordinates are the match venue and the away team's home venue of
+// a match.
+type TravelCoordinates struct {
+	MatchID           int
+	VenueLatitude     float64
+	VenueLongitude    float64
+	AwayHomeLatitude  float64
+	AwayHomeLongitude float64
+}
+
+// VenueRepository provides DB access for venue locations and the travel
+// distances derived from them.
+type VenueRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewVenueRepository(db *sql.DB) *VenueRepository {
+	return &VenueRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// Upsert stores the venues in one transaction, replacing the stored
+// locations of any already known by name.
+func (r *VenueRepository) Upsert(venues []Venue) error {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx)
+	for _, v := range venues {
+		if err := q.UpsertVenue(context.Background(), sqlcdb.UpsertVenueParams{
+			Name:      v.Name,
+			City:      nullString(v.City),
+			Country:   nullString(v.Country),
+			Latitude:  v.Latitude,
+			Longitude: v.Longitude,
+		}); err != nil {
+			return fmt.Errorf("failed to upsert venue %q: %w", v.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListTravelCoordinates returns the venues of the matches whose travel
+// distance can be computed, only those without one unless recompute is set.
+func (r *VenueRepository) ListTravelCoordinates(recompute bool) ([]TravelCoordinates, error) {
+	rows, err := r.q.ListMatchTravelCoordinates(context.Background(), recompute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query travel coordinates: %w", err)
+	}
+
+	coordinates := make([]TravelCoordinates, 0, len(rows))
+	for _, row := range rows {
+		coordinates = append(coordinates, TravelCoordinates{
+			MatchID:           row.ID,
+			VenueLatitude:     row.VenueLatitude,
+			VenueLongitude:    row.VenueLongitude,
+			AwayHomeLatitude:  row.AwayHomeLatitude,
+			AwayHomeLongitude: row.AwayHomeLongitude,
+		})
+	}
+	return coordinates, nil
+}
+
+// SetTravelDistance stores the away team's travel to a match.
+func (r *VenueRepository) SetTravelDistance(matchID int, km float64) error {
+	if err := r.q.SetMatchTravelDistance(context.Background(), sqlcdb.SetMatchTravelDistanceParams{
+		TravelDistanceKm: &km,
+		ID:               matchID,
+	}); err != nil {
+		return fmt.Errorf("failed to store travel distance: %w", err)
+	}
+	return nil
+}
+
+// TravelDistance returns the away team's stored travel to a match, or nil
+// if it is unknown.
+func (r *VenueRepository) TravelDistance(matchID int) (*float64, error) {
+	km, err := r.q.GetMatchTravelDistance(context.Background(), matchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query travel distance: %w", err)
+	}
+	return km, nil
+}
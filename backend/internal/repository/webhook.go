@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+// Webhook is a registered subscription for one or more event types.
+type Webhook struct {
+	ID          int       `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"-"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// WebhookStats summarises deliveries for a webhook in the admin listing.
+type WebhookStats struct {
+	Webhook
+	Pending   int        `json:"pendingDeliveries"`
+	Delivered int        `json:"deliveredCount"`
+	Failed    int        `json:"failedCount"`
+	LastError *string    `json:"lastError,omitempty"`
+	LastSent  *time.Time `json:"lastDeliveredAt,omitempty"`
+}
+
+// WebhookDelivery is a queued delivery of one event to one webhook.
+type WebhookDelivery struct {
+	ID        int
+	WebhookID int
+	URL       string
+	Secret    string
+	EventID   int
+	EventType string
+	Payload   []byte
+	Attempts  int
+}
+
+// WebhookRepository provides DB access for webhooks and their delivery queue.
+type WebhookRepository struct {
+	db *sql.DB
+	q  *sqlcdb.Queries
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db, q: sqlcdb.New(db)}
+}
+
+// Create registers a new webhook and fills in its ID and creation time.
+func (r *WebhookRepository) Create(w *Webhook) error {
+	row, err := r.q.CreateWebhook(context.Background(), sqlcdb.CreateWebhookParams{
+		Url:         w.URL,
+		Secret:      w.Secret,
+		Events:      w.Events,
+		Description: &w.Description,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	w.ID = row.ID
+	w.Active = row.Active
+	w.CreatedAt = derefTime(row.CreatedAt)
+	return nil
+}
+
+// Delete removes a webhook and its pending deliveries.
+func (r *WebhookRepository) Delete(id int) (bool, error) {
+	n, err := r.q.DeleteWebhook(context.Background(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListWithStats returns every webhook along with delivery counters.
+func (r *WebhookRepository) ListWithStats() ([]WebhookStats, error) {
+	rows, err := r.q.ListWebhooksWithStats(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	var result []WebhookStats
+	for _, row := range rows {
+		s := WebhookStats{
+			Webhook: Webhook{
+				ID:          row.ID,
+				URL:         row.Url,
+				Events:      row.Events,
+				Description: row.Description,
+				Active:      row.Active,
+				CreatedAt:   derefTime(row.CreatedAt),
+			},
+			Pending:   row.Pending,
+			Delivered: row.Delivered,
+			Failed:    row.Failed,
+		}
+		if row.LastError != "" {
+			s.LastError = &row.LastError
+		}
+		if !row.LastDeliveredAt.IsZero() {
+			s.LastSent = &row.LastDeliveredAt
+		}
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// PublishEvent records an event and queues a delivery for every active
+// webhook subscribed to its type. Events are de-duplicated by key, so
+// publishing the same key twice is a no-op. Returns whether the event was new.
+func (r *WebhookRepository) PublishEvent(eventType, eventKey string, payload []byte) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ctx := context.Background()
+	q := r.q.WithTx(tx)
+
+	eventID, err := q.InsertWebhookEvent(ctx, sqlcdb.InsertWebhookEventParams{
+		EventType: eventType,
+		EventKey:  eventKey,
+		Payload:   payload,
+	})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+
+	err = q.QueueWebhookDeliveries(ctx, sqlcdb.QueueWebhookDeliveriesParams{
+		EventID:   eventID,
+		EventType: eventType,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to queue deliveries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit event: %w", err)
+	}
+
+	return true, nil
+}
+
+// ListDue returns pending deliveries whose next attempt time has passed.
+func (r *WebhookRepository) ListDue(limit int) ([]WebhookDelivery, error) {
+	rows, err := r.q.ListDueWebhookDeliveries(context.Background(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due deliveries: %w", err)
+	}
+
+	var result []WebhookDelivery
+	for _, row := range rows {
+		result = append(result, WebhookDelivery{
+			ID:        row.ID,
+			WebhookID: row.WebhookID,
+			URL:       row.Url,
+			Secret:    row.Secret,
+			EventID:   row.EventID,
+			EventType: row.EventType,
+			Payload:   row.Payload,
+			Attempts:  row.Attempts,
+		})
+	}
+	return result, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *WebhookRepository) MarkDelivered(id, responseStatus int) error {
+	err := r.q.MarkWebhookDelivered(context.Background(), sqlcdb.MarkWebhookDeliveredParams{
+		ResponseStatus: responseStatus,
+		ID:             id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark delivery: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. When nextAttempt is nil the delivery
+// is given up on; otherwise it is rescheduled.
+func (r *WebhookRepository) MarkFailed(id int, responseStatus *int, errMsg string, nextAttempt *time.Time) error {
+	status := "pending"
+	if nextAttempt == nil {
+		status = "failed"
+	}
+
+	err := r.q.MarkWebhookFailed(context.Background(), sqlcdb.MarkWebhookFailedParams{
+		Status:         status,
+		ResponseStatus: responseStatus,
+		LastError:      errMsg,
+		NextAttemptAt:  nextAttempt,
+		ID:             id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,488 @@
+// Package seed loads curated demo datasets into the database for local
+// development. Seeded rows are flagged is_synthetic and stored under the
+// synthetic data source, so they never collide with provider data and can be
+// purged without touching it. Their external IDs are negative as well, which
+// keeps them apart from football-data.org IDs in API paths.
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/sqlcdb"
+)
+
+//go:embed datasets/*.json
+var datasets embed.FS
+
+// Dataset is a curated demo competition: its clubs and squads. Results are
+// generated from the seed when the dataset is applied.
+type Dataset struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// IDBase keeps each dataset's negative external IDs apart.
+	IDBase      int `json:"idBase"`
+	Competition struct {
+		Name string `json:"name"`
+		Code string `json:"code"`
+		Area string `json:"area"`
+	} `json:"competition"`
+	SeasonStart time.Time `json:"seasonStart"`
+	// PlayedMatchdays are stored as finished; later ones stay scheduled.
+	PlayedMatchdays int    `json:"playedMatchdays"`
+	Teams           []Team `json:"teams"`
+}
+
+// Team is a club in a dataset. Strength scales its expected goals.
+type Team struct {
+	Name      string   `json:"name"`
+	ShortName string   `json:"shortName"`
+	TLA       string   `json:"tla"`
+	Venue     string   `json:"venue"`
+	Strength  float64  `json:"strength"`
+	Players   []Player `json:"players"`
+}
+
+type Player struct {
+	Name        string `json:"name"`
+	Position    string `json:"position"`
+	ShirtNumber int    `json:"shirtNumber"`
+}
+
+// Summary counts the rows written by Apply or left by Purge.
+type Summary struct {
+	Competitions int `json:"competitions"`
+	Teams        int `json:"teams"`
+	Players      int `json:"players"`
+	Matches      int `json:"matches"`
+	Goals        int `json:"goals"`
+}
+
+// List returns the names of the embedded datasets.
+func List() ([]string, error) {
+	entries, err := datasets.ReadDir("datasets")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Load reads an embedded dataset by name.
+func Load(name string) (*Dataset, error) {
+	data, err := datasets.ReadFile("datasets/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown dataset %q", name)
+	}
+
+	var ds Dataset
+	if err := json.Unmarshal(data, &ds); err != nil {
+		return nil, fmt.Errorf("failed to parse dataset %s: %w", name, err)
+	}
+	if ds.IDBase <= 0 || len(ds.Teams) < 2 {
+		return nil, fmt.Errorf("dataset %s needs a positive idBase and at least two teams", name)
+	}
+	return &ds, nil
+}
+
+// Apply replaces the dataset's rows with a freshly generated season. The same
+// dataset and seed always produce the same fixtures, scores and scorers.
+func Apply(db *sql.DB, ds *Dataset, seed int64) (*Summary, error) {
+	ctx := context.Background()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := sqlcdb.New(db).WithTx(tx)
+
+	// Teams cascade to their players and matches, competitions to the rest.
+	if _, err := q.DeleteSyntheticCompetitions(ctx, []int{ds.competitionExternalID()}); err != nil {
+		return nil, fmt.Errorf("failed to remove previous competition: %w", err)
+	}
+	teamIDs := make([]int, len(ds.Teams))
+	for i := range ds.Teams {
+		teamIDs[i] = ds.teamExternalID(i)
+	}
+	if _, err := q.DeleteSyntheticTeams(ctx, teamIDs); err != nil {
+		return nil, fmt.Errorf("failed to remove previous teams: %w", err)
+	}
+
+	g := &generator{ds: ds, q: q, rng: rand.New(rand.NewSource(seed))}
+	if err := g.run(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit seed: %w", err)
+	}
+	return &g.summary, nil
+}
+
+// Purge deletes every seeded row, from all datasets, and returns what was
+// removed.
+func Purge(db *sql.DB) (*Summary, error) {
+	ctx := context.Background()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := sqlcdb.New(db).WithTx(tx)
+
+	before, err := q.CountSyntheticRows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count synthetic rows: %w", err)
+	}
+
+	// Matches first: a synthetic match may reference a real team, and its
+	// stats and events cascade from it.
+	if _, err := q.DeleteSyntheticMatches(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete synthetic matches: %w", err)
+	}
+	if _, err := q.DeleteSyntheticPlayers(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete synthetic players: %w", err)
+	}
+	if _, err := q.DeleteSyntheticTeams(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to delete synthetic teams: %w", err)
+	}
+	if _, err := q.DeleteSyntheticCompetitions(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to delete synthetic competitions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purge: %w", err)
+	}
+	return &Summary{
+		Competitions: before.Competitions,
+		Teams:        before.Teams,
+		Players:      before.Players,
+		Matches:      before.Matches,
+	}, nil
+}
+
+// Count returns how many seeded rows are stored.
+func Count(db *sql.DB) (*Summary, error) {
+	row, err := sqlcdb.New(db).CountSyntheticRows(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count synthetic rows: %w", err)
+	}
+	return &Summary{Competitions: row.Competitions, Teams: row.Teams, Players: row.Players, Matches: row.Matches}, nil
+}
+
+// External IDs are negative so they cannot be mistaken for football-data.org
+// IDs in API paths.
+func (ds *Dataset) competitionExternalID() int { return -ds.IDBase }
+
+func (ds *Dataset) teamExternalID(team int) int { return -(ds.IDBase + 100 + team) }
+
+func (ds *Dataset) playerExternalID(team, i int) int { return -(ds.IDBase + 1000 + team*100 + i) }
+
+func (ds *Dataset) matchExternalID(n int) int { return -(ds.IDBase + 10000 + n) }
+
+type generator struct {
+	ds      *Dataset
+	q       *sqlcdb.Queries
+	rng     *rand.Rand
+	summary Summary
+
+	competitionID int
+	teamIDs       []int
+	playerIDs     [][]int
+}
+
+func (g *generator) run(ctx context.Context) error {
+	ds := g.ds
+	rounds := doubleRoundRobin(len(ds.Teams))
+	seasonEnd := ds.SeasonStart.AddDate(0, 0, 7*len(rounds))
+
+	compID, err := g.q.InsertSyntheticCompetition(ctx, sqlcdb.InsertSyntheticCompetitionParams{
+		ExternalID:  ds.competitionExternalID(),
+		Name:        ds.Competition.Name,
+		Code:        ds.Competition.Code,
+		AreaName:    ds.Competition.Area,
+		SeasonStart: ds.SeasonStart,
+		SeasonEnd:   seasonEnd,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert competition: %w", err)
+	}
+	g.competitionID = compID
+	g.summary.Competitions = 1
+
+	for i, t := range ds.Teams {
+		teamID, err := g.q.InsertSyntheticTeam(ctx, sqlcdb.InsertSyntheticTeamParams{
+			ExternalID: ds.teamExternalID(i),
+			Name:       t.Name,
+			ShortName:  t.ShortName,
+			Tla:        t.TLA,
+			Venue:      t.Venue,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert team %s: %w", t.Name, err)
+		}
+		g.teamIDs = append(g.teamIDs, teamID)
+		g.summary.Teams++
+
+		var players []int
+		for j, p := range t.Players {
+			playerID, err := g.q.InsertSyntheticPlayer(ctx, sqlcdb.InsertSyntheticPlayerParams{
+				ExternalID:  ds.playerExternalID(i, j),
+				TeamID:      teamID,
+				Name:        p.Name,
+				Position:    p.Position,
+				ShirtNumber: p.ShirtNumber,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to insert player %s: %w", p.Name, err)
+			}
+			players = append(players, playerID)
+			g.summary.Players++
+		}
+		g.playerIDs = append(g.playerIDs, players)
+	}
+
+	n := 0
+	for r, fixtures := range rounds {
+		matchday := r + 1
+		kickoff := ds.SeasonStart.AddDate(0, 0, 7*r)
+		for _, f := range fixtures {
+			n++
+			if err := g.match(ctx, n, matchday, kickoff, f[0], f[1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// match stores one fixture. Played matchdays get a score drawn from each
+// side's strength, with scorers and assists picked from the squads.
+func (g *generator) match(ctx context.Context, n, matchday int, kickoff time.Time, home, away int) error {
+	ds := g.ds
+	params := sqlcdb.InsertSyntheticMatchParams{
+		ExternalID:    ds.matchExternalID(n),
+		CompetitionID: g.competitionID,
+		Season:        fmt.Sprintf("%d", ds.competitionExternalID()),
+		Matchday:      matchday,
+		HomeTeamID:    g.teamIDs[home],
+		AwayTeamID:    g.teamIDs[away],
+		UtcDate:       kickoff,
+		Status:        "SCHEDULED",
+		Venue:         ds.Teams[home].Venue,
+	}
+
+	played := matchday <= ds.PlayedMatchdays
+	var homeGoals, awayGoals int
+	if played {
+		h, a := ds.Teams[home].Strength, ds.Teams[away].Strength
+		homeGoals = poisson(g.rng, 1.25*h/a+0.15)
+		awayGoals = poisson(g.rng, 1.05*a/h)
+
+		winner := "DRAW"
+		switch {
+		case homeGoals > awayGoals:
+			winner = "HOME_TEAM"
+		case awayGoals > homeGoals:
+			winner = "AWAY_TEAM"
+		}
+		params.Status = "FINISHED"
+		params.HomeScore = &homeGoals
+		params.AwayScore = &awayGoals
+		params.Winner = &winner
+	}
+
+	matchID, err := g.q.InsertSyntheticMatch(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to insert match %d: %w", n, err)
+	}
+	g.summary.Matches++
+
+	if !played {
+		return nil
+	}
+	if err := g.goals(ctx, matchID, home, homeGoals); err != nil {
+		return err
+	}
+	return g.goals(ctx, matchID, away, awayGoals)
+}
+
+// goals records scorers, assists and goal events for one side.
+func (g *generator) goals(ctx context.Context, matchID, team, count int) error {
+	squad := g.ds.Teams[team].Players
+	type line struct{ goals, assists int }
+	lines := map[int]*line{}
+	get := func(i int) *line {
+		if lines[i] == nil {
+			lines[i] = &line{}
+		}
+		return lines[i]
+	}
+
+	minutes := make([]int, count)
+	for i := range minutes {
+		minutes[i] = 1 + g.rng.Intn(90)
+	}
+	sort.Ints(minutes)
+
+	for _, minute := range minutes {
+		scorer := pick(g.rng, squad, -1, scoringWeight)
+		get(scorer).goals++
+		if g.rng.Float64() < 0.7 {
+			get(pick(g.rng, squad, scorer, assistWeight)).assists++
+		}
+
+		err := g.q.InsertMatchEvent(ctx, sqlcdb.InsertMatchEventParams{
+			MatchID:   matchID,
+			TeamID:    g.teamIDs[team],
+			PlayerID:  g.playerIDs[team][scorer],
+			EventType: "GOAL",
+			Minute:    minute,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert goal event: %w", err)
+		}
+		g.summary.Goals++
+	}
+
+	// Map order is random; insert in squad order so runs are identical.
+	for i := range squad {
+		l := lines[i]
+		if l == nil {
+			continue
+		}
+		err := g.q.UpsertPlayerMatchStats(ctx, sqlcdb.UpsertPlayerMatchStatsParams{
+			MatchID:  matchID,
+			PlayerID: g.playerIDs[team][i],
+			Goals:    l.goals,
+			Assists:  l.assists,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert player stats: %w", err)
+		}
+	}
+	return nil
+}
+
+func scoringWeight(position string) int {
+	switch position {
+	case "Offence":
+		return 6
+	case "Midfield":
+		return 3
+	case "Defence":
+		return 1
+	}
+	return 0
+}
+
+func assistWeight(position string) int {
+	switch position {
+	case "Midfield":
+		return 4
+	case "Offence":
+		return 2
+	case "Defence":
+		return 1
+	}
+	return 0
+}
+
+// pick chooses a squad index by position weight, never returning skip.
+func pick(rng *rand.Rand, squad []Player, skip int, weight func(string) int) int {
+	total := 0
+	for i, p := range squad {
+		if i != skip {
+			total += weight(p.Position)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	n := rng.Intn(total)
+	for i, p := range squad {
+		if i == skip {
+			continue
+		}
+		if n -= weight(p.Position); n < 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// poisson draws from a Poisson distribution with mean lambda.
+func poisson(rng *rand.Rand, lambda float64) int {
+	limit, k, p := math.Exp(-lambda), 0, 1.0
+	for {
+		p *= rng.Float64()
+		if p <= limit {
+			return k
+		}
+		k++
+	}
+}
+
+// doubleRoundRobin returns the rounds of a home-and-away league between n
+// teams as [home, away] index pairs, using the circle method. With an odd n
+// one team rests each round.
+func doubleRoundRobin(n int) [][][2]int {
+	teams := make([]int, n)
+	for i := range teams {
+		teams[i] = i
+	}
+	if n%2 == 1 {
+		teams = append(teams, -1)
+	}
+	size := len(teams)
+
+	var first [][][2]int
+	for r := 0; r < size-1; r++ {
+		var round [][2]int
+		for i := 0; i < size/2; i++ {
+			home, away := teams[i], teams[size-1-i]
+			if home < 0 || away < 0 {
+				continue
+			}
+			if r%2 == 1 {
+				home, away = away, home
+			}
+			round = append(round, [2]int{home, away})
+		}
+		first = append(first, round)
+
+		// Keep the first team fixed and rotate the rest.
+		last := teams[size-1]
+		copy(teams[2:], teams[1:size-1])
+		teams[1] = last
+	}
+
+	rounds := append([][][2]int{}, first...)
+	for _, round := range first {
+		var reverse [][2]int
+		for _, f := range round {
+			reverse = append(reverse, [2]int{f[1], f[0]})
+		}
+		rounds = append(rounds, reverse)
+	}
+	return rounds
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Anomaly kinds.
+const (
+	// AnomalyUpset is a result the model gave a low probability.
+	AnomalyUpset = "upset"
+	// AnomalyScoreline is a scoreline far from the predicted goals.
+	AnomalyScoreline = "scoreline"
+)
+
+// legacyUpsetConfidence flags predictions stored without probabilities as
+// upsets when they were wrong with at least this confidence.
+const legacyUpsetConfidence = 0.6
+
+// AnomalyService flags finished matches the served prediction got badly
+// wrong and publishes them as events.
+type AnomalyService struct {
+	repo      *repository.AnomalyRepository
+	matchRepo *repository.MatchRepository
+	predRepo  *repository.PredictionRepository
+	events    *EventBus
+
+	upsetProbability float64
+	goalsError       float64
+}
+
+// NewAnomalyService flags upsets when the actual outcome had a probability
+// below upsetProbability, and scorelines whose home and away goal errors add
+// up to at least goalsError.
+func NewAnomalyService(db *sql.DB, bus *EventBus, upsetProbability, goalsError float64) *AnomalyService {
+	return &AnomalyService{
+		repo:             repository.NewAnomalyRepository(db),
+		matchRepo:        repository.NewMatchRepository(db),
+		predRepo:         repository.NewPredictionRepository(db),
+		events:           bus,
+		upsetProbability: upsetProbability,
+		goalsError:       goalsError,
+	}
+}
+
+// Detect checks up to limit settled predictions not checked before and
+// returns how many anomalies it found. Each flagged match is published once
+// per kind; a prediction whose anomalies could not be stored or published
+// stays unchecked and is retried on the next run.
+func (s *AnomalyService) Detect(limit int) (int, error) {
+	candidates, err := s.repo.ListCandidates(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	found := 0
+	for _, c := range candidates {
+		anomalies := s.evaluate(c)
+		for _, a := range anomalies {
+			if _, err := s.repo.Insert(a); err != nil {
+				return found, err
+			}
+			if err := s.publish(c, a); err != nil {
+				return found, err
+			}
+		}
+		if err := s.repo.MarkChecked(c.ID); err != nil {
+			return found, err
+		}
+		found += len(anomalies)
+	}
+	return found, nil
+}
+
+// List returns anomalies of matches kicked off on or after since, most
+// recent first. An empty kind returns every kind.
+func (s *AnomalyService) List(since time.Time, kind string, limit int) ([]repository.Anomaly, error) {
+	if kind != "" && kind != AnomalyUpset && kind != AnomalyScoreline {
+		return nil, fmt.Errorf("unknown anomaly kind %q", kind)
+	}
+	return s.repo.List(since, kind, limit)
+}
+
+// evaluate returns the anomalies of one settled prediction.
+func (s *AnomalyService) evaluate(c repository.AnomalyCandidate) []repository.NewAnomaly {
+	details := map[string]interface{}{
+		"competitionCode":  c.CompetitionCode,
+		"homeTeam":         c.HomeTeamName,
+		"awayTeam":         c.AwayTeamName,
+		"predictedOutcome": c.PredictedOutcome,
+		"actualOutcome":    c.ActualOutcome,
+		"confidenceScore":  c.ConfidenceScore,
+		"actualScore":      fmt.Sprintf("%d-%d", c.ActualTeamAGoals, c.ActualTeamBGoals),
+	}
+	if c.HomeWinProbability != nil && c.DrawProbability != nil && c.AwayWinProbability != nil {
+		details["probabilities"] = map[string]float64{
+			"homeWin": *c.HomeWinProbability,
+			"draw":    *c.DrawProbability,
+			"awayWin": *c.AwayWinProbability,
+		}
+	}
+	if c.PredictedTeamAGoals != nil && c.PredictedTeamBGoals != nil {
+		details["predictedGoals"] = map[string]float64{
+			"home": *c.PredictedTeamAGoals,
+			"away": *c.PredictedTeamBGoals,
+		}
+	}
+	data, _ := json.Marshal(details)
+
+	var anomalies []repository.NewAnomaly
+	if probability, upset := s.upset(c); upset {
+		anomalies = append(anomalies, repository.NewAnomaly{
+			MatchID:            c.MatchID,
+			Kind:               AnomalyUpset,
+			ModelVersion:       c.ModelVersion,
+			OutcomeProbability: probability,
+			Context:            data,
+		})
+	}
+	if c.PredictedTeamAGoals != nil && c.PredictedTeamBGoals != nil {
+		goalsError := math.Abs(*c.PredictedTeamAGoals-float64(c.ActualTeamAGoals)) +
+			math.Abs(*c.PredictedTeamBGoals-float64(c.ActualTeamBGoals))
+		if goalsError >= s.goalsError {
+			anomalies = append(anomalies, repository.NewAnomaly{
+				MatchID:      c.MatchID,
+				Kind:         AnomalyScoreline,
+				ModelVersion: c.ModelVersion,
+				GoalsError:   &goalsError,
+				Context:      data,
+			})
+		}
+	}
+	return anomalies
+}
+
+// upset reports whether the actual outcome had a probability below the
+// threshold, returning that probability. Predictions stored without
+// probabilities count as upsets when they were wrong with high confidence.
+func (s *AnomalyService) upset(c repository.AnomalyCandidate) (*float64, bool) {
+	if c.HomeWinProbability == nil || c.DrawProbability == nil || c.AwayWinProbability == nil {
+		wrong := c.PredictionCorrect != nil && !*c.PredictionCorrect
+		return nil, wrong && c.ConfidenceScore >= legacyUpsetConfidence
+	}
+
+	var probability float64
+	switch {
+	case c.ActualTeamAGoals > c.ActualTeamBGoals:
+		probability = *c.HomeWinProbability
+	case c.ActualTeamAGoals < c.ActualTeamBGoals:
+		probability = *c.AwayWinProbability
+	default:
+		probability = *c.DrawProbability
+	}
+	return &probability, probability < s.upsetProbability
+}
+
+// publish notifies subscribers of an anomaly. Upsets keep the
+// upset.detected event; event keys make repeated runs idempotent.
+func (s *AnomalyService) publish(c repository.AnomalyCandidate, a repository.NewAnomaly) error {
+	match, err := s.matchRepo.GetSummaryByID(c.MatchID)
+	if err != nil || match == nil {
+		return err
+	}
+	prediction, err := s.predRepo.GetByMatchID(c.MatchID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"kind":               a.Kind,
+		"outcomeProbability": a.OutcomeProbability,
+		"goalsError":         a.GoalsError,
+		"context":            a.Context,
+		"match":              match,
+		"prediction":         prediction,
+	}
+
+	event := EventAnomalyDetected
+	if a.Kind == AnomalyUpset {
+		event = EventUpsetDetected
+	}
+	return s.events.Publish(event, fmt.Sprintf("%s:%d", event, c.ExternalID), data)
+}
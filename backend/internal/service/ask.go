@@ -0,0 +1,371 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+// maxToolCalls bounds how many repository queries a single question may run.
+const maxToolCalls = 4
+
+// ToolCall records a whitelisted query chosen by the model and its result.
+type ToolCall struct {
+	Tool   string                 `json:"tool"`
+	Args   map[string]interface{} `json:"args"`
+	Result interface{}            `json:"result,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// AskResult is the answer to a natural-language question plus the structured
+// data it was derived from.
+type AskResult struct {
+	Question string     `json:"question"`
+	Answer   string     `json:"answer"`
+	Data     []ToolCall `json:"data"`
+}
+
+// tool is a read-only query the model may choose. The model never sees or
+// writes SQL; it only picks a tool name and supplies arguments.
+type tool struct {
+	description string
+	args        string
+	run         func(args map[string]interface{}) (interface{}, error)
+}
+
+// AskService answers questions over stored data via constrained tool calls.
+type AskService struct {
+	llm        *llm.Client
+	reviews    *InsightReviewService
+	teamRepo   *repository.TeamRepository
+	matchRepo  *repository.MatchRepository
+	playerRepo *repository.PlayerRepository
+	tools      map[string]tool
+}
+
+func NewAskService(llmClient *llm.Client, reviews *InsightReviewService, db *sql.DB) *AskService {
+	s := &AskService{
+		llm:        llmClient.For(llmTaskAsk),
+		reviews:    reviews,
+		teamRepo:   repository.NewTeamRepository(db),
+		matchRepo:  repository.NewMatchRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+	}
+
+	s.tools = map[string]tool{
+		"team_matches": {
+			description: "A team's results with a win/draw/loss summary. Use opponent_top_n to only keep opponents currently in the top N of the competition table.",
+			args:        `{"team": string, "venue": "home"|"away"|"", "season": "YYYY"|"", "competition": code|"", "competition_type": "LEAGUE"|"CUP"|"", "opponent_top_n": int|0, "limit": int<=100}`,
+			run:         s.toolTeamMatches,
+		},
+		"head_to_head": {
+			description: "Historical meetings between two teams, optionally only in league or only in cup competitions.",
+			args:        `{"team_a": string, "team_b": string, "competition_type": "LEAGUE"|"CUP"|"", "limit": int<=50}`,
+			run:         s.toolHeadToHead,
+		},
+		"league_table": {
+			description: "League table computed from stored results.",
+			args:        `{"competition": code, "season": "YYYY"}`,
+			run:         s.toolLeagueTable,
+		},
+		"top_scorers": {
+			description: "Top scorers, optionally for one team, competition or season. per90 ranks by goals per 90 minutes among players with at least 270 minutes.",
+			args:        `{"team": string|"", "competition": code|"", "season": "YYYY"|"", "per90": bool, "limit": int<=50}`,
+			run:         s.toolTopScorers,
+		},
+	}
+
+	return s
+}
+
+// Enabled reports whether questions can be answered (an LLM is configured).
+func (s *AskService) Enabled() bool {
+	return s.llm.Enabled()
+}
+
+// Ask plans tool calls with the LLM, executes them against the repositories
+// and asks the LLM to answer using only the returned data. An answer failing
+// the quality gate is replaced by a stock one.
+func (s *AskService) Ask(question string) (*AskResult, error) {
+	var plan struct {
+		Calls []struct {
+			Tool string                 `json:"tool"`
+			Args map[string]interface{} `json:"args"`
+		} `json:"calls"`
+	}
+
+	if _, err := s.llm.CompleteJSON(s.plannerPrompt(), question, &plan); err != nil {
+		return nil, fmt.Errorf("failed to plan query: %w", err)
+	}
+
+	result := &AskResult{Question: question, Data: []ToolCall{}}
+
+	for i, call := range plan.Calls {
+		if i >= maxToolCalls {
+			break
+		}
+
+		tc := ToolCall{Tool: call.Tool, Args: call.Args}
+		t, ok := s.tools[call.Tool]
+		if !ok {
+			tc.Error = "unknown tool"
+		} else if data, err := t.run(call.Args); err != nil {
+			tc.Error = err.Error()
+		} else {
+			tc.Result = data
+		}
+		result.Data = append(result.Data, tc)
+	}
+
+	dataJSON, err := json.Marshal(result.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tool results: %w", err)
+	}
+
+	answer, err := s.llm.Complete(
+		"You answer football questions using ONLY the JSON data provided. "+
+			"If the data is empty or insufficient, say so. Be concise and quote the numbers you use.",
+		fmt.Sprintf("Question: %s\n\nData:\n%s", question, dataJSON),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate answer: %w", err)
+	}
+
+	result.Answer = s.reviews.Answer(question, answer.Content, "llm:"+answer.Model)
+	return result, nil
+}
+
+func (s *AskService) plannerPrompt() string {
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("You translate football questions into calls to read-only data tools.\n")
+	b.WriteString("Available tools:\n")
+	for _, name := range names {
+		t := s.tools[name]
+		fmt.Fprintf(&b, "- %s: %s Args: %s\n", name, t.description, t.args)
+	}
+	b.WriteString("Competition codes: PL, PD, BL1, SA, FL1, CL, WC, EC. Seasons are the starting year.\n")
+	fmt.Fprintf(&b, "Reply ONLY with JSON: {\"calls\": [{\"tool\": \"name\", \"args\": {...}}]} using at most %d calls.", maxToolCalls)
+	return b.String()
+}
+
+func (s *AskService) resolveTeam(name string) (*repository.TeamInfo, error) {
+	if name == "" {
+		return nil, fmt.Errorf("team name is required")
+	}
+
+	team, err := s.teamRepo.FindByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("no team matching %q", name)
+	}
+	return team, nil
+}
+
+func (s *AskService) toolTeamMatches(args map[string]interface{}) (interface{}, error) {
+	team, err := s.resolveTeam(argString(args, "team"))
+	if err != nil {
+		return nil, err
+	}
+
+	venue := argString(args, "venue")
+	if venue != "home" && venue != "away" {
+		venue = ""
+	}
+
+	filter := repository.TeamMatchFilter{
+		TeamID:          team.ID,
+		Venue:           venue,
+		Season:          argString(args, "season"),
+		CompetitionCode: strings.ToUpper(argString(args, "competition")),
+		CompetitionType: competitionTypeArg(args),
+		Status:          "FINISHED",
+		Limit:           argInt(args, "limit", 100, 1, 100),
+	}
+
+	matches, err := s.matchRepo.ListTeamMatches(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if topN := argInt(args, "opponent_top_n", 0, 0, 20); topN > 0 {
+		if filter.CompetitionCode == "" || filter.Season == "" {
+			return nil, fmt.Errorf("competition and season are required with opponent_top_n")
+		}
+
+		table, err := s.matchRepo.GetResultsTable(filter.CompetitionCode, filter.Season)
+		if err != nil {
+			return nil, err
+		}
+
+		top := make(map[int]bool)
+		for _, row := range table {
+			if row.Position <= topN {
+				top[row.TeamID] = true
+			}
+		}
+
+		filtered := matches[:0]
+		for _, m := range matches {
+			opponent := m.AwayTeamID
+			if m.AwayTeamID == team.ID {
+				opponent = m.HomeTeamID
+			}
+			if top[opponent] {
+				filtered = append(filtered, m)
+			}
+		}
+		matches = filtered
+	}
+
+	won, drawn, lost, goalsFor, goalsAgainst := 0, 0, 0, 0, 0
+	for _, m := range matches {
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		gf, ga := *m.HomeScore, *m.AwayScore
+		if m.AwayTeamID == team.ID {
+			gf, ga = ga, gf
+		}
+		goalsFor += gf
+		goalsAgainst += ga
+		switch {
+		case gf > ga:
+			won++
+		case gf == ga:
+			drawn++
+		default:
+			lost++
+		}
+	}
+
+	return map[string]interface{}{
+		"team":         team.Name,
+		"played":       len(matches),
+		"won":          won,
+		"draw":         drawn,
+		"lost":         lost,
+		"goalsFor":     goalsFor,
+		"goalsAgainst": goalsAgainst,
+		"points":       won*3 + drawn,
+		"matches":      matches,
+	}, nil
+}
+
+func (s *AskService) toolHeadToHead(args map[string]interface{}) (interface{}, error) {
+	teamA, err := s.resolveTeam(argString(args, "team_a"))
+	if err != nil {
+		return nil, err
+	}
+	teamB, err := s.resolveTeam(argString(args, "team_b"))
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.matchRepo.GetHeadToHeadByExternalTeamIDs(teamA.ExternalID, teamB.ExternalID, repository.HeadToHeadFilter{
+		CompetitionType: competitionTypeArg(args),
+		Limit:           argInt(args, "limit", 10, 1, 50),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"teamA":  teamA.Name,
+		"teamB":  teamB.Name,
+		"record": record,
+	}, nil
+}
+
+func (s *AskService) toolLeagueTable(args map[string]interface{}) (interface{}, error) {
+	competition := strings.ToUpper(argString(args, "competition"))
+	season := argString(args, "season")
+	if competition == "" || season == "" {
+		return nil, fmt.Errorf("competition and season are required")
+	}
+
+	return s.matchRepo.GetResultsTable(competition, season)
+}
+
+// minPer90Minutes keeps players with a goal in a short cameo off per-90
+// rankings: three full matches.
+const minPer90Minutes = 270
+
+func (s *AskService) toolTopScorers(args map[string]interface{}) (interface{}, error) {
+	filter := repository.ScorerFilter{
+		CompetitionCode: strings.ToUpper(argString(args, "competition")),
+		Season:          argString(args, "season"),
+		Limit:           argInt(args, "limit", 10, 1, 50),
+	}
+	if per90, _ := args["per90"].(bool); per90 {
+		filter.Per90 = true
+		filter.MinMinutes = minPer90Minutes
+	}
+
+	if name := argString(args, "team"); name != "" {
+		team, err := s.resolveTeam(name)
+		if err != nil {
+			return nil, err
+		}
+		filter.TeamID = team.ID
+	}
+
+	return s.playerRepo.GetTopScorers(filter)
+}
+
+// argString reads a string argument, accepting numbers for fields such as
+// season that models sometimes emit unquoted.
+func argString(args map[string]interface{}, key string) string {
+	switch v := args[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return ""
+	}
+}
+
+// competitionTypeArg reads the competition_type argument, ignoring anything
+// but LEAGUE and CUP.
+func competitionTypeArg(args map[string]interface{}) string {
+	switch t := strings.ToUpper(argString(args, "competition_type")); t {
+	case "LEAGUE", "CUP":
+		return t
+	default:
+		return ""
+	}
+}
+
+// argInt reads a numeric argument clamped to [min, max].
+func argInt(args map[string]interface{}, key string, def, min, max int) int {
+	n := def
+	switch v := args[key].(type) {
+	case float64:
+		n = int(v)
+	case string:
+		if _, err := fmt.Sscanf(v, "%d", &n); err != nil {
+			n = def
+		}
+	}
+
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
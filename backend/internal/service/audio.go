@@ -0,0 +1,189 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/pkg/blob"
+	"github.com/yourusername/football-prediction/pkg/tts"
+)
+
+// audioLinkTTL is how long a signed link to stored audio stays valid.
+const audioLinkTTL = time.Hour
+
+// InsightAudio is the spoken preview of a match, stored in blob storage. URL
+// is a signed link to download it directly, empty when the store cannot sign
+// links.
+type InsightAudio struct {
+	ContentType string
+	ETag        string
+	GeneratedAt time.Time
+	URL         string
+
+	store blob.Store
+	key   string
+}
+
+// Open opens the stored audio. The caller closes the reader.
+func (a *InsightAudio) Open() (io.ReadCloser, error) {
+	r, _, err := a.store.Get(a.key)
+	return r, err
+}
+
+// audioMeta is stored next to each audio file. ScriptHash identifies the text
+// it was spoken from, so an updated preview is spoken again.
+type audioMeta struct {
+	ScriptHash  string    `json:"scriptHash"`
+	ContentType string    `json:"contentType"`
+	Voice       string    `json:"voice"`
+	GeneratedBy string    `json:"generatedBy"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// InsightAudioService speaks generated match previews for podcast-style
+// matchday briefings, storing each until the preview it was spoken from
+// changes.
+type InsightAudioService struct {
+	previews *PreviewService
+	speech   tts.Synthesizer
+	store    blob.Store
+
+	mu    sync.Mutex
+	locks map[int]*sync.Mutex
+}
+
+// NewInsightAudioService stores audio under audio/ in store. A nil
+// synthesizer disables audio.
+func NewInsightAudioService(previews *PreviewService, speech tts.Synthesizer, store blob.Store) *InsightAudioService {
+	return &InsightAudioService{
+		previews: previews,
+		speech:   speech,
+		store:    store,
+		locks:    make(map[int]*sync.Mutex),
+	}
+}
+
+// Enabled reports whether a TTS provider is configured.
+func (s *InsightAudioService) Enabled() bool {
+	return s.speech != nil
+}
+
+// Preview returns the spoken preview of a match identified by its
+// football-data.org or internal ID, synthesizing it when the preview is new or
+// has changed. It returns nil if the match has no preview.
+func (s *InsightAudioService) Preview(id int) (*InsightAudio, error) {
+	// One recording serves every client, so it speaks the UTC preview
+	f, err := s.previews.GetFixturePreview(id, time.UTC)
+	if err != nil || f == nil {
+		return nil, err
+	}
+
+	script := previewScript(*f)
+	sum := sha256.Sum256([]byte(script))
+	hash := hex.EncodeToString(sum[:])
+
+	lock := s.lock(f.Match.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	meta, err := s.readMeta(f.Match.ID)
+	if err != nil {
+		return nil, err
+	}
+	if meta != nil && meta.ScriptHash == hash && meta.Voice == s.speech.Name() {
+		return s.audio(f.Match.ID, meta), nil
+	}
+
+	speech, err := s.speech.Synthesize(script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to synthesize preview: %w", err)
+	}
+	meta = &audioMeta{
+		ScriptHash:  hash,
+		ContentType: speech.ContentType,
+		Voice:       s.speech.Name(),
+		GeneratedBy: f.Narrative.GeneratedBy,
+		GeneratedAt: time.Now().UTC(),
+	}
+	if err := blob.PutBytes(s.store, s.audioKey(f.Match.ID), speech.Audio, speech.ContentType); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audio metadata: %w", err)
+	}
+	if err := blob.PutBytes(s.store, s.metaKey(f.Match.ID), data, "application/json"); err != nil {
+		return nil, err
+	}
+	return s.audio(f.Match.ID, meta), nil
+}
+
+// previewScript is what is read out for a fixture: the fixture, then its
+// written preview.
+func previewScript(f FixturePreview) string {
+	m := f.Match
+	return fmt.Sprintf("%s versus %s, %s. %s. %s",
+		m.HomeTeamName, m.AwayTeamName, m.UtcDate.Format("Monday 2 January"),
+		f.Narrative.Headline, f.Narrative.Summary)
+}
+
+func (s *InsightAudioService) lock(matchID int) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[matchID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[matchID] = lock
+	}
+	return lock
+}
+
+func (s *InsightAudioService) audio(matchID int, meta *audioMeta) *InsightAudio {
+	a := &InsightAudio{
+		ContentType: meta.ContentType,
+		ETag:        `"` + meta.ScriptHash[:16] + `"`,
+		GeneratedAt: meta.GeneratedAt,
+		store:       s.store,
+		key:         s.audioKey(matchID),
+	}
+	// Without a link the audio is streamed through the API instead
+	a.URL, _ = s.store.SignedURL(a.key, audioLinkTTL)
+	return a
+}
+
+func (s *InsightAudioService) audioKey(matchID int) string {
+	return "audio/previews/" + strconv.Itoa(matchID)
+}
+
+func (s *InsightAudioService) metaKey(matchID int) string {
+	return s.audioKey(matchID) + ".json"
+}
+
+// readMeta returns the metadata of a match's stored audio, or nil if it has
+// none.
+func (s *InsightAudioService) readMeta(matchID int) (*audioMeta, error) {
+	data, _, err := blob.ReadAll(s.store, s.metaKey(matchID))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio metadata: %w", err)
+	}
+
+	var meta audioMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, nil
+	}
+	if _, err := s.store.Stat(s.audioKey(matchID)); err != nil {
+		return nil, nil
+	}
+	return &meta, nil
+}
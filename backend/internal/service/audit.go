@@ -0,0 +1,33 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Audit actor types.
+const (
+	AuditActorAdmin = "admin"
+	AuditActorUser  = "user"
+	AuditActorCLI   = "cli"
+)
+
+// AuditService records and lists admin and operational actions.
+type AuditService struct {
+	repo *repository.AuditRepository
+}
+
+func NewAuditService(db *sql.DB) *AuditService {
+	return &AuditService{repo: repository.NewAuditRepository(db)}
+}
+
+// Record stores an entry.
+func (s *AuditService) Record(e repository.AuditEntry) error {
+	return s.repo.Record(e)
+}
+
+// List returns matching entries, newest first.
+func (s *AuditService) List(f repository.AuditFilter) ([]repository.AuditEntry, error) {
+	return s.repo.List(f)
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/model"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// BacktestBaseline predicts every fixture from how often home wins, draws and
+// away wins happened before it. It is the bar a model has to clear.
+const BacktestBaseline = "baseline"
+
+// baselineModelVersion identifies baseline backtests.
+const baselineModelVersion = "outcome-frequency-v1"
+
+// minLogLossProbability keeps log-loss finite for outcomes a model ruled out.
+const minLogLossProbability = 1e-15
+
+// BacktestOptions selects what a backtest replays. Season is the season
+// start year; an empty CompetitionCode replays every competition.
+type BacktestOptions struct {
+	Backend         string
+	CompetitionCode string
+	Season          string
+}
+
+// BacktestReport summarises a stored backtest. Skipped counts fixtures played
+// before any earlier result was stored, which cannot be predicted.
+type BacktestReport struct {
+	ID              int
+	Backend         string
+	ModelVersion    string
+	CompetitionCode string
+	Season          string
+	Matches         int
+	Correct         int
+	Skipped         int
+	Accuracy        float64
+	LogLoss         float64
+	Brier           float64
+}
+
+// BacktestService replays finished fixtures through a prediction backend
+// using only results stored before each kickoff.
+type BacktestService struct {
+	repo        *repository.BacktestRepository
+	predRepo    *repository.PredictionRepository
+	features    *FeatureService
+	predictions *PredictionService
+}
+
+// NewBacktestService replays the go, ml and ensemble backends through
+// predictions, whose configured backend does not matter.
+func NewBacktestService(db *sql.DB, predictions *PredictionService) *BacktestService {
+	return &BacktestService{
+		repo:        repository.NewBacktestRepository(db),
+		predRepo:    repository.NewPredictionRepository(db),
+		features:    NewFeatureService(db),
+		predictions: predictions,
+	}
+}
+
+// backtestPredictor predicts a fixture from its feature snapshot.
+type backtestPredictor func(f repository.BacktestFixture, snapshot *FeatureSnapshot) (*MatchPrediction, error)
+
+// Run replays a season and stores the predictions and scores. The model is
+// refitted for every kickoff day on the results before that day, within the
+// same training window the live model uses, and each fixture's features are
+// snapshotted as of that day through the feature store. The go, ml and
+// ensemble backends predict from that snapshot as live predictions do, the
+// ML service building its own features as of the same day.
+func (s *BacktestService) Run(opts BacktestOptions) (*BacktestReport, error) {
+	switch opts.Backend {
+	case BackendGo, BackendML, BackendEnsemble, BacktestBaseline:
+	default:
+		return nil, fmt.Errorf("unknown backend %q (valid: %s, %s, %s, %s)", opts.Backend, BackendGo, BackendML, BackendEnsemble, BacktestBaseline)
+	}
+	competitionCode := strings.ToUpper(opts.CompetitionCode)
+
+	fixtures, err := s.repo.ListFixtures(competitionCode, opts.Season)
+	if err != nil {
+		return nil, err
+	}
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("no finished fixtures stored for season %s", opts.Season)
+	}
+
+	started := time.Now().UTC()
+	report := &BacktestReport{
+		Backend:         opts.Backend,
+		CompetitionCode: competitionCode,
+		Season:          opts.Season,
+	}
+
+	var (
+		predictions []repository.BacktestPrediction
+		predict     backtestPredictor
+		results     []repository.TrainingResult
+		day         time.Time
+	)
+	for _, f := range fixtures {
+		if kickoffDay := f.UtcDate.UTC().Truncate(24 * time.Hour); predict == nil || !kickoffDay.Equal(day) {
+			day = kickoffDay
+			if results, err = s.predRepo.ListTrainingResultsBetween(day.Add(-trainingWindow), day); err != nil {
+				return nil, err
+			}
+			if predict, err = s.fit(opts.Backend, results, day); err != nil {
+				if !errors.Is(err, model.ErrNoResults) {
+					return nil, fmt.Errorf("failed to fit model for %s: %w", day.Format("2006-01-02"), err)
+				}
+				predict = nil
+			}
+		}
+		if predict == nil {
+			report.Skipped++
+			continue
+		}
+
+		snapshot, err := s.features.SnapshotFrom(results, f.ID, f.HomeTeamID, f.AwayTeamID, day, FeatureSourceBacktest)
+		if err != nil {
+			return nil, err
+		}
+
+		prediction, err := predict(f, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to predict match %d: %w", f.ID, err)
+		}
+		report.ModelVersion = prediction.ModelVersion
+		home, draw, away := prediction.HomeWinProbability, prediction.DrawProbability, prediction.AwayWinProbability
+		p := repository.BacktestPrediction{
+			MatchID:            f.ID,
+			HomeWinProbability: home,
+			DrawProbability:    draw,
+			AwayWinProbability: away,
+			PredictedOutcome:   likeliestOutcome(home, draw, away),
+			ActualOutcome:      outcomeOf(f.HomeScore, f.AwayScore),
+			ExpectedHomeGoals:  prediction.ExpectedHomeGoals,
+			ExpectedAwayGoals:  prediction.ExpectedAwayGoals,
+			TrainedOn:          len(results),
+		}
+		predictions = append(predictions, p)
+
+		actual := map[string]float64{"HOME": home, "DRAW": draw, "AWAY": away}
+		if p.PredictedOutcome == p.ActualOutcome {
+			report.Correct++
+		}
+		report.LogLoss -= math.Log(math.Max(actual[p.ActualOutcome], minLogLossProbability))
+		for outcome, prob := range actual {
+			hit := 0.0
+			if outcome == p.ActualOutcome {
+				hit = 1
+			}
+			report.Brier += (prob - hit) * (prob - hit)
+		}
+	}
+
+	report.Matches = len(predictions)
+	if report.Matches == 0 {
+		return nil, fmt.Errorf("no fixture of season %s had earlier results to train on", opts.Season)
+	}
+	n := float64(report.Matches)
+	report.Accuracy = float64(report.Correct) / n
+	report.LogLoss /= n
+	report.Brier /= n
+
+	report.ID, err = s.repo.Save(repository.Backtest{
+		Backend:         report.Backend,
+		ModelVersion:    report.ModelVersion,
+		CompetitionCode: report.CompetitionCode,
+		Season:          report.Season,
+		Matches:         report.Matches,
+		Correct:         report.Correct,
+		Accuracy:        &report.Accuracy,
+		LogLoss:         &report.LogLoss,
+		Brier:           &report.Brier,
+		StartedAt:       started,
+	}, predictions)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// fit fits a backend on the results known at a point in time.
+func (s *BacktestService) fit(backend string, results []repository.TrainingResult, at time.Time) (backtestPredictor, error) {
+	if len(results) == 0 {
+		return nil, model.ErrNoResults
+	}
+
+	if backend == BacktestBaseline {
+		// Add-one smoothing keeps every outcome possible
+		counts := map[string]float64{"HOME": 1, "DRAW": 1, "AWAY": 1}
+		for _, r := range results {
+			counts[outcomeOf(r.HomeScore, r.AwayScore)]++
+		}
+		total := float64(len(results) + 3)
+		return func(repository.BacktestFixture, *FeatureSnapshot) (*MatchPrediction, error) {
+			return &MatchPrediction{
+				HomeWinProbability: counts["HOME"] / total,
+				DrawProbability:    counts["DRAW"] / total,
+				AwayWinProbability: counts["AWAY"] / total,
+				ModelVersion:       baselineModelVersion,
+			}, nil
+		}, nil
+	}
+
+	m, err := model.Fit(modelResults(results), model.Options{Now: at})
+	if err != nil {
+		return nil, err
+	}
+	return func(f repository.BacktestFixture, snapshot *FeatureSnapshot) (*MatchPrediction, error) {
+		in := PredictionInput{
+			MatchID:            f.ID,
+			HomeTeamID:         f.HomeTeamID,
+			AwayTeamID:         f.AwayTeamID,
+			HomeTeamExternalID: f.HomeTeamExternalID,
+			AwayTeamExternalID: f.AwayTeamExternalID,
+			HomeTeamName:       f.HomeTeamName,
+			AwayTeamName:       f.AwayTeamName,
+			Matchday:           f.Matchday,
+		}
+		if f.CompetitionID != nil {
+			in.CompetitionHomeAdvantage = competitionHomeAdvantage(results, *f.CompetitionID)
+		}
+		return s.predictions.Replay(context.Background(), backend, in, snapshot, m)
+	}, nil
+}
+
+// competitionHomeAdvantage is a competition's home points per game less its
+// away points per game over results, or 0 without any.
+func competitionHomeAdvantage(results []repository.TrainingResult, competitionID int) float64 {
+	var matches, difference int
+	for _, r := range results {
+		if r.CompetitionID == nil || *r.CompetitionID != competitionID {
+			continue
+		}
+		matches++
+		difference += resultPoints(r.HomeScore, r.AwayScore) - resultPoints(r.AwayScore, r.HomeScore)
+	}
+	if matches == 0 {
+		return 0
+	}
+	return math.Round(float64(difference)/float64(matches)*100) / 100
+}
+
+// modelResults converts stored results for fitting the Go model.
+func modelResults(rows []repository.TrainingResult) []model.Result {
+	results := make([]model.Result, 0, len(rows))
+	for _, r := range rows {
+		result := model.Result{
+			HomeTeamID: r.HomeTeamID,
+			AwayTeamID: r.AwayTeamID,
+			HomeGoals:  r.HomeScore,
+			AwayGoals:  r.AwayScore,
+			Date:       r.UtcDate,
+		}
+		if r.CompetitionID != nil {
+			result.CompetitionID = *r.CompetitionID
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// likeliestOutcome picks the outcome served predictions would, preferring a
+// home win, then an away win, on ties.
+func likeliestOutcome(home, draw, away float64) string {
+	switch {
+	case home >= draw && home >= away:
+		return "HOME"
+	case away >= draw:
+		return "AWAY"
+	default:
+		return "DRAW"
+	}
+}
+
+func outcomeOf(homeGoals, awayGoals int) string {
+	switch {
+	case homeGoals > awayGoals:
+		return "HOME"
+	case homeGoals < awayGoals:
+		return "AWAY"
+	default:
+		return "DRAW"
+	}
+}
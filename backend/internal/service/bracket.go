@@ -0,0 +1,237 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// How a two-legged tie was decided, besides the DecidedBy values of its last
+// leg when that went to extra time or penalties.
+const (
+	TieAggregate = "AGGREGATE"
+	TieAwayGoals = "AWAY_GOALS"
+)
+
+// Bracket is the knockout tree of a competition season, built from stored
+// matches. Rounds are in the order they are played; a season without
+// knockout matches has no rounds.
+type Bracket struct {
+	CompetitionCode string         `json:"competitionCode"`
+	Season          int            `json:"season"`
+	Rounds          []BracketRound `json:"rounds"`
+}
+
+// BracketRound is one knockout stage, e.g. QUARTER_FINALS, with its ties in
+// order of the first leg's kickoff.
+type BracketRound struct {
+	Stage string       `json:"stage"`
+	Ties  []BracketTie `json:"ties"`
+}
+
+// BracketTeam is one side of a tie.
+type BracketTeam struct {
+	ID         int    `json:"id"`
+	ExternalID int    `json:"externalId"`
+	Name       string `json:"name"`
+}
+
+// BracketTie is a pairing of one round, over one or two legs. ID is that of
+// a linked two-legged tie, served at /ties/:id. TeamA is the home side of
+// the first leg and Aggregate counts goals in play over the finished legs,
+// nil before the first leg has finished. Winner is set once every leg has
+// finished and the tie is decided; NextTie is then the index of the tie the
+// winner plays in the next round, if it is stored.
+type BracketTie struct {
+	ID        *int                       `json:"id,omitempty"`
+	TeamA     BracketTeam                `json:"teamA"`
+	TeamB     BracketTeam                `json:"teamB"`
+	Aggregate *TieScore                  `json:"aggregate"`
+	Winner    *BracketTeam               `json:"winner"`
+	DecidedBy string                     `json:"decidedBy,omitempty"`
+	NextTie   *int                       `json:"nextTie,omitempty"`
+	Legs      []repository.KnockoutMatch `json:"legs"`
+}
+
+// TieScore is the aggregate score of a tie.
+type TieScore struct {
+	TeamA int `json:"teamA"`
+	TeamB int `json:"teamB"`
+}
+
+// GetBracket returns the knockout tree of a competition season, or nil if the
+// season is not stored. season is the start year, empty for the most recent
+// season.
+func (s *CompetitionService) GetBracket(competitionCode, season string) (*Bracket, error) {
+	competitionCode = strings.ToUpper(competitionCode)
+
+	stored, err := s.matchRepo.ResolveSeason(competitionCode, season)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+
+	matches, err := s.matchRepo.ListKnockoutMatches(competitionCode, stored.Season, football.KnockoutStages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bracket{
+		CompetitionCode: competitionCode,
+		Season:          stored.Year,
+		Rounds:          buildBracket(matches),
+	}, nil
+}
+
+// buildBracket groups knockout matches, in kickoff order, into rounds and
+// ties and links each decided tie to the winner's tie in the next round.
+// The third-place match is a round of its own outside those links.
+func buildBracket(matches []repository.KnockoutMatch) []BracketRound {
+	rounds := []BracketRound{}
+	for _, stage := range football.KnockoutStages {
+		round := BracketRound{Stage: stage, Ties: []BracketTie{}}
+		// Tie index by the pair of internal team IDs, lower first
+		ties := map[[2]int]int{}
+		for _, m := range matches {
+			if m.Stage != stage {
+				continue
+			}
+			key := [2]int{min(m.HomeTeamID, m.AwayTeamID), max(m.HomeTeamID, m.AwayTeamID)}
+			i, ok := ties[key]
+			if !ok {
+				i = len(round.Ties)
+				ties[key] = i
+				round.Ties = append(round.Ties, BracketTie{
+					ID:    m.TieID,
+					TeamA: BracketTeam{ID: m.HomeTeamID, ExternalID: m.HomeTeamExternalID, Name: m.HomeTeamName},
+					TeamB: BracketTeam{ID: m.AwayTeamID, ExternalID: m.AwayTeamExternalID, Name: m.AwayTeamName},
+				})
+			}
+			round.Ties[i].Legs = append(round.Ties[i].Legs, m)
+		}
+		if len(round.Ties) == 0 {
+			continue
+		}
+		for i := range round.Ties {
+			round.Ties[i].settle()
+		}
+		rounds = append(rounds, round)
+	}
+
+	for r, round := range rounds {
+		if round.Stage == football.StageThirdPlace {
+			continue
+		}
+		next := r + 1
+		if next < len(rounds) && rounds[next].Stage == football.StageThirdPlace {
+			next++
+		}
+		if next >= len(rounds) {
+			continue
+		}
+		for i := range round.Ties {
+			tie := &round.Ties[i]
+			if tie.Winner == nil {
+				continue
+			}
+			for j, t := range rounds[next].Ties {
+				if t.TeamA.ID == tie.Winner.ID || t.TeamB.ID == tie.Winner.ID {
+					j := j
+					tie.NextTie = &j
+					break
+				}
+			}
+		}
+	}
+	return rounds
+}
+
+// settle adds up the aggregate score and, once every leg has finished,
+// decides the tie: on aggregate, then on away goals, then on the last leg's
+// shootout.
+func (t *BracketTie) settle() {
+	state := t.aggregateState()
+	if state.finished == 0 {
+		return
+	}
+	t.Aggregate = &TieScore{TeamA: state.a, TeamB: state.b}
+	if state.finished < len(t.Legs) {
+		return
+	}
+
+	last := t.Legs[len(t.Legs)-1]
+	t.DecidedBy = last.DecidedBy
+	if len(t.Legs) > 1 && last.DecidedBy == football.DurationRegular {
+		t.DecidedBy = TieAggregate
+	}
+
+	switch {
+	case state.a != state.b:
+		t.setWinner(state.a > state.b)
+	case len(t.Legs) > 1 && state.awayA != state.awayB && last.DecidedBy != football.DurationPenalties:
+		t.DecidedBy = TieAwayGoals
+		t.setWinner(state.awayA > state.awayB)
+	case last.Penalties.Home != nil && last.Penalties.Away != nil && *last.Penalties.Home != *last.Penalties.Away:
+		homeWon := *last.Penalties.Home > *last.Penalties.Away
+		t.setWinner(homeWon == (last.HomeTeamID == t.TeamA.ID))
+	default:
+		// Level with no tie-breaker stored
+		t.DecidedBy = ""
+	}
+}
+
+func (t *BracketTie) setWinner(teamA bool) {
+	winner := t.TeamB
+	if teamA {
+		winner = t.TeamA
+	}
+	t.Winner = &winner
+}
+
+// tieState is the aggregate and away goals of a tie from TeamA's side, over
+// its finished legs.
+type tieState struct {
+	a, b, awayA, awayB int
+	finished           int
+}
+
+func (t *BracketTie) aggregateState() tieState {
+	var state tieState
+	for _, leg := range t.Legs {
+		if leg.Status != "FINISHED" || leg.HomeScore == nil || leg.AwayScore == nil {
+			continue
+		}
+		state.finished++
+		state.add(leg.HomeTeamID == t.TeamA.ID, *leg.HomeScore, *leg.AwayScore)
+	}
+	return state
+}
+
+// add counts a leg's goals, home being whether TeamA was at home.
+func (s *tieState) add(home bool, homeGoals, awayGoals int) {
+	if home {
+		s.a += homeGoals
+		s.b += awayGoals
+		s.awayB += awayGoals
+	} else {
+		s.a += awayGoals
+		s.b += homeGoals
+		s.awayA += awayGoals
+	}
+}
+
+// leader reports whether TeamA would go through if the tie ended now, and
+// false for ok when it would go to extra time.
+func (s tieState) leader(awayGoalsRule bool) (teamA, ok bool) {
+	switch {
+	case s.a != s.b:
+		return s.a > s.b, true
+	case awayGoalsRule && s.awayA != s.awayB:
+		return s.awayA > s.awayB, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+	"github.com/yourusername/football-prediction/pkg/ical"
+)
+
+// Fixture calendars cover matches from calendarHistory ago onwards, at most
+// calendarMatches of them, and ask clients to refresh every calendarRefresh.
+const (
+	calendarHistory = 90 * 24 * time.Hour
+	calendarMatches = 500
+	calendarRefresh = 6 * time.Hour
+	calendarProdID  = "-//football-prediction//fixtures//EN"
+)
+
+// matchLength is how long a calendar event blocks out: 90 minutes, half-time
+// and stoppages.
+const matchLength = 2 * time.Hour
+
+// FixturesCalendar returns a team's fixtures and recent results as a
+// calendar, or nil if the team does not exist. The team is identified by its
+// football-data.org or internal ID.
+func (s *TeamService) FixturesCalendar(id int) (*ical.Calendar, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.ListCalendarMatches(team.ID, "", time.Now().Add(-calendarHistory), calendarMatches)
+	if err != nil {
+		return nil, err
+	}
+	return fixturesCalendar(team.Name, matches), nil
+}
+
+// FixturesCalendar returns a competition's fixtures and recent results as a
+// calendar, or nil if the competition is not stored.
+func (s *CompetitionService) FixturesCalendar(code string) (*ical.Calendar, error) {
+	code = strings.ToUpper(code)
+
+	comp, err := s.compRepo.GetByCode(code)
+	if err != nil || comp == nil {
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.ListCalendarMatches(0, code, time.Now().Add(-calendarHistory), calendarMatches)
+	if err != nil {
+		return nil, err
+	}
+	return fixturesCalendar(comp.Name, matches), nil
+}
+
+func fixturesCalendar(name string, matches []repository.CalendarMatch) *ical.Calendar {
+	cal := &ical.Calendar{
+		ProdID:          calendarProdID,
+		Name:            name + " fixtures",
+		RefreshInterval: calendarRefresh,
+		Events:          make([]ical.Event, 0, len(matches)),
+	}
+	for _, m := range matches {
+		cal.Events = append(cal.Events, matchEvent(m))
+	}
+	return cal
+}
+
+// matchEvent turns a match into a calendar event. The UID derives from the
+// football-data.org match ID, so a rescheduled match updates the event its
+// subscribers already have rather than adding another.
+func matchEvent(m repository.CalendarMatch) ical.Event {
+	summary := m.HomeTeamName + " vs " + m.AwayTeamName
+	if m.Status == "FINISHED" && m.HomeScore != nil && m.AwayScore != nil {
+		summary = fmt.Sprintf("%s %d-%d %s", m.HomeTeamName, *m.HomeScore, *m.AwayScore, m.AwayTeamName)
+	}
+
+	status := ical.StatusConfirmed
+	switch m.Status {
+	case "CANCELLED":
+		status = ical.StatusCancelled
+	case "POSTPONED", "SCHEDULED":
+		// Postponed, or the kickoff time is not confirmed yet
+		status = ical.StatusTentative
+	}
+
+	description := m.CompetitionName
+	switch {
+	case m.Stage != "" && m.Stage != football.StageRegularSeason:
+		description += ", " + strings.ToLower(strings.ReplaceAll(m.Stage, "_", " "))
+	case m.Matchday > 0:
+		description += fmt.Sprintf(", matchday %d", m.Matchday)
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("match-%d@football-prediction", m.ExternalID),
+		Sequence:    m.Sequence,
+		Stamp:       m.UpdatedAt,
+		Start:       m.UtcDate,
+		End:         m.UtcDate.Add(matchLength),
+		Summary:     summary,
+		Description: description,
+		Location:    m.Venue,
+		Status:      status,
+	}
+}
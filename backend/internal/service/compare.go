@@ -0,0 +1,190 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/model"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+const (
+	// comparisonKeyPlayers is how many top scorers each side lists.
+	comparisonKeyPlayers = 3
+	// comparisonMeetings is how many past meetings the head-to-head covers.
+	comparisonMeetings = 10
+)
+
+// TeamComparison is two teams' seasons side by side, the tale of the tape
+// of a comparison page. HeadToHead counts team A's wins as home wins and
+// team B's as away wins, whichever side was at home, and is nil when they
+// have never met.
+type TeamComparison struct {
+	Season     string                       `json:"season"`
+	TeamA      ComparedTeam                 `json:"teamA"`
+	TeamB      ComparedTeam                 `json:"teamB"`
+	HeadToHead *repository.HeadToHeadRecord `json:"headToHead"`
+}
+
+// ComparedTeam is one side of a comparison. Form lists the team's last
+// results of the season, most recent first, and Elo its rating after its
+// last match of the season. League is nil when the team played no league
+// match that season, and KeyPlayers are its top scorers in it.
+type ComparedTeam struct {
+	Team       *repository.TeamInfo      `json:"team"`
+	Form       string                    `json:"form"`
+	FormPoints int                       `json:"formPoints"`
+	Elo        float64                   `json:"elo"`
+	League     *repository.LeagueProfile `json:"league"`
+	KeyPlayers []repository.ScorerRow    `json:"keyPlayers"`
+	Discipline ComparedDiscipline        `json:"discipline"`
+}
+
+// ComparedDiscipline is a team's league cards over the matches with
+// recorded events, nil per-match rates without any, and its players one
+// booking from a ban as of now, whatever the season.
+type ComparedDiscipline struct {
+	MatchesWithEvents   int              `json:"matchesWithEvents"`
+	YellowCards         int              `json:"yellowCards"`
+	SendingsOff         int              `json:"sendingsOff"`
+	YellowCardsPerMatch *float64         `json:"yellowCardsPerMatch"`
+	SendingsOffPerMatch *float64         `json:"sendingsOffPerMatch"`
+	AtRiskOfBan         []SuspensionRisk `json:"atRiskOfBan"`
+}
+
+// Compare returns two teams, each identified by its football-data.org or
+// internal ID, side by side over a season, or nil if either does not exist.
+// season is the season start year.
+func (s *TeamService) Compare(idA, idB int, season string) (*TeamComparison, error) {
+	teamA, err := s.resolveTeam(idA)
+	if err != nil || teamA == nil {
+		return nil, err
+	}
+	teamB, err := s.resolveTeam(idB)
+	if err != nil || teamB == nil {
+		return nil, err
+	}
+
+	profiles, err := s.matchRepo.ListLeagueProfiles([]int{teamA.ID, teamB.ID}, season)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &TeamComparison{
+		Season: season,
+		TeamA:  ComparedTeam{Team: teamA},
+		TeamB:  ComparedTeam{Team: teamB},
+	}
+	now := time.Now()
+	eloAsOf := time.Time{}
+	for _, side := range []*ComparedTeam{&comparison.TeamA, &comparison.TeamB} {
+		for i := range profiles {
+			if profiles[i].TeamID == side.Team.ID {
+				side.League = &profiles[i]
+			}
+		}
+
+		last, err := s.seasonForm(side, season)
+		if err != nil {
+			return nil, err
+		}
+		if last.After(eloAsOf) {
+			eloAsOf = last
+		}
+
+		competitionCode := ""
+		if side.League != nil {
+			competitionCode = side.League.CompetitionCode
+		}
+		side.KeyPlayers, err = s.playerRepo.GetTopScorers(repository.ScorerFilter{
+			TeamID:          side.Team.ID,
+			CompetitionCode: competitionCode,
+			Season:          season,
+			Limit:           comparisonKeyPlayers,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if side.KeyPlayers == nil {
+			side.KeyPlayers = []repository.ScorerRow{}
+		}
+
+		bookings, err := s.playerRepo.ListTeamBookings(side.Team.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		side.Discipline = comparedDiscipline(side.League, atRiskOfBan(bookings, ""))
+	}
+
+	// Rate both teams on the results known after the later of their last
+	// matches of the season, or now when neither has played in it
+	if eloAsOf.IsZero() {
+		eloAsOf = now
+	} else {
+		eloAsOf = eloAsOf.Add(time.Second)
+	}
+	results, err := s.predRepo.ListTrainingResultsBetween(eloAsOf.Add(-trainingWindow), eloAsOf)
+	if err != nil {
+		return nil, err
+	}
+	ratings := model.Elo(modelResults(results))
+	comparison.TeamA.Elo = math.Round(model.EloRating(ratings, teamA.ID)*10) / 10
+	comparison.TeamB.Elo = math.Round(model.EloRating(ratings, teamB.ID)*10) / 10
+
+	comparison.HeadToHead, err = s.matchRepo.GetHeadToHeadByExternalTeamIDs(teamA.ExternalID, teamB.ExternalID, repository.HeadToHeadFilter{
+		Limit:     comparisonMeetings,
+		Weighting: &DefaultWeighting,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// seasonForm sets a team's form from its last formMatches results of the
+// season and returns when the latest of them kicked off, zero without any.
+func (s *TeamService) seasonForm(side *ComparedTeam, season string) (time.Time, error) {
+	recent, err := s.matchRepo.ListTeamMatches(repository.TeamMatchFilter{
+		TeamID: side.Team.ID,
+		Season: season,
+		Status: "FINISHED",
+		Limit:  formMatches,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var last time.Time
+	for _, m := range recent {
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		gf, ga := *m.HomeScore, *m.AwayScore
+		if m.AwayTeamID == side.Team.ID {
+			gf, ga = ga, gf
+		}
+		side.Form += resultLetter(gf, ga)
+		side.FormPoints += resultPoints(gf, ga)
+		if m.UtcDate.After(last) {
+			last = m.UtcDate
+		}
+	}
+	return last, nil
+}
+
+// comparedDiscipline takes a team's league cards from its profile, if it
+// has one.
+func comparedDiscipline(league *repository.LeagueProfile, atRisk []SuspensionRisk) ComparedDiscipline {
+	d := ComparedDiscipline{AtRiskOfBan: atRisk}
+	if league == nil {
+		return d
+	}
+	d.MatchesWithEvents = league.MatchesWithEvents
+	d.YellowCards = league.YellowCards
+	d.SendingsOff = league.SendingsOff
+	if d.MatchesWithEvents > 0 {
+		d.YellowCardsPerMatch = roundedAvg(d.YellowCards, d.MatchesWithEvents)
+		d.SendingsOffPerMatch = roundedAvg(d.SendingsOff, d.MatchesWithEvents)
+	}
+	return d
+}
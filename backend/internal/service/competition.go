@@ -0,0 +1,240 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// knownCodesTTL is how long stored competition codes are cached for
+// IsKnownCode.
+const knownCodesTTL = 10 * time.Minute
+
+// CompetitionService serves competition views built from stored matches.
+type CompetitionService struct {
+	matchRepo     *repository.MatchRepository
+	compRepo      *repository.CompetitionRepository
+	standingsRepo *repository.StandingsRepository
+
+	mu         sync.Mutex
+	knownCodes map[string]bool
+	loadedAt   time.Time
+}
+
+func NewCompetitionService(db *sql.DB) *CompetitionService {
+	return &CompetitionService{
+		matchRepo:     repository.NewMatchRepository(db),
+		compRepo:      repository.NewCompetitionRepository(db),
+		standingsRepo: repository.NewStandingsRepository(db),
+	}
+}
+
+// IsKnownCode reports whether code, in any case, is a football-data.org
+// competition or one stored in the database. Stored codes are reloaded every
+// knownCodesTTL; if they cannot be loaded the last known set is used.
+func (s *CompetitionService) IsKnownCode(code string) bool {
+	code = strings.ToUpper(code)
+	for _, c := range football.CompetitionCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.loadedAt) > knownCodesTTL {
+		if competitions, err := s.compRepo.List(); err == nil {
+			s.knownCodes = make(map[string]bool, len(competitions))
+			for _, c := range competitions {
+				s.knownCodes[strings.ToUpper(c.Code)] = true
+			}
+		}
+		// Failed loads are retried after the TTL too, not on every request
+		s.loadedAt = time.Now()
+	}
+	return s.knownCodes[code]
+}
+
+// DataCoverage flags which kinds of detail data are stored for the matches
+// of a season, or of any season at the competition level.
+type DataCoverage struct {
+	Lineups     bool `json:"hasLineups"`
+	PlayerStats bool `json:"hasPlayerStats"`
+	TeamStats   bool `json:"hasTeamStats"`
+}
+
+// CompetitionSeason is a stored season with its match counts and coverage.
+type CompetitionSeason struct {
+	repository.SeasonCoverage
+	Coverage DataCoverage `json:"coverage"`
+}
+
+// CompetitionMetadata is a stored competition with the seasons held for it,
+// so clients know what data to expect before querying it.
+type CompetitionMetadata struct {
+	*football.Competition
+	CurrentMatchday int                 `json:"currentMatchday"`
+	Coverage        DataCoverage        `json:"coverage"`
+	Seasons         []CompetitionSeason `json:"seasons"`
+}
+
+// GetMetadata returns a competition with its stored seasons, most recent
+// first, or nil if the competition is not stored. The current matchday is
+// that of the most recent season.
+func (s *CompetitionService) GetMetadata(code string) (*CompetitionMetadata, error) {
+	code = strings.ToUpper(code)
+
+	comp, err := s.compRepo.GetByCode(code)
+	if err != nil || comp == nil {
+		return nil, err
+	}
+
+	coverage, err := s.compRepo.ListSeasonCoverage(code)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &CompetitionMetadata{Competition: comp, Seasons: make([]CompetitionSeason, 0, len(coverage))}
+	for _, c := range coverage {
+		season := CompetitionSeason{
+			SeasonCoverage: c,
+			Coverage: DataCoverage{
+				Lineups:     c.MatchesWithLineups > 0,
+				PlayerStats: c.MatchesWithPlayerStats > 0,
+				TeamStats:   c.MatchesWithTeamStats > 0,
+			},
+		}
+		meta.Coverage.Lineups = meta.Coverage.Lineups || season.Coverage.Lineups
+		meta.Coverage.PlayerStats = meta.Coverage.PlayerStats || season.Coverage.PlayerStats
+		meta.Coverage.TeamStats = meta.Coverage.TeamStats || season.Coverage.TeamStats
+		meta.Seasons = append(meta.Seasons, season)
+	}
+
+	if len(coverage) > 0 {
+		rng, err := s.matchRepo.GetMatchdayRange(code, coverage[0].Season)
+		if err != nil {
+			return nil, err
+		}
+		meta.CurrentMatchday = rng.Current
+	}
+
+	return meta, nil
+}
+
+// MatchdayTableRow is a table line after a round with its movement since the
+// previous round. Change is positive when the team climbed.
+type MatchdayTableRow struct {
+	repository.TableRow
+	PreviousPosition int  `json:"previousPosition,omitempty"`
+	Change           int  `json:"change"`
+	PlayedThisRound  bool `json:"playedThisRound"`
+}
+
+// Matchday is one round of a competition season with its fixtures and the
+// table as it stood after the round.
+type Matchday struct {
+	CompetitionCode string                    `json:"competitionCode"`
+	Season          int                       `json:"season"`
+	Matchday        int                       `json:"matchday"`
+	Range           repository.MatchdayRange  `json:"matchdays"`
+	Matches         []repository.MatchSummary `json:"matches"`
+	Table           []MatchdayTableRow        `json:"table"`
+}
+
+// GetMatchday returns a round of a competition season, or nil if the season
+// or round is not stored. Tables are ranked by the competition's standings
+// rules. season is the start year (empty for the most recent
+// season) and matchday 0 means the current round.
+func (s *CompetitionService) GetMatchday(competitionCode, season string, matchday int) (*Matchday, error) {
+	competitionCode = strings.ToUpper(competitionCode)
+
+	stored, err := s.matchRepo.ResolveSeason(competitionCode, season)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+
+	rng, err := s.matchRepo.GetMatchdayRange(competitionCode, stored.Season)
+	if err != nil {
+		return nil, err
+	}
+	if matchday == 0 {
+		matchday = rng.Current
+	}
+	if matchday < rng.First || matchday > rng.Last || matchday == 0 {
+		return nil, nil
+	}
+
+	matches, err := s.matchRepo.ListMatchday(competitionCode, stored.Season, matchday)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := standingsRules(s.standingsRepo, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.matchRepo.ListSeasonResults(competitionCode, stored.Season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build table: %w", err)
+	}
+	after := computeTable(resultsThroughMatchday(results, matchday), *rules)
+	before := computeTable(resultsThroughMatchday(results, matchday-1), *rules)
+
+	return &Matchday{
+		CompetitionCode: competitionCode,
+		Season:          stored.Year,
+		Matchday:        matchday,
+		Range:           *rng,
+		Matches:         matches,
+		Table:           tableDelta(before, after, matches),
+	}, nil
+}
+
+// resultsThroughMatchday returns the results of rounds up to and including
+// matchday.
+func resultsThroughMatchday(results []repository.MatchSummary, matchday int) []repository.MatchSummary {
+	var through []repository.MatchSummary
+	for _, m := range results {
+		if m.Matchday > 0 && m.Matchday <= matchday {
+			through = append(through, m)
+		}
+	}
+	return through
+}
+
+// tableDelta annotates the table after a round with each team's movement.
+// Teams absent from the previous table have no previous position and no
+// change.
+func tableDelta(before, after []repository.TableRow, matches []repository.MatchSummary) []MatchdayTableRow {
+	previous := make(map[int]int, len(before))
+	for _, row := range before {
+		previous[row.TeamID] = row.Position
+	}
+
+	played := make(map[int]bool)
+	for _, m := range matches {
+		if m.Status == "FINISHED" {
+			played[m.HomeTeamID] = true
+			played[m.AwayTeamID] = true
+		}
+	}
+
+	rows := make([]MatchdayTableRow, 0, len(after))
+	for _, row := range after {
+		r := MatchdayTableRow{TableRow: row, PlayedThisRound: played[row.TeamID]}
+		if pos, ok := previous[row.TeamID]; ok {
+			r.PreviousPosition = pos
+			r.Change = pos - row.Position
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
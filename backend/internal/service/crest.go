@@ -0,0 +1,314 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/blob"
+)
+
+// maxCrestBytes caps the size of a crest image fetched from a provider.
+const maxCrestBytes = 1 << 20
+
+// Crest is a team crest cached in blob storage.
+type Crest struct {
+	ContentType string
+	ETag        string
+	FetchedAt   time.Time
+
+	store blob.Store
+	key   string
+}
+
+// Read returns the cached image, which is at most maxCrestBytes.
+func (c *Crest) Read() ([]byte, error) {
+	data, _, err := blob.ReadAll(c.store, c.key)
+	return data, err
+}
+
+// crestMeta is stored next to each cached image. The upstream validators
+// let refreshes use conditional requests.
+type crestMeta struct {
+	SourceURL     string    `json:"sourceUrl"`
+	ContentType   string    `json:"contentType"`
+	ETag          string    `json:"etag"`
+	UpstreamETag  string    `json:"upstreamEtag,omitempty"`
+	LastModified  string    `json:"lastModified,omitempty"`
+	FetchedAt     time.Time `json:"fetchedAt"`
+	RevalidatedAt time.Time `json:"revalidatedAt"`
+}
+
+// CrestService mirrors team crests from provider CDNs into blob storage so
+// clients never load them from third-party hosts.
+type CrestService struct {
+	teamRepo   *repository.TeamRepository
+	store      blob.Store
+	maxAge     time.Duration
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	locks map[int]*sync.Mutex
+}
+
+// NewCrestService caches crests under crests/ in store and treats copies
+// older than maxAge as due for a refresh.
+func NewCrestService(db *sql.DB, store blob.Store, maxAge time.Duration) *CrestService {
+	return &CrestService{
+		teamRepo: repository.NewTeamRepository(db),
+		store:    store,
+		maxAge:   maxAge,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		locks: make(map[int]*sync.Mutex),
+	}
+}
+
+// Get returns the cached crest of a team identified by its football-data.org
+// or internal ID, fetching it on first use. It returns nil if the team does
+// not exist or has no crest. A stale copy is served when the provider is
+// unreachable; the refresh job brings it up to date.
+func (s *CrestService) Get(id int) (*Crest, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		if team, err = s.teamRepo.GetByID(id); err != nil {
+			return nil, err
+		}
+	}
+	if team == nil || team.CrestURL == "" {
+		return nil, nil
+	}
+
+	lock := s.lock(team.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	meta, err := s.readMeta(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	if meta != nil && meta.SourceURL == team.CrestURL {
+		return s.crest(team.ID, meta), nil
+	}
+
+	fresh, err := s.fetch(team.ID, team.CrestURL, nil)
+	if err != nil {
+		if meta != nil {
+			return s.crest(team.ID, meta), nil
+		}
+		return nil, err
+	}
+	return s.crest(team.ID, fresh), nil
+}
+
+// Refresh re-fetches up to limit crests that are missing, older than the
+// maximum age or whose provider URL has changed. It returns the number of
+// crests checked against the provider; failures are skipped and reported
+// together so one broken URL does not hold up the rest.
+func (s *CrestService) Refresh(limit int) (int, error) {
+	crests, err := s.teamRepo.ListCrests()
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	var errs []error
+	for _, c := range crests {
+		if refreshed >= limit {
+			break
+		}
+
+		lock := s.lock(c.TeamID)
+		lock.Lock()
+		meta, err := s.readMeta(c.TeamID)
+		if err == nil && !s.due(meta, c.CrestURL) {
+			lock.Unlock()
+			continue
+		}
+		if meta != nil && meta.SourceURL != c.CrestURL {
+			meta = nil
+		}
+		if err == nil {
+			_, err = s.fetch(c.TeamID, c.CrestURL, meta)
+		}
+		lock.Unlock()
+
+		refreshed++
+		if err != nil {
+			errs = append(errs, fmt.Errorf("team %d: %w", c.TeamID, err))
+		}
+	}
+	return refreshed, errors.Join(errs...)
+}
+
+func (s *CrestService) due(meta *crestMeta, sourceURL string) bool {
+	return meta == nil || meta.SourceURL != sourceURL || time.Since(meta.RevalidatedAt) > s.maxAge
+}
+
+func (s *CrestService) lock(teamID int) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[teamID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[teamID] = lock
+	}
+	return lock
+}
+
+func (s *CrestService) crest(teamID int, meta *crestMeta) *Crest {
+	return &Crest{
+		ContentType: meta.ContentType,
+		ETag:        meta.ETag,
+		FetchedAt:   meta.FetchedAt,
+		store:       s.store,
+		key:         s.imageKey(teamID),
+	}
+}
+
+func (s *CrestService) imageKey(teamID int) string {
+	return "crests/" + strconv.Itoa(teamID)
+}
+
+func (s *CrestService) metaKey(teamID int) string {
+	return s.imageKey(teamID) + ".json"
+}
+
+// readMeta returns the metadata of a cached crest, or nil if the team has no
+// usable cached copy.
+func (s *CrestService) readMeta(teamID int) (*crestMeta, error) {
+	data, _, err := blob.ReadAll(s.store, s.metaKey(teamID))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crest metadata: %w", err)
+	}
+
+	var meta crestMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, nil
+	}
+	if _, err := s.store.Stat(s.imageKey(teamID)); err != nil {
+		return nil, nil
+	}
+	return &meta, nil
+}
+
+// fetch downloads a crest and stores it. When prev is set the request is
+// conditional and a 304 only bumps the revalidation time.
+func (s *CrestService) fetch(teamID int, sourceURL string, prev *crestMeta) (*crestMeta, error) {
+	req, err := http.NewRequest(http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crest URL: %w", err)
+	}
+	req.Header.Set("User-Agent", "football-prediction-assets/1.0")
+	req.Header.Set("Accept", "image/*")
+	if prev != nil {
+		if prev.UpstreamETag != "" {
+			req.Header.Set("If-None-Match", prev.UpstreamETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch crest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && prev != nil {
+		meta := *prev
+		meta.RevalidatedAt = time.Now()
+		if err := s.writeMeta(teamID, &meta); err != nil {
+			return nil, err
+		}
+		return &meta, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crest fetch returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCrestBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crest: %w", err)
+	}
+	if len(body) > maxCrestBytes {
+		return nil, fmt.Errorf("crest is larger than %d bytes", maxCrestBytes)
+	}
+
+	contentType := crestContentType(resp.Header.Get("Content-Type"), body)
+	if contentType == "" {
+		return nil, fmt.Errorf("crest is not an image")
+	}
+
+	sum := sha256.Sum256(body)
+	now := time.Now()
+	meta := &crestMeta{
+		SourceURL:     sourceURL,
+		ContentType:   contentType,
+		ETag:          `"` + hex.EncodeToString(sum[:8]) + `"`,
+		UpstreamETag:  resp.Header.Get("ETag"),
+		LastModified:  resp.Header.Get("Last-Modified"),
+		FetchedAt:     now,
+		RevalidatedAt: now,
+	}
+	if prev != nil && prev.ETag == meta.ETag {
+		meta.FetchedAt = prev.FetchedAt
+	}
+
+	if err := blob.PutBytes(s.store, s.imageKey(teamID), body, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.writeMeta(teamID, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (s *CrestService) writeMeta(teamID int, meta *crestMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode crest metadata: %w", err)
+	}
+	return blob.PutBytes(s.store, s.metaKey(teamID), data, "application/json")
+}
+
+// crestContentType returns the image media type of a crest, trusting the
+// provider's header when it names an image and sniffing the body otherwise.
+// It returns "" for anything that is not an image.
+func crestContentType(header string, body []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(header); err == nil && strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+
+	sniffed := http.DetectContentType(body)
+	if strings.HasPrefix(sniffed, "image/") {
+		return sniffed
+	}
+	// SVG sniffs as XML or plain text.
+	isText := strings.HasPrefix(sniffed, "text/xml") || strings.HasPrefix(sniffed, "text/plain")
+	if isText && bytes.Contains(body[:min(len(body), 1024)], []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// DataQualityTotals sums the gaps across competitions. Teams playing in
+// several competitions are counted in each.
+type DataQualityTotals struct {
+	MatchesMissingScores       int `json:"matchesMissingScores"`
+	FinishedWithoutPlayerStats int `json:"finishedWithoutPlayerStats"`
+	TeamsMissingCrests         int `json:"teamsMissingCrests"`
+	UnsettledPredictions       int `json:"unsettledPredictions"`
+	UpcomingWithoutPredictions int `json:"upcomingWithoutPredictions"`
+}
+
+// DataQualityReport is the state of the stored data for the ops dashboard.
+type DataQualityReport struct {
+	CheckedAt    time.Time                           `json:"checkedAt"`
+	Totals       DataQualityTotals                   `json:"totals"`
+	Competitions []repository.CompetitionDataQuality `json:"competitions"`
+}
+
+// DataQualityService reports gaps in ingested and derived data.
+type DataQualityService struct {
+	repo *repository.DataQualityRepository
+}
+
+func NewDataQualityService(db *sql.DB) *DataQualityService {
+	return &DataQualityService{repo: repository.NewDataQualityRepository(db)}
+}
+
+// Report checks every competition with stored matches.
+func (s *DataQualityService) Report() (*DataQualityReport, error) {
+	competitions, err := s.repo.ByCompetition()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DataQualityReport{CheckedAt: time.Now().UTC(), Competitions: competitions}
+	for _, c := range competitions {
+		report.Totals.MatchesMissingScores += c.MatchesMissingScores
+		report.Totals.FinishedWithoutPlayerStats += c.FinishedWithoutPlayerStats
+		report.Totals.TeamsMissingCrests += c.TeamsMissingCrests
+		report.Totals.UnsettledPredictions += c.UnsettledPredictions
+		report.Totals.UpcomingWithoutPredictions += c.UpcomingWithoutPredictions
+	}
+	return report, nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/digest"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/mail"
+)
+
+const (
+	// digestMinInterval is the least time between two digests to a user, a
+	// little under a week so a late run does not push the next one back.
+	digestMinInterval = 6 * 24 * time.Hour
+	// digestWindow is how far ahead fixtures and how far back results are
+	// covered.
+	digestWindow = 7 * 24 * time.Hour
+	// maxDigestStreaks bounds the streaks listed in one digest.
+	maxDigestStreaks = 5
+)
+
+// ParseWeekday parses a day name such as "monday" or "Mon".
+func ParseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || (len(s) >= 3 && strings.HasPrefix(name, s)) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// DigestService builds weekly email digests of followed teams' fixtures,
+// predictions, prediction accuracy and streaks, and sends them.
+type DigestService struct {
+	repo           *repository.DigestRepository
+	followRepo     *repository.FollowRepository
+	matchRepo      *repository.MatchRepository
+	predictionRepo *repository.PredictionRepository
+	streaks        *StreakService
+	mailer         mail.Sender
+	day            time.Weekday
+	unsubscribeURL string
+}
+
+// NewDigestService sends digests through mailer on day (UTC). Unsubscribe
+// links point at unsubscribeURL. A nil mailer disables sending.
+func NewDigestService(db *sql.DB, mailer mail.Sender, day time.Weekday, unsubscribeURL string) *DigestService {
+	return &DigestService{
+		repo:           repository.NewDigestRepository(db),
+		followRepo:     repository.NewFollowRepository(db),
+		matchRepo:      repository.NewMatchRepository(db),
+		predictionRepo: repository.NewPredictionRepository(db),
+		streaks:        NewStreakService(db),
+		mailer:         mailer,
+		day:            day,
+		unsubscribeURL: unsubscribeURL,
+	}
+}
+
+// Enabled reports whether a mail provider is configured.
+func (s *DigestService) Enabled() bool {
+	return s.mailer != nil
+}
+
+// SendDue sends up to limit digests to the users due one, on the digest day
+// only, and returns how many were sent. Users with nothing to report are
+// skipped until next week. Failures are skipped and reported together.
+func (s *DigestService) SendDue(limit int) (int, error) {
+	now := time.Now().UTC()
+	if !s.Enabled() || now.Weekday() != s.day {
+		return 0, nil
+	}
+
+	recipients, err := s.repo.ListDue(now.Add(-digestMinInterval), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, r := range recipients {
+		ok, err := s.send(r, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", r.UserID, err))
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *DigestService) send(r repository.DigestRecipient, now time.Time) (bool, error) {
+	d, err := s.Build(r.UserID, r.Name, now)
+	if err != nil {
+		return false, err
+	}
+
+	if !d.Empty() {
+		text, html, err := digest.Render(*d)
+		if err != nil {
+			return false, err
+		}
+		err = s.mailer.Send(mail.Message{
+			To:      r.Email,
+			Subject: d.Subject(),
+			Text:    text,
+			HTML:    html,
+			Headers: map[string]string{
+				// One-click unsubscribe from the mail client (RFC 8058)
+				"List-Unsubscribe":      "<" + d.UnsubscribeURL + ">",
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.repo.MarkSent(r.UserID, now); err != nil {
+		return false, err
+	}
+	return !d.Empty(), nil
+}
+
+// Build assembles a user's digest for the week starting at now.
+func (s *DigestService) Build(userID int, name string, now time.Time) (*digest.Digest, error) {
+	sub, err := s.Subscription(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	follows, err := s.followRepo.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	var teamIDs []int
+	for _, f := range follows {
+		if f.EntityType == repository.FollowTeam {
+			teamIDs = append(teamIDs, f.EntityID)
+		}
+	}
+
+	d := &digest.Digest{
+		Name:           name,
+		WeekOf:         now,
+		Fixtures:       []digest.Fixture{},
+		UnsubscribeURL: s.unsubscribeURL + "?token=" + url.QueryEscape(sub.UnsubscribeToken),
+	}
+	if len(teamIDs) == 0 {
+		return d, nil
+	}
+
+	upcoming, err := s.matchRepo.ListForFeed(repository.FeedFilter{
+		TeamIDs:  teamIDs,
+		From:     now,
+		To:       now.Add(digestWindow),
+		Statuses: []string{"SCHEDULED", "TIMED"},
+		Limit:    50,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.matchRepo.ListForFeed(repository.FeedFilter{
+		TeamIDs:  teamIDs,
+		From:     now.Add(-digestWindow),
+		To:       now,
+		Statuses: []string{"FINISHED"},
+		Limit:    100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(upcoming)+len(results))
+	for _, m := range upcoming {
+		ids = append(ids, m.ID)
+	}
+	for _, m := range results {
+		ids = append(ids, m.ID)
+	}
+	predictions, err := s.predictionRepo.GetByMatchIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range upcoming {
+		d.Fixtures = append(d.Fixtures, digest.Fixture{
+			Kickoff:     m.UtcDate,
+			Competition: m.CompetitionCode,
+			Home:        m.HomeTeamName,
+			Away:        m.AwayTeamName,
+			Prediction:  describePrediction(predictions[m.ID]),
+		})
+	}
+
+	for _, m := range results {
+		if p := predictions[m.ID]; p != nil && p.PredictionCorrect != nil {
+			d.TeamRecord.Total++
+			if *p.PredictionCorrect {
+				d.TeamRecord.Correct++
+			}
+		}
+	}
+	if d.TeamRecord.Total > 0 {
+		if d.ModelRecord.Correct, d.ModelRecord.Total, err = s.predictionRepo.AccuracyBetween(now.Add(-digestWindow), now); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, teamID := range teamIDs {
+		facts, err := s.streaks.TeamFacts(teamID)
+		if err != nil {
+			// Streaks are optional context
+			continue
+		}
+		for _, f := range facts {
+			if len(d.Streaks) < maxDigestStreaks {
+				d.Streaks = append(d.Streaks, f.Description)
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// describePrediction reads a prediction as e.g. "Arsenal, 2-1 (64%
+// confidence)", or "" without one.
+func describePrediction(p *repository.PredictionRecord) string {
+	if p == nil {
+		return ""
+	}
+	text := p.PredictedWinner
+	if p.PredictedTeamAGoals != nil && p.PredictedTeamBGoals != nil {
+		text += fmt.Sprintf(", %d-%d", int(*p.PredictedTeamAGoals+0.5), int(*p.PredictedTeamBGoals+0.5))
+	}
+	return text + fmt.Sprintf(" (%.0f%% confidence)", p.ConfidenceScore*100)
+}
+
+// SetSubscribed subscribes or unsubscribes a user.
+func (s *DigestService) SetSubscribed(userID int, subscribed bool) (*repository.DigestSubscription, error) {
+	if _, err := s.Subscription(userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSubscribed(userID, subscribed); err != nil {
+		return nil, err
+	}
+	return s.Subscription(userID)
+}
+
+// Unsubscribe unsubscribes the holder of an unsubscribe link. Returns false
+// if the token is unknown.
+func (s *DigestService) Unsubscribe(token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	return s.repo.Unsubscribe(token)
+}
+
+// Subscription returns a user's digest subscription, creating it with a
+// fresh unsubscribe token on first use.
+func (s *DigestService) Subscription(userID int) (*repository.DigestSubscription, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate unsubscribe token: %w", err)
+	}
+	return s.repo.Ensure(userID, hex.EncodeToString(b))
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/draw"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+const (
+	defaultDrawSimulations = 2000
+	maxDrawSimulations     = 20000
+	maxDrawTeams           = 64
+	// drawWorkers bounds how many pairings are predicted at once.
+	drawWorkers = 4
+)
+
+// drawRules are the constraints of the draws that can be simulated, by
+// competition code: the Champions League round of 16, pairing group
+// winners with runners-up from another group and country, and the World
+// Cup group stage, where only UEFA may have two teams in a group.
+var drawRules = map[string]draw.Rules{
+	"CL": {Format: draw.Knockout, CountryProtection: true, GroupProtection: true},
+	"WC": {Format: draw.Groups, CountryProtection: true, CountryLimits: map[string]int{"UEFA": 2}},
+}
+
+// DrawEntry is a team qualified for a draw, by football-data.org or
+// internal ID. Country is its association, or confederation for the World
+// Cup, and Group the group it won or came second in before a knockout
+// draw.
+type DrawEntry struct {
+	TeamID  int    `json:"teamId"`
+	Pot     int    `json:"pot"`
+	Country string `json:"country"`
+	Group   string `json:"group"`
+}
+
+// DrawRequest describes a draw to simulate. CountryProtection overrides the
+// competition's rule when set. A non-zero Seed repeats a simulation.
+type DrawRequest struct {
+	Competition       string      `json:"competition"`
+	Teams             []DrawEntry `json:"teams"`
+	Simulations       int         `json:"simulations"`
+	CountryProtection *bool       `json:"countryProtection"`
+	Seed              int64       `json:"seed"`
+}
+
+// DrawTeam is a team in a simulated draw.
+type DrawTeam struct {
+	ID      int    `json:"id"` // external ID
+	Name    string `json:"name"`
+	Pot     int    `json:"pot"`
+	Country string `json:"country,omitempty"`
+}
+
+// DrawPairing is how likely two teams are to be drawn together, and the
+// model's odds of the match at a neutral venue. Team is from the lower pot.
+type DrawPairing struct {
+	Team                   DrawTeam `json:"team"`
+	Opponent               DrawTeam `json:"opponent"`
+	Probability            float64  `json:"probability"`
+	WinProbability         float64  `json:"winProbability"`
+	DrawProbability        float64  `json:"drawProbability"`
+	OpponentWinProbability float64  `json:"opponentWinProbability"`
+}
+
+// DrawOutlook is a team's prospects from the draw. ExpectedDifficulty is
+// one less its expected share of the points against the opponents it may
+// draw, weighted by how likely each is: 0.5 is an even draw.
+type DrawOutlook struct {
+	Team                 DrawTeam  `json:"team"`
+	ExpectedDifficulty   float64   `json:"expectedDifficulty"`
+	LikeliestOpponent    *DrawTeam `json:"likeliestOpponent"`
+	LikeliestProbability float64   `json:"likeliestProbability"`
+}
+
+// DrawSimulation is the outcome of simulating a draw many times. Pairings
+// are most likely first and teams hardest draw first.
+type DrawSimulation struct {
+	Competition string        `json:"competition"`
+	Format      draw.Format   `json:"format"`
+	Simulations int           `json:"simulations"`
+	GeneratedAt time.Time     `json:"generatedAt"`
+	Pairings    []DrawPairing `json:"pairings"`
+	Teams       []DrawOutlook `json:"teams"`
+}
+
+// DrawService simulates cup draws and rates every possible pairing with the
+// prediction models.
+type DrawService struct {
+	teamRepo    *repository.TeamRepository
+	predictions *PredictionService
+}
+
+func NewDrawService(db *sql.DB, predictions *PredictionService) *DrawService {
+	return &DrawService{teamRepo: repository.NewTeamRepository(db), predictions: predictions}
+}
+
+// Simulate runs the draw of req many times under its competition's rules
+// and predicts each pairing that came up as a neutral-venue match.
+func (s *DrawService) Simulate(ctx context.Context, req DrawRequest) (*DrawSimulation, error) {
+	competition := strings.ToUpper(req.Competition)
+	rules, ok := drawRules[competition]
+	if !ok {
+		return nil, invalidf("competition must be one of CL, WC")
+	}
+	if req.CountryProtection != nil {
+		rules.CountryProtection = *req.CountryProtection
+	}
+	if len(req.Teams) < 2 || len(req.Teams) > maxDrawTeams {
+		return nil, invalidf("teams must hold between 2 and %d teams", maxDrawTeams)
+	}
+	simulations := req.Simulations
+	if simulations == 0 {
+		simulations = defaultDrawSimulations
+	}
+	if simulations < 1 || simulations > maxDrawSimulations {
+		return nil, invalidf("simulations must be between 1 and %d", maxDrawSimulations)
+	}
+
+	teams := make(map[int]*repository.TeamInfo, len(req.Teams))
+	sides := make(map[int]DrawTeam, len(req.Teams))
+	entries := make([]draw.Team, 0, len(req.Teams))
+	for _, e := range req.Teams {
+		team, err := s.resolveTeam(e.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, invalidf("team %d not found", e.TeamID)
+		}
+		if e.Pot < 1 {
+			return nil, invalidf("team %d needs a pot from 1", e.TeamID)
+		}
+		teams[team.ID] = team
+		sides[team.ID] = DrawTeam{ID: team.ExternalID, Name: team.Name, Pot: e.Pot, Country: e.Country}
+		entries = append(entries, draw.Team{ID: team.ID, Pot: e.Pot, Country: e.Country, Group: e.Group})
+	}
+
+	seed := req.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	result, err := draw.Simulate(entries, rules, simulations, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return nil, invalidf("%v", err)
+	}
+
+	pairs := make([]draw.Pair, 0, len(result.Counts))
+	for pair := range result.Counts {
+		pairs = append(pairs, pair)
+	}
+	predictions := s.predictPairs(ctx, pairs, teams)
+
+	sim := &DrawSimulation{
+		Competition: competition,
+		Format:      rules.Format,
+		Simulations: simulations,
+		GeneratedAt: time.Now().UTC(),
+		Pairings:    make([]DrawPairing, 0, len(pairs)),
+	}
+	for i, pair := range pairs {
+		p := predictions[i]
+		pairing := DrawPairing{
+			Team:                   sides[pair.A],
+			Opponent:               sides[pair.B],
+			Probability:            math.Round(result.Probability(pair.A, pair.B)*1000) / 1000,
+			WinProbability:         p.HomeWinProbability,
+			DrawProbability:        p.DrawProbability,
+			OpponentWinProbability: p.AwayWinProbability,
+		}
+		if pairing.Team.Pot > pairing.Opponent.Pot {
+			pairing.Team, pairing.Opponent = pairing.Opponent, pairing.Team
+			pairing.WinProbability, pairing.OpponentWinProbability = pairing.OpponentWinProbability, pairing.WinProbability
+		}
+		sim.Pairings = append(sim.Pairings, pairing)
+	}
+	sort.Slice(sim.Pairings, func(i, j int) bool {
+		if sim.Pairings[i].Probability != sim.Pairings[j].Probability {
+			return sim.Pairings[i].Probability > sim.Pairings[j].Probability
+		}
+		return sim.Pairings[i].Team.Name < sim.Pairings[j].Team.Name
+	})
+
+	sim.Teams = drawOutlooks(sim.Pairings, sides, entries)
+	return sim, nil
+}
+
+// predictPairs predicts every pair at a neutral venue, the lower ID as the
+// nominal home side, in the order given.
+func (s *DrawService) predictPairs(ctx context.Context, pairs []draw.Pair, teams map[int]*repository.TeamInfo) []*MatchPrediction {
+	predictions := make([]*MatchPrediction, len(pairs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, drawWorkers)
+	for i, pair := range pairs {
+		wg.Add(1)
+		go func(i int, pair draw.Pair) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			home, away := teams[pair.A], teams[pair.B]
+			predictions[i] = s.predictions.PredictFrom(ctx, PredictionInput{
+				HomeTeamID:         home.ID,
+				AwayTeamID:         away.ID,
+				HomeTeamExternalID: home.ExternalID,
+				AwayTeamExternalID: away.ExternalID,
+				HomeTeamName:       home.Name,
+				AwayTeamName:       away.Name,
+				Neutral:            true,
+			}, nil, false)
+		}(i, pair)
+	}
+	wg.Wait()
+	return predictions
+}
+
+// drawOutlooks weighs each team's pairings by their probability, in the
+// order of teams.
+func drawOutlooks(pairings []DrawPairing, sides map[int]DrawTeam, teams []draw.Team) []DrawOutlook {
+	type tally struct {
+		weight, difficulty float64
+		likeliest          *DrawPairing
+		opponent           DrawTeam
+	}
+	byTeam := make(map[int]*tally)
+	add := func(team, opponent DrawTeam, p *DrawPairing, points float64) {
+		t := byTeam[team.ID]
+		if t == nil {
+			t = &tally{}
+			byTeam[team.ID] = t
+		}
+		t.weight += p.Probability
+		t.difficulty += p.Probability * (1 - points)
+		if t.likeliest == nil || p.Probability > t.likeliest.Probability {
+			t.likeliest, t.opponent = p, opponent
+		}
+	}
+	for i := range pairings {
+		p := &pairings[i]
+		add(p.Team, p.Opponent, p, p.WinProbability+p.DrawProbability/2)
+		add(p.Opponent, p.Team, p, p.OpponentWinProbability+p.DrawProbability/2)
+	}
+
+	outlooks := make([]DrawOutlook, 0, len(teams))
+	for _, team := range teams {
+		side := sides[team.ID]
+		outlook := DrawOutlook{Team: side}
+		if t := byTeam[side.ID]; t != nil && t.weight > 0 {
+			opponent := t.opponent
+			outlook.ExpectedDifficulty = math.Round(t.difficulty/t.weight*1000) / 1000
+			outlook.LikeliestOpponent = &opponent
+			outlook.LikeliestProbability = t.likeliest.Probability
+		}
+		outlooks = append(outlooks, outlook)
+	}
+	sort.SliceStable(outlooks, func(i, j int) bool {
+		return outlooks[i].ExpectedDifficulty > outlooks[j].ExpectedDifficulty
+	})
+	return outlooks
+}
+
+// resolveTeam finds a team by its football-data.org ID, then by internal ID,
+// returning nil if neither exists.
+func (s *DrawService) resolveTeam(id int) (*repository.TeamInfo, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil || team != nil {
+		return team, err
+	}
+	return s.teamRepo.GetByID(id)
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+)
+
+// ProviderAPIFootball is the provider name under which API-Football IDs are
+// mapped to local rows.
+const ProviderAPIFootball = "api-football"
+
+// MinMappingConfidence is the lowest fuzzy score that is accepted and stored
+// as a mapping. Anything below is left for a manual override.
+const MinMappingConfidence = 0.75
+
+// MappingEntityTypes lists the entity types that can be mapped.
+var MappingEntityTypes = []string{repository.EntityTeam, repository.EntityPlayer, repository.EntityMatch}
+
+// EntityMappingService resolves other providers' team, player and fixture
+// IDs to local rows and manages the stored mappings and aliases.
+type EntityMappingService struct {
+	repo *repository.EntityMappingRepository
+}
+
+func NewEntityMappingService(db *sql.DB) *EntityMappingService {
+	return &EntityMappingService{repo: repository.NewEntityMappingRepository(db)}
+}
+
+// ResolveTeam returns the local team for a provider team, or nil if no team
+// matches with enough confidence. A stored mapping wins; otherwise the name
+// is matched against team names and aliases and the result is stored.
+func (s *EntityMappingService) ResolveTeam(provider string, providerID int, name string) (*repository.EntityMapping, error) {
+	if m, err := s.repo.Get(repository.EntityTeam, provider, providerID); err != nil || m != nil {
+		return m, err
+	}
+
+	candidates, err := s.repo.TeamCandidates()
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveName(repository.EntityTeam, provider, providerID, name, candidates)
+}
+
+// ResolvePlayer returns the local player for a provider player, or nil if
+// no player matches. Candidates are limited to the player's local team so
+// common names do not collide across clubs.
+func (s *EntityMappingService) ResolvePlayer(provider string, providerID int, name string, teamID int) (*repository.EntityMapping, error) {
+	if m, err := s.repo.Get(repository.EntityPlayer, provider, providerID); err != nil || m != nil {
+		return m, err
+	}
+
+	candidates, err := s.repo.PlayerCandidates(teamID)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveName(repository.EntityPlayer, provider, providerID, name, candidates)
+}
+
+func (s *EntityMappingService) resolveName(entityType, provider string, providerID int, name string, candidates []repository.NameCandidate) (*repository.EntityMapping, error) {
+	best, score, method := matchCandidate(name, candidates)
+	if best == 0 || score < MinMappingConfidence {
+		return nil, nil
+	}
+
+	m := &repository.EntityMapping{
+		EntityType:   entityType,
+		Provider:     provider,
+		ProviderID:   providerID,
+		ProviderName: name,
+		LocalID:      best,
+		Confidence:   score,
+		Method:       method,
+	}
+	if err := s.repo.Save(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FixtureLookup identifies a local match to find on API-Football.
+type FixtureLookup struct {
+	MatchID    int // internal match ID
+	HomeTeamID int // internal team ID
+	AwayTeamID int // internal team ID
+	Date       time.Time
+}
+
+// ResolveFixture returns the API-Football fixture ID of a local match, or 0
+// if none of the fixtures played that day have both teams resolving to the
+// match's teams. Mappings for the fixture and both teams are stored on the
+// way, so later lookups do not call the provider.
+func (s *EntityMappingService) ResolveFixture(client *apifootball.Client, match FixtureLookup) (int, error) {
+	m, err := s.repo.GetByLocalID(repository.EntityMatch, ProviderAPIFootball, match.MatchID)
+	if err != nil {
+		return 0, err
+	}
+	if m != nil {
+		return m.ProviderID, nil
+	}
+
+	fixtures, err := client.GetFixturesByDate(match.Date)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, f := range fixtures {
+		home, err := s.ResolveTeam(ProviderAPIFootball, f.HomeTeam.ID, f.HomeTeam.Name)
+		if err != nil {
+			return 0, err
+		}
+		if home == nil || home.LocalID != match.HomeTeamID {
+			continue
+		}
+		away, err := s.ResolveTeam(ProviderAPIFootball, f.AwayTeam.ID, f.AwayTeam.Name)
+		if err != nil {
+			return 0, err
+		}
+		if away == nil || away.LocalID != match.AwayTeamID {
+			continue
+		}
+
+		mapping := &repository.EntityMapping{
+			EntityType:   repository.EntityMatch,
+			Provider:     ProviderAPIFootball,
+			ProviderID:   f.ID,
+			ProviderName: f.HomeTeam.Name + " vs " + f.AwayTeam.Name,
+			LocalID:      match.MatchID,
+			Confidence:   min(home.Confidence, away.Confidence),
+			Method:       weakerMethod(home.Method, away.Method),
+		}
+		if err := s.repo.Save(mapping); err != nil {
+			return 0, err
+		}
+		return f.ID, nil
+	}
+
+	return 0, nil
+}
+
+// ListMappings returns stored mappings at or below maxConfidence, least
+// confident first, for review.
+func (s *EntityMappingService) ListMappings(entityType, provider string, maxConfidence float64, limit int) ([]repository.EntityMapping, error) {
+	return s.repo.List(entityType, provider, maxConfidence, limit)
+}
+
+// SetMapping pins a provider ID to a local row. Manual mappings are never
+// replaced by automatic resolution.
+func (s *EntityMappingService) SetMapping(entityType, provider string, providerID, localID int, providerName string) (*repository.EntityMapping, error) {
+	return s.repo.SetManual(entityType, provider, providerID, localID, providerName)
+}
+
+// DeleteMapping forgets a mapping so it is resolved again on next use.
+func (s *EntityMappingService) DeleteMapping(entityType, provider string, providerID int) (bool, error) {
+	return s.repo.Delete(entityType, provider, providerID)
+}
+
+// AddAlias records another name for a team or player. The alias is stored
+// normalized so it compares equal to provider names however they are
+// written.
+func (s *EntityMappingService) AddAlias(entityType string, localID int, alias string) (*repository.EntityAlias, error) {
+	normalized := NormalizeEntityName(alias)
+	if normalized == "" {
+		return nil, invalidf("alias is empty after normalization")
+	}
+
+	id, err := s.repo.AddAlias(entityType, localID, normalized)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.EntityAlias{ID: id, EntityType: entityType, LocalID: localID, Alias: normalized}, nil
+}
+
+// DeleteAlias removes an alias by ID.
+func (s *EntityMappingService) DeleteAlias(id int) (bool, error) {
+	return s.repo.DeleteAlias(id)
+}
+
+// ListAliases returns the aliases of a team or player.
+func (s *EntityMappingService) ListAliases(entityType string, localID int) ([]repository.EntityAlias, error) {
+	return s.repo.ListAliases(entityType, localID)
+}
+
+// Confidence assigned to each matching method. Fuzzy matches score their
+// trigram similarity.
+const (
+	exactConfidence = 1.0
+	aliasConfidence = 0.95
+)
+
+// matchCandidate returns the best-scoring candidate for name with its score
+// and method, or 0 if there are no candidates.
+func matchCandidate(name string, candidates []repository.NameCandidate) (int, float64, string) {
+	target := NormalizeEntityName(name)
+	if target == "" {
+		return 0, 0, ""
+	}
+
+	bestID, bestScore, bestMethod := 0, 0.0, ""
+	for _, c := range candidates {
+		score, method := 0.0, ""
+		for _, n := range c.Names {
+			normalized := NormalizeEntityName(n)
+			if normalized == target {
+				score, method = exactConfidence, repository.MappingExact
+				break
+			}
+			if sim := trigramSimilarity(normalized, target); sim > score {
+				score, method = sim, repository.MappingFuzzy
+			}
+		}
+		if score < aliasConfidence {
+			for _, a := range c.Aliases {
+				if a == target {
+					score, method = aliasConfidence, repository.MappingAlias
+					break
+				}
+			}
+		}
+		if score > bestScore {
+			bestID, bestScore, bestMethod = c.ID, score, method
+		}
+	}
+	return bestID, bestScore, bestMethod
+}
+
+// weakerMethod returns the less trustworthy of two mapping methods.
+func weakerMethod(a, b string) string {
+	rank := map[string]int{
+		repository.MappingManual: 0,
+		repository.MappingExact:  1,
+		repository.MappingAlias:  2,
+		repository.MappingFuzzy:  3,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// Club-name tokens that carry no identity ("FC Barcelona" is "Barcelona").
+var entityNoiseTokens = map[string]bool{
+	"fc": true, "afc": true, "cf": true, "sc": true, "ac": true, "ssc": true,
+	"cd": true, "ud": true, "sv": true, "vfb": true, "vfl": true, "as": true,
+	"club": true, "de": true, "the": true,
+}
+
+// Common abbreviations expanded before comparing ("Man Utd" is "Manchester
+// United").
+var entityTokenExpansions = map[string]string{
+	"utd":    "united",
+	"man":    "manchester",
+	"st":     "saint",
+	"wolves": "wolverhampton",
+	"spurs":  "tottenham",
+}
+
+// Latin letters with diacritics folded to ASCII. Covers the names the
+// providers return for the competitions we ingest.
+var diacriticFolds = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ß", "ss", "ş", "s", "ğ", "g", "ı", "i", "ł", "l",
+	"ć", "c", "č", "c", "š", "s", "ž", "z", "đ", "d",
+)
+
+// NormalizeEntityName reduces a team or player name to a canonical form for
+// comparison: lowercase ASCII words with punctuation, club-type noise words
+// and common abbreviations resolved.
+func NormalizeEntityName(name string) string {
+	name = diacriticFolds.Replace(strings.ToLower(name))
+
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if entityNoiseTokens[f] {
+			continue
+		}
+		if expanded, ok := entityTokenExpansions[f]; ok {
+			f = expanded
+		}
+		words = append(words, f)
+	}
+	return strings.Join(words, " ")
+}
+
+// trigramSimilarity scores two normalized names between 0 and 1 the way
+// pg_trgm does: shared trigrams over the union of both sets, with each word
+// padded by two leading spaces and one trailing space.
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,18 @@
+package service
+
+import "fmt"
+
+// ValidationError reports input a service rejected. Unlike other errors its
+// message is meant for API clients.
+type ValidationError struct {
+	msg string
+}
+
+func (e *ValidationError) Error() string {
+	return e.msg
+}
+
+// invalidf returns a ValidationError with a formatted message.
+func invalidf(format string, args ...any) error {
+	return &ValidationError{msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/football-prediction/pkg/events"
+)
+
+// EventBus publishes domain events to the message broker and subscribes
+// their consumers, so producers such as settlement and prediction do not
+// call webhooks or the scheduler themselves. A nil EventBus drops events.
+type EventBus struct {
+	broker events.Broker
+}
+
+func NewEventBus(broker events.Broker) *EventBus {
+	return &EventBus{broker: broker}
+}
+
+// Publish sends an event. key identifies what the event is about, so
+// consumers can ignore repeats and callers can publish on every run of a
+// job.
+func (b *EventBus) Publish(eventType, key string, data interface{}) error {
+	if b == nil {
+		return nil
+	}
+
+	e, err := events.New(eventType, key, data)
+	if err != nil {
+		return err
+	}
+	return b.broker.Publish(context.Background(), e)
+}
+
+// Subscribe calls handler for events of eventType. Subscribers sharing a
+// group, e.g. the same consumer in several API instances, split the events.
+func (b *EventBus) Subscribe(eventType, group string, handler events.Handler) error {
+	return b.broker.Subscribe(eventType, group, handler)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/export"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/blob"
+)
+
+// ExportLink is an export stored under exports/ and a signed link to
+// download it.
+type ExportLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Rows      int       `json:"rows"`
+}
+
+// ExportService streams training data as CSV or Parquet.
+type ExportService struct {
+	repo    *repository.ExportRepository
+	store   blob.Store
+	linkTTL time.Duration
+}
+
+// NewExportService stores exports delivered as links in store, signing links
+// valid for linkTTL. store may be nil when exports are only streamed.
+func NewExportService(db *sql.DB, store blob.Store, linkTTL time.Duration) *ExportService {
+	return &ExportService{
+		repo:    repository.NewExportRepository(db),
+		store:   store,
+		linkTTL: linkTTL,
+	}
+}
+
+// ExportMatches writes every match matching the filter to w in the given
+// format, returning the number of rows written. Rows are encoded as they are
+// read, so memory use does not grow with the export size.
+func (s *ExportService) ExportMatches(filter repository.ExportFilter, format string, w io.Writer) (int, error) {
+	filter.CompetitionCode = strings.ToUpper(filter.CompetitionCode)
+	filter.Status = strings.ToUpper(filter.Status)
+
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = s.repo.StreamMatches(filter, func(row *repository.ExportRow) error {
+		count++
+		return writer.Write(row)
+	})
+	if err != nil {
+		return count, err
+	}
+
+	return count, writer.Close()
+}
+
+// LinksEnabled reports whether the store can sign download links.
+func (s *ExportService) LinksEnabled() bool {
+	if s.store == nil {
+		return false
+	}
+	_, err := s.store.SignedURL("exports/probe", s.linkTTL)
+	return err == nil
+}
+
+// StoreMatches writes the export to blob storage as it is encoded and
+// returns a signed link to it. name is the file name without extension.
+func (s *ExportService) StoreMatches(filter repository.ExportFilter, format, name string) (*ExportLink, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to name export: %w", err)
+	}
+	key := fmt.Sprintf("exports/%s_%s_%s.%s", name, time.Now().UTC().Format("20060102T150405Z"), hex.EncodeToString(suffix), format)
+
+	pr, pw := io.Pipe()
+	var rows int
+	go func() {
+		var err error
+		rows, err = s.ExportMatches(filter, format, pw)
+		pw.CloseWithError(err)
+	}()
+	if err := s.store.Put(key, pr, export.ContentType(format)); err != nil {
+		// Unblock the exporter if the store gave up first
+		pr.CloseWithError(err)
+		return nil, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	url, err := s.store.SignedURL(key, s.linkTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign export link: %w", err)
+	}
+	return &ExportLink{URL: url, ExpiresAt: time.Now().Add(s.linkTTL).UTC(), Rows: rows}, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Fantasy scoring actions. Rules in fantasy_scoring_rules refer to these.
+const (
+	FantasyAppearance     = "appearance"
+	FantasyAppearance60   = "appearance_60"
+	FantasyGoal           = "goal"
+	FantasyAssist         = "assist"
+	FantasyCleanSheet     = "clean_sheet"
+	FantasyGoalsConceded2 = "goals_conceded_2"
+	FantasyYellowCard     = "yellow_card"
+	FantasyRedCard        = "red_card"
+	FantasyOwnGoal        = "own_goal"
+	// FantasyPenaltyGoal is awarded per scored penalty on top of the goal
+	// itself; a negative value discounts penalties.
+	FantasyPenaltyGoal = "penalty_goal"
+)
+
+var fantasyActions = []string{
+	FantasyAppearance, FantasyAppearance60, FantasyGoal, FantasyAssist, FantasyCleanSheet,
+	FantasyGoalsConceded2, FantasyYellowCard, FantasyRedCard, FantasyOwnGoal, FantasyPenaltyGoal,
+}
+
+var fantasyPositions = []string{"ANY", "GK", "DEF", "MID", "FWD"}
+
+// FantasyService computes and serves fantasy points.
+type FantasyService struct {
+	repo *repository.FantasyRepository
+}
+
+func NewFantasyService(db *sql.DB) *FantasyService {
+	return &FantasyService{repo: repository.NewFantasyRepository(db)}
+}
+
+// Rules returns the current scoring rules.
+func (s *FantasyService) Rules() ([]repository.ScoringRule, error) {
+	return s.repo.ListRules()
+}
+
+// UpdateRules validates and replaces the scoring rules. Existing points are
+// cleared and recomputed by the next scoring run.
+func (s *FantasyService) UpdateRules(rules []repository.ScoringRule) error {
+	seen := make(map[string]bool)
+	for i := range rules {
+		rule := &rules[i]
+		rule.Position = strings.ToUpper(rule.Position)
+		if rule.Position == "" {
+			rule.Position = "ANY"
+		}
+
+		if !containsString(fantasyActions, rule.Action) {
+			return invalidf("unknown action %q", rule.Action)
+		}
+		if !containsString(fantasyPositions, rule.Position) {
+			return invalidf("unknown position %q", rule.Position)
+		}
+
+		key := rule.Action + "/" + rule.Position
+		if seen[key] {
+			return invalidf("duplicate rule for %s", key)
+		}
+		seen[key] = true
+	}
+
+	return s.repo.ReplaceRules(rules)
+}
+
+// ScorePending computes points for finished matches that have not been
+// scored yet. It returns the number of matches scored.
+func (s *FantasyService) ScorePending(limit int) (int, error) {
+	matches, err := s.repo.ListMatchesToScore(limit)
+	if err != nil {
+		return 0, err
+	}
+	if len(matches) == 0 {
+		return 0, nil
+	}
+
+	rules, err := s.repo.ListRules()
+	if err != nil {
+		return 0, err
+	}
+
+	scored := 0
+	for _, m := range matches {
+		if err := s.scoreMatch(m, rules); err != nil {
+			return scored, fmt.Errorf("match %d: %w", m.ExternalID, err)
+		}
+		scored++
+	}
+
+	return scored, nil
+}
+
+func (s *FantasyService) scoreMatch(m repository.MatchSummary, rules []repository.ScoringRule) error {
+	lines, err := s.repo.GetLines(m.ID)
+	if err != nil {
+		return err
+	}
+
+	points := make([]repository.FantasyPoints, 0, len(lines))
+	for _, line := range lines {
+		conceded := 0
+		if m.HomeScore != nil && m.AwayScore != nil {
+			if line.TeamID == m.HomeTeamID {
+				conceded = *m.AwayScore
+			} else {
+				conceded = *m.HomeScore
+			}
+		}
+		points = append(points, ScoreFantasyLine(line, conceded, rules))
+	}
+
+	return s.repo.SavePoints(m.ID, points)
+}
+
+// TopForGameweek returns the highest scoring players of a matchday.
+func (s *FantasyService) TopForGameweek(competitionCode, season string, matchday, limit int) ([]repository.FantasyScore, error) {
+	return s.repo.TopForGameweek(strings.ToUpper(competitionCode), season, matchday, limit)
+}
+
+// PlayerHistory returns a player's points per match, or nil if the player
+// is unknown.
+func (s *FantasyService) PlayerHistory(playerExternalID, limit int) ([]repository.FantasyHistoryRow, error) {
+	return s.repo.PlayerHistory(playerExternalID, limit)
+}
+
+// ScoreFantasyLine applies the scoring rules to one player's match. When
+// minutes are unknown the player is treated as a sub-60-minute appearance,
+// so clean sheet and goals-conceded rules do not apply.
+func ScoreFantasyLine(line repository.FantasyLine, conceded int, rules []repository.ScoringRule) repository.FantasyPoints {
+	position := FantasyPosition(line.Position)
+	result := repository.FantasyPoints{
+		PlayerID:  line.PlayerID,
+		Position:  position,
+		Breakdown: make(map[string]int),
+	}
+
+	played := line.Minutes == nil || *line.Minutes > 0
+	full := line.Minutes != nil && *line.Minutes >= 60
+
+	counts := map[string]int{
+		FantasyGoal:        line.Goals,
+		FantasyAssist:      line.Assists,
+		FantasyYellowCard:  line.YellowCard,
+		FantasyRedCard:     line.RedCard,
+		FantasyOwnGoal:     line.OwnGoals,
+		FantasyPenaltyGoal: line.Penalties,
+	}
+	if played {
+		counts[FantasyAppearance] = 1
+	}
+	if full {
+		counts[FantasyAppearance60] = 1
+		if conceded == 0 {
+			counts[FantasyCleanSheet] = 1
+		}
+		counts[FantasyGoalsConceded2] = conceded / 2
+	}
+
+	for action, n := range counts {
+		if n == 0 {
+			continue
+		}
+		value, ok := rulePoints(rules, action, position)
+		if !ok || value == 0 {
+			continue
+		}
+		result.Breakdown[action] = n * value
+		result.Points += n * value
+	}
+
+	return result
+}
+
+// rulePoints finds the rule for an action, preferring a position-specific
+// rule over an ANY rule.
+func rulePoints(rules []repository.ScoringRule, action, position string) (int, bool) {
+	value, found := 0, false
+	for _, rule := range rules {
+		if rule.Action != action {
+			continue
+		}
+		if rule.Position == position {
+			return rule.Points, true
+		}
+		if rule.Position == "ANY" {
+			value, found = rule.Points, true
+		}
+	}
+	return value, found
+}
+
+// FantasyPosition maps provider and lineup position names to GK, DEF, MID
+// or FWD. Unknown positions count as MID.
+func FantasyPosition(position string) string {
+	p := strings.ToLower(position)
+	switch {
+	case p == "gk" || strings.Contains(p, "goalkeeper"):
+		return "GK"
+	case strings.Contains(p, "back") || strings.Contains(p, "defen") ||
+		p == "cb" || p == "lb" || p == "rb" || p == "lwb" || p == "rwb":
+		return "DEF"
+	case strings.Contains(p, "forward") || strings.Contains(p, "offence") || strings.Contains(p, "striker") ||
+		strings.Contains(p, "winger") || p == "st" || p == "cf" || p == "lw" || p == "rw":
+		return "FWD"
+	default:
+		return "MID"
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/model"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Feature snapshot sources.
+const (
+	FeatureSourceLive     = "live"
+	FeatureSourceBacktest = "backtest"
+)
+
+// Feature windows: form covers the last formMatches results, goal averages
+// the last goalsAvgMatches and home advantage the last homeAdvantageMatches
+// at each venue.
+const (
+	formMatches          = 5
+	goalsAvgMatches      = 10
+	homeAdvantageMatches = 10
+)
+
+// A regular is a player who started at least regularStarts of their team's
+// last regularLineups lineups; one left out of an announced lineup counts
+// as a lineup absence.
+const (
+	regularLineups = 5
+	regularStarts  = 3
+)
+
+// A team's late-goal share is taken over its last lateGoalMatches matches
+// with recorded events, once it has scored at least lateGoalMinGoals goals
+// in them.
+const (
+	lateGoalMatches  = 20
+	lateGoalMinGoals = 5
+)
+
+// FeatureSnapshot is both teams' features for a match as they stood at AsOf.
+type FeatureSnapshot struct {
+	MatchID int                     `json:"matchId"`
+	AsOf    time.Time               `json:"asOf"`
+	Home    repository.TeamFeatures `json:"home"`
+	Away    repository.TeamFeatures `json:"away"`
+}
+
+// FeatureService computes per-team features from data known at a point in
+// time and stores them, so live predictions and backtests see the same
+// features without leaking later results.
+type FeatureService struct {
+	repo       *repository.FeatureRepository
+	predRepo   *repository.PredictionRepository
+	matchRepo  *repository.MatchRepository
+	playerRepo *repository.PlayerRepository
+	coachRepo  *repository.CoachRepository
+	newsRepo   *repository.NewsRepository
+}
+
+func NewFeatureService(db *sql.DB) *FeatureService {
+	return &FeatureService{
+		repo:       repository.NewFeatureRepository(db),
+		predRepo:   repository.NewPredictionRepository(db),
+		matchRepo:  repository.NewMatchRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+		coachRepo:  repository.NewCoachRepository(db),
+		newsRepo:   repository.NewNewsRepository(db),
+	}
+}
+
+// Snapshot computes and stores the features of a match's teams, by internal
+// ID, from results kicked off before asOf.
+func (s *FeatureService) Snapshot(matchID, homeTeamID, awayTeamID int, asOf time.Time, source string) (*FeatureSnapshot, error) {
+	results, err := s.predRepo.ListTrainingResultsBetween(asOf.Add(-trainingWindow), asOf)
+	if err != nil {
+		return nil, err
+	}
+	return s.SnapshotFrom(results, matchID, homeTeamID, awayTeamID, asOf, source)
+}
+
+// SnapshotFrom is Snapshot with results the caller already loaded, oldest
+// first, so backtests can load them once per kickoff day. Results from asOf
+// onwards are ignored.
+func (s *FeatureService) SnapshotFrom(results []repository.TrainingResult, matchID, homeTeamID, awayTeamID int, asOf time.Time, source string) (*FeatureSnapshot, error) {
+	known := results
+	for i, r := range results {
+		if !r.UtcDate.Before(asOf) {
+			known = results[:i]
+			break
+		}
+	}
+
+	unavailable, err := s.playerRepo.ListUnavailable([]int{homeTeamID, awayTeamID}, asOf, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := s.newsRepo.ListSignals([]int{homeTeamID, awayTeamID}, asOf.Add(-featureSignalWindow), asOf, featureSignalConfidence)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.matchRepo.GetSummaryByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	absences, err := s.playerRepo.ListLineupAbsences(matchID, asOf, regularLineups, regularStarts)
+	if err != nil {
+		return nil, err
+	}
+
+	fitted := modelResults(known)
+	ratings := model.Elo(fitted)
+	snapshot := &FeatureSnapshot{
+		MatchID: matchID,
+		AsOf:    asOf,
+		Home:    teamFeatures(known, ratings, homeTeamID),
+		Away:    teamFeatures(known, ratings, awayTeamID),
+	}
+	if home, away, ok := model.FitLeagues(fitted).Between(homeTeamID, awayTeamID); ok {
+		normaliseLeague(&snapshot.Home, home)
+		normaliseLeague(&snapshot.Away, away)
+	}
+	for _, p := range unavailable {
+		if p.TeamID == homeTeamID {
+			snapshot.Home.Unavailable++
+		} else if p.TeamID == awayTeamID {
+			snapshot.Away.Unavailable++
+		}
+	}
+	mergeSignals(snapshot, unavailable, signals)
+	snapshot.Home.LineupAbsences, snapshot.Away.LineupAbsences = countLineupAbsences(absences, homeTeamID, awayTeamID)
+	for _, f := range []*repository.TeamFeatures{&snapshot.Home, &snapshot.Away} {
+		f.MatchID, f.AsOf, f.Source = matchID, asOf, source
+		tenure, err := s.coachRepo.Tenure(f.TeamID, asOf)
+		if err != nil {
+			return nil, err
+		}
+		f.NewManager = tenure.NewManager()
+
+		late, err := s.matchRepo.GetTeamLateGoals(f.TeamID, asOf, lateGoalMatches)
+		if err != nil {
+			return nil, err
+		}
+		f.LateGoalShare = lateGoalShare(late)
+
+		if match != nil && match.CompetitionCode != "" {
+			bookings, err := s.playerRepo.ListTeamBookings(f.TeamID, asOf)
+			if err != nil {
+				return nil, err
+			}
+			f.BookingRisk = len(atRiskOfBan(bookings, match.CompetitionCode))
+		}
+	}
+
+	if err := s.repo.Save([]repository.TeamFeatures{snapshot.Home, snapshot.Away}); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ListForMatch returns the stored snapshots of a match, latest first.
+func (s *FeatureService) ListForMatch(matchID int) ([]repository.TeamFeatures, error) {
+	return s.repo.ListForMatch(matchID)
+}
+
+// mergeSignals adds the players news reports doubtful or suspended, and not
+// already known to be unavailable, to the teams' unavailable players, and
+// flags managers news reports under pressure.
+func mergeSignals(snapshot *FeatureSnapshot, unavailable []repository.UnavailablePlayer, signals []repository.TeamSignal) {
+	absent := make(map[int]bool)
+	for _, p := range unavailable {
+		if p.PlayerID != nil {
+			absent[*p.PlayerID] = true
+		}
+	}
+
+	for _, sig := range signals {
+		var f *repository.TeamFeatures
+		switch sig.TeamID {
+		case snapshot.Home.TeamID:
+			f = &snapshot.Home
+		case snapshot.Away.TeamID:
+			f = &snapshot.Away
+		default:
+			continue
+		}
+
+		switch sig.Signal {
+		case SignalInjuryDoubt, SignalSuspension:
+			if sig.PlayerID == nil || absent[*sig.PlayerID] {
+				continue
+			}
+			absent[*sig.PlayerID] = true
+			f.Unavailable++
+			f.NewsAbsences++
+		case SignalManagerPressure:
+			f.ManagerPressure = true
+		}
+	}
+}
+
+// countLineupAbsences counts the lineup absences of each team.
+func countLineupAbsences(absences []repository.LineupAbsence, homeTeamID, awayTeamID int) (home, away int) {
+	for _, a := range absences {
+		switch a.TeamID {
+		case homeTeamID:
+			home++
+		case awayTeamID:
+			away++
+		}
+	}
+	return home, away
+}
+
+// lateGoalShare is the share of a team's goals scored after minute 75, or
+// nil below lateGoalMinGoals goals.
+func lateGoalShare(l *repository.LateGoals) *float64 {
+	if l.Goals < lateGoalMinGoals {
+		return nil
+	}
+	return rate(l.LateGoals, l.Goals)
+}
+
+// teamFeatures derives a team's form, goal averages, half-time/full-time
+// rates and home advantage from its most recent results, which are ordered
+// oldest first.
+func teamFeatures(results []repository.TrainingResult, ratings map[int]float64, teamID int) repository.TeamFeatures {
+	f := repository.TeamFeatures{
+		TeamID: teamID,
+		Elo:    math.Round(model.EloRating(ratings, teamID)*10) / 10,
+	}
+
+	var scored, conceded int
+	for i := len(results) - 1; i >= 0 && f.Matches < goalsAvgMatches; i-- {
+		r := results[i]
+		var gf, ga int
+		switch teamID {
+		case r.HomeTeamID:
+			gf, ga = r.HomeScore, r.AwayScore
+		case r.AwayTeamID:
+			gf, ga = r.AwayScore, r.HomeScore
+		default:
+			continue
+		}
+
+		if f.Matches < formMatches {
+			switch {
+			case gf > ga:
+				f.Form += "W"
+				f.FormPoints += 3
+			case gf == ga:
+				f.Form += "D"
+				f.FormPoints++
+			default:
+				f.Form += "L"
+			}
+		}
+		scored += gf
+		conceded += ga
+		f.Matches++
+	}
+
+	if f.Matches > 0 {
+		f.GoalsForAvg = roundedAvg(scored, f.Matches)
+		f.GoalsAgainstAvg = roundedAvg(conceded, f.Matches)
+	}
+
+	htft := recentHalfTimeFullTime(results, teamID)
+	f.ComebackRate, f.LeadHoldRate = htft.ComebackRate, htft.LeadHoldRate
+	f.HomeAdvantage = recentHomeAdvantage(results, teamID)
+	return f
+}
+
+// recentHomeAdvantage is a team's points per game in its last
+// homeAdvantageMatches home results less those away, or nil without results
+// at both venues. results are ordered oldest first.
+func recentHomeAdvantage(results []repository.TrainingResult, teamID int) *float64 {
+	var homeMatches, homePoints, awayMatches, awayPoints int
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		switch {
+		case r.HomeTeamID == teamID && homeMatches < homeAdvantageMatches:
+			homeMatches++
+			homePoints += resultPoints(r.HomeScore, r.AwayScore)
+		case r.AwayTeamID == teamID && awayMatches < homeAdvantageMatches:
+			awayMatches++
+			awayPoints += resultPoints(r.AwayScore, r.HomeScore)
+		}
+	}
+	if homeMatches == 0 || awayMatches == 0 {
+		return nil
+	}
+	v := math.Round((float64(homePoints)/float64(homeMatches)-float64(awayPoints)/float64(awayMatches))*100) / 100
+	return &v
+}
+
+// normaliseLeague scales a team's goal averages by the strength of its home
+// league, for a match against a team from another league: goals scored in
+// a strong league count for more and goals conceded in it for less.
+func normaliseLeague(f *repository.TeamFeatures, coefficient float64) {
+	if coefficient <= 0 {
+		return
+	}
+	f.LeagueStrength = &coefficient
+	if f.GoalsForAvg != nil {
+		v := math.Round(*f.GoalsForAvg*coefficient*100) / 100
+		f.GoalsForAvg = &v
+	}
+	if f.GoalsAgainstAvg != nil {
+		v := math.Round(*f.GoalsAgainstAvg/coefficient*100) / 100
+		f.GoalsAgainstAvg = &v
+	}
+}
+
+func roundedAvg(total, n int) *float64 {
+	v := math.Round(float64(total)/float64(n)*100) / 100
+	return &v
+}
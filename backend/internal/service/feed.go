@@ -0,0 +1,132 @@
+package service
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// feedResultsWindow is how far back finished matches appear in a feed.
+const feedResultsWindow = 7 * 24 * time.Hour
+
+// FeedMatch is a match in a personalized feed with its stored prediction.
+type FeedMatch struct {
+	repository.MatchSummary
+	Prediction *repository.PredictionRecord `json:"prediction,omitempty"`
+}
+
+// Feed is a user's personalized view of followed teams and competitions.
+type Feed struct {
+	Follows  []repository.Follow `json:"follows"`
+	Upcoming []FeedMatch         `json:"upcoming"`
+	Results  []FeedMatch         `json:"results"`
+}
+
+// FeedService manages follows and builds personalized feeds.
+type FeedService struct {
+	followRepo     *repository.FollowRepository
+	matchRepo      *repository.MatchRepository
+	predictionRepo *repository.PredictionRepository
+}
+
+func NewFeedService(db *sql.DB) *FeedService {
+	return &FeedService{
+		followRepo:     repository.NewFollowRepository(db),
+		matchRepo:      repository.NewMatchRepository(db),
+		predictionRepo: repository.NewPredictionRepository(db),
+	}
+}
+
+// Follow adds a follow. Returns false if the team or competition is unknown.
+func (s *FeedService) Follow(userID int, entityType, ref string) (bool, error) {
+	if entityType != repository.FollowTeam && entityType != repository.FollowCompetition {
+		return false, invalidf("type must be %q or %q", repository.FollowTeam, repository.FollowCompetition)
+	}
+	return s.followRepo.Add(userID, entityType, ref)
+}
+
+// Unfollow removes a follow. Returns false if it did not exist.
+func (s *FeedService) Unfollow(userID int, entityType, ref string) (bool, error) {
+	if entityType != repository.FollowTeam && entityType != repository.FollowCompetition {
+		return false, invalidf("type must be %q or %q", repository.FollowTeam, repository.FollowCompetition)
+	}
+	return s.followRepo.Remove(userID, entityType, ref)
+}
+
+// ListFollows returns everything a user follows.
+func (s *FeedService) ListFollows(userID int) ([]repository.Follow, error) {
+	return s.followRepo.List(userID)
+}
+
+// Feed returns upcoming fixtures for the next days and results from the last
+// week, restricted to the user's followed teams and competitions.
+func (s *FeedService) Feed(userID, days int) (*Feed, error) {
+	follows, err := s.followRepo.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{Follows: follows, Upcoming: []FeedMatch{}, Results: []FeedMatch{}}
+	if len(follows) == 0 {
+		return feed, nil
+	}
+
+	var teamIDs, competitionIDs []int
+	for _, f := range follows {
+		if f.EntityType == repository.FollowTeam {
+			teamIDs = append(teamIDs, f.EntityID)
+		} else {
+			competitionIDs = append(competitionIDs, f.EntityID)
+		}
+	}
+
+	now := time.Now()
+
+	upcoming, err := s.matchRepo.ListForFeed(repository.FeedFilter{
+		TeamIDs:        teamIDs,
+		CompetitionIDs: competitionIDs,
+		From:           now,
+		To:             now.AddDate(0, 0, days),
+		Statuses:       []string{"SCHEDULED", "TIMED"},
+		Limit:          100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.matchRepo.ListForFeed(repository.FeedFilter{
+		TeamIDs:        teamIDs,
+		CompetitionIDs: competitionIDs,
+		From:           now.Add(-feedResultsWindow),
+		To:             now,
+		Statuses:       []string{"FINISHED"},
+		Descending:     true,
+		Limit:          100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(upcoming)+len(results))
+	for _, m := range upcoming {
+		ids = append(ids, m.ID)
+	}
+	for _, m := range results {
+		ids = append(ids, m.ID)
+	}
+
+	predictions, err := s.predictionRepo.GetByMatchIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range upcoming {
+		feed.Upcoming = append(feed.Upcoming, FeedMatch{MatchSummary: m, Prediction: predictions[m.ID]})
+	}
+	for _, m := range results {
+		feed.Results = append(feed.Results, FeedMatch{MatchSummary: m, Prediction: predictions[m.ID]})
+	}
+
+	return feed, nil
+}
@@ -1,38 +1,165 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/tracing"
+	"github.com/yourusername/football-prediction/pkg/breaker"
 	"github.com/yourusername/football-prediction/pkg/cache"
+	"github.com/yourusername/football-prediction/pkg/datasource"
 	"github.com/yourusername/football-prediction/pkg/football"
+	"github.com/yourusername/football-prediction/pkg/keypool"
+	"github.com/yourusername/football-prediction/pkg/quota"
 )
 
+// Entities cached from football-data.org, as named in CACHE_TTLS and as the
+// first part of their cache keys.
+const (
+	CacheCompetitions = "competitions"
+	CacheMatches      = "matches"
+	CacheStandings    = "standings"
+	CacheMatch        = "match"
+)
+
+// defaultCacheTTLs is how long each entity is cached unless CACHE_TTLS says
+// otherwise.
+var defaultCacheTTLs = map[string]time.Duration{
+	CacheCompetitions: 24 * time.Hour,
+	CacheMatches:      12 * time.Hour,
+	CacheStandings:    24 * time.Hour,
+	CacheMatch:        6 * time.Hour,
+}
+
+// ParseCacheTTLs parses "entity=duration" pairs separated by commas, e.g.
+// "matches=10m,match=2m". Invalid pairs are reported in the error and left
+// out of the result.
+func ParseCacheTTLs(spec string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	var invalid []string
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if _, known := defaultCacheTTLs[name]; err != nil || d <= 0 || !known {
+			invalid = append(invalid, pair)
+			continue
+		}
+		ttls[name] = d
+	}
+
+	if len(invalid) > 0 {
+		return ttls, fmt.Errorf("invalid cache TTLs %s", strings.Join(invalid, ", "))
+	}
+	return ttls, nil
+}
+
 type FootballService struct {
-	client     *football.Client
-	cache      *cache.Cache
-	compRepo   *repository.CompetitionRepository
-	matchRepo  *repository.MatchRepository
-	playerRepo *repository.PlayerRepository
-	cacheTTL   time.Duration
+	client         *football.Client
+	cache          *cache.Cache
+	compRepo       *repository.CompetitionRepository
+	matchRepo      *repository.MatchRepository
+	statusRepo     *repository.MatchStatusRepository
+	playerRepo     *repository.PlayerRepository
+	predictionRepo *repository.PredictionRepository
+	teamRepo       *repository.TeamRepository
+	cacheTTLs      map[string]time.Duration
 }
 
-func NewFootballService(apiKey string, db *sql.DB) *FootballService {
+// NewFootballService returns a service calling football-data.org, through
+// source, with the comma-separated apiKeys in turn. Requests are guarded by
+// the football-data breaker of breakers, when set. cacheTTLs overrides how
+// long entities are cached.
+func NewFootballService(source *datasource.Source, apiKeys string, db *sql.DB, quotas *quota.Manager, breakers *breaker.Set,
+	cacheTTLs map[string]time.Duration) *FootballService {
+	client := football.NewClient(source.Keys(apiKeys)...)
+	client.SetTransport(tracing.Transport(breaker.Transport(breakers.Get(football.Provider), source.Transport())))
+	client.SetQuota(source.Quota(quotas))
+
+	ttls := make(map[string]time.Duration, len(defaultCacheTTLs))
+	for name, ttl := range defaultCacheTTLs {
+		ttls[name] = ttl
+	}
+	for name, ttl := range cacheTTLs {
+		ttls[name] = ttl
+	}
+
 	return &FootballService{
-		client:     football.NewClient(apiKey),
-		cache:      cache.New(),
-		compRepo:   repository.NewCompetitionRepository(db),
-		matchRepo:  repository.NewMatchRepository(db),
-		playerRepo: repository.NewPlayerRepository(db),
-		cacheTTL:   24 * time.Hour, // 24 hours cache
+		client:         client,
+		cache:          cache.New(),
+		compRepo:       repository.NewCompetitionRepository(db),
+		matchRepo:      repository.NewMatchRepository(db),
+		statusRepo:     repository.NewMatchStatusRepository(db),
+		playerRepo:     repository.NewPlayerRepository(db),
+		predictionRepo: repository.NewPredictionRepository(db),
+		teamRepo:       repository.NewTeamRepository(db),
+		cacheTTLs:      ttls,
 	}
 }
 
+// CacheTTLs returns how long each entity is cached.
+func (s *FootballService) CacheTTLs() map[string]time.Duration {
+	return s.cacheTTLs
+}
+
+// CacheEntries returns the cached keys with their ages.
+func (s *FootballService) CacheEntries() []cache.Entry {
+	return s.cache.Entries()
+}
+
+// InvalidateCache removes the cached keys starting with prefix, every key
+// when it is empty, and returns how many it removed.
+func (s *FootballService) InvalidateCache(prefix string) int {
+	return s.cache.DeletePrefix(prefix)
+}
+
+// InvalidateMatch removes what an ingested match makes stale: the match
+// itself and its competition's match lists and, once it is finished, its
+// competition's standings. It returns how many keys it removed.
+func (s *FootballService) InvalidateMatch(externalID int, competitionCode, status string) int {
+	n := 0
+	key := fmt.Sprintf("%s:%d", CacheMatch, externalID)
+	if _, found := s.cache.Get(key); found {
+		s.cache.Delete(key)
+		n++
+	}
+	if competitionCode == "" {
+		return n
+	}
+	n += s.cache.DeletePrefix(fmt.Sprintf("%s:%s:", CacheMatches, competitionCode))
+	if status == "FINISHED" {
+		n += s.cache.DeletePrefix(fmt.Sprintf("%s:%s:", CacheStandings, competitionCode))
+	}
+	return n
+}
+
+// GetTeam finds a stored team by its football-data.org ID, then by internal
+// ID, returning nil if neither exists.
+func (s *FootballService) GetTeam(id int) (*repository.TeamInfo, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil || team != nil {
+		return team, err
+	}
+	return s.teamRepo.GetByID(id)
+}
+
+// KeyStats returns the request counters of each football-data.org key.
+func (s *FootballService) KeyStats() []keypool.Stats {
+	return s.client.KeyStats()
+}
+
 func (s *FootballService) GetCompetitions() ([]football.Competition, error) {
 	// Check cache first
-	cacheKey := "competitions:all"
+	cacheKey := CacheCompetitions + ":all"
 	if cached, found := s.cache.Get(cacheKey); found {
 		return cached.([]football.Competition), nil
 	}
@@ -52,14 +179,14 @@ func (s *FootballService) GetCompetitions() ([]football.Competition, error) {
 	}
 
 	// Cache the result
-	s.cache.Set(cacheKey, resp.Competitions, s.cacheTTL)
+	s.cache.Set(cacheKey, resp.Competitions, s.cacheTTLs[CacheCompetitions])
 
 	return resp.Competitions, nil
 }
 
 func (s *FootballService) GetMatches(competitionCode string, season string) (*football.MatchesResponse, error) {
 	// Check cache
-	cacheKey := fmt.Sprintf("matches:%s:%s", competitionCode, season)
+	cacheKey := fmt.Sprintf("%s:%s:%s", CacheMatches, competitionCode, season)
 	if cached, found := s.cache.Get(cacheKey); found {
 		return cached.(*football.MatchesResponse), nil
 	}
@@ -70,15 +197,15 @@ func (s *FootballService) GetMatches(competitionCode string, season string) (*fo
 		return nil, fmt.Errorf("failed to fetch matches: %w", err)
 	}
 
-	// Cache the result (shorter TTL for matches)
-	s.cache.Set(cacheKey, resp, 12*time.Hour)
+	// Cache the result
+	s.cache.Set(cacheKey, resp, s.cacheTTLs[CacheMatches])
 
 	return resp, nil
 }
 
 func (s *FootballService) GetStandings(competitionCode string, season string) (*football.StandingsResponse, error) {
 	// Check cache
-	cacheKey := fmt.Sprintf("standings:%s:%s", competitionCode, season)
+	cacheKey := fmt.Sprintf("%s:%s:%s", CacheStandings, competitionCode, season)
 	if cached, found := s.cache.Get(cacheKey); found {
 		return cached.(*football.StandingsResponse), nil
 	}
@@ -90,7 +217,7 @@ func (s *FootballService) GetStandings(competitionCode string, season string) (*
 	}
 
 	// Cache the result
-	s.cache.Set(cacheKey, resp, s.cacheTTL)
+	s.cache.Set(cacheKey, resp, s.cacheTTLs[CacheStandings])
 
 	return resp, nil
 }
@@ -105,32 +232,66 @@ func (s *FootballService) GetMatchByExternalID(externalID int) (map[string]inter
 	return s.matchRepo.GetMatchByExternalID(externalID)
 }
 
+// GetMatchStatusHistory returns a match, by external or internal ID, with
+// every change of its status and kickoff since it was stored, oldest
+// first. The match is nil if it does not exist.
+func (s *FootballService) GetMatchStatusHistory(id int) (*repository.MatchSummary, []repository.StatusChange, error) {
+	match, err := s.matchRepo.GetSummaryByExternalID(id)
+	if err == nil && match == nil {
+		match, err = s.matchRepo.GetSummaryByID(id)
+	}
+	if err != nil || match == nil {
+		return nil, nil, err
+	}
+
+	changes, err := s.statusRepo.History(match.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return match, changes, nil
+}
+
 func (s *FootballService) GetMatch(matchID int) (*football.Match, error) {
+	return s.GetMatchContext(context.Background(), matchID)
+}
+
+// GetMatchContext is GetMatch with the cache lookup and API call traced as
+// children of the span in ctx.
+func (s *FootballService) GetMatchContext(ctx context.Context, matchID int) (*football.Match, error) {
 	// Check cache
-	cacheKey := fmt.Sprintf("match:%d", matchID)
-	if cached, found := s.cache.Get(cacheKey); found {
+	cacheKey := fmt.Sprintf("%s:%d", CacheMatch, matchID)
+	cached, found := s.cache.Get(cacheKey)
+	tracing.CacheLookup(ctx, "match", found)
+	if found {
 		return cached.(*football.Match), nil
 	}
 
 	// Fetch from API
-	match, err := s.client.GetMatch(matchID)
+	match, err := s.client.GetMatchContext(ctx, matchID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch match: %w", err)
 	}
 
-	// Cache the result (shorter TTL for individual matches)
-	s.cache.Set(cacheKey, match, 6*time.Hour)
+	// Cache the result
+	s.cache.Set(cacheKey, match, s.cacheTTLs[CacheMatch])
 
 	return match, nil
 }
 
+// DefaultWeighting is how head-to-head records and form are weighted when
+// a request does not say: a result counts half after two years, which
+// keeps most of a decade of meetings in play while letting the last few
+// seasons lead, and a result at the other venue counts 80% as much.
+var DefaultWeighting = repository.Weighting{HalfLifeDays: 730, VenueWeight: 0.8}
+
 // GetHeadToHead returns historical record between the two clubs (by external team IDs).
-func (s *FootballService) GetHeadToHead(homeTeamExternalID, awayTeamExternalID, limit int) (*repository.HeadToHeadRecord, error) {
+func (s *FootballService) GetHeadToHead(homeTeamExternalID, awayTeamExternalID int, filter repository.HeadToHeadFilter) (*repository.HeadToHeadRecord, error) {
 	if s.matchRepo == nil {
 		return nil, fmt.Errorf("match repository not initialised")
 	}
 
-	return s.matchRepo.GetHeadToHeadByExternalTeamIDs(homeTeamExternalID, awayTeamExternalID, limit)
+	filter.CompetitionCode = strings.ToUpper(filter.CompetitionCode)
+	return s.matchRepo.GetHeadToHeadByExternalTeamIDs(homeTeamExternalID, awayTeamExternalID, filter)
 }
 
 // GetKeyPlayers returns key players for the given match, grouped into home/away
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+const (
+	// formFixtures is how many upcoming fixtures the form lists.
+	formFixtures = 5
+	// congestedMatches in the 14 days before kickoff is a congested
+	// schedule, e.g. a Saturday-Wednesday-Saturday run into the fixture.
+	congestedMatches = 4
+)
+
+// UpcomingFixture is a scheduled fixture of a team with how rested it will
+// be. RestDays is whole days since its previous match, nil if there is
+// none stored; MatchesLast14Days counts its matches in the 14 days before
+// kickoff, fixtures scheduled in between included.
+type UpcomingFixture struct {
+	ExternalID         int       `json:"externalId"`
+	UtcDate            time.Time `json:"utcDate"`
+	CompetitionCode    string    `json:"competitionCode"`
+	Home               bool      `json:"home"`
+	OpponentExternalID int       `json:"opponentExternalId"`
+	OpponentName       string    `json:"opponentName"`
+	RestDays           *int      `json:"restDays"`
+	MatchesLast14Days  int       `json:"matchesLast14Days"`
+	Congested          bool      `json:"congested"`
+}
+
+// TeamForm is a team's recent results and the load of its coming schedule.
+// Form lists results most recent first, e.g. "WWDLW". WeightedPointsPerGame
+// averages the points of those results by their Weighting weight, at the
+// venue of the next fixture, or nil without results.
+type TeamForm struct {
+	Team                  *repository.TeamInfo      `json:"team"`
+	Form                  string                    `json:"form"`
+	Points                int                       `json:"points"`
+	WeightedPointsPerGame *float64                  `json:"weightedPointsPerGame"`
+	Weighting             repository.Weighting      `json:"weighting"`
+	Recent                []repository.MatchSummary `json:"recent"`
+	Fixtures              []UpcomingFixture         `json:"fixtures"`
+}
+
+// Form returns the form and upcoming fixtures of a team identified by its
+// football-data.org or internal ID, or nil if the team does not exist.
+// Rest and congestion come from the stored schedules of every competition
+// the team plays in. Results at the other venue from the next fixture count
+// weighting.VenueWeight; without a fixture both venues count alike.
+func (s *TeamService) Form(id int, weighting repository.Weighting) (*TeamForm, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	recent, err := s.matchRepo.ListTeamMatches(repository.TeamMatchFilter{
+		TeamID: team.ID,
+		Status: "FINISHED",
+		Limit:  formMatches,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if recent == nil {
+		recent = []repository.MatchSummary{}
+	}
+
+	now := time.Now()
+	loads, err := s.matchRepo.ListTeamScheduleLoad(team.ID, now, formFixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	form := &TeamForm{Team: team, Weighting: weighting, Recent: recent}
+	var weighted, weights float64
+	for _, m := range recent {
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		gf, ga := *m.HomeScore, *m.AwayScore
+		if m.AwayTeamID == team.ID {
+			gf, ga = ga, gf
+		}
+		points := resultPoints(gf, ga)
+		form.Form += resultLetter(gf, ga)
+		form.Points += points
+
+		sameVenue := len(loads) == 0 || loads[0].Home == (m.HomeTeamID == team.ID)
+		weight := weighting.Weight(m.UtcDate, now, sameVenue)
+		weighted += weight * float64(points)
+		weights += weight
+	}
+	if weights > 0 {
+		v := math.Round(weighted/weights*100) / 100
+		form.WeightedPointsPerGame = &v
+	}
+
+	form.Fixtures = make([]UpcomingFixture, 0, len(loads))
+	for _, l := range loads {
+		form.Fixtures = append(form.Fixtures, UpcomingFixture{
+			ExternalID:         l.ExternalID,
+			UtcDate:            l.UtcDate,
+			CompetitionCode:    l.CompetitionCode,
+			Home:               l.Home,
+			OpponentExternalID: l.OpponentExternalID,
+			OpponentName:       l.OpponentName,
+			RestDays:           restDays(l.UtcDate, l.PreviousMatchAt),
+			MatchesLast14Days:  l.MatchesLast14Days,
+			Congested:          l.MatchesLast14Days >= congestedMatches,
+		})
+	}
+	return form, nil
+}
+
+// restDays is the whole days between a team's previous match and kickoff,
+// or nil without a previous match.
+func restDays(kickoff time.Time, previous *time.Time) *int {
+	if previous == nil {
+		return nil
+	}
+	days := int(kickoff.Sub(*previous).Hours() / 24)
+	return &days
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// goalTimingMinutes is the length of each interval of a goal timing
+// histogram, and goals after lateGoalMinute are late.
+const (
+	goalTimingMinutes = 15
+	lateGoalMinute    = 75
+)
+
+// GoalTimingInterval is the goals a team scored and conceded between two
+// minutes, both included. Stoppage time counts towards the interval it
+// extends, so the last of each half runs on past 45 or 90.
+type GoalTimingInterval struct {
+	From     int `json:"from"`
+	To       int `json:"to"`
+	Scored   int `json:"scored"`
+	Conceded int `json:"conceded"`
+}
+
+// TeamGoalTimings is when a team scores and concedes, in 15-minute
+// intervals over its finished matches with recorded events. The late shares
+// are the shares of the goals scored and conceded after minute 75, nil
+// without any. Extra time is left out.
+type TeamGoalTimings struct {
+	Team              *repository.TeamInfo `json:"team"`
+	Season            string               `json:"season,omitempty"`
+	CompetitionCode   string               `json:"competitionCode,omitempty"`
+	Matches           int                  `json:"matches"`
+	Scored            int                  `json:"scored"`
+	Conceded          int                  `json:"conceded"`
+	Intervals         []GoalTimingInterval `json:"intervals"`
+	LateScoredShare   *float64             `json:"lateScoredShare"`
+	LateConcededShare *float64             `json:"lateConcededShare"`
+}
+
+// GoalTimings returns the goal timings of a team identified by its
+// football-data.org or internal ID, or nil if the team does not exist.
+// season is the season start year; an empty season or competitionCode
+// covers them all.
+func (s *TeamService) GoalTimings(id int, season, competitionCode string) (*TeamGoalTimings, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	competitionCode = strings.ToUpper(competitionCode)
+	timings, err := goalTimings(s.matchRepo, team.ID, season, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+	timings.Team = team
+	return timings, nil
+}
+
+// goalTimings builds the goal timings of a team by internal ID, without the
+// team itself.
+func goalTimings(matchRepo *repository.MatchRepository, teamID int, season, competitionCode string) (*TeamGoalTimings, error) {
+	buckets, matches, err := matchRepo.ListTeamGoalTimings(teamID, season, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	timings := &TeamGoalTimings{
+		Season:          season,
+		CompetitionCode: competitionCode,
+		Matches:         matches,
+		Intervals:       make([]GoalTimingInterval, 0, len(buckets)),
+	}
+	var lateScored, lateConceded int
+	for _, b := range buckets {
+		from := b.Bucket*goalTimingMinutes + 1
+		timings.Intervals = append(timings.Intervals, GoalTimingInterval{
+			From:     from,
+			To:       from + goalTimingMinutes - 1,
+			Scored:   b.Scored,
+			Conceded: b.Conceded,
+		})
+		timings.Scored += b.Scored
+		timings.Conceded += b.Conceded
+		if from > lateGoalMinute {
+			lateScored += b.Scored
+			lateConceded += b.Conceded
+		}
+	}
+	timings.LateScoredShare = rate(lateScored, timings.Scored)
+	timings.LateConcededShare = rate(lateConceded, timings.Conceded)
+	return timings, nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/yourusername/football-prediction/internal/grounding"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+// groundingAttempts is how many times a narrative is generated before it is
+// rejected for stating numbers its facts do not support.
+const groundingAttempts = 2
+
+// groundedNarrative asks the LLM for a headline and summary written from
+// verified facts only. Each reply is checked against the facts; a reply
+// citing unknown facts or stating unsupported numbers is regenerated with
+// the reason, and rejected after groundingAttempts. The published text has
+// the citations stripped; the model returned is the one that wrote it.
+func groundedNarrative(client *llm.Client, systemPrompt, intro string, facts *grounding.Facts) (headline, summary, model string, err error) {
+	prompt := intro + "\n" + facts.Prompt()
+	systemPrompt += "\n" + grounding.Instructions
+
+	var rejection error
+	for attempt := 0; attempt < groundingAttempts; attempt++ {
+		userPrompt := prompt
+		if rejection != nil {
+			userPrompt += fmt.Sprintf("\nYour previous reply was rejected because it %v. Only state numbers from the facts you cite.", rejection)
+		}
+
+		var reply struct {
+			Headline string `json:"headline"`
+			Summary  string `json:"summary"`
+		}
+		r, err := client.CompleteJSON(systemPrompt, userPrompt, &reply)
+		if err != nil {
+			return "", "", "", err
+		}
+		if reply.Headline == "" || reply.Summary == "" {
+			return "", "", "", fmt.Errorf("LLM reply missing headline or summary")
+		}
+
+		if _, err := facts.Check(reply.Headline + "\n" + reply.Summary); err != nil {
+			rejection = err
+			continue
+		}
+		return grounding.Strip(reply.Headline), grounding.Strip(reply.Summary), r.Model, nil
+	}
+	return "", "", "", fmt.Errorf("LLM reply rejected: it %w", rejection)
+}
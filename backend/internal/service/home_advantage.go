@@ -0,0 +1,210 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// DefaultHomeAdvantageWindows are the windows computed when none are
+// configured: about half a league season and two seasons at each venue.
+var DefaultHomeAdvantageWindows = []int{10, 38}
+
+// ParseHomeAdvantageWindows parses window lengths, in matches per venue,
+// separated by commas, e.g. "10,38". Invalid lengths are reported in the
+// error and left out of the result, which falls back to
+// DefaultHomeAdvantageWindows when empty.
+func ParseHomeAdvantageWindows(spec string) ([]int, error) {
+	var windows []int
+	var invalid []string
+	seen := make(map[int]bool)
+	for _, w := range strings.Split(spec, ",") {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		n, err := strconv.Atoi(w)
+		if err != nil || n <= 0 {
+			invalid = append(invalid, w)
+			continue
+		}
+		if !seen[n] {
+			seen[n] = true
+			windows = append(windows, n)
+		}
+	}
+	sort.Ints(windows)
+
+	if len(windows) == 0 {
+		windows = DefaultHomeAdvantageWindows
+	}
+	if len(invalid) > 0 {
+		return windows, fmt.Errorf("invalid home advantage windows %s", strings.Join(invalid, ", "))
+	}
+	return windows, nil
+}
+
+// HomeAdvantageService computes and serves home advantage per competition
+// and team over a few windows of recent matches. There is no attendance
+// data, so the crowd effect is read from how the advantage moves between
+// consecutive windows.
+type HomeAdvantageService struct {
+	repo     *repository.HomeAdvantageRepository
+	teamRepo *repository.TeamRepository
+	windows  []int
+}
+
+// NewHomeAdvantageService computes the given windows, in matches per venue,
+// shortest first.
+func NewHomeAdvantageService(db *sql.DB, windows []int) *HomeAdvantageService {
+	return &HomeAdvantageService{
+		repo:     repository.NewHomeAdvantageRepository(db),
+		teamRepo: repository.NewTeamRepository(db),
+		windows:  windows,
+	}
+}
+
+// HomeAdvantageRow is the home advantage of a competition, or of a team in
+// it when Team is set. Trend is the change in the points-per-game
+// differential since the previous window, nil until that was played.
+type HomeAdvantageRow struct {
+	CompetitionCode         string             `json:"competitionCode"`
+	CompetitionName         string             `json:"competitionName"`
+	Team                    *HomeAdvantageTeam `json:"team,omitempty"`
+	HomeMatches             int                `json:"homeMatches"`
+	AwayMatches             int                `json:"awayMatches"`
+	HomePpg                 float64            `json:"homePpg"`
+	AwayPpg                 float64            `json:"awayPpg"`
+	PpgDifferential         float64            `json:"ppgDifferential"`
+	HomeGoalDifference      float64            `json:"homeGoalDifference"`
+	AwayGoalDifference      float64            `json:"awayGoalDifference"`
+	GoalDifferential        float64            `json:"goalDifferential"`
+	PreviousPpgDifferential *float64           `json:"previousPpgDifferential"`
+	Trend                   *float64           `json:"trend"`
+}
+
+// HomeAdvantageTeam identifies a team by its external ID.
+type HomeAdvantageTeam struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// HomeAdvantageReport is the home advantage of competitions and teams over
+// one window.
+type HomeAdvantageReport struct {
+	WindowMatches int                `json:"windowMatches"`
+	Windows       []int              `json:"windows"`
+	ComputedAt    *time.Time         `json:"computedAt"`
+	Competitions  []HomeAdvantageRow `json:"competitions"`
+	Teams         []HomeAdvantageRow `json:"teams"`
+}
+
+// Windows returns the computed windows, shortest first.
+func (s *HomeAdvantageService) Windows() []int {
+	return s.windows
+}
+
+// Refresh recomputes every window from stored results.
+func (s *HomeAdvantageService) Refresh() error {
+	return s.repo.Refresh(s.windows)
+}
+
+// Report returns the home advantage over window matches per venue, the
+// shortest computed window when 0, optionally of one competition or of one
+// team identified by its football-data.org or internal ID. It returns nil
+// if the window is not computed or the team does not exist.
+func (s *HomeAdvantageService) Report(competitionCode string, teamID, window int) (*HomeAdvantageReport, error) {
+	if window == 0 && len(s.windows) > 0 {
+		window = s.windows[0]
+	}
+	known := false
+	for _, w := range s.windows {
+		known = known || w == window
+	}
+	if !known {
+		return nil, nil
+	}
+
+	filter := repository.HomeAdvantageFilter{WindowMatches: window, CompetitionCode: strings.ToUpper(competitionCode)}
+	if teamID != 0 {
+		team, err := s.teamRepo.GetByExternalID(teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			if team, err = s.teamRepo.GetByID(teamID); err != nil {
+				return nil, err
+			}
+		}
+		if team == nil {
+			return nil, nil
+		}
+		filter.TeamID = team.ID
+	}
+
+	stats, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &HomeAdvantageReport{
+		WindowMatches: window,
+		Windows:       s.windows,
+		Competitions:  []HomeAdvantageRow{},
+		Teams:         []HomeAdvantageRow{},
+	}
+	for _, h := range stats {
+		if report.ComputedAt == nil {
+			computedAt := h.ComputedAt
+			report.ComputedAt = &computedAt
+		}
+		row := HomeAdvantageRow{
+			CompetitionName:         h.CompetitionName,
+			HomeMatches:             h.HomeMatches,
+			AwayMatches:             h.AwayMatches,
+			HomePpg:                 h.HomePpg,
+			AwayPpg:                 h.AwayPpg,
+			PpgDifferential:         h.PpgDifferential,
+			HomeGoalDifference:      h.HomeGoalDifference,
+			AwayGoalDifference:      h.AwayGoalDifference,
+			GoalDifferential:        h.GoalDifferential,
+			PreviousPpgDifferential: h.PreviousPpgDifferential,
+		}
+		if h.CompetitionCode != nil {
+			row.CompetitionCode = *h.CompetitionCode
+		}
+		if p := h.PreviousPpgDifferential; p != nil {
+			trend := math.Round((h.PpgDifferential-*p)*100) / 100
+			row.Trend = &trend
+		}
+		if h.TeamExternalID == nil {
+			report.Competitions = append(report.Competitions, row)
+			continue
+		}
+		row.Team = &HomeAdvantageTeam{ID: *h.TeamExternalID}
+		if h.TeamName != nil {
+			row.Team.Name = *h.TeamName
+		}
+		report.Teams = append(report.Teams, row)
+	}
+	return report, nil
+}
+
+// resultPoints is the league points a team scoring gf and conceding ga
+// takes from a match.
+func resultPoints(gf, ga int) int {
+	switch {
+	case gf > ga:
+		return 3
+	case gf == ga:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"math"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// htftMatches is the number of recent results with a half-time score the
+// comeback and lead-hold features cover. Turnarounds are rare, so the window
+// is wider than the goal averages'.
+const htftMatches = 20
+
+// HalfTimeFullTime counts a team's matches by its result at half time and at
+// full time, over matches with a stored half-time score. Patterns are keyed
+// "HT/FT" with W, D or L from the team's side, e.g. "L/W" for a match won
+// after trailing at the break.
+//
+// A comeback is a match trailing at half time that was not lost; a blown lead
+// is one leading at half time that was not won. The rates are nil when the
+// team never trailed, or never led, at half time.
+type HalfTimeFullTime struct {
+	Matches      int            `json:"matches"`
+	Patterns     map[string]int `json:"patterns"`
+	Comebacks    int            `json:"comebacks"`
+	ComebackRate *float64       `json:"comebackRate"`
+	BlownLeads   int            `json:"blownLeads"`
+	LeadHoldRate *float64       `json:"leadHoldRate"`
+
+	trailing, leading int
+}
+
+func newHalfTimeFullTime() *HalfTimeFullTime {
+	return &HalfTimeFullTime{Patterns: map[string]int{}}
+}
+
+// add counts n matches with the given half-time and full-time results.
+func (h *HalfTimeFullTime) add(halfTime, fullTime string, n int) {
+	h.Matches += n
+	h.Patterns[halfTime+"/"+fullTime] += n
+
+	switch halfTime {
+	case "L":
+		h.trailing += n
+		if fullTime != "L" {
+			h.Comebacks += n
+		}
+	case "W":
+		h.leading += n
+		if fullTime != "W" {
+			h.BlownLeads += n
+		}
+	}
+	h.ComebackRate = rate(h.Comebacks, h.trailing)
+	h.LeadHoldRate = rate(h.leading-h.BlownLeads, h.leading)
+}
+
+// resultLetter is W, D or L for a team that scored gf and conceded ga.
+func resultLetter(gf, ga int) string {
+	switch {
+	case gf > ga:
+		return "W"
+	case gf == ga:
+		return "D"
+	default:
+		return "L"
+	}
+}
+
+func rate(n, of int) *float64 {
+	if of == 0 {
+		return nil
+	}
+	v := math.Round(float64(n)/float64(of)*1000) / 1000
+	return &v
+}
+
+// recentHalfTimeFullTime counts the HT/FT patterns of a team's most recent
+// results that have a half-time score, up to htftMatches. results are
+// ordered oldest first.
+func recentHalfTimeFullTime(results []repository.TrainingResult, teamID int) *HalfTimeFullTime {
+	h := newHalfTimeFullTime()
+	for i := len(results) - 1; i >= 0 && h.Matches < htftMatches; i-- {
+		r := results[i]
+		if r.HalfTimeHomeScore == nil || r.HalfTimeAwayScore == nil {
+			continue
+		}
+
+		switch teamID {
+		case r.HomeTeamID:
+			h.add(resultLetter(*r.HalfTimeHomeScore, *r.HalfTimeAwayScore), resultLetter(r.HomeScore, r.AwayScore), 1)
+		case r.AwayTeamID:
+			h.add(resultLetter(*r.HalfTimeAwayScore, *r.HalfTimeHomeScore), resultLetter(r.AwayScore, r.HomeScore), 1)
+		}
+	}
+	return h
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"database/sql"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// IngestFailureService reports on rows ingestion could not save.
+type IngestFailureService struct {
+	repo *repository.IngestFailureRepository
+}
+
+func NewIngestFailureService(db *sql.DB) *IngestFailureService {
+	return &IngestFailureService{repo: repository.NewIngestFailureRepository(db)}
+}
+
+// Counts returns open and resolved failure counts per entity type.
+func (s *IngestFailureService) Counts() ([]repository.IngestFailureCount, error) {
+	return s.repo.Counts()
+}
+
+// Recent returns the latest failures, optionally only open ones of one type.
+func (s *IngestFailureService) Recent(entityType string, openOnly bool, limit int) ([]repository.IngestFailure, error) {
+	return s.repo.ListRecent(entityType, openOnly, limit)
+}
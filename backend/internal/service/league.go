@@ -0,0 +1,132 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// LeagueService manages user score predictions and private leagues.
+type LeagueService struct {
+	repo      *repository.LeagueRepository
+	matchRepo *repository.MatchRepository
+}
+
+func NewLeagueService(db *sql.DB) *LeagueService {
+	return &LeagueService{
+		repo:      repository.NewLeagueRepository(db),
+		matchRepo: repository.NewMatchRepository(db),
+	}
+}
+
+// Predict stores a user's score prediction for a match (by external ID).
+// Predictions are accepted until kickoff. Returns false if the match is
+// unknown.
+func (s *LeagueService) Predict(userID, matchExternalID, homeGoals, awayGoals int) (bool, error) {
+	if homeGoals < 0 || awayGoals < 0 || homeGoals > 20 || awayGoals > 20 {
+		return false, invalidf("goals must be between 0 and 20")
+	}
+
+	match, err := s.matchRepo.GetSummaryByExternalID(matchExternalID)
+	if err != nil {
+		return false, err
+	}
+	if match == nil {
+		return false, nil
+	}
+
+	if (match.Status != "SCHEDULED" && match.Status != "TIMED") || !match.UtcDate.After(time.Now()) {
+		return true, invalidf("predictions are closed for this match")
+	}
+
+	return true, s.repo.UpsertPrediction(userID, match.ID, homeGoals, awayGoals)
+}
+
+// ListPredictions returns a user's predictions.
+func (s *LeagueService) ListPredictions(userID, limit int) ([]repository.UserPrediction, error) {
+	return s.repo.ListPredictions(userID, limit)
+}
+
+// SettlePredictions scores user predictions for finished matches.
+func (s *LeagueService) SettlePredictions() (int, error) {
+	return s.repo.SettlePredictions()
+}
+
+// Create starts a new league owned by the user. competitionCode may be empty
+// for a league covering all competitions.
+func (s *LeagueService) Create(ownerID int, name, competitionCode string) (*repository.League, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, invalidf("name must be 1-100 characters")
+	}
+
+	code, err := randomHex(4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite code: %w", err)
+	}
+
+	league := &repository.League{
+		Name:            name,
+		OwnerID:         ownerID,
+		CompetitionCode: strings.ToUpper(competitionCode),
+		InviteCode:      strings.ToUpper(code),
+	}
+
+	found, err := s.repo.Create(league)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, invalidf("unknown competition %q", competitionCode)
+	}
+
+	return league, nil
+}
+
+// Join adds the user to a league by invite code. Returns nil if the code is
+// unknown.
+func (s *LeagueService) Join(userID int, inviteCode string) (*repository.League, error) {
+	return s.repo.Join(userID, strings.ToUpper(strings.TrimSpace(inviteCode)))
+}
+
+// ListForUser returns the user's leagues.
+func (s *LeagueService) ListForUser(userID int) ([]repository.League, error) {
+	return s.repo.ListForUser(userID)
+}
+
+// Table returns the league and its ranked table including the model, or nil
+// if the user is not a member.
+func (s *LeagueService) Table(leagueID, userID int) (*repository.League, []repository.LeagueTableRow, error) {
+	league, err := s.repo.GetForMember(leagueID, userID)
+	if err != nil || league == nil {
+		return nil, nil, err
+	}
+
+	table, err := s.repo.GetTable(leagueID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.ExactScores != b.ExactScores {
+			return a.ExactScores > b.ExactScores
+		}
+		if a.CorrectResults != b.CorrectResults {
+			return a.CorrectResults > b.CorrectResults
+		}
+		return a.Name < b.Name
+	})
+	for i := range table {
+		table[i].Position = i + 1
+	}
+
+	return league, table, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// PredictionUpdate is the data of a prediction.updated event: a match's
+// prediction made again once its lineups were announced, the regulars left
+// out of them and the prediction it replaces, nil when there was none.
+type PredictionUpdate struct {
+	repository.NewLineupMatch
+	HomeWinProbability float64                      `json:"homeWinProbability"`
+	DrawProbability    float64                      `json:"drawProbability"`
+	AwayWinProbability float64                      `json:"awayWinProbability"`
+	PredictedOutcome   string                       `json:"predictedOutcome"`
+	ConfidenceScore    float64                      `json:"confidenceScore"`
+	ModelVersion       string                       `json:"modelVersion"`
+	ExpectedHomeGoals  *float64                     `json:"expectedHomeGoals,omitempty"`
+	ExpectedAwayGoals  *float64                     `json:"expectedAwayGoals,omitempty"`
+	Insights           []string                     `json:"insights"`
+	PredictedAt        time.Time                    `json:"predictedAt"`
+	Absences           []repository.LineupAbsence   `json:"absences"`
+	Previous           *repository.PredictionRecord `json:"previous"`
+}
+
+// NewLineupMatches returns up to limit scheduled matches kicking off within
+// window whose lineups were stored since they were last predicted, soonest
+// first.
+func (s *PredictionService) NewLineupMatches(window time.Duration, limit int) ([]repository.NewLineupMatch, error) {
+	now := time.Now().UTC()
+	return s.repo.ListNewLineupMatches(now, now.Add(window), limit)
+}
+
+// RepredictWithLineups predicts a match again now its lineups are
+// announced, whatever its cached prediction, hands the update to announce
+// and then records the prediction as the match's next version with a fresh
+// feature snapshot. Nothing is recorded when announce fails, so the match
+// is predicted and announced again on the next run. It returns nil when no
+// model could predict the match now: the fixed fallback is not announced,
+// nor the cached prediction served while the ML service sheds requests.
+func (s *PredictionService) RepredictWithLineups(ctx context.Context, m repository.NewLineupMatch, announce func(*PredictionUpdate) error) (*PredictionUpdate, error) {
+	previous, err := s.repo.GetByMatchID(m.MatchID)
+	if err != nil {
+		return nil, err
+	}
+	absences, err := s.playerRepo.ListLineupAbsences(m.MatchID, time.Now().UTC(), regularLineups, regularStarts)
+	if err != nil {
+		return nil, err
+	}
+
+	in := PredictionInput{
+		MatchID:            m.MatchID,
+		HomeTeamID:         m.HomeTeamID,
+		AwayTeamID:         m.AwayTeamID,
+		HomeTeamExternalID: m.HomeTeamExternalID,
+		AwayTeamExternalID: m.AwayTeamExternalID,
+		HomeTeamName:       m.HomeTeamName,
+		AwayTeamName:       m.AwayTeamName,
+		Matchday:           m.Matchday,
+	}
+	p := s.PredictFrom(ctx, in, nil, true)
+	if p.Cached || p.ModelVersion == fallbackModelVersion {
+		return nil, nil
+	}
+
+	update := &PredictionUpdate{
+		NewLineupMatch:     m,
+		HomeWinProbability: p.HomeWinProbability,
+		DrawProbability:    p.DrawProbability,
+		AwayWinProbability: p.AwayWinProbability,
+		PredictedOutcome:   p.PredictedOutcome,
+		ConfidenceScore:    p.ConfidenceScore,
+		ModelVersion:       p.ModelVersion,
+		ExpectedHomeGoals:  p.ExpectedHomeGoals,
+		ExpectedAwayGoals:  p.ExpectedAwayGoals,
+		Insights:           p.Insights,
+		PredictedAt:        p.PredictedAt,
+		Absences:           absences,
+		Previous:           previous,
+	}
+	if err := announce(update); err != nil {
+		return nil, err
+	}
+	if err := s.Record(m.MatchID, in, p); err != nil {
+		return nil, err
+	}
+	return update, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/breaker"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+// LLM tasks, each routed to its own models by LLM_ROUTES and accounted
+// separately in llm_usage.
+const (
+	llmTaskAsk     = "ask"
+	llmTaskPreview = "preview"
+	llmTaskRecap   = "recap"
+	llmTaskSignals = "signals"
+)
+
+// LLMSettings configures the LLM providers. A provider is available when its
+// key, or for Ollama its URL, is set. Routes and Prices are in the formats of
+// llm.ParseRoutes and llm.ParsePrices.
+type LLMSettings struct {
+	OpenAIURL    string
+	OpenAIKey    string
+	OpenAIModel  string
+	AnthropicURL string
+	AnthropicKey string
+	OllamaURL    string
+	Routes       string
+	Prices       string
+	// Breakers, when set, guard each provider with the breaker named
+	// "llm-" and the provider's name.
+	Breakers *breaker.Set
+}
+
+// NewLLMClient returns the client every generated-content feature calls
+// models through, recording each request in llm_usage. Without routes, every
+// task tries the available providers in the order OpenAI-compatible,
+// Anthropic, Ollama. Invalid routes or prices are reported in the error and
+// the defaults used instead.
+func NewLLMClient(db *sql.DB, s LLMSettings) (*llm.Client, error) {
+	var providers []llm.Provider
+	var fallback []llm.Target
+	if s.OpenAIKey != "" {
+		model := s.OpenAIModel
+		if model == "" {
+			model = llm.DefaultModel
+		}
+		p := llm.NewOpenAI(s.OpenAIURL, s.OpenAIKey)
+		p.SetTransport(s.transport(llm.ProviderOpenAI))
+		providers = append(providers, p)
+		fallback = append(fallback, llm.Target{Provider: llm.ProviderOpenAI, Model: model})
+	}
+	if s.AnthropicKey != "" {
+		p := llm.NewAnthropic(s.AnthropicURL, s.AnthropicKey)
+		p.SetTransport(s.transport(llm.ProviderAnthropic))
+		providers = append(providers, p)
+		fallback = append(fallback, llm.Target{Provider: llm.ProviderAnthropic, Model: llm.DefaultAnthropicModel})
+	}
+	if s.OllamaURL != "" {
+		p := llm.NewOllama(s.OllamaURL)
+		p.SetTransport(s.transport(llm.ProviderOllama))
+		providers = append(providers, p)
+		fallback = append(fallback, llm.Target{Provider: llm.ProviderOllama, Model: llm.DefaultOllamaModel})
+	}
+
+	var errs []error
+	defaults := map[string][]llm.Target{llm.TaskDefault: fallback}
+	routes, err := llm.ParseRoutes(s.Routes, defaults)
+	if err != nil {
+		errs = append(errs, err)
+		routes = defaults
+	}
+	prices, err := llm.ParsePrices(s.Prices, llm.DefaultPrices)
+	if err != nil {
+		errs = append(errs, err)
+		prices = llm.DefaultPrices
+	}
+
+	return llm.NewClient(llm.Config{
+		Providers: providers,
+		Routes:    routes,
+		Prices:    prices,
+		Usage:     repository.NewLLMUsageRepository(db),
+	}), errors.Join(errs...)
+}
+
+// transport returns the transport of a provider, guarded by its breaker.
+func (s LLMSettings) transport(provider string) http.RoundTripper {
+	return breaker.Transport(s.Breakers.Get("llm-"+provider), nil)
+}
+
+// LLMUsageSummary totals the LLM requests made since a time, overall and per
+// task and model.
+type LLMUsageSummary struct {
+	Since        time.Time                  `json:"since"`
+	Requests     int                        `json:"requests"`
+	Failures     int                        `json:"failures"`
+	InputTokens  int                        `json:"inputTokens"`
+	OutputTokens int                        `json:"outputTokens"`
+	CostUSD      float64                    `json:"costUsd"`
+	Models       []repository.LLMModelUsage `json:"models"`
+}
+
+// LLMUsageService reports the tokens and cost of LLM requests.
+type LLMUsageService struct {
+	repo *repository.LLMUsageRepository
+}
+
+func NewLLMUsageService(db *sql.DB) *LLMUsageService {
+	return &LLMUsageService{repo: repository.NewLLMUsageRepository(db)}
+}
+
+// Summary totals the requests of the last days.
+func (s *LLMUsageService) Summary(days int) (*LLMUsageSummary, error) {
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	models, err := s.repo.ListSummary(since)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &LLMUsageSummary{Since: since, Models: models}
+	for _, m := range models {
+		summary.Requests += m.Requests
+		summary.Failures += m.Failures
+		summary.InputTokens += m.InputTokens
+		summary.OutputTokens += m.OutputTokens
+		summary.CostUSD += m.CostUSD
+	}
+	return summary, nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// Optional sections of the match detail document, selectable with fields=.
+const (
+	MatchFieldScore      = "score"
+	MatchFieldGoals      = "goals"
+	MatchFieldLineups    = "lineups"
+	MatchFieldPlayers    = "players"
+	MatchFieldReferee    = "referee"
+	MatchFieldVenue      = "venue"
+	MatchFieldPrediction = "prediction"
+)
+
+// MatchDetailFields lists every optional section in response order. It is
+// the default when no fields are requested.
+var MatchDetailFields = []string{
+	MatchFieldScore, MatchFieldGoals, MatchFieldLineups, MatchFieldPlayers,
+	MatchFieldReferee, MatchFieldVenue, MatchFieldPrediction,
+}
+
+// ParseMatchFields parses a comma-separated fields= selector. An empty
+// selector returns all sections.
+func ParseMatchFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return MatchDetailFields, nil
+	}
+
+	var fields []string
+	seen := map[string]bool{}
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || seen[f] {
+			continue
+		}
+		if !containsString(MatchDetailFields, f) {
+			return nil, invalidf("unknown field %q (valid: %s)", f, strings.Join(MatchDetailFields, ", "))
+		}
+		seen[f] = true
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// GetMatchDetail assembles a match document from local storage, loading only
+// the requested sections. id may be a football-data.org or internal match ID.
+// The provider is called only for pieces that are missing locally: the whole
+// match if it was never ingested, or goals, referee and venue that were not
+// stored yet.
+func (s *FootballService) GetMatchDetail(id int, fields []string) (*repository.MatchDetail, error) {
+	want := map[string]bool{}
+	for _, f := range fields {
+		want[f] = true
+	}
+
+	detail, err := s.matchRepo.GetDetailByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		if detail, err = s.matchRepo.GetDetailByID(id); err != nil {
+			return nil, err
+		}
+	}
+	if detail == nil {
+		match, err := s.GetMatch(id)
+		if err != nil {
+			return nil, err
+		}
+		return matchDetailFromAPI(match), nil
+	}
+
+	if want[MatchFieldGoals] {
+		if detail.Goals, err = s.matchRepo.ListGoals(detail.ID); err != nil {
+			return nil, err
+		}
+	}
+	if want[MatchFieldLineups] {
+		if detail.Lineups, err = s.matchRepo.ListLineups(detail.ID); err != nil {
+			return nil, err
+		}
+	}
+	if want[MatchFieldPlayers] {
+		if detail.PlayerStats, err = s.matchRepo.ListPlayerStats(detail.ID); err != nil {
+			return nil, err
+		}
+	}
+	if want[MatchFieldPrediction] {
+		if detail.Prediction, err = s.predictionRepo.GetByMatchID(detail.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	missingGoals := want[MatchFieldGoals] && len(detail.Goals) == 0 && goalsScored(detail.Score) > 0
+	missingReferee := want[MatchFieldReferee] && detail.Referee == ""
+	missingVenue := want[MatchFieldVenue] && detail.Venue == ""
+	if missingGoals || missingReferee || missingVenue {
+		// Best effort: serve what is stored if the provider is unavailable.
+		if match, err := s.GetMatch(detail.ExternalID); err == nil {
+			if missingGoals {
+				detail.Goals = goalsFromAPI(match)
+			}
+			if missingReferee {
+				detail.Referee = match.MainReferee()
+			}
+			if missingVenue {
+				detail.Venue = match.Venue
+			}
+		}
+	}
+
+	return detail, nil
+}
+
+func goalsScored(score repository.MatchScore) int {
+	n := 0
+	if score.Home != nil {
+		n += *score.Home
+	}
+	if score.Away != nil {
+		n += *score.Away
+	}
+	return n
+}
+
+// matchDetailFromAPI converts a provider match that is not stored locally.
+// Lineups, player stats and predictions only exist in the database, so they
+// are left empty.
+func matchDetailFromAPI(m *football.Match) *repository.MatchDetail {
+	goals := m.Score.Goals()
+	return &repository.MatchDetail{
+		ExternalID:      m.ID,
+		CompetitionCode: m.Competition.Code,
+		Season:          fmt.Sprintf("%d", m.Season.ID),
+		Matchday:        m.Matchday,
+		Stage:           m.Stage,
+		Group:           m.Group,
+		UtcDate:         m.UtcDate,
+		Status:          m.Status,
+		HomeTeam:        matchTeamFromAPI(m.HomeTeam),
+		AwayTeam:        matchTeamFromAPI(m.AwayTeam),
+		Score: repository.MatchScore{
+			Home:      goals.Home,
+			Away:      goals.Away,
+			HalfTime:  repository.ScorePair(m.Score.HalfTime),
+			ExtraTime: repository.ScorePair(m.Score.ExtraTime),
+			Penalties: repository.ScorePair(m.Score.Penalties),
+			Winner:    m.Score.Winner,
+			Duration:  m.Score.Duration,
+			DecidedBy: decidedBy(m.Status, m.Score.Duration),
+		},
+		Referee:     m.MainReferee(),
+		Venue:       m.Venue,
+		Goals:       goalsFromAPI(m),
+		Lineups:     []repository.TeamLineup{},
+		PlayerStats: []repository.PlayerMatchLine{},
+	}
+}
+
+// decidedBy mirrors the matches.decided_by column for provider matches.
+func decidedBy(status, duration string) string {
+	if status != "FINISHED" {
+		return ""
+	}
+	if duration == "" {
+		return football.DurationRegular
+	}
+	return duration
+}
+
+func matchTeamFromAPI(t football.Team) repository.MatchTeam {
+	return repository.MatchTeam{
+		ExternalID: t.ID,
+		Name:       t.Name,
+		ShortName:  t.ShortName,
+		TLA:        t.TLA,
+		Crest:      t.Crest,
+	}
+}
+
+// goalsFromAPI maps provider goals onto the stored event types. Own goals are
+// credited to the opponent of the scorer's team, as in match_events.
+func goalsFromAPI(m *football.Match) []repository.MatchGoal {
+	goals := []repository.MatchGoal{}
+	for _, g := range m.Goals {
+		goal := repository.MatchGoal{
+			Minute:         g.Minute,
+			InjuryTime:     g.InjuryTime,
+			Type:           "GOAL",
+			TeamExternalID: g.Team.ID,
+			Scorer:         g.Scorer.Name,
+		}
+		switch g.Type {
+		case "OWN":
+			goal.Type = "OWN_GOAL"
+			if g.Team.ID == m.HomeTeam.ID {
+				goal.TeamExternalID = m.AwayTeam.ID
+			} else {
+				goal.TeamExternalID = m.HomeTeam.ID
+			}
+		case "PENALTY":
+			goal.Type = "PENALTY"
+		}
+		if g.Assist != nil {
+			goal.Assist = g.Assist.Name
+		}
+		goals = append(goals, goal)
+	}
+	return goals
+}
+
+// MatchTeamStats is a match with both teams' statistics.
+type MatchTeamStats struct {
+	Match *repository.MatchSummary    `json:"match"`
+	Teams []repository.TeamMatchStats `json:"teams"`
+}
+
+// GetMatchTeamStats returns the stored team statistics (possession, shots,
+// cards) of a match identified by its football-data.org or internal ID, or
+// nil if the match is not stored.
+func (s *FootballService) GetMatchTeamStats(id int) (*MatchTeamStats, error) {
+	match, err := s.matchRepo.GetSummaryByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		if match, err = s.matchRepo.GetSummaryByID(id); err != nil {
+			return nil, err
+		}
+	}
+	if match == nil {
+		return nil, nil
+	}
+
+	teams, err := s.matchRepo.ListTeamStats(match.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &MatchTeamStats{Match: match, Teams: teams}, nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/grounding"
+	"github.com/yourusername/football-prediction/internal/moderation"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Kinds of generated insight checked before they are published.
+const (
+	InsightKindRecap   = "recap"
+	InsightKindPreview = "preview"
+	InsightKindAnswer  = "answer"
+)
+
+// Statuses of a queued insight review.
+const (
+	InsightReviewPending  = "pending"
+	InsightReviewApproved = "approved"
+	InsightReviewRejected = "rejected"
+)
+
+// narrativeRules bound recap and preview headlines and summaries; answers
+// only have a body, and may be a short "the data does not say".
+var (
+	narrativeRules = moderation.Rules{
+		Headline:  moderation.Limits{Min: 15, Max: 120},
+		Body:      moderation.Limits{Min: 80, Max: 1500},
+		ShortBody: 200,
+	}
+	answerRules = moderation.Rules{
+		Body: moderation.Limits{Min: 1, Max: 2000},
+	}
+)
+
+// withheldAnswer replaces an answer that failed moderation.
+const withheldAnswer = "I can't give a reliable answer to that from the stored data."
+
+// InsightReviewService is the quality gate generated text passes before it is
+// published. Texts with issues are queued for review: rejected ones are
+// replaced, by a template or a stock answer, and flagged ones published.
+type InsightReviewService struct {
+	repo      *repository.InsightReviewRepository
+	narrative moderation.Rules
+	answer    moderation.Rules
+}
+
+// NewInsightReviewService bans the terms in moderation.DefaultBanned and
+// banned.
+func NewInsightReviewService(db *sql.DB, banned []string) *InsightReviewService {
+	terms := append(append([]string{}, moderation.DefaultBanned...), banned...)
+	s := &InsightReviewService{
+		repo:      repository.NewInsightReviewRepository(db),
+		narrative: narrativeRules,
+		answer:    answerRules,
+	}
+	s.narrative.Banned = terms
+	s.answer.Banned = terms
+	return s
+}
+
+// Narrative checks a recap or preview of match before it is published. On
+// top of the rules, every number and scoreline must be stated by one of the
+// facts it was written from. It returns an error when the text must not be
+// published.
+func (s *InsightReviewService) Narrative(kind string, match *repository.MatchSummary, generatedBy, headline, summary string, facts *grounding.Facts) error {
+	issues := s.narrative.Check(moderation.Text{
+		Headline: headline,
+		Body:     summary,
+		Names:    []string{match.HomeTeamName, match.AwayTeamName},
+	})
+	if unsupported := facts.Unsupported(headline + "\n" + summary); len(unsupported) > 0 {
+		issues = append([]moderation.Issue{{
+			Check:    "factual",
+			Severity: moderation.SeverityReject,
+			Detail:   "states " + strings.Join(unsupported, ", ") + " which no stored fact supports",
+		}}, issues...)
+	}
+
+	return s.queue(issues, repository.InsightReview{
+		Kind:        kind,
+		MatchID:     &match.ID,
+		Headline:    headline,
+		Body:        summary,
+		GeneratedBy: generatedBy,
+	})
+}
+
+// Answer checks an answer to a question before it is returned, and returns
+// the answer to publish: the stock withheldAnswer when it is rejected.
+func (s *InsightReviewService) Answer(question, answer, generatedBy string) string {
+	issues := s.answer.Check(moderation.Text{Body: answer})
+	if err := s.queue(issues, repository.InsightReview{
+		Kind:        InsightKindAnswer,
+		Subject:     question,
+		Body:        answer,
+		GeneratedBy: generatedBy,
+	}); err != nil {
+		return withheldAnswer
+	}
+	return answer
+}
+
+// queue stores a text with issues for review, and returns an error naming
+// the rejecting checks if it must not be published. Failing to queue it does
+// not keep a text from being published.
+func (s *InsightReviewService) queue(issues []moderation.Issue, review repository.InsightReview) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	rejected := moderation.Rejected(issues)
+	review.Issues = issues
+	review.Published = !rejected
+	if err := s.repo.Add(review); err != nil {
+		fmt.Printf("Failed to queue %s for review: %v\n", review.Kind, err)
+	}
+
+	if !rejected {
+		return nil
+	}
+	var checks []string
+	for _, i := range issues {
+		if i.Severity == moderation.SeverityReject {
+			checks = append(checks, i.Check)
+		}
+	}
+	return fmt.Errorf("%s rejected by moderation: %s", review.Kind, strings.Join(checks, ", "))
+}
+
+// List returns up to limit queued texts with a status, pending by default,
+// newest first, optionally of one kind.
+func (s *InsightReviewService) List(status, kind string, limit int) ([]repository.InsightReview, error) {
+	if status == "" {
+		status = InsightReviewPending
+	}
+	return s.repo.List(status, kind, limit)
+}
+
+// Resolve records a reviewer's verdict on a queued text. It returns false if
+// the text does not exist.
+func (s *InsightReviewService) Resolve(id int, status string) (bool, error) {
+	if status != InsightReviewApproved && status != InsightReviewRejected {
+		return false, invalidf("status must be approved or rejected")
+	}
+	return s.repo.Resolve(id, status)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/news"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+// TeamNews is the recent news about a team, newest first.
+type TeamNews struct {
+	Team     *repository.TeamInfo     `json:"team"`
+	Since    time.Time                `json:"since"`
+	Count    int                      `json:"count"`
+	Articles []repository.NewsArticle `json:"articles"`
+}
+
+// NewsService pulls team news from the configured feeds, tags each article
+// with the teams and players it mentions through the entity resolution
+// names and aliases, and serves it by team. With an LLM configured it also
+// extracts team signals from the articles.
+type NewsService struct {
+	repo     *repository.NewsRepository
+	mappings *repository.EntityMappingRepository
+	teamRepo *repository.TeamRepository
+	client   *news.Client
+	llm      *llm.Client
+	feeds    []news.Feed
+}
+
+func NewNewsService(db *sql.DB, llmClient *llm.Client, feeds []news.Feed) *NewsService {
+	return &NewsService{
+		repo:     repository.NewNewsRepository(db),
+		mappings: repository.NewEntityMappingRepository(db),
+		teamRepo: repository.NewTeamRepository(db),
+		client:   news.NewClient(),
+		llm:      llmClient.For(llmTaskSignals),
+		feeds:    feeds,
+	}
+}
+
+// Ingest fetches every feed and stores the articles not seen before that
+// mention a team. Players are looked for in the squads of the teams an
+// article mentions, by full name, alias or surname. It returns the number
+// of articles stored; feeds that fail are skipped and reported in the
+// error.
+func (s *NewsService) Ingest(ctx context.Context) (int, error) {
+	if len(s.feeds) == 0 {
+		return 0, nil
+	}
+
+	candidates, err := s.mappings.TeamCandidates()
+	if err != nil {
+		return 0, err
+	}
+	teams := make([]news.Entity, 0, len(candidates))
+	for _, c := range candidates {
+		teams = append(teams, newsEntity(c, false))
+	}
+	squads := make(map[int][]news.Entity)
+
+	var errs []error
+	stored := 0
+	for _, feed := range s.feeds {
+		articles, err := s.client.Fetch(ctx, feed)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, a := range articles {
+			text := news.NewText(a, NormalizeEntityName)
+			teamTags := text.Mentions(teams)
+			if len(teamTags) == 0 {
+				continue
+			}
+
+			var playerTags []repository.NewsTag
+			for _, t := range teamTags {
+				squad, ok := squads[t.ID]
+				if !ok {
+					players, err := s.mappings.PlayerCandidates(t.ID)
+					if err != nil {
+						return stored, err
+					}
+					for _, p := range players {
+						squad = append(squad, newsEntity(p, true))
+					}
+					squads[t.ID] = squad
+				}
+				for _, p := range text.Mentions(squad) {
+					playerTags = append(playerTags, repository.NewsTag{ID: p.ID, Relevance: p.Relevance})
+				}
+			}
+
+			saved, err := s.repo.Save(repository.NewsArticle{
+				Source:      a.Source,
+				URL:         a.URL,
+				Title:       a.Title,
+				Summary:     a.Summary,
+				PublishedAt: a.PublishedAt,
+			}, newsTags(teamTags), playerTags)
+			if err != nil {
+				return stored, err
+			}
+			if saved {
+				stored++
+			}
+		}
+	}
+
+	return stored, errors.Join(errs...)
+}
+
+// TeamNews returns up to limit articles about a team identified by its
+// football-data.org or internal ID from the last days, at minRelevance or
+// above, or nil if the team does not exist.
+func (s *NewsService) TeamNews(id, days int, minRelevance float64, limit int) (*TeamNews, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		if team, err = s.teamRepo.GetByID(id); err != nil || team == nil {
+			return nil, err
+		}
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	articles, err := s.repo.ListForTeam(team.ID, since, minRelevance, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &TeamNews{Team: team, Since: since, Count: len(articles), Articles: articles}, nil
+}
+
+// newsEntity is a name candidate with its names normalized the way article
+// text is. Three-letter codes are left out, as they expand to city names
+// ("MAN" is "manchester"). Players also go by their surname, which is only
+// safe to look for within a squad.
+func newsEntity(c repository.NameCandidate, surname bool) news.Entity {
+	e := news.Entity{ID: c.ID, Names: append([]string(nil), c.Aliases...)}
+	for _, name := range c.Names {
+		if len(name) <= 3 {
+			continue
+		}
+		normalized := NormalizeEntityName(name)
+		e.Names = append(e.Names, normalized)
+		if i := strings.LastIndexByte(normalized, ' '); surname && i >= 0 {
+			e.Names = append(e.Names, normalized[i+1:])
+		}
+	}
+	return e
+}
+
+func newsTags(tags []news.Tag) []repository.NewsTag {
+	out := make([]repository.NewsTag, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, repository.NewsTag{ID: t.ID, Relevance: t.Relevance})
+	}
+	return out
+}
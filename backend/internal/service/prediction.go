@@ -0,0 +1,1073 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/model"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/internal/tracing"
+	"github.com/yourusername/football-prediction/pkg/breaker"
+	"github.com/yourusername/football-prediction/pkg/cache"
+	"github.com/yourusername/football-prediction/pkg/mlclient"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Prediction backends, selected with MODEL_BACKEND.
+const (
+	BackendGo       = "go"
+	BackendML       = "ml"
+	BackendEnsemble = "ensemble"
+)
+
+// fallbackModelVersion marks the fixed prediction served when no model is
+// available.
+const fallbackModelVersion = "fallback"
+
+// strongHomeAdvantage is the home less away points per game from which a
+// home team's record at home is worth an insight.
+const strongHomeAdvantage = 1.0
+
+// longTravelKm is the away travel from which the journey is worth an
+// insight, roughly a flight across Europe or between confederations.
+const longTravelKm = 1500.0
+
+// lateGoalInsightShare is the share of a team's goals scored after minute
+// 75 from which its late scoring is worth an insight.
+const lateGoalInsightShare = 0.3
+
+// absenceStrengthLoss is how much each regular starter missing from a
+// lineup weakens the Go model's attack and defence of their team, up to
+// maxCountedAbsences of them. The ML service folds absences into its
+// injury impact at the same rate and cap.
+const (
+	absenceStrengthLoss = 0.05
+	maxCountedAbsences  = 6
+)
+
+// homeAdvantageLift is how much the Go model strengthens a home team's
+// attack per point per game its home advantage exceeds its competition's,
+// whose share the fit already holds, up to maxHomeAdvantageLift either way.
+// The ML service scales the home team's attacking stats the same way.
+const (
+	homeAdvantageLift    = 0.1
+	maxHomeAdvantageLift = 0.15
+)
+
+// travelStrengthLoss is how much the Go model weakens an away team's attack
+// and defence per 1000 km of its journey, up to maxTravelStrengthLoss. The
+// ML service scales the away team's stats the same way.
+const (
+	travelStrengthLoss    = 0.02
+	maxTravelStrengthLoss = 0.06
+)
+
+// lateGoalLift is how much the Go model strengthens a team's attack per
+// unit of the share of its goals scored after minute 75 above
+// typicalLateGoalShare, the share of a league's goals, up to
+// maxLateGoalLift either way: late scorers keep going when others tire. The
+// ML service scales the team's attacking stats the same way.
+const (
+	lateGoalLift         = 0.2
+	typicalLateGoalShare = 0.22
+	maxLateGoalLift      = 0.04
+)
+
+// newManagerLift is how much the Go model strengthens the attack and
+// defence of a team in its first matches under a new manager, the short
+// lift such teams tend to get.
+const newManagerLift = 0.03
+
+// trainingWindow is how far back results are used to fit the Go model.
+const trainingWindow = 3 * 365 * 24 * time.Hour
+
+// PredictionInput identifies the match to predict. MatchID and team IDs are
+// internal, MatchID being 0 for matches not stored; external IDs and names
+// are what the ML service expects. The new-manager flags are set by the
+// service from stored lineups and the home advantages, home points per game
+// less away points per game, from stored aggregates, whatever the caller
+// passes. A home advantage not stored is 0. The league strengths are set
+// when the teams' home leagues differ and are 0 otherwise.
+type PredictionInput struct {
+	MatchID            int
+	HomeTeamID         int
+	AwayTeamID         int
+	HomeTeamExternalID int
+	AwayTeamExternalID int
+	HomeTeamName       string
+	AwayTeamName       string
+	Matchday           int
+	HomeNewManager     bool
+	AwayNewManager     bool
+	// HomeAdvantage is the home team's, CompetitionHomeAdvantage that of
+	// the match's competition.
+	HomeAdvantage            float64
+	CompetitionHomeAdvantage float64
+	HomeLeagueStrength       float64
+	AwayLeagueStrength       float64
+	// RestDays are whole days since each team's previous match, 0 when
+	// unknown; MatchesLast14Days count the matches in the 14 days before
+	// kickoff.
+	HomeRestDays          int
+	AwayRestDays          int
+	HomeMatchesLast14Days int
+	AwayMatchesLast14Days int
+	// AwayTravelKm is the away team's journey from its home venue, 0 when
+	// unknown.
+	AwayTravelKm float64
+	// LineupAbsences count the regular starters each team left out of the
+	// lineup announced for a stored match. They are set by the service and
+	// are 0 until it is announced.
+	HomeLineupAbsences int
+	AwayLineupAbsences int
+	// LateGoalShares are the shares of each team's recent goals scored
+	// after minute 75. They are set by the service and are 0 without
+	// enough recorded goals.
+	HomeLateGoalShare float64
+	AwayLateGoalShare float64
+	// Neutral is set for a fixture at neither team's ground, such as a cup
+	// final; Home and Away then only name the sides.
+	Neutral bool
+	// AsOf is set when a backtest replays a finished match, so the ML
+	// service builds its features from what was known then.
+	AsOf time.Time
+}
+
+// MatchPrediction is a model's prediction for a match. TeamStats and
+// KeyFeatures are passed through from the ML service as sent. Cached is set
+// when the prediction was served from the cache rather than made now.
+type MatchPrediction struct {
+	HomeWinProbability float64
+	DrawProbability    float64
+	AwayWinProbability float64
+	PredictedOutcome   string // "<team> Win" or "Draw"
+	PredictedWinner    string // team name or "Draw"
+	ConfidenceScore    float64
+	ModelVersion       string
+	ExpectedHomeGoals  *float64
+	ExpectedAwayGoals  *float64
+	ModelAccuracy      *float64
+	TeamStats          map[string]interface{}
+	Insights           []string
+	KeyFeatures        map[string]interface{}
+	PredictedAt        time.Time
+	Cached             bool
+}
+
+// predictionLocks stripes the per-match locks that stop concurrent requests
+// for one match from all calling the models.
+const predictionLocks = 64
+
+// cachedPrediction is a served prediction and the fingerprint of the inputs
+// it was made from.
+type cachedPrediction struct {
+	fingerprint string
+	prediction  MatchPrediction
+}
+
+// PredictionService predicts matches with the Python ML service, the native
+// Dixon-Coles model in internal/model, or an average of the two. Shadow
+// backends predict the same fixtures without being served, so models can be
+// compared on identical matches.
+type PredictionService struct {
+	repo       *repository.PredictionRepository
+	coachRepo  *repository.CoachRepository
+	homeRepo   *repository.HomeAdvantageRepository
+	matchRepo  *repository.MatchRepository
+	venueRepo  *repository.VenueRepository
+	playerRepo *repository.PlayerRepository
+	features   *FeatureService
+	backend    string
+	shadows    []string
+	ml         *mlclient.Client
+	refitEvery time.Duration
+
+	cache    *cache.Cache
+	cacheTTL time.Duration
+	locks    [predictionLocks]sync.Mutex
+
+	mu      sync.Mutex
+	model   *model.Model
+	leagues *model.Leagues
+}
+
+// NewPredictionService returns a service using backend, one of go, ml or
+// ensemble (ml when empty or unknown), with shadows as additional backends
+// whose predictions are only recorded. Unknown shadows and the served
+// backend itself are ignored. The ML service is called through ml, or a
+// default client when nil. The Go model is refitted from stored results once
+// it is older than refitEvery, and served predictions are reused for up to
+// cacheTTL while their inputs are unchanged.
+func NewPredictionService(db *sql.DB, backend string, shadows []string, ml *mlclient.Client, refitEvery, cacheTTL time.Duration) *PredictionService {
+	if !isBackend(backend) {
+		backend = BackendML
+	}
+	var shadowBackends []string
+	for _, shadow := range shadows {
+		shadow = strings.TrimSpace(shadow)
+		if isBackend(shadow) && shadow != backend && !containsString(shadowBackends, shadow) {
+			shadowBackends = append(shadowBackends, shadow)
+		}
+	}
+	if ml == nil {
+		ml = mlclient.New(mlclient.Config{Transport: tracing.Transport(nil)})
+	}
+	return &PredictionService{
+		repo:       repository.NewPredictionRepository(db),
+		coachRepo:  repository.NewCoachRepository(db),
+		homeRepo:   repository.NewHomeAdvantageRepository(db),
+		matchRepo:  repository.NewMatchRepository(db),
+		venueRepo:  repository.NewVenueRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+		features:   NewFeatureService(db),
+		backend:    backend,
+		shadows:    shadowBackends,
+		ml:         ml,
+		refitEvery: refitEvery,
+		cache:      cache.New(),
+		cacheTTL:   cacheTTL,
+	}
+}
+
+func isBackend(name string) bool {
+	switch name {
+	case BackendGo, BackendML, BackendEnsemble:
+		return true
+	default:
+		return false
+	}
+}
+
+// Backend returns the configured prediction backend.
+func (s *PredictionService) Backend() string {
+	return s.backend
+}
+
+// Shadows returns the backends that predict alongside the served one.
+func (s *PredictionService) Shadows() []string {
+	return s.shadows
+}
+
+// MLStats returns the ML client's queue and request counters.
+func (s *PredictionService) MLStats() mlclient.Stats {
+	return s.ml.Stats()
+}
+
+// Predict returns the configured backend's prediction. When the ML service
+// is unavailable the Go model answers instead, and when neither can predict
+// a fixed fallback is returned, so a prediction is always served.
+//
+// Predictions are cached per match together with a fingerprint of their
+// inputs, and the cached one is returned until the inputs change, for
+// example when lineups are announced or either team plays, or refresh is
+// set. Fallback answers are not cached so the next request tries again.
+// When the ML service sheds the request because its queue is full or its
+// breaker is open, the last
+// cached prediction is served even if its inputs have changed, and the Go
+// model or the fixed fallback answers only when there is none.
+func (s *PredictionService) Predict(in PredictionInput, refresh bool) *MatchPrediction {
+	return s.PredictFrom(context.Background(), in, nil, refresh)
+}
+
+// PredictFrom is Predict with the input state already known, such as the
+// state stored in the match_prediction_inputs read model, which saves a
+// query when the cached prediction can be served. A nil state is read from
+// the database. Spans of the lookup and the model calls are children of the
+// one in ctx.
+func (s *PredictionService) PredictFrom(ctx context.Context, in PredictionInput, state *repository.PredictionInputState, refresh bool) *MatchPrediction {
+	key := predictionCacheKey(in)
+	lock := &s.locks[lockIndex(key)]
+	lock.Lock()
+	defer lock.Unlock()
+
+	s.setNewManagers(&in)
+	s.setHomeAdvantage(&in)
+	s.setLeagueStrengths(&in)
+	s.setScheduleLoad(&in)
+	s.setTravelDistance(&in)
+	s.setLineupAbsences(&in)
+	s.setLateGoals(&in)
+	fingerprint, err := s.fingerprint(ctx, in, state)
+	if err != nil {
+		fingerprint = ""
+	}
+	if !refresh && fingerprint != "" {
+		cached, ok := s.cache.Get(key)
+		hit := ok && cached.(cachedPrediction).fingerprint == fingerprint
+		tracing.CacheLookup(ctx, "prediction", hit)
+		if hit {
+			p := cached.(cachedPrediction).prediction
+			p.Cached = true
+			return &p
+		}
+	}
+
+	p, complete, shed := s.predict(ctx, in)
+	if shed {
+		if cached, ok := s.cache.Get(key); ok {
+			p := cached.(cachedPrediction).prediction
+			p.Cached = true
+			return &p
+		}
+	}
+	p.PredictedAt = time.Now()
+	if complete && fingerprint != "" {
+		s.cache.Set(key, cachedPrediction{fingerprint: fingerprint, prediction: *p}, s.cacheTTL)
+	}
+	return p
+}
+
+// predict runs the configured backend with its fallbacks. complete is false
+// when part of the backend was unavailable and a fallback answered, and shed
+// is set when that was because the ML service's queue was full or its
+// breaker open.
+func (s *PredictionService) predict(ctx context.Context, in PredictionInput) (p *MatchPrediction, complete, shed bool) {
+	var ml, native *MatchPrediction
+	if s.backend != BackendGo {
+		var err error
+		ml, err = s.predictML(ctx, in)
+		shed = errors.Is(err, mlclient.ErrOverloaded) || errors.Is(err, breaker.ErrOpen)
+	}
+	if s.backend != BackendML || ml == nil {
+		native, _ = s.predictGo(ctx, in)
+	}
+
+	switch {
+	case ml != nil && native != nil:
+		return ensemble(in, ml, native), true, false
+	case ml != nil:
+		return ml, s.backend == BackendML, false
+	case native != nil:
+		return native, s.backend == BackendGo, shed
+	default:
+		return &MatchPrediction{
+			HomeWinProbability: 0.45,
+			DrawProbability:    0.30,
+			AwayWinProbability: 0.25,
+			PredictedOutcome:   "HOME_WIN",
+			ConfidenceScore:    0.65,
+			ModelVersion:       fallbackModelVersion,
+		}, false, shed
+	}
+}
+
+// setNewManagers flags the teams whose coach replaced another within their
+// last few matches. A team whose coaches cannot be read is left unflagged
+// rather than holding up the prediction.
+func (s *PredictionService) setNewManagers(in *PredictionInput) {
+	now := time.Now()
+	if tenure, err := s.coachRepo.Tenure(in.HomeTeamID, now); err == nil {
+		in.HomeNewManager = tenure.NewManager()
+	}
+	if tenure, err := s.coachRepo.Tenure(in.AwayTeamID, now); err == nil {
+		in.AwayNewManager = tenure.NewManager()
+	}
+}
+
+// setHomeAdvantage sets the stored home advantage of a stored match's home
+// team and competition. It is left at 0 when none is stored or it cannot be
+// read.
+func (s *PredictionService) setHomeAdvantage(in *PredictionInput) {
+	if in.MatchID == 0 {
+		return
+	}
+	if advantage, err := s.homeRepo.ForMatch(in.MatchID); err == nil && advantage != nil {
+		in.CompetitionHomeAdvantage = advantage.Competition
+		if advantage.Team != nil {
+			in.HomeAdvantage = *advantage.Team
+		}
+	}
+}
+
+// setLeagueStrengths sets the strengths of the teams' home leagues, as
+// estimated when the Go model was last fitted, when they differ. They are
+// left at 0 when the model cannot be fitted.
+func (s *PredictionService) setLeagueStrengths(in *PredictionInput) {
+	if _, err := s.fittedModel(); err != nil {
+		return
+	}
+	s.mu.Lock()
+	leagues := s.leagues
+	s.mu.Unlock()
+	if home, away, ok := leagues.Between(in.HomeTeamID, in.AwayTeamID); ok {
+		in.HomeLeagueStrength, in.AwayLeagueStrength = home, away
+	}
+}
+
+// setScheduleLoad sets both teams' rest and fixture congestion going into a
+// stored match, from the stored schedules of all their competitions. They
+// are left at 0 when they cannot be read.
+func (s *PredictionService) setScheduleLoad(in *PredictionInput) {
+	if in.MatchID == 0 {
+		return
+	}
+	load, err := s.matchRepo.GetScheduleLoad(in.MatchID)
+	if err != nil || load == nil {
+		return
+	}
+	if days := restDays(load.UtcDate, load.HomePreviousMatchAt); days != nil {
+		in.HomeRestDays = *days
+	}
+	if days := restDays(load.UtcDate, load.AwayPreviousMatchAt); days != nil {
+		in.AwayRestDays = *days
+	}
+	in.HomeMatchesLast14Days = load.HomeMatchesLast14Days
+	in.AwayMatchesLast14Days = load.AwayMatchesLast14Days
+}
+
+// setTravelDistance sets the away team's stored travel to a stored match.
+// It is left at 0 when unknown or it cannot be read.
+func (s *PredictionService) setTravelDistance(in *PredictionInput) {
+	if in.MatchID == 0 {
+		return
+	}
+	if km, err := s.venueRepo.TravelDistance(in.MatchID); err == nil && km != nil {
+		in.AwayTravelKm = *km
+	}
+}
+
+// setLineupAbsences counts the regulars left out of the lineups announced
+// for a stored match. They are left at 0 when none is announced or they
+// cannot be read.
+func (s *PredictionService) setLineupAbsences(in *PredictionInput) {
+	if in.MatchID == 0 {
+		return
+	}
+	absences, err := s.playerRepo.ListLineupAbsences(in.MatchID, time.Now().UTC(), regularLineups, regularStarts)
+	if err != nil {
+		return
+	}
+	in.HomeLineupAbsences, in.AwayLineupAbsences = countLineupAbsences(absences, in.HomeTeamID, in.AwayTeamID)
+}
+
+// setLateGoals sets the shares of both teams' recent goals scored after
+// minute 75. A team's is left at 0 without enough recorded goals or when
+// they cannot be read.
+func (s *PredictionService) setLateGoals(in *PredictionInput) {
+	now := time.Now().UTC()
+	for _, team := range []struct {
+		id    int
+		share *float64
+	}{{in.HomeTeamID, &in.HomeLateGoalShare}, {in.AwayTeamID, &in.AwayLateGoalShare}} {
+		if team.id == 0 {
+			continue
+		}
+		late, err := s.matchRepo.GetTeamLateGoals(team.id, now, lateGoalMatches)
+		if err != nil {
+			continue
+		}
+		if share := lateGoalShare(late); share != nil {
+			*team.share = *share
+		}
+	}
+}
+
+func predictionCacheKey(in PredictionInput) string {
+	if in.MatchID != 0 {
+		return fmt.Sprintf("prediction:match:%d", in.MatchID)
+	}
+	if in.Neutral {
+		return fmt.Sprintf("prediction:neutral:%d:%d", in.HomeTeamID, in.AwayTeamID)
+	}
+	return fmt.Sprintf("prediction:teams:%d:%d:%d", in.HomeTeamID, in.AwayTeamID, in.Matchday)
+}
+
+func lockIndex(key string) int {
+	sum := sha256.Sum256([]byte(key))
+	return int(sum[0]) % predictionLocks
+}
+
+// fingerprint hashes everything a prediction of the match depends on: the
+// input itself, the backend, the lineups announced for the match and both
+// teams' stored results. A nil state is read from the database.
+func (s *PredictionService) fingerprint(ctx context.Context, in PredictionInput, state *repository.PredictionInputState) (string, error) {
+	if state == nil {
+		var err error
+		if state, err = s.repo.InputState(ctx, in.MatchID, in.HomeTeamID, in.AwayTeamID); err != nil {
+			return "", err
+		}
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%+v|%d|%d", s.backend, in, state.LineupPlayers, state.TeamResults)
+	for _, t := range []*time.Time{state.LineupsUpdatedAt, state.ResultsUpdatedAt} {
+		if t != nil {
+			fmt.Fprintf(h, "|%d", t.UnixNano())
+		} else {
+			fmt.Fprint(h, "|-")
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// MatchInputs returns the match_prediction_inputs row of a match by
+// external or internal ID. It returns nil when the match is not in the read
+// model, or has kicked off since it was refreshed, so callers read the
+// match's current state from the base tables.
+func (s *PredictionService) MatchInputs(ctx context.Context, matchID int) (*repository.MatchPredictionInputs, error) {
+	in, err := s.repo.MatchInputs(ctx, matchID)
+	if err != nil || in == nil {
+		return nil, err
+	}
+	if !in.UtcDate.After(time.Now()) {
+		return nil, nil
+	}
+	return in, nil
+}
+
+// RefreshMatchInputs recomputes the match_prediction_inputs read model.
+func (s *PredictionService) RefreshMatchInputs() error {
+	return s.repo.RefreshMatchInputs()
+}
+
+// InvalidateMatch drops the cached prediction of an internal match ID, so
+// the next request predicts it again, e.g. once it has moved.
+func (s *PredictionService) InvalidateMatch(matchID int) {
+	s.cache.Delete(predictionCacheKey(PredictionInput{MatchID: matchID}))
+}
+
+// Record stores the served prediction for an internal match ID so it is
+// settled and counted in the accuracy stats under its model version. The
+// teams' features are snapshotted at prediction time and kept with it. The
+// fixed fallback is not recorded.
+func (s *PredictionService) Record(matchID int, in PredictionInput, p *MatchPrediction) error {
+	if p.ModelVersion == fallbackModelVersion {
+		return nil
+	}
+
+	asOf := p.PredictedAt.UTC()
+	if p.PredictedAt.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	snapshot, err := s.features.Snapshot(matchID, in.HomeTeamID, in.AwayTeamID, asOf, FeatureSourceLive)
+	if err != nil {
+		// The prediction is still worth keeping without its features
+		return errors.Join(err, s.save(matchID, in, p, true, nil))
+	}
+	return s.save(matchID, in, p, true, snapshot)
+}
+
+// RecordShadows has every shadow backend predict the match and stores the
+// results next to the served prediction, whose model version is served.
+// Backends that cannot predict right now are skipped and reported together.
+func (s *PredictionService) RecordShadows(ctx context.Context, matchID int, in PredictionInput, served string) error {
+	s.setNewManagers(&in)
+	s.setHomeAdvantage(&in)
+	s.setLeagueStrengths(&in)
+	s.setScheduleLoad(&in)
+	s.setTravelDistance(&in)
+	s.setLineupAbsences(&in)
+	s.setLateGoals(&in)
+	var errs []error
+	for _, backend := range s.shadows {
+		p, err := s.predictWith(ctx, backend, in)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", backend, err))
+			continue
+		}
+		if p.ModelVersion == served {
+			continue
+		}
+		if err := s.save(matchID, in, p, false, nil); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", backend, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Replay predicts a finished match with a single backend as it would have
+// been predicted at snapshot.AsOf, for backtests. m is the Go model fitted on
+// the results before then. The input is completed as live predictions are,
+// but from the snapshot and the match's stored schedule and travel rather
+// than current data, and the ML service builds its features as of then. The
+// caller sets the competition's home advantage at the time. Nothing is
+// cached or recorded.
+func (s *PredictionService) Replay(ctx context.Context, backend string, in PredictionInput, snapshot *FeatureSnapshot, m *model.Model) (*MatchPrediction, error) {
+	in.AsOf = snapshot.AsOf
+	setSnapshotInputs(&in, snapshot)
+	s.setScheduleLoad(&in)
+	s.setTravelDistance(&in)
+
+	switch backend {
+	case BackendGo:
+		return predictModel(m, in), nil
+	case BackendML:
+		return s.predictML(ctx, in)
+	case BackendEnsemble:
+		ml, err := s.predictML(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		return ensemble(in, ml, predictModel(m, in)), nil
+	default:
+		return nil, fmt.Errorf("unknown prediction backend %q", backend)
+	}
+}
+
+// setSnapshotInputs sets the inputs a feature snapshot holds: new managers,
+// the home team's home advantage, league strengths, lineup absences and
+// late-goal shares. Those it has no value for are left as they are.
+func setSnapshotInputs(in *PredictionInput, snapshot *FeatureSnapshot) {
+	home, away := snapshot.Home, snapshot.Away
+	in.HomeNewManager, in.AwayNewManager = home.NewManager, away.NewManager
+	in.HomeLineupAbsences, in.AwayLineupAbsences = home.LineupAbsences, away.LineupAbsences
+	if home.HomeAdvantage != nil {
+		in.HomeAdvantage = *home.HomeAdvantage
+	}
+	if home.LeagueStrength != nil && away.LeagueStrength != nil {
+		in.HomeLeagueStrength, in.AwayLeagueStrength = *home.LeagueStrength, *away.LeagueStrength
+	}
+	if home.LateGoalShare != nil {
+		in.HomeLateGoalShare = *home.LateGoalShare
+	}
+	if away.LateGoalShare != nil {
+		in.AwayLateGoalShare = *away.LateGoalShare
+	}
+}
+
+// predictWith predicts with a single backend, without falling back to
+// another one.
+func (s *PredictionService) predictWith(ctx context.Context, backend string, in PredictionInput) (*MatchPrediction, error) {
+	switch backend {
+	case BackendGo:
+		return s.predictGo(ctx, in)
+	case BackendML:
+		return s.predictML(ctx, in)
+	case BackendEnsemble:
+		ml, err := s.predictML(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		native, err := s.predictGo(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		return ensemble(in, ml, native), nil
+	default:
+		return nil, fmt.Errorf("unknown prediction backend %q", backend)
+	}
+}
+
+// expectedGoals predicts a match and returns the goals each side is
+// expected to score, asking the Go model when the served prediction has
+// none, as the ML service's do not.
+func (s *PredictionService) expectedGoals(ctx context.Context, in PredictionInput) (home, away float64, modelVersion string, ok bool) {
+	p := s.PredictFrom(ctx, in, nil, false)
+	if p.ExpectedHomeGoals == nil || p.ExpectedAwayGoals == nil {
+		native, err := s.predictWith(ctx, BackendGo, in)
+		if err != nil || native.ExpectedHomeGoals == nil || native.ExpectedAwayGoals == nil {
+			return 0, 0, "", false
+		}
+		p = native
+	}
+	return *p.ExpectedHomeGoals, *p.ExpectedAwayGoals, p.ModelVersion, true
+}
+
+// save stores a prediction with the model's key features and, when given,
+// the point-in-time feature snapshot under "snapshot".
+func (s *PredictionService) save(matchID int, in PredictionInput, p *MatchPrediction, primary bool, snapshot *FeatureSnapshot) error {
+	if p.ModelVersion == fallbackModelVersion {
+		return nil
+	}
+
+	used := make(map[string]interface{}, len(p.KeyFeatures)+1)
+	for k, v := range p.KeyFeatures {
+		used[k] = v
+	}
+	if snapshot != nil {
+		used["snapshot"] = snapshot
+	}
+	var features json.RawMessage
+	if len(used) > 0 {
+		var err error
+		if features, err = json.Marshal(used); err != nil {
+			return fmt.Errorf("failed to encode features: %w", err)
+		}
+	}
+
+	return s.repo.Save(repository.PredictionEntry{
+		MatchID:            matchID,
+		HomeTeamName:       in.HomeTeamName,
+		AwayTeamName:       in.AwayTeamName,
+		HomeGoals:          p.ExpectedHomeGoals,
+		AwayGoals:          p.ExpectedAwayGoals,
+		PredictedOutcome:   p.PredictedOutcome,
+		PredictedWinner:    p.PredictedWinner,
+		ConfidenceScore:    p.ConfidenceScore,
+		Insights:           p.Insights,
+		ModelVersion:       p.ModelVersion,
+		Features:           features,
+		Primary:            primary,
+		HomeWinProbability: &p.HomeWinProbability,
+		DrawProbability:    &p.DrawProbability,
+		AwayWinProbability: &p.AwayWinProbability,
+	})
+}
+
+// ModelComparison is every model's prediction of one match, with how each
+// pair of those models has fared on the settled matches they both predicted.
+// Features are the teams' snapshots taken when predictions were recorded,
+// latest first.
+type ModelComparison struct {
+	MatchID     int                          `json:"matchId"`
+	Predictions []repository.ModelPrediction `json:"predictions"`
+	HeadToHead  []repository.ModelHeadToHead `json:"headToHead"`
+	Features    []repository.TeamFeatures    `json:"features"`
+}
+
+// Compare returns the stored predictions of every model for an internal
+// match ID.
+func (s *PredictionService) Compare(matchID int) (*ModelComparison, error) {
+	predictions, err := s.repo.ListForMatch(matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make(map[string]bool, len(predictions))
+	for _, p := range predictions {
+		models[p.ModelVersion] = true
+	}
+
+	pairs, err := s.repo.HeadToHead("")
+	if err != nil {
+		return nil, err
+	}
+	headToHead := []repository.ModelHeadToHead{}
+	for _, pair := range pairs {
+		if models[pair.ModelA] && models[pair.ModelB] {
+			headToHead = append(headToHead, pair)
+		}
+	}
+
+	features, err := s.features.ListForMatch(matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModelComparison{
+		MatchID:     matchID,
+		Predictions: predictions,
+		HeadToHead:  headToHead,
+		Features:    features,
+	}, nil
+}
+
+// Versions returns every prediction stored for an internal match ID, by
+// every model and each time it predicted the match, oldest first.
+func (s *PredictionService) Versions(matchID int) ([]repository.ModelPrediction, error) {
+	return s.repo.ListVersions(matchID)
+}
+
+// HeadToHead compares model versions pairwise on the settled matches both
+// predicted. A non-empty modelVersion keeps only the pairs that include it.
+func (s *PredictionService) HeadToHead(modelVersion string) ([]repository.ModelHeadToHead, error) {
+	return s.repo.HeadToHead(modelVersion)
+}
+
+// predictML asks the Python ML service for a prediction, waiting for a free
+// worker of the ML client. The request carries the trace context of ctx, so
+// the service's spans join the trace.
+func (s *PredictionService) predictML(ctx context.Context, in PredictionInput) (p *MatchPrediction, err error) {
+	ctx, span := tracing.Start(ctx, "model.ml.predict", attribute.Int("match.id", in.MatchID))
+	defer func() { tracing.End(span, err) }()
+
+	request := map[string]interface{}{
+		"home_team_id":               in.HomeTeamExternalID,
+		"away_team_id":               in.AwayTeamExternalID,
+		"matchday":                   in.Matchday,
+		"home_team_name":             in.HomeTeamName,
+		"away_team_name":             in.AwayTeamName,
+		"home_new_manager":           in.HomeNewManager,
+		"away_new_manager":           in.AwayNewManager,
+		"home_advantage":             in.HomeAdvantage,
+		"competition_home_advantage": in.CompetitionHomeAdvantage,
+		"home_league_strength":       in.HomeLeagueStrength,
+		"away_league_strength":       in.AwayLeagueStrength,
+		"home_rest_days":             in.HomeRestDays,
+		"away_rest_days":             in.AwayRestDays,
+		"home_matches_last_14_days":  in.HomeMatchesLast14Days,
+		"away_matches_last_14_days":  in.AwayMatchesLast14Days,
+		"away_travel_km":             in.AwayTravelKm,
+		"home_lineup_absences":       in.HomeLineupAbsences,
+		"away_lineup_absences":       in.AwayLineupAbsences,
+		"home_late_goal_share":       in.HomeLateGoalShare,
+		"away_late_goal_share":       in.AwayLateGoalShare,
+		"neutral_venue":              in.Neutral,
+	}
+	if !in.AsOf.IsZero() {
+		request["as_of"] = in.AsOf.UTC().Format("2006-01-02")
+	}
+	body, err := s.ml.Predict(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MatchPrediction{
+		HomeWinProbability: body.HomeWinProbability,
+		DrawProbability:    body.DrawProbability,
+		AwayWinProbability: body.AwayWinProbability,
+		PredictedOutcome:   body.PredictedOutcome,
+		PredictedWinner:    predictedWinner(body.PredictedOutcome, in.HomeTeamName, in.AwayTeamName),
+		ConfidenceScore:    body.ConfidenceScore,
+		ModelVersion:       body.ModelVersion,
+		ModelAccuracy:      body.ModelAccuracy,
+		TeamStats:          body.TeamStats,
+		Insights:           body.Insights,
+		KeyFeatures:        body.KeyFeatures,
+	}, nil
+}
+
+// predictedWinner extracts the winning team, or "Draw", from an ML outcome
+// such as "Arsenal Win".
+func predictedWinner(outcome, homeTeamName, awayTeamName string) string {
+	switch {
+	case outcome == "Draw":
+		return "Draw"
+	case strings.Contains(outcome, homeTeamName):
+		return homeTeamName
+	case strings.Contains(outcome, awayTeamName):
+		return awayTeamName
+	default:
+		return strings.TrimSuffix(outcome, " Win")
+	}
+}
+
+// predictGo predicts with the native Dixon-Coles model.
+func (s *PredictionService) predictGo(ctx context.Context, in PredictionInput) (_ *MatchPrediction, err error) {
+	_, span := tracing.Start(ctx, "model.go.predict", attribute.Int("match.id", in.MatchID))
+	defer func() { tracing.End(span, err) }()
+
+	m, err := s.fittedModel()
+	if err != nil {
+		return nil, err
+	}
+	return predictModel(m, in), nil
+}
+
+// predictModel predicts with a fitted Dixon-Coles model.
+func predictModel(m *model.Model, in PredictionInput) *MatchPrediction {
+	home, away := matchAdjustments(in)
+	var p model.Prediction
+	if in.Neutral {
+		p = m.PredictNeutralAdjusted(in.HomeTeamID, in.AwayTeamID, home, away)
+	} else {
+		p = m.PredictAdjusted(in.HomeTeamID, in.AwayTeamID, home, away)
+	}
+	prediction := fromProbabilities(in, p.HomeWin, p.Draw, p.AwayWin)
+	prediction.ModelVersion = model.Version
+	prediction.ExpectedHomeGoals = roundedPtr(p.ExpectedHomeGoals)
+	prediction.ExpectedAwayGoals = roundedPtr(p.ExpectedAwayGoals)
+	homeAttack, homeDefence := m.Strength(in.HomeTeamID)
+	awayAttack, awayDefence := m.Strength(in.AwayTeamID)
+	prediction.KeyFeatures = map[string]interface{}{
+		"home_attack":                round2(homeAttack),
+		"home_defence":               round2(homeDefence),
+		"away_attack":                round2(awayAttack),
+		"away_defence":               round2(awayDefence),
+		"home_advantage":             round2(m.HomeAdvantage),
+		"rho":                        round2(m.Rho),
+		"fitted_matches":             m.Matches,
+		"home_new_manager":           in.HomeNewManager,
+		"away_new_manager":           in.AwayNewManager,
+		"home_team_home_advantage":   in.HomeAdvantage,
+		"competition_home_advantage": in.CompetitionHomeAdvantage,
+		"home_league_strength":       in.HomeLeagueStrength,
+		"away_league_strength":       in.AwayLeagueStrength,
+		"home_rest_days":             in.HomeRestDays,
+		"away_rest_days":             in.AwayRestDays,
+		"home_matches_last_14_days":  in.HomeMatchesLast14Days,
+		"away_matches_last_14_days":  in.AwayMatchesLast14Days,
+		"away_travel_km":             in.AwayTravelKm,
+		"home_lineup_absences":       in.HomeLineupAbsences,
+		"away_lineup_absences":       in.AwayLineupAbsences,
+		"home_late_goal_share":       in.HomeLateGoalShare,
+		"away_late_goal_share":       in.AwayLateGoalShare,
+		"neutral_venue":              in.Neutral,
+	}
+
+	prediction.Insights = append(prediction.Insights,
+		fmt.Sprintf("Expected goals: %s %.1f - %.1f %s", in.HomeTeamName, p.ExpectedHomeGoals, p.ExpectedAwayGoals, in.AwayTeamName),
+		fmt.Sprintf("Most likely score %d-%d (%.0f%%)", p.LikelyHomeGoals, p.LikelyAwayGoals, p.LikelyScoreProb*100),
+	)
+	for _, team := range []struct {
+		id         int
+		name       string
+		newManager bool
+		restDays   int
+		congestion int
+		absences   int
+		lateGoals  float64
+	}{
+		{in.HomeTeamID, in.HomeTeamName, in.HomeNewManager, in.HomeRestDays, in.HomeMatchesLast14Days, in.HomeLineupAbsences, in.HomeLateGoalShare},
+		{in.AwayTeamID, in.AwayTeamName, in.AwayNewManager, in.AwayRestDays, in.AwayMatchesLast14Days, in.AwayLineupAbsences, in.AwayLateGoalShare},
+	} {
+		if !m.Knows(team.id) {
+			prediction.Insights = append(prediction.Insights,
+				fmt.Sprintf("No recent results stored for %s; assuming average strength", team.name))
+		}
+		if team.newManager {
+			prediction.Insights = append(prediction.Insights,
+				fmt.Sprintf("%s are in their first matches under a new manager", team.name))
+		}
+		if team.congestion >= congestedMatches {
+			prediction.Insights = append(prediction.Insights,
+				fmt.Sprintf("%s go in with %d matches in 14 days and %d days' rest", team.name, team.congestion, team.restDays))
+		}
+		if team.absences > 0 {
+			prediction.Insights = append(prediction.Insights,
+				fmt.Sprintf("%s are without %d of their regular starters", team.name, team.absences))
+		}
+		if team.lateGoals >= lateGoalInsightShare {
+			prediction.Insights = append(prediction.Insights,
+				fmt.Sprintf("%s have scored %.0f%% of their recent goals after minute 75", team.name, team.lateGoals*100))
+		}
+	}
+	if in.HomeLeagueStrength > 0 {
+		prediction.Insights = append(prediction.Insights,
+			fmt.Sprintf("League strength: %s %.2f, %s %.2f", in.HomeTeamName, in.HomeLeagueStrength, in.AwayTeamName, in.AwayLeagueStrength))
+	}
+	if in.HomeAdvantage >= strongHomeAdvantage {
+		prediction.Insights = append(prediction.Insights,
+			fmt.Sprintf("%s take %.1f more points per game at home than away", in.HomeTeamName, in.HomeAdvantage))
+	}
+	if in.AwayTravelKm >= longTravelKm {
+		prediction.Insights = append(prediction.Insights,
+			fmt.Sprintf("%s travel %.0f km for this match", in.AwayTeamName, in.AwayTravelKm))
+	}
+	return prediction
+}
+
+// matchAdjustments scales both teams' fitted strengths for what their
+// results cannot show about this match.
+func matchAdjustments(in PredictionInput) (home, away model.Adjustment) {
+	home = strengthAdjustment(in.HomeLineupAbsences, in.HomeNewManager)
+	away = strengthAdjustment(in.AwayLineupAbsences, in.AwayNewManager)
+	home.Attack *= lateGoalFactor(in.HomeLateGoalShare)
+	away.Attack *= lateGoalFactor(in.AwayLateGoalShare)
+	if !in.Neutral {
+		home.Attack *= homeAdvantageFactor(in.HomeAdvantage, in.CompetitionHomeAdvantage)
+		loss := travelLoss(in.AwayTravelKm)
+		away.Attack *= 1 - loss
+		away.Defence *= 1 + loss
+	}
+	return home, away
+}
+
+// homeAdvantageFactor scales a home team's attack for how much more its
+// home advantage is than its competition's. It is 1 when the team's is not
+// stored.
+func homeAdvantageFactor(team, competition float64) float64 {
+	if team == 0 {
+		return 1
+	}
+	lift := homeAdvantageLift * (team - competition)
+	return 1 + max(-maxHomeAdvantageLift, min(lift, maxHomeAdvantageLift))
+}
+
+// lateGoalFactor scales a team's attack for the share of its goals scored
+// late. It is 1 when the share is not known.
+func lateGoalFactor(share float64) float64 {
+	if share == 0 {
+		return 1
+	}
+	lift := lateGoalLift * (share - typicalLateGoalShare)
+	return 1 + max(-maxLateGoalLift, min(lift, maxLateGoalLift))
+}
+
+// travelLoss is how much an away team's journey weakens it.
+func travelLoss(km float64) float64 {
+	return min(travelStrengthLoss*km/1000, maxTravelStrengthLoss)
+}
+
+// strengthAdjustment scales a team's fitted strengths for the regular
+// starters missing from its lineup, which mean fewer goals scored and more
+// conceded, and for a new manager, which means the opposite.
+func strengthAdjustment(absences int, newManager bool) model.Adjustment {
+	loss := absenceStrengthLoss * float64(min(absences, maxCountedAbsences))
+	if newManager {
+		loss -= newManagerLift
+	}
+	return model.Adjustment{Attack: 1 - loss, Defence: 1 + loss}
+}
+
+// fittedModel returns the Go model, refitting it from stored results when it
+// is missing or stale. A stale model is kept if refitting fails.
+func (s *PredictionService) fittedModel() (*model.Model, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.model != nil && time.Since(s.model.FittedAt) < s.refitEvery {
+		return s.model, nil
+	}
+
+	now := time.Now()
+	rows, err := s.repo.ListTrainingResults(now.Add(-trainingWindow))
+	if err == nil {
+		results := modelResults(rows)
+		var fitted *model.Model
+		if fitted, err = model.Fit(results, model.Options{Now: now}); err == nil {
+			s.model = fitted
+			s.leagues = model.FitLeagues(results)
+		}
+	}
+
+	if s.model == nil {
+		return nil, fmt.Errorf("failed to fit prediction model: %w", err)
+	}
+	return s.model, nil
+}
+
+// ensemble averages the ML and Go probabilities. Expected goals come from
+// the Go model and the ML service's stats and insights are kept.
+func ensemble(in PredictionInput, ml, native *MatchPrediction) *MatchPrediction {
+	p := fromProbabilities(in,
+		(ml.HomeWinProbability+native.HomeWinProbability)/2,
+		(ml.DrawProbability+native.DrawProbability)/2,
+		(ml.AwayWinProbability+native.AwayWinProbability)/2,
+	)
+	p.ModelVersion = "ensemble:" + ml.ModelVersion + "+" + native.ModelVersion
+	p.ExpectedHomeGoals = native.ExpectedHomeGoals
+	p.ExpectedAwayGoals = native.ExpectedAwayGoals
+	p.TeamStats = ml.TeamStats
+	p.Insights = append(append([]string{}, ml.Insights...), native.Insights...)
+	p.KeyFeatures = map[string]interface{}{"ml": ml.KeyFeatures, "go": native.KeyFeatures}
+	return p
+}
+
+// fromProbabilities builds a prediction whose outcome is the most likely
+// result and whose confidence is that result's probability.
+func fromProbabilities(in PredictionInput, home, draw, away float64) *MatchPrediction {
+	p := &MatchPrediction{
+		HomeWinProbability: round2(home),
+		DrawProbability:    round2(draw),
+		AwayWinProbability: round2(away),
+	}
+	switch {
+	case home >= draw && home >= away:
+		p.PredictedOutcome, p.PredictedWinner, p.ConfidenceScore = in.HomeTeamName+" Win", in.HomeTeamName, home
+	case away >= draw:
+		p.PredictedOutcome, p.PredictedWinner, p.ConfidenceScore = in.AwayTeamName+" Win", in.AwayTeamName, away
+	default:
+		p.PredictedOutcome, p.PredictedWinner, p.ConfidenceScore = "Draw", "Draw", draw
+	}
+	p.ConfidenceScore = round2(p.ConfidenceScore)
+	return p
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func roundedPtr(v float64) *float64 {
+	r := math.Round(v*10) / 10
+	return &r
+}
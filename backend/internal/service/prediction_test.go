@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/yourusername/football-prediction/internal/model"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// evenModel is a fitted model of two average teams, 1 at home to 2.
+var evenModel = &model.Model{
+	Attack:        map[int]float64{1: 1, 2: 1},
+	Defence:       map[int]float64{1: 1, 2: 1},
+	Base:          1.3,
+	HomeAdvantage: 1.2,
+}
+
+func predictEven(in PredictionInput) model.Prediction {
+	in.HomeTeamID, in.AwayTeamID = 1, 2
+	home, away := matchAdjustments(in)
+	if in.Neutral {
+		return evenModel.PredictNeutralAdjusted(1, 2, home, away)
+	}
+	return evenModel.PredictAdjusted(1, 2, home, away)
+}
+
+func TestHomeAdvantageMovesPrediction(t *testing.T) {
+	base := predictEven(PredictionInput{CompetitionHomeAdvantage: 0.4})
+
+	fortress := predictEven(PredictionInput{HomeAdvantage: 1.4, CompetitionHomeAdvantage: 0.4})
+	if fortress.ExpectedHomeGoals <= base.ExpectedHomeGoals || fortress.HomeWin <= base.HomeWin {
+		t.Errorf("home advantage above the competition's: %+v, want more home goals and wins than %+v", fortress, base)
+	}
+	if fortress.ExpectedAwayGoals != base.ExpectedAwayGoals {
+		t.Errorf("away goals = %v, want %v unchanged", fortress.ExpectedAwayGoals, base.ExpectedAwayGoals)
+	}
+
+	weak := predictEven(PredictionInput{HomeAdvantage: -0.2, CompetitionHomeAdvantage: 0.4})
+	if weak.HomeWin >= base.HomeWin {
+		t.Errorf("home advantage below the competition's: home win %v, want below %v", weak.HomeWin, base.HomeWin)
+	}
+
+	neutral := PredictionInput{Neutral: true, CompetitionHomeAdvantage: 0.4}
+	withAdvantage := neutral
+	withAdvantage.HomeAdvantage = 1.4
+	if got, want := predictEven(withAdvantage), predictEven(neutral); got != want {
+		t.Errorf("neutral venue: %+v, want %+v", got, want)
+	}
+}
+
+func TestHomeAdvantageFactor(t *testing.T) {
+	tests := []struct {
+		name              string
+		team, competition float64
+		want              float64
+	}{
+		{"not stored", 0, 0.4, 1},
+		{"as the competition", 0.4, 0.4, 1},
+		{"above", 0.9, 0.4, 1.05},
+		{"capped above", 3, 0.4, 1 + maxHomeAdvantageLift},
+		{"capped below", -2, 0.4, 1 - maxHomeAdvantageLift},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := homeAdvantageFactor(tt.team, tt.competition); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("homeAdvantageFactor(%v, %v) = %v, want %v", tt.team, tt.competition, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAwayTravelMovesPrediction(t *testing.T) {
+	base := predictEven(PredictionInput{})
+
+	long := predictEven(PredictionInput{AwayTravelKm: 2500})
+	if long.ExpectedAwayGoals >= base.ExpectedAwayGoals || long.ExpectedHomeGoals <= base.ExpectedHomeGoals {
+		t.Errorf("after 2500 km: %+v, want fewer away goals and more home goals than %+v", long, base)
+	}
+	if long.AwayWin >= base.AwayWin {
+		t.Errorf("after 2500 km: away win %v, want below %v", long.AwayWin, base.AwayWin)
+	}
+
+	farther := predictEven(PredictionInput{AwayTravelKm: 9000})
+	capped := predictEven(PredictionInput{AwayTravelKm: 3000})
+	if farther != capped {
+		t.Errorf("after 9000 km: %+v, want the capped %+v", farther, capped)
+	}
+
+	if got := predictEven(PredictionInput{Neutral: true, AwayTravelKm: 2500}); got != predictEven(PredictionInput{Neutral: true}) {
+		t.Errorf("neutral venue: travel moved the prediction to %+v", got)
+	}
+}
+
+func TestLateGoalShareMovesPrediction(t *testing.T) {
+	base := predictEven(PredictionInput{})
+
+	late := predictEven(PredictionInput{HomeLateGoalShare: 0.4, AwayLateGoalShare: 0.1})
+	if late.ExpectedHomeGoals <= base.ExpectedHomeGoals || late.ExpectedAwayGoals >= base.ExpectedAwayGoals {
+		t.Errorf("late home scorers: %+v, want more home goals and fewer away goals than %+v", late, base)
+	}
+	if late.HomeWin <= base.HomeWin {
+		t.Errorf("late home scorers: home win %v, want above %v", late.HomeWin, base.HomeWin)
+	}
+
+	if got := predictEven(PredictionInput{HomeLateGoalShare: typicalLateGoalShare}); got != base {
+		t.Errorf("typical late share: %+v, want %+v", got, base)
+	}
+}
+
+func TestSnapshotInputsMovePrediction(t *testing.T) {
+	advantage, late := 1.2, 0.35
+	snapshot := &FeatureSnapshot{
+		Home: repository.TeamFeatures{TeamID: 1, HomeAdvantage: &advantage, LateGoalShare: &late},
+		Away: repository.TeamFeatures{TeamID: 2, LineupAbsences: 3},
+	}
+	in := PredictionInput{HomeTeamID: 1, AwayTeamID: 2, CompetitionHomeAdvantage: 0.4}
+	setSnapshotInputs(&in, snapshot)
+	if in.HomeAdvantage != advantage || in.HomeLateGoalShare != late || in.AwayLineupAbsences != 3 {
+		t.Fatalf("input = %+v, want the snapshot's home advantage, late-goal share and absences", in)
+	}
+
+	bare := evenModel.Predict(1, 2)
+	replayed := predictModel(evenModel, in)
+	if replayed.HomeWinProbability <= bare.HomeWin {
+		t.Errorf("home win = %v, want above the bare model's %v", replayed.HomeWinProbability, bare.HomeWin)
+	}
+}
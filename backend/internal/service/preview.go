@@ -0,0 +1,491 @@
+package service
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/grounding"
+	"github.com/yourusername/football-prediction/internal/news"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/cache"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+const previewSystemPrompt = `You are a football journalist writing short match previews.
+Only use the facts provided. Do not invent players, injuries or statistics.
+News headlines are recent reports about each team; mention one only if it bears on the match.
+Reply with a JSON object: {"headline": "...", "summary": "..."}.
+The summary must be 2-3 sentences and mention what the prediction expects.`
+
+// previewWorkers bounds how many fixtures of a round are assembled at once,
+// which also bounds concurrent model and LLM calls.
+const previewWorkers = 4
+
+// previewKeyPlayers is how many top scorers are listed per team.
+const previewKeyPlayers = 3
+
+// A preview lists each team's previewNewsArticles latest headlines naming
+// it from the last previewNewsDays.
+const (
+	previewNewsArticles = 3
+	previewNewsDays     = 3
+)
+
+// previewPenaltyShare is the share of a team's goals scored from the spot
+// above which the template preview mentions it.
+const previewPenaltyShare = 0.2
+
+// PreviewService assembles everything needed to preview a round of fixtures
+// in one response.
+type PreviewService struct {
+	matchRepo   *repository.MatchRepository
+	playerRepo  *repository.PlayerRepository
+	teamRepo    *repository.TeamRepository
+	newsRepo    *repository.NewsRepository
+	predictions *PredictionService
+	streaks     *StreakService
+	llm         *llm.Client
+	reviews     *InsightReviewService
+
+	cache    *cache.Cache
+	cacheTTL time.Duration
+	building sync.Mutex
+}
+
+// NewPreviewService keeps each assembled preview for cacheTTL.
+func NewPreviewService(db *sql.DB, predictions *PredictionService, llmClient *llm.Client, reviews *InsightReviewService, cacheTTL time.Duration) *PreviewService {
+	return &PreviewService{
+		matchRepo:   repository.NewMatchRepository(db),
+		playerRepo:  repository.NewPlayerRepository(db),
+		teamRepo:    repository.NewTeamRepository(db),
+		newsRepo:    repository.NewNewsRepository(db),
+		predictions: predictions,
+		streaks:     NewStreakService(db),
+		llm:         llmClient.For(llmTaskPreview),
+		reviews:     reviews,
+		cache:       cache.New(),
+		cacheTTL:    cacheTTL,
+	}
+}
+
+// MatchdayPreview previews every fixture of a round. ETag identifies the
+// assembled content.
+type MatchdayPreview struct {
+	CompetitionCode string           `json:"competitionCode"`
+	Season          int              `json:"season"`
+	Matchday        int              `json:"matchday"`
+	GeneratedAt     time.Time        `json:"generatedAt"`
+	Fixtures        []FixturePreview `json:"fixtures"`
+	ETag            string           `json:"-"`
+}
+
+// FixturePreview is one fixture with its prediction, head-to-head record,
+// both teams' context and a narrative. Prediction is nil for fixtures that
+// have kicked off.
+type FixturePreview struct {
+	Match      repository.MatchSummary      `json:"match"`
+	Prediction *PreviewPrediction           `json:"prediction,omitempty"`
+	HeadToHead *repository.HeadToHeadRecord `json:"headToHead,omitempty"`
+	Home       PreviewTeam                  `json:"home"`
+	Away       PreviewTeam                  `json:"away"`
+	Narrative  PreviewNarrative             `json:"narrative"`
+}
+
+// PreviewPrediction is the served prediction of a fixture.
+type PreviewPrediction struct {
+	HomeWinProbability float64   `json:"homeWinProbability"`
+	DrawProbability    float64   `json:"drawProbability"`
+	AwayWinProbability float64   `json:"awayWinProbability"`
+	PredictedOutcome   string    `json:"predictedOutcome"`
+	ConfidenceScore    float64   `json:"confidenceScore"`
+	ModelVersion       string    `json:"modelVersion"`
+	ExpectedHomeGoals  *float64  `json:"expectedHomeGoals,omitempty"`
+	ExpectedAwayGoals  *float64  `json:"expectedAwayGoals,omitempty"`
+	PredictedAt        time.Time `json:"predictedAt"`
+}
+
+// PreviewTeam is one side's key players, unavailable players, players one
+// booking from a ban, penalty record, current streaks and latest news.
+// LateGoalShare is the share of its goals in the season scored after
+// minute 75, nil until it has scored lateGoalMinGoals with recorded events.
+type PreviewTeam struct {
+	KeyPlayers    []repository.ScorerRow         `json:"keyPlayers"`
+	Unavailable   []repository.UnavailablePlayer `json:"unavailable"`
+	BookingRisk   []SuspensionRisk               `json:"bookingRisk"`
+	SetPieces     *PreviewSetPieces              `json:"setPieces,omitempty"`
+	LateGoalShare *float64                       `json:"lateGoalShare,omitempty"`
+	Streaks       []repository.InsightsFact      `json:"streaks"`
+	News          []repository.NewsArticle       `json:"news"`
+}
+
+// PreviewSetPieces is one side's penalty record in the season and its
+// usual penalty taker.
+type PreviewSetPieces struct {
+	Penalties    PenaltyRecord `json:"penalties"`
+	PenaltyShare *float64      `json:"penaltyShare"`
+	Taker        *PenaltyTaker `json:"taker,omitempty"`
+}
+
+// PreviewNarrative is the written preview of a fixture.
+type PreviewNarrative struct {
+	Headline    string `json:"headline"`
+	Summary     string `json:"summary"`
+	GeneratedBy string `json:"generatedBy"`
+}
+
+// GetPreview returns the preview of a round, or nil if the season or round is
+// not stored. season is the start year (empty for the most recent season) and
+// matchday 0 means the current round. Kickoffs, in the match payloads and the
+// narrative, are given in loc. Previews are assembled once per cacheTTL and
+// zone; every section but the fixture list is best effort.
+func (s *PreviewService) GetPreview(competitionCode, season string, matchday int, loc *time.Location) (*MatchdayPreview, error) {
+	competitionCode = strings.ToUpper(competitionCode)
+
+	stored, err := s.matchRepo.ResolveSeason(competitionCode, season)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+
+	rng, err := s.matchRepo.GetMatchdayRange(competitionCode, stored.Season)
+	if err != nil {
+		return nil, err
+	}
+	if matchday == 0 {
+		matchday = rng.Current
+	}
+	if matchday < rng.First || matchday > rng.Last || matchday == 0 {
+		return nil, nil
+	}
+
+	cacheKey := fmt.Sprintf("preview:%s:%s:%d:%s", competitionCode, stored.Season, matchday, loc)
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*MatchdayPreview), nil
+	}
+
+	// Assemble each preview once even when several requests miss together
+	s.building.Lock()
+	defer s.building.Unlock()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*MatchdayPreview), nil
+	}
+
+	matches, err := s.matchRepo.ListMatchday(competitionCode, stored.Season, matchday)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &MatchdayPreview{
+		CompetitionCode: competitionCode,
+		Season:          stored.Year,
+		Matchday:        matchday,
+		GeneratedAt:     time.Now().UTC(),
+		Fixtures:        make([]FixturePreview, len(matches)),
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, previewWorkers)
+	for i, m := range matches {
+		wg.Add(1)
+		go func(i int, m repository.MatchSummary) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			preview.Fixtures[i] = s.fixture(m, strconv.Itoa(stored.Year), loc)
+		}(i, m)
+	}
+	wg.Wait()
+
+	body, err := json.Marshal(preview)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	preview.ETag = `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	s.cache.Set(cacheKey, preview, s.cacheTTL)
+	return preview, nil
+}
+
+// GetFixturePreview returns the preview of a match identified by its
+// football-data.org or internal ID, as served in its round's preview in loc,
+// or nil if the match does not exist or its round cannot be previewed.
+func (s *PreviewService) GetFixturePreview(id int, loc *time.Location) (*FixturePreview, error) {
+	match, err := s.matchRepo.GetSummaryByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		if match, err = s.matchRepo.GetSummaryByID(id); err != nil || match == nil {
+			return nil, err
+		}
+	}
+	if match.Matchday == 0 {
+		return nil, nil
+	}
+
+	// Seasons are keyed by the year of their first match, which for a
+	// split-year season is the year before the match's own
+	for _, year := range []int{match.UtcDate.Year(), match.UtcDate.Year() - 1} {
+		stored, err := s.matchRepo.ResolveSeason(match.CompetitionCode, strconv.Itoa(year))
+		if err != nil {
+			return nil, err
+		}
+		if stored == nil || stored.Season != match.Season {
+			continue
+		}
+
+		preview, err := s.GetPreview(match.CompetitionCode, strconv.Itoa(year), match.Matchday, loc)
+		if err != nil || preview == nil {
+			return nil, err
+		}
+		for i := range preview.Fixtures {
+			if preview.Fixtures[i].Match.ID == match.ID {
+				return &preview.Fixtures[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// fixture assembles the preview of one fixture with its kickoff in loc.
+// seasonYear scopes key players to the season being previewed.
+func (s *PreviewService) fixture(m repository.MatchSummary, seasonYear string, loc *time.Location) FixturePreview {
+	m.Localize(loc)
+	f := FixturePreview{
+		Match: m,
+		Home:  s.team(m.HomeTeamID, m.CompetitionCode, seasonYear),
+		Away:  s.team(m.AwayTeamID, m.CompetitionCode, seasonYear),
+	}
+
+	if h2h, err := s.matchRepo.GetHeadToHeadByExternalTeamIDs(m.HomeTeamExternalID, m.AwayTeamExternalID, repository.HeadToHeadFilter{Limit: 10}); err == nil {
+		f.HeadToHead = h2h
+	}
+
+	if unavailable, err := s.playerRepo.ListUnavailable([]int{m.HomeTeamID, m.AwayTeamID}, m.UtcDate, time.Now()); err == nil {
+		for _, p := range unavailable {
+			if p.TeamID == m.HomeTeamID {
+				f.Home.Unavailable = append(f.Home.Unavailable, p)
+			} else {
+				f.Away.Unavailable = append(f.Away.Unavailable, p)
+			}
+		}
+	}
+
+	if m.Status == "SCHEDULED" || m.Status == "TIMED" {
+		f.Prediction = s.predict(m)
+	}
+
+	f.Narrative = templatePreview(f)
+	if s.llm.Enabled() {
+		if narrative, err := s.narrate(f, loc); err == nil {
+			f.Narrative = *narrative
+		} else {
+			fmt.Printf("LLM preview failed for match %d, using template: %v\n", m.ID, err)
+		}
+	}
+	return f
+}
+
+// team gathers one side's top scorers, penalty record and late goals of the
+// season, players one booking from a ban in the competition, stored streaks
+// and recent headlines.
+func (s *PreviewService) team(teamID int, competitionCode, seasonYear string) PreviewTeam {
+	var t PreviewTeam
+	if players, err := s.playerRepo.GetTopScorers(repository.ScorerFilter{
+		TeamID:          teamID,
+		CompetitionCode: competitionCode,
+		Season:          seasonYear,
+		Limit:           previewKeyPlayers,
+	}); err == nil {
+		t.KeyPlayers = players
+	}
+	if bookings, err := s.playerRepo.ListTeamBookings(teamID, time.Now()); err == nil {
+		t.BookingRisk = atRiskOfBan(bookings, competitionCode)
+	}
+	if profile, err := setPieceProfile(s.teamRepo, teamID, seasonYear); err == nil && profile != nil {
+		t.SetPieces = &PreviewSetPieces{Penalties: profile.Penalty, PenaltyShare: profile.PenaltyShare}
+		if len(profile.Takers) > 0 {
+			t.SetPieces.Taker = &profile.Takers[0]
+		}
+	}
+	if timings, err := goalTimings(s.matchRepo, teamID, seasonYear, ""); err == nil && timings.Scored >= lateGoalMinGoals {
+		t.LateGoalShare = timings.LateScoredShare
+	}
+	if facts, err := s.streaks.TeamFacts(teamID); err == nil {
+		t.Streaks = facts
+	}
+	since := time.Now().UTC().AddDate(0, 0, -previewNewsDays)
+	if articles, err := s.newsRepo.ListForTeam(teamID, since, news.TitleRelevance, previewNewsArticles); err == nil {
+		t.News = articles
+	}
+	return t
+}
+
+// predict serves the fixture's prediction, recording fresh ones so they are
+// settled like those served one match at a time.
+func (s *PreviewService) predict(m repository.MatchSummary) *PreviewPrediction {
+	in := PredictionInput{
+		MatchID:            m.ID,
+		HomeTeamID:         m.HomeTeamID,
+		AwayTeamID:         m.AwayTeamID,
+		HomeTeamExternalID: m.HomeTeamExternalID,
+		AwayTeamExternalID: m.AwayTeamExternalID,
+		HomeTeamName:       m.HomeTeamName,
+		AwayTeamName:       m.AwayTeamName,
+		Matchday:           m.Matchday,
+	}
+	p := s.predictions.Predict(in, false)
+	if !p.Cached {
+		if err := s.predictions.Record(m.ID, in, p); err != nil {
+			fmt.Printf("Failed to record preview prediction for match %d: %v\n", m.ID, err)
+		}
+	}
+
+	return &PreviewPrediction{
+		HomeWinProbability: p.HomeWinProbability,
+		DrawProbability:    p.DrawProbability,
+		AwayWinProbability: p.AwayWinProbability,
+		PredictedOutcome:   p.PredictedOutcome,
+		ConfidenceScore:    p.ConfidenceScore,
+		ModelVersion:       p.ModelVersion,
+		ExpectedHomeGoals:  p.ExpectedHomeGoals,
+		ExpectedAwayGoals:  p.ExpectedAwayGoals,
+		PredictedAt:        p.PredictedAt,
+	}
+}
+
+// templatePreview writes a plain preview used when no LLM is configured or
+// the LLM fails.
+func templatePreview(f FixturePreview) PreviewNarrative {
+	m := f.Match
+	headline := fmt.Sprintf("%s vs %s", m.HomeTeamName, m.AwayTeamName)
+
+	var sentences []string
+	if p := f.Prediction; p != nil {
+		outcome := "a draw"
+		if p.PredictedOutcome != "Draw" {
+			outcome = "a " + strings.TrimSuffix(p.PredictedOutcome, " Win") + " win"
+		}
+		sentences = append(sentences, fmt.Sprintf("The model expects %s with %.0f%% confidence.",
+			outcome, p.ConfidenceScore*100))
+	}
+	if h := f.HeadToHead; h != nil {
+		sentences = append(sentences, fmt.Sprintf("Recent meetings: %s %d, %s %d, %d drawn.",
+			m.HomeTeamName, h.HomeWins, m.AwayTeamName, h.AwayWins, h.Draws))
+	}
+	for _, facts := range [][]repository.InsightsFact{f.Home.Streaks, f.Away.Streaks} {
+		if len(facts) > 0 {
+			sentences = append(sentences, facts[0].Description+".")
+		}
+	}
+	if n := len(f.Home.Unavailable) + len(f.Away.Unavailable); n > 0 {
+		sentences = append(sentences, fmt.Sprintf("%s unavailable.", countNoun(n, "player")))
+	}
+	if n := len(f.Home.BookingRisk) + len(f.Away.BookingRisk); n > 0 {
+		sentences = append(sentences, fmt.Sprintf("%s one booking from a ban.", countNoun(n, "player")))
+	}
+	for _, side := range []struct {
+		name string
+		team PreviewTeam
+	}{{m.HomeTeamName, f.Home}, {m.AwayTeamName, f.Away}} {
+		if sp := side.team.SetPieces; sp != nil && sp.PenaltyShare != nil && *sp.PenaltyShare >= previewPenaltyShare {
+			sentences = append(sentences, fmt.Sprintf("%s have scored %.0f%% of their goals from the spot.",
+				side.name, *sp.PenaltyShare*100))
+		}
+		if share := side.team.LateGoalShare; share != nil && *share >= lateGoalInsightShare {
+			sentences = append(sentences, fmt.Sprintf("%s have scored %.0f%% of their goals after minute 75.",
+				side.name, *share*100))
+		}
+	}
+
+	return PreviewNarrative{
+		Headline:    headline,
+		Summary:     strings.Join(sentences, " "),
+		GeneratedBy: "template",
+	}
+}
+
+// narrate asks the LLM to write the preview from verified facts assembled
+// for the fixture, with the kickoff in loc, checking every number it states
+// against them, and passes the result through the quality gate.
+func (s *PreviewService) narrate(f FixturePreview, loc *time.Location) (*PreviewNarrative, error) {
+	m := f.Match
+	var facts grounding.Facts
+	facts.Add("Fixture: %s (home) vs %s (away)", m.HomeTeamName, m.AwayTeamName)
+	if m.Matchday > 0 {
+		facts.Add("Competition: %s, matchday %d", m.CompetitionCode, m.Matchday)
+	} else {
+		facts.Add("Competition: %s", m.CompetitionCode)
+	}
+	facts.Add("Kickoff: %s", m.UtcDate.In(loc).Format("Monday 2 January 2006, 15:04 MST"))
+
+	if p := f.Prediction; p != nil {
+		facts.Add("The model predicts %s with %.0f%% confidence", p.PredictedOutcome, p.ConfidenceScore*100)
+		facts.Add("Model probabilities: %s win %.0f%%, draw %.0f%%, %s win %.0f%%",
+			m.HomeTeamName, p.HomeWinProbability*100, p.DrawProbability*100, m.AwayTeamName, p.AwayWinProbability*100)
+		if p.ExpectedHomeGoals != nil && p.ExpectedAwayGoals != nil {
+			facts.Add("Expected goals: %s %.1f, %s %.1f", m.HomeTeamName, *p.ExpectedHomeGoals, m.AwayTeamName, *p.ExpectedAwayGoals)
+		}
+	}
+
+	if h := f.HeadToHead; h != nil && len(h.Matches) > 0 {
+		facts.Add("In their last %d meetings %s won %d, %s won %d and %d were drawn",
+			len(h.Matches), m.HomeTeamName, h.HomeWins, m.AwayTeamName, h.AwayWins, h.Draws)
+	}
+
+	for _, side := range []struct {
+		name string
+		team PreviewTeam
+	}{{m.HomeTeamName, f.Home}, {m.AwayTeamName, f.Away}} {
+		for _, p := range side.team.KeyPlayers {
+			facts.Add("%s of %s has scored %d goals this season", p.Name, side.name, p.Goals)
+		}
+		for _, p := range side.team.Unavailable {
+			facts.Add("%s of %s is unavailable (%s)", p.PlayerName, side.name, p.Reason)
+		}
+		for _, p := range side.team.BookingRisk {
+			facts.Add("%s of %s is one booking from a ban, on %d yellow cards", p.PlayerName, side.name, p.YellowCards)
+		}
+		if sp := side.team.SetPieces; sp != nil {
+			facts.Add("%s have scored %d penalties and missed %d this season", side.name, sp.Penalties.Scored, sp.Penalties.Missed)
+			if sp.PenaltyShare != nil {
+				facts.Add("%s have scored %.0f%% of their goals from the spot", side.name, *sp.PenaltyShare*100)
+			}
+			if sp.Taker != nil {
+				facts.Add("%s is %s's usual penalty taker", sp.Taker.PlayerName, side.name)
+			}
+		}
+		if share := side.team.LateGoalShare; share != nil {
+			facts.Add("%s have scored %.0f%% of their goals this season after minute 75", side.name, *share*100)
+		}
+		for _, fact := range side.team.Streaks {
+			facts.Add("%s", fact.Description)
+		}
+		for _, a := range side.team.News {
+			facts.Add("News about %s: %s (%s, %s)", side.name, a.Title, a.Source, a.PublishedAt.Format("2 Jan"))
+		}
+	}
+
+	headline, summary, model, err := groundedNarrative(s.llm, previewSystemPrompt, "Fixture facts:", &facts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reviews.Narrative(InsightKindPreview, &m, "llm:"+model, headline, summary, &facts); err != nil {
+		return nil, err
+	}
+	return &PreviewNarrative{
+		Headline:    headline,
+		Summary:     summary,
+		GeneratedBy: "llm:" + model,
+	}, nil
+}
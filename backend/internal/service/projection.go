@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+const projectionSimulations = 10000
+
+// ProjectedStandings is a competition's final table as projected from its
+// stored results and the fixtures still to play, ranked by the
+// competition's rules.
+type ProjectedStandings struct {
+	CompetitionCode  string                    `json:"competitionCode"`
+	Season           int                       `json:"season"`
+	Rules            repository.StandingsRules `json:"rules"`
+	MatchesCounted   int                       `json:"matchesCounted"`
+	MatchesRemaining int                       `json:"matchesRemaining"`
+	Simulations      int                       `json:"simulations"`
+	ModelVersion     string                    `json:"modelVersion,omitempty"`
+	Table            []ProjectedStanding       `json:"table"`
+}
+
+// ProjectedStanding is a team's current row with its average points and
+// position at the end of the season and how likely it is to finish in each
+// place, first place first. Rows are ordered by projected position.
+type ProjectedStanding struct {
+	repository.TableRow
+	ProjectedPoints   float64   `json:"projectedPoints"`
+	ProjectedPosition float64   `json:"projectedPosition"`
+	Positions         []float64 `json:"positions"`
+}
+
+// ProjectionService projects league tables to the end of the season.
+type ProjectionService struct {
+	matchRepo     *repository.MatchRepository
+	standingsRepo *repository.StandingsRepository
+	predictions   *PredictionService
+}
+
+func NewProjectionService(db *sql.DB, predictions *PredictionService) *ProjectionService {
+	return &ProjectionService{
+		matchRepo:     repository.NewMatchRepository(db),
+		standingsRepo: repository.NewStandingsRepository(db),
+		predictions:   predictions,
+	}
+}
+
+// Project simulates a competition's remaining fixtures many times from the
+// goals the prediction model expects in each and ranks every simulated
+// table by the competition's rules, or returns nil if the season is not
+// stored. season is the start year; empty means the most recent season.
+// Simulations are seeded with the season and the matches counted, so the
+// projection only moves with results and predictions. A finished season
+// has certain positions and no simulations.
+func (s *ProjectionService) Project(ctx context.Context, competitionCode, season string) (*ProjectedStandings, error) {
+	competitionCode = strings.ToUpper(competitionCode)
+
+	stored, err := s.matchRepo.ResolveSeason(competitionCode, season)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+
+	rules, err := standingsRules(s.standingsRepo, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	played, err := s.matchRepo.ListSeasonResults(competitionCode, stored.Season)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.matchRepo.ListSeasonFixtures(competitionCode, stored.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := &ProjectedStandings{
+		CompetitionCode:  competitionCode,
+		Season:           stored.Year,
+		Rules:            *rules,
+		MatchesCounted:   len(played),
+		MatchesRemaining: len(remaining),
+	}
+
+	fixtures := make([]projectedFixture, 0, len(remaining))
+	for _, m := range remaining {
+		home, away, version, ok := s.predictions.expectedGoals(ctx, PredictionInput{
+			MatchID:            m.ID,
+			HomeTeamID:         m.HomeTeamID,
+			AwayTeamID:         m.AwayTeamID,
+			HomeTeamExternalID: m.HomeTeamExternalID,
+			AwayTeamExternalID: m.AwayTeamExternalID,
+			HomeTeamName:       m.HomeTeamName,
+			AwayTeamName:       m.AwayTeamName,
+			Matchday:           m.Matchday,
+		})
+		if !ok {
+			return nil, fmt.Errorf("no expected goals for match %d", m.ID)
+		}
+		fixtures = append(fixtures, projectedFixture{match: m, home: home, away: away})
+		projection.ModelVersion = version
+	}
+	if len(fixtures) > 0 {
+		projection.Simulations = projectionSimulations
+	}
+
+	seed := int64(stored.Year)*100000 + int64(len(played))
+	projection.Table = projectTable(tallyResults(played, *rules, nil), played, fixtures, *rules, seed)
+	return projection, nil
+}
+
+// projectedFixture is a match still to play with the goals each side is
+// expected to score.
+type projectedFixture struct {
+	match      repository.MatchSummary
+	home, away float64
+}
+
+// projectTable plays fixtures projectionSimulations times on top of the
+// current tallies, which played are the results of, and ranks each
+// simulated table with rankTallies, so head-to-head criteria count the
+// simulated meetings too. Without fixtures the current table is final.
+func projectTable(current map[int]*standingTally, played []repository.MatchSummary, fixtures []projectedFixture, rules repository.StandingsRules, seed int64) []ProjectedStanding {
+	base := make(map[int]*standingTally, len(current))
+	for id, t := range current {
+		c := *t
+		base[id] = &c
+	}
+	for _, f := range fixtures {
+		teamTally(base, f.match.HomeTeamID, f.match.HomeTeamExternalID, f.match.HomeTeamName)
+		teamTally(base, f.match.AwayTeamID, f.match.AwayTeamExternalID, f.match.AwayTeamName)
+	}
+
+	table := rankTallies(base, played, rules)
+	positions := make(map[int][]int, len(table))
+	points := make(map[int]int, len(table))
+	for _, row := range table {
+		positions[row.TeamID] = make([]int, len(table))
+	}
+
+	simulations := 1
+	if len(fixtures) == 0 {
+		for _, row := range table {
+			positions[row.TeamID][row.Position-1] = 1
+			points[row.TeamID] = row.Points
+		}
+	} else {
+		simulations = projectionSimulations
+		rng := rand.New(rand.NewSource(seed))
+		matches := make([]repository.MatchSummary, len(played), len(played)+len(fixtures))
+		copy(matches, played)
+		for i := 0; i < projectionSimulations; i++ {
+			tallies := make(map[int]*standingTally, len(base))
+			for id, t := range base {
+				c := *t
+				tallies[id] = &c
+			}
+			sim := matches[:len(played)]
+			for _, f := range fixtures {
+				m := f.match
+				homeGoals, awayGoals := poissonSample(rng, f.home), poissonSample(rng, f.away)
+				m.HomeScore, m.AwayScore = &homeGoals, &awayGoals
+				addResult(tallies, m, rules)
+				sim = append(sim, m)
+			}
+			for _, row := range rankTallies(tallies, sim, rules) {
+				positions[row.TeamID][row.Position-1]++
+				points[row.TeamID] += row.Points
+			}
+		}
+	}
+
+	projected := make([]ProjectedStanding, 0, len(table))
+	for _, row := range table {
+		counts := positions[row.TeamID]
+		p := ProjectedStanding{
+			TableRow:        row,
+			ProjectedPoints: math.Round(float64(points[row.TeamID])/float64(simulations)*10) / 10,
+			Positions:       make([]float64, len(counts)),
+		}
+		var sum int
+		for i, n := range counts {
+			p.Positions[i] = math.Round(float64(n)/float64(simulations)*1000) / 1000
+			sum += (i + 1) * n
+		}
+		p.ProjectedPosition = math.Round(float64(sum)/float64(simulations)*100) / 100
+		projected = append(projected, p)
+	}
+	sort.SliceStable(projected, func(i, j int) bool {
+		return projected[i].ProjectedPosition < projected[j].ProjectedPosition
+	})
+	return projected
+}
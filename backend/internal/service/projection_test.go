@@ -0,0 +1,187 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// season is the teams of a historic table, by their record at some point,
+// and the matches between them the tie-breakers look back at.
+type season struct {
+	teams    map[int]*standingTally
+	meetings []repository.MatchSummary
+}
+
+// record adds a team's record; its ID is its place in the table as given.
+func (s *season) record(name string, won, drawn, lost, goalsFor, goalsAgainst int) int {
+	if s.teams == nil {
+		s.teams = make(map[int]*standingTally)
+	}
+	id := len(s.teams) + 1
+	s.teams[id] = &standingTally{row: repository.TableRow{
+		TeamID:         id,
+		TeamExternalID: id,
+		TeamName:       name,
+		Played:         won + drawn + lost,
+		Won:            won,
+		Draw:           drawn,
+		Lost:           lost,
+		GoalsFor:       goalsFor,
+		GoalsAgainst:   goalsAgainst,
+		GoalDifference: goalsFor - goalsAgainst,
+		Points:         3*won + drawn,
+	}}
+	return id
+}
+
+// match is a fixture between two of the teams.
+func (s *season) match(home, away int) repository.MatchSummary {
+	h, a := s.teams[home].row, s.teams[away].row
+	return repository.MatchSummary{
+		HomeTeamID:         h.TeamID,
+		HomeTeamExternalID: h.TeamExternalID,
+		HomeTeamName:       h.TeamName,
+		AwayTeamID:         a.TeamID,
+		AwayTeamExternalID: a.TeamExternalID,
+		AwayTeamName:       a.TeamName,
+	}
+}
+
+func (s *season) met(home, away, homeGoals, awayGoals int) {
+	m := s.match(home, away)
+	m.HomeScore, m.AwayScore = &homeGoals, &awayGoals
+	s.meetings = append(s.meetings, m)
+}
+
+func rulesOf(competition string) repository.StandingsRules {
+	return repository.StandingsRules{
+		CompetitionCode: competition,
+		PointsWin:       3,
+		PointsDraw:      1,
+		TieBreakers:     defaultTieBreakers[competition],
+	}
+}
+
+// Final tables whose order a tie-break decided. A finished season projects
+// to its final table with certainty.
+func TestProjectFinalTables(t *testing.T) {
+	// 2006-07 La Liga: level on 76 points, Barcelona had the better goal
+	// difference but Real Madrid won the meetings 2-0 and drew 3-3
+	var liga season
+	madrid := liga.record("Real Madrid", 23, 7, 8, 66, 40)
+	barcelona := liga.record("Barcelona", 22, 10, 6, 78, 33)
+	liga.met(madrid, barcelona, 2, 0)
+	liga.met(barcelona, madrid, 3, 3)
+
+	// 2011-12 Premier League: level on 89 points, Manchester City were
+	// champions on goal difference
+	var pl season
+	city := pl.record("Manchester City", 28, 5, 5, 93, 29)
+	united := pl.record("Manchester United", 28, 5, 5, 89, 33)
+	pl.met(united, city, 1, 6)
+	pl.met(city, united, 1, 0)
+
+	// Euro 2020 group F: Germany and Portugal level on points and goal
+	// difference, Portugal scored more but Germany won their meeting 4-2
+	var euro2020 season
+	france := euro2020.record("France", 1, 2, 0, 4, 3)
+	germany := euro2020.record("Germany", 1, 1, 1, 6, 5)
+	portugal := euro2020.record("Portugal", 1, 1, 1, 7, 6)
+	hungary := euro2020.record("Hungary", 0, 2, 1, 3, 6)
+	euro2020.met(hungary, portugal, 0, 3)
+	euro2020.met(france, germany, 1, 0)
+	euro2020.met(hungary, france, 1, 1)
+	euro2020.met(portugal, germany, 2, 4)
+	euro2020.met(germany, hungary, 2, 2)
+	euro2020.met(portugal, france, 2, 2)
+
+	// Euro 2016 group F: Hungary and Iceland drew 1-1, so the head-to-head
+	// criteria left them level and Hungary topped the group on goal
+	// difference
+	var euro2016 season
+	hungary16 := euro2016.record("Hungary", 1, 2, 0, 6, 4)
+	iceland := euro2016.record("Iceland", 1, 2, 0, 4, 3)
+	portugal16 := euro2016.record("Portugal", 0, 3, 0, 4, 4)
+	austria := euro2016.record("Austria", 0, 1, 2, 1, 4)
+	euro2016.met(austria, hungary16, 0, 2)
+	euro2016.met(portugal16, iceland, 1, 1)
+	euro2016.met(iceland, hungary16, 1, 1)
+	euro2016.met(portugal16, austria, 0, 0)
+	euro2016.met(iceland, austria, 2, 1)
+	euro2016.met(hungary16, portugal16, 3, 3)
+
+	tests := []struct {
+		name        string
+		season      season
+		competition string
+		want        []string
+	}{
+		{"La Liga 2006-07", liga, "PD", []string{"Real Madrid", "Barcelona"}},
+		{"La Liga 2006-07 by Premier League rules", liga, "PL", []string{"Barcelona", "Real Madrid"}},
+		{"Premier League 2011-12", pl, "PL", []string{"Manchester City", "Manchester United"}},
+		{"Euro 2020 group F", euro2020, "EC", []string{"France", "Germany", "Portugal", "Hungary"}},
+		{"Euro 2020 group F by World Cup rules", euro2020, "WC", []string{"France", "Portugal", "Germany", "Hungary"}},
+		{"Euro 2016 group F", euro2016, "EC", []string{"Hungary", "Iceland", "Portugal", "Austria"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := projectTable(tt.season.teams, tt.season.meetings, nil, rulesOf(tt.competition), 1)
+
+			got := make([]string, 0, len(table))
+			for i, row := range table {
+				got = append(got, row.TeamName)
+				if row.Positions[i] != 1 || row.ProjectedPoints != float64(row.Points) {
+					t.Errorf("%s: %+v, want certain to finish %d on %d points", row.TeamName, row, i+1, row.Points)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// The 2011-12 Premier League before its last day: Manchester City and
+// Manchester United level on 86 points, City 8 goals better off, City at
+// home to QPR and United away at Sunderland.
+func TestProjectLastDay(t *testing.T) {
+	var pl season
+	city := pl.record("Manchester City", 27, 5, 5, 90, 27)
+	united := pl.record("Manchester United", 27, 5, 5, 88, 33)
+	sunderland := pl.record("Sunderland", 11, 12, 14, 45, 45)
+	qpr := pl.record("QPR", 10, 7, 20, 41, 63)
+	pl.met(united, city, 1, 6)
+	pl.met(city, united, 1, 0)
+
+	fixtures := []projectedFixture{
+		{match: pl.match(city, qpr), home: 2.4, away: 0.7},
+		{match: pl.match(sunderland, united), home: 0.9, away: 1.7},
+	}
+
+	table := projectTable(pl.teams, pl.meetings, fixtures, rulesOf("PL"), 2012)
+	if table[0].TeamName != "Manchester City" || table[1].TeamName != "Manchester United" {
+		t.Fatalf("projected order starts %s, %s, want Manchester City, Manchester United", table[0].TeamName, table[1].TeamName)
+	}
+
+	cityTitle, unitedTitle := table[0].Positions[0], table[1].Positions[0]
+	if cityTitle <= 0.5 || unitedTitle <= 0 {
+		t.Errorf("title odds City %v, United %v; want City favourites and United still in it", cityTitle, unitedTitle)
+	}
+	if sum := cityTitle + unitedTitle; sum < 0.999 || sum > 1.001 {
+		t.Errorf("title odds sum to %v, want 1", sum)
+	}
+	if p := table[0].ProjectedPoints; p <= 86 || p >= 89 {
+		t.Errorf("City projected on %v points, want between 86 and 89", p)
+	}
+	if pl.teams[city].row.Played != 37 {
+		t.Errorf("the current tallies were changed: %+v", pl.teams[city].row)
+	}
+
+	again := projectTable(pl.teams, pl.meetings, fixtures, rulesOf("PL"), 2012)
+	if !reflect.DeepEqual(again, table) {
+		t.Errorf("projection with the same seed = %+v, want %+v", again, table)
+	}
+}
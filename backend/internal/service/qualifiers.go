@@ -0,0 +1,204 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// QualifierSlots are the group positions that qualify directly and the ones
+// below them that lead to a play-off. Where only the best runners-up across
+// groups go through, the play-off places are the most a group can send.
+type QualifierSlots struct {
+	Direct  int `json:"direct"`
+	Playoff int `json:"playoff"`
+}
+
+// qualifierSlots are the group places of each confederation's current World
+// Cup qualifying format.
+var qualifierSlots = map[string]QualifierSlots{
+	"AFC":      {Direct: 2, Playoff: 2},
+	"CAF":      {Direct: 1, Playoff: 1},
+	"CONCACAF": {Direct: 1, Playoff: 1},
+	"CONMEBOL": {Direct: 6, Playoff: 1},
+	"OFC":      {Direct: 1, Playoff: 0},
+	"UEFA":     {Direct: 1, Playoff: 1},
+}
+
+// Qualification states of a team in its group.
+const (
+	QualifierQualified  = "QUALIFIED"  // a direct place is certain
+	QualifierPlayoff    = "PLAYOFF"    // at least a play-off place is certain
+	QualifierEliminated = "ELIMINATED" // no place is reachable any more
+	QualifierOpen       = "OPEN"
+)
+
+// Qualifiers is the qualification state of a confederation's World Cup
+// qualifiers, computed from stored matches.
+type Qualifiers struct {
+	Confederation   string           `json:"confederation"`
+	CompetitionCode string           `json:"competitionCode"`
+	Season          int              `json:"season"`
+	Slots           QualifierSlots   `json:"slots"`
+	Groups          []QualifierGroup `json:"groups"`
+}
+
+// QualifierGroup is one group's table and the number of its fixtures still
+// to be played.
+type QualifierGroup struct {
+	Group     string              `json:"group"`
+	Remaining int                 `json:"remaining"`
+	Table     []QualifierStanding `json:"table"`
+}
+
+// QualifierStanding is a table line with the team's scenario: MaxPoints if it
+// wins every remaining match, whether a direct place can still be reached,
+// and what is already certain. Teams level on points are assumed to finish
+// above the team, so a place counts as certain only once it no longer
+// depends on tie-breakers. PointsToSecure is how many more points make a
+// direct place certain, when they can still be won.
+type QualifierStanding struct {
+	repository.TableRow
+	Remaining      int    `json:"remaining"`
+	MaxPoints      int    `json:"maxPoints"`
+	CanQualify     bool   `json:"canQualifyDirectly"`
+	Status         string `json:"status"`
+	PointsToSecure *int   `json:"pointsToSecure,omitempty"`
+}
+
+// Qualifiers returns the group tables and scenarios of a confederation's
+// World Cup qualifiers, or nil if no season of them is stored. season is the
+// start year; empty means the most recent season.
+func (s *StandingsService) Qualifiers(confederation, season string) (*Qualifiers, error) {
+	confederation = strings.ToUpper(confederation)
+	code, ok := football.QualifierCodes[confederation]
+	if !ok {
+		return nil, invalidf("unknown confederation %q", confederation)
+	}
+
+	stored, err := s.matchRepo.ResolveSeason(code, season)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+
+	rules, err := s.Rules(code)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.ListGroupMatches(code, stored.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := qualifierSlots[confederation]
+	q := &Qualifiers{
+		Confederation:   confederation,
+		CompetitionCode: code,
+		Season:          stored.Year,
+		Slots:           slots,
+		Groups:          []QualifierGroup{},
+	}
+	for start := 0; start < len(matches); {
+		end := start + 1
+		for end < len(matches) && matches[end].Group == matches[start].Group {
+			end++
+		}
+		q.Groups = append(q.Groups, qualifierGroup(matches[start:end], *rules, slots))
+		start = end
+	}
+	return q, nil
+}
+
+// qualifierGroup ranks one group on its finished matches, listing teams yet
+// to play too, and works out each team's scenario.
+func qualifierGroup(matches []repository.GroupMatch, rules repository.StandingsRules, slots QualifierSlots) QualifierGroup {
+	var finished []repository.MatchSummary
+	remaining := map[int]int{}
+	group := QualifierGroup{Group: matches[0].Group}
+	for _, m := range matches {
+		switch m.Status {
+		case "FINISHED":
+			finished = append(finished, m.MatchSummary)
+		case "CANCELLED", "AWARDED":
+			// Not counted, as in computed standings
+		default:
+			remaining[m.HomeTeamID]++
+			remaining[m.AwayTeamID]++
+			group.Remaining++
+		}
+	}
+
+	tallies := tallyResults(finished, rules, nil)
+	for _, m := range matches {
+		for _, t := range []repository.TableRow{
+			{TeamID: m.HomeTeamID, TeamExternalID: m.HomeTeamExternalID, TeamName: m.HomeTeamName},
+			{TeamID: m.AwayTeamID, TeamExternalID: m.AwayTeamExternalID, TeamName: m.AwayTeamName},
+		} {
+			if _, ok := tallies[t.TeamID]; !ok {
+				tallies[t.TeamID] = &standingTally{row: t}
+			}
+		}
+	}
+
+	table := rankTallies(tallies, finished, rules)
+	group.Table = make([]QualifierStanding, 0, len(table))
+	for _, row := range table {
+		group.Table = append(group.Table, QualifierStanding{
+			TableRow:  row,
+			Remaining: remaining[row.TeamID],
+			MaxPoints: row.Points + remaining[row.TeamID]*rules.PointsWin,
+		})
+	}
+	for i := range group.Table {
+		group.Table[i].scenario(group.Table, slots)
+	}
+	return group
+}
+
+// scenario sets what the team can still reach and what is certain, given
+// the rest of its group.
+func (t *QualifierStanding) scenario(table []QualifierStanding, slots QualifierSlots) {
+	var canPass, surePassed int
+	var rivalsMax []int
+	for _, u := range table {
+		if u.TeamID == t.TeamID {
+			continue
+		}
+		if u.MaxPoints >= t.Points {
+			canPass++
+		}
+		if u.Points > t.MaxPoints {
+			surePassed++
+		}
+		rivalsMax = append(rivalsMax, u.MaxPoints)
+	}
+
+	places := slots.Direct + slots.Playoff
+	t.CanQualify = surePassed < slots.Direct
+	switch {
+	case canPass < slots.Direct:
+		t.Status = QualifierQualified
+	case canPass < places:
+		t.Status = QualifierPlayoff
+	case surePassed >= places:
+		t.Status = QualifierEliminated
+	default:
+		t.Status = QualifierOpen
+	}
+
+	// Points that secure a direct place whatever the others do: more than
+	// the Direct-th best maximum among the rivals.
+	if t.Status != QualifierQualified && t.CanQualify && len(rivalsMax) >= slots.Direct {
+		sort.Sort(sort.Reverse(sort.IntSlice(rivalsMax)))
+		need := rivalsMax[slots.Direct-1] + 1 - t.Points
+		if t.Points+need <= t.MaxPoints {
+			t.PointsToSecure = &need
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/football"
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+// defaultProviderQuotas are the providers' free plans.
+var defaultProviderQuotas = map[string]quota.Limits{
+	football.Provider:    {PerMinute: 10},
+	apifootball.Provider: {PerMinute: 10, PerDay: 100},
+}
+
+// highPriorityReserve is the share of a daily budget only high priority
+// fetches, such as ingests of upcoming followed fixtures, may use.
+const highPriorityReserve = 0.2
+
+// ParseProviderQuotas parses "provider=budget" pairs separated by commas,
+// a budget being requests per min and per day joined by "+", e.g.
+// "football-data=30/min,api-football=300/min+7500/day". A provider given
+// here has no limit on the period it leaves out. Invalid pairs are reported
+// and skipped.
+func ParseProviderQuotas(spec string) (map[string]quota.Limits, error) {
+	quotas := make(map[string]quota.Limits)
+	var invalid []string
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		provider, budget, _ := strings.Cut(pair, "=")
+		provider = strings.TrimSpace(provider)
+		limits, ok := parseProviderBudget(budget)
+		if !ok || provider == "" {
+			invalid = append(invalid, pair)
+			continue
+		}
+		quotas[provider] = limits
+	}
+
+	if len(invalid) > 0 {
+		return quotas, fmt.Errorf("invalid provider quotas %s", strings.Join(invalid, ", "))
+	}
+	return quotas, nil
+}
+
+func parseProviderBudget(budget string) (quota.Limits, bool) {
+	var limits quota.Limits
+	for _, part := range strings.Split(budget, "+") {
+		count, period, _ := strings.Cut(strings.TrimSpace(part), "/")
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 0 {
+			return limits, false
+		}
+		switch period {
+		case "min":
+			limits.PerMinute = n
+		case "day":
+			limits.PerDay = n
+		default:
+			return limits, false
+		}
+	}
+	return limits, true
+}
+
+// NewProviderQuotas returns the request budgets of the football-data.org
+// and API-Football keys, counted in the database so every process using a
+// key shares its budget. overrides replace the free-plan defaults per
+// provider.
+func NewProviderQuotas(db *sql.DB, overrides map[string]quota.Limits) *quota.Manager {
+	limits := make(map[string]quota.Limits, len(defaultProviderQuotas))
+	for provider, l := range defaultProviderQuotas {
+		limits[provider] = l
+	}
+	for provider, l := range overrides {
+		limits[provider] = l
+	}
+
+	m := quota.NewManager(repository.NewProviderQuotaRepository(db))
+	for provider, l := range limits {
+		l.Reserved = int(float64(l.PerDay) * highPriorityReserve)
+		m.SetLimits(provider, l)
+	}
+	return m
+}
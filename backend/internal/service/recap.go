@@ -0,0 +1,318 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/grounding"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/llm"
+)
+
+const recapSystemPrompt = `You are a football journalist writing concise post-match recaps.
+Only use the facts provided. Do not invent players, minutes or statistics.
+Reply with a JSON object: {"headline": "...", "summary": "..."}.
+The summary must be 2-4 sentences and mention how the prediction fared.
+Mention a streak or milestone only if it adds to the story.`
+
+// RecapService generates and serves post-match recaps.
+type RecapService struct {
+	football   *FootballService
+	llm        *llm.Client
+	reviews    *InsightReviewService
+	matchRepo  *repository.MatchRepository
+	playerRepo *repository.PlayerRepository
+	predRepo   *repository.PredictionRepository
+	recapRepo  *repository.RecapRepository
+	streaks    *StreakService
+}
+
+func NewRecapService(football *FootballService, llmClient *llm.Client, reviews *InsightReviewService, db *sql.DB) *RecapService {
+	return &RecapService{
+		football:   football,
+		llm:        llmClient.For(llmTaskRecap),
+		reviews:    reviews,
+		matchRepo:  repository.NewMatchRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+		predRepo:   repository.NewPredictionRepository(db),
+		recapRepo:  repository.NewRecapRepository(db),
+		streaks:    NewStreakService(db),
+	}
+}
+
+// GetRecap returns the stored recap for a match external ID, or nil if the
+// recap has not been generated yet.
+func (s *RecapService) GetRecap(matchExternalID int) (*repository.MatchRecap, error) {
+	return s.recapRepo.GetByMatchExternalID(matchExternalID)
+}
+
+// GenerateMissing creates recaps for finished matches from the last week that
+// do not have one yet. It returns the number of recaps generated.
+func (s *RecapService) GenerateMissing(limit int) (int, error) {
+	ids, err := s.recapRepo.ListFinishedWithoutRecap(time.Now().AddDate(0, 0, -7), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, id := range ids {
+		if _, err := s.Generate(id); err != nil {
+			fmt.Printf("Failed to generate recap for match %d: %v\n", id, err)
+			continue
+		}
+		generated++
+	}
+
+	return generated, nil
+}
+
+// Generate builds and stores the recap for a finished match (internal ID).
+func (s *RecapService) Generate(matchID int) (*repository.MatchRecap, error) {
+	match, err := s.matchRepo.GetSummaryByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return nil, fmt.Errorf("match %d not found", matchID)
+	}
+	if match.Status != "FINISHED" || match.HomeScore == nil || match.AwayScore == nil {
+		return nil, fmt.Errorf("match %d has not finished", matchID)
+	}
+
+	recap := &repository.MatchRecap{
+		MatchID:         match.ID,
+		MatchExternalID: match.ExternalID,
+		KeyMoments:      s.keyMoments(match),
+		GeneratedAt:     time.Now().UTC(),
+	}
+
+	// Best-effort player of the match from stored player stats
+	if players, err := s.playerRepo.GetKeyPlayersForMatch(match.ExternalID, 1); err == nil && len(players) > 0 {
+		recap.PlayerOfTheMatch = &players[0]
+	}
+
+	if prediction, err := s.predRepo.GetByMatchID(match.ID); err == nil && prediction != nil {
+		recap.PredictionReview = reviewPrediction(match, prediction)
+	}
+
+	recap.Headline, recap.Summary = templateRecap(match, recap)
+	recap.GeneratedBy = "template"
+
+	if s.llm.Enabled() {
+		if headline, summary, model, err := s.narrate(match, recap); err == nil {
+			recap.Headline = headline
+			recap.Summary = summary
+			recap.GeneratedBy = "llm:" + model
+		} else {
+			fmt.Printf("LLM recap failed for match %d, using template: %v\n", matchID, err)
+		}
+	}
+
+	if err := s.recapRepo.Upsert(recap); err != nil {
+		return nil, err
+	}
+
+	return recap, nil
+}
+
+// keyMoments extracts goals from the provider's match detail. Returns an
+// empty slice if goal data is unavailable.
+func (s *RecapService) keyMoments(match *repository.MatchSummary) []repository.KeyMoment {
+	moments := []repository.KeyMoment{}
+
+	detail, err := s.football.GetMatch(match.ExternalID)
+	if err != nil {
+		return moments
+	}
+
+	for _, goal := range detail.Goals {
+		moment := repository.KeyMoment{
+			Minute:     goal.Minute,
+			InjuryTime: goal.InjuryTime,
+			Type:       "GOAL",
+			Team:       goal.Team.Name,
+			Player:     goal.Scorer.Name,
+			Score:      fmt.Sprintf("%d-%d", goal.Score.Home, goal.Score.Away),
+		}
+		if goal.Type == "OWN" || goal.Type == "PENALTY" {
+			moment.Type = goal.Type + "_GOAL"
+		}
+		if goal.Assist != nil {
+			moment.Assist = goal.Assist.Name
+		}
+		moments = append(moments, moment)
+	}
+
+	return moments
+}
+
+func reviewPrediction(match *repository.MatchSummary, p *repository.PredictionRecord) *repository.PredictionReview {
+	actualWinner := "Draw"
+	switch match.Winner {
+	case "HOME_TEAM":
+		actualWinner = match.HomeTeamName
+	case "AWAY_TEAM":
+		actualWinner = match.AwayTeamName
+	}
+
+	review := &repository.PredictionReview{
+		PredictedWinner: p.PredictedWinner,
+		ActualWinner:    actualWinner,
+		Correct:         p.PredictedWinner == actualWinner,
+		ActualScore:     fmt.Sprintf("%d-%d", *match.HomeScore, *match.AwayScore),
+		ConfidenceScore: p.ConfidenceScore,
+		ModelVersion:    p.ModelVersion,
+		GotRight:        []string{},
+		GotWrong:        []string{},
+	}
+
+	if review.Correct {
+		review.GotRight = append(review.GotRight, fmt.Sprintf("Predicted outcome (%s)", p.PredictedWinner))
+	} else {
+		review.GotWrong = append(review.GotWrong, fmt.Sprintf("Predicted %s but result was %s", p.PredictedWinner, actualWinner))
+	}
+
+	if p.PredictedTeamAGoals != nil && p.PredictedTeamBGoals != nil {
+		home := int(*p.PredictedTeamAGoals + 0.5)
+		away := int(*p.PredictedTeamBGoals + 0.5)
+		review.PredictedScore = fmt.Sprintf("%d-%d", home, away)
+
+		if home == *match.HomeScore && away == *match.AwayScore {
+			review.GotRight = append(review.GotRight, "Exact scoreline")
+		} else {
+			review.GotWrong = append(review.GotWrong, fmt.Sprintf("Scoreline %s vs actual %s", review.PredictedScore, review.ActualScore))
+		}
+
+		predictedTotal := *p.PredictedTeamAGoals + *p.PredictedTeamBGoals
+		actualTotal := float64(*match.HomeScore + *match.AwayScore)
+		if (predictedTotal > 2.5) == (actualTotal > 2.5) {
+			review.GotRight = append(review.GotRight, "Over/under 2.5 goals")
+		} else {
+			review.GotWrong = append(review.GotWrong, "Over/under 2.5 goals")
+		}
+	}
+
+	return review
+}
+
+func templateRecap(match *repository.MatchSummary, recap *repository.MatchRecap) (string, string) {
+	score := fmt.Sprintf("%s %d-%d %s", match.HomeTeamName, *match.HomeScore, *match.AwayScore, match.AwayTeamName)
+
+	var headline string
+	switch match.Winner {
+	case "HOME_TEAM":
+		headline = fmt.Sprintf("%s beat %s %d-%d", match.HomeTeamName, match.AwayTeamName, *match.HomeScore, *match.AwayScore)
+	case "AWAY_TEAM":
+		headline = fmt.Sprintf("%s win %d-%d at %s", match.AwayTeamName, *match.AwayScore, *match.HomeScore, match.HomeTeamName)
+	default:
+		headline = fmt.Sprintf("%s and %s draw %d-%d", match.HomeTeamName, match.AwayTeamName, *match.HomeScore, *match.AwayScore)
+	}
+
+	summary := fmt.Sprintf("Full time: %s.", score)
+	if len(recap.KeyMoments) > 0 {
+		summary += fmt.Sprintf(" The match produced %d goal(s).", len(recap.KeyMoments))
+	}
+	if recap.PlayerOfTheMatch != nil {
+		summary += fmt.Sprintf(" Player of the match: %s.", recap.PlayerOfTheMatch.Name)
+	}
+	if recap.PredictionReview != nil {
+		if recap.PredictionReview.Correct {
+			summary += " The model called the result correctly."
+		} else {
+			summary += fmt.Sprintf(" The model backed %s.", recap.PredictionReview.PredictedWinner)
+		}
+	}
+
+	return headline, summary
+}
+
+// streakFacts describes both teams' streaks and milestones including the
+// match itself. Streaks are optional context, so failures leave them out.
+func (s *RecapService) streakFacts(match *repository.MatchSummary) []string {
+	var descriptions []string
+	for _, teamID := range []int{match.HomeTeamID, match.AwayTeamID} {
+		facts, err := s.streaks.Compute(teamID)
+		if err != nil {
+			continue
+		}
+		for _, f := range facts {
+			descriptions = append(descriptions, f.Description)
+		}
+	}
+	return descriptions
+}
+
+// narrate asks the LLM to write the headline and summary from verified facts
+// gathered for the recap, checking every number it states against them, and
+// passes the result through the quality gate.
+func (s *RecapService) narrate(match *repository.MatchSummary, recap *repository.MatchRecap) (string, string, string, error) {
+	var facts grounding.Facts
+	facts.Add("Final score: %s %d-%d %s", match.HomeTeamName, *match.HomeScore, *match.AwayScore, match.AwayTeamName)
+	if match.Matchday > 0 {
+		facts.Add("Competition: %s, matchday %d", match.CompetitionCode, match.Matchday)
+	} else {
+		facts.Add("Competition: %s", match.CompetitionCode)
+	}
+
+	for _, m := range recap.KeyMoments {
+		minute := strconv.Itoa(m.Minute)
+		if m.InjuryTime != nil {
+			minute += "+" + strconv.Itoa(*m.InjuryTime)
+		}
+		fact := fmt.Sprintf("%s scored for %s in minute %s, making it %s", m.Player, m.Team, minute, m.Score)
+		switch m.Type {
+		case "OWN_GOAL":
+			fact += " (own goal)"
+		case "PENALTY_GOAL":
+			fact += " (penalty)"
+		}
+		if m.Assist != "" {
+			fact += ", assisted by " + m.Assist
+		}
+		facts.Add("%s", fact)
+	}
+
+	if p := recap.PlayerOfTheMatch; p != nil {
+		fact := fmt.Sprintf("Player of the match: %s, %d goals and %d assists", p.Name, p.Goals, p.Assists)
+		if p.Saves != nil && *p.Saves > 0 {
+			fact += fmt.Sprintf(", %d saves", *p.Saves)
+		}
+		if p.Tackles != nil && p.Interceptions != nil && *p.Tackles+*p.Interceptions > 0 {
+			fact += fmt.Sprintf(", %d tackles and %d interceptions", *p.Tackles, *p.Interceptions)
+		}
+		if p.CleanSheet != nil && *p.CleanSheet && (p.Role == apifootball.PositionGoalkeeper || p.Role == apifootball.PositionDefender) {
+			fact += ", kept a clean sheet"
+		}
+		if p.Rating != nil {
+			fact += fmt.Sprintf(", rated %.1f", *p.Rating)
+		}
+		facts.Add("%s", fact)
+	}
+
+	if r := recap.PredictionReview; r != nil {
+		verdict := "wrong"
+		if r.Correct {
+			verdict = "right"
+		}
+		facts.Add("The model predicted %s with %.0f%% confidence and was %s", r.PredictedWinner, r.ConfidenceScore*100, verdict)
+		if r.PredictedScore != "" {
+			facts.Add("The model's predicted score was %s", r.PredictedScore)
+		}
+	}
+
+	for _, description := range s.streakFacts(match) {
+		facts.Add("%s", description)
+	}
+
+	headline, summary, model, err := groundedNarrative(s.llm, recapSystemPrompt, "Match facts:", &facts)
+	if err != nil {
+		return "", "", "", err
+	}
+	if err := s.reviews.Narrative(InsightKindRecap, match, "llm:"+model, headline, summary, &facts); err != nil {
+		return "", "", "", err
+	}
+	return headline, summary, model, nil
+}
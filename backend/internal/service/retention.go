@@ -0,0 +1,180 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Retention policies. Payload policies clear large columns and keep their
+// rows; the others delete rows that can be rebuilt.
+const (
+	RetentionIngestFailurePayloads = "ingest_failure_payloads"
+	RetentionPredictionFeatures    = "prediction_features"
+	RetentionFeatureSnapshots      = "feature_snapshots"
+	RetentionAPIUsage              = "api_usage"
+	RetentionLLMUsage              = "llm_usage"
+	RetentionRawResponses          = "raw_responses"
+)
+
+// Who started a retention run.
+const (
+	RetentionTriggerSchedule = "schedule"
+	RetentionTriggerAdmin    = "admin"
+)
+
+// ErrRetentionRunning is returned when a retention run is already underway.
+var ErrRetentionRunning = errors.New("a retention run is already in progress")
+
+// retentionPolicies describes every policy, in the order they are applied.
+var retentionPolicies = []struct {
+	name        string
+	description string
+	purge       func(r *repository.RetentionRepository, cutoff time.Time) (int, error)
+}{
+	{RetentionIngestFailurePayloads, "clears the payloads of resolved ingest failures",
+		(*repository.RetentionRepository).PurgeIngestFailurePayloads},
+	{RetentionPredictionFeatures, "clears the features stored with predictions",
+		(*repository.RetentionRepository).PurgePredictionFeatures},
+	{RetentionFeatureSnapshots, "deletes point-in-time feature snapshots",
+		(*repository.RetentionRepository).DeleteFeatureSnapshots},
+	{RetentionAPIUsage, "deletes hourly API usage",
+		(*repository.RetentionRepository).DeleteAPIUsage},
+	{RetentionLLMUsage, "deletes the token and cost records of LLM requests",
+		(*repository.RetentionRepository).DeleteLLMUsage},
+	{RetentionRawResponses, "deletes archived provider responses, which can then no longer be replayed",
+		(*repository.RetentionRepository).DeleteRawResponses},
+}
+
+// RetentionPolicy is a policy and how many months of data it keeps; 0 means
+// the policy is disabled.
+type RetentionPolicy struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Months      int    `json:"months"`
+}
+
+// ParseRetentionPolicies parses "policy=months" pairs separated by commas,
+// e.g. "ingest_failure_payloads=6,api_usage=13". Invalid pairs are reported
+// in the error and left out of the result.
+func ParseRetentionPolicies(spec string) (map[string]int, error) {
+	months := make(map[string]int)
+	var invalid []string
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(pair, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 || !isRetentionPolicy(strings.TrimSpace(name)) {
+			invalid = append(invalid, pair)
+			continue
+		}
+		months[strings.TrimSpace(name)] = n
+	}
+
+	if len(invalid) > 0 {
+		return months, fmt.Errorf("invalid retention policies %s", strings.Join(invalid, ", "))
+	}
+	return months, nil
+}
+
+func isRetentionPolicy(name string) bool {
+	for _, p := range retentionPolicies {
+		if p.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RetentionService purges data older than each policy's retention period
+// and records every run.
+type RetentionService struct {
+	repo    *repository.RetentionRepository
+	months  map[string]int
+	running sync.Mutex
+}
+
+// NewRetentionService returns a service applying the given months of
+// retention per policy. Policies left out are disabled.
+func NewRetentionService(db *sql.DB, months map[string]int) *RetentionService {
+	return &RetentionService{repo: repository.NewRetentionRepository(db), months: months}
+}
+
+// Policies returns every policy with its configured retention.
+func (s *RetentionService) Policies() []RetentionPolicy {
+	policies := make([]RetentionPolicy, 0, len(retentionPolicies))
+	for _, p := range retentionPolicies {
+		policies = append(policies, RetentionPolicy{Name: p.name, Description: p.description, Months: s.months[p.name]})
+	}
+	return policies
+}
+
+// Run applies one policy, or every enabled policy when policy is empty, and
+// returns the recorded runs. A policy that fails is recorded with its error
+// and does not stop the others.
+func (s *RetentionService) Run(policy, triggeredBy string) ([]repository.RetentionRun, error) {
+	if policy != "" {
+		if !isRetentionPolicy(policy) {
+			return nil, invalidf("unknown retention policy %q", policy)
+		}
+		if s.months[policy] == 0 {
+			return nil, invalidf("retention policy %q is disabled", policy)
+		}
+	}
+
+	if !s.running.TryLock() {
+		return nil, ErrRetentionRunning
+	}
+	defer s.running.Unlock()
+
+	now := time.Now().UTC()
+	runs := []repository.RetentionRun{}
+	var failed []string
+	for _, p := range retentionPolicies {
+		months := s.months[p.name]
+		if months == 0 || (policy != "" && p.name != policy) {
+			continue
+		}
+
+		run := repository.RetentionRun{
+			Policy:      p.name,
+			Months:      months,
+			Cutoff:      now.AddDate(0, -months, 0),
+			TriggeredBy: triggeredBy,
+			StartedAt:   time.Now().UTC(),
+		}
+		n, err := p.purge(s.repo, run.Cutoff)
+		run.RowsAffected = n
+		run.FinishedAt = time.Now().UTC()
+		if err != nil {
+			msg := err.Error()
+			run.Error = &msg
+			failed = append(failed, p.name)
+		}
+
+		if err := s.repo.RecordRun(&run); err != nil {
+			return runs, err
+		}
+		runs = append(runs, run)
+	}
+
+	if len(failed) > 0 {
+		return runs, fmt.Errorf("retention failed for %s", strings.Join(failed, ", "))
+	}
+	return runs, nil
+}
+
+// Runs returns the most recent runs, latest first.
+func (s *RetentionService) Runs(limit int) ([]repository.RetentionRun, error) {
+	return s.repo.ListRuns(limit)
+}
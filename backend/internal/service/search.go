@@ -0,0 +1,49 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// SearchTypes lists the kinds of entity returned by search.
+var SearchTypes = []string{"team", "player", "competition"}
+
+// SearchService answers free-text searches over stored entities.
+type SearchService struct {
+	repo *repository.SearchRepository
+}
+
+func NewSearchService(db *sql.DB) *SearchService {
+	return &SearchService{repo: repository.NewSearchRepository(db)}
+}
+
+// ParseSearchTypes parses a comma-separated type= filter. An empty filter
+// returns nil, meaning all types.
+func ParseSearchTypes(raw string) ([]string, error) {
+	var kinds []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || containsString(kinds, t) {
+			continue
+		}
+		if !containsString(SearchTypes, t) {
+			return nil, invalidf("unknown type %q (valid: %s)", t, strings.Join(SearchTypes, ", "))
+		}
+		kinds = append(kinds, t)
+	}
+	return kinds, nil
+}
+
+// NormalizeSearchQuery trims the query and drops LIKE wildcards so they are
+// not interpreted by the database.
+func NormalizeSearchQuery(query string) string {
+	return strings.TrimSpace(strings.NewReplacer("%", "", "_", "", "\\", "").Replace(query))
+}
+
+// Search returns ranked matches for a normalized query, limited to kinds
+// when given.
+func (s *SearchService) Search(query string, kinds []string, limit int) ([]repository.SearchResult, error) {
+	return s.repo.Search(query, kinds, limit)
+}
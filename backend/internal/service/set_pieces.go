@@ -0,0 +1,103 @@
+package service
+
+import (
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// PenaltyRecord is penalties scored and missed, and the share converted.
+// Conversion is nil when no misses were reported, since football-data.org
+// only reports the penalties that went in.
+type PenaltyRecord struct {
+	Scored     int      `json:"scored"`
+	Missed     int      `json:"missed"`
+	Conversion *float64 `json:"conversion"`
+}
+
+// PenaltyTaker is a player's penalty record for the team.
+type PenaltyTaker struct {
+	PlayerID   int    `json:"playerId"` // external ID
+	PlayerName string `json:"playerName"`
+	Matches    int    `json:"matches"`
+	Goals      int    `json:"goals"`
+	PenaltyRecord
+}
+
+// SetPieceProfile is a team's set-piece scoring in a season. Neither
+// provider reports which goals came from free kicks or corners, so
+// penalties are the only set pieces counted.
+type SetPieceProfile struct {
+	Team    *repository.TeamInfo `json:"team"`
+	Season  int                  `json:"season"` // start year
+	Matches int                  `json:"matches"`
+	Goals   int                  `json:"goals"`
+	Penalty PenaltyRecord        `json:"penalties"`
+	// PenaltyShare is the share of the team's goals scored from the spot.
+	PenaltyShare *float64       `json:"penaltyShare"`
+	Takers       []PenaltyTaker `json:"takers"`
+}
+
+// SetPieceProfile returns the set-piece profile of a team identified by its
+// football-data.org or internal ID in the season starting in season, or its
+// latest season when season is empty. It returns nil if the team does not
+// exist or has no finished matches in the season.
+func (s *TeamService) SetPieceProfile(id int, season string) (*SetPieceProfile, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+	profile, err := setPieceProfile(s.teamRepo, team.ID, season)
+	if err != nil || profile == nil {
+		return nil, err
+	}
+	profile.Team = team
+	return profile, nil
+}
+
+// setPieceProfile builds the profile of a team by internal ID, without the
+// team itself.
+func setPieceProfile(teamRepo *repository.TeamRepository, teamID int, season string) (*SetPieceProfile, error) {
+	stats, err := teamRepo.GetSetPieces(teamID, season)
+	if err != nil || stats == nil {
+		return nil, err
+	}
+	takers, err := teamRepo.ListPenaltyTakers(teamID, stats.SeasonYear)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &SetPieceProfile{
+		Season:  stats.SeasonYear,
+		Matches: stats.Matches,
+		Goals:   stats.Goals,
+		Penalty: newPenaltyRecord(stats.PenaltiesScored, stats.PenaltiesMissed, stats.MatchesWithMisses > 0),
+		Takers:  make([]PenaltyTaker, 0, len(takers)),
+	}
+	if stats.Goals > 0 {
+		share := float64(stats.PenaltiesScored) / float64(stats.Goals)
+		profile.PenaltyShare = &share
+	}
+	for _, t := range takers {
+		profile.Takers = append(profile.Takers, PenaltyTaker{
+			PlayerID:      t.PlayerExternalID,
+			PlayerName:    t.PlayerName,
+			Matches:       t.Matches,
+			Goals:         t.Goals,
+			PenaltyRecord: newPenaltyRecord(t.PenaltiesScored, t.PenaltiesMissed, stats.MatchesWithMisses > 0),
+		})
+	}
+	return profile, nil
+}
+
+func newPenaltyRecord(scored, missed int, missesKnown bool) PenaltyRecord {
+	r := PenaltyRecord{Scored: scored, Missed: missed}
+	if missesKnown && scored+missed > 0 {
+		conversion := float64(scored) / float64(scored+missed)
+		r.Conversion = &conversion
+	}
+	return r
+}
+
+// RefreshSetPieces recomputes the team and player set-piece read models.
+func (s *TeamService) RefreshSetPieces() error {
+	return s.teamRepo.RefreshSetPieces()
+}
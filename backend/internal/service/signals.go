@@ -0,0 +1,171 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Team signals extracted from news.
+const (
+	SignalInjuryDoubt     = "injury_doubt"
+	SignalSuspension      = "suspension"
+	SignalManagerPressure = "manager_pressure"
+)
+
+const signalSystemPrompt = `You classify football news articles into structured signals.
+Signals are:
+- "injury_doubt": a named player is injured, ill or a doubt for the next match.
+- "suspension": a named player is suspended or banned.
+- "manager_pressure": the team's manager is under pressure, at risk of the sack or has been sacked.
+Only report what the article states; rumour and speculation get low confidence.
+Only use the team and player IDs provided. Injury doubts and suspensions need a player; manager pressure must not have one.
+Reply with a JSON object: {"signals": [{"teamId": 1, "playerId": 2, "signal": "...", "confidence": 0.8, "detail": "..."}]}.
+Confidence is between 0 and 1. Detail is one short sentence. Reply with an empty list if there are no signals.`
+
+// Extraction looks at up to signalBatch articles per run from the last
+// signalExtractionDays, so a backlog is worked through over several runs
+// and stale news is never sent.
+const (
+	signalBatch          = 50
+	signalExtractionDays = 3
+)
+
+// Signals feed a team's features when they are at featureSignalConfidence
+// or above and from articles published in the featureSignalWindow before
+// the snapshot.
+const (
+	featureSignalConfidence = 0.7
+	featureSignalWindow     = 3 * 24 * time.Hour
+)
+
+// TeamSignals is the signals recently extracted from news about a team,
+// newest first.
+type TeamSignals struct {
+	Team    *repository.TeamInfo    `json:"team"`
+	Since   time.Time               `json:"since"`
+	Count   int                     `json:"count"`
+	Signals []repository.TeamSignal `json:"signals"`
+}
+
+// ExtractSignals asks the LLM to classify recent articles into team signals
+// and stores them. It returns the number of signals stored; articles the
+// LLM fails on are left for the next run and reported in the error. It does
+// nothing when no LLM is configured.
+func (s *NewsService) ExtractSignals() (int, error) {
+	if !s.llm.Enabled() {
+		return 0, nil
+	}
+
+	articles, err := s.repo.ListWithoutSignals(time.Now().UTC().AddDate(0, 0, -signalExtractionDays), signalBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	stored := 0
+	for _, a := range articles {
+		signals, err := s.classify(a)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to extract signals from article %d: %w", a.ID, err))
+			continue
+		}
+		if err := s.repo.SaveSignals(a.ID, signals); err != nil {
+			return stored, err
+		}
+		stored += len(signals)
+	}
+	return stored, errors.Join(errs...)
+}
+
+// TeamSignals returns the signals about a team identified by its
+// football-data.org or internal ID from articles of the last days, at
+// minConfidence or above, or nil if the team does not exist.
+func (s *NewsService) TeamSignals(id, days int, minConfidence float64) (*TeamSignals, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		if team, err = s.teamRepo.GetByID(id); err != nil || team == nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+	signals, err := s.repo.ListSignals([]int{team.ID}, since, now, minConfidence)
+	if err != nil {
+		return nil, err
+	}
+	return &TeamSignals{Team: team, Since: since, Count: len(signals), Signals: signals}, nil
+}
+
+// classify sends an article with the teams and players it mentions to the
+// LLM and keeps the signals that name those teams and players consistently.
+func (s *NewsService) classify(a repository.NewsArticle) ([]repository.TeamSignal, error) {
+	facts, err := json.Marshal(map[string]interface{}{
+		"title":   a.Title,
+		"summary": a.Summary,
+		"teams":   a.Teams,
+		"players": a.Players,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var reply struct {
+		Signals []struct {
+			TeamID     int     `json:"teamId"`
+			PlayerID   *int    `json:"playerId"`
+			Signal     string  `json:"signal"`
+			Confidence float64 `json:"confidence"`
+			Detail     string  `json:"detail"`
+		} `json:"signals"`
+	}
+	if _, err := s.llm.CompleteJSON(signalSystemPrompt, "Article:\n"+string(facts), &reply); err != nil {
+		return nil, err
+	}
+
+	teams := make(map[int]bool, len(a.Teams))
+	for _, t := range a.Teams {
+		teams[t.ID] = true
+	}
+	players := make(map[int]int, len(a.Players))
+	for _, p := range a.Players {
+		players[p.ID] = p.TeamID
+	}
+
+	var signals []repository.TeamSignal
+	for _, r := range reply.Signals {
+		if !teams[r.TeamID] || r.Confidence <= 0 {
+			continue
+		}
+		switch r.Signal {
+		case SignalInjuryDoubt, SignalSuspension:
+			if r.PlayerID == nil {
+				continue
+			}
+			if teamID, ok := players[*r.PlayerID]; !ok || teamID != r.TeamID {
+				continue
+			}
+		case SignalManagerPressure:
+			r.PlayerID = nil
+		default:
+			continue
+		}
+		signals = append(signals, repository.TeamSignal{
+			TeamID:     r.TeamID,
+			PlayerID:   r.PlayerID,
+			Signal:     r.Signal,
+			Confidence: math.Round(math.Min(r.Confidence, 1)*1000) / 1000,
+			Detail:     strings.TrimSpace(r.Detail),
+		})
+	}
+	return signals, nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/model"
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// matchEncoderVersion identifies how matches are encoded. Changing the
+// encoder means bumping it, so stored vectors are re-encoded rather than
+// compared with vectors of another shape or scale.
+const matchEncoderVersion = "v1"
+
+// headToHeadMeetings is how many of the teams' latest meetings the encoder
+// looks at.
+const headToHeadMeetings = 5
+
+// Teams without earlier results are encoded as average ones.
+const (
+	averagePointsPerGame = 1.5
+	neutralHeadToHead    = 0.5
+)
+
+// MatchFeatures are the named values a match is encoded from, as they stood
+// at kickoff. Form is points per game over the last five results and
+// GoalDiff goal difference per game over the last ten; EloGap is the home
+// Elo rating less the away one. HeadToHead is the home team's share of the
+// points in the teams' last Meetings, up to five. SeasonProgress is the
+// matchday as a share of the season's, 0 when unknown, and Knockout is set
+// for knockout rounds.
+type MatchFeatures struct {
+	HomeForm       float64 `json:"homeForm"`
+	AwayForm       float64 `json:"awayForm"`
+	EloGap         float64 `json:"eloGap"`
+	HomeGoalDiff   float64 `json:"homeGoalDiff"`
+	AwayGoalDiff   float64 `json:"awayGoalDiff"`
+	HeadToHead     float64 `json:"headToHead"`
+	Meetings       int     `json:"meetings"`
+	SeasonProgress float64 `json:"seasonProgress"`
+	Knockout       bool    `json:"knockout"`
+}
+
+// vector scales the features to comparable ranges, roughly 0 to 1 or -1 to
+// 1, so none dominates the distance between matches.
+func (f MatchFeatures) vector() []float64 {
+	knockout := 0.0
+	if f.Knockout {
+		knockout = 1
+	}
+	return []float64{
+		f.HomeForm / 3,
+		f.AwayForm / 3,
+		clamp(f.EloGap/400, -1, 1),
+		clamp(f.HomeGoalDiff/3, -1, 1),
+		clamp(f.AwayGoalDiff/3, -1, 1),
+		f.HeadToHead,
+		float64(f.Meetings) / headToHeadMeetings,
+		f.SeasonProgress,
+		knockout,
+	}
+}
+
+// SimilarOutcomes counts how the similar matches ended, from the point of
+// view of their home sides.
+type SimilarOutcomes struct {
+	HomeWins int `json:"homeWins"`
+	Draws    int `json:"draws"`
+	AwayWins int `json:"awayWins"`
+}
+
+// SimilarMatches is the past matches most like a match, nearest first.
+type SimilarMatches struct {
+	Match    *repository.MatchSummary  `json:"match"`
+	Features MatchFeatures             `json:"features"`
+	Outcomes SimilarOutcomes           `json:"outcomes"`
+	Count    int                       `json:"count"`
+	Matches  []repository.SimilarMatch `json:"matches"`
+}
+
+// SimilarityService encodes finished matches as feature vectors, stored with
+// pgvector, and finds the past matches nearest any match.
+type SimilarityService struct {
+	repo      *repository.EmbeddingRepository
+	matchRepo *repository.MatchRepository
+	predRepo  *repository.PredictionRepository
+}
+
+func NewSimilarityService(db *sql.DB) *SimilarityService {
+	return &SimilarityService{
+		repo:      repository.NewEmbeddingRepository(db),
+		matchRepo: repository.NewMatchRepository(db),
+		predRepo:  repository.NewPredictionRepository(db),
+	}
+}
+
+// EmbedMissing encodes up to limit finished matches without a vector from
+// the current encoder, oldest first, and returns how many it stored. The
+// results behind them are loaded once for the batch.
+func (s *SimilarityService) EmbedMissing(limit int) (int, error) {
+	inputs, err := s.repo.ListToEmbed(matchEncoderVersion, limit)
+	if err != nil || len(inputs) == 0 {
+		return 0, err
+	}
+
+	results, err := s.predRepo.ListTrainingResultsBetween(inputs[0].UtcDate.Add(-trainingWindow), inputs[len(inputs)-1].UtcDate)
+	if err != nil {
+		return 0, err
+	}
+
+	var ratings map[int]float64
+	var ratedAt time.Time
+	for i, in := range inputs {
+		known := resultsBetween(results, in.UtcDate.Add(-trainingWindow), in.UtcDate)
+		if ratings == nil || !in.UtcDate.Equal(ratedAt) {
+			ratings, ratedAt = model.Elo(modelResults(known)), in.UtcDate
+		}
+
+		features := encodeMatch(in, known, ratings)
+		raw, err := json.Marshal(features)
+		if err != nil {
+			return i, err
+		}
+		if err := s.repo.Save(in.ID, matchEncoderVersion, repository.MatchEmbedding{Vector: features.vector(), Features: raw}); err != nil {
+			return i, err
+		}
+	}
+	return len(inputs), nil
+}
+
+// Similar returns up to limit past matches nearest a match identified by its
+// football-data.org or internal ID, kicked off before it, or nil if the match
+// does not exist. A match without a stored vector, such as a fixture, is
+// encoded from the results known now.
+func (s *SimilarityService) Similar(id, limit int) (*SimilarMatches, error) {
+	match, err := s.matchRepo.GetSummaryByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		if match, err = s.matchRepo.GetSummaryByID(id); err != nil || match == nil {
+			return nil, err
+		}
+	}
+
+	features, err := s.features(match.ID)
+	if err != nil || features == nil {
+		return nil, err
+	}
+
+	similar, err := s.repo.ListSimilar(features.vector(), matchEncoderVersion, match.ID, match.UtcDate, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SimilarMatches{Match: match, Features: *features, Count: len(similar), Matches: similar}
+	for _, m := range similar {
+		switch {
+		case m.HomeScore > m.AwayScore:
+			out.Outcomes.HomeWins++
+		case m.HomeScore < m.AwayScore:
+			out.Outcomes.AwayWins++
+		default:
+			out.Outcomes.Draws++
+		}
+	}
+	return out, nil
+}
+
+// features returns a match's stored features, or encodes them from the
+// results before its kickoff. It returns nil when the match's teams are not
+// known yet.
+func (s *SimilarityService) features(matchID int) (*MatchFeatures, error) {
+	stored, err := s.repo.Get(matchID, matchEncoderVersion)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		var f MatchFeatures
+		if err := json.Unmarshal(stored.Features, &f); err != nil {
+			return nil, err
+		}
+		return &f, nil
+	}
+
+	in, err := s.repo.GetInput(matchID)
+	if err != nil || in == nil {
+		return nil, err
+	}
+	known, err := s.predRepo.ListTrainingResultsBetween(in.UtcDate.Add(-trainingWindow), in.UtcDate)
+	if err != nil {
+		return nil, err
+	}
+	f := encodeMatch(*in, known, model.Elo(modelResults(known)))
+	return &f, nil
+}
+
+// encodeMatch derives a match's features from the results known at its
+// kickoff, ordered oldest first.
+func encodeMatch(in repository.EmbeddingInput, known []repository.TrainingResult, ratings map[int]float64) MatchFeatures {
+	home := teamFeatures(known, ratings, in.HomeTeamID)
+	away := teamFeatures(known, ratings, in.AwayTeamID)
+	f := MatchFeatures{
+		HomeForm:     formPerGame(home),
+		AwayForm:     formPerGame(away),
+		EloGap:       math.Round((home.Elo-away.Elo)*10) / 10,
+		HomeGoalDiff: goalDiffPerGame(home),
+		AwayGoalDiff: goalDiffPerGame(away),
+		HeadToHead:   neutralHeadToHead,
+		Knockout:     isKnockout(in.Stage),
+	}
+
+	points := 0
+	for i := len(known) - 1; i >= 0 && f.Meetings < headToHeadMeetings; i-- {
+		r := known[i]
+		switch {
+		case r.HomeTeamID == in.HomeTeamID && r.AwayTeamID == in.AwayTeamID:
+			points += resultPoints(r.HomeScore, r.AwayScore)
+		case r.HomeTeamID == in.AwayTeamID && r.AwayTeamID == in.HomeTeamID:
+			points += resultPoints(r.AwayScore, r.HomeScore)
+		default:
+			continue
+		}
+		f.Meetings++
+	}
+	if f.Meetings > 0 {
+		f.HeadToHead = math.Round(float64(points)/float64(3*f.Meetings)*100) / 100
+	}
+
+	if in.Matchday > 0 && in.SeasonMatchdays > 0 {
+		f.SeasonProgress = math.Round(math.Min(float64(in.Matchday)/float64(in.SeasonMatchdays), 1)*100) / 100
+	}
+	return f
+}
+
+// formPerGame is a team's points per game over its form matches.
+func formPerGame(f repository.TeamFeatures) float64 {
+	if len(f.Form) == 0 {
+		return averagePointsPerGame
+	}
+	return math.Round(float64(f.FormPoints)/float64(len(f.Form))*100) / 100
+}
+
+// goalDiffPerGame is a team's goal difference per game over its recent
+// results.
+func goalDiffPerGame(f repository.TeamFeatures) float64 {
+	if f.GoalsForAvg == nil || f.GoalsAgainstAvg == nil {
+		return 0
+	}
+	return math.Round((*f.GoalsForAvg-*f.GoalsAgainstAvg)*100) / 100
+}
+
+// isKnockout reports whether a stage is a knockout round.
+func isKnockout(stage string) bool {
+	for _, s := range football.KnockoutStages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// resultsBetween returns the results kicked off in [since, before), which
+// are ordered oldest first.
+func resultsBetween(results []repository.TrainingResult, since, before time.Time) []repository.TrainingResult {
+	from := sort.Search(len(results), func(i int) bool { return !results[i].UtcDate.Before(since) })
+	to := sort.Search(len(results), func(i int) bool { return !results[i].UtcDate.Before(before) })
+	return results[from:to]
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,401 @@
+package service
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Tie-break criteria for computed standings, applied in order after points.
+// A run of consecutive h2h criteria ranks the teams level when the run
+// starts by a mini-table of the matches between them.
+const (
+	TieGoalDifference    = "goal_difference"
+	TieGoalsFor          = "goals_for"
+	TieWins              = "wins"
+	TieAwayGoalsFor      = "away_goals_for"
+	TieAwayWins          = "away_wins"
+	TieH2HPoints         = "h2h_points"
+	TieH2HGoalDifference = "h2h_goal_difference"
+	TieH2HGoalsFor       = "h2h_goals_for"
+	TieH2HAwayGoalsFor   = "h2h_away_goals_for"
+	// TieH2HAllPlayed skips the h2h criteria that follow it, up to the
+	// next other criterion, unless the teams level have played each other
+	// home and away, as La Liga does.
+	TieH2HAllPlayed = "h2h_all_played"
+	// TieH2HReapply, after a run of h2h criteria, ranks the teams still
+	// level by that run again, with a mini-table of the matches between
+	// them only, if the run separated some of the teams it started with,
+	// as UEFA does.
+	TieH2HReapply = "h2h_reapply"
+)
+
+// TieBreakers lists every supported tie-break criterion.
+var TieBreakers = []string{
+	TieGoalDifference,
+	TieGoalsFor,
+	TieWins,
+	TieAwayGoalsFor,
+	TieAwayWins,
+	TieH2HPoints,
+	TieH2HGoalDifference,
+	TieH2HGoalsFor,
+	TieH2HAwayGoalsFor,
+	TieH2HAllPlayed,
+	TieH2HReapply,
+}
+
+// defaultTieBreakers are the tie-break orders of competitions whose rules
+// are not stored. Others rank by goal difference then goals scored.
+var defaultTieBreakers = map[string][]string{
+	"PL":  {TieGoalDifference, TieGoalsFor, TieH2HPoints, TieH2HAwayGoalsFor},
+	"PD":  {TieH2HAllPlayed, TieH2HPoints, TieH2HGoalDifference, TieGoalDifference, TieGoalsFor},
+	"SA":  {TieH2HPoints, TieH2HGoalDifference, TieGoalDifference, TieGoalsFor},
+	"BL1": {TieGoalDifference, TieGoalsFor, TieH2HPoints, TieH2HAwayGoalsFor, TieAwayGoalsFor},
+	"CL":  {TieGoalDifference, TieGoalsFor, TieAwayGoalsFor, TieWins, TieAwayWins},
+	"EC":  {TieH2HPoints, TieH2HGoalDifference, TieH2HGoalsFor, TieH2HReapply, TieGoalDifference, TieGoalsFor, TieWins},
+	"WC":  {TieGoalDifference, TieGoalsFor, TieH2HPoints, TieH2HGoalDifference, TieH2HGoalsFor},
+}
+
+// tiePoints ranks by points; it always comes before the configured criteria.
+const tiePoints = "points"
+
+// StandingsService builds league tables from stored results.
+type StandingsService struct {
+	matchRepo     *repository.MatchRepository
+	standingsRepo *repository.StandingsRepository
+}
+
+func NewStandingsService(db *sql.DB) *StandingsService {
+	return &StandingsService{
+		matchRepo:     repository.NewMatchRepository(db),
+		standingsRepo: repository.NewStandingsRepository(db),
+	}
+}
+
+// ComputedStandings is a table derived from a season's stored results and
+// the rules it was ranked with.
+type ComputedStandings struct {
+	CompetitionCode string                    `json:"competitionCode"`
+	Season          int                       `json:"season"`
+	Rules           repository.StandingsRules `json:"rules"`
+	MatchesCounted  int                       `json:"matchesCounted"`
+	Table           []repository.TableRow     `json:"table"`
+}
+
+// Rules returns the rules configured for a competition, or the defaults of
+// 3/1/0 points with the competition's usual tie-break order.
+func (s *StandingsService) Rules(competitionCode string) (*repository.StandingsRules, error) {
+	return standingsRules(s.standingsRepo, competitionCode)
+}
+
+func standingsRules(repo *repository.StandingsRepository, competitionCode string) (*repository.StandingsRules, error) {
+	competitionCode = strings.ToUpper(competitionCode)
+
+	rules, err := repo.GetRules(competitionCode)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		tieBreakers, ok := defaultTieBreakers[competitionCode]
+		if !ok {
+			tieBreakers = []string{TieGoalDifference, TieGoalsFor}
+		}
+		rules = &repository.StandingsRules{
+			CompetitionCode: competitionCode,
+			PointsWin:       3,
+			PointsDraw:      1,
+			TieBreakers:     tieBreakers,
+		}
+	}
+	return rules, nil
+}
+
+// UpdateRules validates and stores a competition's rules.
+func (s *StandingsService) UpdateRules(rules repository.StandingsRules) error {
+	rules.CompetitionCode = strings.ToUpper(rules.CompetitionCode)
+	if rules.PointsWin < rules.PointsDraw || rules.PointsDraw < rules.PointsLoss {
+		return invalidf("a win must be worth at least a draw and a draw at least a loss")
+	}
+
+	seen := make(map[string]bool)
+	for _, tb := range rules.TieBreakers {
+		if !isTieBreaker(tb) {
+			return invalidf("unknown tie-breaker %q", tb)
+		}
+		if seen[tb] {
+			return invalidf("tie-breaker %q is listed twice", tb)
+		}
+		seen[tb] = true
+	}
+	for i, tb := range rules.TieBreakers {
+		if tb == TieH2HReapply && (i == 0 || !isH2HCriterion(rules.TieBreakers[i-1])) {
+			return invalidf("tie-breaker %q must follow an h2h criterion", tb)
+		}
+	}
+	if rules.TieBreakers == nil {
+		rules.TieBreakers = []string{}
+	}
+
+	return s.standingsRepo.SaveRules(rules)
+}
+
+func isTieBreaker(name string) bool {
+	for _, tb := range TieBreakers {
+		if tb == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Compute derives a competition's table purely from stored finished
+// matches, ranked by the competition's rules, or returns nil if the season
+// is not stored. season is the start year; empty means the most recent
+// season. Teams still level after every criterion are ordered by name.
+func (s *StandingsService) Compute(competitionCode, season string) (*ComputedStandings, error) {
+	competitionCode = strings.ToUpper(competitionCode)
+
+	stored, err := s.matchRepo.ResolveSeason(competitionCode, season)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+
+	rules, err := s.Rules(competitionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.ListSeasonResults(competitionCode, stored.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ComputedStandings{
+		CompetitionCode: competitionCode,
+		Season:          stored.Year,
+		Rules:           *rules,
+		MatchesCounted:  len(matches),
+		Table:           computeTable(matches, *rules),
+	}, nil
+}
+
+// standingTally is a team's record plus the away figures some tie-breakers
+// need.
+type standingTally struct {
+	row          repository.TableRow
+	awayGoalsFor int
+	awayWins     int
+}
+
+// computeTable tallies results and ranks the teams.
+func computeTable(matches []repository.MatchSummary, rules repository.StandingsRules) []repository.TableRow {
+	return rankTallies(tallyResults(matches, rules, nil), matches, rules)
+}
+
+// rankTallies ranks tallied teams by points and the rules' tie-breakers,
+// which may look back at matches, and assigns positions.
+func rankTallies(tallies map[int]*standingTally, matches []repository.MatchSummary, rules repository.StandingsRules) []repository.TableRow {
+	teams := make([]*standingTally, 0, len(tallies))
+	for _, t := range tallies {
+		teams = append(teams, t)
+	}
+
+	r := &tableRanker{matches: matches, rules: rules, criteria: append([]string{tiePoints}, rules.TieBreakers...)}
+	ranked := r.rank(teams, 0, nil)
+
+	table := make([]repository.TableRow, 0, len(ranked))
+	for i, t := range ranked {
+		row := t.row
+		row.Position = i + 1
+		table = append(table, row)
+	}
+	return table
+}
+
+// tallyResults aggregates matches per team. When only is set, matches are
+// counted only if both teams are in it, which gives the head-to-head
+// mini-table between those teams.
+func tallyResults(matches []repository.MatchSummary, rules repository.StandingsRules, only map[int]bool) map[int]*standingTally {
+	tallies := make(map[int]*standingTally)
+	for _, m := range matches {
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		if only != nil && (!only[m.HomeTeamID] || !only[m.AwayTeamID]) {
+			continue
+		}
+		addResult(tallies, m, rules)
+	}
+	return tallies
+}
+
+// teamTally returns a team's tally, adding an empty one if it has none.
+func teamTally(tallies map[int]*standingTally, id, externalID int, name string) *standingTally {
+	t, ok := tallies[id]
+	if !ok {
+		t = &standingTally{row: repository.TableRow{TeamID: id, TeamExternalID: externalID, TeamName: name}}
+		tallies[id] = t
+	}
+	return t
+}
+
+// addResult counts a scored match in the tallies of both its teams.
+func addResult(tallies map[int]*standingTally, m repository.MatchSummary, rules repository.StandingsRules) {
+	record := func(t *standingTally, gf, ga int) {
+		t.row.Played++
+		t.row.GoalsFor += gf
+		t.row.GoalsAgainst += ga
+		t.row.GoalDifference = t.row.GoalsFor - t.row.GoalsAgainst
+		switch {
+		case gf > ga:
+			t.row.Won++
+			t.row.Points += rules.PointsWin
+		case gf == ga:
+			t.row.Draw++
+			t.row.Points += rules.PointsDraw
+		default:
+			t.row.Lost++
+			t.row.Points += rules.PointsLoss
+		}
+	}
+
+	home := teamTally(tallies, m.HomeTeamID, m.HomeTeamExternalID, m.HomeTeamName)
+	away := teamTally(tallies, m.AwayTeamID, m.AwayTeamExternalID, m.AwayTeamName)
+	record(home, *m.HomeScore, *m.AwayScore)
+	record(away, *m.AwayScore, *m.HomeScore)
+	away.awayGoalsFor += *m.AwayScore
+	if *m.AwayScore > *m.HomeScore {
+		away.awayWins++
+	}
+}
+
+// tableRanker orders teams criterion by criterion, only moving on to the
+// next criterion within groups still level on the previous ones.
+type tableRanker struct {
+	matches  []repository.MatchSummary
+	rules    repository.StandingsRules
+	criteria []string
+}
+
+// rank orders group from criteria[pos] on. miniTable is the group the
+// current run of h2h criteria started with, nil outside a run.
+func (r *tableRanker) rank(group []*standingTally, pos int, miniTable []*standingTally) []*standingTally {
+	if len(group) < 2 {
+		return group
+	}
+	if pos == len(r.criteria) {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].row.TeamName < group[j].row.TeamName
+		})
+		return group
+	}
+
+	criterion := r.criteria[pos]
+	switch {
+	case criterion == TieH2HAllPlayed:
+		if r.allPlayed(group) {
+			return r.rank(group, pos+1, miniTable)
+		}
+		next := pos + 1
+		for next < len(r.criteria) && (isH2HCriterion(r.criteria[next]) || r.criteria[next] == TieH2HReapply) {
+			next++
+		}
+		return r.rank(group, next, nil)
+	case criterion == TieH2HReapply:
+		if miniTable != nil && len(group) < len(miniTable) {
+			start := pos
+			for start > 0 && isH2HCriterion(r.criteria[start-1]) {
+				start--
+			}
+			return r.rank(group, start, nil)
+		}
+		return r.rank(group, pos+1, nil)
+	case isH2HCriterion(criterion):
+		if miniTable == nil {
+			miniTable = group
+		}
+	default:
+		miniTable = nil
+	}
+
+	key := r.key(criterion, group, miniTable)
+	sort.SliceStable(group, func(i, j int) bool {
+		return key[group[i].row.TeamID] > key[group[j].row.TeamID]
+	})
+
+	ranked := make([]*standingTally, 0, len(group))
+	for start := 0; start < len(group); {
+		end := start + 1
+		for end < len(group) && key[group[end].row.TeamID] == key[group[start].row.TeamID] {
+			end++
+		}
+		ranked = append(ranked, r.rank(group[start:end], pos+1, miniTable)...)
+		start = end
+	}
+	return ranked
+}
+
+// allPlayed reports whether every team of group has played every other at
+// home.
+func (r *tableRanker) allPlayed(group []*standingTally) bool {
+	in := make(map[int]bool, len(group))
+	for _, t := range group {
+		in[t.row.TeamID] = true
+	}
+	played := make(map[[2]int]bool)
+	for _, m := range r.matches {
+		if m.HomeScore != nil && m.AwayScore != nil && in[m.HomeTeamID] && in[m.AwayTeamID] {
+			played[[2]int{m.HomeTeamID, m.AwayTeamID}] = true
+		}
+	}
+	return len(played) == len(group)*(len(group)-1)
+}
+
+func isH2HCriterion(name string) bool {
+	return strings.HasPrefix(name, "h2h_") && name != TieH2HAllPlayed && name != TieH2HReapply
+}
+
+// key returns each team's value for a criterion; higher ranks first. h2h
+// criteria count the matches between the teams of miniTable.
+func (r *tableRanker) key(criterion string, group, miniTable []*standingTally) map[int]int {
+	source := make(map[int]*standingTally, len(group))
+	for _, t := range group {
+		source[t.row.TeamID] = t
+	}
+	if isH2HCriterion(criterion) {
+		only := make(map[int]bool, len(miniTable))
+		for _, t := range miniTable {
+			only[t.row.TeamID] = true
+		}
+		source = tallyResults(r.matches, r.rules, only)
+	}
+
+	key := make(map[int]int, len(group))
+	for _, t := range group {
+		s, ok := source[t.row.TeamID]
+		if !ok {
+			continue // no head-to-head matches played yet
+		}
+		switch criterion {
+		case tiePoints, TieH2HPoints:
+			key[t.row.TeamID] = s.row.Points
+		case TieGoalDifference, TieH2HGoalDifference:
+			key[t.row.TeamID] = s.row.GoalDifference
+		case TieGoalsFor, TieH2HGoalsFor:
+			key[t.row.TeamID] = s.row.GoalsFor
+		case TieWins:
+			key[t.row.TeamID] = s.row.Won
+		case TieAwayGoalsFor, TieH2HAwayGoalsFor:
+			key[t.row.TeamID] = s.awayGoalsFor
+		case TieAwayWins:
+			key[t.row.TeamID] = s.awayWins
+		}
+	}
+	return key
+}
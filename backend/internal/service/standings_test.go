@@ -0,0 +1,122 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// played is a finished match between teams named by one letter, whose
+// internal and external IDs are the letter's place in the alphabet.
+func played(home, away string, homeGoals, awayGoals int) repository.MatchSummary {
+	homeID, awayID := int(home[0]-'A'+1), int(away[0]-'A'+1)
+	return repository.MatchSummary{
+		HomeTeamID:         homeID,
+		HomeTeamExternalID: homeID,
+		HomeTeamName:       home,
+		AwayTeamID:         awayID,
+		AwayTeamExternalID: awayID,
+		AwayTeamName:       away,
+		HomeScore:          &homeGoals,
+		AwayScore:          &awayGoals,
+	}
+}
+
+func TestRankTallies(t *testing.T) {
+	tests := []struct {
+		name        string
+		competition string
+		matches     []repository.MatchSummary
+		want        []string
+	}{
+		{
+			// A and B have 6 points each and B the better goal difference,
+			// but A won the meetings on goal difference
+			name:        "La Liga head-to-head once both meetings are played",
+			competition: "PD",
+			matches: []repository.MatchSummary{
+				played("A", "B", 2, 0),
+				played("B", "A", 1, 0),
+				played("B", "C", 5, 0),
+				played("A", "C", 1, 0),
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			// A beat B in their only meeting, so far, which La Liga does
+			// not count until the return match
+			name:        "La Liga goal difference while a meeting is to play",
+			competition: "PD",
+			matches: []repository.MatchSummary{
+				played("A", "B", 1, 0),
+				played("B", "C", 3, 0),
+				played("C", "D", 0, 0),
+			},
+			want: []string{"B", "A", "D", "C"},
+		},
+		{
+			// A, B and C beat each other in turn with no goal difference
+			// between them; A scored most in those matches and B beat C in
+			// the mini-table of the two left, though C has the better
+			// goal difference overall
+			name:        "UEFA reapplies head-to-head to the teams still level",
+			competition: "EC",
+			matches: []repository.MatchSummary{
+				played("A", "B", 3, 2),
+				played("B", "C", 1, 0),
+				played("C", "A", 3, 2),
+				played("A", "D", 1, 0),
+				played("B", "D", 1, 0),
+				played("C", "D", 5, 0),
+			},
+			want: []string{"A", "B", "C", "D"},
+		},
+		{
+			// B beat A but has the worse goal difference
+			name:        "Premier League goal difference before head-to-head",
+			competition: "PL",
+			matches: []repository.MatchSummary{
+				played("B", "A", 1, 0),
+				played("A", "C", 5, 0),
+				played("C", "B", 1, 0),
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			// A and B are level on goal difference and goals, and B won
+			// their meeting
+			name:        "Premier League head-to-head once goals are level",
+			competition: "PL",
+			matches: []repository.MatchSummary{
+				played("B", "A", 2, 1),
+				played("A", "C", 2, 1),
+				played("B", "D", 1, 2),
+			},
+			want: []string{"D", "B", "A", "C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := repository.StandingsRules{
+				CompetitionCode: tt.competition,
+				PointsWin:       3,
+				PointsDraw:      1,
+				TieBreakers:     defaultTieBreakers[tt.competition],
+			}
+			table := rankTallies(tallyResults(tt.matches, rules, nil), tt.matches, rules)
+
+			got := make([]string, 0, len(table))
+			for i, row := range table {
+				if row.Position != i+1 {
+					t.Errorf("%s at position %d, want %d", row.TeamName, row.Position, i+1)
+				}
+				got = append(got, row.TeamName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// Insight fact kinds. Runs count the most recent consecutive matches;
+// milestones are totals over every stored match.
+const (
+	FactWinningRun                 = "winning_run"
+	FactUnbeatenRun                = "unbeaten_run"
+	FactLosingRun                  = "losing_run"
+	FactWinlessRun                 = "winless_run"
+	FactCleanSheets                = "clean_sheets"
+	FactScoringRun                 = "scoring_run"
+	FactGoalDrought                = "goal_drought"
+	FactPlayerScoringRun           = "player_scoring_run"
+	FactGoalsMilestone             = "goals_milestone"
+	FactWinsMilestone              = "wins_milestone"
+	FactMatchesMilestone           = "matches_milestone"
+	FactPlayerGoalsMilestone       = "player_goals_milestone"
+	FactPlayerAppearancesMilestone = "player_appearances_milestone"
+)
+
+// Shortest runs worth reporting. Unbeaten and winless runs must also be
+// longer than the winning or losing run they contain.
+const (
+	minResultRun  = 3
+	minLongRun    = 5
+	minPlayerRun  = 3
+	factsMaxAge   = 24 * time.Hour
+	recentResults = 100
+)
+
+// StreakService computes team and player streaks and upcoming milestones
+// from stored results.
+type StreakService struct {
+	teamRepo  *repository.TeamRepository
+	matchRepo *repository.MatchRepository
+	repo      *repository.InsightsRepository
+}
+
+func NewStreakService(db *sql.DB) *StreakService {
+	return &StreakService{
+		teamRepo:  repository.NewTeamRepository(db),
+		matchRepo: repository.NewMatchRepository(db),
+		repo:      repository.NewInsightsRepository(db),
+	}
+}
+
+// TeamStreaks is a team's stored streaks and milestones, its players'
+// included.
+type TeamStreaks struct {
+	Team  *repository.TeamInfo      `json:"team"`
+	Facts []repository.InsightsFact `json:"facts"`
+}
+
+// GetTeamStreaks returns the stored facts of a team identified by its
+// football-data.org or internal ID, or nil if the team does not exist.
+func (s *StreakService) GetTeamStreaks(id int) (*TeamStreaks, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		if team, err = s.teamRepo.GetByID(id); err != nil {
+			return nil, err
+		}
+	}
+	if team == nil {
+		return nil, nil
+	}
+
+	facts, err := s.repo.ListTeamFacts(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &TeamStreaks{Team: team, Facts: facts}, nil
+}
+
+// TeamFacts returns the stored facts of a team by internal ID.
+func (s *StreakService) TeamFacts(teamID int) ([]repository.InsightsFact, error) {
+	return s.repo.ListTeamFacts(teamID)
+}
+
+// Refresh recomputes the facts of up to limit teams that have played since
+// their facts were computed, or whose facts are a day old, and returns how
+// many teams it updated. Failures are skipped and reported together.
+func (s *StreakService) Refresh(limit int) (int, error) {
+	ids, err := s.repo.ListTeamsDue(time.Now().Add(-factsMaxAge), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	var errs []error
+	for _, id := range ids {
+		facts, err := s.Compute(id)
+		if err == nil {
+			err = s.repo.ReplaceTeamFacts(id, facts)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("team %d: %w", id, err))
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, errors.Join(errs...)
+}
+
+// Compute derives the current facts of a team, by internal ID, and its
+// players without storing them.
+func (s *StreakService) Compute(teamID int) ([]repository.InsightsFact, error) {
+	team, err := s.teamRepo.GetByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("team %d not found", teamID)
+	}
+
+	results, err := s.matchRepo.ListTeamMatches(repository.TeamMatchFilter{
+		TeamID: teamID,
+		Status: "FINISHED",
+		Limit:  recentResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.repo.TeamTotals(teamID)
+	if err != nil {
+		return nil, err
+	}
+	appearances, err := s.repo.ListPlayerAppearances(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := teamFacts(team, results, totals)
+	return append(facts, playerFacts(teamID, appearances)...), nil
+}
+
+// teamResult is a finished match from one team's point of view.
+type teamResult struct {
+	matchID  int
+	date     time.Time
+	scored   int
+	conceded int
+}
+
+// teamFacts finds a team's current runs, from results ordered most recent
+// first, and the milestones its totals are about to reach.
+func teamFacts(team *repository.TeamInfo, results []repository.MatchSummary, totals *repository.TeamTotals) []repository.InsightsFact {
+	var played []teamResult
+	for _, m := range results {
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		r := teamResult{matchID: m.ID, date: m.UtcDate, scored: *m.HomeScore, conceded: *m.AwayScore}
+		if m.AwayTeamID == team.ID {
+			r.scored, r.conceded = r.conceded, r.scored
+		}
+		played = append(played, r)
+	}
+
+	var facts []repository.InsightsFact
+	add := func(kind string, n int, since *time.Time, description string) {
+		facts = append(facts, repository.InsightsFact{
+			TeamID:      team.ID,
+			Kind:        kind,
+			Value:       n,
+			Description: description,
+			SinceDate:   since,
+			LastMatchID: &played[0].matchID,
+		})
+	}
+
+	won, wonSince := resultRun(played, func(r teamResult) bool { return r.scored > r.conceded })
+	unbeaten, unbeatenSince := resultRun(played, func(r teamResult) bool { return r.scored >= r.conceded })
+	lost, lostSince := resultRun(played, func(r teamResult) bool { return r.scored < r.conceded })
+	winless, winlessSince := resultRun(played, func(r teamResult) bool { return r.scored <= r.conceded })
+	cleanSheets, cleanSince := resultRun(played, func(r teamResult) bool { return r.conceded == 0 })
+	scoring, scoringSince := resultRun(played, func(r teamResult) bool { return r.scored > 0 })
+	drought, droughtSince := resultRun(played, func(r teamResult) bool { return r.scored == 0 })
+
+	if won >= minResultRun {
+		add(FactWinningRun, won, wonSince, fmt.Sprintf("%s have won their last %d matches", team.Name, won))
+	}
+	if unbeaten >= minLongRun && unbeaten > won {
+		add(FactUnbeatenRun, unbeaten, unbeatenSince, fmt.Sprintf("%s are unbeaten in %d matches", team.Name, unbeaten))
+	}
+	if lost >= minResultRun {
+		add(FactLosingRun, lost, lostSince, fmt.Sprintf("%s have lost their last %d matches", team.Name, lost))
+	}
+	if winless >= minLongRun && winless > lost {
+		add(FactWinlessRun, winless, winlessSince, fmt.Sprintf("%s are without a win in %d matches", team.Name, winless))
+	}
+	if cleanSheets >= minResultRun {
+		add(FactCleanSheets, cleanSheets, cleanSince, fmt.Sprintf("%s have kept %d clean sheets in a row", team.Name, cleanSheets))
+	}
+	if scoring >= minLongRun {
+		add(FactScoringRun, scoring, scoringSince, fmt.Sprintf("%s have scored in %d consecutive matches", team.Name, scoring))
+	}
+	if drought >= minResultRun {
+		add(FactGoalDrought, drought, droughtSince, fmt.Sprintf("%s have failed to score in %d matches", team.Name, drought))
+	}
+
+	milestone := func(kind string, total, step, within int, noun string) {
+		target := (total/step + 1) * step
+		if target-total > within {
+			return
+		}
+		facts = append(facts, repository.InsightsFact{
+			TeamID: team.ID,
+			Kind:   kind,
+			Value:  total,
+			Target: &target,
+			Description: fmt.Sprintf("%s are %s away from %d %s on record",
+				team.Name, countNoun(target-total, noun), target, plural(noun)),
+		})
+	}
+	if totals != nil && totals.Matches > 0 {
+		milestone(FactGoalsMilestone, totals.Goals, 100, 5, "goal")
+		milestone(FactWinsMilestone, totals.Wins, 50, 2, "win")
+		milestone(FactMatchesMilestone, totals.Matches, 100, 1, "match")
+	}
+	return facts
+}
+
+// resultRun counts the most recent consecutive results matching ok and
+// returns the run's length and the kickoff of its first match.
+func resultRun(results []teamResult, ok func(teamResult) bool) (int, *time.Time) {
+	n := 0
+	for n < len(results) && ok(results[n]) {
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	since := results[n-1].date
+	return n, &since
+}
+
+// playerFacts finds players' scoring runs and the goal and appearance
+// milestones they are about to reach, from appearances grouped by player
+// and ordered most recent first.
+func playerFacts(teamID int, appearances []repository.PlayerAppearance) []repository.InsightsFact {
+	var facts []repository.InsightsFact
+	for start := 0; start < len(appearances); {
+		end := start
+		for end < len(appearances) && appearances[end].PlayerID == appearances[start].PlayerID {
+			end++
+		}
+		player := appearances[start:end]
+		start = end
+
+		playerID := player[0].PlayerID
+		name := player[0].PlayerName
+		lastMatchID := player[0].MatchID
+
+		run, goals := 0, 0
+		for _, a := range player {
+			goals += a.Goals
+		}
+		for run < len(player) && player[run].Goals > 0 {
+			run++
+		}
+		if run >= minPlayerRun {
+			since := player[run-1].UtcDate
+			facts = append(facts, repository.InsightsFact{
+				TeamID:      teamID,
+				PlayerID:    &playerID,
+				PlayerName:  name,
+				Kind:        FactPlayerScoringRun,
+				Value:       run,
+				Description: fmt.Sprintf("%s has scored in %d consecutive appearances", name, run),
+				SinceDate:   &since,
+				LastMatchID: &lastMatchID,
+			})
+		}
+
+		if target := playerGoalTarget(goals); goals > 0 && target-goals <= 2 {
+			facts = append(facts, repository.InsightsFact{
+				TeamID:      teamID,
+				PlayerID:    &playerID,
+				PlayerName:  name,
+				Kind:        FactPlayerGoalsMilestone,
+				Value:       goals,
+				Target:      &target,
+				Description: fmt.Sprintf("%s is %s away from %d goals on record", name, countNoun(target-goals, "goal"), target),
+			})
+		}
+
+		apps := len(player)
+		if target := (apps/50 + 1) * 50; target-apps <= 2 {
+			facts = append(facts, repository.InsightsFact{
+				TeamID:      teamID,
+				PlayerID:    &playerID,
+				PlayerName:  name,
+				Kind:        FactPlayerAppearancesMilestone,
+				Value:       apps,
+				Target:      &target,
+				Description: fmt.Sprintf("%s is %s away from %d appearances on record", name, countNoun(target-apps, "appearance"), target),
+			})
+		}
+	}
+	return facts
+}
+
+// playerGoalTarget returns the next goal milestone: 10, 25, 50 and then
+// every 50.
+func playerGoalTarget(goals int) int {
+	for _, target := range []int{10, 25, 50} {
+		if goals < target {
+			return target
+		}
+	}
+	return (goals/50 + 1) * 50
+}
+
+// countNoun formats a count with the singular or plural noun.
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %s", n, plural(noun))
+}
+
+func plural(noun string) string {
+	if noun == "match" {
+		return "matches"
+	}
+	return noun + "s"
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// BanThreshold is a yellow-card count that earns a ban of Matches matches
+// when reached by matchday Until, or at any time when Until is 0.
+type BanThreshold struct {
+	Yellows int `json:"yellows"`
+	Until   int `json:"until,omitempty"`
+	Matches int `json:"matches"`
+}
+
+// SuspensionRule is a competition's yellow-card accumulation rule: a ban at
+// each threshold, then a one-match ban every Every yellows after the last.
+// Sendings-off are banned on their own and do not add to the count.
+type SuspensionRule struct {
+	Thresholds []BanThreshold `json:"thresholds"`
+	Every      int            `json:"every,omitempty"`
+}
+
+// suspensionRules are the accumulation rules of each competition, by
+// football-data.org code, as they stand this season. Competitions that ban
+// on anything but a yellow count, such as Ligue 1's points, are left out.
+// UEFA and tournament counts are wiped after the quarter-finals, which is
+// not modelled.
+var suspensionRules = map[string]SuspensionRule{
+	"PL":  {Thresholds: []BanThreshold{{5, 19, 1}, {10, 32, 2}, {15, 0, 3}}},
+	"PD":  {Every: 5},
+	"BL1": {Every: 5},
+	"SA":  {Thresholds: []BanThreshold{{5, 0, 1}, {10, 0, 1}, {14, 0, 1}, {17, 0, 1}}, Every: 1},
+	"CL":  {Thresholds: []BanThreshold{{3, 0, 1}}, Every: 2},
+	"WC":  {Thresholds: []BanThreshold{{2, 0, 1}}, Every: 2},
+	"EC":  {Thresholds: []BanThreshold{{2, 0, 1}}, Every: 2},
+}
+
+// next returns the next ban a player on yellows yellows after matchday can
+// still pick up, or nil if there is none.
+func (r SuspensionRule) next(yellows, matchday int) *BanThreshold {
+	last := 0
+	for _, t := range r.Thresholds {
+		last = t.Yellows
+		if t.Yellows > yellows && (t.Until == 0 || matchday < t.Until) {
+			return &t
+		}
+	}
+	if r.Every <= 0 {
+		return nil
+	}
+	next := last + r.Every
+	if yellows >= last {
+		next = last + r.Every*((yellows-last)/r.Every+1)
+	}
+	return &BanThreshold{Yellows: next, Matches: 1}
+}
+
+// SuspensionRisk is a player's bookings in a competition season and the ban
+// one more booking would earn.
+type SuspensionRisk struct {
+	PlayerID        int    `json:"playerId"` // external ID
+	PlayerName      string `json:"playerName"`
+	CompetitionCode string `json:"competitionCode"`
+	Season          string `json:"season"`
+	Matchday        int    `json:"matchday"`
+	YellowCards     int    `json:"yellowCards"`
+	SendingsOff     int    `json:"sendingsOff"`
+	BanAt           int    `json:"banAt"`
+	BanMatches      int    `json:"banMatches"`
+}
+
+// TeamSuspensionRisk lists a team's players one booking from a ban.
+type TeamSuspensionRisk struct {
+	Team    *repository.TeamInfo `json:"team"`
+	Players []SuspensionRisk     `json:"players"`
+}
+
+// SuspensionRisk returns the players of a team identified by its
+// football-data.org or internal ID who are one booking from a ban in a
+// competition with a known accumulation rule, or nil if the team does not
+// exist. Counts come from stored card events.
+func (s *TeamService) SuspensionRisk(id int) (*TeamSuspensionRisk, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	bookings, err := s.playerRepo.ListTeamBookings(team.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &TeamSuspensionRisk{Team: team, Players: atRiskOfBan(bookings, "")}, nil
+}
+
+// atRiskOfBan picks the players one booking from a ban, in competitionCode
+// only unless it is empty.
+func atRiskOfBan(bookings []repository.PlayerBookings, competitionCode string) []SuspensionRisk {
+	risks := []SuspensionRisk{}
+	for _, b := range bookings {
+		if competitionCode != "" && b.CompetitionCode != competitionCode {
+			continue
+		}
+		rule, ok := suspensionRules[b.CompetitionCode]
+		if !ok {
+			continue
+		}
+		ban := rule.next(b.YellowCards, b.Matchday)
+		if ban == nil || ban.Yellows-b.YellowCards != 1 {
+			continue
+		}
+		risks = append(risks, SuspensionRisk{
+			PlayerID:        b.PlayerExternalID,
+			PlayerName:      b.PlayerName,
+			CompetitionCode: b.CompetitionCode,
+			Season:          b.Season,
+			Matchday:        b.Matchday,
+			YellowCards:     b.YellowCards,
+			SendingsOff:     b.SecondYellows + b.RedCards,
+			BanAt:           ban.Yellows,
+			BanMatches:      ban.Matches,
+		})
+	}
+	return risks
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// TeamService serves team-level statistics computed from stored matches.
+type TeamService struct {
+	teamRepo   *repository.TeamRepository
+	matchRepo  *repository.MatchRepository
+	coachRepo  *repository.CoachRepository
+	playerRepo *repository.PlayerRepository
+	predRepo   *repository.PredictionRepository
+}
+
+func NewTeamService(db *sql.DB) *TeamService {
+	return &TeamService{
+		teamRepo:   repository.NewTeamRepository(db),
+		matchRepo:  repository.NewMatchRepository(db),
+		coachRepo:  repository.NewCoachRepository(db),
+		playerRepo: repository.NewPlayerRepository(db),
+		predRepo:   repository.NewPredictionRepository(db),
+	}
+}
+
+// TeamSeasonStats summarises a team's season from its finished matches.
+// The conceded-per-half averages are nil until match events have been
+// ingested for at least one of those matches. HalfTimeFullTime covers the
+// matches with a stored half-time score.
+type TeamSeasonStats struct {
+	Team                  *repository.TeamInfo     `json:"team"`
+	Season                string                   `json:"season"`
+	CompetitionCode       string                   `json:"competitionCode,omitempty"`
+	Overall               repository.RecordSplit   `json:"overall"`
+	Home                  repository.RecordSplit   `json:"home"`
+	Away                  repository.RecordSplit   `json:"away"`
+	MatchesWithEvents     int                      `json:"matchesWithEvents"`
+	AvgConcededFirstHalf  *float64                 `json:"avgConcededFirstHalf"`
+	AvgConcededSecondHalf *float64                 `json:"avgConcededSecondHalf"`
+	HalfTimeFullTime      *HalfTimeFullTime        `json:"halfTimeFullTime"`
+	BiggestWin            *repository.MatchSummary `json:"biggestWin"`
+	BiggestLoss           *repository.MatchSummary `json:"biggestLoss"`
+}
+
+// GetSeasonStats returns season statistics for a team identified by its
+// football-data.org or internal ID, or nil if the team does not exist.
+// season is the season start year; an empty competitionCode covers all
+// competitions.
+func (s *TeamService) GetSeasonStats(id int, season, competitionCode string) (*TeamSeasonStats, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	competitionCode = strings.ToUpper(competitionCode)
+
+	record, err := s.matchRepo.GetTeamSeasonRecord(team.ID, season, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &TeamSeasonStats{
+		Team:            team,
+		Season:          season,
+		CompetitionCode: competitionCode,
+		Overall:         record.Home.Add(record.Away),
+		Home:            record.Home,
+		Away:            record.Away,
+	}
+
+	halves, err := s.matchRepo.GetTeamConcededByHalf(team.ID, season, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+	stats.MatchesWithEvents = halves.Matches
+	if halves.Matches > 0 {
+		first := float64(halves.FirstHalf) / float64(halves.Matches)
+		second := float64(halves.SecondHalf) / float64(halves.Matches)
+		stats.AvgConcededFirstHalf = &first
+		stats.AvgConcededSecondHalf = &second
+	}
+
+	htft, err := s.matchRepo.ListTeamHalfTimeFullTime(team.ID, season, competitionCode)
+	if err != nil {
+		return nil, err
+	}
+	stats.HalfTimeFullTime = newHalfTimeFullTime()
+	for _, c := range htft {
+		stats.HalfTimeFullTime.add(c.HalfTime, c.FullTime, c.Matches)
+	}
+
+	matches, err := s.matchRepo.ListTeamMatches(repository.TeamMatchFilter{
+		TeamID:          team.ID,
+		Season:          season,
+		CompetitionCode: competitionCode,
+		Status:          "FINISHED",
+		Limit:           200,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats.BiggestWin, stats.BiggestLoss = biggestResults(team.ID, matches)
+
+	return stats, nil
+}
+
+// TeamFixtures is a team's fixture list across every stored competition.
+type TeamFixtures struct {
+	Team    *repository.TeamInfo      `json:"team"`
+	Matches []repository.MatchSummary `json:"matches"`
+}
+
+// ListMatches returns a team's matches in all competitions, league, cup and
+// European, or nil if the team does not exist. The team is identified by its
+// football-data.org or internal ID and filter.TeamID is ignored. Scheduled
+// fixtures are listed soonest first, anything else most recent first.
+func (s *TeamService) ListMatches(id int, filter repository.TeamMatchFilter) (*TeamFixtures, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	filter.TeamID = team.ID
+	filter.CompetitionCode = strings.ToUpper(filter.CompetitionCode)
+	filter.OldestFirst = filter.Status == "SCHEDULED" || filter.Status == "TIMED"
+
+	matches, err := s.matchRepo.ListTeamMatches(filter)
+	if err != nil {
+		return nil, err
+	}
+	if matches == nil {
+		matches = []repository.MatchSummary{}
+	}
+
+	return &TeamFixtures{Team: team, Matches: matches}, nil
+}
+
+// TeamCoaches is a team's coaching spells, latest first.
+type TeamCoaches struct {
+	Team   *repository.TeamInfo    `json:"team"`
+	Spells []repository.CoachSpell `json:"spells"`
+}
+
+// Coaches returns the coaching spells of a team identified by its
+// football-data.org or internal ID, or nil if the team does not exist.
+// Spells come from stored lineups, so they only cover matches whose lineup
+// named a coach.
+func (s *TeamService) Coaches(id int) (*TeamCoaches, error) {
+	team, err := s.resolveTeam(id)
+	if err != nil || team == nil {
+		return nil, err
+	}
+
+	spells, err := s.coachRepo.ListSpells(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &TeamCoaches{Team: team, Spells: spells}, nil
+}
+
+// resolveTeam finds a team by its football-data.org ID, then by internal ID,
+// returning nil if neither exists.
+func (s *TeamService) resolveTeam(id int) (*repository.TeamInfo, error) {
+	team, err := s.teamRepo.GetByExternalID(id)
+	if err != nil || team != nil {
+		return team, err
+	}
+	return s.teamRepo.GetByID(id)
+}
+
+// biggestResults picks the widest winning and losing margins. Ties go to the
+// match in which the winning side scored more, then the most recent one.
+func biggestResults(teamID int, matches []repository.MatchSummary) (win, loss *repository.MatchSummary) {
+	bestWin, bestWinFor := 0, 0
+	bestLoss, bestLossFor := 0, 0
+
+	for i := range matches {
+		m := &matches[i]
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+
+		goalsFor, goalsAgainst := *m.HomeScore, *m.AwayScore
+		if m.AwayTeamID == teamID {
+			goalsFor, goalsAgainst = goalsAgainst, goalsFor
+		}
+		margin := goalsFor - goalsAgainst
+
+		switch {
+		case margin > 0 && (margin > bestWin || (margin == bestWin && goalsFor > bestWinFor)):
+			win, bestWin, bestWinFor = m, margin, goalsFor
+		case margin < 0 && (-margin > bestLoss || (-margin == bestLoss && goalsAgainst > bestLossFor)):
+			loss, bestLoss, bestLossFor = m, -margin, goalsAgainst
+		}
+	}
+
+	return win, loss
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// States of a two-legged tie.
+const (
+	TieScheduled      = "SCHEDULED"        // no leg has finished
+	TieFirstLegPlayed = "FIRST_LEG_PLAYED" // the second leg is still to finish
+	TieFinished       = "FINISHED"
+)
+
+const (
+	tieSimulations = 10000
+	// extraTimeShare is extra time's share of a match's expected goals, 30
+	// minutes of 90.
+	extraTimeShare = 1.0 / 3
+)
+
+// awayGoalsAbolished is when UEFA dropped the away goals rule, from the
+// 2021-22 season. Ties whose first leg was played before then are decided on
+// away goals when level on aggregate, extra time included.
+var awayGoalsAbolished = time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+// Tie is a two-legged knockout tie with its aggregate state after the
+// finished legs. AwayGoals counts each side's goals away from home, and
+// Leader is the side going through if the tie ended now, nil when it would
+// go to extra time. Qualification is how likely each side is to go through.
+type Tie struct {
+	BracketTie
+	CompetitionCode string            `json:"competitionCode"`
+	Season          string            `json:"season"`
+	Stage           string            `json:"stage"`
+	State           string            `json:"state"`
+	AwayGoalsRule   bool              `json:"awayGoalsRule"`
+	AwayGoals       *TieScore         `json:"awayGoals"`
+	Leader          *BracketTeam      `json:"leader"`
+	Qualification   *TieQualification `json:"qualification"`
+}
+
+// TieQualification is how likely each side of a tie is to go through, from
+// simulating the legs still to be played with the goals the prediction
+// model expects in each, and how likely the tie is to need extra time and
+// penalties. A finished tie has certain odds and no simulations.
+type TieQualification struct {
+	TeamA        float64 `json:"teamA"`
+	TeamB        float64 `json:"teamB"`
+	ExtraTime    float64 `json:"extraTime"`
+	Penalties    float64 `json:"penalties"`
+	Simulations  int     `json:"simulations"`
+	ModelVersion string  `json:"modelVersion,omitempty"`
+}
+
+// TieService follows two-legged knockout ties.
+type TieService struct {
+	matchRepo   *repository.MatchRepository
+	predictions *PredictionService
+}
+
+func NewTieService(db *sql.DB, predictions *PredictionService) *TieService {
+	return &TieService{matchRepo: repository.NewMatchRepository(db), predictions: predictions}
+}
+
+// GetTie returns a two-legged tie with its aggregate score and
+// qualification odds, or nil if it does not exist.
+func (s *TieService) GetTie(ctx context.Context, id int) (*Tie, error) {
+	legs, err := s.matchRepo.ListTieLegs(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(legs) == 0 {
+		return nil, nil
+	}
+
+	first := legs[0]
+	tie := &Tie{
+		BracketTie: BracketTie{
+			ID:    &id,
+			TeamA: BracketTeam{ID: first.HomeTeamID, ExternalID: first.HomeTeamExternalID, Name: first.HomeTeamName},
+			TeamB: BracketTeam{ID: first.AwayTeamID, ExternalID: first.AwayTeamExternalID, Name: first.AwayTeamName},
+			Legs:  legs,
+		},
+		CompetitionCode: first.CompetitionCode,
+		Season:          first.Season,
+		Stage:           first.Stage,
+		State:           TieScheduled,
+		AwayGoalsRule:   first.UtcDate.Before(awayGoalsAbolished),
+	}
+	tie.settle()
+
+	state := tie.aggregateState()
+	switch {
+	case state.finished == len(legs):
+		tie.State = TieFinished
+	case state.finished > 0:
+		tie.State = TieFirstLegPlayed
+	}
+	if state.finished > 0 {
+		tie.AwayGoals = &TieScore{TeamA: state.awayA, TeamB: state.awayB}
+		if leader, ok := state.leader(tie.AwayGoalsRule); ok {
+			tie.setLeader(leader)
+		}
+	}
+
+	if tie.State == TieFinished {
+		if tie.Winner != nil {
+			tie.Qualification = &TieQualification{
+				TeamA:     boolProbability(tie.Winner.ID == tie.TeamA.ID),
+				TeamB:     boolProbability(tie.Winner.ID == tie.TeamB.ID),
+				ExtraTime: boolProbability(tie.DecidedBy != TieAggregate && tie.DecidedBy != TieAwayGoals),
+				Penalties: boolProbability(tie.DecidedBy == football.DurationPenalties),
+			}
+		}
+		return tie, nil
+	}
+
+	tie.Qualification = s.simulate(ctx, tie, state, id)
+	return tie, nil
+}
+
+func (t *Tie) setLeader(teamA bool) {
+	leader := t.TeamB
+	if teamA {
+		leader = t.TeamA
+	}
+	t.Leader = &leader
+}
+
+// simulate plays the unfinished legs of a tie many times from the goals the
+// prediction model expects in each, with extra time in the last leg when the
+// tie is level and a shootout either side wins half the time. Simulations
+// are seeded with the tie's ID, so the odds only move when the predictions
+// do. It returns nil when a leg has no expected goals.
+func (s *TieService) simulate(ctx context.Context, tie *Tie, state tieState, seed int) *TieQualification {
+	type legRates struct {
+		teamAHome    bool
+		home, away   float64
+		modelVersion string
+	}
+	var remaining []legRates
+	for _, leg := range tie.Legs {
+		if leg.Status == "FINISHED" && leg.HomeScore != nil && leg.AwayScore != nil {
+			continue
+		}
+		home, away, version, ok := s.expectedGoals(ctx, leg)
+		if !ok {
+			return nil
+		}
+		remaining = append(remaining, legRates{leg.HomeTeamID == tie.TeamA.ID, home, away, version})
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(int64(seed)))
+	var teamA, extraTime, penalties int
+	for i := 0; i < tieSimulations; i++ {
+		sim := state
+		for _, leg := range remaining {
+			sim.add(leg.teamAHome, poissonSample(rng, leg.home), poissonSample(rng, leg.away))
+		}
+		aThrough, ok := sim.leader(tie.AwayGoalsRule)
+		if !ok {
+			extraTime++
+			last := remaining[len(remaining)-1]
+			sim.add(last.teamAHome, poissonSample(rng, last.home*extraTimeShare), poissonSample(rng, last.away*extraTimeShare))
+			aThrough, ok = sim.leader(tie.AwayGoalsRule)
+		}
+		if !ok {
+			penalties++
+			aThrough = rng.Intn(2) == 0
+		}
+		if aThrough {
+			teamA++
+		}
+	}
+
+	return &TieQualification{
+		TeamA:        simulatedProbability(teamA),
+		TeamB:        simulatedProbability(tieSimulations - teamA),
+		ExtraTime:    simulatedProbability(extraTime),
+		Penalties:    simulatedProbability(penalties),
+		Simulations:  tieSimulations,
+		ModelVersion: remaining[len(remaining)-1].modelVersion,
+	}
+}
+
+// expectedGoals predicts a leg and returns the goals each side is expected
+// to score.
+func (s *TieService) expectedGoals(ctx context.Context, leg repository.KnockoutMatch) (home, away float64, modelVersion string, ok bool) {
+	return s.predictions.expectedGoals(ctx, PredictionInput{
+		MatchID:            leg.ID,
+		HomeTeamID:         leg.HomeTeamID,
+		AwayTeamID:         leg.AwayTeamID,
+		HomeTeamExternalID: leg.HomeTeamExternalID,
+		AwayTeamExternalID: leg.AwayTeamExternalID,
+		HomeTeamName:       leg.HomeTeamName,
+		AwayTeamName:       leg.AwayTeamName,
+		Matchday:           leg.Matchday,
+	})
+}
+
+// poissonSample draws from a Poisson distribution with mean lambda.
+func poissonSample(rng *rand.Rand, lambda float64) int {
+	limit := math.Exp(-lambda)
+	k, p := 0, rng.Float64()
+	for p > limit {
+		k++
+		p *= rng.Float64()
+	}
+	return k
+}
+
+func simulatedProbability(count int) float64 {
+	return math.Round(float64(count)/tieSimulations*1000) / 1000
+}
+
+func boolProbability(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// Lengths of a match in minutes, without stoppage time.
+const (
+	regulationMinutes = 90
+	extraTimeMinutes  = 120
+)
+
+// Timeline marker categories, for a chart legend.
+const (
+	TimelineGoal         = "goal"
+	TimelineCard         = "card"
+	TimelineSubstitution = "substitution"
+)
+
+// MatchTimeline is chart data for a match: the score after every minute and
+// a marker for each goal, card and substitution. Stoppage-time events count
+// in the minute they were added to, e.g. 45+2 in minute 45.
+type MatchTimeline struct {
+	MatchID    int                  `json:"matchId"`
+	ExternalID int                  `json:"externalId"`
+	Status     string               `json:"status"`
+	HomeTeam   repository.MatchTeam `json:"homeTeam"`
+	AwayTeam   repository.MatchTeam `json:"awayTeam"`
+	// Minutes is the last minute of Score: 90, 120 after extra time, or
+	// later if events were recorded beyond that.
+	Minutes int             `json:"minutes"`
+	Score   []TimelineScore `json:"score"`
+	Events  []TimelineEvent `json:"events"`
+	// Complete is false when the stored goals do not add up to the stored
+	// result, e.g. because events were not ingested for the match.
+	Complete bool `json:"complete"`
+}
+
+// TimelineScore is the cumulative score at the end of a minute. Diff is
+// home minus away goals, the match's momentum line.
+type TimelineScore struct {
+	Minute int `json:"minute"`
+	Home   int `json:"home"`
+	Away   int `json:"away"`
+	Diff   int `json:"diff"`
+}
+
+// TimelineEvent is a chart marker. Side is home or away. For goals Score is
+// the score after the goal; for substitutions Player came on and
+// RelatedPlayer went off.
+type TimelineEvent struct {
+	Minute         int            `json:"minute"`
+	InjuryTime     *int           `json:"injuryTime,omitempty"`
+	Category       string         `json:"category"`
+	Type           string         `json:"type"`
+	Side           string         `json:"side"`
+	TeamExternalID int            `json:"teamExternalId"`
+	Player         string         `json:"player"`
+	RelatedPlayer  string         `json:"relatedPlayer,omitempty"`
+	Score          *TimelineScore `json:"score,omitempty"`
+}
+
+// GetMatchTimeline builds the timeline of a stored match identified by its
+// football-data.org or internal ID from its stored events, or returns nil if
+// the match is not stored.
+func (s *FootballService) GetMatchTimeline(id int) (*MatchTimeline, error) {
+	detail, err := s.matchRepo.GetDetailByExternalID(id)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		if detail, err = s.matchRepo.GetDetailByID(id); err != nil {
+			return nil, err
+		}
+	}
+	if detail == nil {
+		return nil, nil
+	}
+
+	events, err := s.matchRepo.ListTimelineEvents(detail.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTimeline(detail, events), nil
+}
+
+func buildTimeline(detail *repository.MatchDetail, events []repository.TimelineEvent) *MatchTimeline {
+	t := &MatchTimeline{
+		MatchID:    detail.ID,
+		ExternalID: detail.ExternalID,
+		Status:     detail.Status,
+		HomeTeam:   detail.HomeTeam,
+		AwayTeam:   detail.AwayTeam,
+		Minutes:    regulationMinutes,
+		Events:     make([]TimelineEvent, 0, len(events)),
+	}
+	if detail.Score.Duration == football.DurationExtraTime || detail.Score.Duration == football.DurationPenalties {
+		t.Minutes = extraTimeMinutes
+	}
+
+	// Goals scored in each minute, by side
+	var home, away int
+	homeGoals := map[int]int{}
+	awayGoals := map[int]int{}
+
+	for _, e := range events {
+		t.Minutes = max(t.Minutes, e.Minute)
+
+		marker := TimelineEvent{
+			Minute:         e.Minute,
+			InjuryTime:     e.InjuryTime,
+			Category:       timelineCategory(e.Type),
+			Type:           e.Type,
+			Side:           "away",
+			TeamExternalID: e.TeamExternalID,
+			Player:         e.Player,
+			RelatedPlayer:  e.RelatedPlayer,
+		}
+		if e.IsHome {
+			marker.Side = "home"
+		}
+
+		if marker.Category == TimelineGoal {
+			if e.IsHome {
+				home++
+				homeGoals[e.Minute]++
+			} else {
+				away++
+				awayGoals[e.Minute]++
+			}
+			marker.Score = &TimelineScore{Minute: e.Minute, Home: home, Away: away, Diff: home - away}
+		}
+		t.Events = append(t.Events, marker)
+	}
+
+	t.Score = make([]TimelineScore, 0, t.Minutes+1)
+	home, away = 0, 0
+	for minute := 0; minute <= t.Minutes; minute++ {
+		home += homeGoals[minute]
+		away += awayGoals[minute]
+		t.Score = append(t.Score, TimelineScore{Minute: minute, Home: home, Away: away, Diff: home - away})
+	}
+
+	stored := detail.Score
+	t.Complete = stored.Home == nil && stored.Away == nil && home == 0 && away == 0 ||
+		stored.Home != nil && stored.Away != nil && *stored.Home == home && *stored.Away == away
+	return t
+}
+
+func timelineCategory(eventType string) string {
+	switch eventType {
+	case "GOAL", "OWN_GOAL", "PENALTY":
+		return TimelineGoal
+	case "SUBSTITUTION":
+		return TimelineSubstitution
+	default:
+		return TimelineCard
+	}
+}
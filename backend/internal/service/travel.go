@@ -0,0 +1,52 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// TravelService keeps the away team's travel distance of every match whose
+// venues have known coordinates.
+type TravelService struct {
+	venueRepo *repository.VenueRepository
+}
+
+func NewTravelService(db *sql.DB) *TravelService {
+	return &TravelService{venueRepo: repository.NewVenueRepository(db)}
+}
+
+// ImportVenues stores venue coordinates, rejecting the whole batch if any
+// venue is unnamed or lies off the globe.
+func (s *TravelService) ImportVenues(venues []repository.Venue) error {
+	for _, v := range venues {
+		if v.Name == "" {
+			return fmt.Errorf("venue without a name")
+		}
+		if math.Abs(v.Latitude) > 90 || math.Abs(v.Longitude) > 180 {
+			return fmt.Errorf("venue %q: coordinates %.4f, %.4f out of range", v.Name, v.Latitude, v.Longitude)
+		}
+	}
+	return s.venueRepo.Upsert(venues)
+}
+
+// Refresh computes the travel distance of the matches without one whose
+// venues have coordinates, or of all of them when recompute is set, e.g.
+// after venue coordinates were corrected. It returns how many matches were
+// updated.
+func (s *TravelService) Refresh(recompute bool) (int, error) {
+	coordinates, err := s.venueRepo.ListTravelCoordinates(recompute)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range coordinates {
+		km := football.DistanceKm(c.AwayHomeLatitude, c.AwayHomeLongitude, c.VenueLatitude, c.VenueLongitude)
+		if err := s.venueRepo.SetTravelDistance(c.MatchID, math.Round(km*10)/10); err != nil {
+			return 0, err
+		}
+	}
+	return len(coordinates), nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// usageKey identifies an hourly usage aggregate. userID is kept so monthly
+// counters can include requests not flushed yet.
+type usageKey struct {
+	userID   int
+	apiKeyID int
+	hour     time.Time
+	endpoint string
+}
+
+// monthlyCount is a user's request count for a month as loaded from the
+// database plus the requests recorded since.
+type monthlyCount struct {
+	month time.Time
+	used  int
+}
+
+// UsageReport is a user's API usage since a point in time. Limit and
+// Remaining are nil when the quota is unlimited.
+type UsageReport struct {
+	Since     time.Time                  `json:"since"`
+	Limit     *int                       `json:"limit"`
+	Used      int                        `json:"used"`
+	Remaining *int                       `json:"remaining"`
+	Endpoints []repository.EndpointUsage `json:"endpoints"`
+	Days      []repository.DailyUsage    `json:"days"`
+}
+
+// UsageService records per-key API usage, aggregated by hour and endpoint in
+// memory until flushed, and enforces monthly request quotas. It implements
+// auth.Meter.
+type UsageService struct {
+	repo         *repository.UsageRepository
+	defaultQuota int
+
+	mu      sync.Mutex
+	pending map[usageKey]*repository.APIUsage
+	counts  map[int]*monthlyCount
+}
+
+// NewUsageService returns a service enforcing defaultQuota requests per
+// calendar month on users without their own quota; 0 means unlimited.
+func NewUsageService(db *sql.DB, defaultQuota int) *UsageService {
+	return &UsageService{
+		repo:         repository.NewUsageRepository(db),
+		defaultQuota: defaultQuota,
+		pending:      make(map[usageKey]*repository.APIUsage),
+		counts:       make(map[int]*monthlyCount),
+	}
+}
+
+// Quota returns the user's monthly limit and the requests they made this
+// UTC month. Counts are loaded once per user between flushes and kept up to
+// date in memory.
+func (s *UsageService) Quota(user *repository.User) (int, int, error) {
+	limit := s.defaultQuota
+	if user.MonthlyQuota != nil {
+		limit = *user.MonthlyQuota
+	}
+	if limit == 0 {
+		return 0, 0, nil
+	}
+
+	month := monthStart(time.Now())
+	s.mu.Lock()
+	if c, ok := s.counts[user.ID]; ok && c.month.Equal(month) {
+		used := c.used
+		s.mu.Unlock()
+		return limit, used, nil
+	}
+	s.mu.Unlock()
+
+	stored, err := s.repo.CountSince(user.ID, month)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	used := stored
+	for k, u := range s.pending {
+		if k.userID == user.ID && !k.hour.Before(month) {
+			used += u.Requests
+		}
+	}
+	s.counts[user.ID] = &monthlyCount{month: month, used: used}
+	return limit, used, nil
+}
+
+// Record adds a request to its hourly aggregate.
+func (s *UsageService) Record(u auth.Usage) {
+	key := usageKey{
+		userID:   u.UserID,
+		apiKeyID: u.APIKeyID,
+		hour:     u.At.UTC().Truncate(time.Hour),
+		endpoint: u.Endpoint,
+	}
+	latency := int(u.Latency.Milliseconds())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	agg, ok := s.pending[key]
+	if !ok {
+		agg = &repository.APIUsage{APIKeyID: key.apiKeyID, Hour: key.hour, Endpoint: key.endpoint}
+		s.pending[key] = agg
+	}
+	agg.Requests++
+	if u.Status >= 400 {
+		agg.Errors++
+	}
+	agg.Bytes += u.Bytes
+	agg.TotalLatencyMs += latency
+	agg.MaxLatencyMs = max(agg.MaxLatencyMs, latency)
+
+	if c, ok := s.counts[u.UserID]; ok && c.month.Equal(monthStart(u.At)) {
+		c.used++
+	}
+}
+
+// Flush writes the pending aggregates and returns how many were written. On
+// failure they are kept for the next flush. Monthly counts are reloaded
+// afterwards so requests served by other instances are counted too.
+func (s *UsageService) Flush() (int, error) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[usageKey]*repository.APIUsage)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	usage := make([]repository.APIUsage, 0, len(pending))
+	for _, u := range pending {
+		usage = append(usage, *u)
+	}
+	// A stable order keeps concurrent flushes from deadlocking on row locks
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		if a.APIKeyID != b.APIKeyID {
+			return a.APIKeyID < b.APIKeyID
+		}
+		if !a.Hour.Equal(b.Hour) {
+			return a.Hour.Before(b.Hour)
+		}
+		return a.Endpoint < b.Endpoint
+	})
+
+	if err := s.repo.Add(usage); err != nil {
+		s.mu.Lock()
+		for k, u := range pending {
+			if agg, ok := s.pending[k]; ok {
+				agg.Requests += u.Requests
+				agg.Errors += u.Errors
+				agg.Bytes += u.Bytes
+				agg.TotalLatencyMs += u.TotalLatencyMs
+				agg.MaxLatencyMs = max(agg.MaxLatencyMs, u.MaxLatencyMs)
+			} else {
+				s.pending[k] = u
+			}
+		}
+		s.mu.Unlock()
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.counts = make(map[int]*monthlyCount)
+	s.mu.Unlock()
+	return len(usage), nil
+}
+
+// Report returns a user's usage since a time, by default the start of the
+// month, per endpoint and per day, together with their quota for the
+// current month. A non-nil keyID limits
+// the breakdowns to one of the user's keys. Usage not flushed yet is counted
+// in Used but not in the breakdowns.
+func (s *UsageService) Report(user *repository.User, since time.Time, keyID *int) (*UsageReport, error) {
+	if since.IsZero() {
+		since = monthStart(time.Now())
+	}
+
+	limit, used, err := s.Quota(user)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.repo.ListByEndpoint(user.ID, since, keyID)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.repo.ListByDay(user.ID, since, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{Since: since, Used: used, Endpoints: endpoints, Days: days}
+	if limit == 0 {
+		// Unlimited users are not counted in memory
+		if report.Used, err = s.repo.CountSince(user.ID, monthStart(time.Now())); err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+
+	remaining := max(limit-used, 0)
+	report.Limit, report.Remaining = &limit, &remaining
+	return report, nil
+}
+
+// monthStart returns the start of t's UTC calendar month.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"database/sql"
+	"net/mail"
+
+	"github.com/yourusername/football-prediction/internal/auth"
+	"github.com/yourusername/football-prediction/internal/repository"
+)
+
+// UserService manages API users and their keys.
+type UserService struct {
+	repo *repository.UserRepository
+}
+
+func NewUserService(db *sql.DB) *UserService {
+	return &UserService{repo: repository.NewUserRepository(db)}
+}
+
+// Repository exposes the underlying repository for the auth middleware.
+func (s *UserService) Repository() *repository.UserRepository {
+	return s.repo
+}
+
+// CreateUser creates a user and issues a first API key. The plain key is
+// returned only here.
+func (s *UserService) CreateUser(email, name string, isAdmin bool) (*repository.User, *repository.APIKey, string, error) {
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, nil, "", invalidf("invalid email address")
+	}
+
+	user := &repository.User{Email: email, Name: name, IsAdmin: isAdmin}
+	if err := s.repo.Create(user); err != nil {
+		return nil, nil, "", err
+	}
+
+	key, plain, err := s.CreateKey(user.ID, "default")
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return user, key, plain, nil
+}
+
+// CreateKey issues a new API key for a user. Returns a nil key if the user
+// does not exist.
+func (s *UserService) CreateKey(userID int, name string) (*repository.APIKey, string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if user == nil {
+		return nil, "", nil
+	}
+
+	plain, prefix, hash, err := auth.GenerateKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &repository.APIKey{UserID: userID, Name: name, Prefix: prefix}
+	if err := s.repo.CreateAPIKey(key, hash); err != nil {
+		return nil, "", err
+	}
+
+	return key, plain, nil
+}
+
+// ListKeys returns the keys issued to a user.
+func (s *UserService) ListKeys(userID int) ([]repository.APIKey, error) {
+	return s.repo.ListAPIKeys(userID)
+}
+
+// RevokeKey revokes a key. Returns false if no active key matched.
+func (s *UserService) RevokeKey(userID, keyID int) (bool, error) {
+	return s.repo.RevokeAPIKey(userID, keyID)
+}
+
+// SetMonthlyQuota overrides a user's monthly request quota, 0 meaning
+// unlimited, or with nil restores the default. Returns false if the user
+// does not exist.
+func (s *UserService) SetMonthlyQuota(userID int, quota *int) (bool, error) {
+	return s.repo.SetMonthlyQuota(userID, quota)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/football-prediction/internal/repository"
+	"github.com/yourusername/football-prediction/pkg/events"
+)
+
+// Webhook event types.
+const (
+	EventMatchFinished     = events.MatchFinished
+	EventMatchPostponed    = events.MatchPostponed
+	EventMatchRescheduled  = events.MatchRescheduled
+	EventPredictionCreated = events.PredictionCreated
+	EventPredictionUpdated = events.PredictionUpdated
+	EventPredictionSettled = events.PredictionSettled
+	EventUpsetDetected     = "upset.detected"
+	EventAnomalyDetected   = "anomaly.detected"
+)
+
+// WebhookEvents lists every event type a webhook may subscribe to.
+var WebhookEvents = []string{
+	EventMatchFinished,
+	EventMatchPostponed,
+	EventMatchRescheduled,
+	EventPredictionCreated,
+	EventPredictionUpdated,
+	EventPredictionSettled,
+	EventUpsetDetected,
+	EventAnomalyDetected,
+}
+
+// maxWebhookAttempts is the number of tries before a delivery is marked failed.
+const maxWebhookAttempts = 6
+
+// WebhookService registers webhooks, records the events they subscribe to
+// and delivers them.
+type WebhookService struct {
+	repo       *repository.WebhookRepository
+	httpClient *http.Client
+}
+
+func NewWebhookService(db *sql.DB) *WebhookService {
+	return &WebhookService{
+		repo: repository.NewWebhookRepository(db),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Register validates and stores a webhook. The generated signing secret is
+// returned so it can be shown to the caller exactly once.
+func (s *WebhookService) Register(rawURL string, events []string, description string) (*repository.Webhook, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", invalidf("url must be an absolute http(s) URL")
+	}
+
+	if len(events) == 0 {
+		return nil, "", invalidf("at least one event is required")
+	}
+	for _, e := range events {
+		if !isWebhookEvent(e) {
+			return nil, "", invalidf("unknown event %q", e)
+		}
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	webhook := &repository.Webhook{
+		URL:         u.String(),
+		Secret:      secret,
+		Events:      events,
+		Description: description,
+	}
+	if err := s.repo.Create(webhook); err != nil {
+		return nil, "", err
+	}
+
+	return webhook, secret, nil
+}
+
+// Delete removes a webhook. Returns false if it did not exist.
+func (s *WebhookService) Delete(id int) (bool, error) {
+	return s.repo.Delete(id)
+}
+
+// List returns all webhooks with delivery statistics.
+func (s *WebhookService) List() ([]repository.WebhookStats, error) {
+	return s.repo.ListWithStats()
+}
+
+// Record consumes an event from the bus, queueing it for delivery to the
+// webhooks subscribed to its type. The event key de-duplicates events, so
+// one published twice is delivered once.
+func (s *WebhookService) Record(e events.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_, err = s.repo.PublishEvent(e.Type, e.Key, payload)
+	return err
+}
+
+// DeliverPending sends due deliveries, rescheduling failures with exponential
+// backoff. It returns the number of successful deliveries.
+func (s *WebhookService) DeliverPending(limit int) (int, error) {
+	deliveries, err := s.repo.ListDue(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range deliveries {
+		status, err := s.send(d)
+		if err == nil {
+			if err := s.repo.MarkDelivered(d.ID, status); err != nil {
+				return delivered, err
+			}
+			delivered++
+			continue
+		}
+
+		var statusPtr *int
+		if status != 0 {
+			statusPtr = &status
+		}
+
+		var next *time.Time
+		if d.Attempts+1 < maxWebhookAttempts {
+			t := time.Now().Add(time.Duration(1<<uint(d.Attempts)) * time.Minute)
+			next = &t
+		}
+
+		if err := s.repo.MarkFailed(d.ID, statusPtr, err.Error(), next); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+func (s *WebhookService) send(d repository.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest("POST", d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "football-prediction-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.Itoa(d.ID))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(d.Secret, timestamp, d.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload computes the hex HMAC-SHA256 of "<timestamp>.<payload>".
+// Receivers recompute it with their secret to verify authenticity.
+func SignWebhookPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func isWebhookEvent(event string) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
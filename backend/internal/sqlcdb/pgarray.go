@@ -0,0 +1,30 @@
+package sqlcdb
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// typeMaps holds pgtype maps for decoding arrays. A map caches scan plans
+// and is not safe for concurrent use, so each scan borrows one.
+var typeMaps = sync.Pool{New: func() any { return pgtype.NewMap() }}
+
+// arrayScanner scans an array column into dst, a pointer to a slice. The
+// pgx database/sql driver returns arrays in their text form, which
+// database/sql cannot convert to a slice on its own. Array parameters need
+// no wrapping; pgx encodes slices directly.
+type arrayScanner struct {
+	dst any
+}
+
+func pgArray(dst any) sql.Scanner {
+	return arrayScanner{dst: dst}
+}
+
+func (s arrayScanner) Scan(src any) error {
+	m := typeMaps.Get().(*pgtype.Map)
+	defer typeMaps.Put(m)
+	return m.SQLScanner(s.dst).Scan(src)
+}
@@ -0,0 +1,66 @@
+-- name: ListAnomalyCandidates :many
+-- ListAnomalyCandidates returns the settled final pre-kickoff predictions
+-- served that the anomaly detector has not looked at yet, oldest kickoff
+-- first.
+SELECT
+    ph.id, ph.match_id::int AS match_id, m.external_id, m.utc_date,
+    COALESCE(c.code, '') AS competition_code,
+    ht.name AS home_team_name, at.name AS away_team_name,
+    ph.model_version,
+    ph.predicted_team_a_goals, ph.predicted_team_b_goals,
+    COALESCE(ph.predicted_outcome, '') AS predicted_outcome,
+    COALESCE(ph.confidence_score, 0)::float8 AS confidence_score,
+    ph.home_win_probability::float8 AS home_win_probability,
+    ph.draw_probability::float8 AS draw_probability,
+    ph.away_win_probability::float8 AS away_win_probability,
+    ph.actual_team_a_goals::int AS actual_team_a_goals,
+    ph.actual_team_b_goals::int AS actual_team_b_goals,
+    COALESCE(ph.actual_outcome, '') AS actual_outcome,
+    ph.prediction_correct
+FROM prediction_history ph
+JOIN matches m ON m.id = ph.match_id
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+LEFT JOIN competitions c ON c.id = m.competition_id
+WHERE ph.is_primary
+  AND ph.is_final
+  AND ph.anomaly_checked_at IS NULL
+  AND ph.actual_team_a_goals IS NOT NULL
+  AND ph.actual_team_b_goals IS NOT NULL
+ORDER BY m.utc_date
+LIMIT @row_limit::int;
+
+-- name: InsertAnomaly :execrows
+-- InsertAnomaly flags a match, returning 0 rows if it was already flagged
+-- for the same kind.
+INSERT INTO anomalies (match_id, kind, model_version, outcome_probability, goals_error, context)
+VALUES (@match_id::int, @kind::text, @model_version::text, sqlc.narg('outcome_probability'),
+        sqlc.narg('goals_error'), @context::jsonb)
+ON CONFLICT (match_id, kind) DO NOTHING;
+
+-- name: MarkAnomalyChecked :exec
+UPDATE prediction_history
+SET anomaly_checked_at = CURRENT_TIMESTAMP
+WHERE id = @id::int;
+
+-- name: ListAnomalies :many
+-- ListAnomalies returns anomalies of matches kicked off on or after since,
+-- most recent first. An empty kind returns every kind.
+SELECT
+    a.id, a.kind, a.model_version,
+    a.outcome_probability::float8 AS outcome_probability,
+    a.goals_error::float8 AS goals_error,
+    a.context, a.detected_at,
+    m.id AS match_id, m.external_id, m.utc_date,
+    COALESCE(c.code, '') AS competition_code,
+    ht.name AS home_team_name, at.name AS away_team_name,
+    m.home_score, m.away_score
+FROM anomalies a
+JOIN matches m ON m.id = a.match_id
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+LEFT JOIN competitions c ON c.id = m.competition_id
+WHERE m.utc_date >= @since::timestamp
+  AND (@kind::text = '' OR a.kind = @kind::text)
+ORDER BY m.utc_date DESC, a.id DESC
+LIMIT @row_limit::int;
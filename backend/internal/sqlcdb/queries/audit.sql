@@ -0,0 +1,17 @@
+-- name: InsertAuditEntry :exec
+INSERT INTO audit_log (action, actor_type, actor, user_id, target, method, path, status, ip, details)
+VALUES (@action, @actor_type, @actor, sqlc.narg('user_id'), @target, @method, @path, sqlc.narg('status'), @ip, sqlc.narg('details'));
+
+-- name: ListAuditEntries :many
+-- ListAuditEntries returns entries newest first. Empty text filters and
+-- null times match everything; action matches a prefix, e.g. "key." for
+-- every key action.
+SELECT id, action, actor_type, actor, user_id, target, method, path, status, ip, details, created_at
+FROM audit_log
+WHERE (@action::text = '' OR action LIKE @action::text || '%')
+  AND (@actor_type::text = '' OR actor_type = @actor_type::text)
+  AND (@actor::text = '' OR actor = @actor::text)
+  AND (sqlc.narg('since')::timestamp IS NULL OR created_at >= sqlc.narg('since'))
+  AND (sqlc.narg('until')::timestamp IS NULL OR created_at < sqlc.narg('until'))
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit::int;
@@ -0,0 +1,29 @@
+-- name: EnsureBackfillJob :exec
+INSERT INTO backfill_jobs (competition_code, season)
+VALUES ($1, $2)
+ON CONFLICT (competition_code, season) DO NOTHING;
+
+-- name: ListBackfillJobs :many
+-- ListBackfillJobs returns jobs ordered by season then competition, the
+-- order they are processed in.
+SELECT id, competition_code, season, status, attempts, matches_saved,
+       COALESCE(last_error, '') AS last_error, started_at, finished_at
+FROM backfill_jobs
+WHERE competition_code = ANY(@competition_codes::text[])
+  AND season BETWEEN @from_season::text AND @to_season::text
+ORDER BY season, competition_code;
+
+-- name: MarkBackfillJobRunning :exec
+UPDATE backfill_jobs
+SET status = 'running', attempts = attempts + 1, started_at = NOW(), last_error = NULL
+WHERE id = $1;
+
+-- name: MarkBackfillJobDone :exec
+UPDATE backfill_jobs
+SET status = 'done', matches_saved = @matches_saved, finished_at = NOW()
+WHERE id = @id;
+
+-- name: MarkBackfillJobFailed :exec
+UPDATE backfill_jobs
+SET status = 'failed', last_error = @last_error::text
+WHERE id = @id;
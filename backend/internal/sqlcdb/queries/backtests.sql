@@ -0,0 +1,44 @@
+-- name: ListBacktestFixtures :many
+-- ListBacktestFixtures returns the real finished matches of a season, by
+-- start year, in kickoff order, with what a prediction of each needs. An empty competition_code matches every
+-- competition.
+SELECT m.id, m.utc_date, m.competition_id, COALESCE(m.matchday, 0) AS matchday,
+       m.home_team_id::int AS home_team_id, m.away_team_id::int AS away_team_id,
+       ht.external_id AS home_team_external_id, at.external_id AS away_team_external_id,
+       ht.name AS home_team_name, at.name AS away_team_name,
+       m.home_score::int AS home_score, m.away_score::int AS away_score
+FROM matches m
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+LEFT JOIN competitions c ON c.id = m.competition_id
+WHERE m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+  AND m.home_team_id IS NOT NULL
+  AND m.away_team_id IS NOT NULL
+  AND m.is_synthetic = FALSE
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+  AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+ORDER BY m.utc_date, m.id;
+
+-- name: InsertBacktest :one
+INSERT INTO backtests (
+    backend, model_version, competition_code, season, matches, correct,
+    accuracy, log_loss, brier, started_at
+) VALUES (
+    @backend, @model_version, sqlc.narg('competition_code'), @season, @matches::int, @correct::int,
+    sqlc.narg('accuracy'), sqlc.narg('log_loss'), sqlc.narg('brier'), @started_at
+)
+RETURNING id;
+
+-- name: InsertBacktestPrediction :exec
+INSERT INTO backtest_predictions (
+    backtest_id, match_id, home_win_probability, draw_probability, away_win_probability,
+    predicted_outcome, actual_outcome, expected_home_goals, expected_away_goals, trained_on
+) VALUES (
+    @backtest_id, @match_id, @home_win_probability, @draw_probability, @away_win_probability,
+    @predicted_outcome, @actual_outcome, sqlc.narg('expected_home_goals'), sqlc.narg('expected_away_goals'), @trained_on::int
+);
@@ -0,0 +1,58 @@
+-- name: UpsertCoach :one
+INSERT INTO coaches (source, external_id, name, nationality)
+VALUES (@source, @external_id::int, @name, sqlc.narg('nationality'))
+ON CONFLICT (source, external_id) DO UPDATE SET
+    name = EXCLUDED.name,
+    nationality = COALESCE(EXCLUDED.nationality, coaches.nationality)
+RETURNING id;
+
+-- name: DeleteTeamCoachSpells :exec
+DELETE FROM team_coach_spells WHERE team_id = @team_id::int;
+
+-- name: InsertTeamCoachSpells :exec
+-- InsertTeamCoachSpells derives a team's spells from the coaches of its
+-- stored lineups: every run of consecutive matches under one coach is a
+-- spell.
+INSERT INTO team_coach_spells (team_id, coach_id, first_match_id, last_match_id, started_at, last_match_at, matches)
+SELECT @team_id::int, coach_id,
+       (array_agg(match_id ORDER BY utc_date))[1],
+       (array_agg(match_id ORDER BY utc_date DESC))[1],
+       MIN(utc_date), MAX(utc_date), COUNT(*)
+FROM (
+    SELECT ml.coach_id, m.id AS match_id, m.utc_date,
+           ROW_NUMBER() OVER (ORDER BY m.utc_date)
+             - ROW_NUMBER() OVER (PARTITION BY ml.coach_id ORDER BY m.utc_date) AS run
+    FROM match_lineups ml
+    JOIN matches m ON m.id = ml.match_id
+    WHERE ml.team_id = @team_id::int AND ml.coach_id IS NOT NULL
+) appearances
+GROUP BY coach_id, run;
+
+-- name: ListTeamCoachSpells :many
+-- ListTeamCoachSpells returns a team's spells, latest first, with the
+-- team's record in its finished matches during each.
+SELECT s.coach_id, c.name, c.nationality, s.started_at, s.last_match_at, s.matches,
+       COUNT(m.id)::int AS played,
+       COUNT(m.id) FILTER (WHERE (m.home_team_id = s.team_id AND m.home_score > m.away_score)
+                             OR (m.away_team_id = s.team_id AND m.away_score > m.home_score))::int AS won,
+       COUNT(m.id) FILTER (WHERE m.home_score = m.away_score)::int AS draw,
+       COUNT(m.id) FILTER (WHERE (m.home_team_id = s.team_id AND m.home_score < m.away_score)
+                             OR (m.away_team_id = s.team_id AND m.away_score < m.home_score))::int AS lost
+FROM team_coach_spells s
+JOIN coaches c ON c.id = s.coach_id
+LEFT JOIN matches m ON (m.home_team_id = s.team_id OR m.away_team_id = s.team_id)
+    AND m.utc_date BETWEEN s.started_at AND s.last_match_at
+    AND m.status = 'FINISHED' AND m.home_score IS NOT NULL AND m.away_score IS NOT NULL
+WHERE s.team_id = @team_id::int
+GROUP BY s.id, c.id
+ORDER BY s.started_at DESC;
+
+-- name: ListTeamRecentCoaches :many
+-- ListTeamRecentCoaches returns the coaches of a team's latest lineups
+-- kicking off before a time, most recent first.
+SELECT ml.coach_id::int
+FROM match_lineups ml
+JOIN matches m ON m.id = ml.match_id
+WHERE ml.team_id = @team_id::int AND ml.coach_id IS NOT NULL AND m.utc_date < @before
+ORDER BY m.utc_date DESC
+LIMIT @max_matches::int;
@@ -0,0 +1,55 @@
+-- name: UpsertCompetition :exec
+INSERT INTO competitions (
+    source, external_id, name, code, area_name, current_season_start_date, current_season_end_date, type, team_type
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (source, external_id) DO UPDATE
+SET name = EXCLUDED.name,
+    code = EXCLUDED.code,
+    area_name = EXCLUDED.area_name,
+    current_season_start_date = EXCLUDED.current_season_start_date,
+    current_season_end_date = EXCLUDED.current_season_end_date,
+    type = COALESCE(EXCLUDED.type, competitions.type),
+    team_type = EXCLUDED.team_type,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: GetCompetitionByCode :one
+SELECT id, external_id, name, code, area_name, current_season_start_date, current_season_end_date,
+       type, team_type
+FROM competitions
+WHERE code = @code::text;
+
+-- name: ListCompetitions :many
+SELECT id, external_id, name, code, area_name, current_season_start_date, current_season_end_date,
+       type, team_type
+FROM competitions
+ORDER BY name;
+
+-- name: GetCompetitionIDByCode :one
+SELECT id FROM competitions WHERE code = UPPER(@code::text);
+
+-- name: ListCompetitionSeasonCoverage :many
+-- ListCompetitionSeasonCoverage summarises each stored season of a
+-- competition, most recent first, with how many of its matches have
+-- lineups, player stats and team statistics.
+SELECT
+    m.season,
+    EXTRACT(YEAR FROM MIN(m.utc_date))::int AS season_year,
+    MIN(m.utc_date)::timestamp AS first_match_date,
+    MAX(m.utc_date)::timestamp AS last_match_date,
+    COUNT(*)::int AS matches,
+    COUNT(*) FILTER (WHERE m.status = 'FINISHED')::int AS finished_matches,
+    COUNT(*) FILTER (WHERE EXISTS (
+        SELECT 1 FROM match_lineups l WHERE l.match_id = m.id
+    ))::int AS matches_with_lineups,
+    COUNT(*) FILTER (WHERE EXISTS (
+        SELECT 1 FROM player_match_stats s WHERE s.match_id = m.id
+    ))::int AS matches_with_player_stats,
+    COUNT(*) FILTER (WHERE EXISTS (
+        SELECT 1 FROM team_match_statistics t WHERE t.match_id = m.id
+    ))::int AS matches_with_team_stats
+FROM matches m
+JOIN competitions c ON c.id = m.competition_id
+WHERE c.code = @competition_code::text
+GROUP BY m.season
+ORDER BY MIN(m.utc_date) DESC;
@@ -0,0 +1,40 @@
+-- name: ListCompetitionDataQuality :many
+-- ListCompetitionDataQuality counts the gaps in each competition's stored
+-- data: finished matches without a score or without player stats, teams
+-- that played in it without a crest, predictions of finished matches never
+-- settled against the result and upcoming matches without a primary
+-- prediction. Synthetic matches are left out.
+SELECT
+    c.id,
+    c.code,
+    c.name,
+    COUNT(*)::int AS matches,
+    COUNT(*) FILTER (WHERE m.status = 'FINISHED'
+        AND (m.home_score IS NULL OR m.away_score IS NULL))::int AS matches_missing_scores,
+    COUNT(*) FILTER (WHERE m.status = 'FINISHED' AND NOT EXISTS (
+        SELECT 1 FROM player_match_stats s WHERE s.match_id = m.id
+    ))::int AS finished_without_player_stats,
+    (
+        SELECT COUNT(DISTINCT t.id)
+        FROM matches tm
+        JOIN teams t ON t.id IN (tm.home_team_id, tm.away_team_id)
+        WHERE tm.competition_id = c.id AND NOT tm.is_synthetic
+          AND COALESCE(t.crest_url, '') = ''
+    )::int AS teams_missing_crests,
+    (
+        SELECT COUNT(*)
+        FROM prediction_history ph
+        JOIN matches pm ON pm.id = ph.match_id
+        WHERE pm.competition_id = c.id AND NOT pm.is_synthetic
+          AND pm.status = 'FINISHED'
+          AND pm.home_score IS NOT NULL
+          AND ph.actual_team_a_goals IS NULL
+    )::int AS unsettled_predictions,
+    COUNT(*) FILTER (WHERE m.status IN ('SCHEDULED', 'TIMED') AND m.utc_date >= NOW() AND NOT EXISTS (
+        SELECT 1 FROM prediction_history ph WHERE ph.match_id = m.id AND ph.is_primary
+    ))::int AS upcoming_without_predictions
+FROM competitions c
+JOIN matches m ON m.competition_id = c.id
+WHERE NOT m.is_synthetic
+GROUP BY c.id
+ORDER BY c.name;
@@ -0,0 +1,35 @@
+-- name: ListDueDigestRecipients :many
+-- ListDueDigestRecipients returns users following at least one team who have
+-- not unsubscribed from the weekly digest and have not been sent one since
+-- sent_before.
+SELECT u.id, u.email, COALESCE(u.name, '') AS name
+FROM users u
+LEFT JOIN email_digests d ON d.user_id = u.id
+WHERE EXISTS (SELECT 1 FROM user_follows f WHERE f.user_id = u.id AND f.entity_type = 'team')
+  AND COALESCE(d.subscribed, true)
+  AND (d.last_sent_at IS NULL OR d.last_sent_at < @sent_before::timestamp)
+ORDER BY u.id
+LIMIT @row_limit::int;
+
+-- name: EnsureDigestSubscription :one
+-- EnsureDigestSubscription returns a user's digest subscription, creating it
+-- with the given unsubscribe token if the user has none.
+INSERT INTO email_digests (user_id, unsubscribe_token)
+VALUES (@user_id, @unsubscribe_token)
+ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+RETURNING user_id, subscribed, unsubscribe_token, last_sent_at, unsubscribed_at, created_at;
+
+-- name: SetDigestSubscribed :exec
+UPDATE email_digests SET subscribed = @subscribed, unsubscribed_at = sqlc.narg('unsubscribed_at')
+WHERE user_id = @user_id;
+
+-- name: UnsubscribeDigestByToken :execrows
+-- UnsubscribeDigestByToken unsubscribes the holder of an unsubscribe link.
+-- Repeating it keeps the original unsubscribe time.
+UPDATE email_digests
+SET subscribed = false, unsubscribed_at = COALESCE(unsubscribed_at, @unsubscribed_at::timestamp)
+WHERE unsubscribe_token = @unsubscribe_token;
+
+-- name: MarkDigestSent :exec
+UPDATE email_digests SET last_sent_at = @sent_at::timestamp
+WHERE user_id = @user_id;
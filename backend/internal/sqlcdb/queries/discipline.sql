@@ -0,0 +1,33 @@
+-- name: ListTeamBookings :many
+-- ListTeamBookings counts the cards each player got for a team before a
+-- time, in the season of every competition the team last played in, with
+-- the last matchday the team had finished in it.
+WITH seasons AS (
+    SELECT DISTINCT ON (m.competition_id) m.competition_id, m.season
+    FROM matches m
+    WHERE (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+      AND m.competition_id IS NOT NULL AND m.utc_date < @before
+    ORDER BY m.competition_id, m.utc_date DESC
+),
+played AS (
+    SELECT s.competition_id, s.season, COALESCE(MAX(m.matchday), 0)::int AS matchday
+    FROM seasons s
+    LEFT JOIN matches m ON m.competition_id = s.competition_id AND m.season = s.season
+        AND m.status = 'FINISHED' AND m.utc_date < @before
+        AND (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+    GROUP BY s.competition_id, s.season
+)
+SELECT COALESCE(c.code, '')::text AS competition_code, pl.season, pl.matchday,
+       p.id AS player_id, p.external_id AS player_external_id, p.name AS player_name,
+       COUNT(*) FILTER (WHERE e.event_type = 'YELLOW_CARD')::int AS yellow_cards,
+       COUNT(*) FILTER (WHERE e.event_type = 'YELLOW_RED_CARD')::int AS second_yellows,
+       COUNT(*) FILTER (WHERE e.event_type = 'RED_CARD')::int AS red_cards
+FROM played pl
+JOIN competitions c ON c.id = pl.competition_id
+JOIN matches m ON m.competition_id = pl.competition_id AND m.season = pl.season AND m.utc_date < @before
+JOIN match_events e ON e.match_id = m.id
+JOIN players p ON p.id = e.player_id
+WHERE e.team_id = @team_id::int
+  AND e.event_type IN ('YELLOW_CARD', 'YELLOW_RED_CARD', 'RED_CARD')
+GROUP BY c.code, pl.season, pl.matchday, p.id
+ORDER BY competition_code, yellow_cards DESC, p.name;
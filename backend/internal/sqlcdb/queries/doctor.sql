@@ -0,0 +1,124 @@
+-- name: ListDuplicatePlayers :many
+-- ListDuplicatePlayers returns players stored more than once for a team
+-- under different external IDs, each with the row it duplicates: the first
+-- stored, preferring real players to synthetic ones.
+WITH ranked AS (
+    SELECT p.id, p.external_id, p.name, p.team_id,
+           FIRST_VALUE(p.id) OVER w AS keep_id,
+           ROW_NUMBER() OVER w AS position
+    FROM players p
+    WHERE p.team_id IS NOT NULL
+    WINDOW w AS (PARTITION BY p.team_id, LOWER(TRIM(p.name)) ORDER BY p.is_synthetic, p.id)
+)
+SELECT
+    r.keep_id::int AS keep_id,
+    k.external_id AS keep_external_id,
+    r.id AS duplicate_id,
+    r.external_id AS duplicate_external_id,
+    r.name,
+    t.name AS team_name
+FROM ranked r
+JOIN players k ON k.id = r.keep_id
+JOIN teams t ON t.id = r.team_id
+WHERE r.position > 1
+ORDER BY t.name, r.name, r.id;
+
+-- name: MergePlayer :exec
+-- MergePlayer moves a duplicate player's stats, lineups, events, points,
+-- availability, news tags and signals, mappings and aliases onto the
+-- player it duplicates. Rows the kept player already has for the same
+-- match stay with the duplicate, to be deleted with it.
+WITH stats AS (
+    UPDATE player_match_stats d SET player_id = @keep_id::int
+    WHERE d.player_id = @duplicate_id::int AND NOT EXISTS (
+        SELECT 1 FROM player_match_stats k WHERE k.player_id = @keep_id::int AND k.match_id = d.match_id
+    )
+), enhanced AS (
+    UPDATE player_match_stats_enhanced d SET player_id = @keep_id::int
+    WHERE d.player_id = @duplicate_id::int AND NOT EXISTS (
+        SELECT 1 FROM player_match_stats_enhanced k WHERE k.player_id = @keep_id::int AND k.match_id = d.match_id
+    )
+), lineups AS (
+    UPDATE match_lineup_players d SET player_id = @keep_id::int
+    WHERE d.player_id = @duplicate_id::int AND NOT EXISTS (
+        SELECT 1 FROM match_lineup_players k WHERE k.player_id = @keep_id::int AND k.match_lineup_id = d.match_lineup_id
+    )
+), events AS (
+    UPDATE match_events d
+    SET player_id = CASE
+            WHEN d.player_id = @duplicate_id::int AND NOT EXISTS (
+                SELECT 1 FROM match_events k
+                WHERE k.player_id = @keep_id::int AND k.match_id = d.match_id
+                  AND k.event_type = d.event_type AND k.minute IS NOT DISTINCT FROM d.minute
+            ) THEN @keep_id::int
+            ELSE d.player_id
+        END,
+        related_player_id = CASE
+            WHEN d.related_player_id = @duplicate_id::int THEN @keep_id::int
+            ELSE d.related_player_id
+        END
+    WHERE d.player_id = @duplicate_id::int OR d.related_player_id = @duplicate_id::int
+), points AS (
+    UPDATE fantasy_player_points d SET player_id = @keep_id::int
+    WHERE d.player_id = @duplicate_id::int AND NOT EXISTS (
+        SELECT 1 FROM fantasy_player_points k WHERE k.player_id = @keep_id::int AND k.match_id = d.match_id
+    )
+), availability AS (
+    UPDATE player_availability SET player_id = @keep_id::int WHERE player_id = @duplicate_id::int
+), news AS (
+    UPDATE news_article_players d SET player_id = @keep_id::int
+    WHERE d.player_id = @duplicate_id::int AND NOT EXISTS (
+        SELECT 1 FROM news_article_players k WHERE k.player_id = @keep_id::int AND k.article_id = d.article_id
+    )
+), signals AS (
+    UPDATE team_signals d SET player_id = @keep_id::int
+    WHERE d.player_id = @duplicate_id::int AND NOT EXISTS (
+        SELECT 1 FROM team_signals k
+        WHERE k.player_id = @keep_id::int AND k.article_id = d.article_id
+          AND k.team_id = d.team_id AND k.signal = d.signal
+    )
+), mappings AS (
+    UPDATE entity_mappings SET local_id = @keep_id::int
+    WHERE entity_type = 'player' AND local_id = @duplicate_id::int
+)
+UPDATE entity_aliases d SET local_id = @keep_id::int
+WHERE d.entity_type = 'player' AND d.local_id = @duplicate_id::int AND NOT EXISTS (
+    SELECT 1 FROM entity_aliases k WHERE k.entity_type = 'player' AND k.local_id = @keep_id::int AND k.alias = d.alias
+);
+
+-- name: DeleteMergedPlayer :exec
+-- DeleteMergedPlayer deletes a duplicate once merged, with the rows left
+-- behind. Enhanced stats and aliases do not cascade.
+WITH enhanced AS (
+    DELETE FROM player_match_stats_enhanced WHERE player_id = @id::int
+), aliases AS (
+    DELETE FROM entity_aliases WHERE entity_type = 'player' AND local_id = @id::int
+)
+DELETE FROM players WHERE id = @id::int;
+
+-- name: CountOrphanedPlayerStats :one
+-- CountOrphanedPlayerStats counts player stats whose player or match is
+-- missing.
+SELECT COUNT(*)::int AS orphans
+FROM player_match_stats s
+WHERE NOT EXISTS (SELECT 1 FROM players p WHERE p.id = s.player_id)
+   OR NOT EXISTS (SELECT 1 FROM matches m WHERE m.id = s.match_id);
+
+-- name: DeleteOrphanedPlayerStats :execrows
+DELETE FROM player_match_stats s
+WHERE NOT EXISTS (SELECT 1 FROM players p WHERE p.id = s.player_id)
+   OR NOT EXISTS (SELECT 1 FROM matches m WHERE m.id = s.match_id);
+
+-- name: ListMatchesWithoutTeams :many
+-- ListMatchesWithoutTeams returns matches whose home or away team is not
+-- stored, which no page or prediction can show.
+SELECT m.id, m.external_id, m.utc_date, m.status, m.home_team_id, m.away_team_id
+FROM matches m
+WHERE NOT EXISTS (SELECT 1 FROM teams t WHERE t.id = m.home_team_id)
+   OR NOT EXISTS (SELECT 1 FROM teams t WHERE t.id = m.away_team_id)
+ORDER BY m.utc_date, m.id;
+
+-- name: DeleteMatchesWithoutTeams :execrows
+DELETE FROM matches m
+WHERE NOT EXISTS (SELECT 1 FROM teams t WHERE t.id = m.home_team_id)
+   OR NOT EXISTS (SELECT 1 FROM teams t WHERE t.id = m.away_team_id);
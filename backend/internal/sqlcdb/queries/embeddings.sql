@@ -0,0 +1,70 @@
+-- name: ListMatchesToEmbed :many
+-- ListMatchesToEmbed returns real finished matches without an embedding from
+-- the given encoder version, oldest first, with what the encoder needs
+-- beyond earlier results: the stage and the season's last matchday.
+SELECT m.id, m.utc_date, m.home_team_id::int AS home_team_id, m.away_team_id::int AS away_team_id,
+       COALESCE(m.matchday, 0) AS matchday, COALESCE(m.stage, '') AS stage,
+       COALESCE((
+           SELECT MAX(o.matchday) FROM matches o
+           WHERE o.competition_id = m.competition_id AND o.season = m.season
+       ), 0)::int AS season_matchdays
+FROM matches m
+LEFT JOIN match_embeddings e ON e.match_id = m.id
+WHERE m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+  AND m.home_team_id IS NOT NULL
+  AND m.away_team_id IS NOT NULL
+  AND m.is_synthetic = FALSE
+  AND (e.match_id IS NULL OR e.encoder_version <> @encoder_version::text)
+ORDER BY m.utc_date, m.id
+LIMIT @row_limit::int;
+
+-- name: GetMatchToEmbed :one
+-- GetMatchToEmbed returns what the encoder needs about any match, finished
+-- or not, beyond earlier results.
+SELECT m.id, m.utc_date, m.home_team_id::int AS home_team_id, m.away_team_id::int AS away_team_id,
+       COALESCE(m.matchday, 0) AS matchday, COALESCE(m.stage, '') AS stage,
+       COALESCE((
+           SELECT MAX(o.matchday) FROM matches o
+           WHERE o.competition_id = m.competition_id AND o.season = m.season
+       ), 0)::int AS season_matchdays
+FROM matches m
+WHERE m.id = $1
+  AND m.home_team_id IS NOT NULL
+  AND m.away_team_id IS NOT NULL;
+
+-- name: GetMatchEmbedding :one
+SELECT embedding::text AS embedding, features
+FROM match_embeddings
+WHERE match_id = @match_id AND encoder_version = @encoder_version::text;
+
+-- name: UpsertMatchEmbedding :exec
+INSERT INTO match_embeddings (match_id, encoder_version, embedding, features)
+VALUES (@match_id, @encoder_version, @embedding::vector, @features)
+ON CONFLICT (match_id) DO UPDATE SET
+    encoder_version = EXCLUDED.encoder_version,
+    embedding = EXCLUDED.embedding,
+    features = EXCLUDED.features,
+    created_at = CURRENT_TIMESTAMP;
+
+-- name: ListSimilarMatches :many
+-- ListSimilarMatches returns the matches kicked off before a time whose
+-- embeddings from the encoder version are nearest the given one, nearest
+-- first, leaving out the match itself.
+SELECT m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season, m.utc_date,
+       ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+       at.external_id AS away_team_external_id, at.name AS away_team_name,
+       m.home_score::int AS home_score, m.away_score::int AS away_score,
+       COALESCE(m.winner, '') AS winner, e.features,
+       (e.embedding <-> @embedding::vector)::float8 AS distance
+FROM match_embeddings e
+JOIN matches m ON m.id = e.match_id
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE e.encoder_version = @encoder_version::text
+  AND m.id <> @match_id
+  AND m.utc_date < @before::timestamp
+ORDER BY e.embedding <-> @embedding::vector
+LIMIT @row_limit::int;
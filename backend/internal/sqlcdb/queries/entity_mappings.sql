@@ -0,0 +1,85 @@
+-- name: GetEntityMapping :one
+SELECT id, entity_type, provider, provider_id, provider_name, local_id, confidence, method, created_at, updated_at
+FROM entity_mappings
+WHERE entity_type = $1 AND provider = $2 AND provider_id = $3;
+
+-- name: GetEntityMappingByLocalID :one
+SELECT id, entity_type, provider, provider_id, provider_name, local_id, confidence, method, created_at, updated_at
+FROM entity_mappings
+WHERE entity_type = $1 AND provider = $2 AND local_id = $3
+ORDER BY confidence DESC
+LIMIT 1;
+
+-- name: UpsertEntityMapping :exec
+-- UpsertEntityMapping stores an automatic mapping. Manual mappings are never
+-- overwritten.
+INSERT INTO entity_mappings (entity_type, provider, provider_id, provider_name, local_id, confidence, method)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (entity_type, provider, provider_id) DO UPDATE
+SET provider_name = EXCLUDED.provider_name,
+    local_id = EXCLUDED.local_id,
+    confidence = EXCLUDED.confidence,
+    method = EXCLUDED.method
+WHERE entity_mappings.method <> 'manual';
+
+-- name: SetManualEntityMapping :one
+INSERT INTO entity_mappings (entity_type, provider, provider_id, provider_name, local_id, confidence, method)
+VALUES ($1, $2, $3, $4, $5, 1, 'manual')
+ON CONFLICT (entity_type, provider, provider_id) DO UPDATE
+SET provider_name = COALESCE(EXCLUDED.provider_name, entity_mappings.provider_name),
+    local_id = EXCLUDED.local_id,
+    confidence = 1,
+    method = 'manual'
+RETURNING id, entity_type, provider, provider_id, provider_name, local_id, confidence, method, created_at, updated_at;
+
+-- name: DeleteEntityMapping :execrows
+DELETE FROM entity_mappings WHERE entity_type = $1 AND provider = $2 AND provider_id = $3;
+
+-- name: ListEntityMappings :many
+-- ListEntityMappings returns mappings for review, least confident first.
+-- Empty entity_type and provider are not filtered on.
+SELECT id, entity_type, provider, provider_id, provider_name, local_id, confidence, method, created_at, updated_at
+FROM entity_mappings
+WHERE (@entity_type::text = '' OR entity_type = @entity_type::text)
+  AND (@provider::text = '' OR provider = @provider::text)
+  AND confidence <= @max_confidence::float8
+ORDER BY confidence, updated_at DESC
+LIMIT @row_limit::int;
+
+-- name: ListTeamMatchCandidates :many
+-- ListTeamMatchCandidates returns every team with its names and aliases for
+-- matching provider team names.
+SELECT
+    t.id, t.name,
+    COALESCE(t.short_name, '') AS short_name,
+    COALESCE(t.tla, '') AS tla,
+    COALESCE(array_agg(a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}')::text[] AS aliases
+FROM teams t
+LEFT JOIN entity_aliases a ON a.entity_type = 'team' AND a.local_id = t.id
+GROUP BY t.id, t.name, t.short_name, t.tla;
+
+-- name: ListPlayerMatchCandidates :many
+-- ListPlayerMatchCandidates returns a team's players with their aliases for
+-- matching provider player names.
+SELECT
+    p.id, p.name,
+    COALESCE(array_agg(a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}')::text[] AS aliases
+FROM players p
+LEFT JOIN entity_aliases a ON a.entity_type = 'player' AND a.local_id = p.id
+WHERE p.team_id = @team_id::int
+GROUP BY p.id, p.name;
+
+-- name: AddEntityAlias :one
+INSERT INTO entity_aliases (entity_type, local_id, alias)
+VALUES ($1, $2, $3)
+ON CONFLICT (entity_type, local_id, alias) DO UPDATE SET alias = EXCLUDED.alias
+RETURNING id;
+
+-- name: DeleteEntityAlias :execrows
+DELETE FROM entity_aliases WHERE id = $1;
+
+-- name: ListEntityAliases :many
+SELECT id, entity_type, local_id, alias, created_at
+FROM entity_aliases
+WHERE entity_type = $1 AND local_id = $2
+ORDER BY alias;
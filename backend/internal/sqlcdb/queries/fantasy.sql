@@ -0,0 +1,113 @@
+-- name: ListFantasyScoringRules :many
+SELECT action, position, points
+FROM fantasy_scoring_rules
+ORDER BY action, position;
+
+-- name: DeleteFantasyScoringRules :exec
+DELETE FROM fantasy_scoring_rules;
+
+-- name: InsertFantasyScoringRule :exec
+INSERT INTO fantasy_scoring_rules (action, position, points)
+VALUES ($1, $2, $3);
+
+-- name: DeleteAllFantasyPoints :exec
+DELETE FROM fantasy_player_points;
+
+-- name: ListMatchesToScore :many
+-- ListMatchesToScore returns finished matches that have player stats or
+-- events but no computed fantasy points yet.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE m.status = 'FINISHED'
+  AND (EXISTS (SELECT 1 FROM player_match_stats s WHERE s.match_id = m.id)
+       OR EXISTS (SELECT 1 FROM match_events e WHERE e.match_id = m.id AND e.player_id IS NOT NULL))
+  AND NOT EXISTS (SELECT 1 FROM fantasy_player_points f WHERE f.match_id = m.id)
+ORDER BY m.utc_date DESC
+LIMIT @row_limit::int;
+
+-- name: GetFantasyLines :many
+-- GetFantasyLines returns every player with stats or events in a match.
+-- Minutes fall back to the lineup when player_match_stats has none, and own
+-- goals to the recorded events.
+WITH involved AS (
+    SELECT player_id FROM player_match_stats WHERE match_id = @match_id::int
+    UNION
+    SELECT player_id FROM match_events WHERE match_id = @match_id::int AND player_id IS NOT NULL
+)
+SELECT
+    p.id AS player_id,
+    COALESCE(p.team_id, 0) AS team_id,
+    COALESCE(lp.position, p.position, '') AS position,
+    COALESCE(s.minutes_played, lp.minutes_played) AS minutes,
+    COALESCE(s.goals, 0) AS goals,
+    COALESCE(s.assists, 0) AS assists,
+    COALESCE(s.penalties, 0) AS penalties,
+    GREATEST(COALESCE(s.own_goals, 0), COUNT(e.id) FILTER (WHERE e.event_type = 'OWN_GOAL')) AS own_goals,
+    COUNT(e.id) FILTER (WHERE e.event_type = 'YELLOW_CARD') AS yellow_card,
+    COUNT(e.id) FILTER (WHERE e.event_type IN ('RED_CARD', 'YELLOW_RED_CARD')) AS red_card
+FROM involved i
+JOIN players p ON p.id = i.player_id
+LEFT JOIN player_match_stats s ON s.match_id = @match_id::int AND s.player_id = p.id
+LEFT JOIN match_lineups ml ON ml.match_id = @match_id::int AND ml.team_id = p.team_id
+LEFT JOIN match_lineup_players lp ON lp.match_lineup_id = ml.id AND lp.player_id = p.id
+LEFT JOIN match_events e ON e.match_id = @match_id::int AND e.player_id = p.id
+GROUP BY p.id, p.team_id, lp.position, p.position, s.minutes_played, lp.minutes_played, s.goals, s.assists, s.penalties, s.own_goals;
+
+-- name: DeleteFantasyPointsForMatch :exec
+DELETE FROM fantasy_player_points WHERE match_id = $1;
+
+-- name: InsertFantasyPoints :exec
+INSERT INTO fantasy_player_points (match_id, player_id, position, points, breakdown)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListTopFantasyForGameweek :many
+-- ListTopFantasyForGameweek ranks players by points in one matchday of a
+-- competition. An empty season means the competition's most recent season;
+-- otherwise season is the season start year.
+SELECT
+    p.external_id AS player_external_id, p.name, COALESCE(t.name, '') AS team_name, f.position,
+    SUM(f.points)::int AS points, COUNT(*) AS matches,
+    json_agg(f.breakdown) AS breakdowns
+FROM fantasy_player_points f
+JOIN matches m ON m.id = f.match_id
+JOIN competitions c ON c.id = m.competition_id
+JOIN players p ON p.id = f.player_id
+LEFT JOIN teams t ON t.id = p.team_id
+WHERE c.code = @competition_code::text
+  AND m.matchday = @matchday::int
+  AND ((@season::text = '' AND m.season = (
+            SELECT m2.season FROM matches m2
+            WHERE m2.competition_id = c.id
+            ORDER BY m2.utc_date DESC LIMIT 1))
+       OR m.season IN (
+            SELECT season FROM matches
+            GROUP BY season
+            HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text))
+GROUP BY p.id, p.external_id, p.name, t.name, f.position
+ORDER BY SUM(f.points) DESC, p.name
+LIMIT @row_limit::int;
+
+-- name: ListPlayerFantasyHistory :many
+SELECT
+    m.external_id AS match_external_id, COALESCE(c.code, '') AS competition_code,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date,
+    (CASE WHEN m.home_team_id = p.team_id THEN at.name ELSE ht.name END)::text AS opponent,
+    f.points, f.breakdown
+FROM fantasy_player_points f
+JOIN players p ON p.id = f.player_id
+JOIN matches m ON m.id = f.match_id
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+LEFT JOIN competitions c ON c.id = m.competition_id
+WHERE f.player_id = @player_id::int
+ORDER BY m.utc_date DESC
+LIMIT @row_limit::int;
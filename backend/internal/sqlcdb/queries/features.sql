@@ -0,0 +1,45 @@
+-- name: UpsertFeatureSnapshot :exec
+INSERT INTO feature_snapshots (
+    match_id, team_id, as_of, source, elo, form, form_points,
+    goals_for_avg, goals_against_avg, matches, unavailable,
+    comeback_rate, lead_hold_rate, new_manager, booking_risk, home_advantage,
+    league_strength, news_absences, manager_pressure, lineup_absences, late_goal_share
+) VALUES (
+    @match_id, @team_id, @as_of, @source, @elo, @form, @form_points::int,
+    sqlc.narg('goals_for_avg'), sqlc.narg('goals_against_avg'), @matches::int, @unavailable::int,
+    sqlc.narg('comeback_rate'), sqlc.narg('lead_hold_rate'), @new_manager, @booking_risk::int,
+    sqlc.narg('home_advantage'), sqlc.narg('league_strength'), @news_absences::int, @manager_pressure,
+    @lineup_absences::int, sqlc.narg('late_goal_share')
+)
+ON CONFLICT (match_id, team_id, as_of) DO UPDATE SET
+    source = EXCLUDED.source,
+    elo = EXCLUDED.elo,
+    form = EXCLUDED.form,
+    form_points = EXCLUDED.form_points,
+    goals_for_avg = EXCLUDED.goals_for_avg,
+    goals_against_avg = EXCLUDED.goals_against_avg,
+    matches = EXCLUDED.matches,
+    unavailable = EXCLUDED.unavailable,
+    comeback_rate = EXCLUDED.comeback_rate,
+    lead_hold_rate = EXCLUDED.lead_hold_rate,
+    new_manager = EXCLUDED.new_manager,
+    booking_risk = EXCLUDED.booking_risk,
+    home_advantage = EXCLUDED.home_advantage,
+    league_strength = EXCLUDED.league_strength,
+    news_absences = EXCLUDED.news_absences,
+    manager_pressure = EXCLUDED.manager_pressure,
+    lineup_absences = EXCLUDED.lineup_absences,
+    late_goal_share = EXCLUDED.late_goal_share;
+
+-- name: ListFeatureSnapshots :many
+-- ListFeatureSnapshots returns a match's snapshots, latest first.
+SELECT match_id, team_id, as_of, source, elo::float8 AS elo, form, form_points,
+       goals_for_avg::float8 AS goals_for_avg, goals_against_avg::float8 AS goals_against_avg,
+       matches, unavailable,
+       comeback_rate::float8 AS comeback_rate, lead_hold_rate::float8 AS lead_hold_rate,
+       new_manager, booking_risk, home_advantage::float8 AS home_advantage,
+       league_strength::float8 AS league_strength, news_absences, manager_pressure,
+       lineup_absences, late_goal_share::float8 AS late_goal_share
+FROM feature_snapshots
+WHERE match_id = @match_id
+ORDER BY as_of DESC, team_id;
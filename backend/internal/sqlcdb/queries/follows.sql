@@ -0,0 +1,38 @@
+-- name: AddFollow :exec
+INSERT INTO user_follows (user_id, entity_type, entity_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, entity_type, entity_id) DO NOTHING;
+
+-- name: RemoveTeamFollow :execrows
+DELETE FROM user_follows
+WHERE user_id = @user_id AND entity_type = 'team'
+  AND entity_id IN (SELECT id FROM teams WHERE external_id::text = @ref::text);
+
+-- name: RemoveCompetitionFollow :execrows
+DELETE FROM user_follows
+WHERE user_id = @user_id AND entity_type = 'competition'
+  AND entity_id IN (SELECT id FROM competitions WHERE code = UPPER(@ref::text));
+
+-- name: ListFollows :many
+SELECT f.entity_type, f.entity_id,
+       COALESCE(t.external_id::text, c.code, '') AS ref,
+       COALESCE(t.name, c.name, '') AS name,
+       f.created_at
+FROM user_follows f
+LEFT JOIN teams t ON f.entity_type = 'team' AND t.id = f.entity_id
+LEFT JOIN competitions c ON f.entity_type = 'competition' AND c.id = f.entity_id
+WHERE f.user_id = $1
+ORDER BY f.entity_type, f.created_at;
+
+-- name: ListUpcomingFollowInterest :many
+-- ListUpcomingFollowInterest counts, per competition with fixtures kicking
+-- off between from_date and until_date, how many follows the teams playing
+-- them and the competition have, most followed first.
+SELECT COALESCE(c.code, '')::text AS code, COUNT(f.id)::int AS follows
+FROM matches m
+JOIN competitions c ON c.id = m.competition_id
+JOIN user_follows f ON (f.entity_type = 'team' AND f.entity_id IN (m.home_team_id, m.away_team_id))
+    OR (f.entity_type = 'competition' AND f.entity_id = m.competition_id)
+WHERE m.status IN ('SCHEDULED', 'TIMED') AND m.utc_date >= @from_date AND m.utc_date < @until_date
+GROUP BY c.code
+ORDER BY follows DESC, code;
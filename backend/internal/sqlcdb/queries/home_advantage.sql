@@ -0,0 +1,85 @@
+-- name: DeleteHomeAdvantageStats :exec
+DELETE FROM home_advantage_stats;
+
+-- name: InsertHomeAdvantageStats :exec
+-- InsertHomeAdvantageStats computes home advantage over the latest
+-- window_matches finished matches of every team at each venue, per team in
+-- each competition and pooled per competition.
+WITH sides AS (
+    SELECT m.competition_id, m.home_team_id AS team_id, TRUE AS home, m.utc_date,
+           m.home_score AS goals_for, m.away_score AS goals_against
+    FROM matches m
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL AND m.away_score IS NOT NULL
+    UNION ALL
+    SELECT m.competition_id, m.away_team_id, FALSE, m.utc_date, m.away_score, m.home_score
+    FROM matches m
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL AND m.away_score IS NOT NULL
+),
+ranked AS (
+    SELECT competition_id, team_id, home,
+           ROW_NUMBER() OVER (PARTITION BY competition_id, team_id, home ORDER BY utc_date DESC) AS rn,
+           CASE WHEN goals_for > goals_against THEN 3 WHEN goals_for = goals_against THEN 1 ELSE 0 END AS points,
+           goals_for - goals_against AS goal_difference
+    FROM sides
+    WHERE competition_id IS NOT NULL
+)
+INSERT INTO home_advantage_stats (
+    competition_id, team_id, window_matches, home_matches, away_matches,
+    home_ppg, away_ppg, ppg_differential,
+    home_goal_difference, away_goal_difference, goal_differential,
+    previous_ppg_differential
+)
+SELECT competition_id, team_id, @window_matches::int,
+       COUNT(*) FILTER (WHERE latest AND home),
+       COUNT(*) FILTER (WHERE latest AND NOT home),
+       ROUND(AVG(points) FILTER (WHERE latest AND home), 2),
+       ROUND(AVG(points) FILTER (WHERE latest AND NOT home), 2),
+       ROUND(AVG(points) FILTER (WHERE latest AND home) - AVG(points) FILTER (WHERE latest AND NOT home), 2),
+       ROUND(AVG(goal_difference) FILTER (WHERE latest AND home), 2),
+       ROUND(AVG(goal_difference) FILTER (WHERE latest AND NOT home), 2),
+       ROUND(AVG(goal_difference) FILTER (WHERE latest AND home) - AVG(goal_difference) FILTER (WHERE latest AND NOT home), 2),
+       ROUND(AVG(points) FILTER (WHERE NOT latest AND home) - AVG(points) FILTER (WHERE NOT latest AND NOT home), 2)
+FROM (
+    SELECT *, rn <= @window_matches::int AS latest
+    FROM ranked
+    WHERE rn <= 2 * @window_matches::int
+) windows
+GROUP BY GROUPING SETS ((competition_id, team_id), (competition_id))
+HAVING COUNT(*) FILTER (WHERE latest AND home) > 0
+   AND COUNT(*) FILTER (WHERE latest AND NOT home) > 0;
+
+-- name: ListHomeAdvantageStats :many
+-- ListHomeAdvantageStats returns the stored home advantage of a window,
+-- optionally of one competition or team, each competition's own row first
+-- and then its teams by differential.
+SELECT c.code AS competition_code, c.name AS competition_name,
+       t.external_id AS team_external_id, t.name AS team_name,
+       h.window_matches, h.home_matches, h.away_matches,
+       h.home_ppg::float8 AS home_ppg, h.away_ppg::float8 AS away_ppg,
+       h.ppg_differential::float8 AS ppg_differential,
+       h.home_goal_difference::float8 AS home_goal_difference,
+       h.away_goal_difference::float8 AS away_goal_difference,
+       h.goal_differential::float8 AS goal_differential,
+       h.previous_ppg_differential::float8 AS previous_ppg_differential,
+       h.computed_at
+FROM home_advantage_stats h
+JOIN competitions c ON c.id = h.competition_id
+LEFT JOIN teams t ON t.id = h.team_id
+WHERE h.window_matches = @window_matches::int
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+  AND (@team_id::int = 0 OR h.team_id = @team_id::int)
+ORDER BY c.code, h.team_id IS NOT NULL, h.ppg_differential DESC, t.name;
+
+-- name: GetMatchHomeAdvantage :one
+-- GetMatchHomeAdvantage returns the home advantage of a match's home team
+-- and competition over the shortest stored window.
+SELECT c.window_matches,
+       t.ppg_differential::float8 AS team_ppg_differential,
+       c.ppg_differential::float8 AS competition_ppg_differential
+FROM matches m
+JOIN home_advantage_stats c ON c.competition_id = m.competition_id AND c.team_id IS NULL
+LEFT JOIN home_advantage_stats t ON t.competition_id = m.competition_id
+    AND t.team_id = m.home_team_id AND t.window_matches = c.window_matches
+WHERE m.id = @match_id
+ORDER BY c.window_matches
+LIMIT 1;
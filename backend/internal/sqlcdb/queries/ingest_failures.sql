@@ -0,0 +1,44 @@
+-- name: RecordIngestFailure :exec
+-- RecordIngestFailure stores a failed row, or refreshes the open failure
+-- for the same row and bumps its attempt count.
+INSERT INTO ingest_failures (entity_type, external_ref, payload, error)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (entity_type, external_ref) WHERE resolved_at IS NULL DO UPDATE SET
+    payload = EXCLUDED.payload,
+    error = EXCLUDED.error,
+    attempts = ingest_failures.attempts + 1,
+    last_failed_at = CURRENT_TIMESTAMP;
+
+-- name: ListOpenIngestFailures :many
+SELECT id, entity_type, external_ref, payload, error, attempts, first_failed_at, last_failed_at, resolved_at
+FROM ingest_failures
+WHERE resolved_at IS NULL
+  AND (@entity_type::text = '' OR entity_type = @entity_type::text)
+  AND attempts < @max_attempts::int
+ORDER BY first_failed_at
+LIMIT @row_limit::int;
+
+-- name: ListRecentIngestFailures :many
+SELECT id, entity_type, external_ref, payload, error, attempts, first_failed_at, last_failed_at, resolved_at
+FROM ingest_failures
+WHERE (@entity_type::text = '' OR entity_type = @entity_type::text)
+  AND (NOT @open_only::bool OR resolved_at IS NULL)
+ORDER BY last_failed_at DESC
+LIMIT @row_limit::int;
+
+-- name: ResolveIngestFailure :exec
+UPDATE ingest_failures SET resolved_at = CURRENT_TIMESTAMP WHERE id = $1;
+
+-- name: MarkIngestFailureRetryFailed :exec
+UPDATE ingest_failures
+SET attempts = attempts + 1, error = @error, last_failed_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: CountIngestFailures :many
+SELECT entity_type,
+       COUNT(*) FILTER (WHERE resolved_at IS NULL) AS open,
+       COUNT(*) FILTER (WHERE resolved_at IS NOT NULL) AS resolved,
+       COALESCE(SUM(attempts), 0)::int AS attempts
+FROM ingest_failures
+GROUP BY entity_type
+ORDER BY entity_type;
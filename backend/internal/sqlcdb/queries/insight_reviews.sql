@@ -0,0 +1,22 @@
+-- name: InsertInsightReview :exec
+-- InsertInsightReview queues a generated text for review, unless the same
+-- text is already queued.
+INSERT INTO insight_reviews (kind, match_id, subject, headline, body, generated_by, issues, published, content_hash)
+VALUES (@kind, sqlc.narg('match_id'), @subject, @headline, @body, @generated_by, @issues, @published, @content_hash)
+ON CONFLICT (kind, content_hash) DO NOTHING;
+
+-- name: ListInsightReviews :many
+-- ListInsightReviews returns queued texts with a status, newest first,
+-- optionally of one kind.
+SELECT r.id, r.kind, r.match_id, m.external_id AS match_external_id, r.subject, r.headline, r.body,
+       r.generated_by, r.issues, r.published, r.status, r.reviewed_at, r.created_at
+FROM insight_reviews r
+LEFT JOIN matches m ON m.id = r.match_id
+WHERE r.status = @status
+  AND (sqlc.narg('kind')::text IS NULL OR r.kind = sqlc.narg('kind'))
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT @row_limit::int;
+
+-- name: ResolveInsightReview :execrows
+UPDATE insight_reviews SET status = @status, reviewed_at = @reviewed_at
+WHERE id = @id;
@@ -0,0 +1,69 @@
+-- name: ListTeamsDueForFacts :many
+-- ListTeamsDueForFacts returns teams whose facts are missing, older than a
+-- finished match of theirs or older than stale_before, most recently active
+-- first.
+SELECT t.id::int AS team_id
+FROM teams t
+JOIN LATERAL (
+    SELECT MAX(m.updated_at) AS last_update
+    FROM matches m
+    WHERE m.status = 'FINISHED'
+      AND (m.home_team_id = t.id OR m.away_team_id = t.id)
+) r ON r.last_update IS NOT NULL
+LEFT JOIN insights_fact_runs fr ON fr.team_id = t.id
+WHERE fr.computed_at IS NULL
+   OR fr.computed_at < r.last_update
+   OR fr.computed_at < @stale_before::timestamp
+ORDER BY r.last_update DESC
+LIMIT @row_limit::int;
+
+-- name: GetTeamTotals :one
+-- GetTeamTotals counts a team's stored finished matches, wins and goals.
+SELECT
+    COUNT(*)::int AS matches,
+    COUNT(*) FILTER (WHERE (m.home_team_id = @team_id::int AND m.home_score > m.away_score)
+                        OR (m.away_team_id = @team_id::int AND m.away_score > m.home_score))::int AS wins,
+    COALESCE(SUM(CASE WHEN m.home_team_id = @team_id::int THEN m.home_score ELSE m.away_score END), 0)::int AS goals
+FROM matches m
+WHERE m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+  AND (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int);
+
+-- name: ListTeamPlayerAppearances :many
+-- ListTeamPlayerAppearances returns every stored finished-match appearance of
+-- a team's current players, grouped by player and most recent first.
+SELECT p.id AS player_id, p.name AS player_name, m.id AS match_id, m.utc_date,
+       COALESCE(pms.goals, 0)::int AS goals
+FROM players p
+JOIN player_match_stats pms ON pms.player_id = p.id
+JOIN matches m ON m.id = pms.match_id
+WHERE p.team_id = @team_id::int
+  AND m.status = 'FINISHED'
+  AND COALESCE(pms.minutes_played, 1) > 0
+ORDER BY p.id, m.utc_date DESC;
+
+-- name: DeleteTeamFacts :exec
+DELETE FROM insights_facts WHERE team_id = @team_id::int;
+
+-- name: InsertInsightsFact :exec
+INSERT INTO insights_facts (
+    team_id, player_id, kind, value, target, description, since_date, last_match_id
+) VALUES (
+    @team_id::int, sqlc.narg('player_id'), @kind::text, @value::int, sqlc.narg('target'),
+    @description::text, sqlc.narg('since_date'), sqlc.narg('last_match_id')
+);
+
+-- name: MarkTeamFactsComputed :exec
+INSERT INTO insights_fact_runs (team_id, computed_at)
+VALUES (@team_id::int, CURRENT_TIMESTAMP)
+ON CONFLICT (team_id) DO UPDATE SET computed_at = EXCLUDED.computed_at;
+
+-- name: ListTeamFacts :many
+-- ListTeamFacts returns a team's facts and its players', team facts first.
+SELECT f.id, f.team_id, f.player_id, COALESCE(p.name, '') AS player_name,
+       f.kind, f.value, f.target, f.description, f.since_date, f.last_match_id, f.computed_at
+FROM insights_facts f
+LEFT JOIN players p ON p.id = f.player_id
+WHERE f.team_id = @team_id::int
+ORDER BY f.player_id IS NOT NULL, f.value DESC, f.id;
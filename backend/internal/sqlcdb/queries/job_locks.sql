@@ -0,0 +1,19 @@
+-- name: AcquireJobLock :one
+-- AcquireJobLock takes the lock if it is free, expired or already owned by
+-- owner, returning no row if someone else holds it. Expiry uses the
+-- database clock so replicas' clocks need not agree.
+INSERT INTO job_locks (name, owner, acquired_at, expires_at)
+VALUES (@name, @owner, NOW(), NOW() + make_interval(secs => @ttl_seconds::float8))
+ON CONFLICT (name) DO UPDATE SET
+    owner = EXCLUDED.owner,
+    acquired_at = EXCLUDED.acquired_at,
+    expires_at = EXCLUDED.expires_at
+WHERE job_locks.expires_at < NOW() OR job_locks.owner = EXCLUDED.owner
+RETURNING name;
+
+-- name: RenewJobLock :execrows
+UPDATE job_locks SET expires_at = NOW() + make_interval(secs => @ttl_seconds::float8)
+WHERE name = @name AND owner = @owner AND expires_at >= NOW();
+
+-- name: ReleaseJobLock :exec
+DELETE FROM job_locks WHERE name = @name AND owner = @owner;
@@ -0,0 +1,120 @@
+-- name: UpsertUserPrediction :exec
+INSERT INTO user_predictions (user_id, match_id, home_goals, away_goals)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, match_id) DO UPDATE SET
+    home_goals = EXCLUDED.home_goals,
+    away_goals = EXCLUDED.away_goals;
+
+-- name: ListUserPredictions :many
+SELECT up.id, m.id AS match_id, m.external_id AS match_external_id,
+       ht.name AS home_team_name, at.name AS away_team_name, m.utc_date,
+       up.home_goals, up.away_goals, up.points, up.outcome_correct, up.exact_score, up.settled_at
+FROM user_predictions up
+JOIN matches m ON m.id = up.match_id
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+WHERE up.user_id = @user_id
+ORDER BY m.utc_date DESC
+LIMIT @row_limit::int;
+
+-- name: SettleUserPredictions :execrows
+-- SettleUserPredictions scores user predictions for finished matches on the
+-- score after 90 minutes, leaving out extra-time goals.
+UPDATE user_predictions up
+SET
+    exact_score = (up.home_goals = rt.home AND up.away_goals = rt.away),
+    outcome_correct = (SIGN(up.home_goals - up.away_goals) = SIGN(rt.home - rt.away)),
+    points = CASE
+        WHEN up.home_goals = rt.home AND up.away_goals = rt.away THEN @exact_score_points::int
+        WHEN SIGN(up.home_goals - up.away_goals) = SIGN(rt.home - rt.away) THEN @outcome_points::int
+        ELSE 0
+    END,
+    settled_at = CURRENT_TIMESTAMP
+FROM matches m
+CROSS JOIN LATERAL (
+    SELECT m.home_score - COALESCE(m.extra_time_home_score, 0) AS home,
+           m.away_score - COALESCE(m.extra_time_away_score, 0) AS away
+) rt
+WHERE m.id = up.match_id
+  AND up.settled_at IS NULL
+  AND m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL;
+
+-- name: CreateLeague :one
+INSERT INTO leagues (name, owner_id, competition_id, invite_code)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at;
+
+-- name: AddLeagueMember :exec
+INSERT INTO league_members (league_id, user_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: GetLeagueIDByInviteCode :one
+SELECT id FROM leagues WHERE invite_code = $1;
+
+-- name: GetLeagueForMember :one
+SELECT l.id, l.name, l.owner_id, COALESCE(c.code, '') AS competition_code, l.invite_code,
+       (SELECT COUNT(*) FROM league_members WHERE league_id = l.id)::int AS members,
+       l.created_at
+FROM leagues l
+LEFT JOIN competitions c ON c.id = l.competition_id
+WHERE l.id = @id
+  AND EXISTS (SELECT 1 FROM league_members WHERE league_id = l.id AND user_id = @user_id::int);
+
+-- name: ListLeaguesForUser :many
+SELECT l.id, l.name, l.owner_id, COALESCE(c.code, '') AS competition_code, l.invite_code,
+       (SELECT COUNT(*) FROM league_members WHERE league_id = l.id)::int AS members,
+       l.created_at
+FROM leagues l
+LEFT JOIN competitions c ON c.id = l.competition_id
+JOIN league_members lm ON lm.league_id = l.id
+WHERE lm.user_id = @user_id
+ORDER BY l.created_at;
+
+-- name: GetLeagueTable :many
+-- GetLeagueTable scores league members on settled predictions for fixtures
+-- after the league was created (and in its competition, if set). The model
+-- is scored on the same fixtures any member predicted and comes first with
+-- a NULL user_id.
+WITH league AS (
+    SELECT id, competition_id, created_at FROM leagues WHERE id = @league_id::int
+),
+scored AS (
+    SELECT up.user_id, up.match_id, up.points, up.exact_score, up.outcome_correct
+    FROM user_predictions up
+    JOIN league_members lm ON lm.user_id = up.user_id
+    JOIN league l ON l.id = lm.league_id
+    JOIN matches m ON m.id = up.match_id
+    WHERE up.settled_at IS NOT NULL
+      AND m.utc_date >= l.created_at
+      AND (l.competition_id IS NULL OR m.competition_id = l.competition_id)
+)
+SELECT NULL::int AS user_id, 'Model'::text AS name, true AS is_model,
+       COUNT(ph.match_id) AS predictions,
+       COALESCE(SUM(CASE
+           WHEN ROUND(ph.predicted_team_a_goals) = ph.actual_team_a_goals
+            AND ROUND(ph.predicted_team_b_goals) = ph.actual_team_b_goals THEN @exact_score_points::int
+           WHEN ph.prediction_correct THEN @outcome_points::int
+           ELSE 0
+       END), 0)::int AS points,
+       COUNT(*) FILTER (WHERE ROUND(ph.predicted_team_a_goals) = ph.actual_team_a_goals
+                          AND ROUND(ph.predicted_team_b_goals) = ph.actual_team_b_goals) AS exact_scores,
+       COUNT(*) FILTER (WHERE ph.prediction_correct) AS correct_results
+FROM prediction_history ph
+WHERE ph.actual_team_a_goals IS NOT NULL
+  AND ph.is_primary
+  AND ph.is_final
+  AND ph.match_id IN (SELECT DISTINCT match_id FROM scored)
+
+UNION ALL
+
+SELECT u.id, COALESCE(NULLIF(u.name, ''), 'user-' || u.id), false,
+       COUNT(s.match_id), COALESCE(SUM(s.points), 0)::int,
+       COUNT(*) FILTER (WHERE s.exact_score),
+       COUNT(*) FILTER (WHERE s.outcome_correct)
+FROM league_members lm
+JOIN users u ON u.id = lm.user_id
+LEFT JOIN scored s ON s.user_id = u.id
+WHERE lm.league_id = @league_id::int
+GROUP BY u.id, u.name;
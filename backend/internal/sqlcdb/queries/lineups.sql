@@ -0,0 +1,33 @@
+-- name: UpsertMatchLineup :one
+INSERT INTO match_lineups (match_id, team_id, is_home, formation, coach_id)
+VALUES (@match_id::int, @team_id::int, @is_home, sqlc.narg('formation'), sqlc.narg('coach_id')::int)
+ON CONFLICT (match_id, team_id) DO UPDATE SET
+    is_home = EXCLUDED.is_home,
+    formation = EXCLUDED.formation,
+    coach_id = COALESCE(EXCLUDED.coach_id, match_lineups.coach_id)
+RETURNING id;
+
+-- name: DeleteMatchLineupPlayers :exec
+DELETE FROM match_lineup_players WHERE match_lineup_id = @match_lineup_id::int;
+
+-- name: InsertMatchLineupPlayer :exec
+INSERT INTO match_lineup_players (match_lineup_id, player_id, role, position, minutes_played)
+VALUES (@match_lineup_id::int, @player_id::int, @role, sqlc.narg('position'), @minutes_played::int)
+ON CONFLICT (match_lineup_id, player_id) DO UPDATE SET
+    role = EXCLUDED.role,
+    position = EXCLUDED.position,
+    minutes_played = EXCLUDED.minutes_played;
+
+-- name: AggregateLineupMinutes :many
+-- AggregateLineupMinutes copies lineup minutes into player_match_stats for
+-- every player who got on the pitch, and returns the matches whose minutes
+-- changed.
+INSERT INTO player_match_stats (match_id, player_id, minutes_played)
+SELECT ml.match_id, lp.player_id, lp.minutes_played
+FROM match_lineup_players lp
+JOIN match_lineups ml ON ml.id = lp.match_lineup_id
+WHERE lp.minutes_played > 0
+ON CONFLICT (match_id, player_id) DO UPDATE SET
+    minutes_played = EXCLUDED.minutes_played
+WHERE player_match_stats.minutes_played IS DISTINCT FROM EXCLUDED.minutes_played
+RETURNING match_id::int;
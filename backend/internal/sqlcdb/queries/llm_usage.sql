@@ -0,0 +1,18 @@
+-- name: InsertLLMUsage :exec
+INSERT INTO llm_usage (task, provider, model, input_tokens, output_tokens, cost_usd, latency_ms, error, created_at)
+VALUES (@task, @provider, @model, @input_tokens::int, @output_tokens::int, @cost_usd::float8, @latency_ms::int, sqlc.narg('error'), @created_at);
+
+-- name: ListLLMUsageSummary :many
+-- ListLLMUsageSummary totals requests since a time per task and model,
+-- costliest first. Failures are requests the provider did not answer.
+SELECT task, provider, model,
+       COUNT(*)::int AS requests,
+       COUNT(error)::int AS failures,
+       SUM(input_tokens)::int AS input_tokens,
+       SUM(output_tokens)::int AS output_tokens,
+       SUM(cost_usd)::float8 AS cost_usd,
+       AVG(latency_ms)::float8 AS avg_latency_ms
+FROM llm_usage
+WHERE created_at >= @since
+GROUP BY task, provider, model
+ORDER BY cost_usd DESC, requests DESC, task, provider, model;
@@ -0,0 +1,24 @@
+-- name: ListPendingScheduleChanges :many
+-- ListPendingScheduleChanges returns the postponements, cancellations,
+-- reinstatements and reschedules not yet announced, oldest first.
+SELECT h.id, h.match_id, m.external_id, COALESCE(c.code, '') AS competition_code,
+       ht.name AS home_team_name, at.name AS away_team_name,
+       h.kind, h.from_status, h.to_status, h.from_utc_date, h.to_utc_date, h.changed_at
+FROM match_status_history h
+JOIN matches m ON m.id = h.match_id
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+LEFT JOIN competitions c ON c.id = m.competition_id
+WHERE h.notified_at IS NULL AND h.kind <> 'status'
+ORDER BY h.changed_at, h.id
+LIMIT @row_limit::int;
+
+-- name: MarkScheduleChangesNotified :exec
+UPDATE match_status_history SET notified_at = CURRENT_TIMESTAMP
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: ListMatchStatusHistory :many
+SELECT id, match_id, kind, from_status, to_status, from_utc_date, to_utc_date, changed_at, notified_at
+FROM match_status_history
+WHERE match_id = @match_id::int
+ORDER BY changed_at, id;
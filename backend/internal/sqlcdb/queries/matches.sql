@@ -0,0 +1,735 @@
+-- name: GetMatchWithTeamsByExternalID :one
+SELECT
+    m.id, m.external_id, m.status, m.utc_date, COALESCE(m.matchday, 0) AS matchday,
+    ht.id AS home_team_id, ht.name AS home_team_name, ht.external_id AS home_team_external_id,
+    at.id AS away_team_id, at.name AS away_team_name, at.external_id AS away_team_external_id
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+WHERE m.external_id = $1;
+
+-- name: GetMatchWithTeamsByID :one
+SELECT
+    m.id, m.external_id, m.status, m.utc_date, COALESCE(m.matchday, 0) AS matchday,
+    ht.id AS home_team_id, ht.name AS home_team_name, ht.external_id AS home_team_external_id,
+    at.id AS away_team_id, at.name AS away_team_name, at.external_id AS away_team_external_id
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+WHERE m.id = $1;
+
+-- name: ListHeadToHead :many
+-- ListHeadToHead returns finished meetings between two teams identified by
+-- external ID, regardless of venue, most recent first. Empty
+-- competition_type (LEAGUE, CUP), team_type (CLUB, NATIONAL) and
+-- competition_code are not filtered on.
+SELECT
+    m.season,
+    m.utc_date,
+    COALESCE(c.code, '') AS competition_code,
+    COALESCE(c.name, '') AS competition_name,
+    m.home_score,
+    m.away_score,
+    COALESCE(m.winner, '') AS winner,
+    th.external_id AS home_external_id,
+    ta.external_id AS away_external_id
+FROM matches m
+JOIN teams th ON m.home_team_id = th.id
+JOIN teams ta ON m.away_team_id = ta.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE ((th.external_id = @team_a AND ta.external_id = @team_b)
+    OR (th.external_id = @team_b AND ta.external_id = @team_a))
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+  AND (@competition_type::text = '' OR c.type = @competition_type::text)
+  AND (@team_type::text = '' OR c.team_type = @team_type::text)
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+ORDER BY m.utc_date DESC
+LIMIT @row_limit::int;
+
+-- name: GetMatchSummaryByID :one
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE m.id = $1;
+
+-- name: GetMatchSummaryByExternalID :one
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE m.external_id = $1;
+
+-- name: ListTeamMatches :many
+-- ListTeamMatches returns a team's matches, most recent first or, with
+-- oldest_first, soonest first. venue is "home", "away" or "" for both; empty
+-- season, competition_code, status, competition_type and team_type are not
+-- filtered on. season is the season start year.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE ((@venue::text <> 'away' AND m.home_team_id = @team_id::int)
+    OR (@venue::text <> 'home' AND m.away_team_id = @team_id::int))
+  AND (@season::text = '' OR m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text))
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+  AND (@status::text = '' OR m.status = @status::text)
+  AND (@competition_type::text = '' OR c.type = @competition_type::text)
+  AND (@team_type::text = '' OR c.team_type = @team_type::text)
+ORDER BY CASE WHEN @oldest_first::bool THEN m.utc_date END, m.utc_date DESC
+LIMIT @row_limit::int;
+
+-- name: GetResultsTable :many
+-- GetResultsTable aggregates finished matches of a competition season per
+-- team. season is the season start year.
+WITH results AS (
+    SELECT m.home_team_id AS team_id, m.home_score AS gf, m.away_score AS ga
+    FROM matches m
+    JOIN competitions c ON m.competition_id = c.id
+    WHERE c.code = @competition_code::text AND m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+    UNION ALL
+    SELECT m.away_team_id, m.away_score, m.home_score
+    FROM matches m
+    JOIN competitions c ON m.competition_id = c.id
+    WHERE c.code = @competition_code::text AND m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+)
+SELECT
+    t.id AS team_id, t.external_id AS team_external_id, t.name AS team_name,
+    COUNT(*) AS played,
+    SUM(CASE WHEN r.gf > r.ga THEN 1 ELSE 0 END)::int AS won,
+    SUM(CASE WHEN r.gf = r.ga THEN 1 ELSE 0 END)::int AS draw,
+    SUM(CASE WHEN r.gf < r.ga THEN 1 ELSE 0 END)::int AS lost,
+    SUM(r.gf)::int AS goals_for,
+    SUM(r.ga)::int AS goals_against
+FROM results r
+JOIN teams t ON t.id = r.team_id
+GROUP BY t.id, t.external_id, t.name;
+
+-- name: ListFinishedMatchesSince :many
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL AND m.utc_date >= @since
+ORDER BY m.utc_date
+LIMIT @row_limit::int;
+
+-- name: ListFeedMatches :many
+-- ListFeedMatches returns matches in [from_date, to_date) that involve any
+-- of the given teams or belong to any of the given competitions.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE (m.home_team_id = ANY(@team_ids::int[])
+       OR m.away_team_id = ANY(@team_ids::int[])
+       OR m.competition_id = ANY(@competition_ids::int[]))
+  AND m.utc_date >= @from_date AND m.utc_date < @to_date
+  AND m.status = ANY(@statuses::text[])
+ORDER BY CASE WHEN @descending::bool THEN m.utc_date END DESC, m.utc_date
+LIMIT @row_limit::int;
+
+-- name: UpsertMatch :execrows
+-- UpsertMatch inserts or updates a match, resolving the competition and
+-- teams by the source's external IDs. No row is affected if any of them is missing. A
+-- new kickoff time, or the match being postponed, cancelled or reinstated,
+-- bumps schedule_sequence.
+INSERT INTO matches (
+    external_id, competition_id, season, home_team_id, away_team_id,
+    utc_date, status, matchday, home_score, away_score,
+    half_time_home_score, half_time_away_score, extra_time_home_score, extra_time_away_score,
+    penalties_home_score, penalties_away_score, winner, duration, stage, group_name, referee, venue, source
+)
+SELECT @external_id::int, c.id, @season::text, ht.id, at.id,
+       @utc_date::timestamp, @status::text, @matchday::int,
+       sqlc.narg('home_score')::int, sqlc.narg('away_score')::int,
+       sqlc.narg('half_time_home_score')::int, sqlc.narg('half_time_away_score')::int,
+       sqlc.narg('extra_time_home_score')::int, sqlc.narg('extra_time_away_score')::int,
+       sqlc.narg('penalties_home_score')::int, sqlc.narg('penalties_away_score')::int,
+       sqlc.narg('winner')::text, sqlc.narg('duration')::text,
+       sqlc.narg('stage')::text, sqlc.narg('group_name')::text,
+       sqlc.narg('referee')::text, sqlc.narg('venue')::text, c.source
+FROM competitions c
+CROSS JOIN teams ht
+CROSS JOIN teams at
+WHERE c.external_id = @competition_external_id
+  AND ht.external_id = @home_team_external_id
+  AND at.external_id = @away_team_external_id
+  AND c.source = @source::text AND ht.source = c.source AND at.source = c.source
+ON CONFLICT (source, external_id) DO UPDATE
+SET utc_date = EXCLUDED.utc_date,
+    schedule_sequence = matches.schedule_sequence + CASE
+        WHEN matches.utc_date <> EXCLUDED.utc_date
+          OR (matches.status IN ('POSTPONED', 'CANCELLED')) <> (EXCLUDED.status IN ('POSTPONED', 'CANCELLED'))
+        THEN 1 ELSE 0 END,
+    status = EXCLUDED.status,
+    home_score = EXCLUDED.home_score,
+    away_score = EXCLUDED.away_score,
+    half_time_home_score = EXCLUDED.half_time_home_score,
+    half_time_away_score = EXCLUDED.half_time_away_score,
+    extra_time_home_score = EXCLUDED.extra_time_home_score,
+    extra_time_away_score = EXCLUDED.extra_time_away_score,
+    penalties_home_score = EXCLUDED.penalties_home_score,
+    penalties_away_score = EXCLUDED.penalties_away_score,
+    winner = EXCLUDED.winner,
+    duration = COALESCE(EXCLUDED.duration, matches.duration),
+    stage = COALESCE(EXCLUDED.stage, matches.stage),
+    group_name = COALESCE(EXCLUDED.group_name, matches.group_name),
+    referee = COALESCE(EXCLUDED.referee, matches.referee),
+    venue = COALESCE(EXCLUDED.venue, matches.venue),
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: UpsertMatches :many
+-- UpsertMatches upserts many matches in one statement, resolving
+-- competitions and teams by external ID like UpsertMatch. The arrays are
+-- parallel and must not repeat an external ID; matches whose competition or
+-- teams are missing are skipped and absent from the returned IDs.
+INSERT INTO matches (
+    external_id, competition_id, season, home_team_id, away_team_id,
+    utc_date, status, matchday, home_score, away_score,
+    half_time_home_score, half_time_away_score, extra_time_home_score, extra_time_away_score,
+    penalties_home_score, penalties_away_score, winner, duration, stage, group_name, referee, venue, source
+)
+SELECT m.external_id, c.id, m.season, ht.id, at.id,
+       m.utc_date, m.status, m.matchday, m.home_score, m.away_score,
+       m.half_time_home_score, m.half_time_away_score, m.extra_time_home_score, m.extra_time_away_score,
+       m.penalties_home_score, m.penalties_away_score,
+       NULLIF(m.winner, ''), NULLIF(m.duration, ''), NULLIF(m.stage, ''), NULLIF(m.group_name, ''),
+       NULLIF(m.referee, ''), NULLIF(m.venue, ''), c.source
+FROM unnest(
+    @external_ids::int[], @seasons::text[], @utc_dates::timestamp[],
+    @statuses::text[], @matchdays::int[], @home_scores::int[], @away_scores::int[],
+    @half_time_home_scores::int[], @half_time_away_scores::int[],
+    @extra_time_home_scores::int[], @extra_time_away_scores::int[],
+    @penalties_home_scores::int[], @penalties_away_scores::int[],
+    @winners::text[], @durations::text[], @stages::text[], @group_names::text[],
+    @referees::text[], @venues::text[],
+    @competition_external_ids::int[], @home_team_external_ids::int[], @away_team_external_ids::int[]
+) AS m(external_id, season, utc_date, status, matchday, home_score, away_score,
+       half_time_home_score, half_time_away_score, extra_time_home_score, extra_time_away_score,
+       penalties_home_score, penalties_away_score, winner, duration, stage, group_name, referee, venue,
+       competition_external_id, home_team_external_id, away_team_external_id)
+CROSS JOIN competitions c
+CROSS JOIN teams ht
+CROSS JOIN teams at
+WHERE c.external_id = m.competition_external_id
+  AND ht.external_id = m.home_team_external_id
+  AND at.external_id = m.away_team_external_id
+  AND c.source = @source::text AND ht.source = c.source AND at.source = c.source
+ON CONFLICT (source, external_id) DO UPDATE
+SET utc_date = EXCLUDED.utc_date,
+    schedule_sequence = matches.schedule_sequence + CASE
+        WHEN matches.utc_date <> EXCLUDED.utc_date
+          OR (matches.status IN ('POSTPONED', 'CANCELLED')) <> (EXCLUDED.status IN ('POSTPONED', 'CANCELLED'))
+        THEN 1 ELSE 0 END,
+    status = EXCLUDED.status,
+    home_score = EXCLUDED.home_score,
+    away_score = EXCLUDED.away_score,
+    half_time_home_score = EXCLUDED.half_time_home_score,
+    half_time_away_score = EXCLUDED.half_time_away_score,
+    extra_time_home_score = EXCLUDED.extra_time_home_score,
+    extra_time_away_score = EXCLUDED.extra_time_away_score,
+    penalties_home_score = EXCLUDED.penalties_home_score,
+    penalties_away_score = EXCLUDED.penalties_away_score,
+    winner = EXCLUDED.winner,
+    duration = COALESCE(EXCLUDED.duration, matches.duration),
+    stage = COALESCE(EXCLUDED.stage, matches.stage),
+    group_name = COALESCE(EXCLUDED.group_name, matches.group_name),
+    referee = COALESCE(EXCLUDED.referee, matches.referee),
+    venue = COALESCE(EXCLUDED.venue, matches.venue),
+    updated_at = CURRENT_TIMESTAMP
+RETURNING external_id;
+
+-- name: GetMatchDetailByID :one
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    COALESCE(m.stage, '') AS stage, COALESCE(m.group_name, '') AS group_name,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    COALESCE(ht.short_name, '') AS home_team_short_name, COALESCE(ht.tla, '') AS home_team_tla,
+    COALESCE(ht.crest_url, '') AS home_team_crest,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    COALESCE(at.short_name, '') AS away_team_short_name, COALESCE(at.tla, '') AS away_team_tla,
+    COALESCE(at.crest_url, '') AS away_team_crest,
+    m.home_score, m.away_score, m.half_time_home_score, m.half_time_away_score,
+    m.extra_time_home_score, m.extra_time_away_score, m.penalties_home_score, m.penalties_away_score,
+    COALESCE(m.winner, '') AS winner, COALESCE(m.duration, '') AS duration,
+    COALESCE(m.decided_by, '') AS decided_by,
+    COALESCE(m.referee, '') AS referee, COALESCE(m.venue, ht.venue, '') AS venue,
+    m.travel_distance_km::float8 AS travel_distance_km
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE m.id = $1;
+
+-- name: GetMatchDetailByExternalID :one
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    COALESCE(m.stage, '') AS stage, COALESCE(m.group_name, '') AS group_name,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    COALESCE(ht.short_name, '') AS home_team_short_name, COALESCE(ht.tla, '') AS home_team_tla,
+    COALESCE(ht.crest_url, '') AS home_team_crest,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    COALESCE(at.short_name, '') AS away_team_short_name, COALESCE(at.tla, '') AS away_team_tla,
+    COALESCE(at.crest_url, '') AS away_team_crest,
+    m.home_score, m.away_score, m.half_time_home_score, m.half_time_away_score,
+    m.extra_time_home_score, m.extra_time_away_score, m.penalties_home_score, m.penalties_away_score,
+    COALESCE(m.winner, '') AS winner, COALESCE(m.duration, '') AS duration,
+    COALESCE(m.decided_by, '') AS decided_by,
+    COALESCE(m.referee, '') AS referee, COALESCE(m.venue, ht.venue, '') AS venue,
+    m.travel_distance_km::float8 AS travel_distance_km
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE m.external_id = $1;
+
+-- name: ListMatchGoals :many
+-- ListMatchGoals returns the goal events recorded for a match in order.
+SELECT
+    e.event_type,
+    COALESCE(e.minute, 0) AS minute,
+    e.injury_time,
+    COALESCE(t.external_id, 0) AS team_external_id,
+    COALESCE(p.name, '') AS scorer
+FROM match_events e
+LEFT JOIN teams t ON t.id = e.team_id
+LEFT JOIN players p ON p.id = e.player_id
+WHERE e.match_id = @match_id::int
+  AND e.event_type IN ('GOAL', 'OWN_GOAL', 'PENALTY')
+ORDER BY e.minute, COALESCE(e.injury_time, 0), e.id;
+
+-- name: ListMatchTimelineEvents :many
+-- ListMatchTimelineEvents returns every event recorded for a match in order,
+-- with the side of the team it counts for.
+SELECT
+    e.event_type,
+    COALESCE(e.minute, 0) AS minute,
+    e.injury_time,
+    COALESCE(t.external_id, 0) AS team_external_id,
+    COALESCE(e.team_id = m.home_team_id, false) AS is_home,
+    COALESCE(p.name, '') AS player,
+    COALESCE(rp.name, '') AS related_player
+FROM match_events e
+JOIN matches m ON m.id = e.match_id
+LEFT JOIN teams t ON t.id = e.team_id
+LEFT JOIN players p ON p.id = e.player_id
+LEFT JOIN players rp ON rp.id = e.related_player_id
+WHERE e.match_id = @match_id::int
+ORDER BY e.minute, COALESCE(e.injury_time, 0), e.id;
+
+-- name: ListMatchLineupPlayers :many
+-- ListMatchLineupPlayers returns both lineups of a match, home side first,
+-- starters before substitutes.
+SELECT
+    t.external_id AS team_external_id,
+    ml.is_home,
+    COALESCE(ml.formation, '') AS formation,
+    p.name,
+    COALESCE(lp.role, '') AS role,
+    COALESCE(lp.position, p.position, '') AS position,
+    p.shirt_number,
+    lp.minutes_played
+FROM match_lineups ml
+JOIN teams t ON t.id = ml.team_id
+JOIN match_lineup_players lp ON lp.match_lineup_id = ml.id
+JOIN players p ON p.id = lp.player_id
+WHERE ml.match_id = @match_id::int
+ORDER BY ml.is_home DESC, CASE WHEN lp.role = 'starter' THEN 0 ELSE 1 END, lp.id;
+
+-- name: ListMatchPlayerStats :many
+-- ListMatchPlayerStats returns every stored player stat line for a match.
+SELECT
+    p.name,
+    COALESCE(t.external_id, 0) AS team_external_id,
+    COALESCE(s.goals, 0) AS goals,
+    COALESCE(s.assists, 0) AS assists,
+    s.penalties,
+    s.own_goals,
+    COALESCE(s.shots, 0) AS shots,
+    s.shots_on_target,
+    COALESCE(s.key_passes, 0) AS key_passes,
+    s.passes,
+    s.passes_accurate,
+    s.duels,
+    s.duels_won,
+    COALESCE(s.tackles, 0) AS tackles,
+    COALESCE(s.interceptions, 0) AS interceptions,
+    s.rating,
+    s.minutes_played
+FROM player_match_stats s
+JOIN players p ON p.id = s.player_id
+LEFT JOIN teams t ON t.id = p.team_id
+WHERE s.match_id = @match_id::int
+ORDER BY t.external_id, goals DESC, assists DESC, p.name;
+
+-- name: GetTeamSeasonStats :one
+-- GetTeamSeasonStats aggregates a team's finished matches in a season, overall
+-- and split by venue. season is the season start year; an empty
+-- competition_code covers all competitions.
+WITH results AS (
+    SELECT TRUE AS is_home, m.home_score AS gf, m.away_score AS ga
+    FROM matches m
+    LEFT JOIN competitions c ON m.competition_id = c.id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+      AND (@competition_code::text = '' OR c.code = @competition_code::text)
+      AND m.home_team_id = @team_id::int
+    UNION ALL
+    SELECT FALSE, m.away_score, m.home_score
+    FROM matches m
+    LEFT JOIN competitions c ON m.competition_id = c.id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+      AND (@competition_code::text = '' OR c.code = @competition_code::text)
+      AND m.away_team_id = @team_id::int
+)
+SELECT
+    COUNT(*) FILTER (WHERE is_home)::int AS home_played,
+    COUNT(*) FILTER (WHERE is_home AND gf > ga)::int AS home_won,
+    COUNT(*) FILTER (WHERE is_home AND gf = ga)::int AS home_draw,
+    COUNT(*) FILTER (WHERE is_home AND gf < ga)::int AS home_lost,
+    COALESCE(SUM(gf) FILTER (WHERE is_home), 0)::int AS home_goals_for,
+    COALESCE(SUM(ga) FILTER (WHERE is_home), 0)::int AS home_goals_against,
+    COUNT(*) FILTER (WHERE is_home AND ga = 0)::int AS home_clean_sheets,
+    COUNT(*) FILTER (WHERE NOT is_home)::int AS away_played,
+    COUNT(*) FILTER (WHERE NOT is_home AND gf > ga)::int AS away_won,
+    COUNT(*) FILTER (WHERE NOT is_home AND gf = ga)::int AS away_draw,
+    COUNT(*) FILTER (WHERE NOT is_home AND gf < ga)::int AS away_lost,
+    COALESCE(SUM(gf) FILTER (WHERE NOT is_home), 0)::int AS away_goals_for,
+    COALESCE(SUM(ga) FILTER (WHERE NOT is_home), 0)::int AS away_goals_against,
+    COUNT(*) FILTER (WHERE NOT is_home AND ga = 0)::int AS away_clean_sheets
+FROM results;
+
+-- name: ListTeamLeagueProfiles :many
+-- ListTeamLeagueProfiles ranks the teams of every league played in a season
+-- by goals scored and conceded per match, and returns the rows of the given
+-- teams, each in the league they played most matches of. season is the
+-- season start year. Expected goals are averaged over the matches with
+-- ingested statistics, and cards counted over those with recorded events.
+WITH sides AS (
+    SELECT m.competition_id, m.id AS match_id, m.home_team_id AS team_id,
+           m.home_score AS gf, m.away_score AS ga
+    FROM matches m
+    JOIN competitions c ON c.id = m.competition_id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL AND c.type = 'LEAGUE'
+      AND m.home_team_id IS NOT NULL AND m.away_team_id IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+    UNION ALL
+    SELECT m.competition_id, m.id, m.away_team_id, m.away_score, m.home_score
+    FROM matches m
+    JOIN competitions c ON c.id = m.competition_id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL AND c.type = 'LEAGUE'
+      AND m.home_team_id IS NOT NULL AND m.away_team_id IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+),
+tallies AS (
+    SELECT s.competition_id, s.team_id,
+           COUNT(*)::int AS played,
+           SUM(s.gf)::int AS goals_for,
+           SUM(s.ga)::int AS goals_against,
+           COUNT(st.expected_goals)::int AS matches_with_statistics,
+           ROUND(AVG(st.expected_goals), 2)::float8 AS expected_goals_for,
+           ROUND(AVG(opp.expected_goals), 2)::float8 AS expected_goals_against,
+           COUNT(*) FILTER (WHERE ev.events > 0)::int AS matches_with_events,
+           COALESCE(SUM(ev.yellow_cards), 0)::int AS yellow_cards,
+           COALESCE(SUM(ev.sendings_off), 0)::int AS sendings_off
+    FROM sides s
+    LEFT JOIN team_match_statistics st ON st.match_id = s.match_id AND st.team_id = s.team_id
+    LEFT JOIN team_match_statistics opp ON opp.match_id = s.match_id AND opp.team_id <> s.team_id
+    CROSS JOIN LATERAL (
+        SELECT COUNT(*) AS events,
+               COUNT(*) FILTER (WHERE e.team_id = s.team_id AND e.event_type = 'YELLOW_CARD') AS yellow_cards,
+               COUNT(*) FILTER (WHERE e.team_id = s.team_id
+                                  AND e.event_type IN ('YELLOW_RED_CARD', 'RED_CARD')) AS sendings_off
+        FROM match_events e
+        WHERE e.match_id = s.match_id
+    ) ev
+    GROUP BY s.competition_id, s.team_id
+),
+ranked AS (
+    SELECT t.*,
+           RANK() OVER (PARTITION BY t.competition_id
+                        ORDER BY t.goals_for::float8 / t.played DESC)::int AS attack_rank,
+           RANK() OVER (PARTITION BY t.competition_id
+                        ORDER BY t.goals_against::float8 / t.played)::int AS defense_rank,
+           COUNT(*) OVER (PARTITION BY t.competition_id)::int AS teams
+    FROM tallies t
+)
+SELECT DISTINCT ON (r.team_id)
+    r.team_id, COALESCE(c.code, '')::text AS competition_code, c.name AS competition_name,
+    r.teams, r.played, r.goals_for, r.goals_against, r.attack_rank, r.defense_rank,
+    r.matches_with_statistics, r.expected_goals_for, r.expected_goals_against,
+    r.matches_with_events, r.yellow_cards, r.sendings_off
+FROM ranked r
+JOIN competitions c ON c.id = r.competition_id
+WHERE r.team_id = ANY(@team_ids::int[])
+ORDER BY r.team_id, r.played DESC, competition_code;
+
+-- name: GetTeamConcededByHalf :one
+-- GetTeamConcededByHalf totals goals conceded per half over the team's
+-- finished matches in a season that have recorded events. Extra time is
+-- ignored.
+WITH team_matches AS (
+    SELECT m.id
+    FROM matches m
+    LEFT JOIN competitions c ON m.competition_id = c.id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+      AND (@competition_code::text = '' OR c.code = @competition_code::text)
+      AND (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+),
+per_match AS (
+    SELECT
+        tm.id,
+        COUNT(*) FILTER (WHERE e.event_type IN ('GOAL', 'OWN_GOAL', 'PENALTY')
+                           AND e.team_id <> @team_id::int AND e.minute <= 45) AS first_half,
+        COUNT(*) FILTER (WHERE e.event_type IN ('GOAL', 'OWN_GOAL', 'PENALTY')
+                           AND e.team_id <> @team_id::int AND e.minute > 45 AND e.minute <= 90) AS second_half
+    FROM team_matches tm
+    JOIN match_events e ON e.match_id = tm.id
+    GROUP BY tm.id
+)
+SELECT
+    COUNT(*)::int AS matches,
+    COALESCE(SUM(first_half), 0)::int AS first_half,
+    COALESCE(SUM(second_half), 0)::int AS second_half
+FROM per_match;
+
+-- name: GetTeamHalfTimeFullTime :many
+-- GetTeamHalfTimeFullTime counts a team's finished matches in a season by
+-- its result at half time and at full time, W, D or L from its side.
+-- Matches without a stored half-time score are left out.
+WITH results AS (
+    SELECT
+        CASE WHEN m.home_team_id = @team_id::int THEN m.half_time_home_score ELSE m.half_time_away_score END AS ht_gf,
+        CASE WHEN m.home_team_id = @team_id::int THEN m.half_time_away_score ELSE m.half_time_home_score END AS ht_ga,
+        CASE WHEN m.home_team_id = @team_id::int THEN m.home_score ELSE m.away_score END AS ft_gf,
+        CASE WHEN m.home_team_id = @team_id::int THEN m.away_score ELSE m.home_score END AS ft_ga
+    FROM matches m
+    LEFT JOIN competitions c ON m.competition_id = c.id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND m.half_time_home_score IS NOT NULL AND m.half_time_away_score IS NOT NULL
+      AND m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text)
+      AND (@competition_code::text = '' OR c.code = @competition_code::text)
+      AND (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+)
+SELECT
+    (CASE WHEN ht_gf > ht_ga THEN 'W' WHEN ht_gf = ht_ga THEN 'D' ELSE 'L' END)::text AS half_time,
+    (CASE WHEN ft_gf > ft_ga THEN 'W' WHEN ft_gf = ft_ga THEN 'D' ELSE 'L' END)::text AS full_time,
+    COUNT(*)::int AS matches
+FROM results
+GROUP BY 1, 2
+ORDER BY 1, 2;
+
+-- name: ListTeamGoalTimings :many
+-- ListTeamGoalTimings counts the goals a team scored and conceded in each
+-- 15-minute interval of its finished matches with recorded events, bucket 0
+-- being minutes 1-15 and bucket 5 minutes 76-90, with the number of those
+-- matches. Stoppage time counts towards the interval it extends and extra
+-- time is ignored. An empty season or competition_code is not filtered on;
+-- season is the season start year.
+WITH team_matches AS (
+    SELECT m.id
+    FROM matches m
+    LEFT JOIN competitions c ON m.competition_id = c.id
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND (@season::text = '' OR m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text))
+      AND (@competition_code::text = '' OR c.code = @competition_code::text)
+      AND (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+      AND EXISTS (SELECT 1 FROM match_events e WHERE e.match_id = m.id)
+),
+goals AS (
+    SELECT (GREATEST(e.minute, 1) - 1) / 15 AS bucket,
+           e.team_id = @team_id::int AS scored
+    FROM team_matches tm
+    JOIN match_events e ON e.match_id = tm.id
+    WHERE e.event_type IN ('GOAL', 'OWN_GOAL', 'PENALTY')
+      AND e.team_id IS NOT NULL AND e.minute <= 90
+)
+SELECT b.bucket::int AS bucket,
+       COUNT(g.bucket) FILTER (WHERE g.scored)::int AS scored,
+       COUNT(g.bucket) FILTER (WHERE NOT g.scored)::int AS conceded,
+       (SELECT COUNT(*) FROM team_matches)::int AS matches
+FROM generate_series(0, 5) AS b(bucket)
+LEFT JOIN goals g ON g.bucket = b.bucket
+GROUP BY b.bucket
+ORDER BY b.bucket;
+
+-- name: GetTeamLateGoals :one
+-- GetTeamLateGoals counts the goals a team scored, and those after minute
+-- 75, in its last recent finished matches with recorded events kicked off
+-- before a time. Extra time is ignored.
+WITH recent_matches AS (
+    SELECT m.id
+    FROM matches m
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+      AND (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+      AND m.utc_date < @before::timestamp
+      AND EXISTS (SELECT 1 FROM match_events e WHERE e.match_id = m.id)
+    ORDER BY m.utc_date DESC
+    LIMIT @recent::int
+)
+SELECT
+    (SELECT COUNT(*) FROM recent_matches)::int AS matches,
+    COUNT(e.id)::int AS goals,
+    COUNT(e.id) FILTER (WHERE e.minute > 75)::int AS late_goals
+FROM recent_matches rm
+JOIN match_events e ON e.match_id = rm.id
+WHERE e.team_id = @team_id::int
+  AND e.event_type IN ('GOAL', 'OWN_GOAL', 'PENALTY')
+  AND e.minute <= 90;
+
+-- name: ResolveCompetitionSeason :one
+-- ResolveCompetitionSeason maps a season start year to the stored provider
+-- season of a competition. An empty season_year means the most recent one.
+SELECT m.season, EXTRACT(YEAR FROM MIN(m.utc_date))::int AS season_year
+FROM matches m
+JOIN competitions c ON c.id = m.competition_id
+WHERE c.code = @competition_code::text
+GROUP BY m.season
+HAVING @season_year::text = '' OR EXTRACT(YEAR FROM MIN(m.utc_date))::int::text = @season_year::text
+ORDER BY MIN(m.utc_date) DESC
+LIMIT 1;
+
+-- name: GetMatchdayRange :one
+-- GetMatchdayRange returns the first and last matchday of a competition
+-- season, and the current one: the latest matchday that has kicked off, or
+-- the first if none has.
+SELECT
+    COALESCE(MIN(m.matchday), 0)::int AS first_matchday,
+    COALESCE(MAX(m.matchday), 0)::int AS last_matchday,
+    COALESCE(MAX(m.matchday) FILTER (WHERE m.utc_date <= NOW()), MIN(m.matchday), 0)::int AS current_matchday
+FROM matches m
+JOIN competitions c ON c.id = m.competition_id
+WHERE c.code = @competition_code::text
+  AND m.season = @season::text
+  AND m.matchday IS NOT NULL;
+
+-- name: ListMatchdayMatches :many
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+JOIN competitions c ON m.competition_id = c.id
+WHERE c.code = @competition_code::text
+  AND m.season = @season::text
+  AND m.matchday = @matchday::int
+ORDER BY m.utc_date, m.id;
+
+-- name: ListKnockoutMatches :many
+-- ListKnockoutMatches returns the matches of a stored season played in the
+-- given stages, in kickoff order, with the two-legged tie they belong to.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by, m.stage::text AS stage,
+    m.penalties_home_score, m.penalties_away_score, t.id AS tie_id
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+JOIN competitions c ON m.competition_id = c.id
+LEFT JOIN ties t ON m.id IN (t.first_leg_id, t.second_leg_id)
+WHERE c.code = @competition_code::text
+  AND m.season = @season::text
+  AND m.stage = ANY(@stages::text[])
+ORDER BY m.utc_date, m.id;
+
+-- name: ListCalendarMatches :many
+-- ListCalendarMatches returns the matches of a team or a competition
+-- kicked off since since, in kickoff order, for calendar feeds. team_id 0
+-- and an empty competition_code are not filtered on.
+SELECT
+    m.external_id, COALESCE(c.code, '') AS competition_code, COALESCE(c.name, '') AS competition_name,
+    COALESCE(m.matchday, 0) AS matchday, COALESCE(m.stage, '') AS stage, m.utc_date, m.status,
+    ht.name AS home_team_name, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.venue, '') AS venue,
+    m.schedule_sequence, COALESCE(m.updated_at, m.created_at, m.utc_date)::timestamp AS updated_at
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+LEFT JOIN competitions c ON m.competition_id = c.id
+WHERE (@team_id::int = 0 OR m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+  AND m.utc_date >= @since::timestamp
+ORDER BY m.utc_date, m.id
+LIMIT @row_limit::int;
@@ -0,0 +1,87 @@
+-- name: InsertNewsArticle :one
+-- InsertNewsArticle stores an article and returns its ID, or no row if an
+-- article with the URL is already stored.
+INSERT INTO news_articles (source, url, title, summary, published_at)
+VALUES (@source, @url, @title, sqlc.narg('summary'), @published_at)
+ON CONFLICT (url) DO NOTHING
+RETURNING id;
+
+-- name: TagNewsArticleTeam :exec
+INSERT INTO news_article_teams (article_id, team_id, relevance)
+VALUES (@article_id, @team_id, @relevance)
+ON CONFLICT (article_id, team_id) DO UPDATE SET relevance = GREATEST(news_article_teams.relevance, EXCLUDED.relevance);
+
+-- name: TagNewsArticlePlayer :exec
+INSERT INTO news_article_players (article_id, player_id, relevance)
+VALUES (@article_id, @player_id, @relevance)
+ON CONFLICT (article_id, player_id) DO UPDATE SET relevance = GREATEST(news_article_players.relevance, EXCLUDED.relevance);
+
+-- name: ListTeamNews :many
+-- ListTeamNews returns the articles tagged with a team at min_relevance or
+-- above published since a time, newest first.
+SELECT a.id, a.source, a.url, a.title, COALESCE(a.summary, '') AS summary, a.published_at,
+       t.relevance::float8 AS relevance
+FROM news_articles a
+JOIN news_article_teams t ON t.article_id = a.id
+WHERE t.team_id = @team_id
+  AND t.relevance >= @min_relevance::float8
+  AND a.published_at >= @since::timestamp
+ORDER BY a.published_at DESC, a.id DESC
+LIMIT @row_limit::int;
+
+-- name: ListNewsArticlePlayers :many
+-- ListNewsArticlePlayers returns the players tagged in the given articles,
+-- most relevant first.
+SELECT ap.article_id, p.id AS player_id, p.name, COALESCE(p.team_id, 0) AS team_id,
+       ap.relevance::float8 AS relevance
+FROM news_article_players ap
+JOIN players p ON p.id = ap.player_id
+WHERE ap.article_id = ANY(@article_ids::int[])
+ORDER BY ap.article_id, ap.relevance DESC, p.name;
+
+-- name: ListNewsArticleTeams :many
+-- ListNewsArticleTeams returns the teams tagged in the given articles, most
+-- relevant first.
+SELECT at.article_id, t.id AS team_id, t.name, at.relevance::float8 AS relevance
+FROM news_article_teams at
+JOIN teams t ON t.id = at.team_id
+WHERE at.article_id = ANY(@article_ids::int[])
+ORDER BY at.article_id, at.relevance DESC, t.name;
+
+-- name: ListArticlesWithoutSignals :many
+-- ListArticlesWithoutSignals returns articles published since a time whose
+-- signals have not been extracted, newest first.
+SELECT id, source, url, title, COALESCE(summary, '') AS summary, published_at
+FROM news_articles
+WHERE signals_extracted_at IS NULL
+  AND published_at >= @since::timestamp
+ORDER BY published_at DESC, id DESC
+LIMIT @row_limit::int;
+
+-- name: MarkSignalsExtracted :exec
+UPDATE news_articles SET signals_extracted_at = CURRENT_TIMESTAMP WHERE id = $1;
+
+-- name: UpsertTeamSignal :exec
+INSERT INTO team_signals (article_id, team_id, player_id, signal, confidence, detail)
+VALUES (@article_id, @team_id, sqlc.narg('player_id'), @signal, @confidence, sqlc.narg('detail'))
+ON CONFLICT (article_id, team_id, COALESCE(player_id, 0), signal) DO UPDATE SET
+    confidence = EXCLUDED.confidence,
+    detail = EXCLUDED.detail;
+
+-- name: ListTeamSignals :many
+-- ListTeamSignals returns the signals about the given teams from articles
+-- published between since and known_at and extracted by known_at, at
+-- min_confidence or above, newest first.
+SELECT s.id, s.team_id, s.player_id, COALESCE(p.name, '') AS player_name,
+       s.signal, s.confidence::float8 AS confidence, COALESCE(s.detail, '') AS detail,
+       a.id AS article_id, a.title AS article_title, a.url AS article_url,
+       a.published_at
+FROM team_signals s
+JOIN news_articles a ON a.id = s.article_id
+LEFT JOIN players p ON p.id = s.player_id
+WHERE s.team_id = ANY(@team_ids::int[])
+  AND a.published_at >= @since::timestamp
+  AND a.published_at <= @known_at::timestamp
+  AND s.created_at <= @known_at::timestamp
+  AND s.confidence >= @min_confidence::float8
+ORDER BY a.published_at DESC, s.id;
@@ -0,0 +1,51 @@
+-- name: ListPlayerIngestBacklog :many
+-- ListPlayerIngestBacklog returns finished matches still to be ingested:
+-- never attempted, or failed fewer than max_attempts times and not already
+-- attempted since attempted_before. Newest first, so the most relevant
+-- matches are covered before older history.
+SELECT m.id, m.external_id, m.utc_date, m.home_team_id::int AS home_team_id,
+       m.away_team_id::int AS away_team_id, COALESCE(c.code, '')::text AS competition_code,
+       COALESCE(s.attempts, 0)::int AS attempts
+FROM matches m
+JOIN competitions c ON m.competition_id = c.id
+LEFT JOIN player_ingest_state s ON s.match_id = m.id
+WHERE m.status = 'FINISHED'
+  AND m.is_synthetic = FALSE
+  AND m.home_team_id IS NOT NULL
+  AND m.away_team_id IS NOT NULL
+  AND m.utc_date >= @since
+  AND m.utc_date < NOW()
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+  AND (s.match_id IS NULL
+       OR (s.status = 'failed' AND s.attempts < @max_attempts::int AND s.processed_at < @attempted_before))
+ORDER BY m.utc_date DESC, m.id
+LIMIT @row_limit::int;
+
+-- name: CountPlayerIngestStates :many
+-- CountPlayerIngestStates counts finished matches by ingest status, with
+-- 'pending' for those never attempted.
+SELECT COALESCE(s.status, 'pending')::text AS status, COUNT(*)::int AS matches
+FROM matches m
+JOIN competitions c ON m.competition_id = c.id
+LEFT JOIN player_ingest_state s ON s.match_id = m.id
+WHERE m.status = 'FINISHED'
+  AND m.is_synthetic = FALSE
+  AND m.utc_date >= @since
+  AND m.utc_date < NOW()
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+GROUP BY 1
+ORDER BY 1;
+
+-- name: MarkPlayerIngestDone :exec
+INSERT INTO player_ingest_state (match_id, status, attempts, goals, processed_at)
+VALUES (@match_id, 'done', 1, @goals, NOW())
+ON CONFLICT (match_id) DO UPDATE
+SET status = 'done', attempts = player_ingest_state.attempts + 1, goals = EXCLUDED.goals,
+    last_error = NULL, processed_at = NOW();
+
+-- name: MarkPlayerIngestFailed :exec
+INSERT INTO player_ingest_state (match_id, status, attempts, last_error, processed_at)
+VALUES (@match_id, 'failed', 1, @last_error::text, NOW())
+ON CONFLICT (match_id) DO UPDATE
+SET status = 'failed', attempts = player_ingest_state.attempts + 1,
+    last_error = EXCLUDED.last_error, processed_at = NOW();
@@ -0,0 +1,189 @@
+-- name: ListKeyPlayersForMatch :many
+-- ListKeyPlayersForMatch ranks a match's players by key_player_score, which
+-- weighs goals, assists, clean sheets, saves and defensive actions by the
+-- line each played in, so defenders and goalkeepers can stand out too.
+SELECT
+    p.name,
+    COALESCE(p.position, '') AS position,
+    r.role::text AS role,
+    t.external_id AS team_external_id,
+    COALESCE(s.goals, 0) AS goals,
+    COALESCE(s.assists, 0) AS assists,
+    s.penalties,
+    s.own_goals,
+    s.minutes_played,
+    s.rating,
+    s.saves,
+    s.clean_sheet,
+    s.tackles,
+    s.interceptions,
+    ROUND(sc.score, 1)::float8 AS score
+FROM player_match_stats s
+JOIN matches m ON m.id = s.match_id
+JOIN players p ON p.id = s.player_id
+JOIN teams t ON p.team_id = t.id
+CROSS JOIN LATERAL (SELECT player_role(s.position, p.position) AS role) r
+CROSS JOIN LATERAL (
+    SELECT key_player_score(r.role, s.goals, s.assists, s.own_goals, s.clean_sheet, s.saves,
+                            s.penalties_saved, s.tackles, s.interceptions, s.blocks) AS score
+) sc
+WHERE m.external_id = @match_external_id
+-- Ties go to the better-rated player, then to whoever needed fewer minutes
+ORDER BY sc.score DESC, s.rating DESC NULLS LAST, s.minutes_played ASC NULLS LAST
+LIMIT @row_limit::int;
+
+-- name: ListTopScorers :many
+-- ListTopScorers ranks players by goals then assists, or by goals per 90
+-- minutes when per90 is set. A zero team_id and empty competition_code or
+-- season are not filtered on. season is the season start year. Own goals are
+-- not counted as goals. Appearances are matches with minutes played.
+SELECT p.id AS player_id, p.name, COALESCE(t.name, '') AS team_name,
+       SUM(COALESCE(s.goals, 0))::int AS goals,
+       SUM(s.penalties)::int AS penalties,
+       SUM(COALESCE(s.assists, 0))::int AS assists,
+       COUNT(DISTINCT s.match_id) AS matches,
+       COUNT(DISTINCT s.match_id) FILTER (WHERE s.minutes_played > 0)::int AS appearances,
+       SUM(COALESCE(s.minutes_played, 0))::int AS minutes
+FROM player_match_stats s
+JOIN players p ON p.id = s.player_id
+JOIN matches m ON m.id = s.match_id
+LEFT JOIN teams t ON t.id = p.team_id
+LEFT JOIN competitions c ON c.id = m.competition_id
+WHERE (@team_id::int = 0 OR p.team_id = @team_id::int)
+  AND (@competition_code::text = '' OR c.code = @competition_code::text)
+  AND (@season::text = '' OR m.season IN (
+        SELECT season FROM matches
+        GROUP BY season
+        HAVING EXTRACT(YEAR FROM MIN(utc_date))::int::text = @season::text))
+GROUP BY p.id, p.name, t.name
+HAVING SUM(COALESCE(s.minutes_played, 0)) >= @min_minutes::int
+ORDER BY CASE WHEN @per90::bool
+              THEN SUM(COALESCE(s.goals, 0))::float8 / NULLIF(SUM(COALESCE(s.minutes_played, 0)), 0)
+         END DESC NULLS LAST,
+         goals DESC, assists DESC, p.name
+LIMIT @row_limit::int;
+
+-- name: UpsertPlayer :one
+INSERT INTO players (source, external_id, name, team_id)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (source, external_id) DO UPDATE SET
+    name = EXCLUDED.name,
+    updated_at = NOW()
+RETURNING id;
+
+-- name: GetPlayerIDByExternalID :one
+-- GetPlayerIDByExternalID resolves a player ID from an API path, which is
+-- football-data.org's or, for seeded players, a negative synthetic one.
+SELECT id FROM players WHERE external_id = $1 AND source IN ('football-data', 'synthetic');
+
+-- name: UpsertPlayerMatchStats :exec
+-- UpsertPlayerMatchStats stores a player's goal tally. goals includes
+-- penalties; own goals are counted separately.
+INSERT INTO player_match_stats (match_id, player_id, goals, assists, penalties, own_goals)
+VALUES (@match_id::int, @player_id::int, @goals::int, @assists::int, @penalties::int, @own_goals::int)
+ON CONFLICT (match_id, player_id) DO UPDATE SET
+    goals = EXCLUDED.goals,
+    assists = EXCLUDED.assists,
+    penalties = EXCLUDED.penalties,
+    own_goals = EXCLUDED.own_goals;
+
+-- name: UpsertPlayerMatchRatings :exec
+-- UpsertPlayerMatchRatings stores a player's rating and detailed stats from
+-- API-Football. Goals and assists are left alone; minutes are only filled in
+-- when lineups have not already set them.
+INSERT INTO player_match_stats (
+    match_id, player_id, rating, minutes_played, shots, shots_on_target, key_passes,
+    passes, passes_accurate, tackles, interceptions, duels, duels_won, penalties_missed,
+    position, saves, goals_conceded, penalties_saved, blocks, clean_sheet
+) VALUES (
+    @match_id::int, @player_id::int, sqlc.narg('rating')::numeric, sqlc.narg('minutes_played')::int,
+    sqlc.narg('shots')::int, sqlc.narg('shots_on_target')::int, sqlc.narg('key_passes')::int,
+    sqlc.narg('passes')::int, sqlc.narg('passes_accurate')::int, sqlc.narg('tackles')::int,
+    sqlc.narg('interceptions')::int, sqlc.narg('duels')::int, sqlc.narg('duels_won')::int,
+    sqlc.narg('penalties_missed')::int, sqlc.narg('position'), sqlc.narg('saves')::int,
+    sqlc.narg('goals_conceded')::int, sqlc.narg('penalties_saved')::int, sqlc.narg('blocks')::int,
+    sqlc.narg('clean_sheet')::boolean
+)
+ON CONFLICT (match_id, player_id) DO UPDATE SET
+    rating = EXCLUDED.rating,
+    minutes_played = COALESCE(player_match_stats.minutes_played, EXCLUDED.minutes_played),
+    shots = EXCLUDED.shots,
+    shots_on_target = EXCLUDED.shots_on_target,
+    key_passes = EXCLUDED.key_passes,
+    passes = EXCLUDED.passes,
+    passes_accurate = EXCLUDED.passes_accurate,
+    tackles = EXCLUDED.tackles,
+    interceptions = EXCLUDED.interceptions,
+    duels = EXCLUDED.duels,
+    duels_won = EXCLUDED.duels_won,
+    penalties_missed = EXCLUDED.penalties_missed,
+    position = EXCLUDED.position,
+    saves = EXCLUDED.saves,
+    goals_conceded = EXCLUDED.goals_conceded,
+    penalties_saved = EXCLUDED.penalties_saved,
+    blocks = EXCLUDED.blocks,
+    clean_sheet = EXCLUDED.clean_sheet;
+
+-- name: CountRatedPlayerMatchStats :one
+SELECT COUNT(*) FROM player_match_stats WHERE match_id = @match_id::int AND rating IS NOT NULL;
+
+-- name: InsertMatchEvent :exec
+INSERT INTO match_events (match_id, team_id, player_id, event_type, minute, injury_time, related_player_id)
+VALUES (@match_id, @team_id::int, @player_id::int, @event_type, @minute::int, sqlc.narg('injury_time')::int, sqlc.narg('related_player_id')::int)
+ON CONFLICT (match_id, player_id, event_type, minute) DO NOTHING;
+
+-- name: ListLineupAbsentRegulars :many
+-- ListLineupAbsentRegulars returns the regulars each team left out of the
+-- starting lineup it announced for a match by known_at: the players who
+-- started at least min_starts of the team's last recent lineups before it.
+WITH announced AS (
+    SELECT ml.id, ml.team_id, m.utc_date
+    FROM match_lineups ml
+    JOIN matches m ON m.id = ml.match_id
+    WHERE ml.match_id = @match_id::int
+      AND ml.created_at <= @known_at::timestamp
+      AND EXISTS (
+          SELECT 1 FROM match_lineup_players lp
+          WHERE lp.match_lineup_id = ml.id AND lp.role = 'starter')
+),
+recent AS (
+    SELECT a.team_id, ml.id AS lineup_id,
+           ROW_NUMBER() OVER (PARTITION BY a.team_id ORDER BY m.utc_date DESC) AS n
+    FROM announced a
+    JOIN match_lineups ml ON ml.team_id = a.team_id
+    JOIN matches m ON m.id = ml.match_id
+    WHERE m.utc_date < a.utc_date
+)
+SELECT r.team_id, p.id AS player_id, p.name AS player_name, COUNT(*)::int AS starts
+FROM recent r
+JOIN match_lineup_players lp ON lp.match_lineup_id = r.lineup_id AND lp.role = 'starter'
+JOIN players p ON p.id = lp.player_id
+WHERE r.n <= @recent::int
+  AND NOT EXISTS (
+      SELECT 1
+      FROM announced a
+      JOIN match_lineup_players alp ON alp.match_lineup_id = a.id
+      WHERE a.team_id = r.team_id AND alp.player_id = lp.player_id AND alp.role = 'starter')
+GROUP BY r.team_id, p.id, p.name
+HAVING COUNT(*) >= @min_starts::int
+ORDER BY r.team_id, starts DESC, p.name;
+
+-- name: ListUnavailablePlayers :many
+-- ListUnavailablePlayers returns the players of the given teams who are
+-- injured, suspended or otherwise unavailable on on_date, as recorded by
+-- known_at.
+SELECT
+    COALESCE(a.team_id, p.team_id)::int AS team_id,
+    a.player_id,
+    COALESCE(p.name, '') AS player_name,
+    COALESCE(a.reason, '') AS reason,
+    COALESCE(a.severity, '') AS severity,
+    a.unavailable_from,
+    a.unavailable_until
+FROM player_availability a
+LEFT JOIN players p ON p.id = a.player_id
+WHERE COALESCE(a.team_id, p.team_id) = ANY(@team_ids::int[])
+  AND a.unavailable_from <= @on_date::date
+  AND (a.unavailable_until IS NULL OR a.unavailable_until >= @on_date::date)
+  AND (a.created_at IS NULL OR a.created_at <= @known_at::timestamp)
+ORDER BY team_id, player_name;
@@ -0,0 +1,363 @@
+-- name: GetPredictionByMatchID :one
+-- GetPredictionByMatchID returns the prediction served for a match; shadow
+-- predictions from other models are left out.
+SELECT
+    id, match_id, predicted_at,
+    COALESCE(team_a_name, '') AS team_a_name, COALESCE(team_b_name, '') AS team_b_name,
+    predicted_team_a_goals, predicted_team_b_goals,
+    COALESCE(predicted_outcome, '') AS predicted_outcome, COALESCE(predicted_winner, '') AS predicted_winner,
+    COALESCE(confidence_score, 0) AS confidence_score,
+    actual_team_a_goals, actual_team_b_goals, actual_winner,
+    prediction_correct, insights_generated, model_version
+FROM prediction_history
+WHERE match_id = @match_id::int AND is_primary
+ORDER BY predicted_at DESC, version DESC
+LIMIT 1;
+
+-- name: ListPredictionsByMatchIDs :many
+-- ListPredictionsByMatchIDs returns the prediction served for each match,
+-- the latest version of the primary model.
+SELECT DISTINCT ON (match_id)
+    id, match_id, predicted_at,
+    COALESCE(team_a_name, '') AS team_a_name, COALESCE(team_b_name, '') AS team_b_name,
+    predicted_team_a_goals, predicted_team_b_goals,
+    COALESCE(predicted_outcome, '') AS predicted_outcome, COALESCE(predicted_winner, '') AS predicted_winner,
+    COALESCE(confidence_score, 0) AS confidence_score,
+    actual_team_a_goals, actual_team_b_goals, actual_winner,
+    prediction_correct, insights_generated, model_version
+FROM prediction_history
+WHERE match_id = ANY(@match_ids::int[]) AND is_primary
+ORDER BY match_id, predicted_at DESC, version DESC;
+
+-- name: ListUnsettledFinishedPredictions :many
+-- ListUnsettledFinishedPredictions returns internal IDs of finished matches
+-- with a prediction from any model not yet compared with the result.
+SELECT ph.match_id::int AS match_id
+FROM prediction_history ph
+JOIN matches m ON m.id = ph.match_id
+WHERE ph.actual_team_a_goals IS NULL
+  AND m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+GROUP BY ph.match_id, m.utc_date
+ORDER BY m.utc_date
+LIMIT @row_limit::int;
+
+-- name: SettlePrediction :exec
+-- SettlePrediction fills in the actual result and error metrics of every
+-- prediction version for a finished match, though only final ones count in
+-- the accuracy stats. Predictions are for 90 minutes, so knockout matches
+-- are settled on the score before extra time: a tie decided after extra
+-- time or on penalties counts as a draw.
+UPDATE prediction_history ph
+SET
+    actual_team_a_goals = rt.home,
+    actual_team_b_goals = rt.away,
+    actual_outcome = CASE
+        WHEN rt.home > rt.away THEN ht.name || ' Win'
+        WHEN rt.home < rt.away THEN at.name || ' Win'
+        ELSE 'Draw'
+    END,
+    actual_winner = CASE
+        WHEN rt.home > rt.away THEN ht.name
+        WHEN rt.home < rt.away THEN at.name
+        ELSE 'Draw'
+    END,
+    prediction_correct = (
+        CASE
+            WHEN ph.predicted_winner = ht.name AND rt.home > rt.away THEN true
+            WHEN ph.predicted_winner = at.name AND rt.home < rt.away THEN true
+            WHEN ph.predicted_winner = 'Draw' AND rt.home = rt.away THEN true
+            ELSE false
+        END
+    ),
+    goals_error_team_a = ABS(ph.predicted_team_a_goals - rt.home),
+    goals_error_team_b = ABS(ph.predicted_team_b_goals - rt.away),
+    updated_at = CURRENT_TIMESTAMP
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+CROSS JOIN LATERAL (
+    SELECT m.home_score - COALESCE(m.extra_time_home_score, 0) AS home,
+           m.away_score - COALESCE(m.extra_time_away_score, 0) AS away
+) rt
+WHERE ph.match_id = m.id
+  AND ph.match_id = @match_id::int
+  AND m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL;
+
+-- name: ListSettledPredictionHistory :many
+SELECT
+    ph.id, m.id AS match_id, ph.predicted_at,
+    COALESCE(ph.team_a_name, '') AS team_a_name, COALESCE(ph.team_b_name, '') AS team_b_name,
+    COALESCE(ph.predicted_team_a_goals, 0) AS predicted_team_a_goals,
+    COALESCE(ph.predicted_team_b_goals, 0) AS predicted_team_b_goals,
+    COALESCE(ph.predicted_outcome, '') AS predicted_outcome,
+    COALESCE(ph.predicted_winner, '') AS predicted_winner,
+    COALESCE(ph.confidence_score, 0) AS confidence_score,
+    ph.actual_team_a_goals, ph.actual_team_b_goals, ph.actual_outcome, ph.actual_winner,
+    ph.prediction_correct, ph.insights_generated, ph.model_version,
+    ph.goals_error_team_a, ph.goals_error_team_b,
+    m.utc_date
+FROM prediction_history ph
+JOIN matches m ON ph.match_id = m.id
+WHERE ph.actual_team_a_goals IS NOT NULL AND ph.is_primary AND ph.is_final
+ORDER BY m.utc_date DESC
+LIMIT @row_limit::int;
+
+-- name: InsertPredictionHistory :exec
+-- InsertPredictionHistory stores a model's prediction for a match as its
+-- next version, keeping the earlier ones.
+INSERT INTO prediction_history (
+    match_id, team_a_name, team_b_name,
+    predicted_team_a_goals, predicted_team_b_goals,
+    predicted_outcome, predicted_winner, confidence_score,
+    insights_generated, model_version, features_used, is_primary,
+    home_win_probability, draw_probability, away_win_probability, version
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM prediction_history WHERE match_id = $1 AND model_version = $10)
+);
+
+-- name: MarkFinalPrediction :exec
+-- MarkFinalPrediction marks a model's latest prediction for a match made
+-- before kickoff as its final one, the version settled into the accuracy
+-- stats, and unmarks the rest.
+UPDATE prediction_history ph
+SET is_final = (ph.id = f.id), updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT p.id
+    FROM prediction_history p
+    JOIN matches m ON m.id = p.match_id
+    WHERE p.match_id = @match_id::int
+      AND p.model_version = @model_version::text
+      AND p.predicted_at < m.utc_date
+    ORDER BY p.version DESC
+    LIMIT 1
+) f
+WHERE ph.match_id = @match_id::int
+  AND ph.model_version = @model_version::text
+  AND ph.is_final <> (ph.id = f.id);
+
+-- name: SetPrimaryPredictionModel :exec
+-- SetPrimaryPredictionModel makes every version of one model's predictions
+-- for a match primary and turns every other model's into shadow
+-- predictions, so only the model served last stays primary.
+UPDATE prediction_history
+SET is_primary = (model_version = @model_version::text), updated_at = CURRENT_TIMESTAMP
+WHERE match_id = @match_id::int
+  AND is_primary <> (model_version = @model_version::text);
+
+-- name: ListPredictionsForMatch :many
+-- ListPredictionsForMatch returns every model's latest prediction for a
+-- match, the served one first.
+SELECT * FROM (
+    SELECT DISTINCT ON (model_version)
+        id, match_id, predicted_at,
+        COALESCE(team_a_name, '') AS team_a_name, COALESCE(team_b_name, '') AS team_b_name,
+        predicted_team_a_goals, predicted_team_b_goals,
+        COALESCE(predicted_outcome, '') AS predicted_outcome, COALESCE(predicted_winner, '') AS predicted_winner,
+        COALESCE(confidence_score, 0) AS confidence_score,
+        actual_team_a_goals, actual_team_b_goals, actual_winner,
+        prediction_correct, insights_generated, model_version,
+        is_primary, home_win_probability, draw_probability, away_win_probability,
+        version, is_final
+    FROM prediction_history
+    WHERE match_id = @match_id::int
+    ORDER BY model_version, version DESC
+) latest
+ORDER BY is_primary DESC, predicted_at DESC, model_version;
+
+-- name: ListPredictionVersions :many
+-- ListPredictionVersions returns every version of every model's prediction
+-- for a match, oldest first.
+SELECT
+    id, match_id, predicted_at,
+    COALESCE(team_a_name, '') AS team_a_name, COALESCE(team_b_name, '') AS team_b_name,
+    predicted_team_a_goals, predicted_team_b_goals,
+    COALESCE(predicted_outcome, '') AS predicted_outcome, COALESCE(predicted_winner, '') AS predicted_winner,
+    COALESCE(confidence_score, 0) AS confidence_score,
+    actual_team_a_goals, actual_team_b_goals, actual_winner,
+    prediction_correct, insights_generated, model_version,
+    is_primary, home_win_probability, draw_probability, away_win_probability,
+    version, is_final
+FROM prediction_history
+WHERE match_id = @match_id::int
+ORDER BY predicted_at, model_version, version;
+
+-- name: GetPredictionAccuracy :one
+SELECT
+    COUNT(*) AS total_predictions,
+    COALESCE(SUM(CASE WHEN prediction_correct = true THEN 1 ELSE 0 END), 0)::int AS correct_predictions,
+    COALESCE(AVG(goals_error_team_a), 0)::float8 AS avg_goals_error_a,
+    COALESCE(AVG(goals_error_team_b), 0)::float8 AS avg_goals_error_b,
+    COALESCE(AVG(confidence_score), 0)::float8 AS avg_confidence
+FROM prediction_history
+WHERE actual_team_a_goals IS NOT NULL AND is_primary AND is_final;
+
+-- name: GetPredictionAccuracyBetween :one
+-- GetPredictionAccuracyBetween counts the settled served predictions of
+-- matches kicked off from since up to but excluding before.
+SELECT
+    COUNT(*) AS total_predictions,
+    COALESCE(SUM(CASE WHEN ph.prediction_correct = true THEN 1 ELSE 0 END), 0)::int AS correct_predictions
+FROM prediction_history ph
+JOIN matches m ON m.id = ph.match_id
+WHERE ph.actual_team_a_goals IS NOT NULL AND ph.is_primary AND ph.is_final
+  AND m.utc_date >= @since::timestamp AND m.utc_date < @before::timestamp;
+
+-- name: ListPredictionAccuracyByModel :many
+-- ListPredictionAccuracyByModel is GetPredictionAccuracy per model version,
+-- so models can be compared side by side.
+SELECT
+    model_version,
+    COUNT(*) AS total_predictions,
+    COALESCE(SUM(CASE WHEN prediction_correct = true THEN 1 ELSE 0 END), 0)::int AS correct_predictions,
+    COALESCE(AVG(goals_error_team_a), 0)::float8 AS avg_goals_error_a,
+    COALESCE(AVG(goals_error_team_b), 0)::float8 AS avg_goals_error_b,
+    COALESCE(AVG(confidence_score), 0)::float8 AS avg_confidence
+FROM prediction_history
+WHERE actual_team_a_goals IS NOT NULL AND is_final
+GROUP BY model_version
+ORDER BY total_predictions DESC, model_version;
+
+-- name: ListModelTrainingResults :many
+-- ListModelTrainingResults returns real finished results kicked off on or
+-- after since, for fitting the Go prediction model.
+SELECT m.home_team_id::int AS home_team_id, m.away_team_id::int AS away_team_id,
+       m.home_score::int AS home_score, m.away_score::int AS away_score,
+       m.half_time_home_score, m.half_time_away_score, m.utc_date, m.competition_id
+FROM matches m
+WHERE m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+  AND m.home_team_id IS NOT NULL
+  AND m.away_team_id IS NOT NULL
+  AND m.is_synthetic = FALSE
+  AND m.utc_date >= @since::timestamp
+ORDER BY m.utc_date;
+
+-- name: ListTrainingResultsBetween :many
+-- ListTrainingResultsBetween returns real finished results kicked off in
+-- [since, before), oldest first: what was known at a point in time.
+SELECT m.home_team_id::int AS home_team_id, m.away_team_id::int AS away_team_id,
+       m.home_score::int AS home_score, m.away_score::int AS away_score,
+       m.half_time_home_score, m.half_time_away_score, m.utc_date, m.competition_id
+FROM matches m
+WHERE m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+  AND m.home_team_id IS NOT NULL
+  AND m.away_team_id IS NOT NULL
+  AND m.is_synthetic = FALSE
+  AND m.utc_date >= @since::timestamp
+  AND m.utc_date < @before::timestamp
+ORDER BY m.utc_date;
+
+-- name: ListModelHeadToHead :many
+-- ListModelHeadToHead compares every pair of model versions on their final
+-- predictions of the settled matches both predicted. The Brier score is only
+-- averaged over predictions that stored outcome probabilities; lower is
+-- better. An empty model matches every pair, otherwise only pairs that
+-- include it.
+WITH settled AS (
+    SELECT ph.*,
+           CASE
+               WHEN ph.actual_team_a_goals > ph.actual_team_b_goals THEN 'HOME'
+               WHEN ph.actual_team_a_goals < ph.actual_team_b_goals THEN 'AWAY'
+               ELSE 'DRAW'
+           END AS result,
+           ABS(ph.predicted_team_a_goals - ph.actual_team_a_goals)
+             + ABS(ph.predicted_team_b_goals - ph.actual_team_b_goals) AS goals_error
+    FROM prediction_history ph
+    WHERE ph.actual_team_a_goals IS NOT NULL AND ph.is_final
+), scored AS (
+    SELECT s.*,
+           POWER(s.home_win_probability - CASE WHEN s.result = 'HOME' THEN 1 ELSE 0 END, 2)
+             + POWER(s.draw_probability - CASE WHEN s.result = 'DRAW' THEN 1 ELSE 0 END, 2)
+             + POWER(s.away_win_probability - CASE WHEN s.result = 'AWAY' THEN 1 ELSE 0 END, 2) AS brier
+    FROM settled s
+)
+SELECT a.model_version AS model_a, b.model_version AS model_b,
+       COUNT(*)::int AS matches,
+       COUNT(*) FILTER (WHERE a.prediction_correct)::int AS a_correct,
+       COUNT(*) FILTER (WHERE b.prediction_correct)::int AS b_correct,
+       COUNT(*) FILTER (WHERE a.prediction_correct AND NOT b.prediction_correct)::int AS only_a_correct,
+       COUNT(*) FILTER (WHERE b.prediction_correct AND NOT a.prediction_correct)::int AS only_b_correct,
+       AVG(a.goals_error)::float8 AS a_goals_error,
+       AVG(b.goals_error)::float8 AS b_goals_error,
+       AVG(a.brier)::float8 AS a_brier,
+       AVG(b.brier)::float8 AS b_brier
+FROM scored a
+JOIN scored b ON b.match_id = a.match_id AND a.model_version < b.model_version
+WHERE @model::text = '' OR a.model_version = @model::text OR b.model_version = @model::text
+GROUP BY a.model_version, b.model_version
+ORDER BY matches DESC, model_a, model_b;
+
+-- name: GetPredictionInputState :one
+-- GetPredictionInputState summarises the stored data a prediction depends
+-- on: the announced lineups of the match and both teams' finished results.
+-- A change in any of it means the match should be predicted again.
+SELECT
+    (SELECT COUNT(*)
+     FROM match_lineups ml
+     JOIN match_lineup_players mlp ON mlp.match_lineup_id = ml.id
+     WHERE ml.match_id = @match_id::int)::int AS lineup_players,
+    (SELECT MAX(ml.created_at)
+     FROM match_lineups ml
+     WHERE ml.match_id = @match_id::int)::timestamp AS lineups_updated_at,
+    (SELECT COUNT(*)
+     FROM matches m
+     WHERE m.status = 'FINISHED'
+       AND m.home_score IS NOT NULL
+       AND (m.home_team_id IN (@home_team_id::int, @away_team_id::int)
+            OR m.away_team_id IN (@home_team_id::int, @away_team_id::int)))::int AS team_results,
+    (SELECT MAX(m.updated_at)
+     FROM matches m
+     WHERE m.status = 'FINISHED'
+       AND (m.home_team_id IN (@home_team_id::int, @away_team_id::int)
+            OR m.away_team_id IN (@home_team_id::int, @away_team_id::int)))::timestamp AS results_updated_at;
+
+-- name: ListNewLineupMatches :many
+-- ListNewLineupMatches returns the upcoming matches kicking off between
+-- from_date and until_date whose starting lineups were stored after their
+-- latest served prediction was recorded, or that have none.
+SELECT m.id AS match_id, m.external_id, COALESCE(m.matchday, 0)::int AS matchday, m.utc_date,
+       ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+       at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+       l.lineups_updated_at::timestamp AS lineups_updated_at
+FROM matches m
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+JOIN LATERAL (
+    SELECT MAX(ml.created_at) AS lineups_updated_at
+    FROM match_lineups ml
+    WHERE ml.match_id = m.id
+      AND EXISTS (
+          SELECT 1 FROM match_lineup_players lp
+          WHERE lp.match_lineup_id = ml.id AND lp.role = 'starter')
+) l ON l.lineups_updated_at IS NOT NULL
+WHERE m.status IN ('SCHEDULED', 'TIMED')
+  AND m.utc_date >= @from_date::timestamp AND m.utc_date < @until_date::timestamp
+  AND l.lineups_updated_at > COALESCE(
+      (SELECT MAX(ph.predicted_at) FROM prediction_history ph WHERE ph.match_id = m.id AND ph.is_primary),
+      '-infinity'::timestamp)
+ORDER BY m.utc_date, m.id
+LIMIT @row_limit::int;
+
+-- name: GetMatchPredictionInputs :one
+-- GetMatchPredictionInputs reads the match_prediction_inputs row of a match
+-- by external ID, or by internal ID when no match has that external ID.
+SELECT match_id, external_id, status, matchday, utc_date,
+       home_team_id, home_team_external_id, home_team_name,
+       away_team_id, away_team_external_id, away_team_name,
+       h2h_matches, h2h_home_wins, h2h_away_wins, h2h_draws,
+       home_key_players, away_key_players,
+       lineup_players, lineups_updated_at, team_results, results_updated_at,
+       refreshed_at
+FROM match_prediction_inputs
+WHERE external_id = @match_id::int OR match_id = @match_id::int
+ORDER BY (external_id = @match_id::int) DESC
+LIMIT 1;
+
+-- name: RefreshMatchPredictionInputs :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY match_prediction_inputs;
@@ -0,0 +1,20 @@
+-- name: AddProviderQuotaUsage :one
+-- AddProviderQuotaUsage counts requests in the window starting at
+-- window_start and returns the window's total. A newer window starts the
+-- count over; an older one, from a lagging clock, leaves it as it is.
+INSERT INTO provider_quota_usage (provider, key_id, period, window_start, requests, updated_at)
+VALUES (@provider, @key_id, @period, @window_start, @requests::int, NOW())
+ON CONFLICT (provider, key_id, period) DO UPDATE SET
+    requests = CASE
+        WHEN provider_quota_usage.window_start = EXCLUDED.window_start THEN provider_quota_usage.requests + EXCLUDED.requests
+        WHEN provider_quota_usage.window_start < EXCLUDED.window_start THEN EXCLUDED.requests
+        ELSE provider_quota_usage.requests
+    END,
+    window_start = GREATEST(provider_quota_usage.window_start, EXCLUDED.window_start),
+    updated_at = NOW()
+RETURNING requests;
+
+-- name: ListProviderQuotaUsage :many
+SELECT provider, key_id, period, window_start, requests, updated_at
+FROM provider_quota_usage
+ORDER BY provider, key_id, period;
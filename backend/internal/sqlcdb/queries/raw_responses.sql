@@ -0,0 +1,22 @@
+-- name: InsertRawResponse :exec
+INSERT INTO raw_responses (source, endpoint, body, size_bytes, fetched_at)
+VALUES (@source, @endpoint, @body, @size_bytes::int, @fetched_at);
+
+-- name: ListLatestRawResponses :many
+-- ListLatestRawResponses returns the latest response archived up to as_of
+-- for each endpoint, oldest first, so replaying them applies them in the
+-- order they were fetched. An empty source matches every source; endpoint
+-- matches a prefix, e.g. "/v4/competitions/PL/".
+SELECT id, source, endpoint, body, size_bytes, fetched_at
+FROM (
+    SELECT DISTINCT ON (source, endpoint) id, source, endpoint, body, size_bytes, fetched_at
+    FROM raw_responses
+    WHERE (@source::text = '' OR source = @source::text)
+      AND endpoint LIKE @endpoint::text || '%'
+      AND fetched_at <= @as_of
+    ORDER BY source, endpoint, fetched_at DESC, id DESC
+) latest
+ORDER BY fetched_at, id;
+
+-- name: DeleteRawResponsesBefore :execrows
+DELETE FROM raw_responses WHERE fetched_at < @cutoff;
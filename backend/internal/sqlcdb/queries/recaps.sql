@@ -0,0 +1,32 @@
+-- name: UpsertMatchRecap :exec
+INSERT INTO match_recaps (
+    match_id, headline, summary, key_moments,
+    player_of_the_match, prediction_review, generated_by, generated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (match_id) DO UPDATE SET
+    headline = EXCLUDED.headline,
+    summary = EXCLUDED.summary,
+    key_moments = EXCLUDED.key_moments,
+    player_of_the_match = EXCLUDED.player_of_the_match,
+    prediction_review = EXCLUDED.prediction_review,
+    generated_by = EXCLUDED.generated_by,
+    generated_at = EXCLUDED.generated_at;
+
+-- name: GetMatchRecapByMatchExternalID :one
+SELECT
+    mr.match_id, m.external_id AS match_external_id, mr.headline, mr.summary, mr.key_moments,
+    mr.player_of_the_match, mr.prediction_review, mr.generated_by, mr.generated_at
+FROM match_recaps mr
+JOIN matches m ON m.id = mr.match_id
+WHERE m.external_id = $1;
+
+-- name: ListFinishedMatchesWithoutRecap :many
+SELECT m.id
+FROM matches m
+LEFT JOIN match_recaps mr ON mr.match_id = m.id
+WHERE m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.utc_date >= @since
+  AND mr.id IS NULL
+ORDER BY m.utc_date
+LIMIT @row_limit::int;
@@ -0,0 +1,31 @@
+-- name: PurgeIngestFailurePayloads :execrows
+-- PurgeIngestFailurePayloads clears the payloads of failures resolved before
+-- cutoff. Open failures keep theirs so they can still be retried.
+UPDATE ingest_failures SET payload = 'null'::jsonb
+WHERE resolved_at < @cutoff AND payload <> 'null'::jsonb;
+
+-- name: PurgePredictionFeatures :execrows
+-- PurgePredictionFeatures clears the features stored with predictions made
+-- before cutoff.
+UPDATE prediction_history SET features_used = NULL
+WHERE predicted_at < @cutoff AND features_used IS NOT NULL;
+
+-- name: DeleteFeatureSnapshotsBefore :execrows
+DELETE FROM feature_snapshots WHERE as_of < @cutoff;
+
+-- name: DeleteAPIUsageBefore :execrows
+DELETE FROM api_usage WHERE hour < @cutoff;
+
+-- name: DeleteLLMUsageBefore :execrows
+DELETE FROM llm_usage WHERE created_at < @cutoff;
+
+-- name: InsertRetentionRun :one
+INSERT INTO retention_runs (policy, months, cutoff, rows_affected, error, triggered_by, started_at, finished_at)
+VALUES (@policy, @months::int, @cutoff, @rows_affected::int, sqlc.narg('error'), @triggered_by, @started_at, @finished_at)
+RETURNING id;
+
+-- name: ListRetentionRuns :many
+SELECT id, policy, months, cutoff, rows_affected, error, triggered_by, started_at, finished_at
+FROM retention_runs
+ORDER BY started_at DESC, id DESC
+LIMIT @row_limit::int;
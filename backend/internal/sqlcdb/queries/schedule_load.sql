@@ -0,0 +1,51 @@
+-- name: ListTeamScheduleLoad :many
+-- ListTeamScheduleLoad returns a team's upcoming fixtures from after,
+-- soonest first, each with the team's previous match and how many matches
+-- it will have played in the 14 days before kickoff, counting the fixtures
+-- scheduled in between. Postponed and cancelled matches are left out.
+WITH team_matches AS (
+    SELECT m.id, m.external_id, m.utc_date, m.status, m.competition_id,
+           m.home_team_id = @team_id::int AS home,
+           CASE WHEN m.home_team_id = @team_id::int THEN m.away_team_id ELSE m.home_team_id END AS opponent_id
+    FROM matches m
+    WHERE (m.home_team_id = @team_id::int OR m.away_team_id = @team_id::int)
+      AND m.status NOT IN ('POSTPONED', 'CANCELLED', 'SUSPENDED')
+),
+loads AS (
+    SELECT tm.*,
+           LAG(tm.utc_date) OVER (ORDER BY tm.utc_date) AS previous_match_at,
+           COUNT(*) OVER (ORDER BY tm.utc_date RANGE BETWEEN INTERVAL '14 days' PRECEDING AND CURRENT ROW) - 1 AS matches_last_14_days
+    FROM team_matches tm
+)
+SELECT l.external_id, l.utc_date, COALESCE(c.code, '')::text AS competition_code, l.home,
+       COALESCE(o.external_id, 0)::int AS opponent_external_id, COALESCE(o.name, '')::text AS opponent_name,
+       l.previous_match_at, l.matches_last_14_days::int AS matches_last_14_days
+FROM loads l
+LEFT JOIN competitions c ON c.id = l.competition_id
+LEFT JOIN teams o ON o.id = l.opponent_id
+WHERE l.status IN ('SCHEDULED', 'TIMED') AND l.utc_date >= @after::timestamp
+ORDER BY l.utc_date
+LIMIT @max_fixtures::int;
+
+-- name: GetMatchScheduleLoad :one
+-- GetMatchScheduleLoad returns each team's previous match before a match
+-- and how many matches it played or is to play in the 14 days before
+-- kickoff, on the same terms as ListTeamScheduleLoad.
+SELECT
+    (SELECT MAX(p.utc_date) FROM matches p
+     WHERE (p.home_team_id = m.home_team_id OR p.away_team_id = m.home_team_id)
+       AND p.utc_date < m.utc_date AND p.status NOT IN ('POSTPONED', 'CANCELLED', 'SUSPENDED'))::timestamp AS home_previous_match_at,
+    (SELECT COUNT(*) FROM matches p
+     WHERE (p.home_team_id = m.home_team_id OR p.away_team_id = m.home_team_id)
+       AND p.utc_date >= m.utc_date - INTERVAL '14 days' AND p.utc_date < m.utc_date
+       AND p.status NOT IN ('POSTPONED', 'CANCELLED', 'SUSPENDED'))::int AS home_matches_last_14_days,
+    (SELECT MAX(p.utc_date) FROM matches p
+     WHERE (p.home_team_id = m.away_team_id OR p.away_team_id = m.away_team_id)
+       AND p.utc_date < m.utc_date AND p.status NOT IN ('POSTPONED', 'CANCELLED', 'SUSPENDED'))::timestamp AS away_previous_match_at,
+    (SELECT COUNT(*) FROM matches p
+     WHERE (p.home_team_id = m.away_team_id OR p.away_team_id = m.away_team_id)
+       AND p.utc_date >= m.utc_date - INTERVAL '14 days' AND p.utc_date < m.utc_date
+       AND p.status NOT IN ('POSTPONED', 'CANCELLED', 'SUSPENDED'))::int AS away_matches_last_14_days,
+    m.utc_date
+FROM matches m
+WHERE m.id = @match_id;
@@ -0,0 +1,45 @@
+-- name: SearchEntities :many
+-- SearchEntities finds teams, players and competitions whose name starts
+-- with or resembles the query. Prefix matches rank above fuzzy ones. An empty
+-- types array searches every kind.
+WITH hits AS (
+    SELECT
+        'team'::text AS kind, t.id, t.external_id, t.name,
+        COALESCE(t.tla, '')::text AS detail,
+        (CASE WHEN t.name ILIKE @query::text || '%' OR t.short_name ILIKE @query::text || '%'
+              OR t.tla ILIKE @query::text THEN 1 ELSE 0 END
+         + GREATEST(word_similarity(@query::text, t.name),
+                    word_similarity(@query::text, COALESCE(t.short_name, ''))))::float8 AS score
+    FROM teams t
+    WHERE t.name ILIKE @query::text || '%'
+       OR t.short_name ILIKE @query::text || '%'
+       OR t.tla ILIKE @query::text
+       OR @query::text <% t.name
+       OR @query::text <% t.short_name
+    UNION ALL
+    SELECT
+        'player', p.id, p.external_id, p.name,
+        COALESCE(pt.name, ''),
+        (CASE WHEN p.name ILIKE @query::text || '%' OR p.name ILIKE '% ' || @query::text || '%' THEN 1 ELSE 0 END
+         + word_similarity(@query::text, p.name))::float8
+    FROM players p
+    LEFT JOIN teams pt ON pt.id = p.team_id
+    WHERE p.name ILIKE @query::text || '%'
+       OR p.name ILIKE '% ' || @query::text || '%'
+       OR @query::text <% p.name
+    UNION ALL
+    SELECT
+        'competition', c.id, c.external_id, c.name,
+        COALESCE(c.code, ''),
+        (CASE WHEN c.name ILIKE @query::text || '%' OR c.code ILIKE @query::text THEN 1 ELSE 0 END
+         + word_similarity(@query::text, c.name))::float8
+    FROM competitions c
+    WHERE c.name ILIKE @query::text || '%'
+       OR c.code ILIKE @query::text
+       OR @query::text <% c.name
+)
+SELECT kind, id, external_id, name, detail, score
+FROM hits
+WHERE cardinality(@types::text[]) = 0 OR kind = ANY(@types::text[])
+ORDER BY score DESC, LENGTH(name), name
+LIMIT @row_limit::int;
@@ -0,0 +1,54 @@
+-- name: InsertSyntheticCompetition :one
+INSERT INTO competitions (external_id, name, code, area_name, current_season_start_date, current_season_end_date, is_synthetic, source)
+VALUES (@external_id::int, @name::text, @code::text, @area_name::text, @season_start::date, @season_end::date, TRUE, 'synthetic')
+RETURNING id;
+
+-- name: InsertSyntheticTeam :one
+INSERT INTO teams (external_id, name, short_name, tla, venue, is_synthetic, source)
+VALUES (@external_id::int, @name::text, @short_name::text, @tla::text, @venue::text, TRUE, 'synthetic')
+RETURNING id;
+
+-- name: InsertSyntheticPlayer :one
+INSERT INTO players (external_id, team_id, name, position, shirt_number, is_synthetic, source)
+VALUES (@external_id::int, @team_id::int, @name::text, @position::text, @shirt_number::int, TRUE, 'synthetic')
+RETURNING id;
+
+-- name: InsertSyntheticMatch :one
+INSERT INTO matches (
+    external_id, competition_id, season, matchday, home_team_id, away_team_id,
+    utc_date, status, home_score, away_score, winner, duration, venue, is_synthetic, source
+)
+VALUES (
+    @external_id::int, @competition_id::int, @season::text, @matchday::int,
+    @home_team_id::int, @away_team_id::int, @utc_date, @status::text,
+    sqlc.narg('home_score')::int, sqlc.narg('away_score')::int, sqlc.narg('winner')::text,
+    'REGULAR', @venue::text, TRUE, 'synthetic'
+)
+RETURNING id;
+
+-- name: DeleteSyntheticCompetitions :execrows
+-- DeleteSyntheticCompetitions removes seeded competitions and, by cascade,
+-- their matches. An empty external_ids removes all of them.
+DELETE FROM competitions
+WHERE is_synthetic
+  AND (cardinality(@external_ids::int[]) = 0 OR external_id = ANY(@external_ids::int[]));
+
+-- name: DeleteSyntheticTeams :execrows
+-- DeleteSyntheticTeams removes seeded teams and, by cascade, their players.
+-- An empty external_ids removes all of them.
+DELETE FROM teams
+WHERE is_synthetic
+  AND (cardinality(@external_ids::int[]) = 0 OR external_id = ANY(@external_ids::int[]));
+
+-- name: DeleteSyntheticPlayers :execrows
+DELETE FROM players WHERE is_synthetic;
+
+-- name: DeleteSyntheticMatches :execrows
+DELETE FROM matches WHERE is_synthetic;
+
+-- name: CountSyntheticRows :one
+SELECT
+    (SELECT COUNT(*) FROM competitions WHERE is_synthetic) AS competitions,
+    (SELECT COUNT(*) FROM teams WHERE is_synthetic) AS teams,
+    (SELECT COUNT(*) FROM players WHERE is_synthetic) AS players,
+    (SELECT COUNT(*) FROM matches WHERE is_synthetic) AS matches;
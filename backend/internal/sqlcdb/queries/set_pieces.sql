@@ -0,0 +1,26 @@
+-- name: RefreshTeamSetPieceStats :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY team_set_piece_stats;
+
+-- name: RefreshPlayerSetPieceStats :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY player_set_piece_stats;
+
+-- name: GetTeamSetPieceStats :one
+-- GetTeamSetPieceStats reads a team's set-piece aggregate for a season start
+-- year, or its most recent season when season_year is empty.
+SELECT team_id, season_year, matches, goals, penalties_scored, penalties_missed,
+       matches_with_misses, refreshed_at
+FROM team_set_piece_stats
+WHERE team_id = @team_id::int
+  AND (@season_year::text = '' OR season_year::text = @season_year::text)
+ORDER BY season_year DESC
+LIMIT 1;
+
+-- name: ListPlayerSetPieceStats :many
+-- ListPlayerSetPieceStats returns a team's penalty takers in a season, most
+-- penalties taken first.
+SELECT ps.player_id, p.external_id AS player_external_id, p.name AS player_name,
+       ps.matches, ps.goals, ps.penalties_scored, ps.penalties_missed
+FROM player_set_piece_stats ps
+JOIN players p ON p.id = ps.player_id
+WHERE ps.team_id = @team_id::int AND ps.season_year = @season_year::int
+ORDER BY ps.penalties_scored + ps.penalties_missed DESC, ps.penalties_scored DESC, p.name;
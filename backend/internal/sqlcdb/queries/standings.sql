@@ -0,0 +1,72 @@
+-- name: GetStandingsRules :one
+SELECT competition_code, points_win, points_draw, points_loss, tie_breakers
+FROM standings_rules
+WHERE competition_code = @competition_code::text;
+
+-- name: UpsertStandingsRules :exec
+INSERT INTO standings_rules (competition_code, points_win, points_draw, points_loss, tie_breakers)
+VALUES (@competition_code::text, @points_win::int, @points_draw::int, @points_loss::int, @tie_breakers::text[])
+ON CONFLICT (competition_code) DO UPDATE SET
+    points_win = EXCLUDED.points_win,
+    points_draw = EXCLUDED.points_draw,
+    points_loss = EXCLUDED.points_loss,
+    tie_breakers = EXCLUDED.tie_breakers;
+
+-- name: ListSeasonResults :many
+-- ListSeasonResults returns the finished, scored matches of one stored
+-- provider season, oldest first.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+JOIN competitions c ON m.competition_id = c.id
+WHERE c.code = @competition_code::text
+  AND m.season = @season::text
+  AND m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND m.away_score IS NOT NULL
+ORDER BY m.utc_date, m.id;
+
+-- name: ListSeasonFixtures :many
+-- ListSeasonFixtures returns the matches of one stored provider season
+-- still to be played, cancelled ones aside, by kickoff.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+JOIN competitions c ON m.competition_id = c.id
+WHERE c.code = @competition_code::text
+  AND m.season = @season::text
+  AND m.status NOT IN ('FINISHED', 'CANCELLED')
+ORDER BY m.utc_date, m.id;
+
+-- name: ListGroupMatches :many
+-- ListGroupMatches returns every match of one stored provider season that
+-- belongs to a group, played or not, by group and kickoff.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by, m.group_name::text AS group_name
+FROM matches m
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+JOIN competitions c ON m.competition_id = c.id
+WHERE c.code = @competition_code::text
+  AND m.season = @season::text
+  AND m.group_name IS NOT NULL
+ORDER BY m.group_name, m.utc_date, m.id;
@@ -0,0 +1,50 @@
+-- name: UpsertTeamMatchStatistics :exec
+INSERT INTO team_match_statistics (
+    match_id, team_id, possession, shots_total, shots_on_target, shots_off_target,
+    shots_blocked, shots_inside_box, shots_outside_box, corners, fouls, offsides,
+    yellow_cards, red_cards, saves, passes_total, passes_accurate, expected_goals
+)
+VALUES (
+    @match_id::int, @team_id::int, sqlc.narg('possession')::int, sqlc.narg('shots_total')::int,
+    sqlc.narg('shots_on_target')::int, sqlc.narg('shots_off_target')::int,
+    sqlc.narg('shots_blocked')::int, sqlc.narg('shots_inside_box')::int,
+    sqlc.narg('shots_outside_box')::int, sqlc.narg('corners')::int, sqlc.narg('fouls')::int,
+    sqlc.narg('offsides')::int, sqlc.narg('yellow_cards')::int, sqlc.narg('red_cards')::int,
+    sqlc.narg('saves')::int, sqlc.narg('passes_total')::int, sqlc.narg('passes_accurate')::int,
+    sqlc.narg('expected_goals')::float8
+)
+ON CONFLICT (match_id, team_id) DO UPDATE SET
+    possession = EXCLUDED.possession,
+    shots_total = EXCLUDED.shots_total,
+    shots_on_target = EXCLUDED.shots_on_target,
+    shots_off_target = EXCLUDED.shots_off_target,
+    shots_blocked = EXCLUDED.shots_blocked,
+    shots_inside_box = EXCLUDED.shots_inside_box,
+    shots_outside_box = EXCLUDED.shots_outside_box,
+    corners = EXCLUDED.corners,
+    fouls = EXCLUDED.fouls,
+    offsides = EXCLUDED.offsides,
+    yellow_cards = EXCLUDED.yellow_cards,
+    red_cards = EXCLUDED.red_cards,
+    saves = EXCLUDED.saves,
+    passes_total = EXCLUDED.passes_total,
+    passes_accurate = EXCLUDED.passes_accurate,
+    expected_goals = EXCLUDED.expected_goals;
+
+-- name: ListTeamMatchStatistics :many
+-- ListTeamMatchStatistics returns both teams' statistics for a match, home
+-- team first.
+SELECT
+    t.id AS team_id, t.external_id AS team_external_id, t.name AS team_name,
+    s.possession, s.shots_total, s.shots_on_target, s.shots_off_target, s.shots_blocked,
+    s.shots_inside_box, s.shots_outside_box, s.corners, s.fouls, s.offsides,
+    s.yellow_cards, s.red_cards, s.saves, s.passes_total, s.passes_accurate,
+    s.expected_goals::float8 AS expected_goals
+FROM team_match_statistics s
+JOIN matches m ON m.id = s.match_id
+JOIN teams t ON t.id = s.team_id
+WHERE s.match_id = @match_id::int
+ORDER BY (s.team_id = m.home_team_id) DESC;
+
+-- name: CountTeamMatchStatistics :one
+SELECT COUNT(*) FROM team_match_statistics WHERE match_id = @match_id::int;
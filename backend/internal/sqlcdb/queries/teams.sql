@@ -0,0 +1,84 @@
+-- name: UpsertTeam :exec
+-- UpsertTeam inserts or updates a team. A team stays NATIONAL once it has
+-- been saved as one.
+INSERT INTO teams (source, external_id, name, short_name, tla, crest_url, team_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (source, external_id) DO UPDATE
+SET name = EXCLUDED.name,
+    short_name = EXCLUDED.short_name,
+    tla = EXCLUDED.tla,
+    crest_url = EXCLUDED.crest_url,
+    team_type = CASE WHEN EXCLUDED.team_type = 'NATIONAL' THEN 'NATIONAL' ELSE teams.team_type END,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: UpsertTeams :exec
+-- UpsertTeams upserts many teams in one statement, like UpsertTeam. The
+-- arrays are parallel and must not repeat an external ID.
+INSERT INTO teams (source, external_id, name, short_name, tla, crest_url, team_type)
+SELECT @source::text, t.external_id, t.name, t.short_name, t.tla, t.crest_url, t.team_type
+FROM unnest(
+    @external_ids::int[], @names::text[], @short_names::text[],
+    @tlas::text[], @crest_urls::text[], @team_types::text[]
+) AS t(external_id, name, short_name, tla, crest_url, team_type)
+ON CONFLICT (source, external_id) DO UPDATE
+SET name = EXCLUDED.name,
+    short_name = EXCLUDED.short_name,
+    tla = EXCLUDED.tla,
+    crest_url = EXCLUDED.crest_url,
+    team_type = CASE WHEN EXCLUDED.team_type = 'NATIONAL' THEN 'NATIONAL' ELSE teams.team_type END,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: GetTeamByID :one
+SELECT id, external_id, name,
+       COALESCE(short_name, '') AS short_name,
+       COALESCE(tla, '') AS tla,
+       COALESCE(crest_url, '') AS crest_url,
+       team_type
+FROM teams
+WHERE id = $1;
+
+-- name: GetTeamByExternalID :one
+SELECT id, external_id, name,
+       COALESCE(short_name, '') AS short_name,
+       COALESCE(tla, '') AS tla,
+       COALESCE(crest_url, '') AS crest_url,
+       team_type
+FROM teams
+WHERE external_id = $1;
+
+-- name: FindTeamByName :one
+-- FindTeamByName resolves a free-text team name (full name, short name or
+-- TLA) to the best matching team.
+SELECT id, external_id, name,
+       COALESCE(short_name, '') AS short_name,
+       COALESCE(tla, '') AS tla,
+       COALESCE(crest_url, '') AS crest_url,
+       team_type
+FROM teams
+WHERE LOWER(name) = LOWER(@name::text)
+   OR LOWER(short_name) = LOWER(@name::text)
+   OR LOWER(tla) = LOWER(@name::text)
+   OR name ILIKE '%' || @name::text || '%'
+   OR short_name ILIKE '%' || @name::text || '%'
+ORDER BY
+    CASE
+        WHEN LOWER(short_name) = LOWER(@name::text) OR LOWER(name) = LOWER(@name::text) THEN 0
+        WHEN LOWER(tla) = LOWER(@name::text) THEN 1
+        ELSE 2
+    END,
+    LENGTH(name)
+LIMIT 1;
+
+-- name: GetTeamIDByExternalRef :one
+SELECT id FROM teams WHERE external_id::text = @ref::text;
+
+-- name: GetTeamExternalIDByID :one
+SELECT external_id FROM teams WHERE id = $1;
+
+-- name: ListTeamCrests :many
+-- ListTeamCrests returns every team with a provider crest URL, for the
+-- crest cache refresh job.
+SELECT id, crest_url
+FROM teams
+WHERE COALESCE(crest_url, '') <> ''
+ORDER BY id;
@@ -0,0 +1,18 @@
+-- name: ListTieLegs :many
+-- ListTieLegs returns the legs of a two-legged tie, first leg first, with
+-- the same columns as ListKnockoutMatches.
+SELECT
+    m.id, m.external_id, COALESCE(c.code, '') AS competition_code, m.season,
+    COALESCE(m.matchday, 0) AS matchday, m.utc_date, m.status,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    m.home_score, m.away_score, COALESCE(m.winner, '') AS winner,
+    COALESCE(m.decided_by, '') AS decided_by, m.stage::text AS stage,
+    m.penalties_home_score, m.penalties_away_score, t.id AS tie_id
+FROM ties t
+JOIN matches m ON m.id IN (t.first_leg_id, t.second_leg_id)
+JOIN teams ht ON m.home_team_id = ht.id
+JOIN teams at ON m.away_team_id = at.id
+JOIN competitions c ON m.competition_id = c.id
+WHERE t.id = @tie_id::int
+ORDER BY m.id = t.second_leg_id, m.utc_date;
@@ -0,0 +1,48 @@
+-- name: UpsertAPIUsage :exec
+-- UpsertAPIUsage adds an aggregate of requests to a key's hourly usage.
+INSERT INTO api_usage (api_key_id, hour, endpoint, requests, errors, bytes, total_latency_ms, max_latency_ms)
+VALUES (@api_key_id, @hour, @endpoint, @requests::int, @errors::int, @bytes::bigint, @total_latency_ms::bigint, @max_latency_ms::int)
+ON CONFLICT (api_key_id, hour, endpoint) DO UPDATE SET
+    requests = api_usage.requests + EXCLUDED.requests,
+    errors = api_usage.errors + EXCLUDED.errors,
+    bytes = api_usage.bytes + EXCLUDED.bytes,
+    total_latency_ms = api_usage.total_latency_ms + EXCLUDED.total_latency_ms,
+    max_latency_ms = GREATEST(api_usage.max_latency_ms, EXCLUDED.max_latency_ms);
+
+-- name: CountUserRequestsSince :one
+-- CountUserRequestsSince counts the requests made with any of a user's keys,
+-- revoked ones included, from the hour containing since.
+SELECT COALESCE(SUM(u.requests), 0)::int AS requests
+FROM api_usage u
+JOIN api_keys k ON k.id = u.api_key_id
+WHERE k.user_id = @user_id AND u.hour >= date_trunc('hour', @since::timestamp);
+
+-- name: ListUserUsageByEndpoint :many
+-- ListUserUsageByEndpoint totals a user's usage per endpoint, busiest first,
+-- optionally for one of their keys.
+SELECT u.endpoint,
+       SUM(u.requests)::int AS requests,
+       SUM(u.errors)::int AS errors,
+       SUM(u.bytes)::bigint AS bytes,
+       (SUM(u.total_latency_ms)::float8 / GREATEST(SUM(u.requests), 1))::float8 AS avg_latency_ms,
+       MAX(u.max_latency_ms)::int AS max_latency_ms
+FROM api_usage u
+JOIN api_keys k ON k.id = u.api_key_id
+WHERE k.user_id = @user_id AND u.hour >= date_trunc('hour', @since::timestamp)
+  AND (sqlc.narg('api_key_id')::int IS NULL OR u.api_key_id = sqlc.narg('api_key_id'))
+GROUP BY u.endpoint
+ORDER BY requests DESC, u.endpoint;
+
+-- name: ListUserUsageByDay :many
+-- ListUserUsageByDay totals a user's usage per UTC day, oldest first,
+-- optionally for one of their keys.
+SELECT date_trunc('day', u.hour)::timestamp AS day,
+       SUM(u.requests)::int AS requests,
+       SUM(u.errors)::int AS errors,
+       SUM(u.bytes)::bigint AS bytes
+FROM api_usage u
+JOIN api_keys k ON k.id = u.api_key_id
+WHERE k.user_id = @user_id AND u.hour >= date_trunc('hour', @since::timestamp)
+  AND (sqlc.narg('api_key_id')::int IS NULL OR u.api_key_id = sqlc.narg('api_key_id'))
+GROUP BY day
+ORDER BY day;
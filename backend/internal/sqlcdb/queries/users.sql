@@ -0,0 +1,36 @@
+-- name: CreateUser :one
+INSERT INTO users (email, name, is_admin)
+VALUES ($1, $2, $3)
+RETURNING id, created_at;
+
+-- name: GetUserByID :one
+SELECT id, email, COALESCE(name, '') AS name, is_admin, created_at
+FROM users WHERE id = $1;
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (user_id, name, key_prefix, key_hash)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at;
+
+-- name: ListAPIKeys :many
+SELECT id, user_id, COALESCE(name, '') AS name, key_prefix, last_used_at, revoked_at, created_at
+FROM api_keys
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys SET revoked_at = NOW()
+WHERE id = @id AND user_id = @user_id AND revoked_at IS NULL;
+
+-- name: AuthenticateAPIKey :one
+-- AuthenticateAPIKey resolves an active key hash to its user and key and
+-- refreshes the key's last_used_at.
+UPDATE api_keys k SET last_used_at = NOW()
+FROM users u
+WHERE k.user_id = u.id AND k.key_hash = @key_hash AND k.revoked_at IS NULL
+RETURNING u.id AS user_id, u.email, COALESCE(u.name, '') AS user_name, u.is_admin, u.monthly_quota,
+          u.created_at AS user_created_at, k.id AS key_id, COALESCE(k.name, '') AS key_name, k.key_prefix, k.created_at AS key_created_at;
+
+-- name: SetUserMonthlyQuota :execrows
+UPDATE users SET monthly_quota = sqlc.narg('monthly_quota')
+WHERE id = @id;
@@ -0,0 +1,34 @@
+-- name: UpsertVenue :exec
+-- UpsertVenue stores a venue's location by name, replacing the stored one.
+INSERT INTO venues (name, city, country, latitude, longitude)
+VALUES (@name, sqlc.narg('city'), sqlc.narg('country'), @latitude, @longitude)
+ON CONFLICT (name) DO UPDATE SET
+    city = EXCLUDED.city,
+    country = EXCLUDED.country,
+    latitude = EXCLUDED.latitude,
+    longitude = EXCLUDED.longitude,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: ListMatchTravelCoordinates :many
+-- ListMatchTravelCoordinates returns the coordinates of the match venue and
+-- the away team's home venue of every match where both are known, only of
+-- those without a travel distance unless recompute is set. A match without
+-- a venue of its own is played at the home team's.
+SELECT m.id,
+       v.latitude::float8 AS venue_latitude, v.longitude::float8 AS venue_longitude,
+       av.latitude::float8 AS away_home_latitude, av.longitude::float8 AS away_home_longitude
+FROM matches m
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+JOIN venues v ON v.name = COALESCE(m.venue, ht.venue)
+JOIN venues av ON av.name = at.venue
+WHERE @recompute::bool OR m.travel_distance_km IS NULL
+ORDER BY m.id;
+
+-- name: SetMatchTravelDistance :exec
+UPDATE matches SET travel_distance_km = @travel_distance_km WHERE id = @id;
+
+-- name: GetMatchTravelDistance :one
+-- GetMatchTravelDistance returns the away team's travel to a match, NULL
+-- when unknown.
+SELECT travel_distance_km::float8 AS travel_distance_km FROM matches WHERE id = $1;
@@ -0,0 +1,64 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (url, secret, events, description, active)
+VALUES ($1, $2, $3, $4, true)
+RETURNING id, active, created_at;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1;
+
+-- name: ListWebhooksWithStats :many
+-- ListWebhooksWithStats returns every webhook with delivery counters. An
+-- empty last_error and a zero last_delivered_at mean there is none.
+SELECT
+    w.id, w.url, w.events, COALESCE(w.description, '') AS description, w.active, w.created_at,
+    COUNT(*) FILTER (WHERE d.status = 'pending') AS pending,
+    COUNT(*) FILTER (WHERE d.status = 'delivered') AS delivered,
+    COUNT(*) FILTER (WHERE d.status = 'failed') AS failed,
+    COALESCE((SELECT last_error FROM webhook_deliveries
+      WHERE webhook_id = w.id AND last_error IS NOT NULL
+      ORDER BY id DESC LIMIT 1), '')::text AS last_error,
+    COALESCE(MAX(d.delivered_at), '0001-01-01')::timestamp AS last_delivered_at
+FROM webhooks w
+LEFT JOIN webhook_deliveries d ON d.webhook_id = w.id
+GROUP BY w.id
+ORDER BY w.id;
+
+-- name: InsertWebhookEvent :one
+-- InsertWebhookEvent records an event once per key. No row is returned if
+-- the key was already published.
+INSERT INTO webhook_events (event_type, event_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (event_key) DO NOTHING
+RETURNING id;
+
+-- name: QueueWebhookDeliveries :exec
+-- QueueWebhookDeliveries queues an event for every active webhook
+-- subscribed to its type.
+INSERT INTO webhook_deliveries (webhook_id, event_id)
+SELECT id, @event_id::int FROM webhooks
+WHERE active AND @event_type::text = ANY(events);
+
+-- name: ListDueWebhookDeliveries :many
+SELECT d.id, w.id AS webhook_id, w.url, w.secret, e.id AS event_id, e.event_type, e.payload, d.attempts
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+JOIN webhook_events e ON e.id = d.event_id
+WHERE d.status = 'pending'
+  AND d.next_attempt_at <= NOW()
+  AND w.active
+ORDER BY d.next_attempt_at
+LIMIT @row_limit::int;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', attempts = attempts + 1, response_status = @response_status::int,
+    last_error = NULL, delivered_at = NOW()
+WHERE id = @id;
+
+-- name: MarkWebhookFailed :exec
+-- MarkWebhookFailed records a failed attempt. A NULL next_attempt_at keeps
+-- the current schedule.
+UPDATE webhook_deliveries
+SET status = @status, attempts = attempts + 1, response_status = sqlc.narg('response_status'),
+    last_error = @last_error::text, next_attempt_at = COALESCE(sqlc.narg('next_attempt_at')::timestamp, next_attempt_at)
+WHERE id = @id;
@@ -0,0 +1,184 @@
+package testutil
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Factory inserts rows with sensible defaults. Every row gets a unique
+// external ID, so factories can be called repeatedly within one test.
+// Methods return internal database IDs.
+type Factory struct {
+	tb     testing.TB
+	db     *sql.DB
+	nextID int
+}
+
+func NewFactory(tb testing.TB, db *sql.DB) *Factory {
+	return &Factory{tb: tb, db: db, nextID: 1000}
+}
+
+func (f *Factory) externalID() int {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *Factory) insert(query string, args ...interface{}) int {
+	f.tb.Helper()
+
+	var id int
+	if err := f.db.QueryRow(query, args...).Scan(&id); err != nil {
+		f.tb.Fatalf("factory insert failed: %v", err)
+	}
+	return id
+}
+
+// Competition inserts a competition with the given code, e.g. "PL".
+func (f *Factory) Competition(code string) int {
+	f.tb.Helper()
+
+	ext := f.externalID()
+	return f.insert(`
+        INSERT INTO competitions (external_id, name, code, area_name)
+        VALUES ($1, $2, $3, 'Test Area')
+        RETURNING id
+    `, ext, fmt.Sprintf("Competition %s", code), code)
+}
+
+// Team inserts a team. The TLA is derived from the name.
+func (f *Factory) Team(name string) int {
+	f.tb.Helper()
+
+	tla := name
+	if len(tla) > 3 {
+		tla = tla[:3]
+	}
+
+	return f.insert(`
+        INSERT INTO teams (external_id, name, short_name, tla)
+        VALUES ($1, $2, $2, UPPER($3))
+        RETURNING id
+    `, f.externalID(), name, tla)
+}
+
+// Match describes a fixture to insert. Zero values are filled in by
+// Factory.Match: status defaults to FINISHED when both scores are set and
+// SCHEDULED otherwise, and the date defaults to now.
+type Match struct {
+	CompetitionID int
+	HomeTeamID    int
+	AwayTeamID    int
+	Season        string
+	Matchday      int
+	UTCDate       time.Time
+	Status        string
+	HomeScore     *int
+	AwayScore     *int
+}
+
+// Match inserts a match and returns its ID.
+func (f *Factory) Match(m Match) int {
+	f.tb.Helper()
+
+	if m.UTCDate.IsZero() {
+		m.UTCDate = time.Now().UTC()
+	}
+	if m.Season == "" {
+		m.Season = fmt.Sprintf("%d", m.UTCDate.Year())
+	}
+	if m.Matchday == 0 {
+		m.Matchday = 1
+	}
+	if m.Status == "" {
+		m.Status = "SCHEDULED"
+		if m.HomeScore != nil && m.AwayScore != nil {
+			m.Status = "FINISHED"
+		}
+	}
+
+	var winner *string
+	if m.HomeScore != nil && m.AwayScore != nil {
+		w := "DRAW"
+		switch {
+		case *m.HomeScore > *m.AwayScore:
+			w = "HOME_TEAM"
+		case *m.HomeScore < *m.AwayScore:
+			w = "AWAY_TEAM"
+		}
+		winner = &w
+	}
+
+	return f.insert(`
+        INSERT INTO matches (external_id, competition_id, season, matchday, home_team_id, away_team_id,
+                             utc_date, status, home_score, away_score, winner, duration)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'REGULAR')
+        RETURNING id
+    `, f.externalID(), m.CompetitionID, m.Season, m.Matchday, m.HomeTeamID, m.AwayTeamID,
+		m.UTCDate, m.Status, m.HomeScore, m.AwayScore, winner)
+}
+
+// Result inserts a finished match with the given score.
+func (f *Factory) Result(competitionID, homeTeamID, awayTeamID, homeScore, awayScore int, date time.Time) int {
+	f.tb.Helper()
+
+	return f.Match(Match{
+		CompetitionID: competitionID,
+		HomeTeamID:    homeTeamID,
+		AwayTeamID:    awayTeamID,
+		UTCDate:       date,
+		HomeScore:     &homeScore,
+		AwayScore:     &awayScore,
+	})
+}
+
+// Player inserts a player for a team.
+func (f *Factory) Player(teamID int, name, position string) int {
+	f.tb.Helper()
+
+	return f.insert(`
+        INSERT INTO players (external_id, team_id, name, position)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id
+    `, f.externalID(), teamID, name, position)
+}
+
+// PlayerStats describes one player's line in a match.
+type PlayerStats struct {
+	Goals         int
+	Assists       int
+	Shots         int
+	MinutesPlayed int
+}
+
+// PlayerStats inserts a player_match_stats row. Minutes default to 90.
+func (f *Factory) PlayerStats(matchID, playerID int, s PlayerStats) int {
+	f.tb.Helper()
+
+	if s.MinutesPlayed == 0 {
+		s.MinutesPlayed = 90
+	}
+
+	return f.insert(`
+        INSERT INTO player_match_stats (match_id, player_id, goals, assists, shots, minutes_played)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id
+    `, matchID, playerID, s.Goals, s.Assists, s.Shots, s.MinutesPlayed)
+}
+
+// Standing inserts a standings row.
+func (f *Factory) Standing(competitionID, teamID int, season string, position, points int) int {
+	f.tb.Helper()
+
+	return f.insert(`
+        INSERT INTO standings (competition_id, season, team_id, position, points)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `, competitionID, season, teamID, position, points)
+}
+
+// IntPtr returns a pointer to n, for optional score fields.
+func IntPtr(n int) *int {
+	return &n
+}
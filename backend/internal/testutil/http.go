@@ -0,0 +1,47 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// PerformRequest sends a request to handler and returns the recorded
+// response. A non-nil body is encoded as JSON. Extra headers are given as
+// key/value pairs, e.g. "X-API-Key", key.
+func PerformRequest(tb testing.TB, handler http.Handler, method, path string, body interface{}, headers ...string) *httptest.ResponseRecorder {
+	tb.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			tb.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// DecodeJSON decodes a recorded response body into v.
+func DecodeJSON(tb testing.TB, w *httptest.ResponseRecorder, v interface{}) {
+	tb.Helper()
+
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		tb.Fatalf("failed to decode response %q: %v", w.Body.String(), err)
+	}
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer traces pgx queries made within a traced request or job. Spans
+// are named after the sqlc query, e.g. "db GetMatchPredictionInputs".
+// Queries made without a span in their context, such as those of most
+// background work, are not traced, so they do not each start a trace.
+type QueryTracer struct{}
+
+// querySpanKey holds the span of the query in its context, which is only
+// set for traced queries.
+type querySpanKey struct{}
+
+var _ pgx.QueryTracer = QueryTracer{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	name := queryName(data.SQL)
+	ctx, span := Start(ctx, "db "+name,
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperationName(name),
+		semconv.DBQueryText(data.SQL),
+	)
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if span, ok := ctx.Value(querySpanKey{}).(trace.Span); ok {
+		End(span, data.Err)
+	}
+}
+
+// queryName returns the name sqlc puts in the leading "-- name: X :one"
+// comment, or the statement's first keyword for hand-written SQL.
+func queryName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if rest, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		if name, _, ok := strings.Cut(rest, " "); ok {
+			return name
+		}
+	}
+	if keyword, _, ok := strings.Cut(sql, " "); ok {
+		return strings.ToUpper(keyword)
+	}
+	return "query"
+}
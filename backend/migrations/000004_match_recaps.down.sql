@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_match_recaps_updated_at ON match_recaps;
+DROP INDEX IF EXISTS idx_match_recaps_generated_at;
+DROP TABLE IF EXISTS match_recaps;
@@ -0,0 +1,20 @@
+-- Post-match recaps generated after settlement
+
+CREATE TABLE IF NOT EXISTS match_recaps (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER UNIQUE NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    headline VARCHAR(255) NOT NULL,
+    summary TEXT NOT NULL,
+    key_moments JSONB NOT NULL DEFAULT '[]',
+    player_of_the_match JSONB,
+    prediction_review JSONB,
+    generated_by VARCHAR(100) NOT NULL,
+    generated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_recaps_generated_at ON match_recaps(generated_at DESC);
+
+CREATE TRIGGER update_match_recaps_updated_at BEFORE UPDATE ON match_recaps
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
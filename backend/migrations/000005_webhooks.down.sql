@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_webhooks_updated_at ON webhooks;
+DROP INDEX IF EXISTS idx_webhook_events_type;
+DROP INDEX IF EXISTS idx_webhook_deliveries_pending;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_events;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
+DROP INDEX IF EXISTS idx_user_follows_user;
+DROP INDEX IF EXISTS idx_api_keys_user;
+DROP TABLE IF EXISTS user_follows;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS users;
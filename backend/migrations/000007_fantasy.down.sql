@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS fantasy_player_points;
+DROP TRIGGER IF EXISTS update_fantasy_scoring_rules_updated_at ON fantasy_scoring_rules;
+DROP TABLE IF EXISTS fantasy_scoring_rules;
+DROP TABLE IF EXISTS match_events;
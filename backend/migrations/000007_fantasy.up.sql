@@ -0,0 +1,62 @@
+-- Match events (goals, cards) and fantasy scoring
+
+CREATE TABLE IF NOT EXISTS match_events (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE,
+    player_id INTEGER REFERENCES players(id) ON DELETE CASCADE,
+    event_type VARCHAR(30) NOT NULL, -- GOAL / OWN_GOAL / PENALTY / YELLOW_CARD / YELLOW_RED_CARD / RED_CARD
+    minute INTEGER,
+    injury_time INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(match_id, player_id, event_type, minute)
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_events_match ON match_events(match_id);
+CREATE INDEX IF NOT EXISTS idx_match_events_player ON match_events(player_id);
+
+-- Points awarded per action. position is GK / DEF / MID / FWD or ANY.
+CREATE TABLE IF NOT EXISTS fantasy_scoring_rules (
+    id SERIAL PRIMARY KEY,
+    action VARCHAR(30) NOT NULL,
+    position VARCHAR(10) NOT NULL DEFAULT 'ANY',
+    points INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(action, position)
+);
+
+CREATE TRIGGER update_fantasy_scoring_rules_updated_at BEFORE UPDATE ON fantasy_scoring_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO fantasy_scoring_rules (action, position, points) VALUES
+    ('appearance', 'ANY', 1),
+    ('appearance_60', 'ANY', 1),
+    ('goal', 'GK', 6),
+    ('goal', 'DEF', 6),
+    ('goal', 'MID', 5),
+    ('goal', 'FWD', 4),
+    ('assist', 'ANY', 3),
+    ('clean_sheet', 'GK', 4),
+    ('clean_sheet', 'DEF', 4),
+    ('clean_sheet', 'MID', 1),
+    ('goals_conceded_2', 'GK', -1),
+    ('goals_conceded_2', 'DEF', -1),
+    ('yellow_card', 'ANY', -1),
+    ('red_card', 'ANY', -3),
+    ('own_goal', 'ANY', -2)
+ON CONFLICT (action, position) DO NOTHING;
+
+-- Computed points per player per match
+CREATE TABLE IF NOT EXISTS fantasy_player_points (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    player_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    position VARCHAR(10) NOT NULL,
+    points INTEGER NOT NULL,
+    breakdown JSONB NOT NULL DEFAULT '{}',
+    computed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(match_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fantasy_points_player ON fantasy_player_points(player_id);
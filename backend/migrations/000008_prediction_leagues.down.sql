@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_user_predictions_updated_at ON user_predictions;
+DROP INDEX IF EXISTS idx_league_members_user;
+DROP INDEX IF EXISTS idx_user_predictions_match;
+DROP TABLE IF EXISTS league_members;
+DROP TABLE IF EXISTS leagues;
+DROP TABLE IF EXISTS user_predictions;
@@ -0,0 +1,38 @@
+-- User score predictions and private leagues ranking users against the model
+
+CREATE TABLE IF NOT EXISTS user_predictions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    home_goals INTEGER NOT NULL CHECK (home_goals >= 0),
+    away_goals INTEGER NOT NULL CHECK (away_goals >= 0),
+    points INTEGER,                 -- set on settlement
+    outcome_correct BOOLEAN,
+    exact_score BOOLEAN,
+    settled_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, match_id)
+);
+
+CREATE TABLE IF NOT EXISTS leagues (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    competition_id INTEGER REFERENCES competitions(id) ON DELETE SET NULL, -- NULL = all competitions
+    invite_code VARCHAR(16) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS league_members (
+    league_id INTEGER NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (league_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_predictions_match ON user_predictions(match_id);
+CREATE INDEX IF NOT EXISTS idx_league_members_user ON league_members(user_id);
+
+CREATE TRIGGER update_user_predictions_updated_at BEFORE UPDATE ON user_predictions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_backfill_jobs_updated_at ON backfill_jobs;
+DROP TABLE IF EXISTS backfill_jobs;
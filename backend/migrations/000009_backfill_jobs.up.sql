@@ -0,0 +1,19 @@
+-- Checkpoints for historical backfills, one row per competition season
+
+CREATE TABLE IF NOT EXISTS backfill_jobs (
+    id SERIAL PRIMARY KEY,
+    competition_code VARCHAR(10) NOT NULL,
+    season VARCHAR(10) NOT NULL,           -- season start year
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending / running / done / failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    matches_saved INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(competition_code, season)
+);
+
+CREATE TRIGGER update_backfill_jobs_updated_at BEFORE UPDATE ON backfill_jobs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
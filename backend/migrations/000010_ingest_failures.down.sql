@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ingest_failures_open;
+DROP TABLE IF EXISTS ingest_failures;
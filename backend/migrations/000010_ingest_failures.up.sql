@@ -0,0 +1,17 @@
+-- Dead-letter table for ingest rows that could not be saved
+
+CREATE TABLE IF NOT EXISTS ingest_failures (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(30) NOT NULL,  -- competition / team / match / player_stats / match_event
+    external_ref VARCHAR(100) NOT NULL, -- provider ID (or composite key) of the failed row
+    payload JSONB NOT NULL,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    first_failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP
+);
+
+-- One open failure per row; repeated failures bump attempts instead.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ingest_failures_open
+    ON ingest_failures(entity_type, external_ref) WHERE resolved_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN IF EXISTS venue;
+ALTER TABLE matches DROP COLUMN IF EXISTS referee;
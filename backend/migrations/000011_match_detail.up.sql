@@ -0,0 +1,5 @@
+-- Officials and venue captured from the match detail payload so the match
+-- endpoint can be served from local storage
+
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS referee VARCHAR(255);
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS venue VARCHAR(255);
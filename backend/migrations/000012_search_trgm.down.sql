@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_competitions_name_trgm;
+DROP INDEX IF EXISTS idx_players_name_trgm;
+DROP INDEX IF EXISTS idx_teams_short_name_trgm;
+DROP INDEX IF EXISTS idx_teams_name_trgm;
@@ -0,0 +1,8 @@
+-- Trigram indexes for fuzzy search across teams, players and competitions
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_teams_name_trgm ON teams USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_teams_short_name_trgm ON teams USING GIN (short_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_players_name_trgm ON players USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_competitions_name_trgm ON competitions USING GIN (name gin_trgm_ops);
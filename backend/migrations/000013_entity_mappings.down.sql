@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS entity_aliases;
+DROP TRIGGER IF EXISTS update_entity_mappings_updated_at ON entity_mappings;
+DROP TABLE IF EXISTS entity_mappings;
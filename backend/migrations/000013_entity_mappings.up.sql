@@ -0,0 +1,33 @@
+-- Cross-provider entity resolution: provider IDs mapped onto local rows, and
+-- alternative names used when matching by name
+
+CREATE TABLE IF NOT EXISTS entity_mappings (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL,  -- team / player / match
+    provider VARCHAR(30) NOT NULL,     -- e.g. api-football
+    provider_id INTEGER NOT NULL,
+    provider_name VARCHAR(255),
+    local_id INTEGER NOT NULL,         -- teams.id / players.id / matches.id
+    confidence NUMERIC(4,3) NOT NULL,
+    method VARCHAR(20) NOT NULL,       -- exact / alias / fuzzy / manual
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(entity_type, provider, provider_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_mappings_local ON entity_mappings(entity_type, provider, local_id);
+
+CREATE TRIGGER update_entity_mappings_updated_at BEFORE UPDATE ON entity_mappings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Aliases are stored normalized (see service.NormalizeEntityName).
+CREATE TABLE IF NOT EXISTS entity_aliases (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL,  -- team / player
+    local_id INTEGER NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(entity_type, local_id, alias)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_aliases_alias ON entity_aliases(entity_type, alias);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_team_match_statistics_updated_at ON team_match_statistics;
+DROP TABLE IF EXISTS team_match_statistics;
@@ -0,0 +1,32 @@
+-- Per-team match statistics from API-Football /fixtures/statistics.
+-- Counts are NULL when the provider does not report them for a fixture.
+
+CREATE TABLE IF NOT EXISTS team_match_statistics (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams(id),
+    possession INTEGER,               -- percent of ball possession
+    shots_total INTEGER,
+    shots_on_target INTEGER,
+    shots_off_target INTEGER,
+    shots_blocked INTEGER,
+    shots_inside_box INTEGER,
+    shots_outside_box INTEGER,
+    corners INTEGER,
+    fouls INTEGER,
+    offsides INTEGER,
+    yellow_cards INTEGER,
+    red_cards INTEGER,
+    saves INTEGER,
+    passes_total INTEGER,
+    passes_accurate INTEGER,
+    expected_goals NUMERIC(5,2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(match_id, team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_match_statistics_team ON team_match_statistics(team_id);
+
+CREATE TRIGGER update_team_match_statistics_updated_at BEFORE UPDATE ON team_match_statistics
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+ALTER TABLE players DROP COLUMN IF EXISTS is_synthetic;
+ALTER TABLE matches DROP COLUMN IF EXISTS is_synthetic;
+ALTER TABLE teams DROP COLUMN IF EXISTS is_synthetic;
+ALTER TABLE competitions DROP COLUMN IF EXISTS is_synthetic;
@@ -0,0 +1,13 @@
+-- Flag rows loaded by 'footballctl seed' so demo data is never mistaken for
+-- provider data and can be purged with 'footballctl seed --clean'. Seeded
+-- rows use negative external IDs, which no provider issues.
+
+ALTER TABLE competitions ADD COLUMN IF NOT EXISTS is_synthetic BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS is_synthetic BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS is_synthetic BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS is_synthetic BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_competitions_synthetic ON competitions(id) WHERE is_synthetic;
+CREATE INDEX IF NOT EXISTS idx_teams_synthetic ON teams(id) WHERE is_synthetic;
+CREATE INDEX IF NOT EXISTS idx_matches_synthetic ON matches(id) WHERE is_synthetic;
+CREATE INDEX IF NOT EXISTS idx_players_synthetic ON players(id) WHERE is_synthetic;
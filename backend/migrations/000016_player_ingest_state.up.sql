@@ -0,0 +1,25 @@
+-- Per-match progress of cmd/player_ingest, so the whole backlog of finished
+-- matches can be worked through across runs
+
+CREATE TABLE IF NOT EXISTS player_ingest_state (
+    match_id INTEGER PRIMARY KEY REFERENCES matches(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,           -- done / failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    goals INTEGER NOT NULL DEFAULT 0,      -- goals reported by the provider
+    last_error TEXT,
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_ingest_state_status ON player_ingest_state(status);
+
+CREATE TRIGGER update_player_ingest_state_updated_at BEFORE UPDATE ON player_ingest_state
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Matches ingested before this table existed are already done
+INSERT INTO player_ingest_state (match_id, status, attempts, goals)
+SELECT match_id, 'done', 1, COALESCE(SUM(goals), 0)
+FROM player_match_stats
+GROUP BY match_id
+ON CONFLICT (match_id) DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS own_goals;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS penalties;
@@ -0,0 +1,19 @@
+-- Goal types in player stats. goals still counts every goal the player
+-- scored for their own team, penalties included; own goals are kept apart
+-- and never counted in goals.
+
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS penalties INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS own_goals INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill from the goal events already recorded
+INSERT INTO player_match_stats (match_id, player_id, goals, assists, penalties, own_goals)
+SELECT match_id, player_id, 0, 0,
+       COUNT(*) FILTER (WHERE event_type = 'PENALTY'),
+       COUNT(*) FILTER (WHERE event_type = 'OWN_GOAL')
+FROM match_events
+WHERE player_id IS NOT NULL
+  AND event_type IN ('PENALTY', 'OWN_GOAL')
+GROUP BY match_id, player_id
+ON CONFLICT (match_id, player_id) DO UPDATE SET
+    penalties = EXCLUDED.penalties,
+    own_goals = EXCLUDED.own_goals;
@@ -0,0 +1,5 @@
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS duels_won;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS duels;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS passes_accurate;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS passes;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS shots_on_target;
@@ -0,0 +1,9 @@
+-- Per-player detail from API-Football /fixtures/players, alongside the
+-- rating, shots, key passes, tackles and interceptions columns that already
+-- exist
+
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS shots_on_target INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS passes INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS passes_accurate INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS duels INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS duels_won INTEGER;
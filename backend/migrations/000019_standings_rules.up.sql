@@ -0,0 +1,28 @@
+-- Per-competition points and tie-break order for tables computed from stored
+-- results. Competitions without a row use 3/1/0 points and goal difference
+-- then goals scored.
+
+CREATE TABLE IF NOT EXISTS standings_rules (
+    competition_code VARCHAR(10) PRIMARY KEY,
+    points_win INTEGER NOT NULL DEFAULT 3,
+    points_draw INTEGER NOT NULL DEFAULT 1,
+    points_loss INTEGER NOT NULL DEFAULT 0,
+    tie_breakers TEXT[] NOT NULL,          -- applied in order after points
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_standings_rules_updated_at BEFORE UPDATE ON standings_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO standings_rules (competition_code, tie_breakers) VALUES
+    ('PL', ARRAY['goal_difference', 'goals_for', 'h2h_points', 'h2h_away_goals_for']),
+    ('ELC', ARRAY['goal_difference', 'goals_for', 'h2h_points', 'h2h_goal_difference']),
+    ('PD', ARRAY['h2h_points', 'h2h_goal_difference', 'goal_difference', 'goals_for']),
+    ('SA', ARRAY['h2h_points', 'h2h_goal_difference', 'goal_difference', 'goals_for']),
+    ('BL1', ARRAY['goal_difference', 'goals_for', 'h2h_points', 'h2h_away_goals_for', 'away_goals_for']),
+    ('FL1', ARRAY['goal_difference', 'h2h_points', 'h2h_goal_difference', 'goals_for']),
+    ('DED', ARRAY['goal_difference', 'goals_for', 'h2h_points', 'h2h_goal_difference']),
+    ('PPL', ARRAY['h2h_points', 'h2h_goal_difference', 'goal_difference', 'wins', 'goals_for']),
+    ('CL', ARRAY['goal_difference', 'goals_for', 'away_goals_for', 'wins', 'away_wins'])
+ON CONFLICT (competition_code) DO NOTHING;
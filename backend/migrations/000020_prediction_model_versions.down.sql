@@ -0,0 +1,18 @@
+-- Keep only the primary prediction of each match
+DELETE FROM prediction_history WHERE NOT is_primary;
+DELETE FROM prediction_history ph
+USING prediction_history newer
+WHERE newer.match_id = ph.match_id AND newer.id > ph.id;
+
+DROP INDEX IF EXISTS idx_prediction_history_primary;
+DROP INDEX IF EXISTS idx_prediction_history_match_model;
+
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS away_win_probability;
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS draw_probability;
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS home_win_probability;
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS is_primary;
+
+ALTER TABLE prediction_history ALTER COLUMN model_version DROP NOT NULL;
+ALTER TABLE prediction_history ALTER COLUMN model_version DROP DEFAULT;
+
+ALTER TABLE prediction_history ADD CONSTRAINT prediction_history_match_id_key UNIQUE (match_id);
@@ -0,0 +1,48 @@
+-- Let several models predict the same match: one prediction_history row per
+-- match and model version. The row served to users is marked primary; the
+-- others are shadow predictions kept for comparison.
+
+-- prediction_history used to come only from the legacy
+-- 003_enhanced_features.sql; create it here so that file's UNIQUE(match_id)
+-- never applies to a fresh database
+CREATE TABLE IF NOT EXISTS prediction_history (
+    id SERIAL PRIMARY KEY,
+    match_id INT REFERENCES matches(id),
+    predicted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    team_a_name VARCHAR(100),
+    team_b_name VARCHAR(100),
+    predicted_team_a_goals DECIMAL(3,1),
+    predicted_team_b_goals DECIMAL(3,1),
+    predicted_outcome VARCHAR(100),
+    predicted_winner VARCHAR(100),
+    confidence_score DECIMAL(4,2),
+    actual_team_a_goals INT,
+    actual_team_b_goals INT,
+    actual_outcome VARCHAR(100),
+    actual_winner VARCHAR(100),
+    prediction_correct BOOLEAN,
+    features_used JSONB,
+    insights_generated TEXT[],
+    model_version VARCHAR(100),
+    goals_error_team_a DECIMAL(3,1),
+    goals_error_team_b DECIMAL(3,1),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE prediction_history DROP CONSTRAINT IF EXISTS prediction_history_match_id_key;
+
+-- ensemble versions name both of their models
+ALTER TABLE prediction_history ALTER COLUMN model_version TYPE VARCHAR(100);
+UPDATE prediction_history SET model_version = 'unknown' WHERE model_version IS NULL;
+ALTER TABLE prediction_history ALTER COLUMN model_version SET DEFAULT 'unknown';
+ALTER TABLE prediction_history ALTER COLUMN model_version SET NOT NULL;
+
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS home_win_probability DECIMAL(4,3);
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS draw_probability DECIMAL(4,3);
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS away_win_probability DECIMAL(4,3);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prediction_history_match_model
+    ON prediction_history(match_id, model_version);
+CREATE INDEX IF NOT EXISTS idx_prediction_history_primary
+    ON prediction_history(match_id) WHERE is_primary;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_prediction_history_anomaly_unchecked;
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS anomaly_checked_at;
+
+DROP TABLE IF EXISTS anomalies;
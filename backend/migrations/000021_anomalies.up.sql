@@ -0,0 +1,22 @@
+-- Finished matches whose result the served prediction got badly wrong: an
+-- upset, where the actual outcome was given a low probability, or a
+-- scoreline far from the predicted goals.
+
+CREATE TABLE IF NOT EXISTS anomalies (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,              -- upset / scoreline
+    model_version VARCHAR(100) NOT NULL,
+    outcome_probability DECIMAL(4,3),       -- probability given to the actual outcome
+    goals_error DECIMAL(4,1),               -- total goals the scoreline was off by
+    context JSONB NOT NULL DEFAULT '{}',
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(match_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_anomalies_detected_at ON anomalies(detected_at DESC);
+
+-- Settled predictions the detector has looked at, flagged or not
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS anomaly_checked_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_prediction_history_anomaly_unchecked
+    ON prediction_history(match_id) WHERE anomaly_checked_at IS NULL AND is_primary;
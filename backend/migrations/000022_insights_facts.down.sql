@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS insights_fact_runs;
+DROP TABLE IF EXISTS insights_facts;
@@ -0,0 +1,24 @@
+-- Streaks and upcoming milestones of teams and their players, computed from
+-- stored results by the insights job. Facts of a team and its players are
+-- replaced together on every run.
+
+CREATE TABLE IF NOT EXISTS insights_facts (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    player_id INTEGER REFERENCES players(id) ON DELETE CASCADE, -- NULL for team facts
+    kind VARCHAR(40) NOT NULL,
+    value INTEGER NOT NULL,             -- streak length or current total
+    target INTEGER,                     -- milestone being approached
+    description TEXT NOT NULL,
+    since_date TIMESTAMP,               -- first match of a streak
+    last_match_id INTEGER REFERENCES matches(id) ON DELETE SET NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_insights_facts_team ON insights_facts(team_id);
+
+-- When each team's facts were last computed, including teams with none
+CREATE TABLE IF NOT EXISTS insights_fact_runs (
+    team_id INTEGER PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- The table may predate this migration, so only the index added here is
+-- dropped
+DROP INDEX IF EXISTS idx_player_availability_team;
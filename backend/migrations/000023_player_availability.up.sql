@@ -0,0 +1,17 @@
+-- Injuries and suspensions used to come only from the legacy
+-- 003_enhanced_features.sql; create the table here so fresh databases have it
+-- for matchday previews
+CREATE TABLE IF NOT EXISTS player_availability (
+    id SERIAL PRIMARY KEY,
+    player_id INT REFERENCES players(id),
+    team_id INT REFERENCES teams(id),
+    unavailable_from DATE NOT NULL,
+    unavailable_until DATE,
+    reason VARCHAR(50), -- 'injury', 'suspension', 'other'
+    severity VARCHAR(20), -- 'minor', 'moderate', 'severe'
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_availability_player ON player_availability(player_id);
+CREATE INDEX IF NOT EXISTS idx_player_availability_dates ON player_availability(unavailable_from, unavailable_until);
+CREATE INDEX IF NOT EXISTS idx_player_availability_team ON player_availability(team_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS backtest_predictions;
+DROP TABLE IF EXISTS backtests;
@@ -0,0 +1,34 @@
+-- Backtests replay finished fixtures through a prediction backend using only
+-- results available before each kickoff, so model changes can be validated
+-- before they are deployed.
+
+CREATE TABLE IF NOT EXISTS backtests (
+    id SERIAL PRIMARY KEY,
+    backend VARCHAR(20) NOT NULL,           -- go / baseline
+    model_version VARCHAR(100) NOT NULL,
+    competition_code VARCHAR(10),           -- NULL for every competition
+    season VARCHAR(10) NOT NULL,            -- season start year
+    matches INTEGER NOT NULL,
+    correct INTEGER NOT NULL,
+    accuracy DECIMAL(5,4),
+    log_loss DECIMAL(8,5),
+    brier DECIMAL(8,5),
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS backtest_predictions (
+    backtest_id INTEGER NOT NULL REFERENCES backtests(id) ON DELETE CASCADE,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    home_win_probability DECIMAL(4,3) NOT NULL,
+    draw_probability DECIMAL(4,3) NOT NULL,
+    away_win_probability DECIMAL(4,3) NOT NULL,
+    predicted_outcome VARCHAR(10) NOT NULL, -- HOME / DRAW / AWAY
+    actual_outcome VARCHAR(10) NOT NULL,
+    expected_home_goals DECIMAL(3,1),
+    expected_away_goals DECIMAL(3,1),
+    trained_on INTEGER NOT NULL,            -- results the model was fitted on
+    PRIMARY KEY (backtest_id, match_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_backtests_finished_at ON backtests(finished_at DESC);
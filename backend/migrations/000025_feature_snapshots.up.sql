@@ -0,0 +1,22 @@
+-- Per-team features as they stood at a point in time. Live predictions
+-- snapshot them when they are recorded and backtests as of each kickoff day;
+-- both are computed only from data before as_of.
+
+CREATE TABLE IF NOT EXISTS feature_snapshots (
+    id SERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    as_of TIMESTAMP NOT NULL,
+    source VARCHAR(20) NOT NULL,            -- live / backtest
+    elo DECIMAL(6,1) NOT NULL,
+    form VARCHAR(10) NOT NULL DEFAULT '',   -- last results, most recent first, e.g. WWDLW
+    form_points INTEGER NOT NULL DEFAULT 0,
+    goals_for_avg DECIMAL(4,2),
+    goals_against_avg DECIMAL(4,2),
+    matches INTEGER NOT NULL DEFAULT 0,     -- results the goal averages cover
+    unavailable INTEGER NOT NULL DEFAULT 0, -- injured or suspended players
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(match_id, team_id, as_of)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feature_snapshots_match ON feature_snapshots(match_id, as_of DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS api_usage;
+ALTER TABLE users DROP COLUMN IF EXISTS monthly_quota;
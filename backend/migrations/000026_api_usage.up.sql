@@ -0,0 +1,18 @@
+-- Per-key API usage, aggregated by hour and endpoint, and per-user monthly
+-- request quotas.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS monthly_quota INTEGER; -- NULL uses API_MONTHLY_QUOTA, 0 is unlimited
+
+CREATE TABLE IF NOT EXISTS api_usage (
+    api_key_id INTEGER NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    hour TIMESTAMP NOT NULL,               -- start of the UTC hour
+    endpoint VARCHAR(200) NOT NULL,        -- method and route, e.g. GET /api/v1/me/feed
+    requests INTEGER NOT NULL DEFAULT 0,
+    errors INTEGER NOT NULL DEFAULT 0,     -- responses with status >= 400
+    bytes BIGINT NOT NULL DEFAULT 0,       -- response body bytes
+    total_latency_ms BIGINT NOT NULL DEFAULT 0,
+    max_latency_ms INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, hour, endpoint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_usage_hour ON api_usage(hour);
@@ -0,0 +1,17 @@
+-- One row per retention policy applied, whether by the scheduled job or an
+-- admin. Policies either clear large payloads and keep their rows, or delete
+-- rows that can be rebuilt.
+
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id SERIAL PRIMARY KEY,
+    policy VARCHAR(40) NOT NULL,
+    months INTEGER NOT NULL,            -- data older than this many months was purged
+    cutoff TIMESTAMP NOT NULL,
+    rows_affected INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    triggered_by VARCHAR(20) NOT NULL,  -- schedule / admin
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started ON retention_runs(started_at DESC);
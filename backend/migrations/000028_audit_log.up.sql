@@ -0,0 +1,20 @@
+-- Who did what: admin API actions, key management and operational commands
+-- run with footballctl.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id SERIAL PRIMARY KEY,
+    action VARCHAR(60) NOT NULL,        -- e.g. webhook.register, key.revoke, cli.seed
+    actor_type VARCHAR(20) NOT NULL,    -- admin / user / cli
+    actor VARCHAR(255) NOT NULL,        -- user email, OS user for the CLI, "admin token" otherwise
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '', -- e.g. the route parameters, "id=3 keyId=7"
+    method VARCHAR(10) NOT NULL DEFAULT '',
+    path TEXT NOT NULL DEFAULT '',
+    status INTEGER,                     -- HTTP status; 0 or 1 exit code for the CLI
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    details JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log(action, created_at DESC);
@@ -0,0 +1 @@
+DROP MATERIALIZED VIEW IF EXISTS match_prediction_inputs;
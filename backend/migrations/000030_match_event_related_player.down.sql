@@ -0,0 +1,2 @@
+DELETE FROM match_events WHERE event_type = 'SUBSTITUTION';
+ALTER TABLE match_events DROP COLUMN IF EXISTS related_player_id;
@@ -0,0 +1,4 @@
+-- The second player of an event. For a SUBSTITUTION, player_id is the
+-- player coming on and related_player_id the player going off.
+ALTER TABLE match_events
+    ADD COLUMN IF NOT EXISTS related_player_id INTEGER REFERENCES players(id) ON DELETE SET NULL;
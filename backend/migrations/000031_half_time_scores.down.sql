@@ -0,0 +1,4 @@
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS lead_hold_rate;
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS comeback_rate;
+ALTER TABLE matches DROP COLUMN IF EXISTS half_time_away_score;
+ALTER TABLE matches DROP COLUMN IF EXISTS half_time_home_score;
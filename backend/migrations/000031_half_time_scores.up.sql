@@ -0,0 +1,9 @@
+-- Half-time scores of stored matches, and the half-time/full-time pattern
+-- features derived from them.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS half_time_home_score INTEGER;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS half_time_away_score INTEGER;
+
+-- Share of matches trailing at half time that were not lost, and of matches
+-- leading at half time that were won; NULL without such matches.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS comeback_rate DECIMAL(4,3);
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS lead_hold_rate DECIMAL(4,3);
@@ -0,0 +1,5 @@
+ALTER TABLE matches DROP COLUMN IF EXISTS decided_by;
+ALTER TABLE matches DROP COLUMN IF EXISTS penalties_away_score;
+ALTER TABLE matches DROP COLUMN IF EXISTS penalties_home_score;
+ALTER TABLE matches DROP COLUMN IF EXISTS extra_time_away_score;
+ALTER TABLE matches DROP COLUMN IF EXISTS extra_time_home_score;
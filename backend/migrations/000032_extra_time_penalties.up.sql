@@ -0,0 +1,13 @@
+-- Knockout results. home_score and away_score count goals in play, extra
+-- time included and shootout penalties excluded; extra_time_* are the goals
+-- of extra time alone and penalties_* the shootout. Matches stored before
+-- this migration are corrected when they are next ingested.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS extra_time_home_score INTEGER;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS extra_time_away_score INTEGER;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS penalties_home_score INTEGER;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS penalties_away_score INTEGER;
+
+-- How a finished match was decided: REGULAR, EXTRA_TIME or PENALTY_SHOOTOUT
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS decided_by VARCHAR(20) GENERATED ALWAYS AS (
+    CASE WHEN status = 'FINISHED' THEN COALESCE(duration, 'REGULAR') END
+) STORED;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_matches_competition_season_stage;
+ALTER TABLE matches DROP COLUMN IF EXISTS group_name;
+ALTER TABLE matches DROP COLUMN IF EXISTS stage;
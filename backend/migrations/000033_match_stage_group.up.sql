@@ -0,0 +1,7 @@
+-- Tournament stage and group of a match as football-data.org reports them,
+-- e.g. GROUP_STAGE with GROUP_A, or QUARTER_FINALS. League matches are
+-- REGULAR_SEASON with no group.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS stage VARCHAR(30);
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS group_name VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_matches_competition_season_stage ON matches(competition_id, season, stage);
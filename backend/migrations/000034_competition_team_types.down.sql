@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS team_type;
+ALTER TABLE competitions DROP COLUMN IF EXISTS team_type;
+ALTER TABLE competitions DROP COLUMN IF EXISTS type;
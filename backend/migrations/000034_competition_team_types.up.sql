@@ -0,0 +1,20 @@
+-- Competition format (LEAGUE, CUP, ...) as football-data.org reports it, and
+-- whether clubs or national teams play in it. A team is NATIONAL once it is
+-- seen in a national-team competition, so club and international results
+-- can be told apart.
+ALTER TABLE competitions ADD COLUMN IF NOT EXISTS type VARCHAR(20);
+ALTER TABLE competitions ADD COLUMN IF NOT EXISTS team_type VARCHAR(10) NOT NULL DEFAULT 'CLUB'
+    CHECK (team_type IN ('CLUB', 'NATIONAL'));
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS team_type VARCHAR(10) NOT NULL DEFAULT 'CLUB'
+    CHECK (team_type IN ('CLUB', 'NATIONAL'));
+
+UPDATE competitions SET team_type = 'NATIONAL'
+WHERE code IN ('WC', 'EC', 'QAFC', 'QCAF', 'QCCF', 'QCBL', 'QOFC', 'QUFA');
+
+UPDATE teams SET team_type = 'NATIONAL'
+WHERE id IN (
+    SELECT unnest(ARRAY[m.home_team_id, m.away_team_id])
+    FROM matches m
+    JOIN competitions c ON c.id = m.competition_id
+    WHERE c.team_type = 'NATIONAL'
+);
@@ -0,0 +1,4 @@
+-- Number of times a match was rescheduled, postponed or cancelled, or
+-- reinstated, since it was first stored. Calendar feeds serve it as the
+-- event's SEQUENCE so subscribed clients pick up the change.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS schedule_sequence INT NOT NULL DEFAULT 0;
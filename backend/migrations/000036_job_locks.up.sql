@@ -0,0 +1,9 @@
+-- Leases on scheduled jobs, so that with several API replicas each job runs
+-- on one of them at a time. The holder renews its lease while the job runs;
+-- a lease past expires_at, e.g. of a replica that died, can be taken over.
+CREATE TABLE IF NOT EXISTS job_locks (
+    name VARCHAR(100) PRIMARY KEY,
+    owner VARCHAR(255) NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
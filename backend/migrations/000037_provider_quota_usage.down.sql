@@ -0,0 +1 @@
+DROP TABLE IF EXISTS provider_quota_usage;
@@ -0,0 +1,13 @@
+-- Requests made to each external provider per API key in the current minute
+-- and UTC day, so the API and the ingest commands share one budget per key
+-- and keep their counts across restarts. key_id identifies the key without
+-- storing it.
+CREATE TABLE IF NOT EXISTS provider_quota_usage (
+    provider VARCHAR(50) NOT NULL,
+    key_id VARCHAR(32) NOT NULL,
+    period VARCHAR(10) NOT NULL, -- minute / day
+    window_start TIMESTAMPTZ NOT NULL,
+    requests INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, key_id, period)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS new_manager;
+DROP TABLE IF EXISTS team_coach_spells;
+ALTER TABLE match_lineups DROP COLUMN IF EXISTS coach_id;
+DROP INDEX IF EXISTS idx_coaches_source_external;
+ALTER TABLE coaches DROP COLUMN IF EXISTS external_id;
+ALTER TABLE coaches DROP COLUMN IF EXISTS source;
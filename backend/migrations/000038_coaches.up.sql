@@ -0,0 +1,36 @@
+-- Coaches named in team sheets, the coach of each stored lineup, and each
+-- team's spells under a coach derived from them. The legacy schema may
+-- already have a coaches table, without provider IDs.
+CREATE TABLE IF NOT EXISTS coaches (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    nationality VARCHAR(50),
+    date_of_birth DATE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE coaches ADD COLUMN IF NOT EXISTS source VARCHAR(20); -- provider of external_id: football-data / api-football
+ALTER TABLE coaches ADD COLUMN IF NOT EXISTS external_id INTEGER;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_coaches_source_external ON coaches(source, external_id);
+
+ALTER TABLE match_lineups ADD COLUMN IF NOT EXISTS coach_id INTEGER REFERENCES coaches(id) ON DELETE SET NULL;
+
+-- Consecutive matches of a team under the same coach. Rebuilt from
+-- match_lineups whenever one of the team's lineups is saved.
+CREATE TABLE IF NOT EXISTS team_coach_spells (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    coach_id INTEGER NOT NULL REFERENCES coaches(id) ON DELETE CASCADE,
+    first_match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    last_match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,   -- kickoff of the first match
+    last_match_at TIMESTAMP NOT NULL,
+    matches INTEGER NOT NULL,
+    UNIQUE(team_id, started_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_coach_spells_team ON team_coach_spells(team_id, started_at DESC);
+
+-- Whether the team was within its first matches under a coach who replaced
+-- another, the "new manager bounce".
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS new_manager BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_match_events_team_type;
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS booking_risk;
@@ -0,0 +1,6 @@
+-- How many of the team's players were one booking from a ban in the
+-- match's competition.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS booking_risk INTEGER NOT NULL DEFAULT 0;
+
+-- Card events are counted per team and season for suspension risk.
+CREATE INDEX IF NOT EXISTS idx_match_events_team_type ON match_events(team_id, event_type);
@@ -0,0 +1,3 @@
+DROP MATERIALIZED VIEW IF EXISTS player_set_piece_stats;
+DROP MATERIALIZED VIEW IF EXISTS team_set_piece_stats;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS penalties_missed;
@@ -0,0 +1,68 @@
+-- Penalties a player missed in a match, as API-Football reports them. NULL
+-- when only football-data.org, which reports goals alone, covered the match.
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS penalties_missed INTEGER;
+
+-- Set-piece scoring per team and per player and season, by season start
+-- year across competitions. Neither provider says which goals came from
+-- free kicks or corners, so penalties are the set pieces counted: scored
+-- from goal types, missed from API-Football player statistics. The
+-- set-pieces job refreshes both.
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS team_set_piece_stats AS
+WITH seasons AS (
+    SELECT season, EXTRACT(YEAR FROM MIN(utc_date))::int AS season_year
+    FROM matches
+    GROUP BY season
+),
+results AS (
+    SELECT m.id AS match_id, m.season, m.home_team_id AS team_id, m.home_score AS goals
+    FROM matches m
+    WHERE m.status = 'FINISHED' AND m.home_score IS NOT NULL
+    UNION ALL
+    SELECT m.id, m.season, m.away_team_id, m.away_score
+    FROM matches m
+    WHERE m.status = 'FINISHED' AND m.away_score IS NOT NULL
+)
+SELECT r.team_id, s.season_year,
+       COUNT(*)::int AS matches,
+       SUM(r.goals)::int AS goals,
+       SUM(pen.scored)::int AS penalties_scored,
+       SUM(pen.missed)::int AS penalties_missed,
+       COUNT(*) FILTER (WHERE pen.misses_known)::int AS matches_with_misses,
+       CURRENT_TIMESTAMP::timestamp AS refreshed_at
+FROM results r
+JOIN seasons s ON s.season = r.season
+CROSS JOIN LATERAL (
+    SELECT (SELECT COUNT(*) FROM match_events e
+            WHERE e.match_id = r.match_id AND e.team_id = r.team_id AND e.event_type = 'PENALTY') AS scored,
+           COALESCE(SUM(ps.penalties_missed), 0) AS missed,
+           COUNT(ps.penalties_missed) > 0 AS misses_known
+    FROM player_match_stats ps
+    JOIN players p ON p.id = ps.player_id
+    WHERE ps.match_id = r.match_id AND p.team_id = r.team_id
+) pen
+GROUP BY r.team_id, s.season_year;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_set_piece_stats_team ON team_set_piece_stats(team_id, season_year);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS player_set_piece_stats AS
+WITH seasons AS (
+    SELECT season, EXTRACT(YEAR FROM MIN(utc_date))::int AS season_year
+    FROM matches
+    GROUP BY season
+)
+SELECT ps.player_id, p.team_id, s.season_year,
+       COUNT(*)::int AS matches,
+       SUM(COALESCE(ps.goals, 0))::int AS goals,
+       SUM(ps.penalties)::int AS penalties_scored,
+       SUM(COALESCE(ps.penalties_missed, 0))::int AS penalties_missed
+FROM player_match_stats ps
+JOIN players p ON p.id = ps.player_id
+JOIN matches m ON m.id = ps.match_id
+JOIN seasons s ON s.season = m.season
+WHERE m.status = 'FINISHED' AND p.team_id IS NOT NULL
+GROUP BY ps.player_id, p.team_id, s.season_year
+HAVING SUM(ps.penalties) > 0 OR SUM(COALESCE(ps.penalties_missed, 0)) > 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_set_piece_stats_player ON player_set_piece_stats(player_id, season_year);
+CREATE INDEX IF NOT EXISTS idx_player_set_piece_stats_team ON player_set_piece_stats(team_id, season_year);
@@ -0,0 +1,2 @@
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS home_advantage;
+DROP TABLE IF EXISTS home_advantage_stats;
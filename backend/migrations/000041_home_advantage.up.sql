@@ -0,0 +1,28 @@
+-- Home advantage per competition and per team within it, over the latest
+-- window_matches matches each team played at home and away. Competition
+-- rows pool the matches of every team's window. previous_ppg_differential
+-- covers the window before, so a falling differential shows crowds counting
+-- for less. The home-advantage job replaces every row on each run.
+
+CREATE TABLE IF NOT EXISTS home_advantage_stats (
+    id SERIAL PRIMARY KEY,
+    competition_id INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE, -- NULL for the competition as a whole
+    window_matches INTEGER NOT NULL,
+    home_matches INTEGER NOT NULL,
+    away_matches INTEGER NOT NULL,
+    home_ppg DECIMAL(4,2) NOT NULL,
+    away_ppg DECIMAL(4,2) NOT NULL,
+    ppg_differential DECIMAL(4,2) NOT NULL,     -- home_ppg - away_ppg
+    home_goal_difference DECIMAL(4,2) NOT NULL, -- per match
+    away_goal_difference DECIMAL(4,2) NOT NULL,
+    goal_differential DECIMAL(4,2) NOT NULL,    -- home_goal_difference - away_goal_difference
+    previous_ppg_differential DECIMAL(4,2),     -- NULL until the window before was played at both venues
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_home_advantage_stats_scope
+    ON home_advantage_stats(competition_id, COALESCE(team_id, 0), window_matches);
+
+-- How much better the team did at home than away over its latest results.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS home_advantage DECIMAL(4,2);
@@ -0,0 +1 @@
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS league_strength;
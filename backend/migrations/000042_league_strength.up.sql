@@ -0,0 +1,4 @@
+-- Strength coefficient of the team's home league when its opponent came
+-- from another league, whose goal averages were normalised by it. NULL
+-- when both teams play in the same league.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS league_strength DECIMAL(4,3);
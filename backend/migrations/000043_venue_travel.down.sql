@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN IF EXISTS travel_distance_km;
+DROP TABLE IF EXISTS venues;
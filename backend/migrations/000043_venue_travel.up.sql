@@ -0,0 +1,16 @@
+-- Stadium coordinates by the venue name the provider gives matches and
+-- teams, loaded with footballctl venues since neither provider sends them.
+CREATE TABLE IF NOT EXISTS venues (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    city VARCHAR(255),
+    country VARCHAR(255),
+    latitude DECIMAL(9,6) NOT NULL,
+    longitude DECIMAL(9,6) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Great-circle distance from the away team's home venue to the match venue.
+-- NULL until both venues have coordinates.
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS travel_distance_km DECIMAL(7,1);
@@ -0,0 +1,14 @@
+-- Keep only the latest prediction of each match and model
+DELETE FROM prediction_history ph
+USING prediction_history newer
+WHERE newer.match_id = ph.match_id
+  AND newer.model_version = ph.model_version
+  AND newer.version > ph.version;
+
+DROP INDEX IF EXISTS idx_prediction_history_final;
+DROP INDEX IF EXISTS idx_prediction_history_match_model_version;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prediction_history_match_model
+    ON prediction_history(match_id, model_version);
+
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS is_final;
+ALTER TABLE prediction_history DROP COLUMN IF EXISTS version;
@@ -0,0 +1,19 @@
+-- Keep every prediction a model makes for a match instead of replacing the
+-- last one. Each model's predictions of a match are numbered from 1; its
+-- latest made before kickoff is marked final and is the one the accuracy
+-- stats count, so predicting again after kickoff no longer changes them.
+
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE prediction_history ADD COLUMN IF NOT EXISTS is_final BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Stored predictions are each model's only one, final if made in time
+UPDATE prediction_history ph
+SET is_final = TRUE
+FROM matches m
+WHERE m.id = ph.match_id AND ph.predicted_at < m.utc_date;
+
+DROP INDEX IF EXISTS idx_prediction_history_match_model;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prediction_history_match_model_version
+    ON prediction_history(match_id, model_version, version);
+CREATE INDEX IF NOT EXISTS idx_prediction_history_final
+    ON prediction_history(match_id) WHERE is_final;
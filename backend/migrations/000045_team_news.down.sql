@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS news_article_players;
+DROP TABLE IF EXISTS news_article_teams;
+DROP TABLE IF EXISTS news_articles;
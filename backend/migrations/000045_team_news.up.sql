@@ -0,0 +1,33 @@
+-- Team news pulled from the configured RSS feeds and news API, one row per
+-- article URL, with the teams and players each article is about.
+CREATE TABLE IF NOT EXISTS news_articles (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(100) NOT NULL,
+    url TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    summary TEXT,
+    published_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_articles_published ON news_articles(published_at);
+
+-- Relevance runs from 0 to 1: a name in the headline counts more than one
+-- only in the summary.
+CREATE TABLE IF NOT EXISTS news_article_teams (
+    article_id INTEGER NOT NULL REFERENCES news_articles(id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    relevance NUMERIC(4,3) NOT NULL,
+    PRIMARY KEY (article_id, team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_article_teams_team ON news_article_teams(team_id);
+
+CREATE TABLE IF NOT EXISTS news_article_players (
+    article_id INTEGER NOT NULL REFERENCES news_articles(id) ON DELETE CASCADE,
+    player_id INTEGER NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    relevance NUMERIC(4,3) NOT NULL,
+    PRIMARY KEY (article_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_article_players_player ON news_article_players(player_id);
@@ -0,0 +1,4 @@
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS manager_pressure;
+ALTER TABLE feature_snapshots DROP COLUMN IF EXISTS news_absences;
+DROP TABLE IF EXISTS team_signals;
+ALTER TABLE news_articles DROP COLUMN IF EXISTS signals_extracted_at;
@@ -0,0 +1,25 @@
+-- Structured signals an LLM extracted from team news: injury doubts and
+-- suspensions of a player, and pressure on a team's manager, each with the
+-- model's confidence. Articles are read once; signals_extracted_at marks
+-- those done.
+ALTER TABLE news_articles ADD COLUMN IF NOT EXISTS signals_extracted_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS team_signals (
+    id SERIAL PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES news_articles(id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    player_id INTEGER REFERENCES players(id) ON DELETE CASCADE,
+    signal VARCHAR(30) NOT NULL,       -- injury_doubt / suspension / manager_pressure
+    confidence NUMERIC(4,3) NOT NULL,
+    detail TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_signals_team ON team_signals(team_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_signals_unique
+    ON team_signals(article_id, team_id, COALESCE(player_id, 0), signal);
+
+-- Players counted unavailable from news signals rather than recorded
+-- absences, and whether the news had the manager under pressure.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS news_absences INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS manager_pressure BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_match_embeddings_embedding;
+DROP TABLE IF EXISTS match_embeddings;
@@ -0,0 +1,16 @@
+-- Feature vectors of finished matches for similar-match retrieval: form,
+-- head-to-head, strength gap and stakes as they stood at kickoff. Vectors
+-- from an older encoder are re-encoded; features keeps the named values
+-- behind the vector for display.
+CREATE EXTENSION IF NOT EXISTS vector;
+
+CREATE TABLE IF NOT EXISTS match_embeddings (
+    match_id INTEGER PRIMARY KEY REFERENCES matches(id) ON DELETE CASCADE,
+    encoder_version VARCHAR(20) NOT NULL,
+    embedding vector(9) NOT NULL,
+    features JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_embeddings_embedding
+    ON match_embeddings USING hnsw (embedding vector_l2_ops);
@@ -0,0 +1,17 @@
+-- One row per request to an LLM provider, answered or failed, with its
+-- tokens and cost. A failed request is followed by one to the next model on
+-- the task's route.
+CREATE TABLE IF NOT EXISTS llm_usage (
+    id BIGSERIAL PRIMARY KEY,
+    task VARCHAR(30) NOT NULL,            -- insight type, e.g. recap or preview
+    provider VARCHAR(20) NOT NULL,        -- openai, anthropic or ollama
+    model VARCHAR(100) NOT NULL,
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT,                           -- NULL when the provider answered
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_usage_created_at ON llm_usage(created_at);
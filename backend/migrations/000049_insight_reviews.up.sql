@@ -0,0 +1,21 @@
+-- Generated insights that failed a quality check, queued for review.
+-- Rejected texts were never published and a template was served instead;
+-- flagged ones were published. Identical texts are queued once.
+CREATE TABLE IF NOT EXISTS insight_reviews (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,               -- recap, preview or answer
+    match_id INTEGER REFERENCES matches(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL DEFAULT '',        -- the question an answer replies to
+    headline TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    generated_by VARCHAR(150) NOT NULL,
+    issues JSONB NOT NULL,
+    published BOOLEAN NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,       -- SHA-256 of the headline and body
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved or rejected
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, content_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_insight_reviews_status ON insight_reviews(status, created_at DESC);
@@ -0,0 +1,11 @@
+-- Weekly email digests. Users following a team get the digest until they
+-- unsubscribe; a row is created when the first digest is sent or the user
+-- changes their subscription.
+CREATE TABLE IF NOT EXISTS email_digests (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    subscribed BOOLEAN NOT NULL DEFAULT true,
+    unsubscribe_token VARCHAR(64) UNIQUE NOT NULL, -- random, carried by the unsubscribe link
+    last_sent_at TIMESTAMP,
+    unsubscribed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
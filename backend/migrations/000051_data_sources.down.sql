@@ -0,0 +1,24 @@
+-- Fails if two sources share an external ID.
+ALTER TABLE coaches DROP CONSTRAINT IF EXISTS coaches_source_fkey;
+
+DROP INDEX IF EXISTS idx_competitions_external;
+DROP INDEX IF EXISTS idx_teams_external;
+DROP INDEX IF EXISTS idx_matches_external;
+DROP INDEX IF EXISTS idx_players_external;
+
+DROP INDEX IF EXISTS idx_competitions_source_external;
+DROP INDEX IF EXISTS idx_teams_source_external;
+DROP INDEX IF EXISTS idx_matches_source_external;
+DROP INDEX IF EXISTS idx_players_source_external;
+
+ALTER TABLE competitions ADD CONSTRAINT competitions_external_id_key UNIQUE (external_id);
+ALTER TABLE teams ADD CONSTRAINT teams_external_id_key UNIQUE (external_id);
+ALTER TABLE matches ADD CONSTRAINT matches_external_id_key UNIQUE (external_id);
+ALTER TABLE players ADD CONSTRAINT players_external_id_key UNIQUE (external_id);
+
+ALTER TABLE competitions DROP COLUMN IF EXISTS source;
+ALTER TABLE teams DROP COLUMN IF EXISTS source;
+ALTER TABLE matches DROP COLUMN IF EXISTS source;
+ALTER TABLE players DROP COLUMN IF EXISTS source;
+
+DROP TABLE IF EXISTS data_sources;
@@ -0,0 +1,44 @@
+-- Providers whose IDs are stored in external_id. Competitions, teams,
+-- matches and players are keyed by (source, external_id), so two providers
+-- may issue the same number without colliding.
+CREATE TABLE IF NOT EXISTS data_sources (
+    code VARCHAR(20) PRIMARY KEY,
+    sport VARCHAR(20) NOT NULL DEFAULT 'football',
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO data_sources (code, sport, name) VALUES
+    ('football-data', 'football', 'football-data.org'),
+    ('api-football', 'football', 'API-Football'),
+    ('synthetic', 'football', 'Seeded demo data')
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE competitions ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'football-data' REFERENCES data_sources(code);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'football-data' REFERENCES data_sources(code);
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'football-data' REFERENCES data_sources(code);
+ALTER TABLE players ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'football-data' REFERENCES data_sources(code);
+
+-- Rows loaded by 'footballctl seed' were told apart by negative IDs only.
+UPDATE competitions SET source = 'synthetic' WHERE is_synthetic;
+UPDATE teams SET source = 'synthetic' WHERE is_synthetic;
+UPDATE matches SET source = 'synthetic' WHERE is_synthetic;
+UPDATE players SET source = 'synthetic' WHERE is_synthetic;
+
+ALTER TABLE competitions DROP CONSTRAINT IF EXISTS competitions_external_id_key;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_external_id_key;
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS matches_external_id_key;
+ALTER TABLE players DROP CONSTRAINT IF EXISTS players_external_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_competitions_source_external ON competitions(source, external_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teams_source_external ON teams(source, external_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_matches_source_external ON matches(source, external_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_players_source_external ON players(source, external_id);
+
+-- Lookups by external ID alone, e.g. from API paths, still need an index.
+CREATE INDEX IF NOT EXISTS idx_competitions_external ON competitions(external_id);
+CREATE INDEX IF NOT EXISTS idx_teams_external ON teams(external_id);
+CREATE INDEX IF NOT EXISTS idx_matches_external ON matches(external_id);
+CREATE INDEX IF NOT EXISTS idx_players_external ON players(external_id);
+
+ALTER TABLE coaches ADD CONSTRAINT coaches_source_fkey FOREIGN KEY (source) REFERENCES data_sources(code);
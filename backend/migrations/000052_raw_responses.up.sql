@@ -0,0 +1,14 @@
+-- Provider responses as fetched, so they can be re-processed through newer
+-- parsers with 'footballctl replay' instead of fetched again. Only bodies are
+-- kept, never request headers, so no API key is stored.
+CREATE TABLE IF NOT EXISTS raw_responses (
+    id BIGSERIAL PRIMARY KEY,
+    source VARCHAR(20) NOT NULL REFERENCES data_sources(code),
+    endpoint TEXT NOT NULL,               -- path and sorted query, e.g. /v4/competitions/PL/matches?season=2024
+    body BYTEA NOT NULL,                  -- gzip-compressed JSON
+    size_bytes INTEGER NOT NULL,          -- uncompressed size of body
+    fetched_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_raw_responses_endpoint ON raw_responses(source, endpoint, fetched_at DESC);
+CREATE INDEX IF NOT EXISTS idx_raw_responses_fetched_at ON raw_responses(fetched_at);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS record_matches_status_change ON matches;
+DROP FUNCTION IF EXISTS record_match_status_change();
+DROP TABLE IF EXISTS match_status_history;
@@ -0,0 +1,43 @@
+-- Every change of a stored match's status or kickoff, written by a trigger
+-- so each ingest path records it. kind classifies the change; postponements,
+-- cancellations and reschedules are announced as events once, after which
+-- notified_at is set.
+CREATE TABLE IF NOT EXISTS match_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    match_id INTEGER NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,            -- status, postponed, cancelled, reinstated or rescheduled
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    from_utc_date TIMESTAMP NOT NULL,
+    to_utc_date TIMESTAMP NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    notified_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_status_history_match ON match_status_history(match_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_match_status_history_pending ON match_status_history(changed_at)
+    WHERE notified_at IS NULL AND kind <> 'status';
+
+CREATE OR REPLACE FUNCTION record_match_status_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    change_kind VARCHAR(20);
+BEGIN
+    change_kind := CASE
+        WHEN NEW.status = 'CANCELLED' AND OLD.status <> 'CANCELLED' THEN 'cancelled'
+        WHEN NEW.status = 'POSTPONED' AND OLD.status <> 'POSTPONED' THEN 'postponed'
+        WHEN OLD.status IN ('POSTPONED', 'CANCELLED') AND NEW.status NOT IN ('POSTPONED', 'CANCELLED') THEN 'reinstated'
+        WHEN NEW.utc_date <> OLD.utc_date THEN 'rescheduled'
+        ELSE 'status'
+    END;
+
+    INSERT INTO match_status_history (match_id, kind, from_status, to_status, from_utc_date, to_utc_date)
+    VALUES (NEW.id, change_kind, OLD.status, NEW.status, OLD.utc_date, NEW.utc_date);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER record_matches_status_change AFTER UPDATE OF status, utc_date ON matches
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status OR OLD.utc_date IS DISTINCT FROM NEW.utc_date)
+    EXECUTE FUNCTION record_match_status_change();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS link_matches_tie_on_update ON matches;
+DROP TRIGGER IF EXISTS link_matches_tie_on_insert ON matches;
+DROP FUNCTION IF EXISTS link_match_tie();
+DROP TABLE IF EXISTS ties;
@@ -0,0 +1,68 @@
+-- Two-legged knockout ties: the first and second leg of a pairing, the
+-- same two teams with home and away swapped in one stage of a season.
+-- A trigger links the legs once both are stored, so each ingest path does.
+CREATE TABLE IF NOT EXISTS ties (
+    id SERIAL PRIMARY KEY,
+    competition_id INTEGER NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+    season VARCHAR(20) NOT NULL,
+    stage VARCHAR(30) NOT NULL,
+    first_leg_id INTEGER NOT NULL UNIQUE REFERENCES matches(id) ON DELETE CASCADE,
+    second_leg_id INTEGER NOT NULL UNIQUE REFERENCES matches(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ties_competition_season ON ties(competition_id, season, stage);
+
+CREATE OR REPLACE FUNCTION link_match_tie()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.stage IS NULL OR NEW.stage NOT IN ('PLAYOFFS', 'LAST_64', 'LAST_32', 'LAST_16', 'QUARTER_FINALS', 'SEMI_FINALS') THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO ties (competition_id, season, stage, first_leg_id, second_leg_id)
+    SELECT NEW.competition_id, NEW.season, NEW.stage,
+           CASE WHEN (m.utc_date, m.id) < (NEW.utc_date, NEW.id) THEN m.id ELSE NEW.id END,
+           CASE WHEN (m.utc_date, m.id) < (NEW.utc_date, NEW.id) THEN NEW.id ELSE m.id END
+    FROM matches m
+    WHERE m.competition_id = NEW.competition_id
+      AND m.season = NEW.season
+      AND m.stage = NEW.stage
+      AND m.home_team_id = NEW.away_team_id
+      AND m.away_team_id = NEW.home_team_id
+      AND m.id <> NEW.id
+      AND NOT EXISTS (
+          SELECT 1 FROM ties t
+          WHERE t.first_leg_id IN (m.id, NEW.id) OR t.second_leg_id IN (m.id, NEW.id)
+      )
+    ORDER BY m.utc_date, m.id
+    LIMIT 1;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER link_matches_tie_on_insert AFTER INSERT ON matches
+    FOR EACH ROW
+    EXECUTE FUNCTION link_match_tie();
+
+CREATE TRIGGER link_matches_tie_on_update AFTER UPDATE OF stage, home_team_id, away_team_id ON matches
+    FOR EACH ROW
+    WHEN (OLD.stage IS DISTINCT FROM NEW.stage
+          OR OLD.home_team_id IS DISTINCT FROM NEW.home_team_id
+          OR OLD.away_team_id IS DISTINCT FROM NEW.away_team_id)
+    EXECUTE FUNCTION link_match_tie();
+
+-- Link the ties already stored
+INSERT INTO ties (competition_id, season, stage, first_leg_id, second_leg_id)
+SELECT DISTINCT ON (first_leg.id) first_leg.competition_id, first_leg.season, first_leg.stage, first_leg.id, second_leg.id
+FROM matches first_leg
+JOIN matches second_leg
+  ON second_leg.competition_id = first_leg.competition_id
+ AND second_leg.season = first_leg.season
+ AND second_leg.stage = first_leg.stage
+ AND second_leg.home_team_id = first_leg.away_team_id
+ AND second_leg.away_team_id = first_leg.home_team_id
+ AND (second_leg.utc_date, second_leg.id) > (first_leg.utc_date, first_leg.id)
+WHERE first_leg.stage IN ('PLAYOFFS', 'LAST_64', 'LAST_32', 'LAST_16', 'QUARTER_FINALS', 'SEMI_FINALS')
+ORDER BY first_leg.id, second_leg.utc_date
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+-- Regular starters a team left out of its announced lineup, counted once
+-- the lineup is stored before kickoff.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS lineup_absences INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,101 @@
+DROP MATERIALIZED VIEW IF EXISTS match_prediction_inputs;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS match_prediction_inputs AS
+SELECT
+    m.id AS match_id,
+    m.external_id,
+    m.status,
+    COALESCE(m.matchday, 0) AS matchday,
+    m.utc_date,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    h2h.matches AS h2h_matches,
+    h2h.home_wins AS h2h_home_wins,
+    h2h.away_wins AS h2h_away_wins,
+    h2h.draws AS h2h_draws,
+    COALESCE(kp.home, '[]'::jsonb) AS home_key_players,
+    COALESCE(kp.away, '[]'::jsonb) AS away_key_players,
+    (SELECT COUNT(*)
+     FROM match_lineups ml
+     JOIN match_lineup_players mlp ON mlp.match_lineup_id = ml.id
+     WHERE ml.match_id = m.id)::int AS lineup_players,
+    (SELECT MAX(ml.created_at)
+     FROM match_lineups ml
+     WHERE ml.match_id = m.id) AS lineups_updated_at,
+    (SELECT COUNT(*)
+     FROM matches r
+     WHERE r.status = 'FINISHED'
+       AND r.home_score IS NOT NULL
+       AND (r.home_team_id IN (m.home_team_id, m.away_team_id)
+            OR r.away_team_id IN (m.home_team_id, m.away_team_id)))::int AS team_results,
+    (SELECT MAX(r.updated_at)
+     FROM matches r
+     WHERE r.status = 'FINISHED'
+       AND (r.home_team_id IN (m.home_team_id, m.away_team_id)
+            OR r.away_team_id IN (m.home_team_id, m.away_team_id))) AS results_updated_at,
+    CURRENT_TIMESTAMP::timestamp AS refreshed_at
+FROM matches m
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+-- Meetings are counted from the perspective of this fixture's home and away
+-- teams, whoever was at home at the time
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::int AS matches,
+           COUNT(*) FILTER (WHERE (p.winner = 'HOME_TEAM' AND p.home_team_id = m.home_team_id)
+                               OR (p.winner = 'AWAY_TEAM' AND p.away_team_id = m.home_team_id))::int AS home_wins,
+           COUNT(*) FILTER (WHERE (p.winner = 'HOME_TEAM' AND p.home_team_id = m.away_team_id)
+                               OR (p.winner = 'AWAY_TEAM' AND p.away_team_id = m.away_team_id))::int AS away_wins,
+           COUNT(*) FILTER (WHERE p.winner = 'DRAW')::int AS draws
+    FROM (
+        SELECT prev.home_team_id, prev.away_team_id, prev.winner
+        FROM matches prev
+        WHERE ((prev.home_team_id = m.home_team_id AND prev.away_team_id = m.away_team_id)
+            OR (prev.home_team_id = m.away_team_id AND prev.away_team_id = m.home_team_id))
+          AND prev.home_score IS NOT NULL
+          AND prev.away_score IS NOT NULL
+        ORDER BY prev.utc_date DESC
+        LIMIT 10
+    ) p
+) h2h
+-- Key players ranked like ListKeyPlayersForMatch, six across both teams
+LEFT JOIN LATERAL (
+    SELECT jsonb_agg(k.player ORDER BY k.rank) FILTER (WHERE k.team_id = m.home_team_id) AS home,
+           jsonb_agg(k.player ORDER BY k.rank) FILTER (WHERE k.team_id = m.away_team_id) AS away
+    FROM (
+        SELECT p.team_id,
+               ROW_NUMBER() OVER (ORDER BY COALESCE(s.goals, 0) DESC, COALESCE(s.assists, 0) DESC,
+                                  s.rating DESC NULLS LAST, s.minutes_played ASC NULLS LAST) AS rank,
+               jsonb_strip_nulls(jsonb_build_object(
+                   'name', p.name,
+                   'position', COALESCE(p.position, ''),
+                   'teamExternalId', t.external_id,
+                   'goals', COALESCE(s.goals, 0),
+                   'assists', COALESCE(s.assists, 0),
+                   'penalties', s.penalties,
+                   'ownGoals', s.own_goals,
+                   'minutesPlayed', s.minutes_played,
+                   'rating', s.rating
+               )) AS player
+        FROM player_match_stats s
+        JOIN players p ON p.id = s.player_id
+        JOIN teams t ON t.id = p.team_id
+        WHERE s.match_id = m.id
+        ORDER BY rank
+        LIMIT 6
+    ) k
+) kp ON TRUE
+WHERE m.status IN ('SCHEDULED', 'TIMED')
+  AND m.utc_date < (NOW() AT TIME ZONE 'UTC') + INTERVAL '14 days';
+
+-- REFRESH ... CONCURRENTLY needs a unique index
+CREATE UNIQUE INDEX IF NOT EXISTS idx_match_prediction_inputs_match ON match_prediction_inputs(match_id);
+CREATE INDEX IF NOT EXISTS idx_match_prediction_inputs_external ON match_prediction_inputs(external_id);
+
+DROP FUNCTION IF EXISTS key_player_score(TEXT, INTEGER, INTEGER, INTEGER, BOOLEAN, INTEGER, INTEGER, INTEGER, INTEGER, INTEGER);
+DROP FUNCTION IF EXISTS player_role(TEXT, TEXT);
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS clean_sheet;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS blocks;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS penalties_saved;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS goals_conceded;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS saves;
+ALTER TABLE player_match_stats DROP COLUMN IF EXISTS position;
@@ -0,0 +1,166 @@
+-- Goalkeeping and defensive statistics from API-Football /fixtures/players,
+-- alongside the tackles and interceptions already stored: saves, goals and
+-- penalties conceded or saved, blocks, the position played and whether the
+-- player kept a clean sheet, i.e. played at least 60 minutes of a match
+-- their team conceded none in. NULL when the provider did not report them.
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS position VARCHAR(20);
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS saves INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS goals_conceded INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS penalties_saved INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS blocks INTEGER;
+ALTER TABLE player_match_stats ADD COLUMN IF NOT EXISTS clean_sheet BOOLEAN;
+
+-- Clean sheets of the matches whose lineups are already stored
+UPDATE player_match_stats s
+SET clean_sheet = (CASE WHEN ml.is_home THEN m.away_score ELSE m.home_score END) = 0
+                  AND COALESCE(s.minutes_played, 0) >= 60
+FROM match_lineups ml
+JOIN match_lineup_players lp ON lp.match_lineup_id = ml.id
+JOIN matches m ON m.id = ml.match_id
+WHERE ml.match_id = s.match_id
+  AND lp.player_id = s.player_id
+  AND m.status = 'FINISHED'
+  AND m.home_score IS NOT NULL
+  AND s.clean_sheet IS NULL;
+
+-- player_role is the line a player played in a match: G, D, M or F as
+-- API-Football reports it, otherwise from the player's stored position,
+-- midfield when that is unknown.
+CREATE OR REPLACE FUNCTION player_role(match_position TEXT, player_position TEXT)
+RETURNS TEXT AS $$
+BEGIN
+    IF match_position IN ('G', 'D', 'M', 'F') THEN
+        RETURN match_position;
+    END IF;
+    RETURN CASE
+        WHEN player_position ILIKE 'goalkeeper' THEN 'G'
+        WHEN player_position ILIKE '%midfield%' THEN 'M'
+        WHEN player_position ILIKE 'defen%' OR player_position ILIKE '%back' THEN 'D'
+        WHEN player_position ILIKE ANY (ARRAY['offence', '%forward', '%winger', 'striker']) THEN 'F'
+        ELSE 'M'
+    END;
+END;
+$$ language 'plpgsql' IMMUTABLE;
+
+-- key_player_score weighs a player's match by the line they played in: goals
+-- count most for defenders and goalkeepers, clean sheets only behind the
+-- ball, and saves and defensive actions a third of a point each.
+CREATE OR REPLACE FUNCTION key_player_score(
+    role TEXT, goals INTEGER, assists INTEGER, own_goals INTEGER, clean_sheet BOOLEAN,
+    saves INTEGER, penalties_saved INTEGER, tackles INTEGER, interceptions INTEGER, blocks INTEGER
+)
+RETURNS NUMERIC AS $$
+BEGIN
+    RETURN COALESCE(goals, 0) * CASE role WHEN 'G' THEN 6 WHEN 'D' THEN 6 WHEN 'M' THEN 5 ELSE 4 END
+        + COALESCE(assists, 0) * 3
+        + CASE WHEN clean_sheet THEN CASE role WHEN 'G' THEN 4 WHEN 'D' THEN 4 WHEN 'M' THEN 1 ELSE 0 END ELSE 0 END
+        + COALESCE(penalties_saved, 0) * 5
+        + (COALESCE(saves, 0) + COALESCE(tackles, 0) + COALESCE(interceptions, 0) + COALESCE(blocks, 0)) / 3.0
+        - COALESCE(own_goals, 0) * 2;
+END;
+$$ language 'plpgsql' IMMUTABLE;
+
+-- Rank the read model's key players the same way
+DROP MATERIALIZED VIEW IF EXISTS match_prediction_inputs;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS match_prediction_inputs AS
+SELECT
+    m.id AS match_id,
+    m.external_id,
+    m.status,
+    COALESCE(m.matchday, 0) AS matchday,
+    m.utc_date,
+    ht.id AS home_team_id, ht.external_id AS home_team_external_id, ht.name AS home_team_name,
+    at.id AS away_team_id, at.external_id AS away_team_external_id, at.name AS away_team_name,
+    h2h.matches AS h2h_matches,
+    h2h.home_wins AS h2h_home_wins,
+    h2h.away_wins AS h2h_away_wins,
+    h2h.draws AS h2h_draws,
+    COALESCE(kp.home, '[]'::jsonb) AS home_key_players,
+    COALESCE(kp.away, '[]'::jsonb) AS away_key_players,
+    (SELECT COUNT(*)
+     FROM match_lineups ml
+     JOIN match_lineup_players mlp ON mlp.match_lineup_id = ml.id
+     WHERE ml.match_id = m.id)::int AS lineup_players,
+    (SELECT MAX(ml.created_at)
+     FROM match_lineups ml
+     WHERE ml.match_id = m.id) AS lineups_updated_at,
+    (SELECT COUNT(*)
+     FROM matches r
+     WHERE r.status = 'FINISHED'
+       AND r.home_score IS NOT NULL
+       AND (r.home_team_id IN (m.home_team_id, m.away_team_id)
+            OR r.away_team_id IN (m.home_team_id, m.away_team_id)))::int AS team_results,
+    (SELECT MAX(r.updated_at)
+     FROM matches r
+     WHERE r.status = 'FINISHED'
+       AND (r.home_team_id IN (m.home_team_id, m.away_team_id)
+            OR r.away_team_id IN (m.home_team_id, m.away_team_id))) AS results_updated_at,
+    CURRENT_TIMESTAMP::timestamp AS refreshed_at
+FROM matches m
+JOIN teams ht ON ht.id = m.home_team_id
+JOIN teams at ON at.id = m.away_team_id
+-- Meetings are counted from the perspective of this fixture's home and away
+-- teams, whoever was at home at the time
+CROSS JOIN LATERAL (
+    SELECT COUNT(*)::int AS matches,
+           COUNT(*) FILTER (WHERE (p.winner = 'HOME_TEAM' AND p.home_team_id = m.home_team_id)
+                               OR (p.winner = 'AWAY_TEAM' AND p.away_team_id = m.home_team_id))::int AS home_wins,
+           COUNT(*) FILTER (WHERE (p.winner = 'HOME_TEAM' AND p.home_team_id = m.away_team_id)
+                               OR (p.winner = 'AWAY_TEAM' AND p.away_team_id = m.away_team_id))::int AS away_wins,
+           COUNT(*) FILTER (WHERE p.winner = 'DRAW')::int AS draws
+    FROM (
+        SELECT prev.home_team_id, prev.away_team_id, prev.winner
+        FROM matches prev
+        WHERE ((prev.home_team_id = m.home_team_id AND prev.away_team_id = m.away_team_id)
+            OR (prev.home_team_id = m.away_team_id AND prev.away_team_id = m.home_team_id))
+          AND prev.home_score IS NOT NULL
+          AND prev.away_score IS NOT NULL
+        ORDER BY prev.utc_date DESC
+        LIMIT 10
+    ) p
+) h2h
+-- Key players ranked like ListKeyPlayersForMatch, six across both teams
+LEFT JOIN LATERAL (
+    SELECT jsonb_agg(k.player ORDER BY k.rank) FILTER (WHERE k.team_id = m.home_team_id) AS home,
+           jsonb_agg(k.player ORDER BY k.rank) FILTER (WHERE k.team_id = m.away_team_id) AS away
+    FROM (
+        SELECT p.team_id,
+               ROW_NUMBER() OVER (ORDER BY sc.score DESC, s.rating DESC NULLS LAST,
+                                  s.minutes_played ASC NULLS LAST) AS rank,
+               jsonb_strip_nulls(jsonb_build_object(
+                   'name', p.name,
+                   'position', COALESCE(p.position, ''),
+                   'role', r.role,
+                   'teamExternalId', t.external_id,
+                   'goals', COALESCE(s.goals, 0),
+                   'assists', COALESCE(s.assists, 0),
+                   'penalties', s.penalties,
+                   'ownGoals', s.own_goals,
+                   'minutesPlayed', s.minutes_played,
+                   'rating', s.rating,
+                   'saves', s.saves,
+                   'cleanSheet', s.clean_sheet,
+                   'tackles', s.tackles,
+                   'interceptions', s.interceptions,
+                   'score', ROUND(sc.score, 1)
+               )) AS player
+        FROM player_match_stats s
+        JOIN players p ON p.id = s.player_id
+        JOIN teams t ON t.id = p.team_id
+        CROSS JOIN LATERAL (SELECT player_role(s.position, p.position) AS role) r
+        CROSS JOIN LATERAL (
+            SELECT key_player_score(r.role, s.goals, s.assists, s.own_goals, s.clean_sheet, s.saves,
+                                    s.penalties_saved, s.tackles, s.interceptions, s.blocks) AS score
+        ) sc
+        WHERE s.match_id = m.id
+        ORDER BY rank
+        LIMIT 6
+    ) k
+) kp ON TRUE
+WHERE m.status IN ('SCHEDULED', 'TIMED')
+  AND m.utc_date < (NOW() AT TIME ZONE 'UTC') + INTERVAL '14 days';
+
+-- REFRESH ... CONCURRENTLY needs a unique index
+CREATE UNIQUE INDEX IF NOT EXISTS idx_match_prediction_inputs_match ON match_prediction_inputs(match_id);
+CREATE INDEX IF NOT EXISTS idx_match_prediction_inputs_external ON match_prediction_inputs(external_id);
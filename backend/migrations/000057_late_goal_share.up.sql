@@ -0,0 +1,3 @@
+-- Share of a team's recent goals scored after minute 75, from recorded
+-- match events.
+ALTER TABLE feature_snapshots ADD COLUMN IF NOT EXISTS late_goal_share DECIMAL(4,3);
@@ -0,0 +1,77 @@
+// Package migrations embeds the SQL schema files so binaries can apply them
+// without depending on the working directory.
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// FS holds every file in this directory, including the legacy
+// 003_enhanced_features.sql which golang-migrate does not pick up.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// Source returns a golang-migrate source driver reading the embedded files.
+func Source() (source.Driver, error) {
+	src, err := iofs.New(FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	return src, nil
+}
+
+// New returns a migrate instance for the database at databaseURL, a
+// postgres:// URL. It opens its own connection; callers must Close it when
+// done.
+func New(databaseURL string) (*migrate.Migrate, error) {
+	src, err := Source()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, pgxURL(databaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	return m, nil
+}
+
+// Up applies all pending migrations to the database at databaseURL and
+// returns the resulting schema version.
+func Up(databaseURL string) (uint, error) {
+	m, err := New(databaseURL)
+	if err != nil {
+		return 0, err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return 0, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	version, _, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return 0, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, nil
+}
+
+// pgxURL points a postgres:// URL at golang-migrate's pgx driver, which is
+// registered under its own scheme.
+func pgxURL(databaseURL string) string {
+	for _, scheme := range []string{"postgres://", "postgresql://"} {
+		if strings.HasPrefix(databaseURL, scheme) {
+			return "pgx5://" + strings.TrimPrefix(databaseURL, scheme)
+		}
+	}
+	return databaseURL
+}
@@ -1,126 +1,217 @@
 package apifootball
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/yourusername/football-prediction/pkg/breaker"
+	"github.com/yourusername/football-prediction/pkg/keypool"
+	"github.com/yourusername/football-prediction/pkg/quota"
 )
 
+// Provider names API-Football in request budgets.
+const Provider = "api-football"
+
 type Client struct {
 	baseURL    string
-	apiKey     string
+	keys       *keypool.Pool
 	httpClient *http.Client
+	quota      *quota.Manager
+
+	mu      sync.Mutex
+	limits  Limits
+	budgets map[string]*budget // by key, as each key has its own quota
 }
 
-func NewClient(apiKey string) *Client {
+// NewClient returns a client rotating requests over apiKeys, each keeping
+// to DefaultLimits. Use SetLimits for paid plans.
+func NewClient(apiKeys ...string) *Client {
+	budgets := make(map[string]*budget, len(apiKeys))
+	for _, key := range apiKeys {
+		budgets[key] = newBudget(DefaultLimits)
+	}
 	return &Client{
 		baseURL: "https://v3.football.api-sports.io",
-		apiKey:  apiKey,
+		keys:    keypool.New(apiKeys...),
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		limits:  DefaultLimits,
+		budgets: budgets,
 	}
 }
 
+// SetTransport replaces the transport requests are sent with, e.g. to trace
+// them.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetQuota also counts requests against each key's budget in m, shared
+// with other processes, before they are sent.
+func (c *Client) SetQuota(m *quota.Manager) {
+	c.quota = m
+}
+
+// KeyStats returns the request counters of each API key.
+func (c *Client) KeyStats() []keypool.Stats {
+	return c.keys.Stats()
+}
+
+// doRequest performs a GET within the request budget. A request refused
+// for its key is retried at once with the next key; 429s with every key
+// cooling down, 5xx responses and network errors are retried with backoff.
 func (c *Client) doRequest(endpoint string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		body, retryAfter, rotate, err := c.doRequestOnce(endpoint)
+		if err != nil && rotate {
+			continue
+		}
+		if err == nil || !retryable(err) {
+			return body, err
+		}
+
+		c.mu.Lock()
+		limits := c.limits
+		c.mu.Unlock()
+		if attempt >= limits.MaxRetries {
+			return nil, err
+		}
+		wait, ok := backoff(attempt, retryAfter, limits.MaxBackoff)
+		if !ok {
+			return nil, err
+		}
+		time.Sleep(wait)
+	}
+}
+
+// nextKey returns the next key not cooling down that is within its
+// budgets. Keys whose daily quota is used up rest until it resets.
+func (c *Client) nextKey() (string, *budget, error) {
+	if c.keys.Len() == 0 {
+		return "", nil, errors.New("no API key configured")
+	}
+
+	for i := 0; i < c.keys.Len(); i++ {
+		key, wait := c.keys.Next()
+		if key == "" {
+			return "", nil, &APIError{StatusCode: http.StatusTooManyRequests, Body: "every API key is cooling down", RetryAfter: wait}
+		}
+
+		b := c.budgets[key]
+		err := b.reserve()
+		if err == nil && c.quota != nil {
+			err = c.quota.Reserve(context.Background(), Provider, key)
+		}
+		switch {
+		case err == nil:
+			return key, b, nil
+		case errors.Is(err, ErrQuotaExhausted), errors.Is(err, quota.ErrExhausted):
+			c.keys.CoolDown(key, b.resetsAt())
+		default:
+			return "", nil, err
+		}
+	}
+	return "", nil, ErrQuotaExhausted
+}
+
+// doRequestOnce sends the request with the next key. It reports whether
+// the key was refused and is now resting, so the request is worth retrying
+// with another.
+func (c *Client) doRequestOnce(endpoint string) ([]byte, time.Duration, bool, error) {
+	key, b, err := c.nextKey()
+	if err != nil {
+		return nil, 0, false, err
+	}
+
 	req, err := http.NewRequest("GET", c.baseURL+endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("x-apisports-key", c.apiKey)
+	req.Header.Set("x-apisports-key", key)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		c.keys.Report(key, 0, 0)
+		return nil, 0, false, &networkError{err: err}
 	}
 	defer resp.Body.Close()
 
+	b.observe(resp.Header)
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-	}
-
-	return body, nil
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		rotate := c.keys.Report(key, resp.StatusCode, retryAfter)
+		return nil, retryAfter, rotate, &APIError{StatusCode: resp.StatusCode, Body: string(body), RetryAfter: retryAfter}
+	}
+
+	// API-Football reports rate limits in the body of a 200 response.
+	var envelope struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		var errs map[string]string
+		if json.Unmarshal(envelope.Errors, &errs) == nil {
+			if msg, ok := errs["requests"]; ok {
+				b.exhaust()
+				c.keys.CoolDown(key, b.resetsAt())
+				return nil, 0, true, fmt.Errorf("%w: %s", ErrQuotaExhausted, msg)
+			}
+			if msg, ok := errs["rateLimit"]; ok {
+				rotate := c.keys.Report(key, http.StatusTooManyRequests, 0)
+				return nil, 0, rotate, &APIError{StatusCode: http.StatusTooManyRequests, Body: msg}
+			}
+		}
+	}
+
+	c.keys.Report(key, http.StatusOK, 0)
+	return body, 0, false, nil
 }
 
-// GetFixtureLineups fetches lineups for a specific fixture
-func (c *Client) GetFixtureLineups(fixtureID int) ([]FixtureLineupsResponse, error) {
-	endpoint := fmt.Sprintf("/fixtures/lineups?fixture=%d", fixtureID)
-
-	body, err := c.doRequest(endpoint)
-	if err != nil {
-		return nil, err
-	}
+// networkError marks a transport failure, which is worth retrying.
+type networkError struct {
+	err error
+}
 
-	var response struct {
-		Response []FixtureLineupsResponse `json:"response"`
-		Errors   []string                 `json:"errors"`
-	}
+func (e *networkError) Error() string { return "request failed: " + e.err.Error() }
+func (e *networkError) Unwrap() error { return e.err }
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+func retryable(err error) bool {
+	if errors.Is(err, breaker.ErrOpen) {
+		return false
 	}
-
-	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API errors: %v", response.Errors)
+	var netErr *networkError
+	if errors.As(err, &netErr) {
+		return true
 	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500)
+}
 
-	return response.Response, nil
+// GetFixtureLineups fetches lineups for a specific fixture
+func (c *Client) GetFixtureLineups(fixtureID int) ([]FixtureLineupsResponse, error) {
+	return getAll[FixtureLineupsResponse](c, fmt.Sprintf("/fixtures/lineups?fixture=%d", fixtureID))
 }
 
 // GetFixtureEvents fetches events (goals, assists, etc.) for a fixture
 func (c *Client) GetFixtureEvents(fixtureID int) ([]FixtureEvent, error) {
-	endpoint := fmt.Sprintf("/fixtures/events?fixture=%d", fixtureID)
-
-	body, err := c.doRequest(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	var response struct {
-		Response []FixtureEvent `json:"response"`
-		Errors   []string       `json:"errors"`
-	}
-
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API errors: %v", response.Errors)
-	}
-
-	return response.Response, nil
+	return getAll[FixtureEvent](c, fmt.Sprintf("/fixtures/events?fixture=%d", fixtureID))
 }
 
 // GetPlayerStats fetches player statistics for a season
 func (c *Client) GetPlayerStats(playerID, season int) ([]PlayerStatsResponse, error) {
-	endpoint := fmt.Sprintf("/players?id=%d&season=%d", playerID, season)
-
-	body, err := c.doRequest(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	var response struct {
-		Response []PlayerStatsResponse `json:"response"`
-		Errors   []string              `json:"errors"`
-	}
-
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("API errors: %v", response.Errors)
-	}
-
-	return response.Response, nil
+	return getAll[PlayerStatsResponse](c, fmt.Sprintf("/players?id=%d&season=%d", playerID, season))
 }
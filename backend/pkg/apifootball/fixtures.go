@@ -0,0 +1,55 @@
+package apifootball
+
+import (
+	"fmt"
+	"time"
+)
+
+// FixtureSummary is the part of an API-Football fixture needed to match it
+// against a football-data.org match.
+type FixtureSummary struct {
+	ID       int       `json:"id"`
+	Date     time.Time `json:"date"`
+	HomeTeam TeamRef   `json:"homeTeam"`
+	AwayTeam TeamRef   `json:"awayTeam"`
+}
+
+type TeamRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetFixturesByDate lists all fixtures played on the given UTC date. The two
+// providers use different IDs, so callers match fixtures by date and teams.
+func (c *Client) GetFixturesByDate(date time.Time) ([]FixtureSummary, error) {
+	// Format date as YYYY-MM-DD for API-Football
+	endpoint := fmt.Sprintf("/fixtures?date=%s&timezone=UTC", date.UTC().Format("2006-01-02"))
+
+	type fixture struct {
+		Fixture struct {
+			ID   int       `json:"id"`
+			Date time.Time `json:"date"`
+		} `json:"fixture"`
+		Teams struct {
+			Home TeamRef `json:"home"`
+			Away TeamRef `json:"away"`
+		} `json:"teams"`
+	}
+
+	response, err := getAll[fixture](c, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fixtures: %w", err)
+	}
+
+	fixtures := make([]FixtureSummary, 0, len(response))
+	for _, f := range response {
+		fixtures = append(fixtures, FixtureSummary{
+			ID:       f.Fixture.ID,
+			Date:     f.Fixture.Date,
+			HomeTeam: f.Teams.Home,
+			AwayTeam: f.Teams.Away,
+		})
+	}
+
+	return fixtures, nil
+}
@@ -0,0 +1,101 @@
+package apifootball
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Number is a statistic API-Football sends as a number, as a string such
+// as "7.3" or "55%", or as null when it was not recorded. Values that are
+// not numbers at all decode as missing rather than failing the response.
+type Number struct {
+	value *float64
+}
+
+func (n *Number) UnmarshalJSON(data []byte) error {
+	n.value = nil
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case float64:
+		n.value = &v
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(v), "%"), 64); err == nil {
+			n.value = &f
+		}
+	}
+	return nil
+}
+
+func (n Number) MarshalJSON() ([]byte, error) {
+	if n.value == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*n.value)
+}
+
+// Float returns the value, or nil if it is missing.
+func (n Number) Float() *float64 {
+	return n.value
+}
+
+// Errors is the errors field of a response: an empty array when there are
+// none and an object keyed by parameter when there are, or occasionally an
+// array of messages.
+type Errors map[string]string
+
+func (e *Errors) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	*e = nil
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		var messages []string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("want an object or an array of messages, got %s", data)
+		}
+		for i, msg := range messages {
+			if *e == nil {
+				*e = Errors{}
+			}
+			(*e)[strconv.Itoa(i)] = msg
+		}
+		return nil
+	default:
+		var byParam map[string]interface{}
+		if err := json.Unmarshal(data, &byParam); err != nil {
+			return fmt.Errorf("want an object or an array of messages, got %s", data)
+		}
+		for param, msg := range byParam {
+			if *e == nil {
+				*e = Errors{}
+			}
+			(*e)[param] = fmt.Sprint(msg)
+		}
+		return nil
+	}
+}
+
+// Err returns the errors as one error, or nil if there are none.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	params := make([]string, 0, len(e))
+	for param := range e {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	messages := make([]string, len(params))
+	for i, param := range params {
+		messages[i] = param + ": " + e[param]
+	}
+	return fmt.Errorf("API errors: %s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,252 @@
+package apifootball
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+// Limits is the request budget the client keeps to. The defaults match the
+// API-Football free plan.
+type Limits struct {
+	// RequestsPerMinute caps requests in any rolling minute. Zero disables
+	// the cap.
+	RequestsPerMinute int
+	// DailyQuota is the number of requests allowed per UTC day. The
+	// provider's own count, from response headers, takes precedence once a
+	// request has been made. Zero means unlimited.
+	DailyQuota int
+	// MaxRetries is how many times a request is retried after a 429, a 5xx
+	// or a network error.
+	MaxRetries int
+	// MaxBackoff caps the wait before a retry. A Retry-After longer than this
+	// is not waited out; the error is returned instead.
+	MaxBackoff time.Duration
+}
+
+// DefaultLimits is the free plan: 10 requests a minute, 100 a day.
+var DefaultLimits = Limits{
+	RequestsPerMinute: 10,
+	DailyQuota:        100,
+	MaxRetries:        3,
+	MaxBackoff:        time.Minute,
+}
+
+// Quota is the client's view of the daily request quota.
+type Quota struct {
+	Limit     int       `json:"limit"` // 0 when unlimited
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resetsAt"`
+}
+
+// APIError is returned for non-200 responses and for rate-limit errors the
+// API reports in a 200 body.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is how long the API asked us to wait, from the Retry-After
+	// header when present.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// ErrQuotaExhausted is returned without calling the API once the daily quota
+// is used up. Requests are allowed again after Quota().ResetsAt.
+var ErrQuotaExhausted = errors.New("api-football daily quota exhausted")
+
+// IsRateLimited reports whether err is a 429 from the API or the daily
+// quota, the client's or the shared budget's, running out.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return errors.Is(err, ErrQuotaExhausted) || errors.Is(err, quota.ErrExhausted)
+}
+
+// budget tracks the per-minute window and the daily quota.
+type budget struct {
+	mu       sync.Mutex
+	limits   Limits
+	recent   []time.Time // request times within the last minute
+	day      time.Time   // UTC midnight the counters belong to
+	used     int
+	reported int // daily limit from response headers, 0 if not seen
+	left     int // remaining from response headers, -1 if not seen
+}
+
+func newBudget(limits Limits) *budget {
+	return &budget{limits: limits, left: -1}
+}
+
+// SetLimits replaces the request budget of each key. Usage already counted
+// today is kept.
+func (c *Client) SetLimits(limits Limits) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limits = limits
+	for _, b := range c.budgets {
+		b.mu.Lock()
+		b.limits = limits
+		b.mu.Unlock()
+	}
+}
+
+// Quota returns today's daily quota usage, summed over the keys, so callers
+// such as the scheduler can plan how many requests they can still afford.
+func (c *Client) Quota() Quota {
+	total := Quota{ResetsAt: time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)}
+	unlimited := false
+	for _, b := range c.budgets {
+		q := b.quota()
+		total.Used += q.Used
+		total.ResetsAt = q.ResetsAt
+		if q.Limit == 0 || q.Remaining < 0 {
+			unlimited = true
+			continue
+		}
+		total.Limit += q.Limit
+		total.Remaining += q.Remaining
+	}
+	if unlimited {
+		total.Limit, total.Remaining = 0, -1
+	}
+	return total
+}
+
+func (b *budget) quota() Quota {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover(time.Now())
+	q := Quota{Limit: b.dailyLimit(), Used: b.used, ResetsAt: b.day.Add(24 * time.Hour)}
+	switch {
+	case b.left >= 0:
+		q.Remaining = b.left
+	case q.Limit > 0:
+		q.Remaining = max(q.Limit-b.used, 0)
+	default:
+		q.Remaining = -1
+	}
+	return q
+}
+
+// resetsAt returns when the daily quota is next reset.
+func (b *budget) resetsAt() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover(time.Now())
+	return b.day.Add(24 * time.Hour)
+}
+
+// reserve waits for a slot in the per-minute window and counts the request
+// against the daily quota. It returns ErrQuotaExhausted instead of waiting
+// for the next day.
+func (b *budget) reserve() error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.rollover(now)
+
+		if limit := b.dailyLimit(); b.left == 0 || (limit > 0 && b.left < 0 && b.used >= limit) {
+			b.mu.Unlock()
+			return ErrQuotaExhausted
+		}
+
+		cutoff := now.Add(-time.Minute)
+		for len(b.recent) > 0 && !b.recent[0].After(cutoff) {
+			b.recent = b.recent[1:]
+		}
+
+		if b.limits.RequestsPerMinute <= 0 || len(b.recent) < b.limits.RequestsPerMinute {
+			b.recent = append(b.recent, now)
+			b.used++
+			if b.left > 0 {
+				b.left--
+			}
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := b.recent[0].Add(time.Minute).Sub(now)
+		b.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+// observe records the provider's quota headers from a response.
+func (b *budget) observe(h http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if limit, err := strconv.Atoi(h.Get("x-ratelimit-requests-limit")); err == nil {
+		b.reported = limit
+	}
+	if left, err := strconv.Atoi(h.Get("x-ratelimit-requests-remaining")); err == nil {
+		b.left = left
+	}
+}
+
+// exhaust marks the daily quota as used up after the API said so.
+func (b *budget) exhaust() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.left = 0
+}
+
+// rollover resets the daily counters at UTC midnight. Callers hold b.mu.
+func (b *budget) rollover(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if day.Equal(b.day) {
+		return
+	}
+	b.day = day
+	b.used = 0
+	b.left = -1
+}
+
+func (b *budget) dailyLimit() int {
+	if b.reported > 0 {
+		return b.reported
+	}
+	return b.limits.DailyQuota
+}
+
+// backoff returns how long to wait before retry attempt n (0-based):
+// Retry-After when the API sent one, otherwise 1s, 2s, 4s, ... It reports
+// false when the wait would exceed limit.
+func backoff(attempt int, retryAfter, limit time.Duration) (time.Duration, bool) {
+	wait := retryAfter
+	if wait <= 0 {
+		wait = time.Second << attempt
+	}
+	if limit > 0 && wait > limit {
+		return 0, false
+	}
+	return wait, true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
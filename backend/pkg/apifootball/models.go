@@ -4,7 +4,7 @@ package apifootball
 type Response struct {
 	Get        string      `json:"get"`
 	Parameters interface{} `json:"parameters"`
-	Errors     []string    `json:"errors"`
+	Errors     Errors      `json:"errors"`
 	Results    int         `json:"results"`
 	Paging     Paging      `json:"paging"`
 	Response   interface{} `json:"response"`
@@ -110,20 +110,23 @@ type Games struct {
 	Minutes     int    `json:"minutes"`
 	Number      int    `json:"number"`
 	Position    string `json:"position"`
-	Rating      string `json:"rating"`
+	Rating      Number `json:"rating"`
 	Captain     bool   `json:"captain"`
 }
 
+// Goals are nil when the provider did not record them, rather than zero.
 type Goals struct {
-	Total   int `json:"total"`
-	Assists int `json:"assists"`
-	Saves   int `json:"saves"`
+	Total   *int `json:"total"`
+	Assists *int `json:"assists"`
+	Saves   *int `json:"saves"`
 }
 
+// Passes.Accuracy is sent as a number here but as a string on
+// /fixtures/players.
 type Passes struct {
 	Total    int    `json:"total"`
 	Key      int    `json:"key"`
-	Accuracy string `json:"accuracy"`
+	Accuracy Number `json:"accuracy"`
 }
 
 type Shots struct {
@@ -137,7 +140,16 @@ type Tackles struct {
 	Interceptions int `json:"interceptions"`
 }
 
-// Fixture events response (for goals/assists)
+// Fixture event types.
+const (
+	EventGoal  = "Goal"
+	EventCard  = "Card"
+	EventSubst = "subst"
+	EventVar   = "Var"
+)
+
+// Fixture events response (for goals/assists). For a substitution, Player
+// is the player going off and Assist the player coming on.
 type FixtureEvent struct {
 	Time   TimeInfo   `json:"time"`
 	Team   TeamInfo   `json:"team"`
@@ -152,6 +164,12 @@ type TimeInfo struct {
 	Extra   int `json:"extra"`
 }
 
+// IsSendingOff reports whether the event is a red card, straight or second
+// yellow.
+func (e *FixtureEvent) IsSendingOff() bool {
+	return e.Type == EventCard && (e.Detail == "Red Card" || e.Detail == "Second Yellow card")
+}
+
 type AssistInfo struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -0,0 +1,67 @@
+package apifootball
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// page is one page of a list endpoint.
+type page[T any] struct {
+	Errors   Errors `json:"errors"`
+	Paging   Paging `json:"paging"`
+	Response []T    `json:"response"`
+}
+
+// getAll fetches every page of a list endpoint and returns the combined
+// results. The first page says how many there are; if today's quota cannot
+// cover the rest, it fails rather than returning a truncated list.
+func getAll[T any](c *Client, endpoint string) ([]T, error) {
+	first, err := getPage[T](c, endpoint, 1)
+	if err != nil {
+		return nil, err
+	}
+	if first.Paging.Total <= 1 {
+		return first.Response, nil
+	}
+
+	more := first.Paging.Total - 1
+	if remaining := c.Quota().Remaining; remaining >= 0 && remaining < more {
+		return nil, fmt.Errorf("%w: %s needs %d more pages, %d requests left today",
+			ErrQuotaExhausted, endpoint, more, remaining)
+	}
+
+	results := first.Response
+	for n := 2; n <= first.Paging.Total; n++ {
+		p, err := getPage[T](c, endpoint, n)
+		if err != nil {
+			return nil, fmt.Errorf("page %d/%d: %w", n, first.Paging.Total, err)
+		}
+		results = append(results, p.Response...)
+	}
+	return results, nil
+}
+
+func getPage[T any](c *Client, endpoint string, n int) (*page[T], error) {
+	if n > 1 {
+		sep := "?"
+		if strings.Contains(endpoint, "?") {
+			sep = "&"
+		}
+		endpoint = fmt.Sprintf("%s%spage=%d", endpoint, sep, n)
+	}
+
+	body, err := c.doRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var p page[T]
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if err := p.Errors.Err(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,103 @@
+package apifootball
+
+import "fmt"
+
+// Positions reported in a fixture player's games, the line they played in.
+const (
+	PositionGoalkeeper = "G"
+	PositionDefender   = "D"
+	PositionMidfielder = "M"
+	PositionForward    = "F"
+)
+
+// FixtureTeamPlayers is one team's per-player statistics for a fixture.
+type FixtureTeamPlayers struct {
+	Team    TeamInfo        `json:"team"`
+	Players []FixturePlayer `json:"players"`
+}
+
+// FixturePlayer is a player's statistics for a fixture. Statistics holds a
+// single entry.
+type FixturePlayer struct {
+	Player struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Photo string `json:"photo"`
+	} `json:"player"`
+	Statistics []FixturePlayerStats `json:"statistics"`
+}
+
+// FixturePlayerStats is what /fixtures/players reports for a player. Counts
+// are nil when the provider did not record them, which is the case for
+// unused substitutes.
+type FixturePlayerStats struct {
+	Games struct {
+		Minutes    *int   `json:"minutes"`
+		Number     *int   `json:"number"`
+		Position   string `json:"position"`
+		Captain    bool   `json:"captain"`
+		Substitute bool   `json:"substitute"`
+		Rating     Number `json:"rating"`
+	} `json:"games"`
+	Shots struct {
+		Total *int `json:"total"`
+		On    *int `json:"on"`
+	} `json:"shots"`
+	Goals struct {
+		Total    *int `json:"total"`
+		Conceded *int `json:"conceded"`
+		Assists  *int `json:"assists"`
+		Saves    *int `json:"saves"`
+	} `json:"goals"`
+	Passes struct {
+		Total *int `json:"total"`
+		Key   *int `json:"key"`
+		// Accuracy is the number of accurate passes.
+		Accuracy Number `json:"accuracy"`
+	} `json:"passes"`
+	Tackles struct {
+		Total         *int `json:"total"`
+		Blocks        *int `json:"blocks"`
+		Interceptions *int `json:"interceptions"`
+	} `json:"tackles"`
+	Duels struct {
+		Total *int `json:"total"`
+		Won   *int `json:"won"`
+	} `json:"duels"`
+	Penalty struct {
+		Won       *int `json:"won"`
+		Committed *int `json:"commited"`
+		Scored    *int `json:"scored"`
+		Missed    *int `json:"missed"`
+		Saved     *int `json:"saved"`
+	} `json:"penalty"`
+}
+
+// Stats returns the player's statistics, or nil if none were sent.
+func (p *FixturePlayer) Stats() *FixturePlayerStats {
+	if len(p.Statistics) == 0 {
+		return nil
+	}
+	return &p.Statistics[0]
+}
+
+// Rating returns the match rating, or nil if the player was not rated.
+func (s *FixturePlayerStats) Rating() *float64 {
+	return s.Games.Rating.Float()
+}
+
+// PassesAccurate returns the number of accurate passes, or nil if unknown.
+func (s *FixturePlayerStats) PassesAccurate() *int {
+	f := s.Passes.Accuracy.Float()
+	if f == nil {
+		return nil
+	}
+	n := int(*f + 0.5)
+	return &n
+}
+
+// GetFixturePlayers fetches per-player statistics and ratings for both
+// teams of a fixture.
+func (c *Client) GetFixturePlayers(fixtureID int) ([]FixtureTeamPlayers, error) {
+	return getAll[FixtureTeamPlayers](c, fmt.Sprintf("/fixtures/players?fixture=%d", fixtureID))
+}
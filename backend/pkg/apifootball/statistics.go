@@ -0,0 +1,63 @@
+package apifootball
+
+import "fmt"
+
+// Statistic types reported by /fixtures/statistics.
+const (
+	StatShotsOnGoal     = "Shots on Goal"
+	StatShotsOffGoal    = "Shots off Goal"
+	StatTotalShots      = "Total Shots"
+	StatBlockedShots    = "Blocked Shots"
+	StatShotsInsideBox  = "Shots insidebox"
+	StatShotsOutsideBox = "Shots outsidebox"
+	StatFouls           = "Fouls"
+	StatCornerKicks     = "Corner Kicks"
+	StatOffsides        = "Offsides"
+	StatBallPossession  = "Ball Possession"
+	StatYellowCards     = "Yellow Cards"
+	StatRedCards        = "Red Cards"
+	StatGoalkeeperSaves = "Goalkeeper Saves"
+	StatTotalPasses     = "Total passes"
+	StatPassesAccurate  = "Passes accurate"
+	StatExpectedGoals   = "expected_goals"
+)
+
+// FixtureTeamStatistics is one team's statistics for a fixture.
+type FixtureTeamStatistics struct {
+	Team       TeamInfo           `json:"team"`
+	Statistics []FixtureStatistic `json:"statistics"`
+}
+
+// FixtureStatistic is a single named value.
+type FixtureStatistic struct {
+	Type  string `json:"type"`
+	Value Number `json:"value"`
+}
+
+// Int returns the named statistic as an integer, or nil if it is missing.
+// Percentages are returned without the percent sign.
+func (s *FixtureTeamStatistics) Int(name string) *int {
+	f := s.Float(name)
+	if f == nil {
+		return nil
+	}
+	n := int(*f + 0.5)
+	return &n
+}
+
+// Float returns the named statistic as a number, or nil if it is missing.
+func (s *FixtureTeamStatistics) Float(name string) *float64 {
+	for _, stat := range s.Statistics {
+		if stat.Type != name {
+			continue
+		}
+		return stat.Value.Float()
+	}
+	return nil
+}
+
+// GetFixtureStatistics fetches both teams' statistics (possession, shots,
+// cards, ...) for a fixture.
+func (c *Client) GetFixtureStatistics(fixtureID int) ([]FixtureTeamStatistics, error) {
+	return getAll[FixtureTeamStatistics](c, fmt.Sprintf("/fixtures/statistics?fixture=%d", fixtureID))
+}
@@ -0,0 +1,179 @@
+// Package blob stores generated files, such as exports, cached crests and
+// spoken previews, on local disk or in an S3-compatible bucket. Objects are
+// streamed in and out, can be shared through expiring signed URLs and are
+// deleted by age by Cleanup.
+package blob
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Backends.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound is returned for keys with no object.
+var ErrNotFound = errors.New("blob not found")
+
+// Object describes a stored object. ContentType is empty when none was given
+// on upload.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Store is a bucket of objects addressed by slash-separated keys such as
+// "exports/matches.csv".
+type Store interface {
+	// Put streams r into the object at key, replacing any object there.
+	// Readers never see a partly written object.
+	Put(key string, r io.Reader, contentType string) error
+	// Get opens the object at key. The caller closes the reader.
+	Get(key string) (io.ReadCloser, *Object, error)
+	Stat(key string) (*Object, error)
+	// Delete removes the object at key; a missing object is not an error.
+	Delete(key string) error
+	// List returns the objects whose keys start with prefix.
+	List(prefix string) ([]Object, error)
+	// SignedURL returns a URL that downloads the object without other
+	// credentials until expiry.
+	SignedURL(key string, expiry time.Duration) (string, error)
+}
+
+// Config selects and configures a backend. Local stores objects under Dir
+// and signs URLs to PublicURL with SigningKey; S3 uses the rest.
+type Config struct {
+	Backend    string
+	Dir        string
+	PublicURL  string
+	SigningKey string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	// S3PathStyle addresses the bucket in the path rather than the host
+	// name, as most S3-compatible servers expect.
+	S3PathStyle bool
+}
+
+// New returns the store cfg selects, local when Backend is empty.
+func New(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case "", BackendLocal:
+		return NewLocal(cfg.Dir, cfg.PublicURL, cfg.SigningKey), nil
+	case BackendS3:
+		return NewS3(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+	}
+	return nil, fmt.Errorf("unknown blob backend %q", cfg.Backend)
+}
+
+// PutBytes stores data at key.
+func PutBytes(s Store, key string, data []byte, contentType string) error {
+	return s.Put(key, bytes.NewReader(data), contentType)
+}
+
+// ReadAll returns the contents of the object at key.
+func ReadAll(s Store, key string) ([]byte, *Object, error) {
+	r, obj, err := s.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, obj, nil
+}
+
+// Cleanup deletes the objects under each prefix older than its maximum age,
+// and returns how many it deleted. Failures are skipped and reported
+// together.
+func Cleanup(s Store, maxAge map[string]time.Duration, now time.Time) (int, error) {
+	deleted := 0
+	var errs []error
+	for prefix, age := range maxAge {
+		if age <= 0 {
+			continue
+		}
+		objects, err := s.List(prefix)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+			continue
+		}
+		for _, o := range objects {
+			if now.Sub(o.ModTime) < age {
+				continue
+			}
+			if err := s.Delete(o.Key); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", o.Key, err))
+				continue
+			}
+			deleted++
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// DefaultLifecycle keeps export downloads for a week and spoken previews,
+// which are synthesized again on demand, for a month.
+var DefaultLifecycle = map[string]time.Duration{
+	"exports/": 7 * 24 * time.Hour,
+	"audio/":   30 * 24 * time.Hour,
+}
+
+// ParseLifecycle parses "prefix=duration" pairs separated by commas, e.g.
+// "exports/=168h,audio/=720h", into the maximum age of each prefix's
+// objects, on top of the rules in base. A zero duration keeps a prefix's
+// objects forever.
+func ParseLifecycle(spec string, base map[string]time.Duration) (map[string]time.Duration, error) {
+	rules := make(map[string]time.Duration, len(base))
+	for prefix, age := range base {
+		rules[prefix] = age
+	}
+	var invalid []string
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		prefix, value, _ := strings.Cut(pair, "=")
+		age, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || age < 0 || strings.TrimSpace(prefix) == "" {
+			invalid = append(invalid, pair)
+			continue
+		}
+		rules[strings.TrimSpace(prefix)] = age
+	}
+	if len(invalid) > 0 {
+		return rules, fmt.Errorf("invalid blob lifecycle rules %s", strings.Join(invalid, ", "))
+	}
+	return rules, nil
+}
+
+// validKey reports whether key is a relative slash-separated path without
+// empty segments or segments starting with a dot, which the local backend
+// keeps for its own files.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || strings.HasPrefix(seg, ".") {
+			return false
+		}
+	}
+	return true
+}
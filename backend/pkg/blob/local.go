@@ -0,0 +1,225 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tmpMarker names the partial files Put writes before renaming them into
+// place. Like type files they are hidden, so List skips them.
+const tmpMarker = ".tmp-"
+
+// Local stores objects as files under a directory, each with its content
+// type in a hidden ".<name>.type" file beside it. Signed URLs point at
+// PublicURL + "/" + key and are checked with Verify by whatever serves them.
+type Local struct {
+	dir        string
+	publicURL  string
+	signingKey []byte
+}
+
+func NewLocal(dir, publicURL, signingKey string) *Local {
+	return &Local{
+		dir:        dir,
+		publicURL:  strings.TrimSuffix(publicURL, "/"),
+		signingKey: []byte(signingKey),
+	}
+}
+
+func (l *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// typePath is where the content type of the object at path is kept.
+func typePath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".type")
+}
+
+func (l *Local) Put(key string, r io.Reader, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	if contentType == "" {
+		if err := os.Remove(typePath(path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to write blob: %w", err)
+		}
+	} else if err := writeAtomic(typePath(path), strings.NewReader(contentType)); err != nil {
+		return err
+	}
+	return writeAtomic(path, r)
+}
+
+// writeAtomic writes r to a temporary file and renames it into place so
+// readers never see a partial file.
+func writeAtomic(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+tmpMarker+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	return nil
+}
+
+func (l *Local) Get(key string) (io.ReadCloser, *Object, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, nil, ErrNotFound
+	}
+	return f, object(key, path, info), nil
+}
+
+func (l *Local) Stat(key string) (*Object, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat blob: %w", err)
+	}
+	return object(key, path, info), nil
+}
+
+func (l *Local) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{path, typePath(path)} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete blob: %w", err)
+		}
+	}
+	return nil
+}
+
+func (l *Local) List(prefix string) ([]Object, error) {
+	objects := []Object{}
+	err := filepath.WalkDir(l.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		objects = append(objects, *object(key, path, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %w", err)
+	}
+	return objects, nil
+}
+
+// SignedURL signs the key and expiry time with the signing key.
+func (l *Local) SignedURL(key string, expiry time.Duration) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	if len(l.signingKey) == 0 {
+		return "", fmt.Errorf("blob signing key is not configured")
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {l.sign(key, expires)},
+	}
+	return l.publicURL + "/" + escapePath(key) + "?" + query.Encode(), nil
+}
+
+// Verify checks the expiry time and signature of a signed URL for key.
+func (l *Local) Verify(key, expires, signature string) error {
+	if len(l.signingKey) == 0 {
+		return fmt.Errorf("blob signing key is not configured")
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expiry")
+	}
+	if !hmac.Equal([]byte(signature), []byte(l.sign(key, expires))) {
+		return fmt.Errorf("invalid signature")
+	}
+	if time.Now().Unix() > unix {
+		return fmt.Errorf("link has expired")
+	}
+	return nil
+}
+
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func object(key, path string, info fs.FileInfo) *Object {
+	obj := &Object{Key: key, Size: info.Size(), ModTime: info.ModTime()}
+	if data, err := os.ReadFile(typePath(path)); err == nil {
+		obj.ContentType = string(data)
+	}
+	return obj
+}
@@ -0,0 +1,302 @@
+// Package breaker stops calls to an upstream that keeps failing. After a
+// run of failures a breaker opens and calls fail at once with ErrOpen,
+// instead of each one holding a goroutine until it times out. Once the
+// cooldown has passed a few probe calls are let through: if they succeed
+// the breaker closes, otherwise it opens for another cooldown.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// States.
+const (
+	Closed   = "closed"
+	Open     = "open"
+	HalfOpen = "half-open"
+)
+
+const (
+	DefaultFailures = 5
+	DefaultCooldown = 30 * time.Second
+	DefaultProbes   = 1
+)
+
+// ErrOpen is returned for calls rejected while a breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Config configures breakers. Zero values take the defaults.
+type Config struct {
+	// Failures is how many failures in a row open the breaker.
+	Failures int
+	// Cooldown is how long the breaker stays open before probing.
+	Cooldown time.Duration
+	// Probes is how many calls are let through at once while half-open,
+	// and how many must succeed to close the breaker.
+	Probes int
+}
+
+func (c Config) withDefaults() Config {
+	if c.Failures <= 0 {
+		c.Failures = DefaultFailures
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = DefaultCooldown
+	}
+	if c.Probes <= 0 {
+		c.Probes = DefaultProbes
+	}
+	return c
+}
+
+// Status is a breaker's state and its counters since it was created.
+type Status struct {
+	Name  string    `json:"name"`
+	State string    `json:"state"`
+	Since time.Time `json:"since"`
+	// RetryAt is when an open breaker starts probing.
+	RetryAt        *time.Time `json:"retryAt,omitempty"`
+	FailuresInARow int        `json:"failuresInARow"`
+	Requests       int        `json:"requests"`
+	Failures       int        `json:"failures"`
+	Rejected       int        `json:"rejected"`
+	Opened         int        `json:"opened"`
+	LastFailure    string     `json:"lastFailure,omitempty"`
+	LastFailureAt  *time.Time `json:"lastFailureAt,omitempty"`
+}
+
+// Call is a call let through by Allow, to be handed back to Record or
+// Release. Its verdict only moves the breaker if the breaker has not
+// changed state since, so a slow call let through while closed cannot be
+// taken for the probe of a later half-open period.
+type Call struct {
+	generation uint64
+	probe      bool
+}
+
+// Breaker guards one upstream. It is safe for concurrent use.
+type Breaker struct {
+	name string
+	cfg  Config
+
+	mu         sync.Mutex
+	state      string
+	since      time.Time
+	generation uint64 // state changes so far
+	inARow     int
+	probes     int // probe calls in flight
+	succeeded  int // probe calls that succeeded
+	status     Status
+}
+
+func New(name string, cfg Config) *Breaker {
+	return &Breaker{
+		name:  name,
+		cfg:   cfg.withDefaults(),
+		state: Closed,
+		since: time.Now(),
+	}
+}
+
+// Name returns the upstream the breaker guards.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Allow reports whether a call may go ahead, returning ErrOpen if not. A
+// call that is allowed must be followed by Record or Release.
+func (b *Breaker) Allow() (Call, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if b.state == Open && now.Sub(b.since) >= b.cfg.Cooldown {
+		b.setState(HalfOpen, now)
+	}
+	switch {
+	case b.state == Open, b.state == HalfOpen && b.probes >= b.cfg.Probes:
+		b.status.Rejected++
+		return Call{}, fmt.Errorf("%s: %w", b.name, ErrOpen)
+	case b.state == HalfOpen:
+		b.probes++
+	}
+	b.status.Requests++
+	return Call{generation: b.generation, probe: b.state == HalfOpen}, nil
+}
+
+// Record reports how an allowed call went. A nil err is a success. A call
+// let through before the breaker last changed state is counted in the
+// status but leaves the state alone.
+func (b *Breaker) Record(c Call, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if c.generation != b.generation {
+		if err != nil {
+			b.status.Failures++
+			b.status.LastFailure = err.Error()
+			b.status.LastFailureAt = &now
+		}
+		return
+	}
+	probe := c.probe
+	if probe {
+		b.probes--
+	}
+
+	if err == nil {
+		b.inARow = 0
+		if probe {
+			b.succeeded++
+			if b.succeeded >= b.cfg.Probes {
+				b.setState(Closed, now)
+			}
+		}
+		return
+	}
+
+	b.inARow++
+	b.status.Failures++
+	b.status.LastFailure = err.Error()
+	b.status.LastFailureAt = &now
+	if probe || (b.state == Closed && b.inARow >= b.cfg.Failures) {
+		b.setState(Open, now)
+		b.status.Opened++
+	}
+}
+
+// Release ends an allowed call without a verdict, such as one abandoned by
+// its caller, freeing its probe slot.
+func (b *Breaker) Release(c Call) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c.probe && c.generation == b.generation {
+		b.probes--
+	}
+}
+
+func (b *Breaker) setState(state string, now time.Time) {
+	b.state = state
+	b.since = now
+	b.generation++
+	b.probes = 0
+	b.succeeded = 0
+	if state == Closed {
+		b.inARow = 0
+	}
+}
+
+// Status returns the breaker's state and counters.
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := b.status
+	s.Name = b.name
+	s.State = b.state
+	s.Since = b.since
+	s.FailuresInARow = b.inARow
+	if b.state == Open {
+		retryAt := b.since.Add(b.cfg.Cooldown)
+		s.RetryAt = &retryAt
+	}
+	return s
+}
+
+// Set holds the breakers of every upstream, created on first use with the
+// same config.
+type Set struct {
+	cfg Config
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+	names    []string
+}
+
+func NewSet(cfg Config) *Set {
+	return &Set{cfg: cfg, breakers: make(map[string]*Breaker)}
+}
+
+// Get returns the breaker of an upstream. A nil set returns nil, which
+// Transport treats as no breaker.
+func (s *Set) Get(name string) *Breaker {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.breakers[name]
+	if !ok {
+		b = New(name, s.cfg)
+		s.breakers[name] = b
+		s.names = append(s.names, name)
+	}
+	return b
+}
+
+// Statuses returns the status of every breaker, in the order they were
+// created.
+func (s *Set) Statuses() []Status {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	breakers := make([]*Breaker, 0, len(s.names))
+	for _, name := range s.names {
+		breakers = append(breakers, s.breakers[name])
+	}
+	s.mu.Unlock()
+
+	statuses := make([]Status, 0, len(breakers))
+	for _, b := range breakers {
+		statuses = append(statuses, b.Status())
+	}
+	return statuses
+}
+
+// Transport guards requests sent with next, http.DefaultTransport when nil,
+// with b. Network errors, timeouts and 5xx responses count as failures;
+// other responses, rate limits included, as successes. Requests cancelled
+// by their caller count as neither. A nil b returns next unchanged.
+func Transport(b *Breaker, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if b == nil {
+		return next
+	}
+	return &transport{breaker: b, next: next}
+}
+
+type transport struct {
+	breaker *Breaker
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		t.breaker.Release(call)
+	case err != nil:
+		t.breaker.Record(call, err)
+	case resp.StatusCode >= 500:
+		t.breaker.Record(call, fmt.Errorf("status %d", resp.StatusCode))
+	default:
+		t.breaker.Record(call, nil)
+	}
+	return resp, err
+}
@@ -0,0 +1,68 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSlowCallIsNotTakenForTheProbe(t *testing.T) {
+	b := New("upstream", Config{Failures: 1, Cooldown: time.Millisecond, Probes: 1})
+
+	slow, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow while closed: %v", err)
+	}
+	failed, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow while closed: %v", err)
+	}
+	b.Record(failed, errors.New("timeout"))
+	if got := b.Status().State; got != Open {
+		t.Fatalf("state after a failure = %s, want %s", got, Open)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow after cooldown: %v", err)
+	}
+
+	// The call let through while closed succeeds late: the probe is still
+	// in flight, so the breaker stays half-open with its slot taken
+	b.Record(slow, nil)
+	if got := b.Status().State; got != HalfOpen {
+		t.Fatalf("state after the slow call = %s, want %s", got, HalfOpen)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow with the probe in flight = %v, want ErrOpen", err)
+	}
+
+	b.Record(probe, nil)
+	if got := b.Status().State; got != Closed {
+		t.Fatalf("state after the probe = %s, want %s", got, Closed)
+	}
+}
+
+func TestStaleFailureDoesNotReopen(t *testing.T) {
+	b := New("upstream", Config{Failures: 1, Cooldown: time.Millisecond, Probes: 1})
+
+	slow, _ := b.Allow()
+	failed, _ := b.Allow()
+	b.Record(failed, errors.New("timeout"))
+	time.Sleep(2 * time.Millisecond)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow after cooldown: %v", err)
+	}
+	b.Record(probe, nil)
+
+	b.Record(slow, errors.New("timeout"))
+	s := b.Status()
+	if s.State != Closed {
+		t.Fatalf("state after a stale failure = %s, want %s", s.State, Closed)
+	}
+	if s.Failures != 2 {
+		t.Fatalf("failures = %d, want 2", s.Failures)
+	}
+}
@@ -1,15 +1,26 @@
 package cache
 
 import (
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 type item struct {
 	value      interface{}
+	created    int64
 	expiration int64
 }
 
+// Entry describes a cached key without its value.
+type Entry struct {
+	Key        string    `json:"key"`
+	CreatedAt  time.Time `json:"createdAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	AgeSeconds int64     `json:"ageSeconds"`
+}
+
 type Cache struct {
 	items map[string]item
 	mu    sync.RWMutex
@@ -30,10 +41,11 @@ func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	expiration := time.Now().Add(ttl).Unix()
+	now := time.Now()
 	c.items[key] = item{
 		value:      value,
-		expiration: expiration,
+		created:    now.Unix(),
+		expiration: now.Add(ttl).Unix(),
 	}
 }
 
@@ -61,6 +73,44 @@ func (c *Cache) Delete(key string) {
 	delete(c.items, key)
 }
 
+// DeletePrefix removes every key starting with prefix and returns how many
+// it removed.
+func (c *Cache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for key := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.items, key)
+			n++
+		}
+	}
+	return n
+}
+
+// Entries returns the keys that have not expired, sorted.
+func (c *Cache) Entries() []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := time.Now().Unix()
+	entries := make([]Entry, 0, len(c.items))
+	for key, item := range c.items {
+		if now > item.expiration {
+			continue
+		}
+		entries = append(entries, Entry{
+			Key:        key,
+			CreatedAt:  time.Unix(item.created, 0).UTC(),
+			ExpiresAt:  time.Unix(item.expiration, 0).UTC(),
+			AgeSeconds: now - item.created,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
 func (c *Cache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -0,0 +1,193 @@
+// Package contract checks provider responses against the models we decode
+// them into, so schema drift, such as a field turning from a number into a
+// string, is caught before it breaks an ingest.
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Mismatch is a field whose JSON type our model does not accept. Path
+// generalises array indices to [], so Count says how many values at Path
+// mismatched.
+type Mismatch struct {
+	Path  string `json:"path"`
+	Want  string `json:"want"`
+	Got   string `json:"got"`
+	Count int    `json:"count"`
+}
+
+func (m Mismatch) String() string {
+	s := fmt.Sprintf("%s: got %s, want %s", m.Path, m.Got, m.Want)
+	if m.Count > 1 {
+		s += fmt.Sprintf(" (%d values)", m.Count)
+	}
+	return s
+}
+
+// Check reports the fields of body whose JSON type the model, a pointer to
+// the struct body is decoded into, would reject or silently drop. Fields
+// the model does not have are ignored, and null is accepted anywhere, as
+// decoding leaves the zero value. Types with their own UnmarshalJSON are
+// checked by decoding the value with it.
+func Check(body []byte, model interface{}) ([]Mismatch, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	c := checker{found: map[string]*Mismatch{}}
+	c.walk("", raw, reflect.TypeOf(model))
+
+	mismatches := make([]Mismatch, 0, len(c.found))
+	for _, m := range c.found {
+		mismatches = append(mismatches, *m)
+	}
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].Path < mismatches[j].Path })
+	return mismatches, nil
+}
+
+type checker struct {
+	found map[string]*Mismatch // by path
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (c *checker) walk(path string, raw interface{}, t reflect.Type) {
+	if raw == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		data, _ := json.Marshal(raw)
+		if err := json.Unmarshal(data, reflect.New(t).Interface()); err != nil {
+			c.mismatch(path, t.String(), jsonType(raw)+" ("+err.Error()+")")
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+	case reflect.String:
+		if _, ok := raw.(string); !ok {
+			c.mismatch(path, "string", jsonType(raw))
+		}
+	case reflect.Bool:
+		if _, ok := raw.(bool); !ok {
+			c.mismatch(path, "boolean", jsonType(raw))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := raw.(json.Number)
+		if !ok {
+			c.mismatch(path, "integer", jsonType(raw))
+		} else if _, err := n.Int64(); err != nil {
+			c.mismatch(path, "integer", "number "+n.String())
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := raw.(json.Number); !ok {
+			c.mismatch(path, "number", jsonType(raw))
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			c.mismatch(path, "array", jsonType(raw))
+			return
+		}
+		for _, item := range items {
+			c.walk(path+"[]", item, t.Elem())
+		}
+	case reflect.Map:
+		object, ok := raw.(map[string]interface{})
+		if !ok {
+			c.mismatch(path, "object", jsonType(raw))
+			return
+		}
+		for key, value := range object {
+			c.walk(join(path, key), value, t.Elem())
+		}
+	case reflect.Struct:
+		object, ok := raw.(map[string]interface{})
+		if !ok {
+			c.mismatch(path, "object", jsonType(raw))
+			return
+		}
+		c.walkFields(path, object, t)
+	}
+}
+
+// walkFields checks the fields of t present in object, including those of
+// embedded structs.
+func (c *checker) walkFields(path string, object map[string]interface{}, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			c.walkFields(path, object, f.Type)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		value, ok := object[name]
+		if !ok {
+			// encoding/json also matches keys case-insensitively
+			for key, v := range object {
+				if strings.EqualFold(key, name) {
+					value, ok = v, true
+					break
+				}
+			}
+		}
+		if ok {
+			c.walk(join(path, name), value, f.Type)
+		}
+	}
+}
+
+func (c *checker) mismatch(path, want, got string) {
+	if path == "" {
+		path = "."
+	}
+	if m, ok := c.found[path]; ok {
+		m.Count++
+		return
+	}
+	c.found[path] = &Mismatch{Path: path, Want: want, Got: got, Count: 1}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonType(raw interface{}) string {
+	switch v := raw.(type) {
+	case string:
+		return "string"
+	case json.Number:
+		return "number " + v.String()
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "null"
+}
@@ -0,0 +1,81 @@
+package contract
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/yourusername/football-prediction/pkg/datasource"
+)
+
+// TestFixturesMatchModels checks every fixture the mock provider serves, the
+// built-in ones or those recorded to MOCK_FIXTURES_DIR, against the model of
+// its endpoint.
+func TestFixturesMatchModels(t *testing.T) {
+	source, err := datasource.Open(datasource.Mock, os.Getenv("MOCK_FIXTURES_DIR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixtures := source.Fixtures()
+
+	checked := 0
+	err = fs.WalkDir(fixtures, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		body, err := fs.ReadFile(fixtures, path)
+		if err != nil {
+			return err
+		}
+
+		r, ok := CheckFixture(path, body)
+		if !ok {
+			t.Errorf("%s: no schema for this endpoint", path)
+			return nil
+		}
+		checked++
+		if r.Err != nil {
+			t.Errorf("%s: %v", path, r.Err)
+		}
+		for _, m := range r.Mismatches {
+			t.Errorf("%s: %s", path, m)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checked == 0 {
+		t.Fatal("no fixtures checked")
+	}
+}
+
+func TestCheckReportsDrift(t *testing.T) {
+	type model struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Squad []struct {
+			ShirtNumber int `json:"shirtNumber"`
+		} `json:"squad"`
+	}
+
+	body := []byte(`{"id": "57", "name": "Arsenal FC", "extra": true,
+		"squad": [{"shirtNumber": "22"}, {"shirtNumber": null}, {"shirtNumber": "6"}]}`)
+	mismatches, err := Check(body, &model{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Mismatch{
+		{Path: "id", Want: "integer", Got: "string", Count: 1},
+		{Path: "squad[].shirtNumber", Want: "integer", Got: "string", Count: 2},
+	}
+	if len(mismatches) != len(want) {
+		t.Fatalf("mismatches = %v, want %v", mismatches, want)
+	}
+	for i := range want {
+		if mismatches[i] != want[i] {
+			t.Errorf("mismatch %d = %v, want %v", i, mismatches[i], want[i])
+		}
+	}
+}
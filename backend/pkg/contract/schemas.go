@@ -0,0 +1,60 @@
+package contract
+
+import (
+	"regexp"
+
+	"github.com/yourusername/football-prediction/pkg/apifootball"
+	"github.com/yourusername/football-prediction/pkg/football"
+)
+
+// Schema is the model an endpoint's responses are decoded into.
+type Schema struct {
+	Provider string
+	Name     string
+	// Path matches the endpoint's fixture paths, as laid out by
+	// datasource.FixturePath.
+	Path *regexp.Regexp
+	// Model returns a pointer to a new value of the model.
+	Model func() interface{}
+}
+
+// page is the envelope of API-Football list endpoints.
+type page[T any] struct {
+	Errors   apifootball.Errors `json:"errors"`
+	Paging   apifootball.Paging `json:"paging"`
+	Response []T                `json:"response"`
+}
+
+// Schemas are the endpoints the stack calls.
+var Schemas = []Schema{
+	{football.Provider, "competitions", regexp.MustCompile(`^api\.football-data\.org/v4/competitions\.json$`),
+		func() interface{} { return &football.CompetitionsResponse{} }},
+	{football.Provider, "matches", regexp.MustCompile(`^api\.football-data\.org/v4/competitions/[^/]+/matches(__.*)?\.json$`),
+		func() interface{} { return &football.MatchesResponse{} }},
+	{football.Provider, "standings", regexp.MustCompile(`^api\.football-data\.org/v4/competitions/[^/]+/standings(__.*)?\.json$`),
+		func() interface{} { return &football.StandingsResponse{} }},
+	{football.Provider, "match", regexp.MustCompile(`^api\.football-data\.org/v4/matches/\d+\.json$`),
+		func() interface{} { return &football.Match{} }},
+	{football.Provider, "team", regexp.MustCompile(`^api\.football-data\.org/v4/teams/\d+\.json$`),
+		func() interface{} { return &football.TeamSquad{} }},
+	{apifootball.Provider, "fixture lineups", regexp.MustCompile(`^v3\.football\.api-sports\.io/fixtures/lineups__.*\.json$`),
+		func() interface{} { return &page[apifootball.FixtureLineupsResponse]{} }},
+	{apifootball.Provider, "fixture events", regexp.MustCompile(`^v3\.football\.api-sports\.io/fixtures/events__.*\.json$`),
+		func() interface{} { return &page[apifootball.FixtureEvent]{} }},
+	{apifootball.Provider, "fixture statistics", regexp.MustCompile(`^v3\.football\.api-sports\.io/fixtures/statistics__.*\.json$`),
+		func() interface{} { return &page[apifootball.FixtureTeamStatistics]{} }},
+	{apifootball.Provider, "fixture players", regexp.MustCompile(`^v3\.football\.api-sports\.io/fixtures/players__.*\.json$`),
+		func() interface{} { return &page[apifootball.FixtureTeamPlayers]{} }},
+	{apifootball.Provider, "players", regexp.MustCompile(`^v3\.football\.api-sports\.io/players__.*\.json$`),
+		func() interface{} { return &page[apifootball.PlayerStatsResponse]{} }},
+}
+
+// For returns the schema of the endpoint a fixture path belongs to.
+func For(fixturePath string) (Schema, bool) {
+	for _, s := range Schemas {
+		if s.Path.MatchString(fixturePath) {
+			return s, true
+		}
+	}
+	return Schema{}, false
+}
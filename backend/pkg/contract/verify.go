@@ -0,0 +1,86 @@
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/yourusername/football-prediction/pkg/datasource"
+)
+
+// Result is the check of one response.
+type Result struct {
+	Schema     string
+	Provider   string
+	Path       string
+	Mismatches []Mismatch
+	// Err is set when the response could not be checked at all.
+	Err error
+}
+
+// OK reports whether the response matched its model.
+func (r Result) OK() bool {
+	return r.Err == nil && len(r.Mismatches) == 0
+}
+
+// CheckFixture checks a response recorded at fixturePath against the model
+// of its endpoint. It reports false for paths of endpoints without a schema.
+func CheckFixture(fixturePath string, body []byte) (Result, bool) {
+	s, ok := For(fixturePath)
+	if !ok {
+		return Result{}, false
+	}
+	r := Result{Schema: s.Name, Provider: s.Provider, Path: fixturePath}
+	r.Mismatches, r.Err = Check(body, s.Model())
+	return r, true
+}
+
+// Verifier is a transport checking the successful responses that pass
+// through it, so clients exercise endpoints as usual while it collects the
+// results.
+type Verifier struct {
+	base http.RoundTripper
+
+	mu      sync.Mutex
+	results []Result
+}
+
+// NewVerifier returns a verifier sending requests with base, or the default
+// transport when base is nil.
+func NewVerifier(base http.RoundTripper) *Verifier {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Verifier{base: base}
+}
+
+func (v *Verifier) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := v.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	path := datasource.FixturePath(req.URL)
+	if r, ok := CheckFixture(path, body); ok {
+		v.mu.Lock()
+		v.results = append(v.results, r)
+		v.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// Results returns the checks made so far, in the order made.
+func (v *Verifier) Results() []Result {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]Result(nil), v.results...)
+}
@@ -0,0 +1,103 @@
+package datasource
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ArchivedResponse is a provider response body as it was fetched.
+type ArchivedResponse struct {
+	ID        int64
+	Source    string // data source code, e.g. football-data
+	Endpoint  string // see Endpoint
+	Body      []byte
+	FetchedAt time.Time
+}
+
+// ArchiveStore keeps archived responses.
+type ArchiveStore interface {
+	Archive(r ArchivedResponse) error
+}
+
+// ArchiveStats counts the responses an Archive has kept, and those it failed
+// to.
+type ArchiveStats struct {
+	Archived  int    `json:"archived"`
+	Failed    int    `json:"failed"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Archive sends requests on and keeps the body of every successful GET in a
+// store, so it can be replayed through newer parsers without spending the
+// provider's quota again. Failing to archive a response does not fail the
+// request. Like Recorder, it keeps bodies only, never API keys.
+type Archive struct {
+	store  ArchiveStore
+	source string
+	base   http.RoundTripper
+
+	mu    sync.Mutex
+	stats ArchiveStats
+}
+
+// NewArchive returns a transport archiving into store, under source, the
+// responses base returns, or the default transport when base is nil.
+func NewArchive(store ArchiveStore, source string, base http.RoundTripper) *Archive {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Archive{store: store, source: source, base: base}
+}
+
+func (a *Archive) RoundTrip(req *http.Request) (*http.Response, error) {
+	fetchedAt := time.Now().UTC()
+	resp, err := a.base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = a.store.Archive(ArchivedResponse{
+		Source:    a.source,
+		Endpoint:  Endpoint(req.URL),
+		Body:      body,
+		FetchedAt: fetchedAt,
+	})
+
+	a.mu.Lock()
+	if err != nil {
+		a.stats.Failed++
+		a.stats.LastError = err.Error()
+	} else {
+		a.stats.Archived++
+	}
+	a.mu.Unlock()
+	return resp, nil
+}
+
+// Stats returns how many responses were archived so far.
+func (a *Archive) Stats() ArchiveStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+// Endpoint returns what a GET of u is archived under: its path, then its
+// query sorted by parameter, e.g. /v4/competitions/PL/matches?season=2024.
+func Endpoint(u *url.URL) string {
+	if q := u.Query(); len(q) > 0 {
+		return u.Path + "?" + q.Encode()
+	}
+	return u.Path
+}
@@ -0,0 +1,135 @@
+// Package datasource selects where provider data comes from: the live
+// football-data.org and API-Football APIs, or JSON fixtures recorded from
+// them, so the whole stack runs offline without API keys.
+package datasource
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/yourusername/football-prediction/pkg/keypool"
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+// Providers.
+const (
+	Live = "live"
+	Mock = "mock"
+)
+
+// MockKey stands in for API keys when none are configured for the mock
+// provider. Fixtures are served whatever the key.
+const MockKey = "mock"
+
+// Fixtures are the recorded responses served by the mock provider when no
+// fixtures directory is configured. Refresh them with footballctl record.
+//
+//go:embed fixtures
+var Fixtures embed.FS
+
+// Source is the configured provider.
+type Source struct {
+	provider  string
+	dir       string
+	fixtures  fs.FS
+	transport http.RoundTripper
+}
+
+// Open returns the source for provider, "live" or "mock". An empty provider
+// is live. The mock provider serves fixtures from dir, or the embedded
+// Fixtures when dir is empty.
+func Open(provider, dir string) (*Source, error) {
+	switch provider {
+	case "", Live:
+		return &Source{provider: Live}, nil
+	case Mock:
+		var fixtures fs.FS
+		if dir != "" {
+			if _, err := os.Stat(dir); err != nil {
+				return nil, fmt.Errorf("fixtures directory: %w", err)
+			}
+			fixtures = os.DirFS(dir)
+		} else {
+			fixtures, _ = fs.Sub(Fixtures, "fixtures")
+		}
+		return &Source{provider: Mock, dir: dir, fixtures: fixtures, transport: NewReplay(fixtures)}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q, want %q or %q", provider, Live, Mock)
+	}
+}
+
+// Provider returns "live" or "mock".
+func (s *Source) Provider() string {
+	return s.provider
+}
+
+// Mock reports whether requests are served from fixtures.
+func (s *Source) Mock() bool {
+	return s.provider == Mock
+}
+
+// FixturesDir returns the directory fixtures are served from, or "" for the
+// embedded ones.
+func (s *Source) FixturesDir() string {
+	return s.dir
+}
+
+// Fixtures returns the fixtures the mock provider serves, laid out by
+// FixturePath, or nil when live.
+func (s *Source) Fixtures() fs.FS {
+	return s.fixtures
+}
+
+// Transport returns the transport provider clients send requests with: nil,
+// the default transport, when live.
+func (s *Source) Transport() http.RoundTripper {
+	return s.transport
+}
+
+// Keys splits the configured comma-separated API keys. The mock provider
+// needs none, so it makes do with MockKey.
+func (s *Source) Keys(configured string) []string {
+	keys := keypool.Split(configured)
+	if len(keys) == 0 && s.Mock() {
+		return []string{MockKey}
+	}
+	return keys
+}
+
+// Quota returns m, or nil for the mock provider, whose requests cost
+// nothing and should not count against the live keys' budgets.
+func (s *Source) Quota(m *quota.Manager) *quota.Manager {
+	if s.Mock() {
+		return nil
+	}
+	return m
+}
+
+// MinInterval returns interval, or zero for the mock provider, which has no
+// rate limit to keep to.
+func (s *Source) MinInterval(interval time.Duration) time.Duration {
+	if s.Mock() {
+		return 0
+	}
+	return interval
+}
+
+var queryReplacer = strings.NewReplacer("=", "-", "&", "_", "/", "-")
+
+// FixturePath returns the slash-separated path of the fixture recorded for
+// a GET of u: its host and path, then its query sorted by parameter, e.g.
+// api.football-data.org/v4/competitions/PL/matches__season-2024.json.
+func FixturePath(u *url.URL) string {
+	p := path.Clean("/" + u.Host + "/" + u.Path)[1:]
+	if q := u.Query(); len(q) > 0 {
+		p += "__" + queryReplacer.Replace(q.Encode())
+	}
+	return p + ".json"
+}
@@ -0,0 +1,74 @@
+package datasource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Recorder sends requests on and saves successful responses under a
+// directory, laid out for Replay. Only response bodies are saved, so
+// fixtures never contain API keys.
+type Recorder struct {
+	dir  string
+	base http.RoundTripper
+
+	mu    sync.Mutex
+	saved []string
+}
+
+// NewRecorder returns a transport recording into dir the responses base
+// returns, or the default transport when base is nil.
+func NewRecorder(dir string, base http.RoundTripper) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, base: base}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Indented, so re-recorded fixtures diff readably
+	saved := body
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		saved = append(indented.Bytes(), '\n')
+	}
+
+	name := FixturePath(req.URL)
+	file := filepath.Join(r.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", name, err)
+	}
+	if err := os.WriteFile(file, saved, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", name, err)
+	}
+
+	r.mu.Lock()
+	r.saved = append(r.saved, name)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Saved returns the fixtures recorded so far, in the order recorded.
+func (r *Recorder) Saved() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.saved...)
+}
@@ -0,0 +1,63 @@
+package datasource
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strconv"
+)
+
+// Replay answers requests with the fixtures recorded for them, never
+// touching the network. Requests without a fixture get a 404 in the
+// providers' error format.
+type Replay struct {
+	fixtures fs.FS
+}
+
+// NewReplay returns a transport serving fixtures laid out by FixturePath.
+func NewReplay(fixtures fs.FS) *Replay {
+	return &Replay{fixtures: fixtures}
+}
+
+func (r *Replay) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if req.Method != http.MethodGet {
+		return respond(req, http.StatusMethodNotAllowed, errorBody(req, http.StatusMethodNotAllowed, "only GET requests are replayed")), nil
+	}
+
+	name := FixturePath(req.URL)
+	body, err := fs.ReadFile(r.fixtures, name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return respond(req, http.StatusNotFound, errorBody(req, http.StatusNotFound, "no recorded fixture "+name)), nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read fixture %s: %w", name, err)
+	}
+	return respond(req, http.StatusOK, body), nil
+}
+
+func respond(req *http.Request, status int, body []byte) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func errorBody(req *http.Request, status int, message string) []byte {
+	return []byte(fmt.Sprintf(`{"message":%s,"errorCode":%d}`, strconv.Quote(message+" for "+req.URL.String()), status))
+}
@@ -0,0 +1,109 @@
+// Package events carries domain events between the ingest commands, the API
+// and its background workers through a message broker, so producers do not
+// depend on who consumes their events.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Domain event types.
+const (
+	MatchIngested     = "match.ingested"
+	MatchFinished     = "match.finished"
+	MatchPostponed    = "match.postponed"
+	MatchRescheduled  = "match.rescheduled"
+	PredictionCreated = "prediction.created"
+	PredictionUpdated = "prediction.updated"
+	PredictionSettled = "prediction.settled"
+)
+
+// IngestedMatch is the data of a match.ingested event, published for every
+// match saved by an ingest run.
+type IngestedMatch struct {
+	ExternalID      int       `json:"externalId"`
+	CompetitionCode string    `json:"competitionCode"`
+	Status          string    `json:"status"`
+	UtcDate         time.Time `json:"utcDate"`
+	HomeScore       *int      `json:"homeScore"`
+	AwayScore       *int      `json:"awayScore"`
+}
+
+// Event is a domain event. Key identifies what the event is about, e.g.
+// "match.finished:12345", so consumers can ignore repeats.
+type Event struct {
+	Type      string          `json:"type"`
+	Key       string          `json:"key"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// New encodes data into an event created now.
+func New(eventType, key string, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	return Event{Type: eventType, Key: key, CreatedAt: time.Now().UTC(), Data: raw}, nil
+}
+
+// Handler consumes an event.
+type Handler func(Event) error
+
+// Publisher sends events to a broker.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Broker publishes events and delivers them to subscribers. Subscribers in
+// the same group share the events of a type, each event going to one of
+// them, so a group scales out over several processes; every group gets
+// every event.
+type Broker interface {
+	Publisher
+	Subscribe(eventType, group string, handler Handler) error
+	Close() error
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]func(*url.URL) (Broker, error){
+		"memory": func(*url.URL) (Broker, error) { return NewMemoryBroker(), nil },
+		"nats":   DialNATS,
+	}
+)
+
+// Register adds a broker driver for URLs with the given scheme, e.g. one
+// backed by Kafka. Drivers are registered at startup, before Open.
+func Register(scheme string, open func(*url.URL) (Broker, error)) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[scheme] = open
+}
+
+// Open connects to the broker at rawURL, e.g. nats://localhost:4222. An
+// empty URL opens an in-process broker, which only reaches subscribers of
+// the same process.
+func Open(rawURL string) (Broker, error) {
+	if rawURL == "" {
+		return NewMemoryBroker(), nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker URL: %w", err)
+	}
+
+	driversMu.RLock()
+	open, ok := drivers[u.Scheme]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no broker driver for %q", u.Scheme)
+	}
+	return open(u)
+}
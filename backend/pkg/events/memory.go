@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MemoryBroker delivers events to subscribers of the same process,
+// synchronously, so Publish returns their errors.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	groups map[string]map[string]*memoryGroup // by event type, then group
+}
+
+// memoryGroup takes turns between its handlers.
+type memoryGroup struct {
+	handlers []Handler
+	next     int
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{groups: map[string]map[string]*memoryGroup{}}
+}
+
+// Publish hands the event to one handler of every group subscribed to its
+// type.
+func (b *MemoryBroker) Publish(_ context.Context, e Event) error {
+	b.mu.Lock()
+	var handlers []Handler
+	for _, g := range b.groups[e.Type] {
+		handlers = append(handlers, g.handlers[g.next])
+		g.next = (g.next + 1) % len(g.handlers)
+	}
+	b.mu.Unlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *MemoryBroker) Subscribe(eventType, group string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	groups := b.groups[eventType]
+	if groups == nil {
+		groups = map[string]*memoryGroup{}
+		b.groups[eventType] = groups
+	}
+	g := groups[group]
+	if g == nil {
+		g = &memoryGroup{}
+		groups[group] = g
+	}
+	g.handlers = append(g.handlers, handler)
+	return nil
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
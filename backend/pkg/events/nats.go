@@ -0,0 +1,312 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsSubjectPrefix namespaces event subjects, e.g. football.match.finished.
+const natsSubjectPrefix = "football."
+
+const (
+	natsDialTimeout     = 5 * time.Second
+	natsMaxReconnectGap = 30 * time.Second
+	// natsPending is how many received events may wait for their handlers
+	// before reading from the server pauses.
+	natsPending = 256
+)
+
+// NATSBroker speaks the core NATS protocol: subjects are event types under
+// natsSubjectPrefix and groups are queue groups. Delivery is at most once;
+// events published while no subscriber is connected are lost. After a lost
+// connection it reconnects with backoff and subscribes again, failing
+// Publish in the meantime. Handlers run one at a time, in order received.
+type NATSBroker struct {
+	addr    string
+	connect []byte
+
+	mu      sync.Mutex
+	conn    net.Conn
+	w       *bufio.Writer
+	subs    map[string]natsSub // by subscription ID
+	nextSID int
+	closed  bool
+
+	received   chan natsMessage
+	done       chan struct{}
+	dispatched chan struct{}
+}
+
+type natsSub struct {
+	eventType string
+	group     string
+	handler   Handler
+}
+
+type natsMessage struct {
+	sid     string
+	payload []byte
+}
+
+// DialNATS connects to the NATS server at u, nats://[user:pass@]host:port
+// or nats://token@host:port. TLS is not supported.
+func DialNATS(u *url.URL) (Broker, error) {
+	opts := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"lang":     "go",
+		"version":  "1.0",
+		"name":     "football-prediction",
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			opts["user"], opts["pass"] = u.User.Username(), pass
+		} else {
+			opts["auth_token"] = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	b := &NATSBroker{
+		addr:       addr,
+		connect:    connect,
+		subs:       map[string]natsSub{},
+		received:   make(chan natsMessage, natsPending),
+		done:       make(chan struct{}),
+		dispatched: make(chan struct{}),
+	}
+	r, err := b.dial()
+	if err != nil {
+		return nil, err
+	}
+	go b.read(r)
+	go b.dispatch()
+	return b, nil
+}
+
+// dial opens a connection, completes the handshake and subscribes again to
+// everything subscribed so far. It returns the reader for the read loop.
+func (b *NATSBroker) dial() (*bufio.Reader, error) {
+	conn, err := net.DialTimeout("tcp", b.addr, natsDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", b.addr, err)
+	}
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+
+	fail := func(err error) (*bufio.Reader, error) {
+		conn.Close()
+		return nil, fmt.Errorf("NATS handshake with %s failed: %w", b.addr, err)
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	info, err := r.ReadString('\n')
+	if err != nil {
+		return fail(err)
+	}
+	if !strings.HasPrefix(info, "INFO ") {
+		return fail(fmt.Errorf("unexpected greeting %q", strings.TrimSpace(info)))
+	}
+	if strings.Contains(info, `"tls_required":true`) {
+		return fail(errors.New("server requires TLS"))
+	}
+
+	fmt.Fprintf(w, "CONNECT %s\r\nPING\r\n", b.connect)
+	if err := w.Flush(); err != nil {
+		return fail(err)
+	}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return fail(err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			return fail(errors.New(line))
+		}
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		conn.Close()
+		return nil, errors.New("broker closed")
+	}
+	for sid, s := range b.subs {
+		fmt.Fprintf(w, "SUB %s %s %s\r\n", natsSubjectPrefix+s.eventType, s.group, sid)
+	}
+	if err := w.Flush(); err != nil {
+		return fail(err)
+	}
+	b.conn, b.w = conn, w
+	return r, nil
+}
+
+// read processes server messages until the connection is lost, then
+// reconnects, until the broker is closed.
+func (b *NATSBroker) read(r *bufio.Reader) {
+	for {
+		// The connection was lost or the server reported an error
+		_ = b.readMessages(r)
+
+		b.mu.Lock()
+		if b.conn != nil {
+			b.conn.Close()
+			b.conn, b.w = nil, nil
+		}
+		closed := b.closed
+		b.mu.Unlock()
+		if closed {
+			close(b.received)
+			return
+		}
+
+		var err error
+		for gap := time.Second; ; gap = min(gap*2, natsMaxReconnectGap) {
+			select {
+			case <-b.done:
+				close(b.received)
+				return
+			case <-time.After(gap):
+			}
+			if r, err = b.dial(); err == nil {
+				break
+			}
+		}
+	}
+}
+
+func (b *NATSBroker) readMessages(r *bufio.Reader) error {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			if len(fields) < 4 {
+				return fmt.Errorf("malformed message header %q", line)
+			}
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return fmt.Errorf("malformed message header %q", line)
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return err
+			}
+			b.received <- natsMessage{sid: fields[2], payload: payload[:size]}
+		case line == "PING":
+			b.mu.Lock()
+			if b.w != nil {
+				b.w.WriteString("PONG\r\n")
+				err = b.w.Flush()
+			}
+			b.mu.Unlock()
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(line)
+		}
+	}
+}
+
+// dispatch runs the handlers of received events.
+func (b *NATSBroker) dispatch() {
+	defer close(b.dispatched)
+	for m := range b.received {
+		b.mu.Lock()
+		s, ok := b.subs[m.sid]
+		b.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		var e Event
+		if err := json.Unmarshal(m.payload, &e); err != nil {
+			continue
+		}
+		_ = s.handler(e)
+	}
+}
+
+// Publish sends the event, failing if the broker is not connected.
+func (b *NATSBroker) Publish(_ context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.w == nil {
+		return errors.New("not connected to NATS")
+	}
+	fmt.Fprintf(b.w, "PUB %s %d\r\n", natsSubjectPrefix+e.Type, len(payload))
+	b.w.Write(payload)
+	b.w.WriteString("\r\n")
+	return b.w.Flush()
+}
+
+func (b *NATSBroker) Subscribe(eventType, group string, handler Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextSID++
+	sid := strconv.Itoa(b.nextSID)
+	b.subs[sid] = natsSub{eventType: eventType, group: group, handler: handler}
+	if b.w == nil {
+		// Sent on reconnect
+		return nil
+	}
+	fmt.Fprintf(b.w, "SUB %s %s %s\r\n", natsSubjectPrefix+eventType, group, sid)
+	return b.w.Flush()
+}
+
+// Close disconnects and returns once the events already received have been
+// handled.
+func (b *NATSBroker) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	var err error
+	if b.conn != nil {
+		b.w.Flush()
+		err = b.conn.Close()
+	}
+	b.mu.Unlock()
+
+	<-b.dispatched
+	return err
+}
@@ -1,40 +1,201 @@
 package football
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/yourusername/football-prediction/pkg/keypool"
+	"github.com/yourusername/football-prediction/pkg/quota"
 )
 
 const (
 	BaseURL = "https://api.football-data.org/v4"
+	// Provider names football-data.org in request budgets.
+	Provider = "football-data"
 )
 
+// CompetitionCodes are the competitions football-data.org serves on the free
+// tier.
+var CompetitionCodes = []string{
+	"BL1", "BSA", "CL", "CLI", "DED", "EC", "ELC", "FL1", "PD", "PL", "PPL", "SA", "WC",
+}
+
+// QualifierCodes maps each confederation to the football-data.org
+// competition holding its World Cup qualifiers. They are not on the free
+// tier.
+var QualifierCodes = map[string]string{
+	"AFC":      "QAFC",
+	"CAF":      "QCAF",
+	"CONCACAF": "QCCF",
+	"CONMEBOL": "QCBL",
+	"OFC":      "QOFC",
+	"UEFA":     "QUFA",
+}
+
+// Team types: whether a competition is played by clubs or national teams.
+const (
+	TeamTypeClub     = "CLUB"
+	TeamTypeNational = "NATIONAL"
+)
+
+// TeamType returns TeamTypeNational for the World Cup, the European
+// Championship and the World Cup qualifiers, and TeamTypeClub otherwise.
+func (c *Competition) TeamType() string {
+	switch c.Code {
+	case "WC", "EC":
+		return TeamTypeNational
+	}
+	for _, code := range QualifierCodes {
+		if c.Code == code {
+			return TeamTypeNational
+		}
+	}
+	return TeamTypeClub
+}
+
+// APIError is returned for non-200 responses from football-data.org.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is how long until the request counter resets, taken from
+	// the X-RequestCounter-Reset header when present.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// IsRateLimited reports whether err is a 429 response from the API.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
 type Client struct {
-	apiKey     string
+	keys       *keypool.Pool
 	httpClient *http.Client
+
+	// Requests are spaced at least interval apart, across goroutines
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+
+	quota *quota.Manager
 }
 
-func NewClient(apiKey string) *Client {
+// NewClient returns a client rotating requests over apiKeys, resting keys
+// the API starts refusing.
+func NewClient(apiKeys ...string) *Client {
 	return &Client{
-		apiKey: apiKey,
+		keys: keypool.New(apiKeys...),
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
 	}
 }
 
-func (c *Client) doRequest(endpoint string) ([]byte, error) {
+// SetMinInterval spaces requests made through the client at least interval
+// apart, whichever goroutine makes them. The free tier allows 10 a minute.
+func (c *Client) SetMinInterval(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interval = interval
+}
+
+// SetTransport replaces the transport requests are sent with, e.g. to trace
+// them.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetQuota counts requests against each key's budget in m, made at the
+// priority of their context, before they are sent.
+func (c *Client) SetQuota(m *quota.Manager) {
+	c.quota = m
+}
+
+// KeyStats returns the request counters of each API key.
+func (c *Client) KeyStats() []keypool.Stats {
+	return c.keys.Stats()
+}
+
+// wait blocks until the client may make its next request.
+func (c *Client) wait() {
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next
+	if at.Before(now) {
+		at = now
+	}
+	c.next = at.Add(c.interval)
+	c.mu.Unlock()
+
+	time.Sleep(time.Until(at))
+}
+
+// doRequest sends a GET with the next key not cooling down. When the API
+// refuses a key, or the key's daily budget is spent, the request is retried
+// with the next one until every key has been tried.
+func (c *Client) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	if c.keys.Len() == 0 {
+		return nil, errors.New("no API key configured")
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.keys.Len(); attempt++ {
+		key, wait := c.keys.Next()
+		if key == "" {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, &APIError{StatusCode: http.StatusTooManyRequests, Body: "every API key is cooling down", RetryAfter: wait}
+		}
+
+		body, err := c.doRequestWith(ctx, key, endpoint)
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			c.keys.Report(key, http.StatusOK, 0)
+			return body, nil
+		case errors.Is(err, quota.ErrExhausted):
+			c.keys.CoolDown(key, time.Now().UTC().Truncate(24*time.Hour).Add(24*time.Hour))
+		case errors.As(err, &apiErr):
+			if !c.keys.Report(key, apiErr.StatusCode, apiErr.RetryAfter) {
+				return nil, err
+			}
+		default:
+			c.keys.Report(key, 0, 0)
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (c *Client) doRequestWith(ctx context.Context, key, endpoint string) ([]byte, error) {
+	if c.quota != nil {
+		if err := c.quota.Reserve(ctx, Provider, key); err != nil {
+			return nil, err
+		}
+	}
+	c.wait()
+
 	url := fmt.Sprintf("%s%s", BaseURL, endpoint)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("X-Auth-Token", c.apiKey)
+	req.Header.Set("X-Auth-Token", key)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.httpClient.Do(req)
@@ -45,7 +206,11 @@ func (c *Client) doRequest(endpoint string) ([]byte, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+		if reset, err := strconv.Atoi(resp.Header.Get("X-RequestCounter-Reset")); err == nil {
+			apiErr.RetryAfter = time.Duration(reset) * time.Second
+		}
+		return nil, apiErr
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -58,7 +223,7 @@ func (c *Client) doRequest(endpoint string) ([]byte, error) {
 
 // GetCompetitions fetches available competitions
 func (c *Client) GetCompetitions() (*CompetitionsResponse, error) {
-	data, err := c.doRequest("/competitions")
+	data, err := c.doRequest(context.Background(), "/competitions")
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +238,18 @@ func (c *Client) GetCompetitions() (*CompetitionsResponse, error) {
 
 // GetMatches fetches matches for a competition
 func (c *Client) GetMatches(competitionCode string, season string) (*MatchesResponse, error) {
+	return c.GetMatchesContext(context.Background(), competitionCode, season)
+}
+
+// GetMatchesContext is GetMatches within ctx, which also carries the
+// request's quota priority.
+func (c *Client) GetMatchesContext(ctx context.Context, competitionCode string, season string) (*MatchesResponse, error) {
 	endpoint := fmt.Sprintf("/competitions/%s/matches", competitionCode)
 	if season != "" {
 		endpoint += fmt.Sprintf("?season=%s", season)
 	}
 
-	data, err := c.doRequest(endpoint)
+	data, err := c.doRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +269,7 @@ func (c *Client) GetStandings(competitionCode string, season string) (*Standings
 		endpoint += fmt.Sprintf("?season=%s", season)
 	}
 
-	data, err := c.doRequest(endpoint)
+	data, err := c.doRequest(context.Background(), endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -113,9 +284,15 @@ func (c *Client) GetStandings(competitionCode string, season string) (*Standings
 
 // GetMatch fetches a single match by ID
 func (c *Client) GetMatch(matchID int) (*Match, error) {
+	return c.GetMatchContext(context.Background(), matchID)
+}
+
+// GetMatchContext is GetMatch within ctx, which can cancel the request and
+// carries its trace.
+func (c *Client) GetMatchContext(ctx context.Context, matchID int) (*Match, error) {
 	endpoint := fmt.Sprintf("/matches/%d", matchID)
 
-	data, err := c.doRequest(endpoint)
+	data, err := c.doRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -131,74 +308,29 @@ func (c *Client) GetMatch(matchID int) (*Match, error) {
 // GetMatchLineups fetches lineups for a specific match by ID
 // Note: Lineups are only available for finished matches or matches in progress
 func (c *Client) GetMatchLineups(matchID int) (*MatchLineups, error) {
-	endpoint := fmt.Sprintf("/matches/%d", matchID)
-
-	data, err := c.doRequest(endpoint)
+	match, err := c.GetMatch(matchID)
 	if err != nil {
 		return nil, err
 	}
 
-	// The match endpoint returns full match details including lineups
-	// We need to extract just the lineup portion
-	var fullMatch struct {
-		HomeTeam struct {
-			ID     int    `json:"id"`
-			Name   string `json:"name"`
-			Lineup []struct {
-				ID          int    `json:"id"`
-				Name        string `json:"name"`
-				Position    string `json:"position"`
-				ShirtNumber int    `json:"shirtNumber"`
-			} `json:"lineup"`
-			Formation string `json:"formation"`
-			Coach     *Coach `json:"coach"`
-		} `json:"homeTeam"`
-		AwayTeam struct {
-			ID     int    `json:"id"`
-			Name   string `json:"name"`
-			Lineup []struct {
-				ID          int    `json:"id"`
-				Name        string `json:"name"`
-				Position    string `json:"position"`
-				ShirtNumber int    `json:"shirtNumber"`
-			} `json:"lineup"`
-			Formation string `json:"formation"`
-			Coach     *Coach `json:"coach"`
-		} `json:"awayTeam"`
-	}
-
-	if err := json.Unmarshal(data, &fullMatch); err != nil {
-		return nil, fmt.Errorf("failed to parse lineups: %w", err)
-	}
-
 	// Map to our MatchLineups structure
 	lineups := &MatchLineups{}
-	lineups.HomeTeam.ID = fullMatch.HomeTeam.ID
-	lineups.HomeTeam.Name = fullMatch.HomeTeam.Name
-	lineups.HomeTeam.Lineup.Formation = fullMatch.HomeTeam.Formation
-	lineups.HomeTeam.Lineup.Coach = fullMatch.HomeTeam.Coach
-
-	for _, p := range fullMatch.HomeTeam.Lineup {
-		lineups.HomeTeam.Lineup.StartXI = append(lineups.HomeTeam.Lineup.StartXI, LineupPlayer{
-			ID:          p.ID,
-			Name:        p.Name,
-			Position:    p.Position,
-			ShirtNumber: p.ShirtNumber,
-		})
-	}
-
-	lineups.AwayTeam.ID = fullMatch.AwayTeam.ID
-	lineups.AwayTeam.Name = fullMatch.AwayTeam.Name
-	lineups.AwayTeam.Lineup.Formation = fullMatch.AwayTeam.Formation
-	lineups.AwayTeam.Lineup.Coach = fullMatch.AwayTeam.Coach
-
-	for _, p := range fullMatch.AwayTeam.Lineup {
-		lineups.AwayTeam.Lineup.StartXI = append(lineups.AwayTeam.Lineup.StartXI, LineupPlayer{
-			ID:          p.ID,
-			Name:        p.Name,
-			Position:    p.Position,
-			ShirtNumber: p.ShirtNumber,
-		})
+	lineups.HomeTeam.ID = match.HomeTeam.ID
+	lineups.HomeTeam.Name = match.HomeTeam.Name
+	lineups.HomeTeam.Lineup = Lineup{
+		Formation:   match.HomeTeam.Formation,
+		Coach:       match.HomeTeam.Coach,
+		StartXI:     match.HomeTeam.Lineup,
+		Substitutes: match.HomeTeam.Bench,
+	}
+
+	lineups.AwayTeam.ID = match.AwayTeam.ID
+	lineups.AwayTeam.Name = match.AwayTeam.Name
+	lineups.AwayTeam.Lineup = Lineup{
+		Formation:   match.AwayTeam.Formation,
+		Coach:       match.AwayTeam.Coach,
+		StartXI:     match.AwayTeam.Lineup,
+		Substitutes: match.AwayTeam.Bench,
 	}
 
 	return lineups, nil
@@ -208,7 +340,7 @@ func (c *Client) GetMatchLineups(matchID int) (*MatchLineups, error) {
 func (c *Client) GetTeamSquad(teamID int) (*TeamSquad, error) {
 	endpoint := fmt.Sprintf("/teams/%d", teamID)
 
-	data, err := c.doRequest(endpoint)
+	data, err := c.doRequest(context.Background(), endpoint)
 	if err != nil {
 		return nil, err
 	}
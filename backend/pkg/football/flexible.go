@@ -0,0 +1,33 @@
+package football
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// FlexibleString is a value football-data.org sends as a string on some
+// endpoints and a number on others, e.g. the season filter ("2024" or
+// 2024). It holds the value's text and is encoded as a string.
+type FlexibleString string
+
+func (s *FlexibleString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*s = ""
+	case len(data) > 0 && data[0] == '"':
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = FlexibleString(v)
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("want a string or a number, got %s", data)
+		}
+		*s = FlexibleString(n.String())
+	}
+	return nil
+}
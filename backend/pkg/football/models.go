@@ -35,13 +35,50 @@ type Match struct {
 	UtcDate     time.Time   `json:"utcDate"`
 	Status      string      `json:"status"`
 	Matchday    int         `json:"matchday"`
+	Venue       string      `json:"venue"`
 	HomeTeam    Team        `json:"homeTeam"`
 	AwayTeam    Team        `json:"awayTeam"`
 	Score       Score       `json:"score"`
 	Goals       []Goal      `json:"goals"`
-	Referees    []Referee   `json:"referees"`
+	Bookings    []Booking   `json:"bookings"`
+	// Substitutions are only returned by the single-match endpoint, and only
+	// on plans that include lineups.
+	Substitutions []Substitution `json:"substitutions"`
+	Referees      []Referee      `json:"referees"`
+	// Stage is the tournament round, e.g. REGULAR_SEASON, GROUP_STAGE or
+	// QUARTER_FINALS. Group is set in group stages only, e.g. GROUP_A.
+	Stage string `json:"stage"`
+	Group string `json:"group"`
+	// LocalDate and LocalTime are the kickoff in the client's time zone, set
+	// by Localize when the match is served.
+	LocalDate string `json:"localDate,omitempty"`
+	LocalTime string `json:"localTime,omitempty"`
 }
 
+// Match stages referred to by name.
+const (
+	StageRegularSeason = "REGULAR_SEASON"
+	StageGroup         = "GROUP_STAGE"
+	StageLeague        = "LEAGUE_STAGE"
+	StageThirdPlace    = "THIRD_PLACE"
+)
+
+// KnockoutStages lists the knockout rounds in the order they are played.
+// THIRD_PLACE is played by the semi-final losers, outside the bracket's main
+// path.
+var KnockoutStages = []string{
+	"PLAYOFFS", "LAST_64", "LAST_32", "LAST_16",
+	"QUARTER_FINALS", "SEMI_FINALS", StageThirdPlace, "FINAL",
+}
+
+// Goal types. For an own goal, Team and Scorer are the player who put the
+// ball into their own net and that player's team.
+const (
+	GoalRegular = "REGULAR"
+	GoalOwn     = "OWN"
+	GoalPenalty = "PENALTY"
+)
+
 type Goal struct {
 	Minute     int        `json:"minute"`
 	InjuryTime *int       `json:"injuryTime"`
@@ -52,6 +89,22 @@ type Goal struct {
 	Score      GoalScore  `json:"score"`
 }
 
+// Substitution is a player change. Team is the side making it.
+type Substitution struct {
+	Minute    int       `json:"minute"`
+	Team      TeamRef   `json:"team"`
+	PlayerOut PlayerRef `json:"playerOut"`
+	PlayerIn  PlayerRef `json:"playerIn"`
+}
+
+// Booking is a card shown to a player. Card is YELLOW, YELLOW_RED or RED.
+type Booking struct {
+	Minute int       `json:"minute"`
+	Team   TeamRef   `json:"team"`
+	Player PlayerRef `json:"player"`
+	Card   string    `json:"card"`
+}
+
 type TeamRef struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -73,13 +126,32 @@ type Team struct {
 	ShortName string `json:"shortName"`
 	TLA       string `json:"tla"`
 	Crest     string `json:"crest"`
+	// Coach, Formation, Lineup and Bench are only set on the single-match
+	// endpoint, and only on plans that include lineups.
+	Coach     *Coach         `json:"coach,omitempty"`
+	Formation string         `json:"formation,omitempty"`
+	Lineup    []LineupPlayer `json:"lineup,omitempty"`
+	Bench     []LineupPlayer `json:"bench,omitempty"`
 }
 
+// Score durations: how a match was decided.
+const (
+	DurationRegular   = "REGULAR"
+	DurationExtraTime = "EXTRA_TIME"
+	DurationPenalties = "PENALTY_SHOOTOUT"
+)
+
+// Score is a match result. After a shootout Winner is the side that won it
+// and FullTime counts the shootout penalties too; RegularTime, ExtraTime and
+// Penalties are only set for matches that went beyond 90 minutes.
 type Score struct {
-	Winner   string    `json:"winner"`
-	Duration string    `json:"duration"`
-	FullTime ScoreTime `json:"fullTime"`
-	HalfTime ScoreTime `json:"halfTime"`
+	Winner      string    `json:"winner"`
+	Duration    string    `json:"duration"`
+	FullTime    ScoreTime `json:"fullTime"`
+	HalfTime    ScoreTime `json:"halfTime"`
+	RegularTime ScoreTime `json:"regularTime"`
+	ExtraTime   ScoreTime `json:"extraTime"`
+	Penalties   ScoreTime `json:"penalties"`
 }
 
 type ScoreTime struct {
@@ -87,6 +159,21 @@ type ScoreTime struct {
 	Away *int `json:"away"`
 }
 
+// Goals returns the goals scored in play, extra time included and shootout
+// penalties excluded.
+func (s Score) Goals() ScoreTime {
+	if s.RegularTime.Home == nil || s.RegularTime.Away == nil {
+		return s.FullTime
+	}
+
+	home, away := *s.RegularTime.Home, *s.RegularTime.Away
+	if s.ExtraTime.Home != nil && s.ExtraTime.Away != nil {
+		home += *s.ExtraTime.Home
+		away += *s.ExtraTime.Away
+	}
+	return ScoreTime{Home: &home, Away: &away}
+}
+
 type Referee struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
@@ -94,6 +181,29 @@ type Referee struct {
 	Nationality string `json:"nationality"`
 }
 
+// MainReferee returns the name of the match referee, or "" if the officials
+// are not known yet.
+func (m *Match) MainReferee() string {
+	for _, r := range m.Referees {
+		if r.Type == "REFEREE" {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// Localize sets LocalDate and LocalTime to the kickoff in loc.
+func (m *Match) Localize(loc *time.Location) {
+	m.LocalDate, m.LocalTime = LocalKickoff(m.UtcDate, loc)
+}
+
+// LocalKickoff returns the date (YYYY-MM-DD) and time of day (HH:MM) of a
+// kickoff in loc.
+func LocalKickoff(utc time.Time, loc *time.Location) (date, clock string) {
+	t := utc.In(loc)
+	return t.Format("2006-01-02"), t.Format("15:04")
+}
+
 // Standing represents team standings in a competition
 type Standing struct {
 	Position       int    `json:"position"`
@@ -124,7 +234,7 @@ type CompetitionsResponse struct {
 
 type MatchesResponse struct {
 	Filters struct {
-		Season interface{} `json:"season"`
+		Season FlexibleString `json:"season"`
 	} `json:"filters"`
 	ResultSet struct {
 		Count  int    `json:"count"`
@@ -138,7 +248,7 @@ type MatchesResponse struct {
 
 type StandingsResponse struct {
 	Filters struct {
-		Season interface{} `json:"season"`
+		Season FlexibleString `json:"season"`
 	} `json:"filters"`
 	Competition Competition     `json:"competition"`
 	Season      Season          `json:"season"`
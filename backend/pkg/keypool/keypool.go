@@ -0,0 +1,197 @@
+// Package keypool rotates the requests made to a provider over several API
+// keys, resting keys the provider starts refusing until they recover.
+package keypool
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/football-prediction/pkg/quota"
+)
+
+const (
+	// RateLimitCooldown rests a key after a 429 that did not say for how
+	// long.
+	RateLimitCooldown = time.Minute
+	// forbiddenStrikes is how many 403s in a row rest a key. A single 403
+	// is usually a resource the plan does not include rather than a bad
+	// key.
+	forbiddenStrikes = 2
+	// A key refused with 403 rests forbiddenCooldown, doubling with every
+	// further refusal up to maxForbiddenCooldown.
+	forbiddenCooldown    = 5 * time.Minute
+	maxForbiddenCooldown = time.Hour
+)
+
+// Stats are a key's request counters since the pool was created. Key is
+// the key's ID, never the key itself.
+type Stats struct {
+	Key          string     `json:"key"`
+	Requests     int        `json:"requests"`
+	Succeeded    int        `json:"succeeded"`
+	RateLimited  int        `json:"rateLimited"`
+	Forbidden    int        `json:"forbidden"`
+	Failed       int        `json:"failed"`
+	Cooldowns    int        `json:"cooldowns"`
+	CoolingUntil *time.Time `json:"coolingUntil,omitempty"`
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// Pool hands out a provider's keys round robin, skipping keys that are
+// cooling down. It is safe for concurrent use.
+type Pool struct {
+	mu   sync.Mutex
+	keys []*key
+	next int
+}
+
+type key struct {
+	value   string
+	stats   Stats
+	until   time.Time
+	strikes int // 403s in a row
+}
+
+// Split parses a comma-separated list of keys, as configured in the
+// environment.
+func Split(value string) []string {
+	var keys []string
+	for _, k := range strings.Split(value, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// New returns a pool of the given keys, ignoring empty and repeated ones.
+func New(keys ...string) *Pool {
+	p := &Pool{}
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		p.keys = append(p.keys, &key{value: k, stats: Stats{Key: quota.KeyID(k)}})
+	}
+	return p
+}
+
+// Len returns the number of keys.
+func (p *Pool) Len() int {
+	return len(p.keys)
+}
+
+// Next returns the next key not cooling down. When every key is, it
+// returns "" and how long until the first of them recovers.
+func (p *Pool) Next() (string, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", 0
+	}
+
+	now := time.Now()
+	var soonest time.Time
+	for i := 0; i < len(p.keys); i++ {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if !k.until.After(now) {
+			p.next = (p.next + i + 1) % len(p.keys)
+			k.stats.Requests++
+			k.stats.LastUsedAt = &now
+			return k.value, 0
+		}
+		if soonest.IsZero() || k.until.Before(soonest) {
+			soonest = k.until
+		}
+	}
+	return "", soonest.Sub(now)
+}
+
+// Report records how a request made with k went: the response status, or 0
+// when no response came back, and on a 429 how long the provider asked to
+// wait. It reports whether k is now cooling down, in which case the
+// request is worth retrying with another key.
+func (p *Pool) Report(k string, status int, retryAfter time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry := p.find(k)
+	if entry == nil {
+		return false
+	}
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		entry.stats.RateLimited++
+		if retryAfter <= 0 {
+			retryAfter = RateLimitCooldown
+		}
+		entry.coolDown(time.Now().Add(retryAfter))
+		return true
+	case status == http.StatusForbidden:
+		entry.stats.Forbidden++
+		entry.strikes++
+		if entry.strikes < forbiddenStrikes {
+			return false
+		}
+		wait := forbiddenCooldown << min(entry.strikes-forbiddenStrikes, 8)
+		entry.coolDown(time.Now().Add(min(wait, maxForbiddenCooldown)))
+		return true
+	case status >= 200 && status < 400:
+		entry.stats.Succeeded++
+		entry.strikes = 0
+	default:
+		entry.stats.Failed++
+	}
+	return false
+}
+
+// CoolDown rests k until the given time, e.g. once its daily quota is used
+// up.
+func (p *Pool) CoolDown(k string, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if entry := p.find(k); entry != nil {
+		entry.coolDown(until)
+	}
+}
+
+// Stats returns the counters of every key, in the order configured.
+func (p *Pool) Stats() []Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	stats := make([]Stats, 0, len(p.keys))
+	for _, k := range p.keys {
+		s := k.stats
+		if k.until.After(now) {
+			until := k.until
+			s.CoolingUntil = &until
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+func (p *Pool) find(k string) *key {
+	for _, entry := range p.keys {
+		if entry.value == k {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (k *key) coolDown(until time.Time) {
+	if until.After(k.until) {
+		k.until = until
+	}
+	k.stats.Cooldowns++
+}
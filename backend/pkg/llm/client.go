@@ -0,0 +1,193 @@
+// Package llm is the single layer every generated-content feature calls
+// models through. Each task, such as recaps or previews, is routed to an
+// ordered list of provider models: the first answers, and the next is tried
+// when one fails. Every request's tokens and cost are recorded.
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskDefault is the route of tasks without one of their own.
+const TaskDefault = "default"
+
+// Message is a single chat message sent to the model.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Reply is a model's answer and what it cost. Model is "provider/model".
+type Reply struct {
+	Content      string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+}
+
+// Usage is one request to a provider, answered or not.
+type Usage struct {
+	Task         string
+	Provider     string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+	Latency      time.Duration
+	Error        string
+	CreatedAt    time.Time
+}
+
+// UsageStore persists request usage. Failing to record usage does not fail
+// the request.
+type UsageStore interface {
+	Record(u Usage) error
+}
+
+// Config is what a client routes requests with. Routes map a task to the
+// models tried in order; Prices are per model, for the cost of each request.
+type Config struct {
+	Providers []Provider
+	Routes    map[string][]Target
+	Prices    map[string]Price
+	Usage     UsageStore
+}
+
+// Client sends a task's requests along its route.
+type Client struct {
+	providers map[string]Provider
+	routes    map[string][]Target
+	prices    map[string]Price
+	usage     UsageStore
+	task      string
+}
+
+func NewClient(cfg Config) *Client {
+	c := &Client{
+		providers: make(map[string]Provider, len(cfg.Providers)),
+		routes:    cfg.Routes,
+		prices:    cfg.Prices,
+		usage:     cfg.Usage,
+		task:      TaskDefault,
+	}
+	for _, p := range cfg.Providers {
+		c.providers[p.Name()] = p
+	}
+	return c
+}
+
+// For returns a client sending requests along the route of task, or the
+// default route if task has none.
+func (c *Client) For(task string) *Client {
+	if c == nil {
+		return nil
+	}
+	scoped := *c
+	scoped.task = task
+	return &scoped
+}
+
+// Enabled reports whether the client's route has a configured provider.
+func (c *Client) Enabled() bool {
+	return len(c.route()) > 0
+}
+
+// route returns the targets of the client's task whose provider is
+// configured.
+func (c *Client) route() []Target {
+	if c == nil {
+		return nil
+	}
+	targets, ok := c.routes[c.task]
+	if !ok {
+		targets = c.routes[TaskDefault]
+	}
+	var out []Target
+	for _, t := range targets {
+		if _, ok := c.providers[t.Provider]; ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Chat sends the messages along the route and returns the first answer.
+// Each failed model is recorded and the next one tried; the error joins
+// every model's failure.
+func (c *Client) Chat(messages []Message) (Reply, error) {
+	route := c.route()
+	if len(route) == 0 {
+		return Reply{}, fmt.Errorf("llm client not configured")
+	}
+
+	var errs []error
+	for _, t := range route {
+		start := time.Now()
+		reply, err := c.providers[t.Provider].Chat(t.Model, messages)
+		u := Usage{
+			Task:      c.task,
+			Provider:  t.Provider,
+			Model:     t.Model,
+			Latency:   time.Since(start),
+			CreatedAt: start.UTC(),
+		}
+		if err != nil {
+			u.Error = err.Error()
+			c.record(u)
+			errs = append(errs, fmt.Errorf("%s: %w", t, err))
+			continue
+		}
+
+		reply.Model = t.String()
+		reply.CostUSD = c.prices[t.Model].Cost(reply.InputTokens, reply.OutputTokens)
+		u.InputTokens, u.OutputTokens, u.CostUSD = reply.InputTokens, reply.OutputTokens, reply.CostUSD
+		c.record(u)
+		return reply, nil
+	}
+	return Reply{}, errors.Join(errs...)
+}
+
+func (c *Client) record(u Usage) {
+	if c.usage != nil {
+		_ = c.usage.Record(u)
+	}
+}
+
+// Complete is a convenience wrapper for a single system + user prompt.
+func (c *Client) Complete(systemPrompt, userPrompt string) (Reply, error) {
+	return c.Chat([]Message{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userPrompt},
+	})
+}
+
+// CompleteJSON asks the model for a JSON document and decodes it into v.
+// Markdown code fences around the JSON are tolerated.
+func (c *Client) CompleteJSON(systemPrompt, userPrompt string, v interface{}) (Reply, error) {
+	reply, err := c.Complete(systemPrompt, userPrompt)
+	if err != nil {
+		return reply, err
+	}
+
+	if err := json.Unmarshal([]byte(ExtractJSON(reply.Content)), v); err != nil {
+		return reply, fmt.Errorf("failed to parse LLM JSON reply: %w", err)
+	}
+
+	return reply, nil
+}
+
+// ExtractJSON strips markdown fences and surrounding prose from a reply that
+// is expected to contain a single JSON object.
+func ExtractJSON(reply string) string {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start == -1 || end < start {
+		return reply
+	}
+	return reply[start : end+1]
+}
@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Target is a model of a provider a task is routed to.
+type Target struct {
+	Provider string
+	Model    string
+}
+
+func (t Target) String() string {
+	return t.Provider + "/" + t.Model
+}
+
+// ParseTarget parses "provider:model". A model without a provider is an
+// OpenAI-compatible one.
+func ParseTarget(s string) (Target, error) {
+	s = strings.TrimSpace(s)
+	provider, model, ok := strings.Cut(s, ":")
+	if !ok {
+		provider, model = ProviderOpenAI, s
+	}
+	provider, model = strings.TrimSpace(provider), strings.TrimSpace(model)
+	switch provider {
+	case ProviderOpenAI, ProviderAnthropic, ProviderOllama:
+	default:
+		return Target{}, fmt.Errorf("unknown LLM provider %q", provider)
+	}
+	if model == "" {
+		return Target{}, fmt.Errorf("missing model for LLM provider %q", provider)
+	}
+	return Target{Provider: provider, Model: model}, nil
+}
+
+// ParseRoutes parses task routes such as
+// "default=openai:gpt-4o-mini|ollama:llama3.1,recap=anthropic:claude-3-5-haiku-latest":
+// comma-separated tasks, each with the models tried in order separated by
+// "|". Routes given override those in base, which is not modified.
+func ParseRoutes(spec string, base map[string][]Target) (map[string][]Target, error) {
+	routes := make(map[string][]Target, len(base))
+	for task, targets := range base {
+		routes[task] = targets
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		task, chain, ok := strings.Cut(entry, "=")
+		task = strings.TrimSpace(task)
+		if !ok || task == "" {
+			return nil, fmt.Errorf("invalid LLM route %q", entry)
+		}
+		var targets []Target
+		for _, s := range strings.Split(chain, "|") {
+			t, err := ParseTarget(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid LLM route %q: %w", entry, err)
+			}
+			targets = append(targets, t)
+		}
+		routes[task] = targets
+	}
+	return routes, nil
+}
+
+// Price is what a model costs in USD per million input and output tokens.
+type Price struct {
+	Input  float64
+	Output float64
+}
+
+// Cost is the price of a request's tokens.
+func (p Price) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
+
+// DefaultPrices are list prices of common models. Models without a price,
+// such as local ones, cost nothing.
+var DefaultPrices = map[string]Price{
+	"llama-3.1-70b-versatile":  {Input: 0.59, Output: 0.79},
+	"llama-3.1-8b-instant":     {Input: 0.05, Output: 0.08},
+	"gpt-4o":                   {Input: 2.50, Output: 10.00},
+	"gpt-4o-mini":              {Input: 0.15, Output: 0.60},
+	"claude-3-5-haiku-latest":  {Input: 0.80, Output: 4.00},
+	"claude-3-5-sonnet-latest": {Input: 3.00, Output: 15.00},
+}
+
+// ParsePrices parses model prices such as "gpt-4o-mini=0.15/0.60", USD per
+// million input/output tokens, comma-separated. Prices given override those
+// in base, which is not modified.
+func ParsePrices(spec string, base map[string]Price) (map[string]Price, error) {
+	prices := make(map[string]Price, len(base))
+	for model, p := range base {
+		prices[model] = p
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		model, price, ok := strings.Cut(entry, "=")
+		input, output, ok2 := strings.Cut(price, "/")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid LLM price %q", entry)
+		}
+		in, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LLM price %q: %w", entry, err)
+		}
+		out, err := strconv.ParseFloat(strings.TrimSpace(output), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LLM price %q: %w", entry, err)
+		}
+		prices[strings.TrimSpace(model)] = Price{Input: in, Output: out}
+	}
+	return prices, nil
+}
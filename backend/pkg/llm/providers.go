@@ -0,0 +1,254 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider names.
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderOllama    = "ollama"
+)
+
+const (
+	// DefaultOpenAIURL points at Groq's OpenAI-compatible endpoint (free
+	// tier); any OpenAI-compatible API works.
+	DefaultOpenAIURL = "https://api.groq.com/openai/v1"
+	// DefaultModel is the model of the default route when none is
+	// configured.
+	DefaultModel          = "llama-3.1-70b-versatile"
+	DefaultAnthropicURL   = "https://api.anthropic.com"
+	DefaultAnthropicModel = "claude-3-5-haiku-latest"
+	DefaultOllamaURL      = "http://localhost:11434"
+	DefaultOllamaModel    = "llama3.1"
+)
+
+const (
+	temperature = 0.3
+	// maxTokens caps a reply where the API requires a cap.
+	maxTokens = 1024
+	// anthropicVersion is the Messages API version requested.
+	anthropicVersion = "2023-06-01"
+)
+
+// Provider is a chat completions API.
+type Provider interface {
+	Name() string
+	// Chat returns the model's reply to the messages with its token
+	// counts.
+	Chat(model string, messages []Message) (Reply, error)
+}
+
+// OpenAI talks to any OpenAI-compatible chat completions API.
+type OpenAI struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewOpenAI(baseURL, apiKey string) *OpenAI {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIURL
+	}
+	return &OpenAI{baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: apiKey, httpClient: newHTTPClient()}
+}
+
+func (p *OpenAI) Name() string { return ProviderOpenAI }
+
+// SetTransport replaces the transport requests are sent with.
+func (p *OpenAI) SetTransport(rt http.RoundTripper) { p.httpClient.Transport = rt }
+
+func (p *OpenAI) Chat(model string, messages []Message) (Reply, error) {
+	var response struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	err := postJSON(p.httpClient, p.baseURL+"/chat/completions", map[string]string{
+		"Authorization": "Bearer " + p.apiKey,
+	}, map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": temperature,
+	}, &response)
+	if err != nil {
+		return Reply{}, err
+	}
+
+	if len(response.Choices) == 0 {
+		return Reply{}, fmt.Errorf("LLM returned no choices")
+	}
+	return Reply{
+		Content:      strings.TrimSpace(response.Choices[0].Message.Content),
+		InputTokens:  response.Usage.PromptTokens,
+		OutputTokens: response.Usage.CompletionTokens,
+	}, nil
+}
+
+// Anthropic talks to the Anthropic Messages API.
+type Anthropic struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewAnthropic(baseURL, apiKey string) *Anthropic {
+	if baseURL == "" {
+		baseURL = DefaultAnthropicURL
+	}
+	return &Anthropic{baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: apiKey, httpClient: newHTTPClient()}
+}
+
+func (p *Anthropic) Name() string { return ProviderAnthropic }
+
+// SetTransport replaces the transport requests are sent with.
+func (p *Anthropic) SetTransport(rt http.RoundTripper) { p.httpClient.Transport = rt }
+
+// Chat sends the system messages as the system prompt, as the Messages API
+// takes it apart from the conversation.
+func (p *Anthropic) Chat(model string, messages []Message) (Reply, error) {
+	var system []string
+	conversation := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == "system" {
+			system = append(system, m.Content)
+			continue
+		}
+		conversation = append(conversation, m)
+	}
+
+	var response struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	err := postJSON(p.httpClient, p.baseURL+"/v1/messages", map[string]string{
+		"x-api-key":         p.apiKey,
+		"anthropic-version": anthropicVersion,
+	}, map[string]interface{}{
+		"model":       model,
+		"system":      strings.Join(system, "\n\n"),
+		"messages":    conversation,
+		"max_tokens":  maxTokens,
+		"temperature": temperature,
+	}, &response)
+	if err != nil {
+		return Reply{}, err
+	}
+
+	var text strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return Reply{}, fmt.Errorf("LLM returned no text")
+	}
+	return Reply{
+		Content:      strings.TrimSpace(text.String()),
+		InputTokens:  response.Usage.InputTokens,
+		OutputTokens: response.Usage.OutputTokens,
+	}, nil
+}
+
+// Ollama talks to a local Ollama server.
+type Ollama struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewOllama(baseURL string) *Ollama {
+	if baseURL == "" {
+		baseURL = DefaultOllamaURL
+	}
+	return &Ollama{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: newHTTPClient()}
+}
+
+func (p *Ollama) Name() string { return ProviderOllama }
+
+// SetTransport replaces the transport requests are sent with.
+func (p *Ollama) SetTransport(rt http.RoundTripper) { p.httpClient.Transport = rt }
+
+func (p *Ollama) Chat(model string, messages []Message) (Reply, error) {
+	var response struct {
+		Message         Message `json:"message"`
+		PromptEvalCount int     `json:"prompt_eval_count"`
+		EvalCount       int     `json:"eval_count"`
+	}
+	err := postJSON(p.httpClient, p.baseURL+"/api/chat", nil, map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+		"stream":   false,
+		"options":  map[string]interface{}{"temperature": temperature},
+	}, &response)
+	if err != nil {
+		return Reply{}, err
+	}
+
+	if response.Message.Content == "" {
+		return Reply{}, fmt.Errorf("LLM returned no message")
+	}
+	return Reply{
+		Content:      strings.TrimSpace(response.Message.Content),
+		InputTokens:  response.PromptEvalCount,
+		OutputTokens: response.EvalCount,
+	}, nil
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 60 * time.Second}
+}
+
+// postJSON posts payload to url with headers and decodes the response into
+// v, failing on any status but 200.
+func postJSON(client *http.Client, url string, headers map[string]string, payload, v interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("LLM API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// Package mail sends email through a pluggable provider.
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Provider names.
+const (
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+)
+
+const DefaultSendGridURL = "https://api.sendgrid.com"
+
+// Message is an email with a plain text body and, optionally, an HTML
+// alternative.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Headers are extra headers, such as List-Unsubscribe.
+	Headers map[string]string
+}
+
+// Sender delivers email.
+type Sender interface {
+	Name() string
+	Send(m Message) error
+}
+
+// Config selects and configures a provider. From is the sender address,
+// optionally with a display name, e.g. "Football Insights <digest@example.com>".
+type Config struct {
+	Provider string
+	From     string
+
+	SMTPAddr     string // host:port
+	SMTPUsername string
+	SMTPPassword string
+
+	SendGridURL string
+	SendGridKey string
+}
+
+// New returns the sender of a provider, or nil if provider is empty.
+func New(cfg Config) (Sender, error) {
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+	from, err := netmail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+
+	switch cfg.Provider {
+	case ProviderSMTP:
+		if _, _, err := net.SplitHostPort(cfg.SMTPAddr); err != nil {
+			return nil, fmt.Errorf("invalid SMTP address %q", cfg.SMTPAddr)
+		}
+		return NewSMTP(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, from), nil
+	case ProviderSendGrid:
+		return NewSendGrid(cfg.SendGridURL, cfg.SendGridKey, from), nil
+	}
+	return nil, fmt.Errorf("unknown mail provider %q", cfg.Provider)
+}
+
+// SMTP delivers through an SMTP server, upgrading to TLS when the server
+// offers STARTTLS.
+type SMTP struct {
+	addr string
+	auth smtp.Auth
+	from *netmail.Address
+}
+
+func NewSMTP(addr, username, password string, from *netmail.Address) *SMTP {
+	s := &SMTP{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+func (s *SMTP) Name() string { return ProviderSMTP }
+
+func (s *SMTP) Send(m Message) error {
+	to, err := netmail.ParseAddress(m.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", m.To, err)
+	}
+	data, err := s.compose(to, m)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(s.addr, s.auth, s.from.Address, []string{to.Address}, data); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// compose builds the MIME message: text and HTML as alternatives, both
+// quoted-printable.
+func (s *SMTP) compose(to *netmail.Address, m Message) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to create message ID: %w", err)
+	}
+	domain := s.from.Address[strings.LastIndex(s.from.Address, "@")+1:]
+
+	headers := map[string]string{
+		"From":         s.from.String(),
+		"To":           to.String(),
+		"Subject":      mime.QEncoding.Encode("utf-8", m.Subject),
+		"Date":         time.Now().Format(time.RFC1123Z),
+		"Message-ID":   "<" + hex.EncodeToString(id) + "@" + domain + ">",
+		"MIME-Version": "1.0",
+	}
+	for k, v := range m.Headers {
+		headers[textproto.CanonicalMIMEHeaderKey(k)] = v
+	}
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, k := range names {
+		if strings.ContainsAny(k+headers[k], "\r\n") {
+			return nil, fmt.Errorf("invalid %s header", k)
+		}
+		fmt.Fprintf(&buf, "%s: %s\r\n", k, headers[k])
+	}
+
+	if m.HTML == "" {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&buf, m.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose email: %w", err)
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compose email: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("failed to compose email: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("failed to compose email: %w", err)
+	}
+	return nil
+}
+
+// SendGrid delivers through the SendGrid v3 mail send API.
+type SendGrid struct {
+	baseURL    string
+	apiKey     string
+	from       *netmail.Address
+	httpClient *http.Client
+}
+
+func NewSendGrid(baseURL, apiKey string, from *netmail.Address) *SendGrid {
+	if baseURL == "" {
+		baseURL = DefaultSendGridURL
+	}
+	return &SendGrid{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		from:       from,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *SendGrid) Name() string { return ProviderSendGrid }
+
+func (s *SendGrid) Send(m Message) error {
+	to, err := netmail.ParseAddress(m.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", m.To, err)
+	}
+
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	content := []map[string]string{{"type": "text/plain", "value": m.Text}}
+	if m.HTML != "" {
+		content = append(content, map[string]string{"type": "text/html", "value": m.HTML})
+	}
+	payload := map[string]interface{}{
+		"personalizations": []map[string]interface{}{
+			{"to": []address{{Email: to.Address, Name: to.Name}}},
+		},
+		"from":    address{Email: s.from.Address, Name: s.from.Name},
+		"subject": m.Subject,
+		"content": content,
+	}
+	if len(m.Headers) > 0 {
+		payload["headers"] = m.Headers
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/v3/mail/send", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("SendGrid API error (status %d): %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+// Package mlclient calls the Python ML service through a bounded pool of
+// workers. Requests beyond the pool wait in a queue of limited depth, and
+// once the queue is full they are shed instead of piling onto a service that
+// is already behind, so callers can fall back to a cached or baseline
+// prediction.
+package mlclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultURL        = "http://localhost:8000"
+	DefaultWorkers    = 8
+	DefaultQueueDepth = 32
+	DefaultTimeout    = 10 * time.Second
+)
+
+// ErrOverloaded is returned when every worker is busy and the queue is
+// full.
+var ErrOverloaded = errors.New("ML service queue is full")
+
+// waitBuckets are the upper bounds of the queue wait histogram.
+var waitBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// Config configures a Client. Zero values take the defaults.
+type Config struct {
+	URL string
+	// Workers is how many requests are sent to the service at once.
+	Workers int
+	// QueueDepth is how many requests may wait for a worker.
+	QueueDepth int
+	// Timeout is each request's deadline, time spent queued included.
+	Timeout   time.Duration
+	Transport http.RoundTripper
+}
+
+// Prediction is the service's answer for one match.
+type Prediction struct {
+	HomeWinProbability float64                `json:"home_win_probability"`
+	DrawProbability    float64                `json:"draw_probability"`
+	AwayWinProbability float64                `json:"away_win_probability"`
+	PredictedOutcome   string                 `json:"predicted_outcome"`
+	ConfidenceScore    float64                `json:"confidence_score"`
+	ModelVersion       string                 `json:"model_version"`
+	ModelAccuracy      *float64               `json:"model_accuracy"`
+	TeamStats          map[string]interface{} `json:"team_stats"`
+	Insights           []string               `json:"insights"`
+	KeyFeatures        map[string]interface{} `json:"key_features"`
+}
+
+// WaitBucket counts the requests that waited at most UpToMs for a worker.
+// The last bucket, with UpToMs 0, counts those that waited longer.
+type WaitBucket struct {
+	UpToMs int64 `json:"upToMs,omitempty"`
+	Count  int   `json:"count"`
+}
+
+// Stats are the client's counters since it was created, with the requests
+// running and queued right now.
+type Stats struct {
+	Workers    int          `json:"workers"`
+	QueueDepth int          `json:"queueDepth"`
+	Running    int          `json:"running"`
+	Queued     int          `json:"queued"`
+	Requests   int          `json:"requests"`
+	Succeeded  int          `json:"succeeded"`
+	Failed     int          `json:"failed"`
+	Shed       int          `json:"shed"`
+	Expired    int          `json:"expired"` // deadline passed while queued
+	WaitAvgMs  float64      `json:"waitAvgMs"`
+	WaitMaxMs  float64      `json:"waitMaxMs"`
+	Waits      []WaitBucket `json:"waits"`
+}
+
+// Client sends predictions to the ML service. It is safe for concurrent use.
+type Client struct {
+	url        string
+	timeout    time.Duration
+	queueDepth int
+	httpClient *http.Client
+	workers    chan struct{}
+
+	mu        sync.Mutex
+	stats     Stats
+	queued    int
+	waited    int // requests that got a worker
+	waitTotal time.Duration
+	waitMax   time.Duration
+	waits     []int // per waitBuckets, then one for longer waits
+}
+
+func New(cfg Config) *Client {
+	if cfg.URL == "" {
+		cfg.URL = DefaultURL
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	if cfg.QueueDepth <= 0 {
+		cfg.QueueDepth = DefaultQueueDepth
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Client{
+		url:        strings.TrimSuffix(cfg.URL, "/"),
+		timeout:    cfg.Timeout,
+		queueDepth: cfg.QueueDepth,
+		httpClient: &http.Client{Transport: cfg.Transport},
+		workers:    make(chan struct{}, cfg.Workers),
+		stats:      Stats{Workers: cfg.Workers, QueueDepth: cfg.QueueDepth},
+		waits:      make([]int, len(waitBuckets)+1),
+	}
+}
+
+// Predict posts features to the service's /predict endpoint once a worker
+// is free. It returns ErrOverloaded without calling the service when the
+// queue is full, and the context's error when the deadline passes first.
+func (c *Client) Predict(ctx context.Context, features interface{}) (*Prediction, error) {
+	payload, err := json.Marshal(features)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ML request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer func() { <-c.workers }()
+
+	p, err := c.post(ctx, payload)
+	c.mu.Lock()
+	if err != nil {
+		c.stats.Failed++
+	} else {
+		c.stats.Succeeded++
+	}
+	c.mu.Unlock()
+	return p, err
+}
+
+// acquire takes a worker, queueing for one if none is free.
+func (c *Client) acquire(ctx context.Context) error {
+	c.mu.Lock()
+	c.stats.Requests++
+	c.mu.Unlock()
+
+	select {
+	case c.workers <- struct{}{}:
+		c.recordWait(0)
+		return nil
+	default:
+	}
+
+	c.mu.Lock()
+	if c.queued >= c.queueDepth {
+		c.stats.Shed++
+		c.mu.Unlock()
+		return ErrOverloaded
+	}
+	c.queued++
+	c.mu.Unlock()
+
+	start := time.Now()
+	select {
+	case c.workers <- struct{}{}:
+		c.mu.Lock()
+		c.queued--
+		c.mu.Unlock()
+		c.recordWait(time.Since(start))
+		return nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.queued--
+		c.stats.Expired++
+		c.mu.Unlock()
+		return fmt.Errorf("gave up waiting for an ML worker: %w", ctx.Err())
+	}
+}
+
+func (c *Client) recordWait(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.waited++
+	c.waitTotal += d
+	if d > c.waitMax {
+		c.waitMax = d
+	}
+	i := 0
+	for i < len(waitBuckets) && d > waitBuckets[i] {
+		i++
+	}
+	c.waits[i]++
+}
+
+func (c *Client) post(ctx context.Context, payload []byte) (*Prediction, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/predict", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ML request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ML service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ML service returned status %d", resp.StatusCode)
+	}
+
+	var p Prediction
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to decode ML prediction: %w", err)
+	}
+	return &p, nil
+}
+
+// Stats returns the client's counters and queue wait times.
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Running = len(c.workers)
+	s.Queued = c.queued
+	if c.waited > 0 {
+		s.WaitAvgMs = float64(c.waitTotal) / float64(c.waited) / float64(time.Millisecond)
+	}
+	s.WaitMaxMs = float64(c.waitMax) / float64(time.Millisecond)
+	s.Waits = make([]WaitBucket, len(c.waits))
+	for i, n := range c.waits {
+		s.Waits[i].Count = n
+		if i < len(waitBuckets) {
+			s.Waits[i].UpToMs = waitBuckets[i].Milliseconds()
+		}
+	}
+	return s
+}
@@ -0,0 +1,292 @@
+// Package quota budgets the requests made to rate-limited providers such as
+// football-data.org and API-Football, per provider and API key, so every
+// process sharing a key keeps to one budget and fetches that matter most are
+// not crowded out by bulk ones.
+package quota
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Period is the length of a budget window.
+type Period string
+
+const (
+	PerMinute Period = "minute"
+	PerDay    Period = "day"
+)
+
+// Priority ranks a fetch. High priority fetches may use the part of the
+// daily budget reserved for them; Normal ones stop short of it.
+type Priority int
+
+const (
+	Normal Priority = iota
+	High
+)
+
+func (p Priority) String() string {
+	if p == High {
+		return "high"
+	}
+	return "normal"
+}
+
+// ErrExhausted is returned instead of waiting for the next day once the
+// daily budget available to a fetch's priority is used up.
+var ErrExhausted = errors.New("provider quota exhausted")
+
+// Limits is a provider's budget per API key. Zero disables a limit.
+type Limits struct {
+	PerMinute int
+	PerDay    int
+	// Reserved is how many of the daily requests only High priority
+	// fetches may use.
+	Reserved int
+}
+
+// Usage is a budget window as seen by the manager. Remaining is -1 when
+// the window is unlimited.
+type Usage struct {
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resetsAt"`
+}
+
+// Status is the budget of one provider key. Key is the key's ID, never the
+// key itself.
+type Status struct {
+	Provider string `json:"provider"`
+	Key      string `json:"key"`
+	Minute   Usage  `json:"minute"`
+	Day      Usage  `json:"day"`
+	Reserved int    `json:"reserved"`
+}
+
+// Count is a stored request count of a window.
+type Count struct {
+	Provider string
+	KeyID    string
+	Period   Period
+	Start    time.Time
+	Requests int
+}
+
+// Store persists request counts, so they survive restarts and are shared by
+// the processes using the same keys.
+type Store interface {
+	// Add counts n requests in the window starting at start and returns
+	// its total, including requests counted by other processes. A window
+	// newer than the stored one starts the count over.
+	Add(provider, keyID string, period Period, start time.Time, n int) (int, error)
+	// List returns the stored count of every provider key and period.
+	List() ([]Count, error)
+}
+
+// KeyID identifies an API key in counts and status without revealing it.
+func KeyID(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:6])
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose fetches are made at priority p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFrom returns the priority set with WithPriority, Normal if none.
+func PriorityFrom(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+// Manager tracks the budgets of every provider key. Windows are fixed UTC
+// minutes and days. Processes sharing a store see each other's requests as
+// they count their own, so together they may overshoot a window by a
+// request or two but no more. When the store fails, counting carries on in
+// memory rather than holding up fetches.
+type Manager struct {
+	store Store
+
+	mu      sync.Mutex
+	limits  map[string]Limits // by provider
+	budgets map[budgetKey]*budget
+}
+
+type budgetKey struct {
+	provider string
+	keyID    string
+}
+
+type budget struct {
+	mu         sync.Mutex
+	minute     time.Time
+	minuteUsed int
+	day        time.Time
+	dayUsed    int
+}
+
+// NewManager returns a manager counting in store, or in memory only when
+// store is nil.
+func NewManager(store Store) *Manager {
+	return &Manager{store: store, limits: map[string]Limits{}, budgets: map[budgetKey]*budget{}}
+}
+
+// SetLimits sets the budget of each of the provider's keys.
+func (m *Manager) SetLimits(provider string, limits Limits) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limits[provider] = limits
+}
+
+// Reserve counts a request to provider with apiKey, made at the priority in
+// ctx. It waits for the next minute when the minute's budget is spent, and
+// returns ErrExhausted once the day's budget for the priority is.
+func (m *Manager) Reserve(ctx context.Context, provider, apiKey string) error {
+	keyID := KeyID(apiKey)
+	m.mu.Lock()
+	limits := m.limits[provider]
+	b := m.budgets[budgetKey{provider, keyID}]
+	if b == nil {
+		b = &budget{}
+		m.budgets[budgetKey{provider, keyID}] = b
+	}
+	m.mu.Unlock()
+
+	dayLimit := limits.PerDay
+	if PriorityFrom(ctx) != High {
+		dayLimit -= limits.Reserved
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now().UTC()
+		minute, day := now.Truncate(time.Minute), now.Truncate(24*time.Hour)
+		if !b.day.Equal(day) {
+			b.day, b.dayUsed = day, m.add(provider, keyID, PerDay, day, 0, 0)
+		}
+		if !b.minute.Equal(minute) {
+			b.minute, b.minuteUsed = minute, m.add(provider, keyID, PerMinute, minute, 0, 0)
+		}
+
+		if limits.PerDay > 0 && b.dayUsed >= dayLimit {
+			b.mu.Unlock()
+			return fmt.Errorf("%w: %s used %d of %d requests today", ErrExhausted, provider, b.dayUsed, limits.PerDay)
+		}
+		if limits.PerMinute <= 0 || b.minuteUsed < limits.PerMinute {
+			b.dayUsed = m.add(provider, keyID, PerDay, day, 1, b.dayUsed)
+			b.minuteUsed = m.add(provider, keyID, PerMinute, minute, 1, b.minuteUsed)
+			b.mu.Unlock()
+			return nil
+		}
+		b.mu.Unlock()
+
+		t := time.NewTimer(minute.Add(time.Minute).Sub(now))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// add counts n requests in the store and returns the window's total, or
+// local plus n if the store cannot be reached.
+func (m *Manager) add(provider, keyID string, period Period, start time.Time, n, local int) int {
+	if m.store == nil {
+		return local + n
+	}
+	total, err := m.store.Add(provider, keyID, period, start, n)
+	if err != nil {
+		return local + n
+	}
+	return total
+}
+
+// Status returns the budget of every provider key that has made requests,
+// as stored, ordered by provider. Without a store only this process's
+// requests are known.
+func (m *Manager) Status() ([]Status, error) {
+	var counts []Count
+	if m.store != nil {
+		var err error
+		if counts, err = m.store.List(); err != nil {
+			return nil, err
+		}
+	} else {
+		m.mu.Lock()
+		for k, b := range m.budgets {
+			b.mu.Lock()
+			counts = append(counts,
+				Count{Provider: k.provider, KeyID: k.keyID, Period: PerMinute, Start: b.minute, Requests: b.minuteUsed},
+				Count{Provider: k.provider, KeyID: k.keyID, Period: PerDay, Start: b.day, Requests: b.dayUsed})
+			b.mu.Unlock()
+		}
+		m.mu.Unlock()
+	}
+
+	now := time.Now().UTC()
+	minute, day := now.Truncate(time.Minute), now.Truncate(24*time.Hour)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	byKey := map[budgetKey]*Status{}
+	for _, c := range counts {
+		s := byKey[budgetKey{c.Provider, c.KeyID}]
+		if s == nil {
+			limits := m.limits[c.Provider]
+			s = &Status{
+				Provider: c.Provider,
+				Key:      c.KeyID,
+				Minute:   newUsage(limits.PerMinute, minute.Add(time.Minute)),
+				Day:      newUsage(limits.PerDay, day.Add(24*time.Hour)),
+				Reserved: limits.Reserved,
+			}
+			byKey[budgetKey{c.Provider, c.KeyID}] = s
+		}
+		switch {
+		case c.Period == PerMinute && c.Start.Equal(minute):
+			s.Minute.use(c.Requests)
+		case c.Period == PerDay && c.Start.Equal(day):
+			s.Day.use(c.Requests)
+		}
+	}
+
+	statuses := make([]Status, 0, len(byKey))
+	for _, s := range byKey {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Provider != statuses[j].Provider {
+			return statuses[i].Provider < statuses[j].Provider
+		}
+		return statuses[i].Key < statuses[j].Key
+	})
+	return statuses, nil
+}
+
+func newUsage(limit int, resetsAt time.Time) Usage {
+	u := Usage{Limit: limit, ResetsAt: resetsAt}
+	u.use(0)
+	return u
+}
+
+func (u *Usage) use(requests int) {
+	u.Used = requests
+	u.Remaining = -1
+	if u.Limit > 0 {
+		u.Remaining = max(u.Limit-u.Used, 0)
+	}
+}
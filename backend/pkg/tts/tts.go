@@ -0,0 +1,177 @@
+// Package tts converts text to speech through a pluggable provider.
+package tts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Provider names.
+const (
+	ProviderOpenAI     = "openai"
+	ProviderElevenLabs = "elevenlabs"
+)
+
+const (
+	DefaultOpenAIURL       = "https://api.openai.com/v1"
+	DefaultOpenAIModel     = "tts-1"
+	DefaultOpenAIVoice     = "alloy"
+	DefaultElevenLabsURL   = "https://api.elevenlabs.io"
+	DefaultElevenLabsModel = "eleven_multilingual_v2"
+	// DefaultElevenLabsVoice is the "Rachel" premade voice.
+	DefaultElevenLabsVoice = "21m00Tcm4TlvDq8ikWAM"
+)
+
+// maxAudioBytes caps the size of synthesized audio read from a provider.
+const maxAudioBytes = 20 << 20
+
+// Speech is synthesized audio.
+type Speech struct {
+	Audio       []byte
+	ContentType string
+}
+
+// Synthesizer is a text-to-speech API.
+type Synthesizer interface {
+	// Name identifies the provider, model and voice, e.g. "openai/tts-1/alloy".
+	Name() string
+	Synthesize(text string) (*Speech, error)
+}
+
+// New returns the synthesizer of a provider, or nil if provider is empty.
+// Empty settings take the provider's defaults.
+func New(provider, baseURL, apiKey, model, voice string) (Synthesizer, error) {
+	switch provider {
+	case "":
+		return nil, nil
+	case ProviderOpenAI:
+		return NewOpenAI(baseURL, apiKey, model, voice), nil
+	case ProviderElevenLabs:
+		return NewElevenLabs(baseURL, apiKey, model, voice), nil
+	}
+	return nil, fmt.Errorf("unknown TTS provider %q", provider)
+}
+
+// OpenAI talks to an OpenAI-compatible speech API.
+type OpenAI struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	voice      string
+	httpClient *http.Client
+}
+
+func NewOpenAI(baseURL, apiKey, model, voice string) *OpenAI {
+	return &OpenAI{
+		baseURL:    strings.TrimSuffix(orDefault(baseURL, DefaultOpenAIURL), "/"),
+		apiKey:     apiKey,
+		model:      orDefault(model, DefaultOpenAIModel),
+		voice:      orDefault(voice, DefaultOpenAIVoice),
+		httpClient: newHTTPClient(),
+	}
+}
+
+func (p *OpenAI) Name() string {
+	return ProviderOpenAI + "/" + p.model + "/" + p.voice
+}
+
+func (p *OpenAI) Synthesize(text string) (*Speech, error) {
+	return post(p.httpClient, p.baseURL+"/audio/speech", map[string]string{
+		"Authorization": "Bearer " + p.apiKey,
+	}, map[string]interface{}{
+		"model":           p.model,
+		"voice":           p.voice,
+		"input":           text,
+		"response_format": "mp3",
+	})
+}
+
+// ElevenLabs talks to the ElevenLabs text-to-speech API.
+type ElevenLabs struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	voice      string
+	httpClient *http.Client
+}
+
+func NewElevenLabs(baseURL, apiKey, model, voice string) *ElevenLabs {
+	return &ElevenLabs{
+		baseURL:    strings.TrimSuffix(orDefault(baseURL, DefaultElevenLabsURL), "/"),
+		apiKey:     apiKey,
+		model:      orDefault(model, DefaultElevenLabsModel),
+		voice:      orDefault(voice, DefaultElevenLabsVoice),
+		httpClient: newHTTPClient(),
+	}
+}
+
+func (p *ElevenLabs) Name() string {
+	return ProviderElevenLabs + "/" + p.model + "/" + p.voice
+}
+
+func (p *ElevenLabs) Synthesize(text string) (*Speech, error) {
+	return post(p.httpClient, p.baseURL+"/v1/text-to-speech/"+url.PathEscape(p.voice), map[string]string{
+		"xi-api-key": p.apiKey,
+		"Accept":     "audio/mpeg",
+	}, map[string]interface{}{
+		"text":     text,
+		"model_id": p.model,
+	})
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 60 * time.Second}
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// post sends payload to url with headers and returns the audio answered.
+func post(client *http.Client, url string, headers map[string]string, payload interface{}) (*Speech, error) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAudioBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TTS API error (status %d): %s", resp.StatusCode, string(body))
+	}
+	if len(body) > maxAudioBytes {
+		return nil, fmt.Errorf("TTS audio exceeds %d bytes", maxAudioBytes)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "audio/") {
+		contentType = "audio/mpeg"
+	}
+	return &Speech{Audio: body, ContentType: contentType}, nil
+}
@@ -40,6 +40,17 @@ interface AccuracyStats {
   avgGoalsErrorB: number;
   avgConfidence: number;
   accuracyPercentage: number;
+  byModel?: ModelAccuracy[];
+}
+
+interface ModelAccuracy {
+  modelVersion: string;
+  totalPredictions: number;
+  correctPredictions: number;
+  avgGoalsErrorA: number;
+  avgGoalsErrorB: number;
+  avgConfidence: number;
+  accuracyPercentage: number;
 }
 
 export default function PredictionsHistory() {
@@ -155,6 +166,41 @@ export default function PredictionsHistory() {
           </div>
         )}
 
+        {/* Accuracy by model version */}
+        {stats?.byModel && stats.byModel.length > 1 && (
+          <div className="bg-[#2B3139] border border-border rounded-lg p-6 mb-8">
+            <p className="text-[#848E9C] text-sm mb-4">By Model</p>
+            <table className="w-full text-sm">
+              <thead>
+                <tr className="text-left text-[#848E9C]">
+                  <th className="pb-2 font-medium">Model</th>
+                  <th className="pb-2 font-medium text-right">Accuracy</th>
+                  <th className="pb-2 font-medium text-right">Predictions</th>
+                  <th className="pb-2 font-medium text-right">Goals Error</th>
+                </tr>
+              </thead>
+              <tbody>
+                {stats.byModel.map((m) => (
+                  <tr key={m.modelVersion} className="border-t border-border">
+                    <td className="py-2 text-foreground">
+                      {m.modelVersion || "unknown"}
+                    </td>
+                    <td className="py-2 text-right text-foreground">
+                      {m.accuracyPercentage.toFixed(1)}%
+                    </td>
+                    <td className="py-2 text-right text-[#848E9C]">
+                      {m.correctPredictions}/{m.totalPredictions}
+                    </td>
+                    <td className="py-2 text-right text-[#848E9C]">
+                      ±{((m.avgGoalsErrorA + m.avgGoalsErrorB) / 2).toFixed(2)}
+                    </td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          </div>
+        )}
+
         {/* Predictions List */}
         {predictions.length > 0 ? (
           <div className="space-y-4">
@@ -4,7 +4,7 @@ import { useState, useEffect } from "react";
 import Image from "next/image";
 import { Card, CardContent } from "./ui/card";
 import { Badge } from "./ui/badge";
-import { api, type Match, type Prediction } from "@/lib/api";
+import { api, crestUrl, type Match, type Prediction } from "@/lib/api";
 import { ShimmerButton } from "./ui/shimmer-button";
 import { useMatchContext } from "@/contexts/MatchContext";
 
@@ -102,7 +102,7 @@ export function MatchCard({ match }: MatchCardProps) {
             <div className="relative w-12 h-12 shrink-0 smooth-hover hover:scale-110 hover:rotate-3">
               {match.homeTeam.crest ? (
                 <Image
-                  src={match.homeTeam.crest}
+                  src={crestUrl(match.homeTeam)}
                   alt={match.homeTeam.name}
                   fill
                   className="object-contain"
@@ -149,10 +149,11 @@ export function MatchCard({ match }: MatchCardProps) {
             <div className="relative w-12 h-12 shrink-0 smooth-hover hover:scale-110 hover:-rotate-3">
               {match.awayTeam.crest ? (
                 <Image
-                  src={match.awayTeam.crest}
+                  src={crestUrl(match.awayTeam)}
                   alt={`${match.awayTeam.name} crest`}
                   fill
                   className="object-contain"
+                  unoptimized
                 />
               ) : (
                 <div className="w-12 h-12 bg-gradient-to-br from-slate-200 to-slate-300 dark:from-slate-700 dark:to-slate-800 rounded-full flex items-center justify-center">
@@ -342,8 +343,12 @@ export function MatchCard({ match }: MatchCardProps) {
                               </p>
                               {prediction.keyPlayers.home.map((player, idx) => (
                                 <p key={idx} className="ml-2">
-                                  {player.name} ({player.position?.charAt(0)}) –{" "}
-                                  {player.goals} G, {player.assists} A
+                                  {player.name} ({player.role ?? player.position?.charAt(0)}) –{" "}
+                                  {player.goals} G
+                                  {player.penalties > 0 && ` (${player.penalties} pen)`}, {player.assists} A
+                                  {player.ownGoals > 0 && `, ${player.ownGoals} OG`}
+                                  {!!player.saves && `, ${player.saves} saves`}
+                                  {player.cleanSheet && (player.role === "G" || player.role === "D") && ", CS"}
                                 </p>
                               ))}
                             </div>
@@ -356,8 +361,12 @@ export function MatchCard({ match }: MatchCardProps) {
                               </p>
                               {prediction.keyPlayers.away.map((player, idx) => (
                                 <p key={idx} className="ml-2">
-                                  {player.name} ({player.position?.charAt(0)}) –{" "}
-                                  {player.goals} G, {player.assists} A
+                                  {player.name} ({player.role ?? player.position?.charAt(0)}) –{" "}
+                                  {player.goals} G
+                                  {player.penalties > 0 && ` (${player.penalties} pen)`}, {player.assists} A
+                                  {player.ownGoals > 0 && `, ${player.ownGoals} OG`}
+                                  {!!player.saves && `, ${player.saves} saves`}
+                                  {player.cleanSheet && (player.role === "G" || player.role === "D") && ", CS"}
                                 </p>
                               ))}
                             </div>
@@ -55,9 +55,17 @@ export interface PlayerInsight {
   name: string;
   position: string;
   teamExternalId: number;
-  goals: number;
+  goals: number; // includes penalties
   assists: number;
+  penalties: number;
+  ownGoals: number;
   rating?: number | null;
+  role?: "G" | "D" | "M" | "F";
+  saves?: number;
+  cleanSheet?: boolean;
+  tackles?: number;
+  interceptions?: number;
+  score?: number;
 }
 
 export interface KeyPlayers {
@@ -77,6 +85,12 @@ export interface Prediction {
   confidenceScore: number;
   modelVersion?: string;
   modelAccuracy?: number;
+  predictedAt?: string;
+  cached?: boolean;
+  expectedGoals?: {
+    home: number;
+    away: number;
+  };
   teamStats?: {
     homeForm: number;
     awayForm: number;
@@ -103,6 +117,104 @@ export interface Standing {
   form: string;
 }
 
+export interface Anomaly {
+  id: number;
+  kind: "upset" | "scoreline";
+  modelVersion: string;
+  outcomeProbability: number | null;
+  goalsError: number | null;
+  context: Record<string, unknown>;
+  detectedAt: string;
+  matchId: number;
+  externalId: number;
+  utcDate: string;
+  competitionCode: string;
+  homeTeamName: string;
+  awayTeamName: string;
+  homeScore: number | null;
+  awayScore: number | null;
+}
+
+export interface InsightsFact {
+  id: number;
+  teamId: number;
+  playerId?: number;
+  playerName?: string;
+  kind: string;
+  value: number;
+  target?: number;
+  description: string;
+  sinceDate?: string;
+  lastMatchId?: number;
+  computedAt: string;
+}
+
+export interface TeamStreaks {
+  team: {
+    id: number;
+    externalId: number;
+    name: string;
+    shortName: string;
+    tla: string;
+    crest: string;
+  };
+  facts: InsightsFact[];
+}
+
+export interface FixturePreview {
+  match: {
+    id: number;
+    externalId: number;
+    competitionCode: string;
+    matchday: number;
+    utcDate: string;
+    status: string;
+    homeTeamName: string;
+    awayTeamName: string;
+    homeScore: number | null;
+    awayScore: number | null;
+  };
+  prediction?: {
+    homeWinProbability: number;
+    drawProbability: number;
+    awayWinProbability: number;
+    predictedOutcome: string;
+    confidenceScore: number;
+    modelVersion: string;
+    expectedHomeGoals?: number;
+    expectedAwayGoals?: number;
+    predictedAt: string;
+  };
+  headToHead?: { homeWins: number; awayWins: number; draws: number };
+  home: PreviewTeam;
+  away: PreviewTeam;
+  narrative: { headline: string; summary: string; generatedBy: string };
+}
+
+export interface PreviewTeam {
+  keyPlayers: { playerId: number; name: string; goals: number; assists: number }[] | null;
+  unavailable: { playerName: string; reason: string; until?: string }[] | null;
+  streaks: InsightsFact[] | null;
+}
+
+export interface MatchdayPreview {
+  competitionCode: string;
+  season: number;
+  matchday: number;
+  generatedAt: string;
+  fixtures: FixturePreview[];
+}
+
+// ApiErrorBody is the envelope every backend error is returned in.
+export interface ApiErrorBody {
+  error: {
+    code: string;
+    message: string;
+    details?: Record<string, unknown>;
+    requestId?: string;
+  };
+}
+
 class ApiClient {
   private baseUrl: string;
 
@@ -118,7 +230,8 @@ class ApiClient {
     });
 
     if (!response.ok) {
-      throw new Error(`API error: ${response.statusText}`);
+      const body: ApiErrorBody | null = await response.json().catch(() => null);
+      throw new Error(`API error: ${body?.error?.message ?? response.statusText}`);
     }
 
     return response.json();
@@ -154,6 +267,32 @@ class ApiClient {
   async getPrediction(matchId: number): Promise<Prediction> {
     return this.fetch(`/api/v1/predictions/${matchId}`);
   }
+
+  async getUpsets(
+    since?: string,
+    kind?: Anomaly["kind"]
+  ): Promise<{ since: string; anomalies: Anomaly[]; count: number }> {
+    const params = new URLSearchParams();
+    if (since) params.append("since", since);
+    if (kind) params.append("kind", kind);
+    return this.fetch(`/api/v1/insights/upsets?${params}`);
+  }
+
+  async getMatchdayPreview(code: string, matchday?: number): Promise<MatchdayPreview> {
+    const params = new URLSearchParams();
+    if (matchday) params.append("matchday", String(matchday));
+    return this.fetch(`/api/v1/competitions/${code}/preview?${params}`);
+  }
+
+  async getTeamStreaks(teamId: number): Promise<TeamStreaks> {
+    return this.fetch(`/api/v1/teams/${teamId}/streaks`);
+  }
 }
 
 export const api = new ApiClient(API_URL);
+
+// crestUrl points at the backend's cached copy of a team crest, so the
+// browser never hotlinks the provider's CDN.
+export function crestUrl(team: Team): string {
+  return `${API_URL}/api/v1/assets/crest/${team.id}`;
+}
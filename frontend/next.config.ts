@@ -1,16 +1,7 @@
 import type { NextConfig } from "next";
 
-const nextConfig: NextConfig = {
-  images: {
-    remotePatterns: [
-      {
-        protocol: "https",
-        hostname: "crests.football-data.org",
-        port: "",
-        pathname: "/**",
-      },
-    ],
-  },
-};
+// Team crests are served by the backend's asset cache and rendered
+// unoptimized, so no provider image hosts are allowed here.
+const nextConfig: NextConfig = {};
 
 export default nextConfig;
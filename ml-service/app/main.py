@@ -35,6 +35,24 @@ class PredictionRequest(BaseModel):
     away_team_name: Optional[str] = None
     home_team_position: Optional[int] = None
     away_team_position: Optional[int] = None
+    home_new_manager: bool = False
+    away_new_manager: bool = False
+    home_advantage: float = 0.0
+    competition_home_advantage: float = 0.0
+    home_league_strength: float = 0.0
+    away_league_strength: float = 0.0
+    home_rest_days: int = 0
+    away_rest_days: int = 0
+    home_matches_last_14_days: int = 0
+    away_matches_last_14_days: int = 0
+    away_travel_km: float = 0.0
+    home_lineup_absences: int = 0
+    away_lineup_absences: int = 0
+    home_late_goal_share: float = 0.0
+    away_late_goal_share: float = 0.0
+    neutral_venue: bool = False
+    # Replays a past match from what was known on this date (YYYY-MM-DD)
+    as_of: Optional[str] = None
 
 class TeamStats(BaseModel):
     home_form: float
@@ -83,7 +101,24 @@ async def predict_match(request: PredictionRequest):
             away_team_id=request.away_team_id,
             matchday=request.matchday,
             home_team_name=request.home_team_name,
-            away_team_name=request.away_team_name
+            away_team_name=request.away_team_name,
+            home_new_manager=request.home_new_manager,
+            away_new_manager=request.away_new_manager,
+            home_advantage=request.home_advantage,
+            competition_home_advantage=request.competition_home_advantage,
+            home_league_strength=request.home_league_strength,
+            away_league_strength=request.away_league_strength,
+            home_rest_days=request.home_rest_days,
+            away_rest_days=request.away_rest_days,
+            home_matches_last_14_days=request.home_matches_last_14_days,
+            away_matches_last_14_days=request.away_matches_last_14_days,
+            away_travel_km=request.away_travel_km,
+            home_lineup_absences=request.home_lineup_absences,
+            away_lineup_absences=request.away_lineup_absences,
+            home_late_goal_share=request.home_late_goal_share,
+            away_late_goal_share=request.away_late_goal_share,
+            neutral_venue=request.neutral_venue,
+            as_of=request.as_of
         )
         
         return result
@@ -21,7 +21,16 @@ class TeamAgnosticPredictor:
             print("⚠️  Warning: Could not load trained model. Using fallback.")
     
     def predict(self, home_team_id: int, away_team_id: int, matchday: int = 1,
-                home_team_name: str = None, away_team_name: str = None) -> Dict:
+                home_team_name: str = None, away_team_name: str = None,
+                home_new_manager: bool = False, away_new_manager: bool = False,
+                home_advantage: float = 0.0, competition_home_advantage: float = 0.0,
+                home_league_strength: float = 0.0, away_league_strength: float = 0.0,
+                home_rest_days: int = 0, away_rest_days: int = 0,
+                home_matches_last_14_days: int = 0, away_matches_last_14_days: int = 0,
+                away_travel_km: float = 0.0, home_lineup_absences: int = 0,
+                away_lineup_absences: int = 0, home_late_goal_share: float = 0.0,
+                away_late_goal_share: float = 0.0, neutral_venue: bool = False,
+                as_of: str = None) -> Dict:
         """
         Predict match outcome using team-agnostic neural network.
         
@@ -31,14 +40,43 @@ class TeamAgnosticPredictor:
             matchday: Match day number
             home_team_name: Home team name for display
             away_team_name: Away team name for display
+            home_new_manager: Home team is in its first matches under a new
+                manager, whose win rate replaces the predecessor's as neutral
+            away_new_manager: Away team is in its first matches under a new manager
+            home_advantage: Home team's home less away points per game, 0
+                when unknown; how far it exceeds the competition's scales the
+                home team's attacking stats
+            competition_home_advantage: The same across the competition
+            home_league_strength: Home team's league strength coefficient when
+                the teams come from different leagues, 0 otherwise
+            away_league_strength: Away team's league strength coefficient
+            home_rest_days: Days since the home team's previous match across all
+                competitions, 0 when unknown
+            away_rest_days: The same for the away team
+            home_matches_last_14_days: Home team's matches in the 14 days before
+                kickoff, scheduled ones included
+            away_matches_last_14_days: The same for the away team
+            away_travel_km: Away team's journey from its home venue, 0 when
+                unknown; it weakens the away team's attack and defence
+            home_lineup_absences: Regular starters the home team left out of
+                its announced lineup, 0 until it is announced; they set the
+                injury impact when they weigh more than the known injuries
+            away_lineup_absences: The same for the away team
+            home_late_goal_share: Share of the home team's recent goals scored
+                after minute 75, 0 without enough recorded goals; a share
+                above a league's scales its attacking stats
+            away_late_goal_share: The same for the away team
+            neutral_venue: The match is at neither team's ground
+            as_of: Date (YYYY-MM-DD) to build the features as of, for
+                backtests replaying past matches; today when not given
         
         Returns:
             Dict with predicted scores, outcome, confidence, and insights
         """
         try:
-            # Get current date for feature extraction
+            # Features only see data from before the match date
             from datetime import datetime
-            match_date = datetime.now().strftime('%Y-%m-%d')
+            match_date = as_of or datetime.now().strftime('%Y-%m-%d')
             
             # Check if this is an international match (teams with no historical data)
             has_home_data = self._check_team_has_data(home_team_id, match_date)
@@ -46,14 +84,14 @@ class TeamAgnosticPredictor:
             
             # If both teams lack data (international match), use FIFA ranking-based prediction
             if not has_home_data and not has_away_data:
-                return self._predict_international_match(home_team_name, away_team_name)
+                return self._predict_international_match(home_team_name, away_team_name, neutral_venue)
             
             # Extract features for both teams
             home_features = self.feature_engineer.extract_features_for_team(
                 team_id=home_team_id,
                 opponent_id=away_team_id,
                 match_date=match_date,
-                is_at_venue=True
+                is_at_venue=not neutral_venue
             )
             
             away_features = self.feature_engineer.extract_features_for_team(
@@ -63,6 +101,51 @@ class TeamAgnosticPredictor:
                 is_at_venue=False
             )
             
+            # The backend sees every competition's schedule, not just this one's
+            if home_rest_days > 0:
+                home_features['rest_days'] = min(home_rest_days, 14)
+            if away_rest_days > 0:
+                away_features['rest_days'] = min(away_rest_days, 14)
+            
+            # The coach record on file is the predecessor's, not the new
+            # manager's, who starts from the neutral default
+            if home_new_manager:
+                home_features['coach_win_rate'] = 0.5
+            if away_new_manager:
+                away_features['coach_win_rate'] = 0.5
+            
+            # Starters left out of the lineup weigh like injuries, and mostly
+            # are the injured and suspended players already counted
+            for features, absences in ((home_features, home_lineup_absences),
+                                       (away_features, away_lineup_absences)):
+                if absences > 0:
+                    features['injury_impact'] = min(features['injury_impact'], -min(0.3, absences * 0.05))
+            
+            # Domestic stats of teams from different leagues are not comparable
+            if home_league_strength > 0 and away_league_strength > 0:
+                self._normalize_for_league(home_features, home_league_strength, away_league_strength)
+                self._normalize_for_league(away_features, away_league_strength, home_league_strength)
+            
+            # The competition's home advantage is in every team's stats, a
+            # home record beyond it is not
+            if home_advantage != 0 and not neutral_venue:
+                lift = 0.1 * (home_advantage - competition_home_advantage)
+                self._scale_attack(home_features, 1 + max(-0.15, min(lift, 0.15)))
+            
+            # Late scorers keep going when others tire
+            for features, share in ((home_features, home_late_goal_share),
+                                    (away_features, away_late_goal_share)):
+                if share > 0:
+                    lift = 0.2 * (share - 0.22)
+                    self._scale_attack(features, 1 + max(-0.04, min(lift, 0.04)))
+            
+            # A long journey tells at both ends of the pitch; the features'
+            # own travel distance is a flat estimate for every away match
+            if away_travel_km > 0 and not neutral_venue:
+                loss = min(0.06, 0.02 * away_travel_km / 1000)
+                self._scale_attack(away_features, 1 - loss)
+                self._scale_conceded(home_features, 1 + loss)
+            
             # Predict using neural network
             result = self.model.predict_match(
                 team_a_features=home_features,
@@ -79,6 +162,26 @@ class TeamAgnosticPredictor:
                 home_team_name=home_team_name,
                 away_team_name=away_team_name
             )
+            for name, new_manager in ((home_team_name or "Home Team", home_new_manager),
+                                      (away_team_name or "Away Team", away_new_manager)):
+                if new_manager:
+                    insights.append(f"{name} are in their first matches under a new manager")
+            for name, congestion, rest in ((home_team_name or "Home Team", home_matches_last_14_days, home_rest_days),
+                                           (away_team_name or "Away Team", away_matches_last_14_days, away_rest_days)):
+                if congestion >= 4:
+                    insights.append(f"{name} go in with {congestion} matches in 14 days and {rest} days' rest")
+            for name, absences in ((home_team_name or "Home Team", home_lineup_absences),
+                                   (away_team_name or "Away Team", away_lineup_absences)):
+                if absences > 0:
+                    insights.append(f"{name} are without {absences} of their regular starters")
+            for name, share in ((home_team_name or "Home Team", home_late_goal_share),
+                                (away_team_name or "Away Team", away_late_goal_share)):
+                if share >= 0.3:
+                    insights.append(f"{name} have scored {share:.0%} of their recent goals after minute 75")
+            if home_advantage >= 1.0:
+                insights.append(f"{home_team_name or 'Home Team'} take {home_advantage:.1f} more points per game at home than away")
+            if away_travel_km >= 1500:
+                insights.append(f"{away_team_name or 'Away Team'} travel {away_travel_km:.0f} km for this match")
             
             # The model already calculated probabilities correctly in team_score_predictor.py
             # Extract them from the result instead of recalculating
@@ -104,7 +207,23 @@ class TeamAgnosticPredictor:
                     'away_quality': away_features['team_quality_rating'],
                     'home_xg': home_features['team_xg_per_game'],
                     'away_xg': away_features['team_xg_per_game'],
-                    'quality_difference': home_features['quality_difference']
+                    'quality_difference': home_features['quality_difference'],
+                    'home_new_manager': home_new_manager,
+                    'away_new_manager': away_new_manager,
+                    'home_team_home_advantage': home_advantage,
+                    'competition_home_advantage': competition_home_advantage,
+                    'home_league_strength': home_league_strength,
+                    'away_league_strength': away_league_strength,
+                    'home_rest_days': home_rest_days,
+                    'away_rest_days': away_rest_days,
+                    'home_matches_last_14_days': home_matches_last_14_days,
+                    'away_matches_last_14_days': away_matches_last_14_days,
+                    'away_travel_km': away_travel_km,
+                    'home_lineup_absences': home_lineup_absences,
+                    'away_lineup_absences': away_lineup_absences,
+                    'home_late_goal_share': home_late_goal_share,
+                    'away_late_goal_share': away_late_goal_share,
+                    'neutral_venue': neutral_venue
                 }
             }
             
@@ -113,6 +232,30 @@ class TeamAgnosticPredictor:
             # Fallback to simple prediction
             return self._fallback_prediction(home_team_name, away_team_name)
     
+    def _normalize_for_league(self, features: Dict, team_strength: float, opponent_strength: float):
+        """
+        Scale a team's attacking stats by its league's strength and its
+        opponent's defensive stats by the opponent's league, so goals
+        scored in a strong league count for more and conceded for less.
+        """
+        for key in ('team_xg_per_game', 'team_goals_per_game'):
+            features[key] = features[key] * team_strength
+        for key in ('opponent_xg_conceded', 'opponent_goals_conceded'):
+            features[key] = features[key] / opponent_strength
+        features['attack_vs_defense'] = features['team_xg_per_game'] - features['opponent_xg_conceded']
+    
+    def _scale_attack(self, features: Dict, factor: float):
+        """Scale a team's attacking stats by factor."""
+        for key in ('team_xg_per_game', 'team_goals_per_game'):
+            features[key] = features[key] * factor
+        features['attack_vs_defense'] = features['team_xg_per_game'] - features['opponent_xg_conceded']
+    
+    def _scale_conceded(self, features: Dict, factor: float):
+        """Scale what a team's opponent concedes by factor."""
+        for key in ('opponent_xg_conceded', 'opponent_goals_conceded'):
+            features[key] = features[key] * factor
+        features['attack_vs_defense'] = features['team_xg_per_game'] - features['opponent_xg_conceded']
+    
     def _check_team_has_data(self, team_id: int, match_date: str) -> bool:
         """Check if team has historical match data"""
         import psycopg2
@@ -122,8 +265,8 @@ class TeamAgnosticPredictor:
         cursor.execute("""
             SELECT COUNT(*) FROM matches m
             JOIN teams t ON (m.home_team_id = t.id OR m.away_team_id = t.id)
-            WHERE t.external_id = %s AND m.status = 'FINISHED'
-        """, (team_id,))
+            WHERE t.external_id = %s AND m.status = 'FINISHED' AND m.utc_date < %s
+        """, (team_id, match_date))
         
         count = cursor.fetchone()[0]
         cursor.close()
@@ -131,7 +274,8 @@ class TeamAgnosticPredictor:
         
         return count > 5
     
-    def _predict_international_match(self, home_team_name: str, away_team_name: str) -> Dict:
+    def _predict_international_match(self, home_team_name: str, away_team_name: str,
+                                     neutral_venue: bool = False) -> Dict:
         """Predict international match using Elo-style ratings with form and tournament context"""
         
         # Elo-style ratings (1400-2100 scale) based on FIFA rankings and recent performance
@@ -150,8 +294,8 @@ class TeamAgnosticPredictor:
         home_elo = elo_ratings.get(home_team_name, 1700)
         away_elo = elo_ratings.get(away_team_name, 1700)
         
-        # Home advantage in Elo points (smaller for neutral venues in World Cup)
-        home_advantage = 50
+        # Home advantage in Elo points, none at a neutral venue
+        home_advantage = 0 if neutral_venue else 50
         adjusted_home_elo = home_elo + home_advantage
         
         # Logistic win probability based on Elo difference